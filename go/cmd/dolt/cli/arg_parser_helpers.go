@@ -158,6 +158,10 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use.")
 	ap.SupportsString(UserFlag, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(SingleBranchFlag, "", "Clone only the history leading to the tip of a single branch, either specified by --branch or the remote's HEAD (default).")
+	ap.SupportsStringList(TablesFlag, "", "tables", "Clone only the data of the given tables. The data of the other tables is fetched from the remote when it's first read.")
+	ap.SupportsStringList(ExcludeTablesFlag, "", "tables", "Don't clone the data of the given tables. Their data is fetched from the remote when it's first read.")
+	ap.SupportsFlag(LazyFlag, "", "Don't clone any data. Every chunk is fetched from the remote when it's first read, and cached on disk.")
+	ap.SupportsUint(LazyCacheSizeFlag, "", "megabytes", "The size of the disk cache of a lazy clone, in megabytes. The default is 1024.")
 	return ap
 }
 
@@ -275,6 +279,9 @@ func CreateBackupArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"profile", "AWS profile to use."})
 	ap.SupportsFlag(VerboseFlag, "v", "When printing the list of backups adds additional details.")
 	ap.SupportsFlag(ForceFlag, "f", "When restoring a backup, overwrite the contents of the existing database with the same name.")
+	ap.SupportsFlag(IncrementalFlag, "", "When syncing a backup, record the sync as a point in time in the backup's catalog. Supported for file, localbs and gs backups.")
+	ap.SupportsString(AsOfParam, "", "time", "When restoring a backup, restore the latest point in time recorded in the backup's catalog at or before {{.LessThan}}time{{.GreaterThan}}.")
+	ap.SupportsFlag(RestoreBranchControl, "", "When restoring a backup with --as-of, replace the branch control data of the server with the data recorded with the point in time.")
 	ap.SupportsString("ref", "", "ref", "Git ref to use as the Dolt data ref for git remotes (default: refs/dolt/data).")
 	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
@@ -301,6 +308,7 @@ func CreateLogArgParser(isTableFunction bool) *argparser.ArgParser {
 	ap.SupportsStringList(NotFlag, "", "revision", "Excludes commits from revision.")
 	ap.SupportsFlag(AllFlag, "", "Automatically select every branch in database")
 	ap.SupportsFlag(ShowSignatureFlag, "", "Shows the signature of each commit.")
+	ap.SupportsFlag(FollowFlag, "", "Continues listing the history of a single table beyond renames of the table.")
 	if isTableFunction {
 		ap.SupportsStringList(TablesFlag, "t", "table", "Restricts the log to commits that modified the specified tables.")
 	} else {
@@ -343,6 +351,25 @@ func CreateGCArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreatePurgeRowsArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("purge-rows", 0)
+	ap.SupportsString(TableParam, "t", "table", "The table to delete rows from.")
+	ap.SupportsString(WhereParam, "", "expression", "The rows to delete, as a SQL expression over the columns of the table.")
+	ap.SupportsFlag(SkipGCFlag, "", "Don't run a full garbage collection after rewriting history, leaving the purged rows in the original commits.")
+	return ap
+}
+
+func CreateSquashHistoryArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("squash-history", 0)
+	ap.SupportsString(KeepAllParam, "", "age", "Keep every commit younger than this age, e.g. 90d.")
+	ap.SupportsString(KeepDailyParam, "", "age", "Keep the last commit of each day for commits younger than this age, e.g. 1y or 365d.")
+	ap.SupportsString(KeepWeeklyParam, "", "age", "Keep the last commit of each week for commits younger than this age, or forever.")
+	ap.SupportsStringList(PreserveParam, "", "commits", "Commits which are always kept, in addition to the commits of branches and tags.")
+	ap.SupportsFlag(DryRunFlag, "", "Report the commits which would be squashed without rewriting history.")
+	ap.SupportsFlag(SkipGCFlag, "", "Don't run a full garbage collection after rewriting history, leaving the squashed commits in storage.")
+	return ap
+}
+
 func CreateCountCommitsArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("gc", 0)
 	ap.SupportsString("from", "f", "commit id", "commit to start counting from")
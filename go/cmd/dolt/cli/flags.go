@@ -21,6 +21,7 @@ const (
 	AllFlag                = "all"
 	AllowEmptyFlag         = "allow-empty"
 	AmendFlag              = "amend"
+	AsOfParam              = "as-of"
 	AuthorParam            = "author"
 	ArchiveLevelParam      = "archive-level"
 	BranchParam            = "branch"
@@ -38,14 +39,22 @@ const (
 	DryRunFlag             = "dry-run"
 	EmptyParam             = "empty"
 	ExcludeIgnoreRulesFlag = "x"
+	ExcludeTablesFlag      = "exclude-tables"
+	FollowFlag             = "follow"
 	ForceFlag              = "force"
 	FullFlag               = "full"
 	GraphFlag              = "graph"
 	HardResetParam         = "hard"
 	HostFlag               = "host"
 	IncludeUntrackedFlag   = "include-untracked"
+	IncrementalFlag        = "incremental"
 	InteractiveFlag        = "interactive"
 	JobFlag                = "job"
+	KeepAllParam           = "keep-all"
+	KeepDailyParam         = "keep-daily"
+	KeepWeeklyParam        = "keep-weekly"
+	LazyFlag               = "lazy"
+	LazyCacheSizeFlag      = "lazy-cache-size"
 	ListFlag               = "list"
 	MergesFlag             = "merges"
 	MessageArg             = "message"
@@ -65,11 +74,13 @@ const (
 	OutputOnlyFlag         = "output-only"
 	ParentsFlag            = "parents"
 	PatchFlag              = "patch"
+	PreserveParam          = "preserve"
 	PasswordFlag           = "password"
 	PortFlag               = "port"
 	PruneFlag              = "prune"
 	QuietFlag              = "quiet"
 	RemoteParam            = "remote"
+	RestoreBranchControl   = "restore-branch-control"
 	SetUpstreamFlag        = "set-upstream"
 	SetUpstreamToFlag      = "set-upstream-to"
 	ShallowFlag            = "shallow"
@@ -79,12 +90,14 @@ const (
 	SilentFlag             = "silent"
 	SingleBranchFlag       = "single-branch"
 	SkipEmptyFlag          = "skip-empty"
+	SkipGCFlag             = "skip-gc"
 	SkipVerificationFlag   = "skip-verification"
 	SoftResetParam         = "soft"
 	SquashParam            = "squash"
 	StagedFlag             = "staged"
 	StatFlag               = "stat"
 	SystemFlag             = "system"
+	TableParam             = "table"
 	TablesFlag             = "tables"
 	TheirsFlag             = "theirs"
 	TrackFlag              = "track"
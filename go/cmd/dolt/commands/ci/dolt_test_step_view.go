@@ -33,36 +33,37 @@ func previewDoltTestStatements(dt *dolt_ci.DoltTestStep) ([]string, error) {
 // buildPreviewSelectors computes which selectors (test names and group names) to preview based on
 // the provided DoltTestStep configuration. Wildcards collapse the corresponding set to a single "*".
 func buildPreviewSelectors(dt *dolt_ci.DoltTestStep) []string {
-	spec := deriveSelectionSpec(dt)
 	testsProvided := len(dt.Tests) > 0
 	groupsProvided := len(dt.TestGroups) > 0
+	testsWildcard := testsProvided && dt.TestsWildcard()
+	groupsWildcard := groupsProvided && dt.GroupsWildcard()
 
 	switch {
 	case testsProvided && groupsProvided:
-		if spec.testsWildcard && !spec.groupsWildcard {
-			return nodesToValues(dt.TestGroups)
+		if testsWildcard && !groupsWildcard {
+			return dt.GroupNames()
 		}
-		if spec.groupsWildcard && !spec.testsWildcard {
-			return nodesToValues(dt.Tests)
+		if groupsWildcard && !testsWildcard {
+			return dt.TestNames()
 		}
-		if spec.testsWildcard && spec.groupsWildcard {
+		if testsWildcard && groupsWildcard {
 			return []string{"*"}
 		}
-		args := append([]string{}, nodesToValues(dt.Tests)...)
-		args = append(args, nodesToValues(dt.TestGroups)...)
+		args := append([]string{}, dt.TestNames()...)
+		args = append(args, dt.GroupNames()...)
 		return args
 
 	case testsProvided:
-		if spec.testsWildcard {
+		if testsWildcard {
 			return []string{"*"}
 		}
-		return nodesToValues(dt.Tests)
+		return dt.TestNames()
 
 	case groupsProvided:
-		if spec.groupsWildcard {
+		if groupsWildcard {
 			return []string{"*"}
 		}
-		return nodesToValues(dt.TestGroups)
+		return dt.GroupNames()
 	}
 
 	return []string{"*"}
@@ -16,26 +16,33 @@ package ci
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/fatih/color"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/testreport"
 )
 
+const formatFlag = "format"
+
 var runDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Run a Dolt CI workflow",
-	LongDesc:  "Run a Dolt CI workflow by executing all saved queries and validating their results",
+	LongDesc: `Run a Dolt CI workflow by executing all saved queries and validating their results.
+
+With {{.EmphasisLeft}}--format{{.EmphasisRight}}, the results are written as a JUnit XML, TAP or JSON report instead, for CI systems to ingest. Each step is reported with the time it took to run, in a suite named after its job, and failed steps are reported with their failure message and query.`,
 	Synopsis: []string{
-		"{{.LessThan}}workflow name{{.GreaterThan}}",
+		"[--format {{.LessThan}}format{{.GreaterThan}}] {{.LessThan}}workflow name{{.GreaterThan}}",
 	},
 }
 
@@ -65,6 +72,7 @@ func (cmd RunCmd) Docs() *cli.CommandDocumentation {
 // ArgParser implements cli.Command.
 func (cmd RunCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.SupportsString(formatFlag, "", "format", "How to report the results of the workflow. Valid values are text, junit, tap, json. Defaults to text.")
 	return ap
 }
 
@@ -72,12 +80,17 @@ func (cmd RunCmd) ArgParser() *argparser.ArgParser {
 func (cmd RunCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, cliCtx cli.CliContext) int {
 	ap := cmd.ArgParser()
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, runDocs, ap))
-	_ = cli.ParseArgsOrDie(ap, args, help)
+	apr := cli.ParseArgsOrDie(ap, args, help)
 
-	if len(args) == 0 {
+	if apr.NArg() == 0 {
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(fmt.Errorf("must specify workflow name")), usage)
 	}
-	workflowName := args[0]
+	workflowName := apr.Arg(0)
+
+	format, err := testreport.ParseFormat(apr.GetValueOrDefault(formatFlag, string(testreport.FormatText)))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
 
 	queryist, err := cliCtx.QueryEngine(ctx)
 	if err != nil {
@@ -105,12 +118,32 @@ func (cmd RunCmd) Exec(ctx context.Context, commandStr string, args []string, _
 		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
-	savedQueries, err := getSavedQueries(queryist.Context, queryist.Queryist)
-	if err != nil {
-		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	var failed bool
+	if format == testreport.FormatText {
+		savedQueries, err := dolt_ci.GetSavedQueries(queryist.Context, queryist.Queryist)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		cli.Println(color.CyanString("Running workflow: %s", workflowName))
+		failed = queryAndPrint(queryist.Context, queryist.Queryist, config, savedQueries)
+	} else {
+		result, err := dolt_ci.RunWorkflow(queryist.Context, queryist.Queryist, config)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		if err = testreport.Write(cli.CliOut, format, workflowReport(result)); err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		failed = !result.Passed()
+	}
+
+	status := doltdb.CIWorkflowPassed
+	if failed {
+		status = doltdb.CIWorkflowFailed
+	}
+	if err = recordResult(queryist.Context, queryist.Queryist, workflowName, status); err != nil {
+		cli.PrintErrln(color.YellowString("warning: the result of workflow '%s' was not recorded: %s", workflowName, err.Error()))
 	}
-	cli.Println(color.CyanString("Running workflow: %s", workflowName))
-	failed := queryAndPrint(queryist.Context, queryist.Queryist, config, savedQueries)
 
 	if failed {
 		return 1
@@ -118,6 +151,35 @@ func (cmd RunCmd) Exec(ctx context.Context, commandStr string, args []string, _
 	return 0
 }
 
+// workflowReport returns the test report of |result|, with a suite for each job, and a case for each step.
+func workflowReport(result *dolt_ci.WorkflowResult) testreport.Report {
+	report := testreport.Report{Name: result.Workflow}
+	for _, step := range result.Steps {
+		c := testreport.Case{
+			Name:     step.Step,
+			Query:    step.Query,
+			Duration: step.FinishedAt.Sub(step.StartedAt),
+			Failed:   !step.Passed(),
+		}
+		if c.Failed {
+			c.Failure = step.Err.Error()
+		}
+		report.AddCase(step.Job, c)
+	}
+	return report
+}
+
+// recordResult records |status| as the result of |workflowName| on the HEAD commit, so that branch protection rules
+// requiring the workflow can check it. Results can't be recorded when the working set has uncommitted changes.
+func recordResult(sqlCtx *sql.Context, queryist cli.Queryist, workflowName string, status doltdb.CIWorkflowStatus) error {
+	query, err := dbr.InterpolateForDialect("CALL DOLT_CI_RECORD_RESULT(?, ?)", []interface{}{workflowName, string(status)}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	_, err = cli.GetRowsForSql(queryist, sqlCtx, query)
+	return err
+}
+
 // queryAndPrint iterates through the jobs and steps for the given config, then runs each saved query and given assertion
 func queryAndPrint(sqlCtx *sql.Context, queryist cli.Queryist, config *dolt_ci.WorkflowConfig, savedQueries map[string]string) bool {
 	// returns true if any job had failures
@@ -138,7 +200,7 @@ func queryAndPrint(sqlCtx *sql.Context, queryist cli.Queryist, config *dolt_ci.W
 				query := savedQueries[sq.SavedQueryName.Value]
 				rows, qErr := runCIQuery(queryist, sqlCtx, sq, query)
 				if qErr == nil {
-					err = assertQueries(rows, sq.ExpectedRows.Value, sq.ExpectedColumns.Value, query)
+					err = dolt_ci.AssertSavedQueryRows(rows, sq.ExpectedRows.Value, sq.ExpectedColumns.Value)
 				} else {
 					err = qErr
 				}
@@ -235,36 +297,3 @@ func runCIQuery(queryist cli.Queryist, sqlCtx *sql.Context, step *dolt_ci.SavedQ
 
 	return rows, nil
 }
-
-// assertQueries takes in the result of a saved query execution, and the unparsed assertions,
-// then returns if the assertions failed
-func assertQueries(rows []sql.Row, expectedRowsAndComparison string, expectedColumnsAndComparison string, query string) error {
-	var colCount int64
-	var errs []string
-	rowCount := int64(len(rows))
-	if rowCount > 0 {
-		colCount = int64(len(rows[0]))
-	}
-
-	colCompType, expectedCols, err := dolt_ci.ParseSavedQueryExpectedResultString(expectedColumnsAndComparison)
-	if colCompType != dolt_ci.WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
-		err = dolt_ci.ValidateQueryExpectedRowOrColumnCount(colCount, expectedCols, colCompType, "column")
-		if err != nil {
-			errStr := fmt.Sprintf("Assertion failed: %s", err.Error())
-			errs = append(errs, errStr)
-		}
-	}
-	rowCompType, expectedRows, err := dolt_ci.ParseSavedQueryExpectedResultString(expectedRowsAndComparison)
-	if rowCompType != dolt_ci.WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
-		err = dolt_ci.ValidateQueryExpectedRowOrColumnCount(rowCount, expectedRows, rowCompType, "row")
-		if err != nil {
-			errStr := fmt.Sprintf("Assertion failed: %s", err.Error())
-			errs = append(errs, errStr)
-		}
-	}
-
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
-	}
-	return nil
-}
@@ -43,6 +43,10 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+With {{.EmphasisLeft}}--tables{{.EmphasisRight}} or {{.EmphasisLeft}}--exclude-tables{{.EmphasisRight}}, the clone is sparse: the schemas of every table are cloned, but the rows and indexes of the tables which are left out are only fetched from the remote when they're first read. Later fetches and pulls into the clone leave out the same tables.
+
+With {{.EmphasisLeft}}--lazy{{.EmphasisRight}}, no data is cloned at all. Every chunk the clone doesn't have is fetched from the remote when it's first read, and cached on disk in {{.LessThan}}.dolt/lazy_cache{{.GreaterThan}}, up to {{.EmphasisLeft}}--lazy-cache-size{{.EmphasisRight}} megabytes. The remote must stay available for the clone to be read.
 `,
 	Synopsis: []string{
 		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
@@ -115,6 +119,10 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	if verr != nil {
 		return verr
 	}
+	lazy, err := actions.LazyFetchConfigFromArgs(apr)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
 
 	dEnv.UserPassConfig, verr = getRemoteUserAndPassConfig(apr)
 	if verr != nil {
@@ -165,7 +173,9 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	// Nil out the old Dolt env so we don't accidentally operate on the wrong database
 	dEnv = nil
 
-	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, singleBranch, depth, clonedEnv)
+	sparse := actions.SparseFilterFromArgs(apr)
+
+	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, singleBranch, depth, sparse, lazy, clonedEnv)
 	if err != nil {
 		// If we're cloning into a directory that already exists do not erase it. Otherwise
 		// make best effort to delete the directory we created.
@@ -35,6 +35,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/mvdata"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/sqlexport"
@@ -53,11 +54,14 @@ const (
 	noAutocommitFlag = "no-autocommit"
 	schemaOnlyFlag   = "schema-only"
 	noCreateDbFlag   = "no-create-db"
+	applyMasksFlag   = "apply-masks"
 
 	sqlFileExt     = "sql"
 	csvFileExt     = "csv"
 	jsonFileExt    = "json"
 	parquetFileExt = "parquet"
+	avroFileExt    = "avro"
+	arrowFileExt   = "arrow"
 	emptyFileExt   = ""
 	emptyStr       = ""
 )
@@ -68,11 +72,15 @@ var dumpDocs = cli.CommandDocumentationContent{
 If a dump file already exists then the operation will fail, unless the {{.EmphasisLeft}}--force | -f{{.EmphasisRight}} flag 
 is provided. The force flag forces the existing dump file to be overwritten. The {{.EmphasisLeft}}-r{{.EmphasisRight}} flag 
 is used to support different file formats of the dump. In the case of non .sql files each table is written to a separate
-csv,json or parquet file. 
+csv, json, parquet, avro or arrow file. 
+
+The {{.EmphasisLeft}}--apply-masks{{.EmphasisRight}} flag replaces the values of the columns masked in 
+{{.EmphasisLeft}}dolt_column_masks{{.EmphasisRight}} with their masked values, as seen by users without the SUPER 
+privilege. Masked values may not satisfy the constraints of their columns, so a masked dump may not load as is.
 `,
 
 	Synopsis: []string{
-		"[-f] [-r {{.LessThan}}result-format{{.GreaterThan}}] [-fn {{.LessThan}}file_name{{.GreaterThan}}]  [-d {{.LessThan}}directory{{.GreaterThan}}] [--batch] [--no-batch] [--no-autocommit] [--no-create-db] ",
+		"[-f] [-r {{.LessThan}}result-format{{.GreaterThan}}] [-fn {{.LessThan}}file_name{{.GreaterThan}}]  [-d {{.LessThan}}directory{{.GreaterThan}}] [--batch] [--no-batch] [--no-autocommit] [--no-create-db] [--apply-masks] ",
 	},
 }
 
@@ -96,7 +104,7 @@ func (cmd DumpCmd) Docs() *cli.CommandDocumentation {
 
 func (cmd DumpCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
-	ap.SupportsString(FormatFlag, "r", "result_file_type", "Define the type of the output file. Defaults to sql. Valid values are sql, csv, json and parquet.")
+	ap.SupportsString(FormatFlag, "r", "result_file_type", "Define the type of the output file. Defaults to sql. Valid values are sql, csv, json, parquet, avro and arrow.")
 	ap.SupportsString(filenameFlag, "fn", "file_name", "Define file name for dump file. Defaults to `doltdump.sql`.")
 	ap.SupportsString(directoryFlag, "d", "directory_name", "Define directory name to dump the files in. Defaults to `doltdump/`.")
 	ap.SupportsFlag(forceParam, "f", "If data already exists in the destination, the force flag will allow the target to be overwritten.")
@@ -105,6 +113,7 @@ func (cmd DumpCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(noAutocommitFlag, "na", "Turn off autocommit for each dumped table. Useful for speeding up loading of output SQL file.")
 	ap.SupportsFlag(schemaOnlyFlag, "", "Dump a table's schema, without including any data, to the output SQL file.")
 	ap.SupportsFlag(noCreateDbFlag, "", "Do not write `CREATE DATABASE` statements in SQL files.")
+	ap.SupportsFlag(applyMasksFlag, "", "Dump the masked values of the columns masked in `dolt_column_masks`.")
 	return ap
 }
 
@@ -157,6 +166,13 @@ func (cmd DumpCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	defer sql.SessionCommandEnd(sqlCtx.Session)
 	sqlCtx.SetCurrentDatabase(dbName)
 
+	if apr.Contains(applyMasksFlag) {
+		err = sqlCtx.SetSessionVariable(sqlCtx, dsess.ForceColumnMasks, true)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
 	switch resFormat {
 	case emptyFileExt, sqlFileExt:
 		var defaultName string
@@ -204,7 +220,7 @@ func (cmd DumpCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		if err != nil {
 			return HandleVErrAndExitCode(err, usage)
 		}
-	case csvFileExt, jsonFileExt, parquetFileExt:
+	case csvFileExt, jsonFileExt, parquetFileExt, avroFileExt, arrowFileExt:
 		err = dumpNonSqlTables(sqlCtx, engine.GetUnderlyingEngine(), root, dEnv, force, tblNames, resFormat, outputFileOrDirName, false)
 		if err != nil {
 			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
@@ -723,7 +739,7 @@ func validateDumpArgs(apr *argparser.ArgParseResults) (string, errhand.VerboseEr
 			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", directoryFlag, sqlFileExt).SetPrintUsage().Build()
 		}
 		return fn, nil
-	case csvFileExt, jsonFileExt, parquetFileExt:
+	case csvFileExt, jsonFileExt, parquetFileExt, avroFileExt, arrowFileExt:
 		if fnOk {
 			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", filenameFlag, rf).SetPrintUsage().Build()
 		}
@@ -763,7 +779,7 @@ func newTableArgs(tblName string, destination mvdata.DataLocation, batched, auto
 }
 
 // dumpNonSqlTables returns nil if all tables is dumped successfully, and it returns err if there is one.
-// It handles only csv and json file types(rf).
+// It handles csv, json, parquet, avro and arrow file types(rf).
 func dumpNonSqlTables(ctx *sql.Context, engine *sqle.Engine, root doltdb.RootValue, dEnv *env.DoltEnv, force bool, tblNames []string, rf string, dirName string, batched bool) errhand.VerboseError {
 	var fName string
 	if dirName == emptyStr {
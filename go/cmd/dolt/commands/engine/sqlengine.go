@@ -85,6 +85,8 @@ type SqlEngineConfig struct {
 	SystemVariables            SystemVariables
 	ClusterController          *cluster.Controller
 	AutoGCController           *sqle.AutoGCController
+	WebhookController          *sqle.WebhookController
+	CIController               *sqle.CIController
 	BinlogReplicaController    binlogreplication.BinlogReplicaController
 	EventSchedulerStatus       eventscheduler.SchedulerStatus
 	BranchActivityTracking     bool
@@ -251,6 +253,7 @@ func NewSqlEngine(
 	engine.Analyzer.Catalog.MySQLDb.SetPlugins(map[string]mysql_db.PlaintextAuthPlugin{
 		"authentication_dolt_jwt": NewAuthenticateDoltJWTPlugin(config.JwksConfig),
 	})
+	pro.SetMySQLDb(engine.Analyzer.Catalog.MySQLDb)
 
 	if config.AutoGCController != nil {
 		err = config.AutoGCController.RunBackgroundThread(bThreads, sqlEngine.NewDefaultContext)
@@ -271,6 +274,33 @@ func NewSqlEngine(
 		})
 	}
 
+	if config.WebhookController != nil {
+		err = config.WebhookController.RunBackgroundThread(bThreads)
+		if err != nil {
+			return nil, err
+		}
+		err = config.WebhookController.ApplyCommitHooks(ctx, mrEnv, dbs...)
+		if err != nil {
+			return nil, err
+		}
+		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, config.WebhookController.InitDatabaseHook())
+		pro.DropDatabaseHooks = append(pro.DropDatabaseHooks, config.WebhookController.DropDatabaseHook())
+		pro.SetWebhookController(config.WebhookController)
+	}
+
+	if config.CIController != nil {
+		err = config.CIController.RunBackgroundThread(bThreads, sqlEngine.NewDefaultContext, sqlEngine, engine.Analyzer.Catalog.MySQLDb)
+		if err != nil {
+			return nil, err
+		}
+		err = config.CIController.ApplyCommitHooks(ctx, mrEnv, dbs...)
+		if err != nil {
+			return nil, err
+		}
+		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, config.CIController.InitDatabaseHook())
+		pro.DropDatabaseHooks = append(pro.DropDatabaseHooks, config.CIController.DropDatabaseHook())
+	}
+
 	var statsPro sql.StatsProvider
 	_, enabled, _ := sql.SystemVariables.GetGlobal(dsess.DoltStatsEnabled)
 	if enabled.(int8) == 1 {
@@ -344,6 +374,7 @@ func NewSqlEngine(
 		if err != nil {
 			return nil, err
 		}
+		pro.RegisterProcedure(dblr.DoltReplicationFilterProcedure)
 	}
 
 	return sqlEngine, nil
@@ -494,7 +525,7 @@ func configureBinlogReplicaController(config *SqlEngineConfig, engine *gms.Engin
 	dblr.DoltBinlogReplicaController.SetEngine(engine)
 	engine.Analyzer.Catalog.BinlogReplicaController = config.BinlogReplicaController
 
-	return nil
+	return dblr.DoltBinlogReplicaController.LoadReplicationFilters(executionCtx)
 }
 
 // configureBinlogPrimaryController configures the |engine| to use the default Dolt binlog primary controller, as well
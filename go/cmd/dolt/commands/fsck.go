@@ -130,7 +130,8 @@ func (cmd FsckCmd) Exec(ctx context.Context, commandStr string, args []string, d
 
 	params := make(map[string]interface{})
 	params[dbfactory.ChunkJournalParam] = struct{}{}
-	dbFact := dbfactory.FileFactory{}
+	// The registered factory opens the database with the configured chunk encryption, if any.
+	dbFact, _ := dbfactory.DBFactories[dbfactory.FileScheme].(dbfactory.FileFactory)
 	ddb, _, _, err := dbFact.CreateDbNoCache(ctx, types.Format_Default, u, params, func(vErr error) {
 		errs.AppendE(vErr)
 	})
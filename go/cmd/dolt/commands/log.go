@@ -50,6 +50,9 @@ The command takes options to control what is shown and how.
 {{.EmphasisLeft}}dolt log [<revisions>...] -- <table>{{.EmphasisRight}}
   Lists commit logs starting from revisions, only including commits with changes to table.
 	
+{{.EmphasisLeft}}dolt log --follow [<revisions>...] [--] <table>{{.EmphasisRight}}
+  Lists commit logs with changes to table, continuing past commits which renamed the table. Renames aren't recorded: a renamed table is recognized by its column tags, which renames keep, so a table whose columns were mostly recreated or retyped along with the rename isn't followed.
+	
 {{.EmphasisLeft}}dolt log <revisionB>..<revisionA>{{.EmphasisRight}}
 {{.EmphasisLeft}}dolt log <revisionA> --not <revisionB>{{.EmphasisRight}}
 {{.EmphasisLeft}}dolt log ^<revisionB> <revisionA>{{.EmphasisRight}}
@@ -235,6 +238,10 @@ func constructInterpolatedDoltLogQuery(apr *argparser.ArgParseResults, queryist
 		writeToBuffer("'--merges'")
 	}
 
+	if apr.Contains(cli.FollowFlag) {
+		writeToBuffer("'--follow'")
+	}
+
 	if excludedCommits, hasExcludedCommits := apr.GetValueList(cli.NotFlag); hasExcludedCommits {
 		writeToBuffer("'--not'")
 		for _, commit := range excludedCommits {
@@ -0,0 +1,125 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var purgeRowsDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Deletes rows of a table from the entire history of the repository.",
+	LongDesc: `Deletes the rows of a table matching a SQL expression from every commit of every branch, tag and remote-tracking branch, and from the working and staged changes of every branch.
+
+Every commit that contains a deleted row, and every descendant of such a commit, is rewritten. Branches and tags are moved to the rewritten commits, and the hash of each rewritten commit is printed next to the hash of the commit it replaced, one pair per line. The same mapping is recorded in the {{.EmphasisLeft}}dolt_purged_commits{{.EmphasisRight}} system table, which keeps the mappings of every purge, so that references to the original commits kept outside the repository can be updated later.
+
+Once history is rewritten, the reflog is truncated so that it no longer references the original commits, and a full garbage collection is run to remove them from local storage. Copies of the original commits in clones, remotes and backups are not affected and must be purged separately.
+
+{{.EmphasisLeft}}--where{{.EmphasisRight}} is resolved against the schema of each version of the table, so it may only reference columns that exist in every version that is rewritten.
+
+Purging rows fails if the repository has stashes, or if a merge or rebase is in progress on any branch.`,
+	Synopsis: []string{
+		"--table {{.LessThan}}table{{.GreaterThan}} --where {{.LessThan}}expression{{.GreaterThan}} [--skip-gc]",
+	},
+}
+
+type PurgeRowsCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd PurgeRowsCmd) Name() string {
+	return "purge-rows"
+}
+
+// Description returns a description of the command
+func (cmd PurgeRowsCmd) Description() string {
+	return purgeRowsDocs.ShortDesc
+}
+
+func (cmd PurgeRowsCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(purgeRowsDocs, ap)
+}
+
+func (cmd PurgeRowsCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreatePurgeRowsArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd PurgeRowsCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd PurgeRowsCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, purgeRowsDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if !apr.Contains(cli.TableParam) || !apr.Contains(cli.WhereParam) {
+		return HandleVErrAndExitCode(errhand.BuildDError("Invalid Argument: --table and --where are required").SetPrintUsage().Build(), usage)
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	query, err := constructDoltPurgeRowsQuery(apr)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	rows, err := cli.GetRowsForSql(queryist.Queryist, queryist.Context, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	for _, row := range rows {
+		oldCommit, err := cli.QueryValueAsString(row[0])
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		newCommit, err := cli.QueryValueAsString(row[1])
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		cli.Printf("%s %s\n", oldCommit, newCommit)
+	}
+
+	return 0
+}
+
+// constructDoltPurgeRowsQuery generates the sql query necessary to call DOLT_PURGE_ROWS()
+func constructDoltPurgeRowsQuery(apr *argparser.ArgParseResults) (string, error) {
+	tableName, _ := apr.GetValue(cli.TableParam)
+	where, _ := apr.GetValue(cli.WhereParam)
+
+	query := "CALL DOLT_PURGE_ROWS('--table', ?, '--where', ?"
+	params := []interface{}{tableName, where}
+	if apr.Contains(cli.SkipGCFlag) {
+		query += ", '--skip-gc'"
+	}
+	query += ")"
+
+	return dbr.InterpolateForDialect(query, params, dialect.MySQL)
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+var remoteHelperDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Serves a remote over stdin and stdout.",
+	LongDesc: `Serves the chunk store at {{.LessThan}}path{{.GreaterThan}} over stdin and stdout. This command is run on the remote host by ssh remotes, and is not meant to be run directly.
+
+The path is a directory holding a chunk store, as for file remotes, and is created when it is first pushed to. A path starting with {{.EmphasisLeft}}~/{{.EmphasisRight}} is relative to the home directory of the user.
+
+To give a key read-only access to a remote, restrict it to {{.EmphasisLeft}}dolt remote-helper --read-only{{.EmphasisRight}} with a forced command in authorized_keys.`,
+	Synopsis: []string{
+		"[--read-only] {{.LessThan}}path{{.GreaterThan}}",
+	},
+}
+
+const (
+	remoteHelperReadOnlyFlag = "read-only"
+	remoteHelperMemTableSize = 128 * 1024 * 1024
+)
+
+type RemoteHelperCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RemoteHelperCmd) Name() string {
+	return dbfactory.SSHRemoteHelperCommand
+}
+
+// Description returns a description of the command
+func (cmd RemoteHelperCmd) Description() string {
+	return remoteHelperDocs.ShortDesc
+}
+
+// Hidden should return true if this command should be hidden from the help text
+func (cmd RemoteHelperCmd) Hidden() bool {
+	return true
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd RemoteHelperCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd RemoteHelperCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(remoteHelperDocs, ap)
+}
+
+func (cmd RemoteHelperCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.SupportsFlag(remoteHelperReadOnlyFlag, "", "Reject pushes to the remote.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd RemoteHelperCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, _ cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, remoteHelperDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		return HandleVErrAndExitCode(errhand.BuildDError("a remote path is required").SetPrintUsage().Build(), usage)
+	}
+
+	// stdout carries the protocol, so nothing else may be written to it
+	conn := &stdioConn{r: os.Stdin, w: os.Stdout}
+	cli.CliOut = os.Stderr
+
+	err := serveRemoteHelper(ctx, conn, apr.Arg(0), apr.Contains(remoteHelperReadOnlyFlag))
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	return 0
+}
+
+func serveRemoteHelper(ctx context.Context, conn net.Conn, repoPath string, readOnly bool) error {
+	if repoPath == "~" || strings.HasPrefix(repoPath, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		repoPath = filepath.Join(home, repoPath[1:])
+	}
+	abs, err := filepath.Abs(filepath.FromSlash(repoPath))
+	if err != nil {
+		return err
+	}
+
+	// table files are served relative to the parent directory of the chunk store
+	fs, err := filesys.LocalFilesysWithWorkingDir(filepath.Dir(abs))
+	if err != nil {
+		return err
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
+	logger.SetLevel(logrus.WarnLevel)
+
+	dbCache := &remoteHelperDBCache{path: abs}
+	defer dbCache.Close()
+
+	server, err := remotesrv.NewServer(remotesrv.ServerArgs{
+		Logger:             logrus.NewEntry(logger),
+		HttpHost:           dbfactory.SSHScheme,
+		HttpListenAddr:     "stdio",
+		GrpcListenAddr:     "stdio",
+		FS:                 fs,
+		DBCache:            dbCache,
+		ReadOnly:           readOnly,
+		ConcurrencyControl: remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_IGNORE_WORKING_SET,
+		PushValidator:      branch_protection.PushValidator{},
+	})
+	if err != nil {
+		return err
+	}
+	return server.ServeConn(conn)
+}
+
+// remoteHelperDBCache is a remotesrv.DBCache for the single chunk store served by the remote helper, which is opened
+// when first requested. Requested paths are ignored.
+type remoteHelperDBCache struct {
+	path string
+
+	mu    sync.Mutex
+	store *nbs.NomsBlockStore
+}
+
+func (c *remoteHelperDBCache) Get(ctx context.Context, _, nbfVerStr string) (remotesrv.RemoteSrvStore, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.store != nil {
+		return c.store, nil
+	}
+	if err := os.MkdirAll(c.path, os.ModePerm); err != nil {
+		return nil, err
+	}
+	store, err := nbs.NewLocalStore(ctx, nbfVerStr, c.path, remoteHelperMemTableSize, nbs.NewUnlimitedMemQuotaProvider(), false)
+	if err != nil {
+		return nil, err
+	}
+	c.store = store
+	return store, nil
+}
+
+func (c *remoteHelperDBCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.store == nil {
+		return nil
+	}
+	return c.store.Close()
+}
+
+// stdioConn is a net.Conn over the stdin and stdout of this process. Deadlines are not supported.
+type stdioConn struct {
+	r io.ReadCloser
+	w io.WriteCloser
+}
+
+var _ net.Conn = (*stdioConn)(nil)
+
+func (c *stdioConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *stdioConn) Write(b []byte) (int, error) {
+	return c.w.Write(b)
+}
+
+func (c *stdioConn) Close() error {
+	rerr := c.r.Close()
+	werr := c.w.Close()
+	if rerr != nil {
+		return rerr
+	}
+	return werr
+}
+
+func (c *stdioConn) LocalAddr() net.Addr {
+	return stdioAddr{}
+}
+
+func (c *stdioConn) RemoteAddr() net.Addr {
+	return stdioAddr{}
+}
+
+func (c *stdioConn) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (c *stdioConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (c *stdioConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+type stdioAddr struct{}
+
+func (stdioAddr) Network() string {
+	return "stdio"
+}
+
+func (stdioAddr) String() string {
+	return "stdio"
+}
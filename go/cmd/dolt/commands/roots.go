@@ -91,10 +91,13 @@ func (cmd RootsCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return BuildVerrAndExit("Unable to read table files.", err)
 	}
 
+	// The registered factory carries the configured chunk encryption, if any.
+	dbFact, _ := dbfactory.DBFactories[dbfactory.FileScheme].(dbfactory.FileFactory)
+
 	n := apr.GetIntOrDefault(numFilesParam, len(itr.files))
 	for i := 0; i < n; i++ {
 		fPath, modified := itr.next()
-		err = cmd.processTableFile(ctx, fPath, modified, dEnv.FS)
+		err = cmd.processTableFile(ctx, fPath, modified, dEnv.FS, dbFact.Encryption)
 
 		if err == io.EOF {
 			break
@@ -106,7 +109,7 @@ func (cmd RootsCmd) Exec(ctx context.Context, commandStr string, args []string,
 	return 0
 }
 
-func (cmd RootsCmd) processTableFile(ctx context.Context, path string, modified time.Time, fs filesys.Filesys) error {
+func (cmd RootsCmd) processTableFile(ctx context.Context, path string, modified time.Time, fs filesys.Filesys, ce *nbs.ChunkEncryption) error {
 	cli.Printf("Processing '%s' last modified: %v\n", path, modified)
 	rdCloser, err := fs.OpenForRead(path)
 
@@ -116,7 +119,7 @@ func (cmd RootsCmd) processTableFile(ctx context.Context, path string, modified
 
 	defer rdCloser.Close()
 
-	return nbs.IterChunks(ctx, rdCloser.(io.ReadSeeker), func(chunk chunks.Chunk) (stop bool, err error) {
+	return nbs.IterChunks(ctx, rdCloser.(io.ReadSeeker), ce, func(chunk chunks.Chunk) (stop bool, err error) {
 		// Want a clean db every loop
 		sp, _ := spec.ForDatabase("mem")
 		vrw := sp.GetVRW(ctx)
@@ -604,6 +604,16 @@ func (cfg *commandLineServerConfig) AutoGCBehavior() servercfg.AutoGCBehavior {
 	return stubAutoGCBehavior{}
 }
 
+// Webhooks returns nil; webhooks can only be configured in a YAML config file.
+func (cfg *commandLineServerConfig) Webhooks() []servercfg.WebhookConfig {
+	return nil
+}
+
+// RunCIWorkflows returns false; running dolt ci workflows can only be enabled in a YAML config file.
+func (cfg *commandLineServerConfig) RunCIWorkflows() bool {
+	return servercfg.DefaultRunCIWorkflows
+}
+
 func (cfg *commandLineServerConfig) Overrides() sql.EngineOverrides {
 	return sql.EngineOverrides{}
 }
@@ -638,3 +648,5 @@ func (stubAutoGCBehavior) Enable() bool {
 }
 
 func (stubAutoGCBehavior) ArchiveLevel() int { return servercfg.DefaultCompressionLevel }
+
+func (stubAutoGCBehavior) HistoryRetention() servercfg.HistoryRetention { return nil }
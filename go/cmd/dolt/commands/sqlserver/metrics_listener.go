@@ -27,6 +27,7 @@ import (
 	"github.com/shirou/gopsutil/v4/mem"
 	"github.com/sirupsen/logrus"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/clusterdb"
 	"github.com/dolthub/dolt/go/libraries/utils/version"
@@ -56,6 +57,14 @@ type metricsListener struct {
 	isReplicaGauges      *prometheus.GaugeVec
 	replicationLagGauges *prometheus.GaugeVec
 
+	// lazy fetch metrics, reported as gauges since the counts are read from the lazy sources
+	lazyRequestedGauges  *prometheus.GaugeVec
+	lazyCacheHitGauges   *prometheus.GaugeVec
+	lazyRemoteGauges     *prometheus.GaugeVec
+	lazyPrefetchedGauges *prometheus.GaugeVec
+	lazyCacheBytesGauges *prometheus.GaugeVec
+	lazySeenDbs          map[string]struct{}
+
 	// sys metrics
 	cpuUsage  prometheus.Gauge
 	diskUsage prometheus.Gauge
@@ -141,6 +150,31 @@ func newMetricsListener(labels prometheus.Labels, versionStr, storagePath string
 			Help:        "one if the server is currently in this role, zero otherwise",
 			ConstLabels: labels,
 		}, []string{dbLabel}),
+		lazyRequestedGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_lazy_fetch_requested_chunks",
+			Help:        "The number of chunks a lazily fetching database has read from its remote or its disk cache",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
+		lazyCacheHitGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_lazy_fetch_cache_hits",
+			Help:        "The number of chunks a lazily fetching database has read from its disk cache",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
+		lazyRemoteGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_lazy_fetch_remote_chunks",
+			Help:        "The number of chunks a lazily fetching database has read from its remote when they were needed",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
+		lazyPrefetchedGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_lazy_fetch_prefetched_chunks",
+			Help:        "The number of chunks a lazily fetching database has prefetched from its remote",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
+		lazyCacheBytesGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_lazy_fetch_cache_bytes",
+			Help:        "The size of the disk cache of a lazily fetching database",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
 		cpuUsage: prometheus.NewGauge(prometheus.GaugeOpts{
 			Name:        "sys_cpu_usage",
 			Help:        "The percentage of CPU used by the system",
@@ -159,6 +193,7 @@ func newMetricsListener(labels prometheus.Labels, versionStr, storagePath string
 		clusterStatus:  clusterStatus,
 		mu:             &sync.Mutex{},
 		clusterSeenDbs: make(map[string]struct{}),
+		lazySeenDbs:    make(map[string]struct{}),
 		mountPoint:     mountPoint,
 	}
 
@@ -181,6 +216,11 @@ func newMetricsListener(labels prometheus.Labels, versionStr, storagePath string
 	prometheus.MustRegister(ml.histQueryDur)
 	prometheus.MustRegister(ml.replicationLagGauges)
 	prometheus.MustRegister(ml.isReplicaGauges)
+	prometheus.MustRegister(ml.lazyRequestedGauges)
+	prometheus.MustRegister(ml.lazyCacheHitGauges)
+	prometheus.MustRegister(ml.lazyRemoteGauges)
+	prometheus.MustRegister(ml.lazyPrefetchedGauges)
+	prometheus.MustRegister(ml.lazyCacheBytesGauges)
 	prometheus.MustRegister(ml.cpuUsage)
 	prometheus.MustRegister(ml.diskUsage)
 	prometheus.MustRegister(ml.memUsage)
@@ -204,6 +244,7 @@ func (ml *metricsListener) pollMetrics() bool {
 	}
 
 	ml.pollReplicationMetrics()
+	ml.pollLazyFetchMetrics()
 	ml.pollSysMetrics()
 
 	return true
@@ -244,6 +285,30 @@ func (ml *metricsListener) pollReplicationMetrics() {
 	ml.clusterSeenDbs = dbNames
 }
 
+func (ml *metricsListener) pollLazyFetchMetrics() {
+	dbNames := make(map[string]struct{})
+	for _, dbStats := range remotestorage.AllLazyFetchStats() {
+		dbNames[dbStats.Name] = struct{}{}
+		ml.lazyRequestedGauges.WithLabelValues(dbStats.Name).Set(float64(dbStats.Stats.RequestedChunks))
+		ml.lazyCacheHitGauges.WithLabelValues(dbStats.Name).Set(float64(dbStats.Stats.CacheHits))
+		ml.lazyRemoteGauges.WithLabelValues(dbStats.Name).Set(float64(dbStats.Stats.RemoteChunks))
+		ml.lazyPrefetchedGauges.WithLabelValues(dbStats.Name).Set(float64(dbStats.Stats.PrefetchedChunks))
+		ml.lazyCacheBytesGauges.WithLabelValues(dbStats.Name).Set(float64(dbStats.Stats.CacheBytes))
+	}
+
+	// deregister metrics for databases which no longer fetch lazily
+	for db := range ml.lazySeenDbs {
+		if _, ok := dbNames[db]; !ok {
+			ml.lazyRequestedGauges.DeletePartialMatch(prometheus.Labels{dbLabel: db})
+			ml.lazyCacheHitGauges.DeletePartialMatch(prometheus.Labels{dbLabel: db})
+			ml.lazyRemoteGauges.DeletePartialMatch(prometheus.Labels{dbLabel: db})
+			ml.lazyPrefetchedGauges.DeletePartialMatch(prometheus.Labels{dbLabel: db})
+			ml.lazyCacheBytesGauges.DeletePartialMatch(prometheus.Labels{dbLabel: db})
+		}
+	}
+	ml.lazySeenDbs = dbNames
+}
+
 func (ml *metricsListener) pollSysMetrics() {
 	percentages, err := cpu.Percent(0, false)
 
@@ -307,6 +372,7 @@ func (ml *metricsListener) Close() {
 	prometheus.Unregister(ml.histQueryDur)
 
 	ml.closeReplicationMetrics()
+	ml.closeLazyFetchMetrics()
 	ml.closeSysMetrics()
 }
 
@@ -315,6 +381,14 @@ func (ml *metricsListener) closeReplicationMetrics() {
 	prometheus.Unregister(ml.isReplicaGauges)
 }
 
+func (ml *metricsListener) closeLazyFetchMetrics() {
+	prometheus.Unregister(ml.lazyRequestedGauges)
+	prometheus.Unregister(ml.lazyCacheHitGauges)
+	prometheus.Unregister(ml.lazyRemoteGauges)
+	prometheus.Unregister(ml.lazyPrefetchedGauges)
+	prometheus.Unregister(ml.lazyCacheBytesGauges)
+}
+
 func (ml *metricsListener) closeSysMetrics() {
 	prometheus.Unregister(ml.cpuUsage)
 	prometheus.Unregister(ml.diskUsage)
@@ -44,6 +44,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
@@ -287,12 +288,37 @@ func ConfigureServices(
 					return fmt.Errorf("invalid value for %s: %d", cli.ArchiveLevelParam, cmp)
 				}
 				config.AutoGCController = sqle.NewAutoGCController(cmp, lgr)
+				if retention := cfg.ServerConfig.AutoGCBehavior().HistoryRetention(); retention != nil {
+					if err := config.AutoGCController.SetHistoryRetention(retention); err != nil {
+						return err
+					}
+				}
 			}
 			return nil
 		},
 	}
 	controller.Register(InitAutoGCController)
 
+	InitWebhookController := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			if webhooks := cfg.ServerConfig.Webhooks(); len(webhooks) > 0 {
+				config.WebhookController = sqle.NewWebhookController(webhooks, lgr)
+			}
+			return nil
+		},
+	}
+	controller.Register(InitWebhookController)
+
+	InitCIController := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			if cfg.ServerConfig.RunCIWorkflows() {
+				config.CIController = sqle.NewCIController(lgr)
+			}
+			return nil
+		},
+	}
+	controller.Register(InitCIController)
+
 	// mySQLServer is going to be populated down below once further services
 	// are initialized. However, we want to block Controller shutdown on all
 	// connections being fully drained from the Server. Stopping the
@@ -736,6 +762,10 @@ func ConfigureServices(
 				ConcurrencyControl: remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_ASSERT_WORKING_SET,
 				Options:            sqlContextInterceptor.Options(),
 				HttpInterceptor:    sqlContextInterceptor.HTTP(nil),
+				PushValidator:      branch_protection.PushValidator{},
+			}
+			if config.CIController != nil {
+				args.PushListener = config.CIController
 			}
 			var err error
 			args.FS = sqlEngine.FileSystem()
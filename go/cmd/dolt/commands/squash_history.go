@@ -0,0 +1,132 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	eventsapi "github.com/dolthub/eventsapi_schema/dolt/services/eventsapi/v1alpha1"
+)
+
+var squashHistoryDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Squashes old history according to a retention policy.",
+	LongDesc: `Rewrites the history of every branch, tag and remote-tracking branch to keep only the commits selected by a retention policy, and squashes every other commit into the next commit that is kept.
+
+The retention policy has three tiers, each given as an age relative to now: a number of days (e.g. {{.EmphasisLeft}}90d{{.EmphasisRight}}), weeks ({{.EmphasisLeft}}12w{{.EmphasisRight}}) or years ({{.EmphasisLeft}}1y{{.EmphasisRight}}), a duration ({{.EmphasisLeft}}36h{{.EmphasisRight}}), or {{.EmphasisLeft}}forever{{.EmphasisRight}}:
+
+{{.EmphasisLeft}}--keep-all{{.EmphasisRight}} keeps every commit younger than the given age.
+
+{{.EmphasisLeft}}--keep-daily{{.EmphasisRight}} keeps the last commit of each day on the first-parent history of each ref, for older commits younger than the given age.
+
+{{.EmphasisLeft}}--keep-weekly{{.EmphasisRight}} keeps the last commit of each week on the first-parent history of each ref, for older commits still younger than the given age.
+
+The heads of all branches, tags and remote-tracking branches, the first commit of the repository, and the commits given with {{.EmphasisLeft}}--preserve{{.EmphasisRight}} are always kept. A kept commit keeps its data, author, date and message, but every descendant of a squashed commit gets a new hash. All refs are moved to the rewritten commits at once, and the old and new head of each ref are printed along with the number of commits in its history before and after squashing.
+
+Once history is rewritten, a full garbage collection is run to remove the squashed commits from local storage. Clones and remotes must be force pushed or cloned again.
+
+With {{.EmphasisLeft}}--dry-run{{.EmphasisRight}}, the number of commits that would be kept is printed, and nothing is changed.
+
+Squashing history fails if a merge or rebase is in progress on any branch. Stashes are left as is, and keep the history of the commits they were created on in storage.`,
+	Synopsis: []string{
+		"[--keep-all {{.LessThan}}age{{.GreaterThan}}] [--keep-daily {{.LessThan}}age{{.GreaterThan}}] [--keep-weekly {{.LessThan}}age{{.GreaterThan}}] [--preserve {{.LessThan}}commits{{.GreaterThan}}] [--dry-run] [--skip-gc]",
+	},
+}
+
+type SquashHistoryCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd SquashHistoryCmd) Name() string {
+	return "squash-history"
+}
+
+// Description returns a description of the command
+func (cmd SquashHistoryCmd) Description() string {
+	return squashHistoryDocs.ShortDesc
+}
+
+func (cmd SquashHistoryCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(squashHistoryDocs, ap)
+}
+
+func (cmd SquashHistoryCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateSquashHistoryArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd SquashHistoryCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd SquashHistoryCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, squashHistoryDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if !apr.ContainsAny(cli.KeepAllParam, cli.KeepDailyParam, cli.KeepWeeklyParam) {
+		return HandleVErrAndExitCode(errhand.BuildDError("Invalid Argument: at least one of --keep-all, --keep-daily or --keep-weekly is required").SetPrintUsage().Build(), usage)
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	query, err := constructDoltSquashHistoryQuery(apr)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	schema, rowIter, _, err := queryist.Queryist.Query(queryist.Context, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	err = engine.PrettyPrintResults(queryist.Context, engine.FormatTabular, schema, rowIter, false, false, false, false)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	return 0
+}
+
+// constructDoltSquashHistoryQuery generates the sql query necessary to call DOLT_SQUASH_HISTORY()
+func constructDoltSquashHistoryQuery(apr *argparser.ArgParseResults) (string, error) {
+	var args []string
+	var params []interface{}
+	for _, param := range []string{cli.KeepAllParam, cli.KeepDailyParam, cli.KeepWeeklyParam, cli.PreserveParam} {
+		if val, ok := apr.GetValue(param); ok {
+			args = append(args, "'--"+param+"', ?")
+			params = append(params, val)
+		}
+	}
+	for _, flag := range []string{cli.DryRunFlag, cli.SkipGCFlag} {
+		if apr.Contains(flag) {
+			args = append(args, "'--"+flag+"'")
+		}
+	}
+
+	query := "CALL DOLT_SQUASH_HISTORY(" + strings.Join(args, ", ") + ")"
+	return dbr.InterpolateForDialect(query, params, dialect.MySQL)
+}
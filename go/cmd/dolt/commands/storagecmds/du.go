@@ -0,0 +1,208 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagecmds
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dustin/go-humanize"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	tablesFlag = "tables"
+	bytesFlag  = "bytes"
+)
+
+var duDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Show the storage used by refs and tables",
+	LongDesc: `Shows the storage used by every ref of the database, as computed by the {{.EmphasisLeft}}dolt_storage_usage{{.EmphasisRight}} system table. Refs are given by name, e.g. {{.EmphasisLeft}}main{{.EmphasisRight}}, or as full refs, e.g. {{.EmphasisLeft}}refs/tags/v1{{.EmphasisRight}}. All refs are shown when none are given.
+
+For each ref, the output lists the number of chunks reachable from the ref, the logical size of those chunks, and the size of the chunks which are not reachable from any other ref. The latter is the storage that deleting the ref would free up after a garbage collection. The working set of a branch is accounted for along with the branch. Sizes are the sizes of chunks before compression.
+
+With {{.EmphasisLeft}}--tables{{.EmphasisRight}}, the storage used by every table across the history of each ref is listed as well.
+
+Computing storage usage reads every chunk of the database, which can take a long time for large databases.`,
+	Synopsis: []string{
+		"[--tables] [--bytes] [{{.LessThan}}ref{{.GreaterThan}}...]",
+	},
+}
+
+type DuCmd struct{}
+
+// Name implements cli.Command.
+func (cmd DuCmd) Name() string {
+	return "du"
+}
+
+// Description implements cli.Command.
+func (cmd DuCmd) Description() string {
+	return duDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd DuCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(duDocs, cmd.ArgParser())
+}
+
+// ArgParser implements cli.Command.
+func (cmd DuCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A branch, tag or remote branch, or a full ref. Defaults to all refs."})
+	ap.SupportsFlag(tablesFlag, "t", "Also show the storage used by every table of each ref.")
+	ap.SupportsFlag(bytesFlag, "b", "Show sizes as exact byte counts.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd DuCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, duDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	query := "SELECT ref, table_name, chunk_count, logical_bytes, exclusive_bytes FROM " + doltdb.StorageUsageTableName
+	if !apr.Contains(tablesFlag) {
+		query += " WHERE table_name = ''"
+	}
+	query += " ORDER BY ref, table_name"
+	rows, err := cli.GetRowsForSql(queryist.Queryist, queryist.Context, query)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	rows, err = filterRefs(rows, apr.Args)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	sch, rows, err := formatUsage(rows, apr.Contains(tablesFlag), apr.Contains(bytesFlag))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	err = engine.PrettyPrintResults(queryist.Context, engine.FormatTabular, sch, sql.RowsToRowIter(rows...), false, false, false, false)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	return 0
+}
+
+// filterRefs returns the rows of |rows| for the refs named by |names|, or all rows if |names| is empty. Names can be
+// full refs, or the names of branches, tags and remote branches.
+func filterRefs(rows []sql.Row, names []string) ([]sql.Row, error) {
+	if len(names) == 0 {
+		return rows, nil
+	}
+
+	found := make(map[string]bool)
+	var filtered []sql.Row
+	for _, row := range rows {
+		r, err := cli.QueryValueAsString(row[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if r == name || r == "refs/heads/"+name || r == "refs/tags/"+name || r == "refs/remotes/"+name {
+				found[name] = true
+				filtered = append(filtered, row)
+				break
+			}
+		}
+	}
+
+	for _, name := range names {
+		if !found[name] {
+			return nil, fmt.Errorf("unknown ref '%s'", name)
+		}
+	}
+	return filtered, nil
+}
+
+// formatUsage returns the schema and rows to print for the rows of dolt_storage_usage.
+func formatUsage(rows []sql.Row, withTables, exactBytes bool) (sql.Schema, []sql.Row, error) {
+	sch := sql.Schema{{Name: "ref", Type: types.Text}}
+	if withTables {
+		sch = append(sch, &sql.Column{Name: "table", Type: types.Text})
+	}
+	sch = append(sch,
+		&sql.Column{Name: "chunks", Type: types.Text},
+		&sql.Column{Name: "size", Type: types.Text},
+		&sql.Column{Name: "exclusive", Type: types.Text})
+
+	formatted := make([]sql.Row, len(rows))
+	for i, row := range rows {
+		r, err := cli.QueryValueAsString(row[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		out := sql.Row{r}
+		if withTables {
+			tbl, err := cli.QueryValueAsString(row[1])
+			if err != nil {
+				return nil, nil, err
+			}
+			out = append(out, tbl)
+		}
+
+		for j := 2; j < 5; j++ {
+			n, err := queryValueAsUint64(row[j])
+			if err != nil {
+				return nil, nil, err
+			}
+			switch {
+			case j == 2:
+				out = append(out, humanize.Comma(int64(n)))
+			case exactBytes:
+				out = append(out, strconv.FormatUint(n, 10))
+			default:
+				out = append(out, humanize.IBytes(n))
+			}
+		}
+		formatted[i] = out
+	}
+	return sch, formatted, nil
+}
+
+// queryValueAsUint64 converts an unsigned integer value from a query result, which is a string when connected to a
+// sql-server.
+func queryValueAsUint64(value any) (uint64, error) {
+	switch v := value.(type) {
+	case uint64:
+		return v, nil
+	case int64:
+		return uint64(v), nil
+	}
+	s, err := cli.QueryValueAsString(value)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
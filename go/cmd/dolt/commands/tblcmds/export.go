@@ -44,7 +44,9 @@ var exportDocs = cli.CommandDocumentationContent{
 
 The output format is inferred from the file extension, or can be set explicitly with {{.EmphasisLeft}}--file-type{{.EmphasisRight}}.
 
-Supported file types: {{.EmphasisLeft}}csv{{.EmphasisRight}}, {{.EmphasisLeft}}psv{{.EmphasisRight}}, {{.EmphasisLeft}}json{{.EmphasisRight}}, {{.EmphasisLeft}}jsonl{{.EmphasisRight}}, {{.EmphasisLeft}}sql{{.EmphasisRight}}, {{.EmphasisLeft}}parquet{{.EmphasisRight}}.
+Supported file types: {{.EmphasisLeft}}csv{{.EmphasisRight}}, {{.EmphasisLeft}}psv{{.EmphasisRight}}, {{.EmphasisLeft}}json{{.EmphasisRight}}, {{.EmphasisLeft}}jsonl{{.EmphasisRight}}, {{.EmphasisLeft}}sql{{.EmphasisRight}}, {{.EmphasisLeft}}parquet{{.EmphasisRight}}, {{.EmphasisLeft}}avro{{.EmphasisRight}}, {{.EmphasisLeft}}arrow{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}.avro{{.EmphasisRight}} exports an Avro object container file, and {{.EmphasisLeft}}.arrow{{.EmphasisRight}} (or {{.EmphasisLeft}}.feather{{.EmphasisRight}}) exports an Arrow IPC file. The SQL type of each column is stored in the file's schema, so that these files can be imported again without a schema file.
 
 {{.EmphasisLeft}}.json{{.EmphasisRight}} exports a single JSON object containing a {{.EmphasisLeft}}rows{{.EmphasisRight}} array; {{.EmphasisLeft}}.jsonl{{.EmphasisRight}} exports one JSON object per line.
 
@@ -0,0 +1,310 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testcmds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtablefunctions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/testreport"
+)
+
+const (
+	updateSnapshotsFlag = "update-snapshots"
+	formatFlag          = "format"
+)
+
+var runDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Run the tests defined in dolt_tests",
+	LongDesc: `Runs the tests defined in the {{.EmphasisLeft}}dolt_tests{{.EmphasisRight}} system table with {{.EmphasisLeft}}dolt_test_run(){{.EmphasisRight}}, and prints the result of each test. Tests are selected by name or by group; all tests are run when none are given. The command fails if any test fails.
+
+With {{.EmphasisLeft}}--update-snapshots{{.EmphasisRight}}, the snapshots of the selected {{.EmphasisLeft}}expected_snapshot{{.EmphasisRight}} tests are regenerated from the current result of their queries before the tests are run. Snapshots are stored in {{.EmphasisLeft}}dolt_tests{{.EmphasisRight}}, so updated snapshots show up in {{.EmphasisLeft}}dolt diff{{.EmphasisRight}}, and must be committed like any other change.
+
+With {{.EmphasisLeft}}--format{{.EmphasisRight}}, the results are written as a JUnit XML, TAP or JSON report instead, for CI systems to ingest. Each test is reported with the time it took to run, in a suite named after its group, and failed tests are reported with their failure message and query.`,
+	Synopsis: []string{
+		"[--update-snapshots] [--format {{.LessThan}}format{{.GreaterThan}}] [{{.LessThan}}test or group{{.GreaterThan}}...]",
+	},
+}
+
+type RunCmd struct{}
+
+// Name implements cli.Command.
+func (cmd RunCmd) Name() string {
+	return "run"
+}
+
+// Description implements cli.Command.
+func (cmd RunCmd) Description() string {
+	return runDocs.ShortDesc
+}
+
+// RequiresRepo implements cli.Command.
+func (cmd RunCmd) RequiresRepo() bool {
+	return true
+}
+
+// Docs implements cli.Command.
+func (cmd RunCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(runDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd RunCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"test or group", "The name of a test, or of a group of tests, to run. Defaults to all tests."})
+	ap.SupportsFlag(updateSnapshotsFlag, "", "Regenerate the snapshots of the selected expected_snapshot tests before running them.")
+	ap.SupportsString(formatFlag, "", "format", "How to report the results of the tests. Valid values are text, junit, tap, json. Defaults to text.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd RunCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, runDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	format, err := testreport.ParseFormat(apr.GetValueOrDefault(formatFlag, string(testreport.FormatText)))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	if apr.Contains(updateSnapshotsFlag) {
+		if err = updateSnapshots(queryist.Context, queryist.Queryist, apr.Args); err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
+	results, err := runTests(queryist.Context, queryist.Queryist, apr.Args)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	if format != testreport.FormatText {
+		report := testreport.Report{Name: "dolt test"}
+		for _, result := range results {
+			report.AddCase(result.group, testreport.Case{
+				Name:     result.name,
+				Query:    result.query,
+				Duration: result.duration,
+				Failure:  result.message,
+				Failed:   !result.passed(),
+			})
+		}
+		if err = testreport.Write(cli.CliOut, format, report); err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		for _, result := range results {
+			if !result.passed() {
+				return 1
+			}
+		}
+		return 0
+	}
+
+	var passed, failed int
+	for _, result := range results {
+		name := result.name
+		if result.group != "" {
+			name = fmt.Sprintf("%s (%s)", result.name, result.group)
+		}
+		if result.passed() {
+			passed++
+			cli.Println(color.GreenString("PASS") + " " + name)
+		} else {
+			failed++
+			cli.Println(color.RedString("FAIL") + " " + name)
+			cli.Println(fmt.Sprintf("  query: %s", result.query))
+			cli.Println(fmt.Sprintf("  %s", result.message))
+		}
+	}
+
+	summary := fmt.Sprintf("%d passed, %d failed", passed, failed)
+	if failed > 0 {
+		cli.Println(color.RedString(summary))
+		return 1
+	}
+	cli.Println(color.GreenString(summary))
+	return 0
+}
+
+type testResult struct {
+	name     string
+	group    string
+	query    string
+	status   string
+	message  string
+	duration time.Duration
+}
+
+func (r testResult) passed() bool {
+	return r.status == "PASS"
+}
+
+// runTests runs the tests selected by |selectors| one at a time with dolt_test_run, so that each one can be timed.
+func runTests(sqlCtx *sql.Context, queryist cli.Queryist, selectors []string) ([]testResult, error) {
+	testNames, err := selectTests(sqlCtx, queryist, selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []testResult
+	for _, testName := range testNames {
+		start := time.Now()
+		rows, err := commands.InterpolateAndRunQuery(queryist, sqlCtx, testFunctionQuery("dolt_test_run", 1), testName)
+		if err != nil {
+			return nil, err
+		}
+		duration := time.Since(start)
+
+		for _, row := range rows {
+			values, err := rowToStrings(sqlCtx, row)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, testResult{
+				name:     values[0],
+				group:    values[1],
+				query:    values[2],
+				status:   values[3],
+				message:  values[4],
+				duration: duration,
+			})
+		}
+	}
+	return results, nil
+}
+
+// selectTests returns the names of the tests selected by |selectors|, in the order dolt_test_run would run them. Like
+// the arguments of dolt_test_run, each selector is the name of a test, or else the name of a group of tests, and no
+// selectors select every test.
+func selectTests(sqlCtx *sql.Context, queryist cli.Queryist, selectors []string) ([]string, error) {
+	if len(selectors) == 0 {
+		selectors = []string{"*"}
+	}
+
+	var testNames []string
+	for _, selector := range selectors {
+		var rows []sql.Row
+		var err error
+		if selector == "*" {
+			rows, err = commands.InterpolateAndRunQuery(queryist, sqlCtx, "SELECT test_name FROM dolt_tests")
+		} else {
+			rows, err = commands.InterpolateAndRunQuery(queryist, sqlCtx, "SELECT test_name FROM dolt_tests WHERE test_name = ?", selector)
+			if err == nil && len(rows) == 0 {
+				rows, err = commands.InterpolateAndRunQuery(queryist, sqlCtx, "SELECT test_name FROM dolt_tests WHERE test_group = ?", selector)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, fmt.Errorf("could not find tests for argument: %s", selector)
+		}
+
+		for _, row := range rows {
+			values, err := rowToStrings(sqlCtx, row)
+			if err != nil {
+				return nil, err
+			}
+			testNames = append(testNames, values[0])
+		}
+	}
+	return testNames, nil
+}
+
+// updateSnapshots stores the current snapshot of every expected_snapshot test selected by |selectors| in dolt_tests.
+func updateSnapshots(sqlCtx *sql.Context, queryist cli.Queryist, selectors []string) error {
+	rows, err := commands.InterpolateAndRunQuery(queryist, sqlCtx, testFunctionQuery("dolt_test_snapshots", len(selectors)), stringsToInterfaces(selectors)...)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		values, err := rowToStrings(sqlCtx, row)
+		if err != nil {
+			return err
+		}
+		testName, snapshot := values[0], values[1]
+
+		current, err := commands.InterpolateAndRunQuery(queryist, sqlCtx, "SELECT assertion_value FROM dolt_tests WHERE test_name = ?", testName)
+		if err != nil {
+			return err
+		}
+		if len(current) == 1 {
+			currentSnapshot, err := dtablefunctions.GetStringColAsString(sqlCtx, current[0][0])
+			if err != nil {
+				return err
+			}
+			if currentSnapshot != nil && *currentSnapshot == snapshot {
+				continue
+			}
+		}
+
+		_, err = commands.InterpolateAndRunQuery(queryist, sqlCtx, "UPDATE dolt_tests SET assertion_value = ? WHERE test_name = ?", snapshot, testName)
+		if err != nil {
+			return fmt.Errorf("could not update snapshot for test %s: %w", testName, err)
+		}
+		cli.PrintErrln(color.YellowString("Updated snapshot for test %s", testName))
+	}
+	return nil
+}
+
+// testFunctionQuery returns a query selecting from the table function |name| with |numArgs| placeholder arguments.
+func testFunctionQuery(name string, numArgs int) string {
+	placeholders := make([]string, numArgs)
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	return fmt.Sprintf("SELECT * FROM %s(%s)", name, strings.Join(placeholders, ", "))
+}
+
+func rowToStrings(sqlCtx *sql.Context, row sql.Row) ([]string, error) {
+	values := make([]string, len(row))
+	for i, v := range row {
+		str, err := dtablefunctions.GetStringColAsString(sqlCtx, v)
+		if err != nil {
+			return nil, err
+		}
+		if str != nil {
+			values[i] = *str
+		}
+	}
+	return values, nil
+}
+
+func stringsToInterfaces(ss []string) []interface{} {
+	res := make([]interface{}, len(ss))
+	for i, s := range ss {
+		res[i] = s
+	}
+	return res
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testcmds
+
+import (
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+)
+
+// Commands runs the tests in dolt_tests when no subcommand is given, so that `dolt test` and `dolt test run` are
+// equivalent.
+var Commands = cli.NewSubCommandHandlerWithUnspecified("test", "Commands for running the tests defined in dolt_tests.", false, RunCmd{}, []cli.Command{
+	RunCmd{},
+})
@@ -88,6 +88,7 @@ var commandsWithoutCliCtx = []cli.Command{
 	&commands.Assist{},
 	commands.ProfileCmd{},
 	commands.ArchiveCmd{},
+	commands.RemoteHelperCmd{},
 	commands.FsckCmd{},
 	commands.ConfigCmd{},
 }
@@ -433,6 +434,11 @@ func runMain() int {
 	}
 	args = nil
 
+	if err := dbfactory.ConfigureChunkEncryption(ctx); err != nil {
+		cli.PrintErrln(color.RedString("Failed to configure chunk encryption. %v", err))
+		return 1
+	}
+
 	// This is the dEnv passed to sub-commands, and is used to create the multi-repo environment.
 	dEnv := env.LoadWithoutDB(ctx, env.GetCurrentUserHomeDir, cfg.dataDirFS, doltdb.LocalDirDoltDB, doltversion.Version)
 
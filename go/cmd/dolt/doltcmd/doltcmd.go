@@ -26,7 +26,9 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/indexcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/schcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/sqlserver"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/storagecmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/tblcmds"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/testcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/doltversion"
 )
 
@@ -73,6 +75,8 @@ var doltSubCommands = []cli.Command{
 	commands.GarbageCollectionCmd{},
 	commands.FsckCmd{},
 	commands.FilterBranchCmd{},
+	commands.PurgeRowsCmd{},
+	commands.SquashHistoryCmd{},
 	commands.MergeBaseCmd{},
 	commands.RootsCmd{},
 	commands.VersionCmd{VersionStr: doltversion.Version},
@@ -88,7 +92,10 @@ var doltSubCommands = []cli.Command{
 	commands.ReflogCmd{},
 	commands.RebaseCmd{},
 	commands.ArchiveCmd{},
+	commands.RemoteHelperCmd{},
 	ci.Commands,
+	testcmds.Commands,
+	storagecmds.Commands,
 	commands.DebugCmd{},
 	commands.RmCmd{},
 }
@@ -0,0 +1,156 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	// CatalogKey is the key of the blob holding the catalog of a backup.
+	CatalogKey = "dolt_backup_catalog"
+	// branchControlKeyPrefix prefixes the keys of the blobs holding the branch control data of backup points. The
+	// data is addressed by its hash, so points sharing the same data share the same blob.
+	branchControlKeyPrefix = "dolt_backup_branch_control_"
+
+	maxCatalogUpdateAttempts = 10
+)
+
+// Point is a point in time that a backup can be restored to. Every sync of a backup records the root of the backup's
+// chunk store after the sync. The chunks written by earlier syncs are never removed from the backup, so the roots of
+// all of its points remain readable.
+type Point struct {
+	// Time is when the sync finished.
+	Time time.Time `json:"time"`
+	// Root is the root hash of the backup's chunk store, which references the branches, tags, working sets and
+	// tuples of the database at |Time|.
+	Root string `json:"root"`
+	// BranchControl is the key of the blob holding the serialized branch control data at |Time|, or empty if there
+	// was none.
+	BranchControl string `json:"branch_control,omitempty"`
+}
+
+// Catalog lists the points of a backup, ordered by time.
+type Catalog struct {
+	Points []Point `json:"points"`
+}
+
+// LoadCatalog reads the catalog of the backup stored in |bs|, along with the version of its blob. Returns an empty
+// catalog and version if the backup has no catalog yet.
+func LoadCatalog(ctx context.Context, bs blobstore.Blobstore) (*Catalog, string, error) {
+	data, ver, err := blobstore.GetBytes(ctx, bs, CatalogKey, blobstore.AllRange)
+	if blobstore.IsNotFoundError(err) {
+		return &Catalog{}, "", nil
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	var catalog Catalog
+	if err = json.Unmarshal(data, &catalog); err != nil {
+		return nil, "", fmt.Errorf("unable to read backup catalog: %w", err)
+	}
+	sort.SliceStable(catalog.Points, func(i, j int) bool {
+		return catalog.Points[i].Time.Before(catalog.Points[j].Time)
+	})
+	return &catalog, ver, nil
+}
+
+// PointAsOf returns the latest point recorded at or before |t|. Returns false if every point is later than |t|.
+func (c *Catalog) PointAsOf(t time.Time) (Point, bool) {
+	i := sort.Search(len(c.Points), func(i int) bool {
+		return c.Points[i].Time.After(t)
+	})
+	if i == 0 {
+		return Point{}, false
+	}
+	return c.Points[i-1], true
+}
+
+// RecordPoint adds a point for |root| at time |t| to the catalog of the backup stored in |bs|. |branchControl| is the
+// serialized branch control data to restore along with |root|, and may be empty. The catalog is updated with a
+// check-and-put, so concurrent syncs of the same backup each record their point.
+func RecordPoint(ctx context.Context, bs blobstore.Blobstore, root hash.Hash, branchControl []byte, t time.Time) error {
+	point := Point{Time: t.UTC(), Root: root.String()}
+	if len(branchControl) > 0 {
+		point.BranchControl = branchControlKeyPrefix + hash.Of(branchControl).String()
+		exists, err := bs.Exists(ctx, point.BranchControl)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if _, err = blobstore.PutBytes(ctx, bs, point.BranchControl, branchControl); err != nil {
+				return err
+			}
+		}
+	}
+
+	for attempt := 0; attempt < maxCatalogUpdateAttempts; attempt++ {
+		catalog, ver, err := LoadCatalog(ctx, bs)
+		if err != nil {
+			return err
+		}
+		catalog.Points = append(catalog.Points, point)
+		data, err := json.Marshal(catalog)
+		if err != nil {
+			return err
+		}
+
+		_, err = bs.CheckAndPut(ctx, ver, CatalogKey, int64(len(data)), bytes.NewReader(data))
+		if err == nil {
+			return nil
+		} else if !blobstore.IsCheckAndPutError(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("unable to update backup catalog: too many concurrent updates")
+}
+
+// LoadBranchControl returns the serialized branch control data recorded for |p|, or nil if there is none.
+func LoadBranchControl(ctx context.Context, bs blobstore.Blobstore, p Point) ([]byte, error) {
+	if p.BranchControl == "" {
+		return nil, nil
+	}
+	data, _, err := blobstore.GetBytes(ctx, bs, p.BranchControl, blobstore.AllRange)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read branch control data of backup point %s: %w", p.Time.Format(time.RFC3339), err)
+	}
+	return data, nil
+}
+
+// asOfLayouts are the accepted layouts of the times passed to ParseAsOf, from the most to the least precise.
+var asOfLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// ParseAsOf parses the time a backup is restored as of. Times without a zone are UTC, like commit dates.
+func ParseAsOf(s string) (time.Time, error) {
+	for _, layout := range asOfLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s', expected a time like '2006-01-02 15:04:05' or '2006-01-02T15:04:05Z'", s)
+}
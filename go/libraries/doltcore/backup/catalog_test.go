@@ -0,0 +1,100 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestCatalog(t *testing.T) {
+	ctx := context.Background()
+	bs := blobstore.NewInMemoryBlobstore("")
+
+	catalog, ver, err := LoadCatalog(ctx, bs)
+	require.NoError(t, err)
+	assert.Empty(t, catalog.Points)
+	assert.Empty(t, ver)
+
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	bc := []byte("branch control")
+	root1, root2, root3 := hash.Of([]byte("1")), hash.Of([]byte("2")), hash.Of([]byte("3"))
+	require.NoError(t, RecordPoint(ctx, bs, root1, nil, start))
+	require.NoError(t, RecordPoint(ctx, bs, root3, bc, start.Add(2*time.Hour)))
+	require.NoError(t, RecordPoint(ctx, bs, root2, bc, start.Add(time.Hour)))
+
+	catalog, ver, err = LoadCatalog(ctx, bs)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ver)
+	require.Len(t, catalog.Points, 3)
+	assert.Equal(t, catalog.Points[1].BranchControl, catalog.Points[2].BranchControl)
+
+	_, ok := catalog.PointAsOf(start.Add(-time.Second))
+	assert.False(t, ok)
+
+	tests := []struct {
+		asOf time.Time
+		root hash.Hash
+	}{
+		{start, root1},
+		{start.Add(59 * time.Minute), root1},
+		{start.Add(time.Hour), root2},
+		{start.Add(90 * time.Minute), root2},
+		{start.Add(24 * time.Hour), root3},
+	}
+	for _, test := range tests {
+		point, ok := catalog.PointAsOf(test.asOf)
+		require.True(t, ok)
+		assert.Equal(t, test.root.String(), point.Root, "as of %s", test.asOf)
+	}
+
+	point, _ := catalog.PointAsOf(start)
+	data, err := LoadBranchControl(ctx, bs, point)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	point, _ = catalog.PointAsOf(start.Add(time.Hour))
+	data, err = LoadBranchControl(ctx, bs, point)
+	require.NoError(t, err)
+	assert.Equal(t, bc, data)
+}
+
+func TestParseAsOf(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected time.Time
+	}{
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-02 15:04:05", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2024-01-02T15:04:05.5", time.Date(2024, 1, 2, 15, 4, 5, 500000000, time.UTC)},
+		{"2024-01-02T15:04:05Z", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2024-01-02T15:04:05-08:00", time.Date(2024, 1, 2, 23, 4, 5, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		actual, err := ParseAsOf(test.s)
+		require.NoError(t, err, test.s)
+		assert.True(t, test.expected.Equal(actual), "%s: expected %s, got %s", test.s, test.expected, actual)
+	}
+
+	_, err := ParseAsOf("yesterday")
+	assert.Error(t, err)
+}
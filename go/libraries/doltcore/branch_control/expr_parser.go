@@ -16,6 +16,7 @@ package branch_control
 
 import (
 	"math"
+	"strings"
 	"sync"
 	"unicode/utf8"
 
@@ -212,6 +213,22 @@ func Match(matchExprCollection []MatchExpression, str string, collation sql.Coll
 	return validMatches
 }
 
+// MatchesBranch returns whether |branch| matches the expression |expr|, the way branch names are matched against the
+// branch column of the branch control tables: case-insensitively, with '_' and '%' as wildcards and '\' as the escape
+// character.
+func MatchesBranch(expr string, branch string) bool {
+	matchExpr := MatchExpression{
+		CollectionIndex: 0,
+		SortOrders:      ParseExpression(strings.ToLower(FoldExpression(expr)), sql.Collation_utf8mb4_0900_ai_ci),
+	}
+	if len(branch) == 0 {
+		return matchExpr.IsAtEnd()
+	}
+	matches := Match([]MatchExpression{matchExpr}, strings.ToLower(branch), sql.Collation_utf8mb4_0900_ai_ci)
+	defer indexPool.Put(matches)
+	return len(matches) > 0
+}
+
 // Matches returns true when the given sort order matches the expectation of the calling match expression. Returns a
 // reduced match expression as `next`, which should take the place of the calling match function. In the event of a
 // branch, returns the branching match expression as `extra`.
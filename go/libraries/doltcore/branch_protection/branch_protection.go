@@ -0,0 +1,416 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package branch_protection enforces the rules declared in the dolt_branch_protection system table. The rules that
+// protect a branch are read from the head commit of that branch, so that changing them requires the same review as
+// any other change to the branch.
+package branch_protection
+
+import (
+	"context"
+	goerrors "errors"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var (
+	ErrDirectCommit       = errors.NewKind("branch `%s` is protected; changes must be merged into it from another branch")
+	ErrForcePush          = errors.NewKind("branch `%s` is protected; it cannot be force pushed, rewritten or deleted")
+	ErrWorkflowNotPassed  = errors.NewKind("branch `%s` is protected; workflow `%s` has not passed on commit %s")
+	ErrNotEnoughApprovals = errors.NewKind("branch `%s` is protected; commit %s has %d of the %d required approvals")
+	ErrApproveAsOtherUser = errors.NewKind("user `%s` cannot record or remove approvals for user `%s`")
+	ErrSelfApproval       = errors.NewKind("user `%s` cannot approve commit %s, which they authored")
+	ErrMergeParent        = errors.NewKind("branch `%s` is protected; merge commit %s must have the head of the branch, %s, as its first parent")
+	ErrMergeChanged       = errors.NewKind("branch `%s` is protected; a merge of commit %s must contain exactly the result of the merge")
+	ErrPushedApprovals    = errors.NewKind("pushes cannot change the approvals or workflow results recorded by the server")
+)
+
+// rulesAt returns the rules declared on |head| that protect |branch|.
+func rulesAt(ctx context.Context, head *doltdb.Commit, branch string) ([]doltdb.BranchProtectionRule, error) {
+	root, err := head.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return doltdb.GetBranchProtectionRules(ctx, root, branch)
+}
+
+// CheckCommit checks a commit made directly on |branch|, whose head is |head|. If |amend| is set, the commit replaces
+// |head| rather than descending from it.
+func CheckCommit(ctx context.Context, branch string, head *doltdb.Commit, amend bool) error {
+	rules, err := rulesAt(ctx, head, branch)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.BlockDirectCommits {
+			return ErrDirectCommit.New(branch)
+		}
+		if amend && !rule.AllowForcePush {
+			return ErrForcePush.New(branch)
+		}
+	}
+	return nil
+}
+
+// CheckMergeCommit checks committing |root| to |branch|, whose head is |head|, as the merge of |merged|. In addition to
+// the checks of CheckMerge, branches whose rules block direct commits or require workflows or approvals only accept the
+// result of the merge, so that the commit can't make changes that weren't reviewed.
+func CheckMergeCommit(ctx context.Context, ddb *doltdb.DoltDB, branch string, head, merged *doltdb.Commit, root doltdb.RootValue) error {
+	if err := CheckMerge(ctx, ddb, branch, head, merged); err != nil {
+		return err
+	}
+	rules, err := rulesAt(ctx, head, branch)
+	if err != nil || !guardsMerges(rules) {
+		return err
+	}
+	return checkMergeResult(ctx, branch, head, merged, root)
+}
+
+// CheckMerge checks a merge of |merged| into |branch|, whose head is |head|. The workflow results and approvals of
+// |merged| are read from |ddb|. Merging a commit that |head| already contains is always allowed.
+func CheckMerge(ctx context.Context, ddb *doltdb.DoltDB, branch string, head, merged *doltdb.Commit) error {
+	rules, err := rulesAt(ctx, head, branch)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	_, err = head.CanFastForwardTo(ctx, merged)
+	if goerrors.Is(err, doltdb.ErrUpToDate) || goerrors.Is(err, doltdb.ErrIsAhead) {
+		return nil
+	} else if err != nil && !goerrors.Is(err, doltdb.ErrNoCommonAncestor) {
+		return err
+	}
+	return checkRequirements(ctx, ddb, branch, rules, merged)
+}
+
+// CheckUpdate checks moving |branch| from |old| to |new| other than by committing to it: with a push, or by resetting,
+// copying over or deleting the branch. A nil |old| is a new branch, which is never protected, and a nil |new| deletes
+// the branch. The rules protecting the branch, and the workflow results and approvals of the new commits, are read
+// from |ddb|, the database of the branch.
+//
+// An update that does not fast-forward the branch is a force push. Branches that block direct commits must either be
+// updated to a merge commit, in which case the requirements of the rules apply to its second parent, or fast-forward
+// to a commit that meets the requirements. A merge commit must have |old| as its first parent, and contain exactly the
+// result of the merge.
+func CheckUpdate(ctx context.Context, ddb *doltdb.DoltDB, branch string, old, new *doltdb.Commit) error {
+	if old == nil {
+		return nil
+	}
+	rules, err := rulesAt(ctx, old, branch)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	forced := new == nil
+	if !forced {
+		canFF, err := old.CanFastForwardTo(ctx, new)
+		if goerrors.Is(err, doltdb.ErrUpToDate) {
+			return nil
+		} else if goerrors.Is(err, doltdb.ErrIsAhead) || goerrors.Is(err, doltdb.ErrNoCommonAncestor) {
+			canFF, err = false, nil
+		}
+		if err != nil {
+			return err
+		}
+		forced = !canFF
+	}
+	if forced {
+		for _, rule := range rules {
+			if !rule.AllowForcePush {
+				return ErrForcePush.New(branch)
+			}
+		}
+		if new == nil {
+			return nil
+		}
+	}
+
+	merged := new
+	isMerge := new.NumParents() > 1
+	if isMerge {
+		merged, err = parent(ctx, new, 1)
+		if err != nil {
+			return err
+		}
+	}
+	for _, rule := range rules {
+		if rule.BlockDirectCommits && !isMerge && !rule.HasRequirements() {
+			return ErrDirectCommit.New(branch)
+		}
+	}
+	if isMerge && guardsMerges(rules) {
+		if err = checkMergeParent(ctx, branch, old, new); err != nil {
+			return err
+		}
+		root, err := new.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		if err = checkMergeResult(ctx, branch, old, merged, root); err != nil {
+			return err
+		}
+	}
+	return checkRequirements(ctx, ddb, branch, rules, merged)
+}
+
+// guardsMerges returns whether |rules| block direct commits or have requirements, in which case a merge commit may
+// only contain the result of the merge.
+func guardsMerges(rules []doltdb.BranchProtectionRule) bool {
+	for _, rule := range rules {
+		if rule.BlockDirectCommits || rule.HasRequirements() {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMergeParent returns ErrMergeParent if the first parent of the merge commit |mergeCommit| isn't |head|.
+func checkMergeParent(ctx context.Context, branch string, head, mergeCommit *doltdb.Commit) error {
+	first, err := parent(ctx, mergeCommit, 0)
+	if err != nil {
+		return err
+	}
+	headHash, err := head.HashOf()
+	if err != nil {
+		return err
+	}
+	firstHash, err := first.HashOf()
+	if err != nil {
+		return err
+	}
+	if firstHash != headHash {
+		mergeHash, err := mergeCommit.HashOf()
+		if err != nil {
+			return err
+		}
+		return ErrMergeParent.New(branch, mergeHash.String(), headHash.String())
+	}
+	return nil
+}
+
+// checkMergeResult returns ErrMergeChanged if |root| differs from the result of merging |merged| into |head|. The
+// tables of materialized views are not compared, since they are refreshed when a merge is committed.
+func checkMergeResult(ctx context.Context, branch string, head, merged *doltdb.Commit, root doltdb.RootValue) error {
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		sqlCtx = sql.NewContext(ctx)
+	}
+	result, err := merge.MergeCommits(sqlCtx, doltdb.SimpleTableResolver{}, head, merged, editor.Options{})
+	if err != nil {
+		return err
+	}
+
+	views, err := doltdb.GetMaterializedViews(ctx, root)
+	if err != nil {
+		return err
+	}
+	isView := make(map[string]bool, len(views))
+	for _, view := range views {
+		isView[strings.ToLower(view.Name)] = true
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, result.Root, root)
+	if err != nil {
+		return err
+	}
+	for _, delta := range deltas {
+		if isView[strings.ToLower(delta.FromName.Name)] || isView[strings.ToLower(delta.ToName.Name)] {
+			continue
+		}
+		changed, err := delta.HasChangesIgnoringColumnTags(ctx)
+		if err != nil {
+			return err
+		}
+		if changed {
+			h, err := merged.HashOf()
+			if err != nil {
+				return err
+			}
+			return ErrMergeChanged.New(branch, h.String())
+		}
+	}
+	return nil
+}
+
+// parent returns the parent |i| of |cm|.
+func parent(ctx context.Context, cm *doltdb.Commit, i int) (*doltdb.Commit, error) {
+	optParent, err := cm.GetParent(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := optParent.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return p, nil
+}
+
+// checkRequirements checks that |merged| has passed the workflows and has the approvals required by |rules|.
+func checkRequirements(ctx context.Context, ddb *doltdb.DoltDB, branch string, rules []doltdb.BranchProtectionRule, merged *doltdb.Commit) error {
+	requiredApprovals := 0
+	var requiredWorkflows []string
+	for _, rule := range rules {
+		requiredWorkflows = append(requiredWorkflows, rule.RequiredWorkflows...)
+		requiredApprovals = max(requiredApprovals, rule.RequiredApprovals)
+	}
+	if len(requiredWorkflows) == 0 && requiredApprovals == 0 {
+		return nil
+	}
+
+	h, err := merged.HashOf()
+	if err != nil {
+		return err
+	}
+
+	if len(requiredWorkflows) > 0 {
+		results, err := ddb.GetCIWorkflowResults(ctx, h)
+		if err != nil {
+			return err
+		}
+		passed := make(map[string]bool, len(results))
+		for _, result := range results {
+			passed[result.Workflow] = result.Status == doltdb.CIWorkflowPassed
+		}
+		for _, workflow := range requiredWorkflows {
+			if !passed[workflow] {
+				return ErrWorkflowNotPassed.New(branch, workflow, h.String())
+			}
+		}
+	}
+
+	if requiredApprovals > 0 {
+		approvals, err := ddb.GetBranchApprovals(ctx)
+		if err != nil {
+			return err
+		}
+		approvers := make(map[string]struct{})
+		for _, approval := range approvals {
+			if approval.Commit == h.String() {
+				approvers[approval.Approver] = struct{}{}
+			}
+		}
+		if len(approvers) < requiredApprovals {
+			return ErrNotEnoughApprovals.New(branch, h.String(), len(approvers), requiredApprovals)
+		}
+	}
+
+	return nil
+}
+
+// PushValidator is a remotesrv.PushValidator that enforces branch protection rules on pushes to a remotesapi server.
+type PushValidator struct{}
+
+var _ remotesrv.PushValidator = PushValidator{}
+
+// ValidatePush implements remotesrv.PushValidator.
+func (PushValidator) ValidatePush(ctx context.Context, _ string, cs remotesrv.RemoteSrvStore, last, current hash.Hash) error {
+	ddb, err := doltdb.DoltDBFromCS(cs, "")
+	if err != nil {
+		return err
+	}
+	if err = checkPushedTuples(ctx, ddb, last, current); err != nil {
+		return err
+	}
+	if last.IsEmpty() {
+		// a new store has no branches to protect
+		return nil
+	}
+
+	oldBranches, err := ddb.GetBranchesByRootHash(ctx, last)
+	if err != nil {
+		return err
+	}
+	newBranches, err := ddb.GetBranchesByRootHash(ctx, current)
+	if err != nil {
+		return err
+	}
+	newHeads := make(map[string]hash.Hash, len(newBranches))
+	for _, b := range newBranches {
+		newHeads[b.Ref.String()] = b.Hash
+	}
+
+	for _, b := range oldBranches {
+		newHead, ok := newHeads[b.Ref.String()]
+		if ok && newHead == b.Hash {
+			continue
+		}
+
+		old, err := readCommit(ctx, ddb, b.Hash)
+		if err != nil {
+			return err
+		}
+		var new *doltdb.Commit
+		if ok {
+			new, err = readCommit(ctx, ddb, newHead)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = CheckUpdate(ctx, ddb, b.Ref.GetPath(), old, new); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPushedTuples returns ErrPushedApprovals if the approvals or dolt ci results of the root |current| differ from
+// those of the root |last|. They are stored outside of any commit, so a push could otherwise record approvals or
+// results that the server never did, and have them accepted by a later push.
+func checkPushedTuples(ctx context.Context, ddb *doltdb.DoltDB, last, current hash.Hash) error {
+	oldTuples := make(map[string]hash.Hash)
+	if !last.IsEmpty() {
+		var err error
+		oldTuples, err = ddb.GetTuplesByRootHash(ctx, last)
+		if err != nil {
+			return err
+		}
+	}
+	newTuples, err := ddb.GetTuplesByRootHash(ctx, current)
+	if err != nil {
+		return err
+	}
+
+	for key, addr := range newTuples {
+		if doltdb.IsBranchProtectionTuple(key) && oldTuples[key] != addr {
+			return ErrPushedApprovals.New()
+		}
+	}
+	for key := range oldTuples {
+		if _, ok := newTuples[key]; doltdb.IsBranchProtectionTuple(key) && !ok {
+			return ErrPushedApprovals.New()
+		}
+	}
+	return nil
+}
+
+func readCommit(ctx context.Context, ddb *doltdb.DoltDB, h hash.Hash) (*doltdb.Commit, error) {
+	optCmt, err := ddb.ReadCommit(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	cmt, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cmt, nil
+}
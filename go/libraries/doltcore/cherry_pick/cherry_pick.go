@@ -20,11 +20,13 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/matview"
 )
 
 // ErrCherryPickUncommittedChanges is returned when a cherry-pick is attempted without a clean working set.
@@ -129,6 +131,11 @@ func CherryPick(ctx *sql.Context, commit string, options CherryPickOptions) (str
 		return "", nil, fmt.Errorf("failed to get roots for current session")
 	}
 
+	roots, err = matview.RefreshForCommit(ctx, dbName, roots)
+	if err != nil {
+		return "", nil, err
+	}
+
 	pendingCommit, err := doltSession.NewPendingCommit(ctx, dbName, roots, *commitProps)
 	if err != nil {
 		return "", nil, err
@@ -141,6 +148,10 @@ func CherryPick(ctx *sql.Context, commit string, options CherryPickOptions) (str
 		}
 	}
 
+	if err = checkBranchProtection(ctx, doltSession, dbName, options.Amend); err != nil {
+		return "", nil, err
+	}
+
 	newCommit, err := doltSession.DoltCommit(ctx, dbName, doltSession.GetTransaction(), pendingCommit)
 	if err != nil {
 		return "", nil, err
@@ -308,6 +319,11 @@ func ContinueCherryPick(ctx *sql.Context, dbName string) (string, int, int, int,
 		return "", 0, 0, 0, fmt.Errorf("fatal: unable to load roots for %s", dbName)
 	}
 
+	roots, err = matview.RefreshForCommit(ctx, dbName, roots)
+	if err != nil {
+		return "", 0, 0, 0, fmt.Errorf("error: failed to refresh materialized views: %w", err)
+	}
+
 	pendingCommit, err := doltSession.NewPendingCommit(ctx, dbName, roots, commitProps)
 	if err != nil {
 		return "", 0, 0, 0, fmt.Errorf("error: failed to create pending commit: %w", err)
@@ -316,6 +332,10 @@ func ContinueCherryPick(ctx *sql.Context, dbName string) (string, int, int, int,
 		return "", 0, 0, 0, fmt.Errorf("error: no changes to commit")
 	}
 
+	if err = checkBranchProtection(ctx, doltSession, dbName, false); err != nil {
+		return "", 0, 0, 0, err
+	}
+
 	clearedWs := ws.ClearMerge()
 	err = doltSession.SetWorkingSet(ctx, dbName, clearedWs)
 	if err != nil {
@@ -552,3 +572,25 @@ func stageCherryPickedTables(ctx *sql.Context, mergeStats map[doltdb.TableName]*
 
 	return doltSession.SetRoots(ctx, dbName, roots)
 }
+
+// checkBranchProtection checks a commit cherry-picked onto the branch of |dbName| against the protection rules of the
+// branch. Commits replayed by a rebase aren't checked, since the rebased branch is checked when it replaces the branch
+// being rebased.
+func checkBranchProtection(ctx *sql.Context, doltSession *dsess.DoltSession, dbName string, amend bool) error {
+	ws, err := doltSession.WorkingSet(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	if ws.RebaseActive() {
+		return nil
+	}
+	headRef, err := doltSession.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	head, err := doltSession.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	return branch_protection.CheckCommit(ctx, headRef.GetPath(), head, amend)
+}
@@ -109,6 +109,8 @@ func AWSCredentialSourceFromStr(str string) AWSCredentialSource {
 
 // AWSFactory is a DBFactory implementation for creating AWS backed databases
 type AWSFactory struct {
+	// Encryption, if set, encrypts the chunk data stored in S3. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
 }
 
 func (fact AWSFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
@@ -157,7 +159,7 @@ func (fact AWSFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinForm
 	}
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	return nbs.NewAWSStore(ctx, nbf.VersionString(), parts[0], dbName, parts[1], s3.NewFromConfig(cfg), dynamodb.NewFromConfig(cfg), defaultMemTableSize, q)
+	return nbs.NewAWSStoreWithEncryption(ctx, nbf.VersionString(), parts[0], dbName, parts[1], s3.NewFromConfig(cfg), dynamodb.NewFromConfig(cfg), defaultMemTableSize, q, fact.Encryption)
 }
 
 func validatePath(path string) (string, error) {
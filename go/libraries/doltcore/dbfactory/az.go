@@ -33,6 +33,8 @@ import (
 
 // AzureDBFactory is a DBFactory implementation for creating Azure Blob Storage backed databases
 type AzureDBFactory struct {
+	// Encryption, if set, encrypts the chunk data stored in Azure Blob Storage. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
 }
 
 func (fact AzureDBFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
@@ -74,7 +76,7 @@ func (fact AzureDBFactory) CreateDB(ctx context.Context, nbf *types.NomsBinForma
 
 	bs := blobstore.NewAzureBlobstore(azClient, containerName, blobPrefix)
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	azStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	azStore, err := nbs.NewBSStoreWithEncryption(ctx, nbf.VersionString(), bs, defaultMemTableSize, q, fact.Encryption)
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -0,0 +1,61 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/blobstore"
+)
+
+// CreateBlobstore returns a Blobstore for the location of the database at |urlStr|, which can be used to keep files
+// alongside the database. Only the schemes of databases stored in a local directory or in a GCS bucket are supported.
+// For file URLs the blobs share the database's directory, which must already exist.
+func CreateBlobstore(ctx context.Context, urlStr string) (blobstore.Blobstore, error) {
+	urlObj, err := earl.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(urlObj.Scheme) {
+	case FileScheme:
+		path, err := url.PathUnescape(urlObj.Path)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewLocalBlobstore(urlObj.Host + filepath.FromSlash(path)), nil
+	case LocalBSScheme:
+		absPath, err := filepath.Abs(filepath.Join(urlObj.Host, urlObj.Path))
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewLocalBlobstore(absPath), nil
+	case GSScheme:
+		gcs, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path), nil
+	default:
+		return nil, fmt.Errorf("blobstores are not supported for '%s' urls", urlObj.Scheme)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// ConfigureChunkEncryption enables the encryption of chunk data at rest when an encryption key is set with
+// DOLT_ENCRYPTION_KEY or DOLT_ENCRYPTION_KEY_FILE. It registers DBFactories which pass the encryption to the stores
+// of the local databases, remotes and backups they open, so this must be called before any database is opened.
+func ConfigureChunkEncryption(ctx context.Context) error {
+	keys, err := encryptionKeysFromEnv()
+	if err != nil || keys == nil {
+		return err
+	}
+
+	wrapper, err := nbs.NewLocalKeyWrapper(keys...)
+	if err != nil {
+		return err
+	}
+	return ConfigureChunkEncryptionWithKeyWrapper(ctx, wrapper)
+}
+
+// ConfigureChunkEncryptionWithKeyWrapper enables the encryption of chunk data at rest, with data keys wrapped by
+// |wrapper|, which can delegate to a key management service.
+func ConfigureChunkEncryptionWithKeyWrapper(ctx context.Context, wrapper nbs.KeyWrapper) error {
+	ce, err := nbs.NewChunkEncryption(ctx, wrapper)
+	if err != nil {
+		return err
+	}
+	DBFactories[FileScheme] = FileFactory{Encryption: ce}
+	DBFactories[LocalBSScheme] = LocalBSFactory{Encryption: ce}
+	DBFactories[AWSScheme] = AWSFactory{Encryption: ce}
+	DBFactories[GSScheme] = GSFactory{Encryption: ce}
+	DBFactories[OSSScheme] = OSSFactory{Encryption: ce}
+	DBFactories[AzScheme] = AzureDBFactory{Encryption: ce}
+	DBFactories[OCIScheme] = OCIFactory{Encryption: ce}
+	DBFactories[HTTPScheme] = DoltRemoteFactory{insecure: true, Encryption: ce}
+	DBFactories[HTTPSScheme] = DoltRemoteFactory{insecure: false, Encryption: ce}
+	DBFactories[SSHScheme] = SSHFactory{Encryption: ce}
+	for _, scheme := range []string{GitFileScheme, GitHTTPScheme, GitHTTPSScheme, GitSSHScheme} {
+		DBFactories[scheme] = GitRemoteFactory{Encryption: ce}
+	}
+	return nil
+}
+
+// encryptionKeysFromEnv returns the encryption keys set in the environment, or nil if none are.
+func encryptionKeysFromEnv() ([][]byte, error) {
+	keysStr, keysFile := os.Getenv(dconfig.EnvEncryptionKey), os.Getenv(dconfig.EnvEncryptionKeyFile)
+	if keysStr != "" && keysFile != "" {
+		return nil, fmt.Errorf("only one of %s and %s may be set", dconfig.EnvEncryptionKey, dconfig.EnvEncryptionKeyFile)
+	}
+
+	if keysStr == "" && keysFile == "" {
+		return nil, nil
+	}
+
+	source := dconfig.EnvEncryptionKey
+	if keysFile != "" {
+		data, err := os.ReadFile(keysFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", dconfig.EnvEncryptionKeyFile, err)
+		}
+		keysStr, source = string(data), keysFile
+	}
+
+	keys, err := nbs.ParseLocalKeys(keysStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s: no encryption key found", source)
+	}
+	return keys, nil
+}
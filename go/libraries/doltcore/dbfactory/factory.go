@@ -56,6 +56,9 @@ const (
 
 	OSSScheme = "oss"
 
+	// SSHScheme
+	SSHScheme = "ssh"
+
 	// Git remote dbfactory schemes (Git remotes as Dolt remotes)
 	GitFileScheme  = "git+file"
 	GitHTTPScheme  = "git+http"
@@ -88,6 +91,7 @@ var DBFactories = map[string]DBFactory{
 	LocalBSScheme:  LocalBSFactory{},
 	HTTPScheme:     NewDoltRemoteFactory(true),
 	HTTPSScheme:    NewDoltRemoteFactory(false),
+	SSHScheme:      SSHFactory{},
 	GitFileScheme:  GitRemoteFactory{},
 	GitHTTPScheme:  GitRemoteFactory{},
 	GitHTTPSScheme: GitRemoteFactory{},
@@ -78,6 +78,8 @@ var DoltStatsDir = filepath.Join(DoltDir, StatsDir)
 
 // FileFactory is a DBFactory implementation for creating local filesys backed databases
 type FileFactory struct {
+	// Encryption, if set, encrypts the chunk data of the databases at rest. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
 }
 
 type singletonDB struct {
@@ -202,7 +204,7 @@ func (fact FileFactory) CreateDbNoCache(ctx context.Context, nbf *types.NomsBinF
 	if useJournal && chunkJournalFeatureFlag {
 		// Allow higher layers (e.g. embedded driver) to opt into fail-fast lock behavior instead of
 		// falling back to read-only mode on lock timeout.
-		opts := nbs.JournalingStoreOptions{}
+		opts := nbs.JournalingStoreOptions{Encryption: fact.Encryption}
 		if params != nil {
 			if _, ok := params[FailOnJournalLockTimeoutParam]; ok {
 				opts.FailOnLockTimeout = true
@@ -210,7 +212,7 @@ func (fact FileFactory) CreateDbNoCache(ctx context.Context, nbf *types.NomsBinF
 		}
 		newGenSt, err = nbs.NewLocalJournalingStoreWithOptions(ctx, nbf.VersionString(), path, q, mmapArchiveIndexes, recCb, opts)
 	} else {
-		newGenSt, err = nbs.NewLocalStore(ctx, nbf.VersionString(), path, defaultMemTableSize, q, mmapArchiveIndexes)
+		newGenSt, err = nbs.NewLocalStoreWithEncryption(ctx, nbf.VersionString(), path, defaultMemTableSize, q, mmapArchiveIndexes, fact.Encryption)
 	}
 
 	if err != nil {
@@ -230,7 +232,7 @@ func (fact FileFactory) CreateDbNoCache(ctx context.Context, nbf *types.NomsBinF
 		}
 	}
 
-	oldGenSt, err := nbs.NewLocalStore(ctx, newGenSt.Version(), oldgenPath, defaultMemTableSize, q, mmapArchiveIndexes)
+	oldGenSt, err := nbs.NewLocalStoreWithEncryption(ctx, newGenSt.Version(), oldgenPath, defaultMemTableSize, q, mmapArchiveIndexes, fact.Encryption)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -63,7 +63,10 @@ type GitCacheRootProvider interface {
 // - git+http
 // - git+https
 // - git+ssh
-type GitRemoteFactory struct{}
+type GitRemoteFactory struct {
+	// Encryption, if set, encrypts the chunk data pushed to the git remote. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
+}
 
 var _ DBFactory = GitRemoteFactory{}
 
@@ -142,7 +145,7 @@ func (fact GitRemoteFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFor
 	}
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	cs, err := nbs.NewGitStore(ctx, nbf.VersionString(), cacheRepo, ref, blobstore.GitBlobstoreOptions{RemoteName: remoteName}, defaultMemTableSize, q)
+	cs, err := nbs.NewGitStoreWithEncryption(ctx, nbf.VersionString(), cacheRepo, ref, blobstore.GitBlobstoreOptions{RemoteName: remoteName}, defaultMemTableSize, q, fact.Encryption)
 	if err != nil {
 		return nil, nil, nil, err
 	}
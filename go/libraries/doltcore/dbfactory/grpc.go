@@ -27,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -56,6 +57,8 @@ type GRPCDialProvider interface {
 // GRPC rpcs defined by remoteapis.ChunkStoreServiceClient
 type DoltRemoteFactory struct {
 	insecure bool
+	// Encryption, if set, encrypts the chunk data pushed to the remote server. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
 }
 
 func (fact DoltRemoteFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
@@ -64,7 +67,7 @@ func (fact DoltRemoteFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinF
 
 // NewDoltRemoteFactory creates a DoltRemoteFactory instance using the given GRPCConnectionProvider, and insecure setting
 func NewDoltRemoteFactory(insecure bool) DoltRemoteFactory {
-	return DoltRemoteFactory{insecure}
+	return DoltRemoteFactory{insecure: insecure}
 }
 
 // CreateDB creates a database backed by a remote server that implements the GRPC rpcs defined by
@@ -129,7 +132,7 @@ func (fact DoltRemoteFactory) newChunkStore(ctx context.Context, nbf *types.Noms
 		conn.Close()
 		return nil, fmt.Errorf("could not access dolt url '%s': %w", urlObj.String(), err)
 	}
-	cs = cs.WithHTTPFetcher(cfg.HTTPFetcher)
+	cs = cs.WithHTTPFetcher(cfg.HTTPFetcher).WithChunkEncryption(fact.Encryption)
 	cs.SetFinalizer(conn.Close)
 
 	if _, ok := params[NoCachingParameter]; ok {
@@ -30,6 +30,8 @@ import (
 
 // GSFactory is a DBFactory implementation for creating GCS backed databases
 type GSFactory struct {
+	// Encryption, if set, encrypts the chunk data stored in GCS. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
 }
 
 func (fact GSFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
@@ -48,7 +50,7 @@ func (fact GSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, ur
 
 	bs := blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path)
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	gcsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	gcsStore, err := nbs.NewBSStoreWithEncryption(ctx, nbf.VersionString(), bs, defaultMemTableSize, q, fact.Encryption)
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -63,6 +65,8 @@ func (fact GSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, ur
 
 // LocalBSFactory is a DBFactory implementation for creating a local filesystem blobstore backed databases for testing
 type LocalBSFactory struct {
+	// Encryption, if set, encrypts the chunk data stored in the blobstore. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
 }
 
 func (fact LocalBSFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
@@ -81,7 +85,7 @@ func (fact LocalBSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinForma
 
 	bs := blobstore.NewLocalBlobstore(absPath)
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	bsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	bsStore, err := nbs.NewBSStoreWithEncryption(ctx, nbf.VersionString(), bs, defaultMemTableSize, q, fact.Encryption)
 
 	if err != nil {
 		return nil, nil, nil, err
@@ -30,6 +30,8 @@ import (
 
 // OCIFactory is a DBFactory implementation for creating OCI backed databases
 type OCIFactory struct {
+	// Encryption, if set, encrypts the chunk data stored in OCI Object Storage. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
 }
 
 func (fact OCIFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
@@ -54,7 +56,7 @@ func (fact OCIFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, u
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
 
-	ociStore, err := nbs.NewNoConjoinBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	ociStore, err := nbs.NewNoConjoinBSStoreWithEncryption(ctx, nbf.VersionString(), bs, defaultMemTableSize, q, fact.Encryption)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -58,6 +58,8 @@ type ossCredential struct {
 
 // OSSFactory is a DBFactory implementation for creating OSS backed databases
 type OSSFactory struct {
+	// Encryption, if set, encrypts the chunk data stored in OSS. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
 }
 
 // PrepareDB prepares an OSS backed database
@@ -97,7 +99,7 @@ func (fact OSSFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinForm
 	}
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
-	return nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	return nbs.NewBSStoreWithEncryption(ctx, nbf.VersionString(), bs, defaultMemTableSize, q, fact.Encryption)
 }
 
 func ossConfigFromParams(params map[string]interface{}) ossCredential {
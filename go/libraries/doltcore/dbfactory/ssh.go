@@ -0,0 +1,260 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// SSHRemoteHelperCommand is the dolt command run on the remote host of an ssh remote. It serves the remotesapi
+	// chunk store of the repository at the path of the remote over its stdin and stdout.
+	SSHRemoteHelperCommand = "remote-helper"
+
+	defaultSSHCommand  = "ssh"
+	defaultSSHExecPath = "dolt"
+)
+
+// SSHFactory is a DBFactory implementation for creating databases backed by a chunk store on another host, reached
+// over ssh. For each connection, the factory runs `ssh [user@]host dolt remote-helper <path>` and speaks the
+// remotesapi protocol, and the HTTP protocol used to transfer table files, over the stdin and stdout of the ssh
+// process. Like file remotes, the path of an ssh remote is a directory holding a chunk store, which is created by the
+// first push if it doesn't exist.
+//
+// The ssh command can be overridden with the DOLT_SSH environment variable, and the path of the dolt binary on the
+// remote host with DOLT_SSH_EXEC_PATH.
+type SSHFactory struct {
+	// Encryption, if set, encrypts the chunk data pushed to the remote host. See ConfigureChunkEncryption.
+	Encryption *nbs.ChunkEncryption
+}
+
+func (fact SSHFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	// the remote helper creates the chunk store on the remote host when it is first written to
+	return nil
+}
+
+// CreateDB creates a database backed by the chunk store at the path of |urlObj| on its host.
+func (fact SSHFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	args, err := sshCommandArgs(urlObj)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	dial := func(ctx context.Context) (net.Conn, error) {
+		return dialSSH(args)
+	}
+
+	conn, err := grpc.Dial(urlObj.Host,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return dial(ctx)
+		}),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(128*1024*1024)),
+		grpc.WithChainUnaryInterceptor(remotestorage.RetryingUnaryClientInterceptor))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Table files are transferred over HTTP/2 without TLS, on connections of their own.
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, _, _ string, _ *tls.Config) (net.Conn, error) {
+			return dial(ctx)
+		},
+	}
+
+	csClient := remotesapi.NewChunkStoreServiceClient(conn)
+	cs, err := remotestorage.NewDoltChunkStoreFromPath(ctx, nbf, urlObj.Path, urlObj.Host, false, csClient)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("could not access dolt url '%s': %w", urlObj.String(), err)
+	}
+	cs = cs.WithHTTPFetcher(&http.Client{Transport: transport}).WithChunkEncryption(fact.Encryption)
+	cs.SetFinalizer(func() error {
+		transport.CloseIdleConnections()
+		return conn.Close()
+	})
+
+	if _, ok := params[NoCachingParameter]; ok {
+		cs = cs.WithNoopChunkCache()
+	}
+
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+// sshCommandArgs returns the command line that runs the remote helper for the repository at |urlObj|.
+func sshCommandArgs(urlObj *url.URL) ([]string, error) {
+	host := urlObj.Hostname()
+	if host == "" {
+		return nil, fmt.Errorf("ssh remote url '%s' has no host", urlObj.String())
+	}
+	if strings.HasPrefix(host, "-") || strings.HasPrefix(urlObj.User.Username(), "-") {
+		// these would be interpreted as options of the ssh command
+		return nil, fmt.Errorf("invalid ssh remote url '%s'", urlObj.String())
+	}
+
+	repoPath := urlObj.Path
+	if strings.HasPrefix(repoPath, "/~") {
+		// ssh://host/~/path is relative to the home directory of the user on the remote host
+		repoPath = repoPath[1:]
+	}
+	if repoPath == "" || repoPath == "/" {
+		return nil, fmt.Errorf("ssh remote url '%s' has no path", urlObj.String())
+	}
+
+	sshCmd := strings.Fields(os.Getenv(dconfig.EnvSSH))
+	if len(sshCmd) == 0 {
+		sshCmd = []string{defaultSSHCommand}
+	}
+	if port := urlObj.Port(); port != "" {
+		sshCmd = append(sshCmd, "-p", port)
+	}
+	if urlObj.User != nil && urlObj.User.Username() != "" {
+		host = urlObj.User.Username() + "@" + host
+	}
+
+	execPath := os.Getenv(dconfig.EnvSSHExecPath)
+	if execPath == "" {
+		execPath = defaultSSHExecPath
+	}
+
+	// the remote command is run by the shell of the remote user
+	remoteCmd := fmt.Sprintf("%s %s %s", shellQuote(execPath), SSHRemoteHelperCommand, shellQuote(path.Clean(repoPath)))
+	return append(sshCmd, host, remoteCmd), nil
+}
+
+// shellQuote quotes |s| for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// dialSSH starts the command |args| and returns a connection over its stdin and stdout. The standard error of the
+// command, which is where ssh reports authentication and connection failures, is passed through.
+func dialSSH(args []string) (net.Conn, error) {
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec // the ssh command is configured by the user
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", args[0], err)
+	}
+	return &sshConn{cmd: cmd, r: stdout, w: stdin}, nil
+}
+
+// sshConn is a net.Conn over the stdin and stdout of an ssh process. Deadlines are not supported.
+type sshConn struct {
+	cmd *exec.Cmd
+	r   io.ReadCloser
+	w   io.WriteCloser
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+var _ net.Conn = (*sshConn)(nil)
+
+func (c *sshConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *sshConn) Write(b []byte) (int, error) {
+	return c.w.Write(b)
+}
+
+// Close closes the stdin of the ssh process, which ends the remote helper, and waits for the process to exit.
+func (c *sshConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.w.Close()
+		done := make(chan error, 1)
+		go func() {
+			done <- c.cmd.Wait()
+		}()
+		select {
+		case err := <-done:
+			var exitErr *exec.ExitError
+			if err != nil && !errors.As(err, &exitErr) {
+				c.closeErr = err
+			}
+		case <-time.After(10 * time.Second):
+			c.cmd.Process.Kill()
+			<-done
+		}
+	})
+	return c.closeErr
+}
+
+func (c *sshConn) LocalAddr() net.Addr {
+	return sshAddr(c.cmd.Path)
+}
+
+func (c *sshConn) RemoteAddr() net.Addr {
+	return sshAddr(strings.Join(c.cmd.Args[1:], " "))
+}
+
+func (c *sshConn) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (c *sshConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (c *sshConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+type sshAddr string
+
+func (a sshAddr) Network() string {
+	return SSHScheme
+}
+
+func (a sshAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+)
+
+func TestSSHCommandArgs(t *testing.T) {
+	t.Setenv(dconfig.EnvSSH, "")
+	t.Setenv(dconfig.EnvSSHExecPath, "")
+
+	tests := []struct {
+		url      string
+		expected []string
+	}{
+		{
+			url:      "ssh://example.com/srv/dolt/db",
+			expected: []string{"ssh", "example.com", "'dolt' remote-helper '/srv/dolt/db'"},
+		},
+		{
+			url:      "ssh://me@example.com:2222/srv/dolt/db/",
+			expected: []string{"ssh", "-p", "2222", "me@example.com", "'dolt' remote-helper '/srv/dolt/db'"},
+		},
+		{
+			url:      "ssh://example.com/~/db",
+			expected: []string{"ssh", "example.com", "'dolt' remote-helper '~/db'"},
+		},
+		{
+			url:      "ssh://example.com/it's",
+			expected: []string{"ssh", "example.com", `'dolt' remote-helper '/it'\''s'`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			u, err := earl.Parse(test.url)
+			require.NoError(t, err)
+			args, err := sshCommandArgs(u)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, args)
+		})
+	}
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(dconfig.EnvSSH, "ssh -i key -o BatchMode=yes")
+		t.Setenv(dconfig.EnvSSHExecPath, "/opt/dolt/bin/dolt")
+		u, err := earl.Parse("ssh://example.com/db")
+		require.NoError(t, err)
+		args, err := sshCommandArgs(u)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ssh", "-i", "key", "-o", "BatchMode=yes", "example.com", "'/opt/dolt/bin/dolt' remote-helper '/db'"}, args)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{"ssh://example.com", "ssh://example.com/", "ssh://-oProxyCommand=x/db"} {
+			u, err := earl.Parse(s)
+			require.NoError(t, err)
+			_, err = sshCommandArgs(u)
+			assert.Error(t, err, s)
+		}
+	})
+}
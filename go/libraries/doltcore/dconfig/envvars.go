@@ -45,6 +45,14 @@ const (
 	EnvDbNameReplace                 = "DOLT_DBNAME_REPLACE"
 	EnvDoltRootHost                  = "DOLT_ROOT_HOST"
 	EnvDoltRootPassword              = "DOLT_ROOT_PASSWORD"
+	EnvSSH                           = "DOLT_SSH"
+	EnvSSHExecPath                   = "DOLT_SSH_EXEC_PATH"
+
+	// Base64 encoded 256-bit keys encrypting chunk data at rest, separated by commas or whitespace. The first key
+	// encrypts new data, the others are only used to read data written before a key rotation. At most one of these
+	// may be set.
+	EnvEncryptionKey     = "DOLT_ENCRYPTION_KEY"
+	EnvEncryptionKeyFile = "DOLT_ENCRYPTION_KEY_FILE"
 
 	// If set, must be "kill_connections" or "session_aware"
 	// Will go away after session_aware is made default-and-only.
@@ -67,3 +67,34 @@ func TestMatchTableDeltas(t *testing.T) {
 		require.ElementsMatch(t, expected, received)
 	}
 }
+
+func TestRenamedTableOverlap(t *testing.T) {
+	before := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 10, types.IntKind, true),
+		schema.NewColumn("a", 11, types.StringKind, false),
+		schema.NewColumn("b", 12, types.StringKind, false),
+	))
+	// the table and its column |a| were renamed, and |b| was dropped
+	after := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 10, types.IntKind, true),
+		schema.NewColumn("renamed_a", 11, types.StringKind, false),
+		schema.NewColumn("c", 13, types.StringKind, false),
+	))
+	require.Equal(t, 2, renamedTableOverlap(before, after))
+
+	differentPk := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("id", 20, types.IntKind, true),
+		schema.NewColumn("a", 11, types.StringKind, false),
+		schema.NewColumn("b", 12, types.StringKind, false),
+	))
+	require.Equal(t, 0, renamedTableOverlap(before, differentPk))
+
+	// a single shared tag isn't enough to match tables with many columns
+	unrelated := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 10, types.IntKind, true),
+		schema.NewColumn("x", 21, types.StringKind, false),
+		schema.NewColumn("y", 22, types.StringKind, false),
+		schema.NewColumn("z", 23, types.StringKind, false),
+	))
+	require.Equal(t, 0, renamedTableOverlap(before, unrelated))
+}
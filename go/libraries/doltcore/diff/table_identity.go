@@ -0,0 +1,113 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+)
+
+// ResolveTableAcrossRenames returns the name the table |name|, with schema |toSch| in |toRoot|, had in |fromRoot|, an
+// older root value. A table with the same name is always a match. Otherwise, the table it was renamed from is found by
+// tag continuity: renaming a table or its columns keeps the column tags, so the table of |fromRoot| sharing the most
+// column tags with |toSch| is chosen, as long as it has the same primary key tags and shares at least half of its
+// columns. Tables of |fromRoot| which still exist under the same name in |toRoot| were not renamed and are never
+// matched. |toRoot| may be nil when it isn't known, in which case every table of |fromRoot| is considered. Returns
+// false if no table of |fromRoot| matches. Renames aren't recorded, so this is a heuristic, described on
+// renamedTableOverlap.
+func ResolveTableAcrossRenames(ctx context.Context, fromRoot, toRoot doltdb.RootValue, name doltdb.TableName, toSch schema.Schema) (doltdb.TableName, bool, error) {
+	_, correctedName, ok, err := doltdb.GetTableInsensitive(ctx, fromRoot, name)
+	if err != nil {
+		return doltdb.TableName{}, false, err
+	}
+	if ok {
+		return doltdb.TableName{Name: correctedName, Schema: name.Schema}, true, nil
+	}
+	if toSch == nil {
+		return doltdb.TableName{}, false, nil
+	}
+
+	names, err := fromRoot.GetTableNames(ctx, name.Schema, false)
+	if err != nil {
+		return doltdb.TableName{}, false, err
+	}
+
+	var renamed doltdb.TableName
+	most := 0
+	for _, n := range names {
+		if doltdb.HasDoltPrefix(n) {
+			continue
+		}
+		tblName := doltdb.TableName{Name: n, Schema: name.Schema}
+		if toRoot != nil {
+			stillExists, err := toRoot.HasTable(ctx, tblName)
+			if err != nil {
+				return doltdb.TableName{}, false, err
+			}
+			if stillExists {
+				continue
+			}
+		}
+
+		tbl, ok, err := fromRoot.GetTable(ctx, tblName)
+		if err != nil {
+			return doltdb.TableName{}, false, err
+		}
+		if !ok {
+			continue
+		}
+		fromSch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return doltdb.TableName{}, false, err
+		}
+
+		if overlap := renamedTableOverlap(fromSch, toSch); overlap > most {
+			renamed, most = tblName, overlap
+		}
+	}
+
+	return renamed, most > 0, nil
+}
+
+// renamedTableOverlap returns the number of column tags shared by |from| and |to| if |to| could be |from| after a
+// rename, or 0 otherwise. A column's tag is derived from a hash of the table name, the column name and the column
+// types of the table when the column is added, and lies below schema.ReservedTagMin (2^50). Tables created
+// independently under different names therefore rarely share tags, but tables created with the same name and columns
+// get the same tags, and changing the type of a column can give it a new one. Requiring the same primary key tags
+// and an overlap of at least half of the columns of the smaller schema keeps a table sharing only a few tags from
+// being mistaken for a rename, while following a table whose other columns were added, dropped or retyped.
+func renamedTableOverlap(from, to schema.Schema) int {
+	fromPks := set.NewUint64Set(from.GetPKCols().Tags)
+	toPks := set.NewUint64Set(to.GetPKCols().Tags)
+	if fromPks.Size() != toPks.Size() || fromPks.Intersection(toPks).Size() != fromPks.Size() {
+		return 0
+	}
+
+	fromTags := set.NewUint64Set(from.GetAllCols().Tags)
+	toTags := set.NewUint64Set(to.GetAllCols().Tags)
+	overlap := fromTags.Intersection(toTags).Size()
+
+	smaller := fromTags.Size()
+	if toTags.Size() < smaller {
+		smaller = toTags.Size()
+	}
+	if overlap == 0 || overlap*2 < smaller {
+		return 0
+	}
+	return overlap
+}
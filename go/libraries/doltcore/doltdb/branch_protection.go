@@ -0,0 +1,193 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// branchApprovalsTupleKey is the key of the tuple holding every approval recorded in dolt_branch_approvals. Approvals
+// are not versioned, as recording one must not change the commit being approved.
+const branchApprovalsTupleKey = "branch_approvals"
+
+// BranchProtectionRule is a single entry in the dolt_branch_protection system table.
+type BranchProtectionRule struct {
+	// BranchPattern selects the branches the rule protects. Patterns are matched like the branch column of
+	// dolt_branch_control: `%` matches any sequence of characters, `_` matches any single character, `\` escapes them,
+	// and patterns are matched case-insensitively.
+	BranchPattern string
+	// BlockDirectCommits requires changes to the branch to be merged from another branch.
+	BlockDirectCommits bool
+	// RequiredWorkflows are the dolt ci workflows that must have passed on a commit before it is merged.
+	RequiredWorkflows []string
+	// RequiredApprovals is the number of approvals a commit needs before it is merged.
+	RequiredApprovals int
+	// AllowForcePush allows the branch to be moved to a commit that does not descend from its head, or deleted.
+	AllowForcePush bool
+}
+
+// Matches returns whether this rule protects the branch named |branch|.
+func (r BranchProtectionRule) Matches(branch string) bool {
+	return branch_control.MatchesBranch(r.BranchPattern, branch)
+}
+
+// HasRequirements returns whether this rule requires workflows or approvals for merged commits.
+func (r BranchProtectionRule) HasRequirements() bool {
+	return len(r.RequiredWorkflows) > 0 || r.RequiredApprovals > 0
+}
+
+// GetBranchProtectionKey is a function that reads the branch_pattern column from dolt_branch_protection. This is used
+// to handle the Doltgres extended string type.
+var GetBranchProtectionKey = getBranchProtectionKey
+
+// GetBranchProtectionValue is a function that reads the remaining columns from dolt_branch_protection. This is used
+// to handle the Doltgres extended string type.
+var GetBranchProtectionValue = getBranchProtectionValue
+
+func getBranchProtectionKey(_ context.Context, keyDesc *val.TupleDesc, keyTuple val.Tuple) (string, error) {
+	pattern, ok := keyDesc.GetString(0, keyTuple)
+	if !ok {
+		return "", fmt.Errorf("failed to read branch_pattern from %s", BranchProtectionTableName)
+	}
+	return pattern, nil
+}
+
+func getBranchProtectionValue(_ context.Context, valDesc *val.TupleDesc, valTuple val.Tuple) (rule BranchProtectionRule, err error) {
+	blockDirect, ok := valDesc.GetInt8(0, valTuple)
+	if !ok {
+		return rule, fmt.Errorf("failed to read block_direct_commits from %s", BranchProtectionTableName)
+	}
+	// required_workflows is nullable
+	workflows, _ := valDesc.GetString(1, valTuple)
+	approvals, ok := valDesc.GetUint32(2, valTuple)
+	if !ok {
+		return rule, fmt.Errorf("failed to read required_approvals from %s", BranchProtectionTableName)
+	}
+	allowForce, ok := valDesc.GetInt8(3, valTuple)
+	if !ok {
+		return rule, fmt.Errorf("failed to read allow_force_push from %s", BranchProtectionTableName)
+	}
+
+	rule.BlockDirectCommits = blockDirect != 0
+	for _, workflow := range strings.Split(workflows, ",") {
+		if workflow = strings.TrimSpace(workflow); workflow != "" {
+			rule.RequiredWorkflows = append(rule.RequiredWorkflows, workflow)
+		}
+	}
+	rule.RequiredApprovals = int(approvals)
+	rule.AllowForcePush = allowForce != 0
+	return rule, nil
+}
+
+// GetBranchProtectionRules returns every rule declared in dolt_branch_protection on |root| that protects the branch
+// named |branch|. If dolt_branch_protection does not exist, no rules are returned.
+func GetBranchProtectionRules(ctx context.Context, root RootValue, branch string) ([]BranchProtectionRule, error) {
+	table, found, err := root.GetTable(ctx, TableName{Name: GetBranchProtectionTableName()})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		// dolt_branch_protection doesn't exist, so no branch is protected.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(index)
+	keyDesc, valDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []BranchProtectionRule
+	for {
+		keyTuple, valTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		pattern, err := GetBranchProtectionKey(ctx, keyDesc, keyTuple)
+		if err != nil {
+			return nil, err
+		}
+		rule, err := GetBranchProtectionValue(ctx, valDesc, valTuple)
+		if err != nil {
+			return nil, err
+		}
+		rule.BranchPattern = pattern
+		if rule.Matches(branch) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+// IsBranchProtectionTuple returns whether |key| is the key of a tuple holding the approvals or the dolt ci results that
+// branch protection rules require. They are only written by the server enforcing the rules, so a push must not change
+// them.
+func IsBranchProtectionTuple(key string) bool {
+	return key == branchApprovalsTupleKey || strings.HasPrefix(key, ciResultsTupleKeyPrefix)
+}
+
+// BranchApproval is a single entry in the dolt_branch_approvals system table, recording that a user approved a commit
+// for merging into protected branches.
+type BranchApproval struct {
+	Commit     string    `json:"commit"`
+	Approver   string    `json:"approver"`
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+// GetBranchApprovals returns every approval recorded in this database.
+func (ddb *DoltDB) GetBranchApprovals(ctx context.Context) ([]BranchApproval, error) {
+	data, ok, err := ddb.GetTuple(ctx, branchApprovalsTupleKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var approvals []BranchApproval
+	if err = json.Unmarshal(data, &approvals); err != nil {
+		return nil, fmt.Errorf("failed to read branch approvals: %w", err)
+	}
+	return approvals, nil
+}
+
+// SetBranchApprovals replaces the approvals recorded in this database with |approvals|.
+func (ddb *DoltDB) SetBranchApprovals(ctx context.Context, approvals []BranchApproval) error {
+	data, err := json.Marshal(approvals)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, branchApprovalsTupleKey, data)
+}
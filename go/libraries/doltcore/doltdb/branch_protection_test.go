@@ -0,0 +1,49 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchProtectionRuleMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		branch  string
+		matches bool
+	}{
+		{"main", "main", true},
+		{"main", "MAIN", true},
+		{"main", "main2", false},
+		{"%", "anything", true},
+		{"%", "", true},
+		{"release/%", "release/1.0", true},
+		{"release/%", "releases/1.0", false},
+		{"release_", "release1", true},
+		{"release_", "release", false},
+		{"%fix%", "hotfix/abc", true},
+		{"%fix%", "feature", false},
+		{"a%b%c", "aXbYc", true},
+		{"a%b%c", "aXcYb", false},
+		{`release\_1`, "release_1", true},
+		{`release\_1`, "releasex1", false},
+	}
+	for _, test := range tests {
+		rule := BranchProtectionRule{BranchPattern: test.pattern}
+		assert.Equal(t, test.matches, rule.Matches(test.branch), "pattern %q, branch %q", test.pattern, test.branch)
+	}
+}
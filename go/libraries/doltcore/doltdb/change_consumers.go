@@ -0,0 +1,147 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// changeConsumersTupleKey is the key of the tuple holding the positions of the consumers of dolt_changes_since. Like
+// the reflog, the positions are not versioned.
+const changeConsumersTupleKey = "change_consumers"
+
+// ErrChangeConsumerNotFound is returned when a change consumer doesn't exist.
+var ErrChangeConsumerNotFound = errors.New("change consumer not found")
+
+// ErrChangeConsumerExists is returned when adding a change consumer that already exists.
+var ErrChangeConsumerExists = errors.New("change consumer already exists")
+
+// ChangeConsumer is a named consumer of the row changes committed to a branch, whose position in the stream of
+// changes is kept by the server. Cursor is the cursor of the last change the consumer processed, as returned by
+// dolt_changes_since, and is empty for a consumer that hasn't processed any change yet.
+type ChangeConsumer struct {
+	Name      string    `json:"name"`
+	Branch    string    `json:"branch"`
+	Cursor    string    `json:"cursor"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// changeConsumersMu serializes the updates of the change consumers of every database.
+var changeConsumersMu sync.Mutex
+
+func (ddb *DoltDB) getChangeConsumers(ctx context.Context) (map[string]ChangeConsumer, error) {
+	consumers := make(map[string]ChangeConsumer)
+	data, ok, err := ddb.GetTuple(ctx, changeConsumersTupleKey)
+	if err != nil || !ok {
+		return consumers, err
+	}
+	if err = json.Unmarshal(data, &consumers); err != nil {
+		return nil, fmt.Errorf("failed to read change consumers: %w", err)
+	}
+	return consumers, nil
+}
+
+func (ddb *DoltDB) setChangeConsumers(ctx context.Context, consumers map[string]ChangeConsumer) error {
+	data, err := json.Marshal(consumers)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, changeConsumersTupleKey, data)
+}
+
+// GetChangeConsumers returns the change consumers of this database, ordered by name.
+func (ddb *DoltDB) GetChangeConsumers(ctx context.Context) ([]ChangeConsumer, error) {
+	consumers, err := ddb.getChangeConsumers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]ChangeConsumer, 0, len(consumers))
+	for _, c := range consumers {
+		res = append(res, c)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// GetChangeConsumer returns the change consumer named |name|, and whether it exists.
+func (ddb *DoltDB) GetChangeConsumer(ctx context.Context, name string) (ChangeConsumer, bool, error) {
+	consumers, err := ddb.getChangeConsumers(ctx)
+	if err != nil {
+		return ChangeConsumer{}, false, err
+	}
+	c, ok := consumers[name]
+	return c, ok, nil
+}
+
+// AddChangeConsumer adds the change consumer |consumer|, returning ErrChangeConsumerExists if a consumer with the
+// same name exists.
+func (ddb *DoltDB) AddChangeConsumer(ctx context.Context, consumer ChangeConsumer) error {
+	changeConsumersMu.Lock()
+	defer changeConsumersMu.Unlock()
+
+	consumers, err := ddb.getChangeConsumers(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := consumers[consumer.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrChangeConsumerExists, consumer.Name)
+	}
+	consumer.UpdatedAt = time.Now()
+	consumers[consumer.Name] = consumer
+	return ddb.setChangeConsumers(ctx, consumers)
+}
+
+// SetChangeConsumerCursor records |cursor| as the position of the change consumer named |name|.
+func (ddb *DoltDB) SetChangeConsumerCursor(ctx context.Context, name, cursor string) error {
+	changeConsumersMu.Lock()
+	defer changeConsumersMu.Unlock()
+
+	consumers, err := ddb.getChangeConsumers(ctx)
+	if err != nil {
+		return err
+	}
+	c, ok := consumers[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrChangeConsumerNotFound, name)
+	}
+	c.Cursor = cursor
+	c.UpdatedAt = time.Now()
+	consumers[name] = c
+	return ddb.setChangeConsumers(ctx, consumers)
+}
+
+// RemoveChangeConsumer removes the change consumer named |name|.
+func (ddb *DoltDB) RemoveChangeConsumer(ctx context.Context, name string) error {
+	changeConsumersMu.Lock()
+	defer changeConsumersMu.Unlock()
+
+	consumers, err := ddb.getChangeConsumers(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := consumers[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrChangeConsumerNotFound, name)
+	}
+	delete(consumers, name)
+	return ddb.setChangeConsumers(ctx, consumers)
+}
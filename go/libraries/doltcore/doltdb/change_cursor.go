@@ -0,0 +1,104 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const changeCursorSeparator = ":"
+
+// ChangeCursor is a position in the stream of row changes committed to a branch. Every change returned by
+// dolt_changes_since carries the cursor that resumes the stream right after it, so a consumer only needs to persist the
+// cursor of the last change it processed.
+//
+// A cursor is written as the hash of a commit, optionally followed by a colon and the 1-based ordinal of a change
+// within that commit. A cursor without an ordinal points to the end of its commit, so that any commit hash can be
+// used to start reading the changes made after it. The empty cursor points to the start of the branch's history.
+type ChangeCursor struct {
+	Commit hash.Hash
+	// Seq is the ordinal of a change within |Commit|, or 0 for the end of |Commit|.
+	Seq uint64
+}
+
+// ParseChangeCursor parses a cursor previously returned by ChangeCursor.String.
+func ParseChangeCursor(s string) (ChangeCursor, error) {
+	if s == "" {
+		return ChangeCursor{}, nil
+	}
+
+	commitStr, seqStr, hasSeq := strings.Cut(s, changeCursorSeparator)
+	h, ok := hash.MaybeParse(commitStr)
+	if !ok {
+		return ChangeCursor{}, fmt.Errorf("invalid change cursor '%s'", s)
+	}
+	if !hasSeq {
+		return ChangeCursor{Commit: h}, nil
+	}
+
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil || seq == 0 {
+		return ChangeCursor{}, fmt.Errorf("invalid change cursor '%s'", s)
+	}
+	return ChangeCursor{Commit: h, Seq: seq}, nil
+}
+
+// ResolveChangeCursor parses |s| as a cursor returned by ChangeCursor.String, falling back to resolving it as a
+// revision relative to |headRef|, in which case the cursor points to the end of the resolved commit.
+func ResolveChangeCursor(ctx context.Context, ddb *DoltDB, headRef ref.DoltRef, s string) (ChangeCursor, error) {
+	cursor, parseErr := ParseChangeCursor(s)
+	if parseErr == nil {
+		return cursor, nil
+	}
+
+	cs, err := NewCommitSpec(s)
+	if err != nil {
+		return ChangeCursor{}, parseErr
+	}
+	optCmt, err := ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return ChangeCursor{}, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return ChangeCursor{}, ErrGhostCommitEncountered
+	}
+	h, err := cm.HashOf()
+	if err != nil {
+		return ChangeCursor{}, err
+	}
+	return ChangeCursor{Commit: h}, nil
+}
+
+// IsEmpty returns whether this cursor points to the start of history.
+func (c ChangeCursor) IsEmpty() bool {
+	return c.Commit.IsEmpty()
+}
+
+func (c ChangeCursor) String() string {
+	if c.IsEmpty() {
+		return ""
+	}
+	if c.Seq == 0 {
+		return c.Commit.String()
+	}
+	return c.Commit.String() + changeCursorSeparator + strconv.FormatUint(c.Seq, 10)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ciResultsTupleKeyPrefix prefixes the keys of the tuples holding the results of the dolt ci workflows run on each
+// commit. Like approvals, results are not versioned.
+const ciResultsTupleKeyPrefix = "ci_results/"
+
+// CIWorkflowStatus is the outcome of a run of a dolt ci workflow.
+type CIWorkflowStatus string
+
+const (
+	// CIWorkflowPassed is the status of a workflow run in which every job passed.
+	CIWorkflowPassed CIWorkflowStatus = "passed"
+	// CIWorkflowFailed is the status of a workflow run in which any job failed.
+	CIWorkflowFailed CIWorkflowStatus = "failed"
+	// CIWorkflowError is the status of a workflow run that could not be completed, for example because the workflow
+	// could not be loaded.
+	CIWorkflowError CIWorkflowStatus = "error"
+)
+
+// CIWorkflowResult is the result of the latest run of a dolt ci workflow on a commit.
+type CIWorkflowResult struct {
+	Workflow   string           `json:"workflow"`
+	Status     CIWorkflowStatus `json:"status"`
+	RecordedAt time.Time        `json:"recorded_at"`
+}
+
+func ciResultsTupleKey(commit hash.Hash) string {
+	return ciResultsTupleKeyPrefix + commit.String()
+}
+
+// GetCIWorkflowResults returns the results recorded for the dolt ci workflows run on |commit|.
+func (ddb *DoltDB) GetCIWorkflowResults(ctx context.Context, commit hash.Hash) ([]CIWorkflowResult, error) {
+	data, ok, err := ddb.GetTuple(ctx, ciResultsTupleKey(commit))
+	if err != nil || !ok {
+		return nil, err
+	}
+	var results []CIWorkflowResult
+	if err = json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to read ci results for commit %s: %w", commit.String(), err)
+	}
+	return results, nil
+}
+
+// RecordCIWorkflowResult records |result| for |commit|, replacing any earlier result of the same workflow.
+func (ddb *DoltDB) RecordCIWorkflowResult(ctx context.Context, commit hash.Hash, result CIWorkflowResult) error {
+	results, err := ddb.GetCIWorkflowResults(ctx, commit)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range results {
+		if results[i].Workflow == result.Workflow {
+			results[i] = result
+			replaced = true
+		}
+	}
+	if !replaced {
+		results = append(results, result)
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, ciResultsTupleKey(commit), data)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ciRunsTupleKey is the key of the tuple holding the history of the dolt ci workflows run by a sql-server. Like
+// workflow results, the history is not versioned.
+const ciRunsTupleKey = "ci_runs"
+
+// MaxCIRuns is the number of workflow runs kept in the history of a database. The oldest runs are removed first.
+const MaxCIRuns = 1000
+
+// CIRun is a run of a dolt ci workflow, triggered by an update to a branch.
+type CIRun struct {
+	ID         int64            `json:"id"`
+	Workflow   string           `json:"workflow"`
+	Event      string           `json:"event"`
+	Branch     string           `json:"branch"`
+	Commit     string           `json:"commit"`
+	Status     CIWorkflowStatus `json:"status"`
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	Error      string           `json:"error,omitempty"`
+	Steps      []CIStepResult   `json:"steps,omitempty"`
+}
+
+// CIStepResult is the result of a single step of a CIRun.
+type CIStepResult struct {
+	Job         string              `json:"job"`
+	Step        string              `json:"step"`
+	Status      CIWorkflowStatus    `json:"status"`
+	StartedAt   time.Time           `json:"started_at"`
+	FinishedAt  time.Time           `json:"finished_at"`
+	Error       string              `json:"error,omitempty"`
+	FailingRows []map[string]string `json:"failing_rows,omitempty"`
+}
+
+type ciRunHistory struct {
+	NextID int64   `json:"next_id"`
+	Runs   []CIRun `json:"runs"`
+}
+
+// ciRunsMu serializes the updates of the run history of every database.
+var ciRunsMu sync.Mutex
+
+func (ddb *DoltDB) getCIRunHistory(ctx context.Context) (ciRunHistory, error) {
+	history := ciRunHistory{NextID: 1}
+	data, ok, err := ddb.GetTuple(ctx, ciRunsTupleKey)
+	if err != nil || !ok {
+		return history, err
+	}
+	if err = json.Unmarshal(data, &history); err != nil {
+		return history, fmt.Errorf("failed to read ci run history: %w", err)
+	}
+	return history, nil
+}
+
+// GetCIRuns returns the history of the dolt ci workflows run on this database, oldest first.
+func (ddb *DoltDB) GetCIRuns(ctx context.Context) ([]CIRun, error) {
+	history, err := ddb.getCIRunHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return history.Runs, nil
+}
+
+// AddCIRun adds |run| to the history of this database with a new ID, which is returned. Once the history holds
+// MaxCIRuns runs, the oldest run is removed.
+func (ddb *DoltDB) AddCIRun(ctx context.Context, run CIRun) (int64, error) {
+	ciRunsMu.Lock()
+	defer ciRunsMu.Unlock()
+
+	history, err := ddb.getCIRunHistory(ctx)
+	if err != nil {
+		return 0, err
+	}
+	run.ID = history.NextID
+	history.NextID++
+	history.Runs = append(history.Runs, run)
+	if len(history.Runs) > MaxCIRuns {
+		history.Runs = history.Runs[len(history.Runs)-MaxCIRuns:]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return 0, err
+	}
+	if err = ddb.SetTuple(ctx, ciRunsTupleKey, data); err != nil {
+		return 0, err
+	}
+	return run.ID, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ColumnMaskStrategy names the way a column mask hides the values of a column.
+type ColumnMaskStrategy string
+
+const (
+	// ColumnMaskNull replaces every value with NULL.
+	ColumnMaskNull ColumnMaskStrategy = "null"
+	// ColumnMaskHash replaces string values with their hex encoded SHA-256 hash, salted with the optional argument.
+	// Values of other types are replaced with NULL.
+	ColumnMaskHash ColumnMaskStrategy = "hash"
+	// ColumnMaskPartial reveals the number of trailing characters given by the argument and replaces the others with
+	// '*'. Values of non-string types are replaced with NULL.
+	ColumnMaskPartial ColumnMaskStrategy = "partial"
+	// ColumnMaskExpression replaces values with the result of the expression given by the argument, which may refer
+	// to any column of the table.
+	ColumnMaskExpression ColumnMaskStrategy = "expression"
+)
+
+// ColumnMaskStrategies is the list of every supported column mask strategy.
+var ColumnMaskStrategies = []ColumnMaskStrategy{
+	ColumnMaskNull,
+	ColumnMaskHash,
+	ColumnMaskPartial,
+	ColumnMaskExpression,
+}
+
+// ColumnMask is a single entry in the dolt_column_masks system table.
+type ColumnMask struct {
+	TableName  string
+	ColumnName string
+	Strategy   ColumnMaskStrategy
+	Argument   string
+}
+
+// GetColumnMaskKey is a function that reads the table_name and column_name columns from dolt_column_masks. This is
+// used to handle the Doltgres extended string type.
+var GetColumnMaskKey = getColumnMaskKey
+
+// GetColumnMaskValue is a function that reads the strategy and argument columns from dolt_column_masks. This is used
+// to handle the Doltgres extended string type.
+var GetColumnMaskValue = getColumnMaskValue
+
+func getColumnMaskKey(_ context.Context, keyDesc *val.TupleDesc, keyTuple val.Tuple) (tableName string, columnName string, err error) {
+	tableName, ok := keyDesc.GetString(0, keyTuple)
+	if !ok {
+		return "", "", fmt.Errorf("failed to read table_name from %s", ColumnMasksTableName)
+	}
+	columnName, ok = keyDesc.GetString(1, keyTuple)
+	if !ok {
+		return "", "", fmt.Errorf("failed to read column_name from %s", ColumnMasksTableName)
+	}
+	return tableName, columnName, nil
+}
+
+func getColumnMaskValue(ctx context.Context, valDesc *val.TupleDesc, valTuple val.Tuple, ns tree.NodeStore) (strategy string, argument string, err error) {
+	strategy, ok := valDesc.GetString(0, valTuple)
+	if !ok {
+		return "", "", fmt.Errorf("failed to read strategy from %s", ColumnMasksTableName)
+	}
+	// argument is nullable
+	argument, _, err = readTextField(ctx, valDesc, 1, valTuple, ns)
+	if err != nil {
+		return "", "", err
+	}
+	return strategy, argument, nil
+}
+
+// GetColumnMasks returns every mask declared in dolt_column_masks on |root| for the columns of the table
+// |tableName|. Table names are matched case-insensitively. If dolt_column_masks does not exist, no masks are
+// returned.
+func GetColumnMasks(ctx context.Context, root RootValue, tableName TableName) ([]ColumnMask, error) {
+	masksTableName := TableName{Name: GetColumnMasksTableName(), Schema: tableName.Schema}
+	table, found, err := root.GetTable(ctx, masksTableName)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		// dolt_column_masks doesn't exist, so no column is masked.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(index)
+	keyDesc, valDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var masks []ColumnMask
+	for {
+		keyTuple, valTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		maskTable, columnName, err := GetColumnMaskKey(ctx, keyDesc, keyTuple)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(maskTable, tableName.Name) {
+			continue
+		}
+
+		strategy, argument, err := GetColumnMaskValue(ctx, valDesc, valTuple, m.NodeStore())
+		if err != nil {
+			return nil, err
+		}
+		masks = append(masks, ColumnMask{
+			TableName:  maskTable,
+			ColumnName: columnName,
+			Strategy:   ColumnMaskStrategy(strings.ToLower(strategy)),
+			Argument:   argument,
+		})
+	}
+
+	return masks, nil
+}
@@ -150,6 +150,64 @@ func (cmItr *commitItr[C]) Next(ctx C) (hash.Hash, *OptionalCommit, *datas.Commi
 	return next, &OptionalCommit{cmItr.curr, next}, nil, 0, nil
 }
 
+// FirstParentCommitItr is a CommitItr which walks the first-parent history of a commit, starting with the commit
+// itself. Commits reachable only through the other parents of a merge are skipped, so the commits returned are the
+// ones made on, or merged into, the branch the walk started from, newest first. If a ghost commit is encountered, it
+// is returned with a nil commit and iteration stops.
+type FirstParentCommitItr[C Context] struct {
+	ddb     *DoltDB
+	start   *Commit
+	curr    *Commit
+	started bool
+}
+
+var _ CommitItr[context.Context] = (*FirstParentCommitItr[context.Context])(nil)
+
+func NewFirstParentCommitItr[C Context](ddb *DoltDB, start *Commit) *FirstParentCommitItr[C] {
+	return &FirstParentCommitItr[C]{ddb: ddb, start: start}
+}
+
+// Next implements the CommitItr interface.
+// Note: This implementation always returns nil for the metadata and height return values.
+func (i *FirstParentCommitItr[C]) Next(ctx C) (hash.Hash, *OptionalCommit, *datas.CommitMeta, uint64, error) {
+	if !i.started {
+		i.started = true
+		h, err := i.start.HashOf()
+		if err != nil {
+			return hash.Hash{}, nil, nil, 0, err
+		}
+		i.curr = i.start
+		return h, &OptionalCommit{i.curr, h}, nil, 0, nil
+	}
+
+	if i.curr == nil || i.curr.NumParents() == 0 {
+		i.curr = nil
+		return hash.Hash{}, nil, nil, 0, io.EOF
+	}
+
+	parents, err := i.curr.ParentHashes(ctx)
+	if err != nil {
+		return hash.Hash{}, nil, nil, 0, err
+	}
+
+	next := parents[0]
+	i.curr, err = HashToCommit(ctx, i.ddb.ValueReadWriter(), i.ddb.ns, next)
+	if err != nil && err != ErrGhostCommitEncountered {
+		return hash.Hash{}, nil, nil, 0, err
+	}
+	if err == ErrGhostCommitEncountered {
+		i.curr = nil
+	}
+
+	return next, &OptionalCommit{i.curr, next}, nil, 0, nil
+}
+
+func (i *FirstParentCommitItr[C]) Reset(_ context.Context) error {
+	i.curr = nil
+	i.started = false
+	return nil
+}
+
 func HashToCommit(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeStore, h hash.Hash) (*Commit, error) {
 	dc, err := datas.LoadCommitAddr(ctx, vrw, h)
 	if err != nil {
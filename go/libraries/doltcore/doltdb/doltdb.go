@@ -988,6 +988,23 @@ func (ddb *DoltDB) SetHead(ctx context.Context, ref ref.DoltRef, addr hash.Hash)
 	return err
 }
 
+// RefUpdate moves a branch, tag or remote ref from the commit |Prev| to the commit |New|.
+type RefUpdate struct {
+	Ref  ref.DoltRef
+	Prev hash.Hash
+	New  hash.Hash
+}
+
+// UpdateRefs moves several refs to new commits atomically, ignoring lineage. If any ref no longer points at its |Prev|
+// commit, no ref is updated and datas.ErrOptimisticLockFailed is returned. Working sets are not updated.
+func (ddb *DoltDB) UpdateRefs(ctx context.Context, updates []RefUpdate) error {
+	dsUpdates := make([]datas.HeadUpdate, len(updates))
+	for i, u := range updates {
+		dsUpdates[i] = datas.HeadUpdate{ID: u.Ref.String(), Prev: u.Prev, New: u.New}
+	}
+	return ddb.db.SetHeads(ctx, dsUpdates)
+}
+
 // CommitWithParentSpecs commits the value hash given to the branch given, using the list of parent hashes given. Returns an
 // error if the value or any parents can't be resolved, or if anything goes wrong accessing the underlying storage.
 func (ddb *DoltDB) CommitWithParentSpecs(ctx context.Context, valHash hash.Hash, dref ref.DoltRef, parentCmSpecs []*CommitSpec, cm *datas.CommitMeta) (*Commit, error) {
@@ -2012,6 +2029,15 @@ func (ddb *DoltDB) PullChunks(
 	statsCh chan pull.Stats,
 	skipHashes hash.HashSet,
 ) error {
+	if scs, ok := ddb.sparseChunkStore(); ok {
+		filter, ok, err := ddb.GetSparseFilter(ctx)
+		if err != nil {
+			return err
+		}
+		if ok && !filter.IsEmpty() {
+			return pullSparse(ctx, ddb, srcDB, scs, filter, targetHashes, tempDir, statsCh, skipHashes)
+		}
+	}
 	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, skipHashes)
 }
 
@@ -2022,10 +2048,21 @@ func pullHash(
 	tempDir string,
 	statsCh chan pull.Stats,
 	skipHashes hash.HashSet,
+) error {
+	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
+	return pullWithWalker(ctx, destDB, srcDB, targetHashes, tempDir, statsCh, waf)
+}
+
+func pullWithWalker(
+	ctx context.Context,
+	destDB, srcDB datas.Database,
+	targetHashes []hash.Hash,
+	tempDir string,
+	statsCh chan pull.Stats,
+	waf pull.WalkAddrs,
 ) error {
 	srcCS := datas.ChunkStoreFromDatabase(srcDB)
 	destCS := datas.ChunkStoreFromDatabase(destDB)
-	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
 
 	if datas.CanUsePuller(srcDB) && datas.CanUsePuller(destDB) {
 		puller, err := pull.NewPuller(ctx, tempDir, defaultTargetFileSize, srcCS, destCS, waf, targetHashes, statsCh)
@@ -2082,6 +2119,26 @@ func (ddb *DoltDB) IterateRoots(cb func(root string, timestamp *time.Time) error
 	}
 }
 
+// TruncateReflog removes every previous root hash of this DoltDB
+// visited by IterateRoots, so that the reflog no longer references
+// the commits they point to. The chunks of those commits remain in the
+// store until it is garbage collected.
+//
+// Only works in the case that the underlying store is a
+// NomsBlockStore instance. Otherwise it does nothing.
+func (ddb *DoltDB) TruncateReflog(ctx context.Context) error {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+
+	if generationalNBS, ok := cs.(*nbs.GenerationalNBS); ok {
+		cs = generationalNBS.NewGen()
+	}
+
+	if nbsStore, ok := cs.(*nbs.NomsBlockStore); ok {
+		return nbsStore.TruncateRoots(ctx)
+	}
+	return nil
+}
+
 // SetCrashOnFatalError puts the store into a mode where it will
 // crash the running process is there is a fatal I/O error which
 // prevents Dolt from being able to continue safely while
@@ -2229,6 +2286,33 @@ func (ddb *DoltDB) GetBranchesByRootHash(ctx context.Context, rootHash hash.Hash
 	return refs, nil
 }
 
+// GetTuplesByRootHash returns the addresses of the tuples of the root |rootHash|, keyed by tuple key.
+func (ddb *DoltDB) GetTuplesByRootHash(ctx context.Context, rootHash hash.Hash) (map[string]hash.Hash, error) {
+	dss, err := ddb.db.DatasetsByRootHash(ctx, rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	tuples := make(map[string]hash.Hash)
+	err = dss.IterAll(ctx, func(key string, addr hash.Hash) error {
+		if !ref.IsRef(key) {
+			return nil
+		}
+		dref, err := ref.Parse(key)
+		if err != nil {
+			return err
+		}
+		if _, ok := tuplesRefFilter[dref.GetType()]; ok {
+			tuples[dref.GetPath()] = addr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tuples, nil
+}
+
 // AddStash takes current branch head commit, stash root value and stash metadata to create a new stash.
 // It stores the new stash object in stash list Dataset, which can be created if it does not exist.
 // Otherwise, it updates the stash list Dataset as there can only be one stashes Dataset.
@@ -166,6 +166,21 @@ func (db hooksDatabase) FastForward(ctx context.Context, ds datas.Dataset, newHe
 	return ds, err
 }
 
+func (db hooksDatabase) SetHeads(ctx context.Context, updates []datas.HeadUpdate) error {
+	err := db.Database.SetHeads(ctx, updates)
+	if err != nil {
+		return err
+	}
+	for _, u := range updates {
+		ds, err := db.Database.GetDataset(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+		db.ExecuteCommitHooks(ctx, ds, false)
+	}
+	return nil
+}
+
 func (db hooksDatabase) Delete(ctx context.Context, ds datas.Dataset, workingSetPath string) (datas.Dataset, error) {
 	ds, err := db.Database.Delete(ctx, ds, workingSetPath)
 	if err == nil {
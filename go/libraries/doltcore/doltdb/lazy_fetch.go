@@ -0,0 +1,101 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// lazyFetchTupleKey is the key of the tuple holding the LazyFetchConfig of a lazy clone.
+const lazyFetchTupleKey = "lazy_fetch"
+
+// DefaultLazyFetchCacheSizeMB is the default bound of the disk cache of the chunks fetched by a lazy clone.
+const DefaultLazyFetchCacheSizeMB = 1024
+
+var ErrLazyFetchNotSupported = errors.New("lazy fetching is not supported by this database's storage")
+
+// LazyFetchConfig is the configuration of a lazy clone, which fetches no chunks when it's cloned, and instead reads
+// every chunk it doesn't have from Remote when it's needed. The chunks read are cached on disk, up to CacheSizeMB.
+type LazyFetchConfig struct {
+	Remote      string `json:"remote"`
+	CacheSizeMB uint64 `json:"cache_size_mb,omitempty"`
+}
+
+// CacheSize returns the bound of the disk cache in bytes.
+func (c LazyFetchConfig) CacheSize() uint64 {
+	if c.CacheSizeMB == 0 {
+		return DefaultLazyFetchCacheSizeMB * 1024 * 1024
+	}
+	return c.CacheSizeMB * 1024 * 1024
+}
+
+func (ddb *DoltDB) lazyChunkStore() (nbs.LazyChunkStore, bool) {
+	lcs, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.LazyChunkStore)
+	return lcs, ok
+}
+
+// ChunkEncryption returns the encryption of the chunk data of this database, or nil if its chunk data isn't encrypted.
+// The chunks a lazy clone caches on disk are encrypted with it.
+func (ddb *DoltDB) ChunkEncryption() *nbs.ChunkEncryption {
+	if ecs, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.EncryptingChunkStore); ok {
+		return ecs.ChunkEncryption()
+	}
+	return nil
+}
+
+// GetLazyFetchConfig returns the configuration of this database if it's a lazy clone.
+func (ddb *DoltDB) GetLazyFetchConfig(ctx context.Context) (LazyFetchConfig, bool, error) {
+	if _, ok := ddb.lazyChunkStore(); !ok {
+		return LazyFetchConfig{}, false, nil
+	}
+	data, ok, err := ddb.GetTuple(ctx, lazyFetchTupleKey)
+	if err != nil || !ok {
+		return LazyFetchConfig{}, false, err
+	}
+	var c LazyFetchConfig
+	if err = json.Unmarshal(data, &c); err != nil {
+		return LazyFetchConfig{}, false, fmt.Errorf("failed to read lazy clone configuration: %w", err)
+	}
+	return c, true, nil
+}
+
+// SetLazyFetchConfig makes this database a lazy clone of |c.Remote|.
+func (ddb *DoltDB) SetLazyFetchConfig(ctx context.Context, c LazyFetchConfig) error {
+	if _, ok := ddb.lazyChunkStore(); !ok {
+		return ErrLazyFetchNotSupported
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, lazyFetchTupleKey, data)
+}
+
+// SetLazySource sets the source this database reads the chunks it doesn't have from. Chunks present in |src| are
+// reported as present in this database, so pulling from |src| fetches nothing. A nil |src| disables lazy reads.
+func (ddb *DoltDB) SetLazySource(src nbs.LazySource) error {
+	lcs, ok := ddb.lazyChunkStore()
+	if !ok {
+		return ErrLazyFetchNotSupported
+	}
+	lcs.SetLazySource(src)
+	return nil
+}
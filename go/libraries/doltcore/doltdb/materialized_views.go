@@ -0,0 +1,157 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// MaterializedView is a single entry in the dolt_materialized_views system table.
+type MaterializedView struct {
+	Name       string
+	Definition string
+}
+
+// GetMaterializedViews returns every materialized view declared in dolt_materialized_views on |root|. If
+// dolt_materialized_views does not exist, no views are returned.
+func GetMaterializedViews(ctx context.Context, root RootValue) ([]MaterializedView, error) {
+	table, found, err := root.GetTable(ctx, TableName{Name: GetMaterializedViewsTableName()})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(index)
+	keyDesc, valDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var views []MaterializedView
+	for {
+		keyTuple, valTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := keyDesc.GetString(0, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("failed to read %s from %s", MaterializedViewsNameCol, MaterializedViewsTableName)
+		}
+		definition, ok, err := readTextField(ctx, valDesc, 0, valTuple, m.NodeStore())
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("failed to read %s from %s", MaterializedViewsDefinitionCol, MaterializedViewsTableName)
+		}
+		views = append(views, MaterializedView{Name: name, Definition: definition})
+	}
+	return views, nil
+}
+
+// materializedViewRefreshesTupleKey is the key of the tuple holding the most recent refreshes of the materialized
+// views of a database. Refreshes are not versioned: they are looked up by the content of a view's table, so they
+// apply to any branch or commit where the view has that content.
+const materializedViewRefreshesTupleKey = "materialized_view_refreshes"
+
+// MaxMaterializedViewRefreshes is the number of refreshes kept for a database. The oldest refreshes are removed first.
+const MaxMaterializedViewRefreshes = 1000
+
+// MaterializedViewRefresh records that a materialized view's table, with the row data |Result|, is the result of its
+// definition on base tables with the row data |Tables|. It allows the next refresh of the view to only process the
+// rows of the base tables that changed since.
+type MaterializedViewRefresh struct {
+	View       string `json:"view"`
+	Definition string `json:"definition"`
+	Result     string `json:"result"`
+	// Tables is the hash of the row data of each base table of the view, by name.
+	Tables map[string]string `json:"tables"`
+}
+
+// materializedViewRefreshesMu serializes the updates of the refreshes of every database.
+var materializedViewRefreshesMu sync.Mutex
+
+func (ddb *DoltDB) getMaterializedViewRefreshes(ctx context.Context) ([]MaterializedViewRefresh, error) {
+	data, ok, err := ddb.GetTuple(ctx, materializedViewRefreshesTupleKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var refreshes []MaterializedViewRefresh
+	if err = json.Unmarshal(data, &refreshes); err != nil {
+		return nil, fmt.Errorf("failed to read materialized view refreshes: %w", err)
+	}
+	return refreshes, nil
+}
+
+// GetMaterializedViewRefresh returns the most recent refresh of the view |view| with the definition |definition| that
+// resulted in the row data |result|, if there is one.
+func (ddb *DoltDB) GetMaterializedViewRefresh(ctx context.Context, view, definition string, result hash.Hash) (MaterializedViewRefresh, bool, error) {
+	refreshes, err := ddb.getMaterializedViewRefreshes(ctx)
+	if err != nil {
+		return MaterializedViewRefresh{}, false, err
+	}
+	for i := len(refreshes) - 1; i >= 0; i-- {
+		r := refreshes[i]
+		if r.View == view && r.Definition == definition && r.Result == result.String() {
+			return r, true, nil
+		}
+	}
+	return MaterializedViewRefresh{}, false, nil
+}
+
+// RecordMaterializedViewRefresh records |refresh|. Once MaxMaterializedViewRefreshes refreshes are recorded, the
+// oldest refresh is removed.
+func (ddb *DoltDB) RecordMaterializedViewRefresh(ctx context.Context, refresh MaterializedViewRefresh) error {
+	materializedViewRefreshesMu.Lock()
+	defer materializedViewRefreshesMu.Unlock()
+
+	refreshes, err := ddb.getMaterializedViewRefreshes(ctx)
+	if err != nil {
+		return err
+	}
+	refreshes = append(refreshes, refresh)
+	if len(refreshes) > MaxMaterializedViewRefreshes {
+		refreshes = refreshes[len(refreshes)-MaxMaterializedViewRefreshes:]
+	}
+
+	data, err := json.Marshal(refreshes)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, materializedViewRefreshesTupleKey, data)
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// MergeResolverRowLevel is the value of the column_name column in dolt_merge_resolvers for a resolver that applies to
+// whole rows rather than a single column.
+const MergeResolverRowLevel = "*"
+
+// MergeResolverStrategy names a strategy for automatically resolving a conflicting cell or row during a merge.
+type MergeResolverStrategy string
+
+const (
+	// MergeResolverOurs takes the value from our side of the merge.
+	MergeResolverOurs MergeResolverStrategy = "ours"
+	// MergeResolverTheirs takes the value from their side of the merge.
+	MergeResolverTheirs MergeResolverStrategy = "theirs"
+	// MergeResolverMax takes the greater of our and their values. NULL values are ignored.
+	MergeResolverMax MergeResolverStrategy = "max"
+	// MergeResolverMin takes the lesser of our and their values. NULL values are ignored.
+	MergeResolverMin MergeResolverStrategy = "min"
+	// MergeResolverSum applies the change made on each side relative to the ancestor, i.e. ours + theirs - base.
+	MergeResolverSum MergeResolverStrategy = "sum"
+	// MergeResolverLatest takes the value from the side with the greater value in the column named by the resolver's
+	// argument. A row-level resolver takes the whole row from that side, and a column-level resolver takes only the
+	// value of its column.
+	MergeResolverLatest MergeResolverStrategy = "latest"
+	// MergeResolverJsonArrayConcat concatenates our JSON array with the elements of their JSON array that are not
+	// already present in ours.
+	MergeResolverJsonArrayConcat MergeResolverStrategy = "json_array_concat"
+	// MergeResolverJsonArrayUnion merges our and their JSON documents, merging concurrent changes to the same array
+	// by keeping the elements of our array and appending the elements added to their array. Elements removed on
+	// either side are removed from the result.
+	MergeResolverJsonArrayUnion MergeResolverStrategy = "json_array_union"
+	// MergeResolverJsonArraySet merges our and their JSON documents, treating arrays as sets of distinct values.
+	MergeResolverJsonArraySet MergeResolverStrategy = "json_array_set"
+	// MergeResolverJsonArrayKeyed merges our and their JSON documents, treating arrays of objects as maps keyed on the
+	// field named by the resolver's argument, or "id" if there is no argument. Concurrent edits to different elements
+	// are merged, and only edits to the same element that cannot be merged conflict.
+	MergeResolverJsonArrayKeyed MergeResolverStrategy = "json_array_keyed"
+	// MergeResolverExpression evaluates the SQL expression in the resolver's argument. The expression may reference
+	// the base_, our_ and their_ values of every non-primary-key column of the table.
+	MergeResolverExpression MergeResolverStrategy = "expression"
+)
+
+// MergeResolverStrategies is the list of every supported merge resolver strategy.
+var MergeResolverStrategies = []MergeResolverStrategy{
+	MergeResolverOurs,
+	MergeResolverTheirs,
+	MergeResolverMax,
+	MergeResolverMin,
+	MergeResolverSum,
+	MergeResolverLatest,
+	MergeResolverJsonArrayConcat,
+	MergeResolverJsonArrayUnion,
+	MergeResolverJsonArraySet,
+	MergeResolverJsonArrayKeyed,
+	MergeResolverExpression,
+}
+
+// MergeResolver is a single entry in the dolt_merge_resolvers system table.
+type MergeResolver struct {
+	TableName  string
+	ColumnName string
+	Strategy   MergeResolverStrategy
+	Argument   string
+}
+
+// IsRowLevel returns whether this resolver applies to entire rows rather than a single column.
+func (r MergeResolver) IsRowLevel() bool {
+	return r.ColumnName == MergeResolverRowLevel
+}
+
+// GetMergeResolverKey is a function that reads the table_name and column_name columns from dolt_merge_resolvers.
+// This is used to handle the Doltgres extended string type.
+var GetMergeResolverKey = getMergeResolverKey
+
+// GetMergeResolverValue is a function that reads the strategy and argument columns from dolt_merge_resolvers. This
+// is used to handle the Doltgres extended string type.
+var GetMergeResolverValue = getMergeResolverValue
+
+func getMergeResolverKey(_ context.Context, keyDesc *val.TupleDesc, keyTuple val.Tuple) (tableName string, columnName string, err error) {
+	tableName, ok := keyDesc.GetString(0, keyTuple)
+	if !ok {
+		return "", "", fmt.Errorf("failed to read table_name from %s", MergeResolversTableName)
+	}
+	columnName, ok = keyDesc.GetString(1, keyTuple)
+	if !ok {
+		return "", "", fmt.Errorf("failed to read column_name from %s", MergeResolversTableName)
+	}
+	return tableName, columnName, nil
+}
+
+func getMergeResolverValue(ctx context.Context, valDesc *val.TupleDesc, valTuple val.Tuple, ns tree.NodeStore) (strategy string, argument string, err error) {
+	strategy, ok := valDesc.GetString(0, valTuple)
+	if !ok {
+		return "", "", fmt.Errorf("failed to read strategy from %s", MergeResolversTableName)
+	}
+	// argument is nullable
+	argument, _, err = readTextField(ctx, valDesc, 1, valTuple, ns)
+	if err != nil {
+		return "", "", err
+	}
+	return strategy, argument, nil
+}
+
+// GetMergeResolvers returns every resolver declared in dolt_merge_resolvers on |root| for the table |tableName|.
+// Table names are matched case-insensitively. If dolt_merge_resolvers does not exist, no resolvers are returned.
+func GetMergeResolvers(ctx context.Context, root RootValue, tableName TableName) ([]MergeResolver, error) {
+	resolversTableName := TableName{Name: GetMergeResolversTableName(), Schema: tableName.Schema}
+	table, found, err := root.GetTable(ctx, resolversTableName)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		// dolt_merge_resolvers doesn't exist, so there is nothing to apply.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(index)
+	keyDesc, valDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolvers []MergeResolver
+	for {
+		keyTuple, valTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		resolverTable, resolverColumn, err := GetMergeResolverKey(ctx, keyDesc, keyTuple)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(resolverTable, tableName.Name) {
+			continue
+		}
+
+		strategy, argument, err := GetMergeResolverValue(ctx, valDesc, valTuple, m.NodeStore())
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, MergeResolver{
+			TableName:  resolverTable,
+			ColumnName: resolverColumn,
+			Strategy:   MergeResolverStrategy(strings.ToLower(strategy)),
+			Argument:   argument,
+		})
+	}
+
+	return resolvers, nil
+}
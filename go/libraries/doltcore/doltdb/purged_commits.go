@@ -0,0 +1,73 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// purgedCommitsTupleKey is the key of the tuple holding the commits rewritten by purges of rows. Like the reflog, it
+// is not versioned. It only holds the hashes of the commits, so it doesn't keep the original commits from being
+// garbage collected.
+const purgedCommitsTupleKey = "purged_commits"
+
+// PurgedCommit records that a purge of rows from the table Table replaced the commit Old with the commit New. The
+// filter selecting the purged rows isn't recorded, as it may contain the very values that were purged.
+type PurgedCommit struct {
+	Old      string    `json:"old"`
+	New      string    `json:"new"`
+	Table    string    `json:"table"`
+	PurgedAt time.Time `json:"purged_at"`
+}
+
+// purgedCommitsMu serializes the updates of the purged commits of every database.
+var purgedCommitsMu sync.Mutex
+
+// GetPurgedCommits returns the commits rewritten by every purge of rows of this database, in the order they were
+// recorded.
+func (ddb *DoltDB) GetPurgedCommits(ctx context.Context) ([]PurgedCommit, error) {
+	data, ok, err := ddb.GetTuple(ctx, purgedCommitsTupleKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var purged []PurgedCommit
+	if err = json.Unmarshal(data, &purged); err != nil {
+		return nil, fmt.Errorf("failed to read purged commits: %w", err)
+	}
+	return purged, nil
+}
+
+// AddPurgedCommits records the commits rewritten by a purge of rows. Unlike most histories, it's never truncated: a
+// commit purged long ago may still be referenced by a clone, or by a system outside of the database.
+func (ddb *DoltDB) AddPurgedCommits(ctx context.Context, commits []PurgedCommit) error {
+	purgedCommitsMu.Lock()
+	defer purgedCommitsMu.Unlock()
+
+	purged, err := ddb.GetPurgedCommits(ctx)
+	if err != nil {
+		return err
+	}
+	purged = append(purged, commits...)
+
+	data, err := json.Marshal(purged)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, purgedCommitsTupleKey, data)
+}
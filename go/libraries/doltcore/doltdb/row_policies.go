@@ -0,0 +1,182 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// RowPolicyGranteeAll is the value of the grantee column in dolt_row_policies for a policy that applies to every user.
+const RowPolicyGranteeAll = "%"
+
+// RowPolicyCommand names the statements a row-level security policy applies to.
+type RowPolicyCommand string
+
+const (
+	// RowPolicyAll applies a policy to every statement.
+	RowPolicyAll RowPolicyCommand = "all"
+	// RowPolicySelect applies a policy to the rows read by any statement.
+	RowPolicySelect RowPolicyCommand = "select"
+	// RowPolicyInsert applies a policy to the rows written by INSERT statements.
+	RowPolicyInsert RowPolicyCommand = "insert"
+	// RowPolicyUpdate applies a policy to the rows changed by UPDATE statements, both before and after the update.
+	RowPolicyUpdate RowPolicyCommand = "update"
+	// RowPolicyDelete applies a policy to the rows removed by DELETE statements.
+	RowPolicyDelete RowPolicyCommand = "delete"
+)
+
+// RowPolicyCommands is the list of every supported row policy command.
+var RowPolicyCommands = []RowPolicyCommand{
+	RowPolicyAll,
+	RowPolicySelect,
+	RowPolicyInsert,
+	RowPolicyUpdate,
+	RowPolicyDelete,
+}
+
+// RowPolicy is a single entry in the dolt_row_policies system table.
+type RowPolicy struct {
+	TableName  string
+	PolicyName string
+	Command    RowPolicyCommand
+	Grantee    string
+	Filter     string
+}
+
+// AppliesTo returns whether this policy applies to statements of type |cmd| run by a user whose name or one of whose
+// roles is in |grantees|.
+func (p RowPolicy) AppliesTo(cmd RowPolicyCommand, grantees []string) bool {
+	if p.Command != RowPolicyAll && p.Command != cmd {
+		return false
+	}
+	if p.Grantee == RowPolicyGranteeAll {
+		return true
+	}
+	for _, grantee := range grantees {
+		if p.Grantee == grantee {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRowPolicyKey is a function that reads the table_name and policy_name columns from dolt_row_policies. This is
+// used to handle the Doltgres extended string type.
+var GetRowPolicyKey = getRowPolicyKey
+
+// GetRowPolicyValue is a function that reads the command, grantee and filter columns from dolt_row_policies. This is
+// used to handle the Doltgres extended string type.
+var GetRowPolicyValue = getRowPolicyValue
+
+func getRowPolicyKey(_ context.Context, keyDesc *val.TupleDesc, keyTuple val.Tuple) (tableName string, policyName string, err error) {
+	tableName, ok := keyDesc.GetString(0, keyTuple)
+	if !ok {
+		return "", "", fmt.Errorf("failed to read table_name from %s", RowPoliciesTableName)
+	}
+	policyName, ok = keyDesc.GetString(1, keyTuple)
+	if !ok {
+		return "", "", fmt.Errorf("failed to read policy_name from %s", RowPoliciesTableName)
+	}
+	return tableName, policyName, nil
+}
+
+func getRowPolicyValue(ctx context.Context, valDesc *val.TupleDesc, valTuple val.Tuple, ns tree.NodeStore) (command string, grantee string, filter string, err error) {
+	command, ok := valDesc.GetString(0, valTuple)
+	if !ok {
+		return "", "", "", fmt.Errorf("failed to read command from %s", RowPoliciesTableName)
+	}
+	grantee, ok = valDesc.GetString(1, valTuple)
+	if !ok {
+		return "", "", "", fmt.Errorf("failed to read grantee from %s", RowPoliciesTableName)
+	}
+	filter, ok, err = readTextField(ctx, valDesc, 2, valTuple, ns)
+	if err != nil {
+		return "", "", "", err
+	}
+	if !ok {
+		return "", "", "", fmt.Errorf("failed to read filter from %s", RowPoliciesTableName)
+	}
+	return command, grantee, filter, nil
+}
+
+// GetRowPolicies returns every policy declared in dolt_row_policies on |root| for the table |tableName|. Table names
+// are matched case-insensitively. If dolt_row_policies does not exist, no policies are returned.
+func GetRowPolicies(ctx context.Context, root RootValue, tableName TableName) ([]RowPolicy, error) {
+	policiesTableName := TableName{Name: GetRowPoliciesTableName(), Schema: tableName.Schema}
+	table, found, err := root.GetTable(ctx, policiesTableName)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		// dolt_row_policies doesn't exist, so every row is visible.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := durable.MapFromIndex(index)
+	keyDesc, valDesc := sch.GetMapDescriptors(m.NodeStore())
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []RowPolicy
+	for {
+		keyTuple, valTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		policyTable, policyName, err := GetRowPolicyKey(ctx, keyDesc, keyTuple)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(policyTable, tableName.Name) {
+			continue
+		}
+
+		command, grantee, filter, err := GetRowPolicyValue(ctx, valDesc, valTuple, m.NodeStore())
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, RowPolicy{
+			TableName:  policyTable,
+			PolicyName: policyName,
+			Command:    RowPolicyCommand(strings.ToLower(command)),
+			Grantee:    grantee,
+			Filter:     filter,
+		})
+	}
+
+	return policies, nil
+}
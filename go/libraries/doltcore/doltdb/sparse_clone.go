@@ -0,0 +1,228 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// sparseFilterTupleKey is the key of the tuple holding the SparseFilter of a sparse clone. Like the other tuples, it
+// is not versioned and is never pushed.
+const sparseFilterTupleKey = "sparse_filter"
+
+var ErrSparseCloneNotSupported = errors.New("sparse clones are not supported by this database's storage")
+
+// SparseFilter selects the tables whose data is fetched into a sparse clone. The schemas of every table are always
+// fetched, but the rows and indexes of the tables which are not included are only fetched from Remote when they're
+// first read. Dolt system tables are always included.
+type SparseFilter struct {
+	Remote        string   `json:"remote"`
+	Tables        []string `json:"tables,omitempty"`
+	ExcludeTables []string `json:"exclude_tables,omitempty"`
+}
+
+// IsEmpty returns whether the filter includes every table.
+func (f SparseFilter) IsEmpty() bool {
+	return len(f.Tables) == 0 && len(f.ExcludeTables) == 0
+}
+
+// Includes returns whether the data of the table |name| is fetched.
+func (f SparseFilter) Includes(name string) bool {
+	if HasDoltPrefix(name) {
+		return true
+	}
+	if len(f.Tables) > 0 && !containsTableNameFold(f.Tables, name) {
+		return false
+	}
+	return !containsTableNameFold(f.ExcludeTables, name)
+}
+
+func containsTableNameFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ddb *DoltDB) sparseChunkStore() (nbs.SparseChunkStore, bool) {
+	scs, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.SparseChunkStore)
+	return scs, ok
+}
+
+// GetSparseFilter returns the filter of this database if it's a sparse clone.
+func (ddb *DoltDB) GetSparseFilter(ctx context.Context) (SparseFilter, bool, error) {
+	if _, ok := ddb.sparseChunkStore(); !ok {
+		return SparseFilter{}, false, nil
+	}
+	data, ok, err := ddb.GetTuple(ctx, sparseFilterTupleKey)
+	if err != nil || !ok {
+		return SparseFilter{}, false, err
+	}
+	var f SparseFilter
+	if err = json.Unmarshal(data, &f); err != nil {
+		return SparseFilter{}, false, fmt.Errorf("failed to read sparse clone filter: %w", err)
+	}
+	return f, true, nil
+}
+
+// SetSparseFilter makes this database a sparse clone of |f.Remote|. Every later pull into it only fetches the data of
+// the tables included by |f|.
+func (ddb *DoltDB) SetSparseFilter(ctx context.Context, f SparseFilter) error {
+	if _, ok := ddb.sparseChunkStore(); !ok {
+		return ErrSparseCloneNotSupported
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return ddb.SetTuple(ctx, sparseFilterTupleKey, data)
+}
+
+// SetSparseFetcher sets the function used to fetch the table data left out by a sparse clone when it's first read.
+// Without one, reading that data is an error.
+func (ddb *DoltDB) SetSparseFetcher(f func(ctx context.Context, addrs hash.HashSet) error) {
+	if scs, ok := ddb.sparseChunkStore(); ok {
+		scs.SetSparseFetcher(f)
+	}
+}
+
+// FetchSparseChunks pulls |addrs|, which were left out by a sparse clone, and every chunk reachable from them from
+// |srcDB|, regardless of the database's SparseFilter.
+func (ddb *DoltDB) FetchSparseChunks(ctx context.Context, tempDir string, srcDB *DoltDB, addrs hash.HashSet) error {
+	return pullHash(ctx, ddb.db, srcDB.db, addrs.ToSlice(), tempDir, nil, nil)
+}
+
+// pullSparse pulls into |ddb| like pullHash, but leaves out the data of the tables excluded by |filter|, and records
+// it as sparse in |ddb|'s chunk store.
+func pullSparse(
+	ctx context.Context,
+	ddb, srcDB *DoltDB,
+	scs nbs.SparseChunkStore,
+	filter SparseFilter,
+	targetHashes []hash.Hash,
+	tempDir string,
+	statsCh chan pull.Stats,
+	skipHashes hash.HashSet,
+) error {
+	w := &sparseWalker{
+		ctx:      ctx,
+		ns:       srcDB.NodeStore(),
+		filter:   filter,
+		walk:     types.WalkAddrsForNBF(srcDB.Format(), skipHashes),
+		excluded: hash.NewHashSet(),
+		included: hash.NewHashSet(),
+		groups:   make(map[hash.Hash]hash.HashSet),
+	}
+	err := pullWithWalker(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, w.walkAddrs)
+	if err != nil {
+		return err
+	}
+	if len(w.groups) == 0 {
+		return nil
+	}
+	// The refs to the pulled chunks are only set once this returns, so the left out chunks are recorded before anything
+	// references them.
+	return scs.PersistSparseHashes(ctx, w.groups)
+}
+
+// sparseWalker walks the chunks pulled into a sparse clone. When it walks a root value, it records which of its tables
+// are excluded by the filter. When it then walks the table chunk of an excluded table, it only walks its schema, and
+// records the rest of its addresses in a group keyed by the table chunk, to be fetched together on first read.
+//
+// A table chunk shared by an included and an excluded table is walked entirely, as long as the root value including
+// it is walked first. Otherwise, the data of the included table is fetched on first read like an excluded table's.
+type sparseWalker struct {
+	ctx    context.Context
+	ns     tree.NodeStore
+	filter SparseFilter
+	walk   pull.WalkAddrs
+
+	excluded hash.HashSet
+	included hash.HashSet
+	groups   map[hash.Hash]hash.HashSet
+}
+
+func (w *sparseWalker) walkAddrs(c chunks.Chunk, cb func(hash.Hash, bool) error) error {
+	switch serial.GetFileID(c.Data()) {
+	case serial.RootValueFileID:
+		if err := w.recordTables(c); err != nil {
+			return err
+		}
+	case serial.TableFileID:
+		if w.excluded.Has(c.Hash()) && !w.included.Has(c.Hash()) {
+			return w.walkExcludedTable(c, cb)
+		}
+	}
+	return w.walk(c, cb)
+}
+
+func (w *sparseWalker) recordTables(c chunks.Chunk) error {
+	srv, err := serial.TryGetRootAsRootValue(c.Data(), serial.MessagePrefixSz)
+	if err != nil {
+		return err
+	}
+	am, err := fbRvStorage{srv}.getAddressMap(nil, w.ns)
+	if err != nil {
+		return err
+	}
+	return am.IterAll(w.ctx, func(key string, addr hash.Hash) error {
+		name, ok := decodeTableNameFromSerialization(key)
+		if ok && !w.filter.Includes(name.Name) {
+			w.excluded.Insert(addr)
+		} else {
+			w.included.Insert(addr)
+		}
+		return nil
+	})
+}
+
+func (w *sparseWalker) walkExcludedTable(c chunks.Chunk, cb func(hash.Hash, bool) error) error {
+	tbl, err := serial.TryGetRootAsTable(c.Data(), serial.MessagePrefixSz)
+	if err != nil {
+		return err
+	}
+	schemaAddr := hash.New(tbl.SchemaBytes())
+
+	group := hash.NewHashSet()
+	err = w.walk(c, func(h hash.Hash, isLeaf bool) error {
+		if h == schemaAddr {
+			return cb(h, isLeaf)
+		}
+		group.Insert(h)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if group.Size() > 0 {
+		w.groups[c.Hash()] = group
+	}
+	return nil
+}
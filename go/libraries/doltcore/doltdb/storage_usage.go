@@ -0,0 +1,435 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// StorageUsage is the storage used by everything reachable from a ref, or by a single table across the history of a
+// ref. Sizes are logical: they are the sizes of the chunks before compression, so they don't include the overhead of
+// table files and don't account for chunks which are no longer reachable and will be removed by GC.
+type StorageUsage struct {
+	// Ref is the ref, e.g. refs/heads/main. The working set of a branch is accounted for along with the branch.
+	Ref string
+	// Table is the name of the table, or empty if this is the usage of the entire ref.
+	Table TableName
+	// ChunkCount is the number of distinct chunks reachable from the ref, or from the versions of the table in the
+	// history of the ref.
+	ChunkCount uint64
+	// LogicalBytes is the size of the chunks counted by ChunkCount.
+	LogicalBytes uint64
+	// ExclusiveBytes is the size of the chunks counted by ChunkCount which are not reachable from any other ref. It is
+	// the amount of storage that deleting the ref would free up after a GC.
+	ExclusiveBytes uint64
+}
+
+const (
+	// noChunkOwner and sharedChunkOwner are the owners of chunks not yet reached from any ref, and of chunks reached
+	// from more than one ref.
+	noChunkOwner     = -1
+	sharedChunkOwner = -2
+
+	storageUsageCacheSize = 8
+
+	// maxCachedChildren is the maximum number of child addresses remembered by a chunkUsageWalker, about 20MB of
+	// hashes.
+	maxCachedChildren = 1 << 20
+)
+
+// storageUsageCache caches the computed usage by the root hash of the chunk store, and whether table usage was
+// included. The usage only depends on the chunks reachable from the root, so it can be shared by databases.
+var storageUsageCache, _ = lru.New[storageUsageCacheKey, []StorageUsage](storageUsageCacheSize)
+
+type storageUsageCacheKey struct {
+	root   hash.Hash
+	tables bool
+}
+
+// StorageUsage computes the storage used by every ref of the database, and by every table of the refs which have
+// commits when |includeTables| is true. Usage is computed by walking the addresses of the chunks reachable from every
+// ref, which reads every reachable chunk at least once, so it's expensive for large databases. The size and owner of
+// every reachable chunk are kept in memory while computing usage, about 32 bytes per chunk. Results are cached until
+// the root of the database changes.
+func (ddb *DoltDB) StorageUsage(ctx context.Context, includeTables bool) ([]StorageUsage, error) {
+	root, err := ddb.NomsRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := storageUsageCacheKey{root: root, tables: includeTables}
+	if usage, ok := storageUsageCache.Get(key); ok {
+		return usage, nil
+	}
+
+	owners, err := ddb.storageOwners(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	walker := newChunkUsageWalker(datas.ChunkStoreFromDatabase(ddb.db), ddb.Format())
+
+	// First, find the chunks reachable from every ref, and which refs reach each chunk.
+	usage := make([]StorageUsage, len(owners))
+	for i, owner := range owners {
+		usage[i] = StorageUsage{Ref: owner.ref}
+		err = walker.walk(ctx, owner.roots, func(h hash.Hash, cu *chunkUsage) {
+			usage[i].ChunkCount++
+			usage[i].LogicalBytes += uint64(cu.size)
+			if cu.owner == noChunkOwner {
+				cu.owner = int32(i)
+			} else {
+				cu.owner = sharedChunkOwner
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, cu := range walker.chunks {
+		if cu.owner >= 0 {
+			usage[cu.owner].ExclusiveBytes += uint64(cu.size)
+		}
+	}
+
+	if includeTables {
+		for i, owner := range owners {
+			tables, err := ddb.tableVersions(ctx, owner)
+			if err != nil {
+				return nil, err
+			}
+			for _, tv := range tables {
+				tu := StorageUsage{Ref: owner.ref, Table: tv.name}
+				err = walker.walk(ctx, tv.addrs, func(h hash.Hash, cu *chunkUsage) {
+					tu.ChunkCount++
+					tu.LogicalBytes += uint64(cu.size)
+					if cu.owner == int32(i) {
+						tu.ExclusiveBytes += uint64(cu.size)
+					}
+				})
+				if err != nil {
+					return nil, err
+				}
+				usage = append(usage, tu)
+			}
+		}
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].Ref != usage[j].Ref {
+			return usage[i].Ref < usage[j].Ref
+		}
+		return usage[i].Table.Less(usage[j].Table)
+	})
+	storageUsageCache.Add(key, usage)
+	return usage, nil
+}
+
+// storageOwner is a ref, along with the datasets whose chunks are accounted for with it.
+type storageOwner struct {
+	ref   string
+	roots []hash.Hash
+	// commit is the head commit of the ref, if it has one.
+	commit hash.Hash
+	// workingSet is the working set of the ref, if it's a branch with one.
+	workingSet *datas.WorkingSetHead
+}
+
+// storageOwners returns the refs of the database at |root|, ordered by name. Every dataset is accounted for with a
+// ref, so that chunks reachable from stashes, tuples, etc. are never counted as exclusive to a branch.
+func (ddb *DoltDB) storageOwners(ctx context.Context, root hash.Hash) ([]*storageOwner, error) {
+	dss, err := ddb.db.DatasetsByRootHash(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	byRef := make(map[string]*storageOwner)
+	var owners []*storageOwner
+	ownerFor := func(r string) *storageOwner {
+		owner, ok := byRef[r]
+		if !ok {
+			owner = &storageOwner{ref: r}
+			byRef[r] = owner
+			owners = append(owners, owner)
+		}
+		return owner
+	}
+
+	err = dss.IterAll(ctx, func(key string, addr hash.Hash) error {
+		ds, err := ddb.db.GetDatasetByRootHash(ctx, key, root)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case ref.IsWorkingSet(key):
+			owner := ownerFor(key)
+			if headRef, err := ref.NewWorkingSetRef(key).ToHeadRef(); err == nil {
+				owner = ownerFor(headRef.String())
+			}
+			owner.roots = append(owner.roots, addr)
+			if ds.IsWorkingSet() {
+				if owner.workingSet, err = ds.HeadWorkingSet(); err != nil {
+					return err
+				}
+			}
+		case ds.IsTag():
+			owner := ownerFor(key)
+			owner.roots = append(owner.roots, addr)
+			if _, owner.commit, err = ds.HeadTag(); err != nil {
+				return err
+			}
+		default:
+			owner := ownerFor(key)
+			owner.roots = append(owner.roots, addr)
+			if !ref.IsRef(key) {
+				break
+			}
+			if dref, err := ref.Parse(key); err == nil {
+				if _, ok := ref.HeadRefTypes[dref.GetType()]; ok {
+					owner.commit = addr
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(owners, func(i, j int) bool {
+		return owners[i].ref < owners[j].ref
+	})
+	return owners, nil
+}
+
+// tableVersions are the addresses of the versions of a table.
+type tableVersions struct {
+	name  TableName
+	addrs []hash.Hash
+}
+
+// tableVersions returns the versions of every table in the history of |owner|, including its working set, ordered
+// by table name.
+func (ddb *DoltDB) tableVersions(ctx context.Context, owner *storageOwner) ([]tableVersions, error) {
+	byName := make(map[TableName]hash.HashSet)
+	addRoot := func(root RootValue) error {
+		names, err := root.GetAllTableNames(ctx, false)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			addr, ok, err := root.GetTableHash(ctx, name)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if byName[name] == nil {
+				byName[name] = hash.NewHashSet()
+			}
+			byName[name].Insert(addr)
+		}
+		return nil
+	}
+
+	if ws := owner.workingSet; ws != nil {
+		addrs := []hash.Hash{ws.WorkingAddr}
+		if ws.StagedAddr != nil {
+			addrs = append(addrs, *ws.StagedAddr)
+		}
+		for _, addr := range addrs {
+			root, err := LoadRootValueFromRootIshAddr(ctx, ddb.vrw, ddb.ns, addr)
+			if err != nil {
+				return nil, err
+			}
+			if err = addRoot(root); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !owner.commit.IsEmpty() {
+		seen := hash.NewHashSet(owner.commit)
+		pending := []hash.Hash{owner.commit}
+		for len(pending) > 0 {
+			h := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+
+			optCmt, err := ddb.ReadCommit(ctx, h)
+			if err != nil {
+				return nil, err
+			}
+			cm, ok := optCmt.ToCommit()
+			if !ok {
+				// ghost commits of shallow clones have no data
+				continue
+			}
+			root, err := cm.GetRootValue(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if err = addRoot(root); err != nil {
+				return nil, err
+			}
+
+			parents, err := cm.ParentHashes(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, parent := range parents {
+				if !seen.Has(parent) {
+					seen.Insert(parent)
+					pending = append(pending, parent)
+				}
+			}
+		}
+	}
+
+	versions := make([]tableVersions, 0, len(byName))
+	for name, addrs := range byName {
+		versions = append(versions, tableVersions{name: name, addrs: addrs.ToSlice()})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].name.Less(versions[j].name)
+	})
+	return versions, nil
+}
+
+// chunkUsage is what is known of a chunk reached by a chunkUsageWalker.
+type chunkUsage struct {
+	size uint32
+	// owner is the index of the only ref the chunk was reached from, noChunkOwner or sharedChunkOwner.
+	owner int32
+	// leaf is true if the chunk has no children, which means it never needs to be read again.
+	leaf bool
+}
+
+// chunkUsageWalker walks the chunks reachable from a set of addresses. It remembers the size of every chunk it reads,
+// which chunks have no children, and the children of interior chunks, so the chunks shared by many refs and many
+// versions of a table are read from the chunk store as few times as possible. The children of interior chunks are only
+// remembered up to maxCachedChildren addresses; interior chunks reached after that are read again on every walk.
+type chunkUsageWalker struct {
+	cs        chunks.ChunkStore
+	walkAddrs func(chunks.Chunk, func(h hash.Hash, isleaf bool) error) error
+	chunks    map[hash.Hash]chunkUsage
+	// children are the addresses of the children of interior chunks, and cachedChildren the number of addresses it holds.
+	children       map[hash.Hash][]hash.Hash
+	cachedChildren int
+}
+
+func newChunkUsageWalker(cs chunks.ChunkStore, nbf *types.NomsBinFormat) *chunkUsageWalker {
+	return &chunkUsageWalker{
+		cs:        cs,
+		walkAddrs: types.WalkAddrsForNBF(nbf, nil),
+		chunks:    make(map[hash.Hash]chunkUsage),
+		children:  make(map[hash.Hash][]hash.Hash),
+	}
+}
+
+// walk calls |visit| once for every chunk reachable from |roots|. |visit| may update the chunk's usage.
+func (w *chunkUsageWalker) walk(ctx context.Context, roots []hash.Hash, visit func(h hash.Hash, cu *chunkUsage)) error {
+	visited := hash.NewHashSet()
+	pending := hash.NewHashSet(roots...)
+	for len(pending) > 0 {
+		for h := range pending {
+			visited.Insert(h)
+		}
+
+		next := hash.NewHashSet()
+		addChildren := func(children []hash.Hash) {
+			for _, child := range children {
+				if !visited.Has(child) {
+					next.Insert(child)
+				}
+			}
+		}
+
+		toRead := hash.NewHashSet()
+		for h := range pending {
+			cu, ok := w.chunks[h]
+			if !ok {
+				cu = chunkUsage{owner: noChunkOwner}
+				w.chunks[h] = cu
+			}
+			if ok && cu.leaf {
+				visit(h, &cu)
+				w.chunks[h] = cu
+				continue
+			}
+			if children, ok := w.children[h]; ok {
+				visit(h, &cu)
+				w.chunks[h] = cu
+				addChildren(children)
+				continue
+			}
+			toRead.Insert(h)
+		}
+
+		var mu sync.Mutex
+		var walkErr error
+		err := w.cs.GetMany(ctx, toRead, func(ctx context.Context, c *chunks.Chunk) {
+			var children []hash.Hash
+			err := w.walkAddrs(*c, func(h hash.Hash, _ bool) error {
+				children = append(children, h)
+				return nil
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				walkErr = err
+				return
+			}
+			cu := w.chunks[c.Hash()]
+			cu.size = uint32(len(c.Data()))
+			cu.leaf = len(children) == 0
+			w.chunks[c.Hash()] = cu
+			if !cu.leaf && w.cachedChildren+len(children) <= maxCachedChildren {
+				w.children[c.Hash()] = children
+				w.cachedChildren += len(children)
+			}
+			addChildren(children)
+		})
+		if err != nil {
+			return err
+		}
+		if walkErr != nil {
+			return walkErr
+		}
+
+		for h := range toRead {
+			cu := w.chunks[h]
+			if cu.size == 0 {
+				// chunks missing from the store, like the ghost chunks of shallow clones, are never read again
+				cu.leaf = true
+			}
+			visit(h, &cu)
+			w.chunks[h] = cu
+		}
+		pending = next
+	}
+	return nil
+}
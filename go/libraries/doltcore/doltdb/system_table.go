@@ -124,6 +124,11 @@ var getWriteableSystemTables = func() []string {
 		SchemasTableName,
 		ProceduresTableName,
 		IgnoreTableName,
+		GetMergeResolversTableName(),
+		GetRowPoliciesTableName(),
+		GetColumnMasksTableName(),
+		GetBranchProtectionTableName(),
+		GetMaterializedViewsTableName(),
 		GetRebaseTableName(),
 		GetQueryCatalogTableName(),
 		GetTestsTableName(),
@@ -160,6 +165,13 @@ func GeneratedSystemTableNames() []string {
 		GetBackupsTableName(),
 		GetStashesTableName(),
 		GetBranchActivityTableName(),
+		GetWebhookDeliveriesTableName(),
+		GetBranchApprovalsTableName(),
+		GetCIRunsTableName(),
+		GetCIStepResultsTableName(),
+		GetStorageUsageTableName(),
+		GetPurgedCommitsTableName(),
+		GetChangeConsumersTableName(),
 		// [dtables.StatusTable] now uses [adapters.DoltTableAdapterRegistry] in its constructor for Doltgres.
 		StatusTableName,
 		StatusIgnoredTableName,
@@ -233,6 +245,101 @@ const (
 	NonlocalTablesOptionsCol = "options"
 )
 
+const (
+	// MergeResolversTableName is the name of the table declaring custom merge conflict resolvers
+	MergeResolversTableName = "dolt_merge_resolvers"
+
+	// MergeResolversTableNameCol is the name of the column containing the table a resolver applies to
+	MergeResolversTableNameCol = "table_name"
+
+	// MergeResolversColumnNameCol is the name of the column containing the column a resolver applies to, or
+	// MergeResolverRowLevel for a resolver that applies to entire rows
+	MergeResolversColumnNameCol = "column_name"
+
+	// MergeResolversStrategyCol is the name of the column containing the resolution strategy
+	MergeResolversStrategyCol = "strategy"
+
+	// MergeResolversArgumentCol is the name of the column containing the optional strategy argument
+	MergeResolversArgumentCol = "argument"
+)
+
+const (
+	// MaterializedViewsTableName is the name of the table declaring materialized views
+	MaterializedViewsTableName = "dolt_materialized_views"
+
+	// MaterializedViewsNameCol is the name of the column containing the name of a materialized view, which is also
+	// the name of the table storing its result
+	MaterializedViewsNameCol = "view_name"
+
+	// MaterializedViewsDefinitionCol is the name of the column containing the SELECT statement defining a
+	// materialized view
+	MaterializedViewsDefinitionCol = "definition"
+)
+
+const (
+	// RowPoliciesTableName is the name of the table declaring row-level security policies
+	RowPoliciesTableName = "dolt_row_policies"
+
+	// RowPoliciesTableNameCol is the name of the column containing the table a policy applies to
+	RowPoliciesTableNameCol = "table_name"
+
+	// RowPoliciesPolicyNameCol is the name of the column containing the name of a policy
+	RowPoliciesPolicyNameCol = "policy_name"
+
+	// RowPoliciesCommandCol is the name of the column containing the statements a policy applies to
+	RowPoliciesCommandCol = "command"
+
+	// RowPoliciesGranteeCol is the name of the column containing the user or role a policy applies to, or
+	// RowPolicyGranteeAll for a policy that applies to every user
+	RowPoliciesGranteeCol = "grantee"
+
+	// RowPoliciesFilterCol is the name of the column containing the boolean expression selecting the rows a policy
+	// grants access to
+	RowPoliciesFilterCol = "filter"
+)
+
+const (
+	// ColumnMasksTableName is the name of the table declaring the masks applied to column values
+	ColumnMasksTableName = "dolt_column_masks"
+
+	// ColumnMasksTableNameCol is the name of the column containing the table a mask applies to
+	ColumnMasksTableNameCol = "table_name"
+
+	// ColumnMasksColumnNameCol is the name of the column containing the column a mask applies to
+	ColumnMasksColumnNameCol = "column_name"
+
+	// ColumnMasksStrategyCol is the name of the column containing the masking strategy
+	ColumnMasksStrategyCol = "strategy"
+
+	// ColumnMasksArgumentCol is the name of the column containing the optional strategy argument
+	ColumnMasksArgumentCol = "argument"
+)
+
+const (
+	// BranchProtectionTableName is the name of the table declaring the rules protecting branches
+	BranchProtectionTableName = "dolt_branch_protection"
+
+	// BranchProtectionBranchPatternCol is the name of the column containing the pattern of the branches a rule protects
+	BranchProtectionBranchPatternCol = "branch_pattern"
+
+	// BranchProtectionBlockDirectCommitsCol is the name of the column declaring whether changes must be merged
+	BranchProtectionBlockDirectCommitsCol = "block_direct_commits"
+
+	// BranchProtectionRequiredWorkflowsCol is the name of the column containing the comma-separated dolt ci workflows
+	// that must pass on merged commits
+	BranchProtectionRequiredWorkflowsCol = "required_workflows"
+
+	// BranchProtectionRequiredApprovalsCol is the name of the column containing the number of approvals merged
+	// commits need
+	BranchProtectionRequiredApprovalsCol = "required_approvals"
+
+	// BranchProtectionAllowForcePushCol is the name of the column declaring whether a branch may be force pushed
+	BranchProtectionAllowForcePushCol = "allow_force_push"
+
+	// BranchApprovalsTableName is the name of the table recording the approvals of commits
+	BranchApprovalsTableName = "dolt_branch_approvals"
+)
+
 const (
 	// SchemasTableName is the name of the dolt schema fragment table
 	SchemasTableName = "dolt_schemas"
@@ -391,6 +498,18 @@ var GetQueryCatalogTableName = func() string { return DoltQueryCatalogTableName
 
 var GetNonlocalTablesTableName = func() string { return NonlocalTableName }
 
+var GetMergeResolversTableName = func() string { return MergeResolversTableName }
+
+var GetRowPoliciesTableName = func() string { return RowPoliciesTableName }
+
+var GetColumnMasksTableName = func() string { return ColumnMasksTableName }
+
+var GetBranchProtectionTableName = func() string { return BranchProtectionTableName }
+
+var GetMaterializedViewsTableName = func() string { return MaterializedViewsTableName }
+
+var GetBranchApprovalsTableName = func() string { return BranchApprovalsTableName }
+
 var GetTestsTableName = func() string {
 	return TestsTableName
 }
@@ -399,6 +518,30 @@ var GetBranchActivityTableName = func() string {
 	return BranchActivityTableName
 }
 
+var GetWebhookDeliveriesTableName = func() string {
+	return WebhookDeliveriesTableName
+}
+
+var GetCIRunsTableName = func() string {
+	return CIRunsTableName
+}
+
+var GetCIStepResultsTableName = func() string {
+	return CIStepResultsTableName
+}
+
+var GetStorageUsageTableName = func() string {
+	return StorageUsageTableName
+}
+
+var GetPurgedCommitsTableName = func() string {
+	return PurgedCommitsTableName
+}
+
+var GetChangeConsumersTableName = func() string {
+	return ChangeConsumersTableName
+}
+
 const (
 	// LogTableName is the log system table name
 	LogTableName = "dolt_log"
@@ -462,6 +605,24 @@ const (
 
 	// BranchActivityTableName is the branch activity system table name
 	BranchActivityTableName = "dolt_branch_activity"
+
+	// WebhookDeliveriesTableName is the webhook deliveries system table name
+	WebhookDeliveriesTableName = "dolt_webhook_deliveries"
+
+	// CIRunsTableName is the system table name for the history of dolt ci workflow runs
+	CIRunsTableName = "dolt_ci_runs"
+
+	// CIStepResultsTableName is the system table name for the step results of dolt ci workflow runs
+	CIStepResultsTableName = "dolt_ci_step_results"
+
+	// StorageUsageTableName is the system table name for the storage used by every ref and table
+	StorageUsageTableName = "dolt_storage_usage"
+
+	// PurgedCommitsTableName is the system table name for the commits rewritten by purges of rows
+	PurgedCommitsTableName = "dolt_purged_commits"
+
+	// ChangeConsumersTableName is the system table name for the positions of the consumers of dolt_changes_since
+	ChangeConsumersTableName = "dolt_change_consumers"
 )
 
 // DoltGeneratedTableNames is a list of all the generated dolt system tables that are not specific to a user table.
@@ -0,0 +1,42 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// readTextField reads the TEXT field |i| of |tup|. TEXT values may be stored outside of the tuple, so they can't be
+// read with val.TupleDesc.GetString. If the field is NULL, |ok| is false.
+func readTextField(ctx context.Context, desc *val.TupleDesc, i int, tup val.Tuple, ns tree.NodeStore) (s string, ok bool, err error) {
+	v, err := tree.GetField(ctx, desc, i, tup, ns)
+	if err != nil || v == nil {
+		return "", false, err
+	}
+	s, ok, err = sql.Unwrap[string](ctx, v)
+	if err != nil {
+		return "", false, err
+	}
+	if !ok {
+		return "", false, fmt.Errorf("unexpected type %T for a TEXT field", v)
+	}
+	return s, true, nil
+}
@@ -199,7 +199,7 @@ func (mr *MultiRepoTestSetup) CloneDB(fromRemote, dbName string) {
 		mr.Errhand(err)
 	}
 
-	err = actions.CloneRemote(ctx, srcDB, r.Name, "", false, -1, dEnv)
+	err = actions.CloneRemote(ctx, srcDB, r.Name, "", false, -1, doltdb.SparseFilter{}, nil, dEnv)
 	if err != nil {
 		mr.Errhand(err)
 	}
@@ -0,0 +1,62 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtestutils
+
+import (
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+// MustSqlColumn returns a column of the SQL type |t|, and panics if it can't be created.
+func MustSqlColumn(name string, tag uint64, t sql.Type, pk bool, constraints ...schema.ColConstraint) schema.Column {
+	ti, err := typeinfo.FromSqlType(t)
+	if err != nil {
+		panic(err)
+	}
+	col, err := schema.NewColumnWithTypeInfo(name, tag, ti, pk, "", false, "", constraints...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// TypedSampleSchema is the schema of TypedSampleRows. Its columns cover the SQL types that typed file formats, such
+// as Arrow and Avro, have to round-trip, and a column name that isn't a valid identifier in every format.
+var TypedSampleSchema = schema.MustSchemaFromCols(schema.NewColCollection(
+	MustSqlColumn("name", 0, gmstypes.MustCreateStringWithDefaults(sqltypes.VarChar, 64), true, schema.NotNullConstraint{}),
+	MustSqlColumn("age", 1, gmstypes.Uint64, false),
+	MustSqlColumn("salary", 2, gmstypes.MustCreateDecimalType(10, 2), false),
+	MustSqlColumn("born", 3, gmstypes.Date, false),
+	MustSqlColumn("title", 4, gmstypes.Text, false),
+	MustSqlColumn("nick name", 5, gmstypes.Text, false),
+	MustSqlColumn("shift", 6, gmstypes.Time, false),
+))
+
+// TypedSampleRows returns rows of TypedSampleSchema, with null values in every nullable column.
+func TypedSampleRows() []sql.Row {
+	born := time.Date(1990, 3, 14, 0, 0, 0, 0, time.UTC)
+	return []sql.Row{
+		{"Bill Billerson", uint64(32), "1234.50", born, "Senior Dufus", "Billy", "08:30:00"},
+		{"Rob Robertson", uint64(25), "99.99", born, "Dufus", nil, "-12:00:00.5"},
+		{"John Johnson", uint64(21), nil, nil, "", nil, nil},
+		{"Andy Anderson", nil, nil, nil, nil, nil, nil},
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -165,8 +166,10 @@ func sortedKeys(m map[string]iohelp.ReadStats) []string {
 // CloneRemote - common entry point for both dolt_clone() and `dolt clone`
 // The database must be initialized with a remote before calling this function.
 //
-// The `branch` parameter is the branch to clone. If it is empty, the default branch is used.
-func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch bool, depth int, dEnv *env.DoltEnv) error {
+// The `branch` parameter is the branch to clone. If it is empty, the default branch is used. If `sparse` isn't empty,
+// only the data of the tables it includes is pulled, and the rest is fetched from the remote when it's first read. If
+// `lazy` isn't nil, no data is pulled at all, and every chunk is read from the remote when it's needed.
+func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch bool, depth int, sparse doltdb.SparseFilter, lazy *doltdb.LazyFetchConfig, dEnv *env.DoltEnv) error {
 	// We support two forms of cloning: full and shallow. These two approaches have little in common, with the exception
 	// of the first and last steps. Determining the branch to check out and setting the working set to the checked out commit.
 
@@ -186,11 +189,39 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 
 	var checkedOutCommit *doltdb.Commit
 
+	if !sparse.IsEmpty() {
+		// The filter must be set before pulling, since every pull into a sparse clone is filtered.
+		sparse.Remote = remoteName
+		err = dEnv.DoltDB(ctx).SetSparseFilter(ctx, sparse)
+		if err == nil {
+			err = dEnv.ConfigureSparseFetch(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+		}
+	} else if lazy != nil {
+		// Once the remote is the lazy source, every chunk of the remote is present in the clone, so pulling fetches nothing.
+		lazyCfg := *lazy
+		lazyCfg.Remote = remoteName
+		err = dEnv.DoltDB(ctx).SetLazyFetchConfig(ctx, lazyCfg)
+		if err == nil {
+			err = dEnv.ConfigureLazyFetch(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+		}
+	}
+
 	// Step 1) Pull the remote information we care about to a local disk.
-	if depth <= 0 {
-		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
-	} else {
+	if depth > 0 {
 		checkedOutCommit, err = shallowCloneDataPull(ctx, dEnv.DbData(ctx), srcDB, remoteName, branch, depth)
+	} else if !sparse.IsEmpty() || lazy != nil {
+		checkedOutCommit, err = pullCloneData(ctx, dEnv.DbData(ctx), srcDB, remoteName, branch, singleBranch)
+		if err == nil && lazy != nil {
+			err = setLazyCloneTags(ctx, dEnv.DoltDB(ctx), srcRefHashes)
+		}
+	} else {
+		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
 	}
 
 	if err != nil {
@@ -367,6 +398,109 @@ func shallowCloneDataPull[C doltdb.Context](ctx C, destData env.DbData[C], srcDB
 	return cmt, nil
 }
 
+// SparseFilterFromArgs returns the SparseFilter of a clone given the --tables and --exclude-tables arguments in |apr|.
+func SparseFilterFromArgs(apr *argparser.ArgParseResults) doltdb.SparseFilter {
+	var filter doltdb.SparseFilter
+	if tables, ok := apr.GetValueList(cli.TablesFlag); ok {
+		filter.Tables = trimTableNames(tables)
+	}
+	if tables, ok := apr.GetValueList(cli.ExcludeTablesFlag); ok {
+		filter.ExcludeTables = trimTableNames(tables)
+	}
+	return filter
+}
+
+// LazyFetchConfigFromArgs returns the LazyFetchConfig of a clone given the --lazy and --lazy-cache-size arguments in
+// |apr|, or nil if the clone isn't lazy.
+func LazyFetchConfigFromArgs(apr *argparser.ArgParseResults) (*doltdb.LazyFetchConfig, error) {
+	if !apr.Contains(cli.LazyFlag) {
+		if apr.Contains(cli.LazyCacheSizeFlag) {
+			return nil, fmt.Errorf("--%s can only be used with --%s", cli.LazyCacheSizeFlag, cli.LazyFlag)
+		}
+		return nil, nil
+	}
+	for _, flag := range []string{cli.DepthFlag, cli.TablesFlag, cli.ExcludeTablesFlag} {
+		if apr.Contains(flag) {
+			return nil, fmt.Errorf("--%s cannot be used with --%s", cli.LazyFlag, flag)
+		}
+	}
+	var lazyCfg doltdb.LazyFetchConfig
+	if size, ok := apr.GetUint(cli.LazyCacheSizeFlag); ok {
+		if size == 0 {
+			return nil, fmt.Errorf("--%s must be greater than 0", cli.LazyCacheSizeFlag)
+		}
+		lazyCfg.CacheSizeMB = size
+	}
+	return &lazyCfg, nil
+}
+
+func trimTableNames(names []string) []string {
+	trimmed := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			trimmed = append(trimmed, name)
+		}
+	}
+	return trimmed
+}
+
+// pullCloneData is a helper function for sparse and lazy clones to fetch the branches of the remote, or only |branch|
+// if |singleBranch| is set, and create the local |branch|. Unlike a full clone, which copies the remote's table files,
+// the chunks are pulled, so that the data left out of the clone can be skipped.
+func pullCloneData[C doltdb.Context](ctx C, destData env.DbData[C], srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch bool) (*doltdb.Commit, error) {
+	remotes, err := destData.Rsr.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+	remote, ok := remotes.Get(remoteName)
+	if !ok {
+		// By the time we get to this point, the remote should be created, so this should never happen.
+		return nil, fmt.Errorf("remote %s not found", remoteName)
+	}
+
+	var args []string
+	if singleBranch {
+		args = []string{branch}
+	}
+	specs, defaultSpecs, err := env.ParseRefSpecs(args, destData.Rsr, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fetchRefSpecsWithDepth(ctx, destData, srcDB, specs, defaultSpecs, &remote, ref.ForceUpdate, -1, NoopRunProgFuncs, NoopStopProgFuncs)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteRef := ref.NewRemoteRef(remoteName, branch)
+	cmt, err := destData.Ddb.ResolveCommitRef(ctx, remoteRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// This is the only local branch after the clone is complete.
+	err = destData.Ddb.SetHeadToCommit(ctx, ref.NewBranchRef(branch), cmt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateLocalBranch, branch, err.Error())
+	}
+
+	return cmt, nil
+}
+
+// setLazyCloneTags creates the tags of the remote in a lazy clone. Fetching follows only the tags whose chunks the
+// destination doesn't have, and a lazy clone has every chunk of its remote.
+func setLazyCloneTags(ctx context.Context, ddb *doltdb.DoltDB, srcRefHashes []doltdb.RefWithHash) error {
+	for _, srcRef := range srcRefHashes {
+		if srcRef.Ref.GetType() != ref.TagRefType {
+			continue
+		}
+		if err := ddb.SetHead(ctx, srcRef.Ref, srcRef.Hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // InitEmptyClonedRepo inits an empty, newly cloned repo. This would be unnecessary if we properly initialized the
 // storage for a repository when we created it on dolthub. If we do that, this code can be removed.
 func InitEmptyClonedRepo(ctx context.Context, dEnv *env.DoltEnv) error {
@@ -90,6 +90,30 @@ var _ Step = (*DoltTestStep)(nil)
 
 func (s *DoltTestStep) GetName() string { return s.Name.Value }
 
+// TestNames returns the names of the tests selected by the step.
+func (s *DoltTestStep) TestNames() []string { return nodesToValues(s.Tests) }
+
+// GroupNames returns the names of the test groups selected by the step.
+func (s *DoltTestStep) GroupNames() []string { return nodesToValues(s.TestGroups) }
+
+// TestsWildcard returns whether the step selects tests with the "*" wildcard.
+func (s *DoltTestStep) TestsWildcard() bool { return hasWildcard(s.Tests) }
+
+// GroupsWildcard returns whether the step selects test groups with the "*" wildcard.
+func (s *DoltTestStep) GroupsWildcard() bool { return hasWildcard(s.TestGroups) }
+
+func hasWildcard(nodes []yaml.Node) bool {
+	return len(nodes) == 1 && strings.TrimSpace(nodes[0].Value) == "*"
+}
+
+func nodesToValues(nodes []yaml.Node) []string {
+	var vals []string
+	for _, n := range nodes {
+		vals = append(vals, n.Value)
+	}
+	return vals
+}
+
 func (s *Steps) UnmarshalYAML(value *yaml.Node) error {
 	if value == nil {
 		*s = nil
@@ -220,6 +244,23 @@ type WorkflowConfig struct {
 	Jobs []Job     `yaml:"jobs"`
 }
 
+// TriggeredByPush returns whether the workflow runs when |branch| is pushed to. A push trigger without any branches
+// matches every branch.
+func (w *WorkflowConfig) TriggeredByPush(branch string) bool {
+	if w.On.Push == nil {
+		return false
+	}
+	if len(w.On.Push.Branches) == 0 {
+		return true
+	}
+	for _, b := range w.On.Push.Branches {
+		if b.Value == branch {
+			return true
+		}
+	}
+	return false
+}
+
 func ParseWorkflowConfig(r io.Reader) (workflow *WorkflowConfig, err error) {
 	workflow = &WorkflowConfig{}
 
@@ -195,3 +195,30 @@ jobs:
 	err = ValidateWorkflowConfig(wf)
 	require.NoError(t, err)
 }
+
+func TestWorkflowTriggeredByPush(t *testing.T) {
+	parse := func(on string) *WorkflowConfig {
+		yml := "name: wf\non:\n" + on + `
+jobs:
+  - name: job
+    steps:
+      - name: step
+        saved_query_name: sq
+`
+		wf, err := ParseWorkflowConfig(strings.NewReader(yml))
+		require.NoError(t, err)
+		return wf
+	}
+
+	wf := parse("  push:\n    branches:\n      - main\n      - release\n")
+	require.True(t, wf.TriggeredByPush("main"))
+	require.True(t, wf.TriggeredByPush("release"))
+	require.False(t, wf.TriggeredByPush("feature"))
+
+	wf = parse("  push: {}\n")
+	require.True(t, wf.TriggeredByPush("main"))
+	require.True(t, wf.TriggeredByPush("feature"))
+
+	wf = parse("  workflow_dispatch: {}\n")
+	require.False(t, wf.TriggeredByPush("main"))
+}
@@ -0,0 +1,379 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dolt_ci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// maxFailingRows is the maximum number of rows kept in the result of a failed step.
+const maxFailingRows = 10
+
+// StepResult is the result of running a single step of a workflow job.
+type StepResult struct {
+	Job        string
+	Step       string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Query is the query run by a saved query step, or the queries of the failed tests of a dolt test step, one per
+	// line.
+	Query string
+	// Err is the reason the step failed, or nil if it passed.
+	Err error
+	// FailingRows are the rows explaining a failure: the rows returned by a saved query whose assertions failed, or
+	// the failed tests of a dolt test step.
+	FailingRows []map[string]string
+}
+
+// Passed returns whether the step passed.
+func (r StepResult) Passed() bool {
+	return r.Err == nil
+}
+
+// WorkflowResult is the result of running every job of a workflow.
+type WorkflowResult struct {
+	Workflow   string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Steps      []StepResult
+}
+
+// Passed returns whether every step of the workflow passed.
+func (r *WorkflowResult) Passed() bool {
+	for _, step := range r.Steps {
+		if !step.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// RunWorkflow runs every step of every job of |config| using |queryist|, and returns the result of each step. A step
+// failing doesn't stop the rest of the workflow from running. An error is returned only if the workflow could not
+// be run at all.
+func RunWorkflow(sqlCtx *sql.Context, queryist cli.Queryist, config *WorkflowConfig) (*WorkflowResult, error) {
+	savedQueries, err := GetSavedQueries(sqlCtx, queryist)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WorkflowResult{Workflow: config.Name.Value, StartedAt: time.Now()}
+	for _, job := range config.Jobs {
+		for _, step := range job.Steps {
+			stepResult := StepResult{Job: job.Name.Value, Step: step.GetName(), StartedAt: time.Now()}
+			switch st := step.(type) {
+			case *SavedQueryStep:
+				stepResult.Query = savedQueries[st.SavedQueryName.Value]
+				stepResult.FailingRows, stepResult.Err = runSavedQueryStep(sqlCtx, queryist, st, stepResult.Query)
+			case *DoltTestStep:
+				stepResult.FailingRows, stepResult.Query, stepResult.Err = runDoltTestStep(sqlCtx, queryist, st)
+			default:
+				stepResult.Err = fmt.Errorf("unsupported step type for step: %s", step.GetName())
+			}
+			stepResult.FinishedAt = time.Now()
+			result.Steps = append(result.Steps, stepResult)
+		}
+	}
+	result.FinishedAt = time.Now()
+	return result, nil
+}
+
+func runSavedQueryStep(sqlCtx *sql.Context, queryist cli.Queryist, step *SavedQueryStep, query string) ([]map[string]string, error) {
+	if query == "" {
+		return nil, fmt.Errorf("Could not find saved query: %s", step.SavedQueryName.Value)
+	}
+	sch, rowIter, _, err := queryist.Query(sqlCtx, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := sql.RowIterToRows(sqlCtx, rowIter)
+	if err != nil {
+		return nil, err
+	}
+
+	assertErr := AssertSavedQueryRows(rows, step.ExpectedRows.Value, step.ExpectedColumns.Value)
+	if assertErr == nil {
+		return nil, nil
+	}
+
+	failingRows := make([]map[string]string, 0, min(len(rows), maxFailingRows))
+	for _, row := range rows[:min(len(rows), maxFailingRows)] {
+		failingRow := make(map[string]string, len(sch))
+		for i, col := range sch {
+			// binary values can't be shown, and are left empty
+			failingRow[col.Name], _ = toUtf8StringValue(sqlCtx, col, row[i])
+		}
+		failingRows = append(failingRows, failingRow)
+	}
+	return failingRows, assertErr
+}
+
+func runDoltTestStep(sqlCtx *sql.Context, queryist cli.Queryist, step *DoltTestStep) ([]map[string]string, string, error) {
+	rows, err := ResolveDoltTestRows(sqlCtx, queryist, step)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var failingRows []map[string]string
+	var failures, failedQueries []string
+	for _, row := range rows {
+		testName, err := ColumnValueAsString(sqlCtx, row[0])
+		if err != nil {
+			return nil, "", err
+		}
+		groupName, err := ColumnValueAsString(sqlCtx, row[1])
+		if err != nil {
+			return nil, "", err
+		}
+		query, err := ColumnValueAsString(sqlCtx, row[2])
+		if err != nil {
+			return nil, "", err
+		}
+		status, err := ColumnValueAsString(sqlCtx, row[3])
+		if err != nil {
+			return nil, "", err
+		}
+		message, err := ColumnValueAsString(sqlCtx, row[4])
+		if err != nil {
+			return nil, "", err
+		}
+		if strings.ToUpper(status) == "PASS" {
+			continue
+		}
+		if message == "" {
+			message = "failed"
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", testName, message))
+		failedQueries = append(failedQueries, query)
+		if len(failingRows) < maxFailingRows {
+			failingRows = append(failingRows, map[string]string{"test": testName, "group": groupName, "message": message})
+		}
+	}
+	if len(failures) > 0 {
+		return failingRows, strings.Join(failedQueries, "\n"), errors.New(strings.Join(failures, "; "))
+	}
+	return nil, "", nil
+}
+
+// AssertSavedQueryRows checks the |rows| returned by a saved query against the unparsed expected row and column
+// counts of its step, and returns an error describing every assertion that failed.
+func AssertSavedQueryRows(rows []sql.Row, expectedRowsAndComparison string, expectedColumnsAndComparison string) error {
+	var colCount int64
+	var errs []string
+	rowCount := int64(len(rows))
+	if rowCount > 0 {
+		colCount = int64(len(rows[0]))
+	}
+
+	colCompType, expectedCols, err := ParseSavedQueryExpectedResultString(expectedColumnsAndComparison)
+	if colCompType != WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
+		err = ValidateQueryExpectedRowOrColumnCount(colCount, expectedCols, colCompType, "column")
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Assertion failed: %s", err.Error()))
+		}
+	}
+	rowCompType, expectedRows, err := ParseSavedQueryExpectedResultString(expectedRowsAndComparison)
+	if rowCompType != WorkflowSavedQueryExpectedRowColumnComparisonTypeUnspecified {
+		err = ValidateQueryExpectedRowOrColumnCount(rowCount, expectedRows, rowCompType, "row")
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("Assertion failed: %s", err.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// ResolveDoltTestRows runs the dolt tests selected by |step| and returns the dolt_test_run rows of every selected
+// test. Tests may be selected by name, by group, or by both, in which case only the named tests of each group are
+// selected. A "*" wildcard selects every test or group.
+func ResolveDoltTestRows(sqlCtx *sql.Context, queryist cli.Queryist, step *DoltTestStep) ([]sql.Row, error) {
+	testsProvided := len(step.Tests) > 0
+	groupsProvided := len(step.TestGroups) > 0
+	testsWildcard := testsProvided && step.TestsWildcard()
+	groupsWildcard := groupsProvided && step.GroupsWildcard()
+
+	switch {
+	case !testsProvided && !groupsProvided:
+		return getAllDoltTestRunRows(sqlCtx, queryist)
+
+	case testsProvided && !groupsProvided:
+		if testsWildcard {
+			return getAllDoltTestRunRows(sqlCtx, queryist)
+		}
+		return collectRowsForSelectors(sqlCtx, queryist, "test", step.TestNames())
+
+	case groupsProvided && !testsProvided:
+		if groupsWildcard {
+			return getAllDoltTestRunRows(sqlCtx, queryist)
+		}
+		return collectRowsForSelectors(sqlCtx, queryist, "group", step.GroupNames())
+
+	default: // both provided
+		if testsWildcard && !groupsWildcard {
+			// All tests in specified groups
+			return collectRowsForSelectors(sqlCtx, queryist, "group", step.GroupNames())
+		}
+		if groupsWildcard && !testsWildcard {
+			// Only specified test names across all groups
+			return collectRowsForSelectors(sqlCtx, queryist, "test", step.TestNames())
+		}
+		// Neither wildcard: intersection
+		return collectIntersectionRows(sqlCtx, queryist, step.TestNames(), step.GroupNames())
+	}
+}
+
+// collectRowsForSelectors fetches rows for each selector using dolt_test_run('<selector>').
+// kind should be "test" or "group" to produce specific error messages if an empty result is somehow returned without error.
+func collectRowsForSelectors(sqlCtx *sql.Context, queryist cli.Queryist, kind string, selectors []string) ([]sql.Row, error) {
+	var allRows []sql.Row
+	for _, sel := range selectors {
+		rows, err := fetchDoltTestRunRows(sqlCtx, queryist, sel)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			// dolt_test_run should return an error in this scenario; this is a defensive fallback
+			if kind == "test" {
+				return nil, fmt.Errorf("test '%s' not found", sel)
+			}
+			return nil, fmt.Errorf("group '%s' not found", sel)
+		}
+		allRows = append(allRows, rows...)
+	}
+	return allRows, nil
+}
+
+// collectIntersectionRows returns only the rows for the specified tests within each specified group.
+// It also verifies that each named test exists within every specified group.
+func collectIntersectionRows(sqlCtx *sql.Context, queryist cli.Queryist, testNames, groupNames []string) ([]sql.Row, error) {
+	var allRows []sql.Row
+	for _, group := range groupNames {
+		rows, err := fetchDoltTestRunRows(sqlCtx, queryist, group)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, fmt.Errorf("group '%s' not found", group)
+		}
+		groupTests := make(map[string]bool)
+		for _, r := range rows {
+			tName, err := ColumnValueAsString(sqlCtx, r[0])
+			if err != nil {
+				return nil, err
+			}
+			groupTests[tName] = true
+		}
+		// verify requested tests exist in this group
+		for _, t := range testNames {
+			if !groupTests[t] {
+				return nil, fmt.Errorf("test '%s' not found in group '%s'", t, group)
+			}
+		}
+		// filter rows to only requested tests
+		for _, r := range rows {
+			tName, err := ColumnValueAsString(sqlCtx, r[0])
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range testNames {
+				if tName == t {
+					allRows = append(allRows, r)
+					break
+				}
+			}
+		}
+	}
+	return allRows, nil
+}
+
+// fetchDoltTestRunRows runs dolt_test_run for the provided selector (test or group value)
+func fetchDoltTestRunRows(sqlCtx *sql.Context, queryist cli.Queryist, selector string) ([]sql.Row, error) {
+	q := fmt.Sprintf("SELECT * FROM dolt_test_run('%s')", strings.ReplaceAll(selector, "'", "''"))
+	return cli.GetRowsForSql(queryist, sqlCtx, q)
+}
+
+// getAllDoltTestRunRows runs dolt_test_run() with no arguments to return all rows
+func getAllDoltTestRunRows(sqlCtx *sql.Context, queryist cli.Queryist) ([]sql.Row, error) {
+	return cli.GetRowsForSql(queryist, sqlCtx, "SELECT * FROM dolt_test_run()")
+}
+
+// GetSavedQueries returns the statements of the saved queries in the dolt_query_catalog table, by name. A database
+// without the table has no saved queries.
+func GetSavedQueries(sqlCtx *sql.Context, queryist cli.Queryist) (map[string]string, error) {
+	savedQueries := make(map[string]string)
+	resetFunc, err := cli.SetSystemVar(queryist, sqlCtx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	_, rowIter, _, err := queryist.Query(sqlCtx, "SHOW TABLES LIKE 'dolt_query_catalog'")
+	if err != nil {
+		return nil, err
+	}
+	if resetFunc != nil {
+		err = resetFunc()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := sql.RowIterToRows(sqlCtx, rowIter)
+	if len(rows) > 0 {
+		_, rowIter, _, err = queryist.Query(sqlCtx, "SELECT * FROM dolt_query_catalog")
+		if err != nil {
+			return nil, err
+		}
+		rows, err = sql.RowIterToRows(sqlCtx, rowIter)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			var queryName, queryStatement string
+			queryName, err = ColumnValueAsString(sqlCtx, row[2])
+			if err != nil {
+				return nil, err
+			}
+			queryStatement, err := ColumnValueAsString(sqlCtx, row[3])
+			if err != nil {
+				return nil, err
+			}
+			savedQueries[queryName] = queryStatement
+		}
+	}
+	return savedQueries, nil
+}
+
+// ColumnValueAsString returns the value of a string column of a query result. The dolt_query_catalog system table
+// returns *val.TextStorage types under certain situations, so those are unwrapped.
+func ColumnValueAsString(sqlCtx *sql.Context, tableValue interface{}) (string, error) {
+	if ts, ok := tableValue.(*val.TextStorage); ok {
+		return ts.Unwrap(sqlCtx)
+	} else if str, ok := tableValue.(string); ok {
+		return str, nil
+	} else {
+		return "", fmt.Errorf("unexpected type %T, was expecting string", tableValue)
+	}
+}
@@ -62,6 +62,10 @@ func InferColumnTypesFromTableReader(ctx context.Context, rd table.ReadCloser, a
 	// skip through the file in an exponential manner
 	const exp = 1.02
 
+	if trd, ok := rd.(table.TypedReader); ok && trd.HasDeclaredTypes() {
+		return declaredColumnTypes(trd.GetSchema(), args), nil
+	}
+
 	var curr, prev row.Row
 	i := newInferrer(rd.GetSchema(), args)
 OUTER:
@@ -93,6 +97,21 @@ OUTER:
 	return i.inferColumnTypes()
 }
 
+// declaredColumnTypes returns the columns of |readerSch|, whose types were declared by the source being read, renamed
+// by the name mapper of |args|.
+func declaredColumnTypes(readerSch schema.Schema, args InferenceArgs) *schema.ColCollection {
+	mapper := args.ColNameMapper()
+	var cols []schema.Column
+	_ = readerSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		col.Name = mapper.Map(col.Name)
+		col.Tag = schema.ReservedTagMin + tag
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	return schema.NewColCollection(cols...)
+}
+
 type inferrer struct {
 	readerSch      schema.Schema
 	inferSets      map[uint64]typeInfoSet
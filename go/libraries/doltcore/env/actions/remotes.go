@@ -830,6 +830,22 @@ func SyncRoots(ctx context.Context, srcDb, destDb *doltdb.DoltDB, tempTableDir s
 		return nil
 	}
 
+	return syncRoots(ctx, srcDb, destDb, srcRoot, true, tempTableDir, progStarter, progStopper)
+}
+
+// SyncRootsAt is like SyncRoots, but rewrites the destination manifest to |srcRoot|, an earlier root of |srcDb| whose
+// chunks are still held by |srcDb|. Used to restore backups to an earlier point in time.
+func SyncRootsAt(ctx context.Context, srcDb, destDb *doltdb.DoltDB, srcRoot hash.Hash, tempTableDir string, progStarter ProgStarter, progStopper ProgStopper) error {
+	curRoot, err := srcDb.NomsRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Cloning copies the table files of |srcDb| along with its current root, so it can only be used for that root.
+	return syncRoots(ctx, srcDb, destDb, srcRoot, srcRoot == curRoot, tempTableDir, progStarter, progStopper)
+}
+
+func syncRoots(ctx context.Context, srcDb, destDb *doltdb.DoltDB, srcRoot hash.Hash, allowClone bool, tempTableDir string, progStarter ProgStarter, progStopper ProgStopper) error {
 	destRoot, err := destDb.NomsRoot(ctx)
 	if err != nil {
 		return err
@@ -848,9 +864,12 @@ func SyncRoots(ctx context.Context, srcDb, destDb *doltdb.DoltDB, tempTableDir s
 		}
 	}()
 
-	canClone, err := canSyncRootsWithClone(ctx, srcDb, destDb, destRoot)
-	if err != nil {
-		return err
+	canClone := false
+	if allowClone {
+		canClone, err = canSyncRootsWithClone(ctx, srcDb, destDb, destRoot)
+		if err != nil {
+			return err
+		}
 	}
 
 	if canClone {
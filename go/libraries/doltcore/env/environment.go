@@ -33,6 +33,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/grpcendpoint"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/utils/concurrentmap"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
@@ -52,6 +53,8 @@ const (
 
 	tempTablesDir = "temptf"
 
+	lazyCacheDir = "lazy_cache"
+
 	TmpDirName = "tmp"
 
 	InvalidRemoteNameCharacters = " \t\n\r./\\!@#$%^&*(){}[],.<>'\"?=+|"
@@ -146,6 +149,12 @@ func (dEnv *DoltEnv) LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBi
 		dEnv.doltDB = ddb
 		dEnv.urlStr = urlStr
 		dEnv.DBLoadError = nil
+		if err = dEnv.ConfigureSparseFetch(ctx); err != nil {
+			return err
+		}
+		if err = dEnv.ConfigureLazyFetch(ctx); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -175,6 +184,88 @@ func (dEnv *DoltEnv) GetRemoteDB(ctx context.Context, format *types.NomsBinForma
 	}
 }
 
+// ConfigureSparseFetch sets up the env's database, if it's a sparse clone, to fetch the table data left out of it from
+// its remote when it's first read.
+func (dEnv *DoltEnv) ConfigureSparseFetch(ctx context.Context) error {
+	ddb := dEnv.doltDB
+	if ddb == nil {
+		return nil
+	}
+	filter, ok, err := ddb.GetSparseFilter(ctx)
+	if err != nil || !ok {
+		return err
+	}
+
+	// The fetcher is never called concurrently, so the remote database is opened once without locking.
+	var srcDB *doltdb.DoltDB
+	ddb.SetSparseFetcher(func(ctx context.Context, addrs hash.HashSet) error {
+		if srcDB == nil {
+			remotes, err := dEnv.GetRemotes()
+			if err != nil {
+				return err
+			}
+			r, ok := remotes.Get(filter.Remote)
+			if !ok {
+				return fmt.Errorf("%w: '%s', which the sparse clone fetches table data from", ErrUnknownRemote, filter.Remote)
+			}
+			srcDB, err = dEnv.GetRemoteDB(ctx, ddb.Format(), r, true)
+			if err != nil {
+				return err
+			}
+		}
+		tmpDir, err := dEnv.TempTableFilesDir()
+		if err != nil {
+			return err
+		}
+		return ddb.FetchSparseChunks(ctx, tmpDir, srcDB, addrs)
+	})
+	return nil
+}
+
+// ConfigureLazyFetch sets up the env's database, if it's a lazy clone, to read the chunks it doesn't have from its
+// remote, through a disk cache in the dolt directory.
+func (dEnv *DoltEnv) ConfigureLazyFetch(ctx context.Context) error {
+	ddb := dEnv.doltDB
+	if ddb == nil {
+		return nil
+	}
+	lazyCfg, ok, err := ddb.GetLazyFetchConfig(ctx)
+	if err != nil || !ok {
+		return err
+	}
+	cacheDir, err := dEnv.LazyCacheDir()
+	if err != nil {
+		return err
+	}
+	cache, err := remotestorage.NewDiskChunkCache(cacheDir, lazyCfg.CacheSize(), ddb.ChunkEncryption())
+	if err != nil {
+		return err
+	}
+
+	src := remotestorage.NewLazySource(func(ctx context.Context) (chunks.ChunkStore, error) {
+		remotes, err := dEnv.GetRemotes()
+		if err != nil {
+			return nil, err
+		}
+		r, ok := remotes.Get(lazyCfg.Remote)
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s', which the lazy clone reads chunks from", ErrUnknownRemote, lazyCfg.Remote)
+		}
+		srcDB, err := dEnv.GetRemoteDB(ctx, ddb.Format(), r, false)
+		if err != nil {
+			return nil, err
+		}
+		return datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(srcDB)), nil
+	}, cache)
+	if err = ddb.SetLazySource(src); err != nil {
+		return err
+	}
+	// The stats are reported under the name the database has when it's served from its parent directory.
+	repoDir := filepath.Dir(filepath.Dir(cacheDir))
+	remotestorage.RegisterLazySource(dbfactory.DirToDBName(filepath.Base(repoDir)), src)
+	return nil
+}
+
 func (dEnv *DoltEnv) GetConfig() config.ReadableConfig {
 	return dEnv.Config
 }
@@ -268,6 +359,13 @@ func LoadDoltDB(ctx context.Context, fs filesys.Filesys, urlStr string, dEnv *Do
 		dEnv.DBLoadError = dbLoadErr
 		dEnv.urlStr = urlStr
 
+		if dbLoadErr == nil {
+			dEnv.DBLoadError = dEnv.ConfigureSparseFetch(ctx)
+		}
+		if dEnv.DBLoadError == nil {
+			dEnv.DBLoadError = dEnv.ConfigureLazyFetch(ctx)
+		}
+
 		if dbLoadErr == nil && dEnv.HasDoltDir() {
 			if !dEnv.HasDoltTempTableDir() {
 				tmpDir, err := dEnv.TempTableFilesDir()
@@ -1335,6 +1433,15 @@ func (dEnv *DoltEnv) GetUserHomeDir() (string, error) {
 	return getHomeDir(dEnv.hdp)
 }
 
+// LazyCacheDir returns the directory of the disk cache of the chunks read from a remote by a lazy clone or replica.
+func (dEnv *DoltEnv) LazyCacheDir() (string, error) {
+	doltDir := dEnv.GetDoltDir()
+	if doltDir == "" {
+		return "", ErrDoltRepositoryNotFound
+	}
+	return dEnv.FS.Abs(filepath.Join(doltDir, lazyCacheDir))
+}
+
 func (dEnv *DoltEnv) TempTableFilesDir() (string, error) {
 	doltDir := dEnv.GetDoltDir()
 	if doltDir == "" {
@@ -1820,6 +1820,8 @@ type valueMerger struct {
 	syncPool                               pool.BuffPool
 	keyless                                bool
 	ns                                     tree.NodeStore
+	// resolvers are the custom conflict resolvers declared for this table in dolt_merge_resolvers, or nil.
+	resolvers *mergeResolvers
 }
 
 func NewValueMerger(merged, leftSch, rightSch, baseSch schema.Schema, syncPool pool.BuffPool, ns tree.NodeStore) *valueMerger {
@@ -1912,7 +1914,7 @@ func (m *valueMerger) TryMerge(ctx *sql.Context, left, right, base val.Tuple) (v
 			return nil, false, err
 		}
 		if isConflict {
-			return nil, false, nil
+			return m.resolveRowConflict(ctx, left, right)
 		}
 	}
 
@@ -1928,8 +1930,14 @@ func (m *valueMerger) TryMerge(ctx *sql.Context, left, right, base val.Tuple) (v
 		if err != nil {
 			return nil, false, err
 		}
+		if isConflict && m.resolvers != nil {
+			v, isConflict, err = m.resolvers.resolveColumn(ctx, m, i, left, right, base)
+			if err != nil {
+				return nil, false, err
+			}
+		}
 		if isConflict {
-			return nil, false, nil
+			return m.resolveRowConflict(ctx, left, right)
 		}
 		mergedValues[i] = v
 	}
@@ -1937,6 +1945,15 @@ func (m *valueMerger) TryMerge(ctx *sql.Context, left, right, base val.Tuple) (v
 	return val.NewTuple(m.syncPool, mergedValues...), true, nil
 }
 
+// resolveRowConflict attempts to resolve a row that could not be merged cell-wise using the row-level resolver
+// declared in dolt_merge_resolvers, if any.
+func (m *valueMerger) resolveRowConflict(ctx *sql.Context, left, right val.Tuple) (val.Tuple, bool, error) {
+	if m.resolvers == nil {
+		return nil, false, nil
+	}
+	return m.resolvers.resolveRow(ctx, m, left, right)
+}
+
 // processBaseColumn returns whether column |i| of the base schema,
 // if removed on one side, causes a conflict when merged with the other side.
 func (m *valueMerger) processBaseColumn(ctx context.Context, i int, left, right, base val.Tuple) (conflict bool, err error) {
@@ -0,0 +1,491 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	mergeResolverBasePrefix   = "base_"
+	mergeResolverOursPrefix   = "our_"
+	mergeResolverTheirsPrefix = "their_"
+	mergeResolverResultColumn = "dolt_merge_resolved"
+)
+
+// mergeResolvers applies the custom conflict resolution strategies declared in the dolt_merge_resolvers system table
+// to a single table. Resolvers are only consulted after the cell-wise merge in valueMerger has failed, so they never
+// change the result of a merge that would have succeeded without them.
+type mergeResolvers struct {
+	tableName string
+	defs      []doltdb.MergeResolver
+
+	// The fields below are populated lazily, the first time a conflict needs to be resolved, since doing so requires
+	// a *sql.Context to resolve expressions.
+	initialized bool
+	row         *compiledResolver
+	columns     map[int]*compiledResolver
+}
+
+// compiledResolver is a dolt_merge_resolvers entry bound to the merged schema of the table being merged.
+type compiledResolver struct {
+	def doltdb.MergeResolver
+	// argIdx is the index in the merged value tuple of the column named by the argument of the latest strategy.
+	argIdx int
+	// expr is the resolved expression for the sum and expression strategies.
+	expr sql.Expression
+}
+
+// newMergeResolvers returns the resolvers for |tableName| described by |defs|, or nil if there are none.
+func newMergeResolvers(tableName string, defs []doltdb.MergeResolver) *mergeResolvers {
+	if len(defs) == 0 {
+		return nil
+	}
+	return &mergeResolvers{tableName: tableName, defs: defs}
+}
+
+// init binds each resolver definition to the merged schema of |m|, resolving any expressions. Resolvers that name a
+// column that does not exist in the merged schema are ignored.
+func (r *mergeResolvers) init(ctx *sql.Context, m *valueMerger) error {
+	if r.initialized {
+		return nil
+	}
+	r.initialized = true
+	r.columns = make(map[int]*compiledResolver)
+
+	nonPKCols := m.resultSchema.GetNonPKCols()
+	for _, def := range r.defs {
+		compiled := &compiledResolver{def: def, argIdx: -1}
+
+		switch def.Strategy {
+		case doltdb.MergeResolverLatest:
+			idx, ok := storedIndexByName(nonPKCols, def.Argument)
+			if !ok {
+				return fmt.Errorf("merge resolver for %s.%s: column %s used by strategy %s does not exist",
+					r.tableName, def.ColumnName, def.Argument, def.Strategy)
+			}
+			compiled.argIdx = idx
+		case doltdb.MergeResolverOurs, doltdb.MergeResolverTheirs:
+		case doltdb.MergeResolverMax, doltdb.MergeResolverMin, doltdb.MergeResolverSum,
+			doltdb.MergeResolverJsonArrayConcat, doltdb.MergeResolverJsonArrayUnion, doltdb.MergeResolverJsonArraySet,
+			doltdb.MergeResolverJsonArrayKeyed, doltdb.MergeResolverExpression:
+			if def.IsRowLevel() {
+				return fmt.Errorf("merge resolver for %s: strategy %s cannot be used as a row-level resolver", r.tableName, def.Strategy)
+			}
+		default:
+			return fmt.Errorf("merge resolver for %s.%s: unknown strategy %s", r.tableName, def.ColumnName, def.Strategy)
+		}
+
+		if def.IsRowLevel() {
+			r.row = compiled
+			continue
+		}
+
+		idx, ok := storedIndexByName(nonPKCols, def.ColumnName)
+		if !ok {
+			// The column was dropped or is part of the primary key, so there is nothing to resolve.
+			continue
+		}
+
+		switch def.Strategy {
+		case doltdb.MergeResolverSum:
+			name := nonPKCols.GetByStoredIndex(idx).Name
+			exprStr := fmt.Sprintf("COALESCE(`%s`, 0) + COALESCE(`%s`, 0) - COALESCE(`%s`, 0)",
+				mergeResolverOursPrefix+name, mergeResolverTheirsPrefix+name, mergeResolverBasePrefix+name)
+			expr, err := r.resolveExpression(ctx, m, idx, exprStr)
+			if err != nil {
+				return err
+			}
+			compiled.expr = expr
+		case doltdb.MergeResolverExpression:
+			expr, err := r.resolveExpression(ctx, m, idx, def.Argument)
+			if err != nil {
+				return err
+			}
+			compiled.expr = expr
+		}
+		r.columns[idx] = compiled
+	}
+
+	return nil
+}
+
+// storedIndexByName returns the stored index of the column named |name| in |cols|, matched case-insensitively.
+func storedIndexByName(cols *schema.ColCollection, name string) (int, bool) {
+	col, ok := cols.GetByNameCaseInsensitive(name)
+	if !ok || col.Virtual {
+		return -1, false
+	}
+	return cols.StoredIndexByTag(col.Tag)
+}
+
+// resolveExpression resolves |exprStr| against a synthetic schema containing the base_, our_ and their_ variants of
+// each stored non-primary-key column of the merged schema. The result of the expression is typed as the merged
+// column at stored index |idx|.
+func (r *mergeResolvers) resolveExpression(ctx *sql.Context, m *valueMerger, idx int, exprStr string) (sql.Expression, error) {
+	nonPKCols := m.resultSchema.GetNonPKCols()
+	var storedCols []schema.Column
+	for _, col := range nonPKCols.GetColumns() {
+		if !col.Virtual {
+			storedCols = append(storedCols, col)
+		}
+	}
+
+	var cols []schema.Column
+	for _, prefix := range []string{mergeResolverBasePrefix, mergeResolverOursPrefix, mergeResolverTheirsPrefix} {
+		for _, col := range storedCols {
+			cols = append(cols, resolverColumn(prefix+col.Name, uint64(len(cols)), col))
+		}
+	}
+	result := resolverColumn(mergeResolverResultColumn, uint64(len(cols)), nonPKCols.GetByStoredIndex(idx))
+	result.Generated = exprStr
+	cols = append(cols, result)
+
+	exprSch := schema.UnkeyedSchemaFromCols(schema.NewColCollection(cols...))
+	expr, err := expranalysis.ResolveDefaultExpression(ctx, r.tableName, exprSch, result)
+	if err != nil {
+		return nil, fmt.Errorf("merge resolver for %s.%s: unable to resolve expression %q: %w",
+			r.tableName, nonPKCols.GetByStoredIndex(idx).Name, exprStr, err)
+	}
+	return expr, nil
+}
+
+// resolverColumn returns a nullable, non-generated copy of |col| named |name| with tag |tag|.
+func resolverColumn(name string, tag uint64, col schema.Column) schema.Column {
+	return schema.Column{
+		Name:     name,
+		Tag:      tag,
+		Kind:     col.Kind,
+		TypeInfo: col.TypeInfo,
+	}
+}
+
+// resolveColumn attempts to resolve a conflict in column |i| of the merged schema using a column-level resolver. It
+// returns the resolved value and whether the column is still in conflict.
+func (r *mergeResolvers) resolveColumn(ctx *sql.Context, m *valueMerger, i int, left, right, base val.Tuple) ([]byte, bool, error) {
+	if err := r.init(ctx, m); err != nil {
+		return nil, true, err
+	}
+	resolver, ok := r.columns[i]
+	if !ok || left == nil || right == nil {
+		return nil, true, nil
+	}
+
+	leftCol, leftOk := m.resultColumn(ctx, i, left, m.leftVD, m.leftMapping)
+	rightCol, rightOk := m.resultColumn(ctx, i, right, m.rightVD, m.rightMapping)
+	if !leftOk || !rightOk {
+		return nil, true, nil
+	}
+
+	switch resolver.def.Strategy {
+	case doltdb.MergeResolverOurs:
+		return leftCol, false, nil
+	case doltdb.MergeResolverTheirs:
+		return rightCol, false, nil
+	case doltdb.MergeResolverMax, doltdb.MergeResolverMin:
+		if leftCol == nil {
+			return rightCol, false, nil
+		}
+		if rightCol == nil {
+			return leftCol, false, nil
+		}
+		cmp := m.resultVD.Comparator().CompareValues(ctx, i, leftCol, rightCol, m.resultVD.Types[i])
+		if (cmp >= 0) == (resolver.def.Strategy == doltdb.MergeResolverMax) {
+			return leftCol, false, nil
+		}
+		return rightCol, false, nil
+	case doltdb.MergeResolverLatest:
+		leftIsLatest, ok := m.leftIsLatest(ctx, resolver.argIdx, left, right)
+		if !ok {
+			return nil, true, nil
+		}
+		if leftIsLatest {
+			return leftCol, false, nil
+		}
+		return rightCol, false, nil
+	case doltdb.MergeResolverJsonArrayConcat:
+		return r.concatJsonArrays(ctx, m, i, left, right)
+	case doltdb.MergeResolverJsonArrayUnion:
+		return r.mergeJsonArrays(ctx, m, i, JsonArrayMergeUnion, "", left, right, base)
+	case doltdb.MergeResolverJsonArraySet:
+		return r.mergeJsonArrays(ctx, m, i, JsonArrayMergeSet, "", left, right, base)
+	case doltdb.MergeResolverJsonArrayKeyed:
+		return r.mergeJsonArrays(ctx, m, i, JsonArrayMergeKeyed, resolver.def.Argument, left, right, base)
+	case doltdb.MergeResolverSum, doltdb.MergeResolverExpression:
+		return r.evalExpression(ctx, m, i, resolver.expr, left, right, base)
+	default:
+		return nil, true, nil
+	}
+}
+
+// resolveRow attempts to resolve a conflicting row using the row-level resolver, if one is declared. It returns the
+// merged tuple and whether the row was resolved.
+func (r *mergeResolvers) resolveRow(ctx *sql.Context, m *valueMerger, left, right val.Tuple) (val.Tuple, bool, error) {
+	if err := r.init(ctx, m); err != nil {
+		return nil, false, err
+	}
+	if r.row == nil || left == nil || right == nil {
+		return nil, false, nil
+	}
+
+	var useLeft bool
+	switch r.row.def.Strategy {
+	case doltdb.MergeResolverOurs:
+		useLeft = true
+	case doltdb.MergeResolverTheirs:
+		useLeft = false
+	case doltdb.MergeResolverLatest:
+		var ok bool
+		useLeft, ok = m.leftIsLatest(ctx, r.row.argIdx, left, right)
+		if !ok {
+			return nil, false, nil
+		}
+	default:
+		return nil, false, nil
+	}
+
+	side, vd, mapping := left, m.leftVD, m.leftMapping
+	if !useLeft {
+		side, vd, mapping = right, m.rightVD, m.rightMapping
+	}
+	values := make([][]byte, m.numCols)
+	for i := 0; i < m.numCols; i++ {
+		col, ok := m.resultColumn(ctx, i, side, vd, mapping)
+		if !ok {
+			return nil, false, nil
+		}
+		values[i] = col
+	}
+	return val.NewTuple(m.syncPool, values...), true, nil
+}
+
+// evalExpression evaluates |expr| over the base, left and right rows and serializes the result as column |i| of the
+// merged schema.
+func (r *mergeResolvers) evalExpression(ctx *sql.Context, m *valueMerger, i int, expr sql.Expression, left, right, base val.Tuple) ([]byte, bool, error) {
+	row := make(sql.Row, 0, 3*m.numCols+1)
+	for _, side := range []struct {
+		tuple   val.Tuple
+		vd      *val.TupleDesc
+		mapping val.OrdinalMapping
+	}{
+		{base, m.baseVD, m.baseMapping},
+		{left, m.leftVD, m.leftMapping},
+		{right, m.rightVD, m.rightMapping},
+	} {
+		for j := 0; j < m.numCols; j++ {
+			v, err := m.resultValue(ctx, j, side.tuple, side.vd, side.mapping)
+			if err != nil {
+				return nil, true, err
+			}
+			row = append(row, v)
+		}
+	}
+	row = append(row, nil)
+
+	result, err := expr.Eval(ctx, row)
+	if err != nil {
+		return nil, true, fmt.Errorf("merge resolver for %s.%s: %w",
+			r.tableName, m.resultSchema.GetNonPKCols().GetByStoredIndex(i).Name, err)
+	}
+	return m.serializeResultValue(ctx, i, result)
+}
+
+// concatJsonArrays resolves column |i| by appending the elements of the right JSON array that are not already present
+// in the left JSON array. If either side is not a JSON array, the conflict is left unresolved.
+func (r *mergeResolvers) concatJsonArrays(ctx *sql.Context, m *valueMerger, i int, left, right val.Tuple) ([]byte, bool, error) {
+	leftVal, err := m.resultValue(ctx, i, left, m.leftVD, m.leftMapping)
+	if err != nil {
+		return nil, true, err
+	}
+	rightVal, err := m.resultValue(ctx, i, right, m.rightVD, m.rightMapping)
+	if err != nil {
+		return nil, true, err
+	}
+	leftJson, ok := leftVal.(sql.JSONWrapper)
+	if !ok {
+		return nil, true, nil
+	}
+	rightJson, ok := rightVal.(sql.JSONWrapper)
+	if !ok {
+		return nil, true, nil
+	}
+	leftDoc, err := leftJson.ToInterface(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	rightDoc, err := rightJson.ToInterface(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	leftArr, ok := leftDoc.([]interface{})
+	if !ok {
+		return nil, true, nil
+	}
+	rightArr, ok := rightDoc.([]interface{})
+	if !ok {
+		return nil, true, nil
+	}
+
+	merged := append([]interface{}{}, leftArr...)
+	for _, rv := range rightArr {
+		found := false
+		for _, lv := range leftArr {
+			cmp, err := types.CompareJSON(ctx, types.JSONDocument{Val: lv}, types.JSONDocument{Val: rv})
+			if err != nil {
+				return nil, true, err
+			}
+			if cmp == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, rv)
+		}
+	}
+	return m.serializeResultValue(ctx, i, types.JSONDocument{Val: merged})
+}
+
+// mergeJsonArrays resolves column |i| with a three-way merge of the base, left and right JSON documents in which
+// concurrent changes to the same array are merged according to |mode|. If a value is not JSON, or if the same element
+// was edited differently on each side, the conflict is left unresolved.
+func (r *mergeResolvers) mergeJsonArrays(ctx *sql.Context, m *valueMerger, i int, mode JsonArrayMergeMode, key string, left, right, base val.Tuple) ([]byte, bool, error) {
+	var docs [3]interface{}
+	for j, side := range []struct {
+		tuple   val.Tuple
+		vd      *val.TupleDesc
+		mapping val.OrdinalMapping
+	}{
+		{base, m.baseVD, m.baseMapping},
+		{left, m.leftVD, m.leftMapping},
+		{right, m.rightVD, m.rightMapping},
+	} {
+		v, err := m.resultValue(ctx, i, side.tuple, side.vd, side.mapping)
+		if err != nil {
+			return nil, true, err
+		}
+		if v == nil {
+			continue
+		}
+		doc, ok := v.(sql.JSONWrapper)
+		if !ok {
+			return nil, true, nil
+		}
+		docs[j], err = doc.ToInterface(ctx)
+		if err != nil {
+			return nil, true, err
+		}
+	}
+
+	baseDoc, leftDoc, rightDoc := docs[0], docs[1], docs[2]
+	if leftDoc == nil || rightDoc == nil {
+		// a NULL on either side can't be merged with a document
+		return nil, true, nil
+	}
+	merged, conflict, err := mergeJSONWithArrays(ctx, mode, key, baseDoc, leftDoc, rightDoc)
+	if err != nil || conflict {
+		return nil, true, err
+	}
+	return m.serializeResultValue(ctx, i, types.JSONDocument{Val: merged})
+}
+
+// leftIsLatest returns whether |left| has a greater value than |right| in column |idx| of the merged schema. NULL
+// values sort before any other value. If both values are equal, there is no latest side and false is returned as
+// the second result.
+func (m *valueMerger) leftIsLatest(ctx *sql.Context, idx int, left, right val.Tuple) (bool, bool) {
+	leftCol, leftOk := m.resultColumn(ctx, idx, left, m.leftVD, m.leftMapping)
+	rightCol, rightOk := m.resultColumn(ctx, idx, right, m.rightVD, m.rightMapping)
+	if !leftOk || !rightOk {
+		return false, false
+	}
+	switch {
+	case leftCol == nil && rightCol == nil:
+		return false, false
+	case leftCol == nil:
+		return false, true
+	case rightCol == nil:
+		return true, true
+	}
+	cmp := m.resultVD.Comparator().CompareValues(ctx, idx, leftCol, rightCol, m.resultVD.Types[idx])
+	if cmp == 0 {
+		return false, false
+	}
+	return cmp > 0, true
+}
+
+// resultColumn returns column |i| of the merged schema from |tuple|, converted to the merged column type. Columns
+// missing from the side's schema are returned as NULL. The returned bool is false if the value could not be
+// converted.
+func (m *valueMerger) resultColumn(ctx *sql.Context, i int, tuple val.Tuple, vd *val.TupleDesc, mapping val.OrdinalMapping) ([]byte, bool) {
+	col, colIdx, exists := getColumn(&tuple, &mapping, i)
+	if !exists {
+		return nil, true
+	}
+	col, err := convert(ctx, vd, m.resultVD, m.resultSchema, colIdx, i, tuple, col, m.ns)
+	if err != nil {
+		return nil, false
+	}
+	return col, true
+}
+
+// resultValue returns the SQL value of column |i| of the merged schema from |tuple|, converted to the merged column
+// type. Columns missing from the side's schema, and missing tuples, are returned as NULL.
+func (m *valueMerger) resultValue(ctx *sql.Context, i int, tuple val.Tuple, vd *val.TupleDesc, mapping val.OrdinalMapping) (interface{}, error) {
+	if tuple == nil {
+		return nil, nil
+	}
+	colIdx := mapping[i]
+	if colIdx == -1 {
+		return nil, nil
+	}
+	v, err := tree.GetField(ctx, vd, colIdx, tuple, m.ns)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	sqlType := m.resultSchema.GetNonPKCols().GetByStoredIndex(i).TypeInfo.ToSqlType()
+	v, _, err = sqlType.Convert(ctx, v)
+	return v, err
+}
+
+// serializeResultValue converts |v| to the type of column |i| of the merged schema and serializes it. If the value
+// cannot be converted, the column is left in conflict.
+func (m *valueMerger) serializeResultValue(ctx *sql.Context, i int, v interface{}) ([]byte, bool, error) {
+	if v == nil {
+		return nil, false, nil
+	}
+	sqlType := m.resultSchema.GetNonPKCols().GetByStoredIndex(i).TypeInfo.ToSqlType()
+	converted, _, err := sqlType.Convert(ctx, v)
+	if err != nil {
+		return nil, true, nil
+	}
+	typ := m.resultVD.Types[i]
+	// If a resolver assigns NULL to a non-null column, the merged tuple is validated before it is merged into the
+	// table, the same as any other merged value.
+	typ.Nullable = true
+	result, err := tree.Serialize(ctx, m.ns, typ, converted)
+	if err != nil {
+		return nil, true, err
+	}
+	return result, false, nil
+}
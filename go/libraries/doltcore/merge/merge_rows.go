@@ -81,10 +81,15 @@ type TableMerger struct {
 	// exception is for the dolt_verify_constraints() stored procedure, which allows callers to
 	// only record constraint violations for a specified subset of tables.
 	recordViolations bool
+
+	// resolvers are the entries of dolt_merge_resolvers on the left side of the merge that apply to this table.
+	resolvers []doltdb.MergeResolver
 }
 
 func (tm TableMerger) GetNewValueMerger(mergeSch schema.Schema, leftRows prolly.Map) *valueMerger {
-	return NewValueMerger(mergeSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), leftRows.NodeStore())
+	vm := NewValueMerger(mergeSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), leftRows.NodeStore())
+	vm.resolvers = newMergeResolvers(tm.name.Name, tm.resolvers)
+	return vm
 }
 
 func rowsFromTable(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
@@ -365,6 +370,13 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 		return nil, errors.New("Attempting to merge fundamentally different objects, which has not yet been implemented\n" +
 			"Please contact us and share how you ran into this error to better help our development efforts.")
 	}
+
+	if tm.HasTable() {
+		tm.resolvers, err = doltdb.GetMergeResolvers(ctx, rm.left, tblName)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &tm, nil
 }
 
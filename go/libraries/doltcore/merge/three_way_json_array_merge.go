@@ -0,0 +1,373 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+// JsonArrayMergeMode selects how arrays are merged by mergeJSONWithArrays.
+type JsonArrayMergeMode int
+
+const (
+	// JsonArrayMergeUnion keeps the order of our array and appends the elements added to their array. Elements
+	// removed on either side are removed from the result. Elements are identified by value.
+	JsonArrayMergeUnion JsonArrayMergeMode = iota
+	// JsonArrayMergeSet treats arrays as sets: the result contains the elements of our array and the elements added
+	// to their array, without duplicates, minus the elements removed on either side.
+	JsonArrayMergeSet
+	// JsonArrayMergeKeyed treats arrays of objects as maps keyed on a field of each object. Elements with the same
+	// key are merged recursively, so that conflicts only happen when the same element is edited differently.
+	JsonArrayMergeKeyed
+)
+
+// DefaultJsonArrayMergeKey is the field used to identify the elements of arrays merged with JsonArrayMergeKeyed when
+// no other field is given.
+const DefaultJsonArrayMergeKey = "id"
+
+// jsonArrayMerger performs a three-way merge of decoded JSON documents. Unlike the merge of ThreeWayJsonDiffer, which
+// treats arrays as opaque values, it merges concurrent changes to the same array according to its mode.
+type jsonArrayMerger struct {
+	mode JsonArrayMergeMode
+	// key is the field identifying array elements for JsonArrayMergeKeyed.
+	key string
+}
+
+// mergeJSONWithArrays merges the JSON values |left| and |right| with their common ancestor |base|, any of which may
+// be nil if the value is absent, using |mode| to merge arrays. It returns the merged value and whether the values
+// are in conflict.
+func mergeJSONWithArrays(ctx context.Context, mode JsonArrayMergeMode, key string, base, left, right interface{}) (interface{}, bool, error) {
+	if key == "" {
+		key = DefaultJsonArrayMergeKey
+	}
+	m := jsonArrayMerger{mode: mode, key: key}
+	return m.merge(ctx, base, left, right)
+}
+
+func (m jsonArrayMerger) merge(ctx context.Context, base, left, right interface{}) (interface{}, bool, error) {
+	if eq, err := jsonEqual(ctx, left, right); err != nil || eq {
+		return left, false, err
+	}
+	if eq, err := jsonEqual(ctx, base, left); err != nil || eq {
+		return right, false, err
+	}
+	if eq, err := jsonEqual(ctx, base, right); err != nil || eq {
+		return left, false, err
+	}
+
+	switch l := left.(type) {
+	case map[string]interface{}:
+		r, ok := right.(map[string]interface{})
+		if !ok {
+			return nil, true, nil
+		}
+		b, ok := base.(map[string]interface{})
+		if !ok {
+			if base != nil {
+				return nil, true, nil
+			}
+			b = map[string]interface{}{}
+		}
+		return m.mergeObjects(ctx, b, l, r)
+	case []interface{}:
+		r, ok := right.([]interface{})
+		if !ok {
+			return nil, true, nil
+		}
+		b, ok := base.([]interface{})
+		if !ok {
+			if base != nil {
+				return nil, true, nil
+			}
+			b = []interface{}{}
+		}
+		return m.mergeArrays(ctx, b, l, r)
+	default:
+		// Scalars that were changed differently on both sides
+		return nil, true, nil
+	}
+}
+
+// mergeObjects merges JSON objects key by key.
+func (m jsonArrayMerger) mergeObjects(ctx context.Context, base, left, right map[string]interface{}) (interface{}, bool, error) {
+	merged := make(map[string]interface{}, len(left))
+	keys := make(map[string]struct{}, len(left)+len(right))
+	for k := range left {
+		keys[k] = struct{}{}
+	}
+	for k := range right {
+		keys[k] = struct{}{}
+	}
+
+	for k := range keys {
+		v, present, conflict, err := m.mergeMember(ctx, base, left, right, k)
+		if err != nil || conflict {
+			return nil, conflict, err
+		}
+		if present {
+			merged[k] = v
+		}
+	}
+	return merged, false, nil
+}
+
+// mergeMember merges the member |k| of three JSON objects. It returns the merged value, whether the member is present
+// in the merged object, and whether the member is in conflict.
+func (m jsonArrayMerger) mergeMember(ctx context.Context, base, left, right map[string]interface{}, k string) (interface{}, bool, bool, error) {
+	b, inBase := base[k]
+	l, inLeft := left[k]
+	r, inRight := right[k]
+
+	switch {
+	case inLeft && inRight:
+		if !inBase {
+			// added on both sides
+			eq, err := jsonEqual(ctx, l, r)
+			return l, true, !eq, err
+		}
+		v, conflict, err := m.merge(ctx, b, l, r)
+		return v, true, conflict, err
+	case inLeft:
+		if !inBase {
+			return l, true, false, nil
+		}
+		// removed on the right, which is a conflict if the left modified it
+		eq, err := jsonEqual(ctx, b, l)
+		return nil, false, !eq, err
+	case inRight:
+		if !inBase {
+			return r, true, false, nil
+		}
+		eq, err := jsonEqual(ctx, b, r)
+		return nil, false, !eq, err
+	default:
+		return nil, false, false, nil
+	}
+}
+
+func (m jsonArrayMerger) mergeArrays(ctx context.Context, base, left, right []interface{}) (interface{}, bool, error) {
+	switch m.mode {
+	case JsonArrayMergeKeyed:
+		return m.mergeKeyedArrays(ctx, base, left, right)
+	case JsonArrayMergeUnion, JsonArrayMergeSet:
+		return m.mergeValueArrays(ctx, base, left, right)
+	default:
+		return nil, true, fmt.Errorf("unknown json array merge mode %d", m.mode)
+	}
+}
+
+// mergeValueArrays merges arrays whose elements are identified by value, for JsonArrayMergeUnion and
+// JsonArrayMergeSet.
+func (m jsonArrayMerger) mergeValueArrays(ctx context.Context, base, left, right []interface{}) (interface{}, bool, error) {
+	removedByLeft, err := jsonDifference(ctx, base, left)
+	if err != nil {
+		return nil, true, err
+	}
+	removedByRight, err := jsonDifference(ctx, base, right)
+	if err != nil {
+		return nil, true, err
+	}
+	addedByRight, err := jsonDifference(ctx, right, base)
+	if err != nil {
+		return nil, true, err
+	}
+
+	merged := make([]interface{}, 0, len(left)+len(addedByRight))
+	for _, v := range left {
+		removed, err := jsonContains(ctx, removedByRight, v)
+		if err != nil {
+			return nil, true, err
+		}
+		if removed {
+			// each removal on the right removes a single occurrence
+			removedByRight, err = jsonRemoveOne(ctx, removedByRight, v)
+			if err != nil {
+				return nil, true, err
+			}
+			continue
+		}
+		merged = append(merged, v)
+	}
+	for _, v := range addedByRight {
+		if m.mode == JsonArrayMergeUnion {
+			// elements added on both sides are only kept once
+			added, err := jsonContains(ctx, left, v)
+			if err != nil {
+				return nil, true, err
+			}
+			inBase, err := jsonContains(ctx, base, v)
+			if err != nil {
+				return nil, true, err
+			}
+			if added && !inBase {
+				continue
+			}
+			// an element the left removed is not added back
+			removed, err := jsonContains(ctx, removedByLeft, v)
+			if err != nil {
+				return nil, true, err
+			}
+			if removed {
+				continue
+			}
+		}
+		merged = append(merged, v)
+	}
+
+	if m.mode == JsonArrayMergeSet {
+		return jsonDistinct(ctx, merged)
+	}
+	return merged, false, nil
+}
+
+// mergeKeyedArrays merges arrays of objects keyed on |m.key|, for JsonArrayMergeKeyed. Arrays with elements that are
+// not objects, or that do not have a unique scalar key, are in conflict. The merged array keeps the order of the left
+// array, followed by the elements added to the right array in their order.
+func (m jsonArrayMerger) mergeKeyedArrays(ctx context.Context, base, left, right []interface{}) (interface{}, bool, error) {
+	baseByKey, _, ok := m.indexByKey(base)
+	if !ok {
+		return nil, true, nil
+	}
+	leftByKey, leftKeys, ok := m.indexByKey(left)
+	if !ok {
+		return nil, true, nil
+	}
+	rightByKey, rightKeys, ok := m.indexByKey(right)
+	if !ok {
+		return nil, true, nil
+	}
+
+	order := append([]string{}, leftKeys...)
+	for _, k := range rightKeys {
+		if _, ok := leftByKey[k]; !ok {
+			order = append(order, k)
+		}
+	}
+
+	merged := make([]interface{}, 0, len(order))
+	for _, k := range order {
+		v, present, conflict, err := m.mergeMember(ctx, baseByKey, leftByKey, rightByKey, k)
+		if err != nil || conflict {
+			return nil, conflict, err
+		}
+		if present {
+			merged = append(merged, v)
+		}
+	}
+	return merged, false, nil
+}
+
+// indexByKey returns the elements of |arr| by the JSON encoding of their key, and the keys in the order of |arr|. The
+// returned bool is false if an element is not an object with a scalar key, or if a key is used more than once.
+func (m jsonArrayMerger) indexByKey(arr []interface{}) (map[string]interface{}, []string, bool) {
+	byKey := make(map[string]interface{}, len(arr))
+	keys := make([]string, 0, len(arr))
+	for _, v := range arr {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, nil, false
+		}
+		keyVal, ok := obj[m.key]
+		if !ok {
+			return nil, nil, false
+		}
+		var k string
+		switch kv := keyVal.(type) {
+		case string:
+			k = "s" + kv
+		case float64, int64, uint64, int, bool:
+			k = fmt.Sprintf("n%v", kv)
+		default:
+			return nil, nil, false
+		}
+		if _, dup := byKey[k]; dup {
+			return nil, nil, false
+		}
+		byKey[k] = v
+		keys = append(keys, k)
+	}
+	return byKey, keys, true
+}
+
+func jsonEqual(ctx context.Context, a, b interface{}) (bool, error) {
+	if a == nil || b == nil {
+		return a == nil && b == nil, nil
+	}
+	cmp, err := types.CompareJSON(ctx, types.JSONDocument{Val: a}, types.JSONDocument{Val: b})
+	return cmp == 0, err
+}
+
+func jsonContains(ctx context.Context, arr []interface{}, v interface{}) (bool, error) {
+	for _, e := range arr {
+		eq, err := jsonEqual(ctx, e, v)
+		if err != nil || eq {
+			return eq, err
+		}
+	}
+	return false, nil
+}
+
+// jsonRemoveOne returns |arr| without its first element equal to |v|.
+func jsonRemoveOne(ctx context.Context, arr []interface{}, v interface{}) ([]interface{}, error) {
+	for i, e := range arr {
+		eq, err := jsonEqual(ctx, e, v)
+		if err != nil {
+			return nil, err
+		}
+		if eq {
+			return append(append([]interface{}{}, arr[:i]...), arr[i+1:]...), nil
+		}
+	}
+	return arr, nil
+}
+
+// jsonDifference returns the elements of |a| that are not in |b|, counting duplicates, so that an element present
+// twice in |a| and once in |b| is returned once.
+func jsonDifference(ctx context.Context, a, b []interface{}) ([]interface{}, error) {
+	remaining := append([]interface{}{}, b...)
+	var diff []interface{}
+	for _, v := range a {
+		found, err := jsonContains(ctx, remaining, v)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			remaining, err = jsonRemoveOne(ctx, remaining, v)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		diff = append(diff, v)
+	}
+	return diff, nil
+}
+
+func jsonDistinct(ctx context.Context, arr []interface{}) ([]interface{}, bool, error) {
+	distinct := make([]interface{}, 0, len(arr))
+	for _, v := range arr {
+		found, err := jsonContains(ctx, distinct, v)
+		if err != nil {
+			return nil, true, err
+		}
+		if !found {
+			distinct = append(distinct, v)
+		}
+	}
+	return distinct, false, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeJSONWithArrays(t *testing.T) {
+	tests := []struct {
+		name              string
+		mode              JsonArrayMergeMode
+		key               string
+		base, left, right string
+		// expected is the merged document, or empty if the merge conflicts
+		expected string
+	}{
+		{
+			name:     "union appends elements added on both sides",
+			mode:     JsonArrayMergeUnion,
+			base:     `{"tags": ["a"]}`,
+			left:     `{"tags": ["a", "b"]}`,
+			right:    `{"tags": ["a", "c"]}`,
+			expected: `{"tags": ["a", "b", "c"]}`,
+		},
+		{
+			name:     "union applies removals from both sides",
+			mode:     JsonArrayMergeUnion,
+			base:     `{"tags": ["a", "b", "c"]}`,
+			left:     `{"tags": ["b", "c", "d"]}`,
+			right:    `{"tags": ["a", "b"]}`,
+			expected: `{"tags": ["b", "d"]}`,
+		},
+		{
+			name:     "union keeps elements added on both sides once",
+			mode:     JsonArrayMergeUnion,
+			base:     `[]`,
+			left:     `["a", "b"]`,
+			right:    `["b", "c"]`,
+			expected: `["a", "b", "c"]`,
+		},
+		{
+			name:     "union keeps duplicates of the left",
+			mode:     JsonArrayMergeUnion,
+			base:     `["a"]`,
+			left:     `["a", "a"]`,
+			right:    `["a", "b"]`,
+			expected: `["a", "a", "b"]`,
+		},
+		{
+			name:     "set removes duplicates",
+			mode:     JsonArrayMergeSet,
+			base:     `["a"]`,
+			left:     `["a", "b", "b"]`,
+			right:    `["c", "a", "c"]`,
+			expected: `["a", "b", "c"]`,
+		},
+		{
+			name:     "objects are merged key by key",
+			mode:     JsonArrayMergeSet,
+			base:     `{"a": 1, "b": [1]}`,
+			left:     `{"a": 2, "b": [1, 2]}`,
+			right:    `{"a": 1, "b": [1, 3], "c": true}`,
+			expected: `{"a": 2, "b": [1, 2, 3], "c": true}`,
+		},
+		{
+			name:  "scalars changed on both sides conflict",
+			mode:  JsonArrayMergeUnion,
+			base:  `{"a": 1, "b": []}`,
+			left:  `{"a": 2, "b": [1]}`,
+			right: `{"a": 3, "b": [2]}`,
+		},
+		{
+			name:  "member removed on one side and modified on the other conflicts",
+			mode:  JsonArrayMergeUnion,
+			base:  `{"a": [1], "b": 1}`,
+			left:  `{"b": 2}`,
+			right: `{"a": [1, 2], "b": 1}`,
+		},
+		{
+			name:     "keyed merges edits to different elements",
+			mode:     JsonArrayMergeKeyed,
+			base:     `{"rules": [{"id": 1, "allow": false}, {"id": 2, "allow": false}]}`,
+			left:     `{"rules": [{"id": 1, "allow": true}, {"id": 2, "allow": false}, {"id": 3, "allow": true}]}`,
+			right:    `{"rules": [{"id": 2, "allow": false, "note": "x"}, {"id": 1, "allow": false}, {"id": 4}]}`,
+			expected: `{"rules": [{"id": 1, "allow": true}, {"id": 2, "allow": false, "note": "x"}, {"id": 3, "allow": true}, {"id": 4}]}`,
+		},
+		{
+			name:     "keyed merges edits to different fields of the same element",
+			mode:     JsonArrayMergeKeyed,
+			base:     `[{"id": "a", "x": 1, "y": 1}]`,
+			left:     `[{"id": "a", "x": 2, "y": 1}]`,
+			right:    `[{"id": "a", "x": 1, "y": 2}]`,
+			expected: `[{"id": "a", "x": 2, "y": 2}]`,
+		},
+		{
+			name:     "keyed applies removals",
+			mode:     JsonArrayMergeKeyed,
+			base:     `[{"id": 1}, {"id": 2}]`,
+			left:     `[{"id": 1}, {"id": 2}, {"id": 3}]`,
+			right:    `[{"id": 2}]`,
+			expected: `[{"id": 2}, {"id": 3}]`,
+		},
+		{
+			name:  "keyed conflicts when the same element is edited differently",
+			mode:  JsonArrayMergeKeyed,
+			base:  `[{"id": 1, "x": 1}]`,
+			left:  `[{"id": 1, "x": 2}]`,
+			right: `[{"id": 1, "x": 3}]`,
+		},
+		{
+			name:  "keyed conflicts when an element is removed and edited",
+			mode:  JsonArrayMergeKeyed,
+			base:  `[{"id": 1, "x": 1}, {"id": 2}]`,
+			left:  `[{"id": 1, "x": 2}, {"id": 2}]`,
+			right: `[{"id": 2}]`,
+		},
+		{
+			name:     "keyed uses the given key",
+			mode:     JsonArrayMergeKeyed,
+			key:      "name",
+			base:     `[{"name": "a", "v": 1}]`,
+			left:     `[{"name": "a", "v": 2}]`,
+			right:    `[{"name": "a", "v": 1}, {"name": "b", "v": 1}]`,
+			expected: `[{"name": "a", "v": 2}, {"name": "b", "v": 1}]`,
+		},
+		{
+			name:  "keyed conflicts on elements without the key",
+			mode:  JsonArrayMergeKeyed,
+			base:  `[{"id": 1}]`,
+			left:  `[{"id": 1}, {"x": 1}]`,
+			right: `[{"id": 1}, {"y": 1}]`,
+		},
+		{
+			name:  "keyed conflicts on duplicate keys",
+			mode:  JsonArrayMergeKeyed,
+			base:  `[]`,
+			left:  `[{"id": 1, "x": 1}, {"id": 1, "x": 2}]`,
+			right: `[{"id": 2}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			merged, conflict, err := mergeJSONWithArrays(ctx, test.mode, test.key,
+				unmarshalJSON(t, test.base), unmarshalJSON(t, test.left), unmarshalJSON(t, test.right))
+			require.NoError(t, err)
+			if test.expected == "" {
+				assert.True(t, conflict)
+				return
+			}
+			require.False(t, conflict)
+			eq, err := jsonEqual(ctx, unmarshalJSON(t, test.expected), merged)
+			require.NoError(t, err)
+			assert.True(t, eq, "expected %s, got %v", test.expected, merged)
+		})
+	}
+}
+
+func unmarshalJSON(t *testing.T, s string) interface{} {
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
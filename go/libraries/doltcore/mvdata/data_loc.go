@@ -60,6 +60,15 @@ const (
 
 	// ParquetFile is the format of a data location that is a .paquet file
 	ParquetFile DataFormat = ".parquet"
+
+	// AvroFile is the format of a data location that is an Avro object container file
+	AvroFile DataFormat = ".avro"
+
+	// ArrowFile is the format of a data location that is an Arrow IPC file
+	ArrowFile DataFormat = ".arrow"
+
+	// featherFileExt is the extension of Feather V2 files, which are Arrow IPC files
+	featherFileExt = ".feather"
 )
 
 // ReadableStr returns a human readable string for a DataFormat
@@ -81,6 +90,10 @@ func (df DataFormat) ReadableStr() string {
 		return "sql file"
 	case ParquetFile:
 		return "parquet file"
+	case AvroFile:
+		return "avro file"
+	case ArrowFile:
+		return "arrow file"
 	default:
 		return "invalid"
 	}
@@ -127,6 +140,10 @@ func NewDataLocation(path, fileFmtStr string) DataLocation {
 			dataFmt = SqlFile
 		case string(ParquetFile):
 			dataFmt = ParquetFile
+		case string(AvroFile):
+			dataFmt = AvroFile
+		case string(ArrowFile), featherFileExt:
+			dataFmt = ArrowFile
 		}
 	}
 
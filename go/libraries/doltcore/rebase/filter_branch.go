@@ -81,7 +81,8 @@ func AllBranchesAndTags(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted
 	if err != nil {
 		return err
 	}
-	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, append(branches, tags...)...)
+	_, err = rebaseRefs(ctx, dEnv.DoltDB(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, append(branches, tags...)...)
+	return err
 }
 
 // AllBranches rewrites the history of all branches in the repo using the |replay| function.
@@ -90,7 +91,8 @@ func AllBranches(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted bool,
 	if err != nil {
 		return err
 	}
-	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, branches...)
+	_, err = rebaseRefs(ctx, dEnv.DoltDB(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, branches...)
+	return err
 }
 
 // CurrentBranch rewrites the history of the current branch using the |replay| function.
@@ -99,17 +101,18 @@ func CurrentBranch(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted bool
 	if err != nil {
 		return nil
 	}
-	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, headRef)
+	_, err = rebaseRefs(ctx, dEnv.DoltDB(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, headRef)
+	return err
 }
 
-func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn, refs ...ref.DoltRef) error {
-	ddb := dbData.Ddb
+// rebaseRefs rewrites the history of |refs|, and returns the rebased commits keyed by the hash of their original.
+func rebaseRefs(ctx context.Context, ddb *doltdb.DoltDB, applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn, refs ...ref.DoltRef) (visitedSet, error) {
 	heads := make([]*doltdb.Commit, len(refs))
 	for i, dRef := range refs {
 		var err error
 		heads[i], err = ddb.ResolveCommitRef(ctx, dRef)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -119,38 +122,38 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 		case ref.BranchRef:
 			hRootVal, err := heads[i].GetRootValue(ctx)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			hHash, err := hRootVal.HashOf()
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			wsRef, err := ref.WorkingSetRefForHead(dRef)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			wHash, err := ws.WorkingRoot().HashOf()
 			if err != nil {
-				return err
+				return nil, err
 			}
 			sHash, err := ws.StagedRoot().HashOf()
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if !applyUncommitted && (!hHash.Equal(wHash) || !hHash.Equal(sHash)) {
-				return fmt.Errorf("local changes detected on branch %s, clear uncommitted changes (dolt stash dolt commit) before using filter-branch, or use --apply-to-uncommitted", dRef.String())
+				return nil, fmt.Errorf("local changes detected on branch %s, clear uncommitted changes (dolt stash dolt commit) before using filter-branch, or use --apply-to-uncommitted", dRef.String())
 			}
 
 			if !hHash.Equal(wHash) {
 				var newWRoot doltdb.RootValue
 				newWRoot, err = rootReplayer.ReplayRoot(ctx, ws.WorkingRoot(), nil, nil)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				ws = ws.WithWorkingRoot(newWRoot)
 			} else {
@@ -160,7 +163,7 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 				var newSRoot doltdb.RootValue
 				newSRoot, err = rootReplayer.ReplayRoot(ctx, ws.StagedRoot(), nil, nil)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				ws = ws.WithStagedRoot(newSRoot)
 			} else {
@@ -172,9 +175,9 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 		}
 	}
 
-	newHeads, err := rebase(ctx, ddb, commitReplayer, nerf, heads...)
+	newHeads, vs, err := rebase(ctx, ddb, commitReplayer, nerf, heads...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for i, r := range refs {
@@ -183,7 +186,7 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 			newHead := newHeads[i]
 			err = ddb.NewBranchAtCommit(ctx, dRef, newHead, nil)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			newWorkingSet := newWorkingSets[i]
@@ -194,13 +197,13 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 			var wsRef ref.WorkingSetRef
 			wsRef, err = ref.WorkingSetRefForHead(dRef)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			var ws *doltdb.WorkingSet
 			ws, err = ddb.ResolveWorkingSet(ctx, wsRef)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			if newWorkingSet.WorkingRoot() != nil {
@@ -213,44 +216,46 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 			var currWsHash hash.Hash
 			currWsHash, err = ws.HashOf()
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			err = ddb.UpdateWorkingSet(ctx, wsRef, ws, currWsHash, ws.Meta(), nil)
+		case ref.RemoteRef:
+			err = ddb.SetHeadToCommit(ctx, dRef, newHeads[i])
 		case ref.TagRef:
 			// rewrite tag with new commit
 			var tag *doltdb.Tag
 			if tag, err = ddb.ResolveTag(ctx, dRef); err != nil {
-				return err
+				return nil, err
 			}
 			if err = ddb.DeleteTag(ctx, dRef); err != nil {
-				return err
+				return nil, err
 			}
 			err = ddb.NewTagAtCommit(ctx, dRef, newHeads[i], tag.Meta)
 		default:
-			return fmt.Errorf("cannot rebase ref: %s", ref.String(dRef))
+			return nil, fmt.Errorf("cannot rebase ref: %s", ref.String(dRef))
 		}
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return vs, nil
 }
 
-func rebase(ctx context.Context, ddb *doltdb.DoltDB, commitReplayer CommitReplayer, nerf NeedsRebaseFn, origins ...*doltdb.Commit) ([]*doltdb.Commit, error) {
+func rebase(ctx context.Context, ddb *doltdb.DoltDB, commitReplayer CommitReplayer, nerf NeedsRebaseFn, origins ...*doltdb.Commit) ([]*doltdb.Commit, visitedSet, error) {
 	var rebasedCommits []*doltdb.Commit
 	vs := make(visitedSet)
 	for _, cm := range origins {
 		rc, err := rebaseRecursive(ctx, ddb, commitReplayer, nerf, vs, cm)
 
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		rebasedCommits = append(rebasedCommits, rc)
 	}
 
-	return rebasedCommits, nil
+	return rebasedCommits, vs, nil
 }
 
 func rebaseRecursive(ctx context.Context, ddb *doltdb.DoltDB, commitReplayer CommitReplayer, nerf NeedsRebaseFn, vs visitedSet, commit *doltdb.Commit) (*doltdb.Commit, error) {
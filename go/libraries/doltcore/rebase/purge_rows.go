@@ -0,0 +1,388 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// RewrittenCommit pairs a commit with the commit that replaced it when its history was rewritten.
+type RewrittenCommit struct {
+	Old hash.Hash
+	New hash.Hash
+}
+
+// PurgeRows deletes the rows of |tableName| matching the SQL expression |where| from every commit of every branch,
+// tag and remote-tracking branch of |ddb|, and from the working and staged roots of every branch. The table is
+// followed through renames: in each commit, rows are deleted from the table with the column tags of |tableName| at
+// the head of one of those refs, whatever its name in that commit. It returns the
+// commits that were rewritten, ordered by the hash of the original commit. They are also recorded in the database,
+// where they can be read from the dolt_purged_commits system table.
+//
+// The reflog is truncated, so that it no longer references the original commits, but they remain in the chunk store
+// until it is garbage collected. Stashes and in-progress merges and rebases reference commits and roots that can't
+// be rewritten, so PurgeRows refuses to run while any exist.
+func PurgeRows(ctx *sql.Context, ddb *doltdb.DoltDB, dbName string, tableName doltdb.TableName, where string) ([]RewrittenCommit, error) {
+	stashes, err := ddb.GetStashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(stashes) > 0 {
+		return nil, fmt.Errorf("cannot purge rows while stashes exist, drop them with dolt stash clear first")
+	}
+
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkNoMergeOrRebase(ctx, ddb, branches, "purge rows"); err != nil {
+		return nil, err
+	}
+
+	tags, err := ddb.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := ddb.GetRemoteRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := append(append(branches, tags...), remotes...)
+	tableTags, err := purgedTableTags(ctx, ddb, tableName, refs)
+	if err != nil {
+		return nil, err
+	}
+	purger := &rowPurger{
+		sqlCtx:    ctx,
+		dbName:    dbName,
+		tableName: tableName,
+		tags:      tableTags,
+		where:     where,
+		purged:    make(map[hash.Hash]*doltdb.Table),
+	}
+	vs, err := rebaseRefs(ctx, ddb, true, purger, purger, EntireHistory(), refs...)
+	if err != nil {
+		return nil, err
+	}
+	if err = ddb.TruncateReflog(ctx); err != nil {
+		return nil, err
+	}
+
+	var rewritten []RewrittenCommit
+	for old, cm := range vs {
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if h != old {
+			rewritten = append(rewritten, RewrittenCommit{Old: old, New: h})
+		}
+	}
+	sort.Slice(rewritten, func(i, j int) bool {
+		return rewritten[i].Old.String() < rewritten[j].Old.String()
+	})
+
+	purgedAt := time.Now()
+	purged := make([]doltdb.PurgedCommit, len(rewritten))
+	for i, rc := range rewritten {
+		purged[i] = doltdb.PurgedCommit{Old: rc.Old.String(), New: rc.New.String(), Table: tableName.Name, PurgedAt: purgedAt}
+	}
+	if err = ddb.AddPurgedCommits(ctx, purged); err != nil {
+		return nil, err
+	}
+	return rewritten, nil
+}
+
+// checkNoMergeOrRebase returns an error if a merge or rebase is in progress on any of |branches|. The working sets of
+// such branches reference commits, which can't be rewritten by |action|.
+func checkNoMergeOrRebase(ctx context.Context, ddb *doltdb.DoltDB, branches []ref.DoltRef, action string) error {
+	for _, branch := range branches {
+		wsRef, err := ref.WorkingSetRefForHead(branch)
+		if err != nil {
+			return err
+		}
+		ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == doltdb.ErrWorkingSetNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if ws.MergeActive() || ws.RebaseActive() {
+			return fmt.Errorf("cannot %s while a merge or rebase is in progress on branch %s", action, branch.GetPath())
+		}
+	}
+	return nil
+}
+
+// purgedTableTags returns the column tags of the versions of the table named |tableName| at the heads of |refs|, and
+// in the working and staged roots of those that are branches. A renamed table keeps the tags of its columns, so they
+// identify the table in the commits where it has another name.
+func purgedTableTags(ctx context.Context, ddb *doltdb.DoltDB, tableName doltdb.TableName, refs []ref.DoltRef) (map[uint64]struct{}, error) {
+	tags := make(map[uint64]struct{})
+	addTags := func(root doltdb.RootValue) error {
+		tbl, ok, err := root.GetTable(ctx, tableName)
+		if err != nil || !ok {
+			return err
+		}
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return err
+		}
+		for _, tag := range sch.GetAllCols().Tags {
+			tags[tag] = struct{}{}
+		}
+		return nil
+	}
+
+	for _, r := range refs {
+		cm, err := ddb.ResolveCommitRef(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err = addTags(root); err != nil {
+			return nil, err
+		}
+		if r.GetType() != ref.BranchRefType {
+			continue
+		}
+
+		wsRef, err := ref.WorkingSetRefForHead(r)
+		if err != nil {
+			return nil, err
+		}
+		ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == doltdb.ErrWorkingSetNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if err = addTags(ws.WorkingRoot()); err != nil {
+			return nil, err
+		}
+		if err = addTags(ws.StagedRoot()); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: %s", doltdb.ErrTableNotFound, tableName.Name)
+	}
+	return tags, nil
+}
+
+// rowPurger deletes the rows of a table matching a filter expression from the roots it replays.
+type rowPurger struct {
+	sqlCtx    *sql.Context
+	dbName    string
+	tableName doltdb.TableName
+	// tags are the column tags identifying the versions of the table, as returned by purgedTableTags
+	tags  map[uint64]struct{}
+	where string
+	// purged maps the hash of each version of the table seen so far to the version without the purged rows
+	purged map[hash.Hash]*doltdb.Table
+}
+
+var _ CommitReplayer = &rowPurger{}
+var _ RootReplayer = &rowPurger{}
+
+// ReplayCommit implements the CommitReplayer interface.
+func (p *rowPurger) ReplayCommit(ctx context.Context, commit, _, _ *doltdb.Commit) (doltdb.RootValue, error) {
+	root, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newRoot, err := p.purgeRoot(root)
+	if err != nil {
+		h, _ := commit.HashOf()
+		return nil, fmt.Errorf("error purging rows from commit %s: %w", h.String(), err)
+	}
+	return newRoot, nil
+}
+
+// ReplayRoot implements the RootReplayer interface.
+func (p *rowPurger) ReplayRoot(_ context.Context, root, _, _ doltdb.RootValue) (doltdb.RootValue, error) {
+	return p.purgeRoot(root)
+}
+
+func (p *rowPurger) purgeRoot(root doltdb.RootValue) (doltdb.RootValue, error) {
+	tableName, tbl, ok, err := p.findTable(root)
+	if err != nil || !ok {
+		return root, err
+	}
+	h, err := tbl.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	newTbl, ok := p.purged[h]
+	if !ok {
+		newTbl, err = p.purgeTable(root, tableName, tbl)
+		if err != nil {
+			return nil, err
+		}
+		p.purged[h] = newTbl
+	}
+	if newTbl == tbl {
+		return root, nil
+	}
+	return root.PutTable(p.sqlCtx, tableName, newTbl)
+}
+
+// findTable returns the version of the purged table in |root| and its name there, which differs from the name of the
+// purged table if it was renamed. It returns false if |root| has no table with a column tag of the purged table.
+func (p *rowPurger) findTable(root doltdb.RootValue) (doltdb.TableName, *doltdb.Table, bool, error) {
+	tbl, ok, err := root.GetTable(p.sqlCtx, p.tableName)
+	if err != nil {
+		return doltdb.TableName{}, nil, false, err
+	}
+	if ok {
+		sch, err := tbl.GetSchema(p.sqlCtx)
+		if err != nil {
+			return doltdb.TableName{}, nil, false, err
+		}
+		if p.hasTag(sch) {
+			return p.tableName, tbl, true, nil
+		}
+	}
+
+	// tags are unique across the tables of a root, so at most one table has tags of the purged table
+	var found doltdb.TableName
+	err = root.IterTables(p.sqlCtx, func(name doltdb.TableName, table *doltdb.Table, sch schema.Schema) (bool, error) {
+		if !p.hasTag(sch) {
+			return false, nil
+		}
+		found, tbl, ok = name, table, true
+		return true, nil
+	})
+	if err != nil || !ok {
+		return doltdb.TableName{}, nil, false, err
+	}
+	return found, tbl, true, nil
+}
+
+// hasTag returns whether |sch| has a column tag of the purged table.
+func (p *rowPurger) hasTag(sch schema.Schema) bool {
+	for _, tag := range sch.GetAllCols().Tags {
+		if _, ok := p.tags[tag]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// purgeTable returns |tbl|, the version of the purged table named |tableName| in |root|, without the rows matching
+// the filter of this purger. It returns |tbl| itself when no row matches.
+func (p *rowPurger) purgeTable(root doltdb.RootValue, tableName doltdb.TableName, tbl *doltdb.Table) (*doltdb.Table, error) {
+	ctx := p.sqlCtx
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := expranalysis.ResolveTableExpression(ctx, p.tableName.Name, sch, p.where)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve the filter against the schema of table %s: %w", p.tableName.Name, err)
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := table.NewTableIterator(ctx, sch, rowData)
+	if err != nil {
+		return nil, err
+	}
+	var matches []sql.Row
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			iter.Close(ctx)
+			return nil, err
+		}
+		res, err := filter.Eval(ctx, row)
+		if err != nil {
+			iter.Close(ctx)
+			return nil, err
+		}
+		if sql.IsTrue(res) {
+			matches = append(matches, row)
+		}
+	}
+	if err = iter.Close(ctx); err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return tbl, nil
+	}
+
+	// rows are deleted through a write session so that secondary indexes are kept consistent
+	ws := doltdb.EmptyWorkingSet(ref.NewWorkingSetRef("purge-rows")).WithWorkingRoot(root).WithStagedRoot(root)
+	tracker, err := dsess.NewAutoIncrementTracker(ctx, p.dbName, ws)
+	if err != nil {
+		return nil, err
+	}
+	writeSession := writer.NewWriteSession(tbl.Format(), ws, tracker, editor.Options{})
+	tableWriter, err := writeSession.GetTableWriter(ctx, tableName, p.dbName, noopRootSetter, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range matches {
+		if err = tableWriter.Delete(ctx, row); err != nil {
+			return nil, err
+		}
+	}
+	if err = tableWriter.Close(ctx); err != nil {
+		return nil, err
+	}
+	ws, err = writeSession.Flush(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newTbl, ok, err := ws.WorkingRoot().GetTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
+	return newTbl, nil
+}
+
+// noopRootSetter discards the roots written by a table writer, which are read back from its write session instead.
+func noopRootSetter(*sql.Context, string, doltdb.RootValue) error {
+	return nil
+}
@@ -0,0 +1,412 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// RetainForever is the age of a retention tier which never expires.
+const RetainForever = time.Duration(math.MaxInt64)
+
+// RetentionPolicy describes the history kept when squashing the history of a database. Ages are relative to the time
+// history is squashed, and a zero age disables a tier:
+//   - every commit younger than KeepAll is kept,
+//   - of the older commits, the last commit of each day on the first-parent history of each ref is kept until
+//     KeepDaily,
+//   - of the older commits still, the last commit of each week on the first-parent history of each ref is kept until
+//     KeepWeekly.
+//
+// Every other commit is squashed into the next kept commit. The heads of all refs, root commits and the commits in
+// Preserve are always kept.
+type RetentionPolicy struct {
+	KeepAll    time.Duration
+	KeepDaily  time.Duration
+	KeepWeekly time.Duration
+	Preserve   []hash.Hash
+}
+
+// ParseRetentionAge parses the age of a retention tier, which is a number of days (90d), weeks (12w) or years (1y), a
+// Go duration (36h), or "forever".
+func ParseRetentionAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	if strings.EqualFold(s, "forever") {
+		return RetainForever, nil
+	}
+
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"w", 7 * 24 * time.Hour},
+		{"y", 365 * 24 * time.Hour},
+	}
+	for _, u := range units {
+		if n, ok := strings.CutSuffix(s, u.suffix); ok {
+			v, err := strconv.ParseInt(n, 10, 64)
+			if err != nil || v < 0 || v > math.MaxInt64/int64(u.unit) {
+				break
+			}
+			return time.Duration(v) * u.unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid retention age '%s', expected a number of days (90d), weeks (12w) or years (1y), a duration (36h), or forever", s)
+	}
+	return d, nil
+}
+
+// SquashedRef describes how squashing history changed a ref.
+type SquashedRef struct {
+	Ref     ref.DoltRef
+	OldHead hash.Hash
+	// NewHead is the head of the ref after squashing, which is empty for a dry run.
+	NewHead hash.Hash
+	// Commits is the number of commits in the history of the ref before squashing, and KeptCommits the number after.
+	Commits     int
+	KeptCommits int
+}
+
+// SquashHistory rewrites the history of every branch, tag and remote-tracking branch of |ddb| to keep only the commits
+// selected by |policy|, and returns how each ref changed, ordered by ref. A kept commit keeps its root value and
+// metadata, and its parents are replaced by the nearest kept commits in its original history. Every descendant of a
+// squashed commit is rewritten, and all refs are moved to their rewritten heads atomically. Working sets are not
+// changed, since squashing history doesn't change the root value of any kept commit. If |dryRun| is true, nothing is
+// written.
+//
+// The squashed commits remain in the chunk store until it is garbage collected. In-progress merges and rebases
+// reference commits that can't be rewritten, so SquashHistory refuses to run while any exist. Stashes are left as is,
+// and keep the history of the commits they were created on reachable.
+func SquashHistory(ctx context.Context, ddb *doltdb.DoltDB, policy RetentionPolicy, now time.Time, dryRun bool) ([]SquashedRef, error) {
+	if policy.KeepAll <= 0 && policy.KeepDaily <= 0 && policy.KeepWeekly <= 0 {
+		return nil, errors.New("a retention policy must keep at least one of all, daily or weekly commits")
+	}
+
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkNoMergeOrRebase(ctx, ddb, branches, "squash history"); err != nil {
+		return nil, err
+	}
+	tags, err := ddb.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := ddb.GetRemoteRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refs := append(append(branches, tags...), remotes...)
+
+	g := &historyGraph{nodes: make(map[hash.Hash]*historyNode)}
+	heads := make([]hash.Hash, len(refs))
+	for i, r := range refs {
+		cm, err := ddb.ResolveCommitRef(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		if heads[i], err = cm.HashOf(); err != nil {
+			return nil, err
+		}
+		if err = g.load(ctx, ddb, cm); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = g.markKept(policy, heads, now); err != nil {
+		return nil, err
+	}
+
+	squashed := make([]SquashedRef, len(refs))
+	for i, r := range refs {
+		squashed[i] = SquashedRef{Ref: r, OldHead: heads[i]}
+		squashed[i].Commits, squashed[i].KeptCommits = g.count(heads[i])
+	}
+
+	if !dryRun {
+		rewritten, err := g.rewrite(ctx, ddb, heads)
+		if err != nil {
+			return nil, err
+		}
+		var updates []doltdb.RefUpdate
+		for i, r := range refs {
+			squashed[i].NewHead = rewritten[heads[i]]
+			if squashed[i].NewHead != heads[i] {
+				updates = append(updates, doltdb.RefUpdate{Ref: r, Prev: heads[i], New: squashed[i].NewHead})
+			}
+		}
+		if len(updates) > 0 {
+			err = ddb.UpdateRefs(ctx, updates)
+			if errors.Is(err, datas.ErrOptimisticLockFailed) {
+				return nil, errors.New("refs were updated while history was being squashed, try again")
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(squashed, func(i, j int) bool {
+		return squashed[i].Ref.String() < squashed[j].Ref.String()
+	})
+	return squashed, nil
+}
+
+// historyGraph is the commit graph of a database, loaded into memory.
+type historyGraph struct {
+	nodes map[hash.Hash]*historyNode
+}
+
+type historyNode struct {
+	parents []hash.Hash
+	time    time.Time
+	keep    bool
+	// walked is set once the node has been visited on the first-parent history of a ref.
+	walked bool
+	// visit is the state of the node in the depth-first traversal of the graph: unvisited, visiting or visited.
+	visit uint8
+}
+
+// load adds the history of |head| to the graph.
+func (g *historyGraph) load(ctx context.Context, ddb *doltdb.DoltDB, head *doltdb.Commit) error {
+	stack := []*doltdb.Commit{head}
+	for len(stack) > 0 {
+		cm := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		if _, ok := g.nodes[h]; ok {
+			continue
+		}
+
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return err
+		}
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return err
+		}
+		g.nodes[h] = &historyNode{parents: parents, time: meta.Time()}
+
+		for _, p := range parents {
+			if _, ok := g.nodes[p]; ok {
+				continue
+			}
+			optCm, err := ddb.ReadCommit(ctx, p)
+			if err != nil {
+				return err
+			}
+			parent, ok := optCm.ToCommit()
+			if !ok {
+				return doltdb.ErrGhostCommitEncountered
+			}
+			stack = append(stack, parent)
+		}
+	}
+	return nil
+}
+
+// markKept marks the commits kept by |policy|.
+func (g *historyGraph) markKept(policy RetentionPolicy, heads []hash.Hash, now time.Time) error {
+	for _, h := range policy.Preserve {
+		n, ok := g.nodes[h]
+		if !ok {
+			return fmt.Errorf("commit %s to preserve is not in the history of any branch or tag", h.String())
+		}
+		n.keep = true
+	}
+	for _, n := range g.nodes {
+		if len(n.parents) == 0 || now.Sub(n.time) < policy.KeepAll {
+			n.keep = true
+		}
+	}
+
+	for _, head := range heads {
+		g.nodes[head].keep = true
+
+		// Walk the first-parent history of the ref from newest to oldest commit, keeping the first commit seen in each
+		// day or week. Once we reach history walked for another ref, its days and weeks have already been kept.
+		days := make(map[string]bool)
+		weeks := make(map[[2]int]bool)
+		for h := head; ; {
+			n := g.nodes[h]
+			age := now.Sub(n.time)
+			t := n.time.UTC()
+			switch {
+			case age < policy.KeepAll:
+			case age < policy.KeepDaily:
+				day := t.Format(time.DateOnly)
+				if !days[day] {
+					days[day] = true
+					n.keep = true
+				}
+			case age < policy.KeepWeekly:
+				year, week := t.ISOWeek()
+				if !weeks[[2]int{year, week}] {
+					weeks[[2]int{year, week}] = true
+					n.keep = true
+				}
+			}
+
+			if n.walked || len(n.parents) == 0 {
+				break
+			}
+			n.walked = true
+			h = n.parents[0]
+		}
+	}
+	return nil
+}
+
+// count returns the number of commits, and the number of kept commits, in the history of |head|.
+func (g *historyGraph) count(head hash.Hash) (commits, kept int) {
+	seen := hash.NewHashSet(head)
+	queue := []hash.Hash{head}
+	for len(queue) > 0 {
+		n := g.nodes[queue[0]]
+		queue = queue[1:]
+		commits++
+		if n.keep {
+			kept++
+		}
+		for _, p := range n.parents {
+			if !seen.Has(p) {
+				seen.Insert(p)
+				queue = append(queue, p)
+			}
+		}
+	}
+	return commits, kept
+}
+
+// topologicalOrder returns the commits in the history of |heads|, with every commit after its parents.
+func (g *historyGraph) topologicalOrder(heads []hash.Hash) []hash.Hash {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	type frame struct {
+		h    hash.Hash
+		next int
+	}
+
+	order := make([]hash.Hash, 0, len(g.nodes))
+	for _, head := range heads {
+		if g.nodes[head].visit != unvisited {
+			continue
+		}
+		g.nodes[head].visit = visiting
+		stack := []frame{{h: head}}
+		for len(stack) > 0 {
+			f := &stack[len(stack)-1]
+			n := g.nodes[f.h]
+			if f.next < len(n.parents) {
+				p := n.parents[f.next]
+				f.next++
+				if g.nodes[p].visit == unvisited {
+					g.nodes[p].visit = visiting
+					stack = append(stack, frame{h: p})
+				}
+				continue
+			}
+			n.visit = visited
+			order = append(order, f.h)
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return order
+}
+
+// rewrite writes the squashed history of |heads|, and returns the rewritten commit of every kept commit.
+func (g *historyGraph) rewrite(ctx context.Context, ddb *doltdb.DoltDB, heads []hash.Hash) (map[hash.Hash]hash.Hash, error) {
+	// nearest maps each squashed commit to the nearest kept commits in its history
+	nearest := make(map[hash.Hash][]hash.Hash)
+	rewritten := make(map[hash.Hash]hash.Hash)
+	for _, h := range g.topologicalOrder(heads) {
+		n := g.nodes[h]
+		var parents []hash.Hash
+		for _, p := range n.parents {
+			kept := []hash.Hash{p}
+			if !g.nodes[p].keep {
+				kept = nearest[p]
+			}
+			for _, k := range kept {
+				if !slices.Contains(parents, k) {
+					parents = append(parents, k)
+				}
+			}
+		}
+		if !n.keep {
+			nearest[h] = parents
+			continue
+		}
+
+		for i := range parents {
+			parents[i] = rewritten[parents[i]]
+		}
+		if slices.Equal(parents, n.parents) {
+			rewritten[h] = h
+			continue
+		}
+
+		optCm, err := ddb.ReadCommit(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		cm, ok := optCm.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		newCm, err := ddb.CommitDangling(ctx, root.NomsValue(), datas.CommitOptions{Parents: parents, Meta: meta})
+		if err != nil {
+			return nil, err
+		}
+		if rewritten[h], err = newCm.HashOf(); err != nil {
+			return nil, err
+		}
+	}
+	return rewritten, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestParseRetentionAge(t *testing.T) {
+	tests := []struct {
+		age      string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"0", 0},
+		{"90d", 90 * 24 * time.Hour},
+		{"12w", 12 * 7 * 24 * time.Hour},
+		{"1y", 365 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"forever", RetainForever},
+		{"Forever", RetainForever},
+	}
+	for _, test := range tests {
+		t.Run(test.age, func(t *testing.T) {
+			age, err := ParseRetentionAge(test.age)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, age)
+		})
+	}
+
+	for _, invalid := range []string{"-1d", "d", "1.5d", "ninety days", "-5h", "9999999999999999y"} {
+		t.Run(invalid, func(t *testing.T) {
+			_, err := ParseRetentionAge(invalid)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// testHistory builds a history graph from commits given as name, time and parent names.
+func testHistory(t *testing.T, commits ...testHistoryCommit) *historyGraph {
+	g := &historyGraph{nodes: make(map[hash.Hash]*historyNode)}
+	for _, c := range commits {
+		tm, err := time.Parse(time.RFC3339, c.time)
+		require.NoError(t, err)
+		n := &historyNode{time: tm}
+		for _, p := range c.parents {
+			n.parents = append(n.parents, testCommitHash(p))
+		}
+		g.nodes[testCommitHash(c.name)] = n
+	}
+	return g
+}
+
+type testHistoryCommit struct {
+	name    string
+	time    string
+	parents []string
+}
+
+func testCommitHash(name string) hash.Hash {
+	return hash.Of([]byte(name))
+}
+
+func keptCommits(g *historyGraph, names ...string) []string {
+	var kept []string
+	for _, name := range names {
+		if g.nodes[testCommitHash(name)].keep {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+func TestSquashHistoryPolicy(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2020-03-01T00:00:00Z")
+	require.NoError(t, err)
+	history := []testHistoryCommit{
+		{"root", "2019-01-01T00:00:00Z", nil},
+		{"a", "2020-01-01T10:00:00Z", []string{"root"}},
+		{"b", "2020-01-01T12:00:00Z", []string{"a"}},
+		{"c", "2020-01-02T10:00:00Z", []string{"b"}},
+		{"d", "2020-01-09T10:00:00Z", []string{"c"}},
+		{"e", "2020-02-29T10:00:00Z", []string{"d"}},
+	}
+	all := []string{"root", "a", "b", "c", "d", "e"}
+	head := []hash.Hash{testCommitHash("e")}
+
+	t.Run("keep all", func(t *testing.T) {
+		g := testHistory(t, history...)
+		require.NoError(t, g.markKept(RetentionPolicy{KeepAll: 7 * 24 * time.Hour}, head, now))
+		assert.Equal(t, []string{"root", "e"}, keptCommits(g, all...))
+		commits, kept := g.count(head[0])
+		assert.Equal(t, 6, commits)
+		assert.Equal(t, 2, kept)
+	})
+
+	t.Run("keep daily", func(t *testing.T) {
+		g := testHistory(t, history...)
+		require.NoError(t, g.markKept(RetentionPolicy{KeepAll: 7 * 24 * time.Hour, KeepDaily: 60 * 24 * time.Hour}, head, now))
+		assert.Equal(t, []string{"root", "b", "c", "d", "e"}, keptCommits(g, all...))
+	})
+
+	t.Run("keep weekly", func(t *testing.T) {
+		g := testHistory(t, history...)
+		require.NoError(t, g.markKept(RetentionPolicy{KeepAll: 7 * 24 * time.Hour, KeepWeekly: RetainForever}, head, now))
+		assert.Equal(t, []string{"root", "c", "d", "e"}, keptCommits(g, all...))
+	})
+
+	t.Run("keep daily then weekly", func(t *testing.T) {
+		g := testHistory(t, history...)
+		require.NoError(t, g.markKept(RetentionPolicy{KeepDaily: 55 * 24 * time.Hour, KeepWeekly: RetainForever}, head, now))
+		assert.Equal(t, []string{"root", "c", "d", "e"}, keptCommits(g, all...))
+	})
+
+	t.Run("preserve and heads", func(t *testing.T) {
+		g := testHistory(t, history...)
+		policy := RetentionPolicy{KeepAll: time.Hour, Preserve: []hash.Hash{testCommitHash("a")}}
+		require.NoError(t, g.markKept(policy, []hash.Hash{testCommitHash("e"), testCommitHash("c")}, now))
+		assert.Equal(t, []string{"root", "a", "c", "e"}, keptCommits(g, all...))
+
+		policy.Preserve = []hash.Hash{testCommitHash("unknown")}
+		assert.Error(t, g.markKept(policy, head, now))
+	})
+}
+
+func TestSquashHistoryTopologicalOrder(t *testing.T) {
+	g := testHistory(t,
+		testHistoryCommit{"root", "2020-01-01T00:00:00Z", nil},
+		testHistoryCommit{"a", "2020-01-02T00:00:00Z", []string{"root"}},
+		testHistoryCommit{"b", "2020-01-03T00:00:00Z", []string{"root"}},
+		testHistoryCommit{"merge", "2020-01-04T00:00:00Z", []string{"a", "b"}},
+		testHistoryCommit{"c", "2020-01-05T00:00:00Z", []string{"merge"}},
+	)
+	heads := []hash.Hash{testCommitHash("c"), testCommitHash("b")}
+
+	order := g.topologicalOrder(heads)
+	require.Len(t, order, 5)
+	position := make(map[hash.Hash]int)
+	for i, h := range order {
+		position[h] = i
+	}
+	for h, n := range g.nodes {
+		for _, p := range n.parents {
+			assert.Less(t, position[p], position[h])
+		}
+	}
+
+	commits, _ := g.count(testCommitHash("c"))
+	assert.Equal(t, 5, commits)
+	commits, _ = g.count(testCommitHash("b"))
+	assert.Equal(t, 2, commits)
+}
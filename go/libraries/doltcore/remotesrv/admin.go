@@ -0,0 +1,152 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ErrRepoExists = errors.New("repository already exists")
+var ErrRepoNotFound = errors.New("repository not found")
+
+// RepoManager creates and deletes the repositories hosted by a server. A DBCache backed by a RepoManager should
+// return ErrRepoNotFound for the repositories which haven't been created, instead of creating them.
+type RepoManager interface {
+	// CreateRepo creates an empty repository at |repoPath| with the storage format |nbfVerStr|. It returns
+	// ErrRepoExists if the repository already exists.
+	CreateRepo(ctx context.Context, repoPath, nbfVerStr string) error
+	// DeleteRepo deletes the repository at |repoPath| and all of its storage. It returns ErrRepoNotFound if the
+	// repository doesn't exist.
+	DeleteRepo(ctx context.Context, repoPath string) error
+	// ListRepos returns the paths of all the repositories, in order.
+	ListRepos(ctx context.Context) ([]string, error)
+}
+
+// RepoAdminServer implements the RepoAdminService on top of a RepoManager. Access to it should be restricted to
+// admins, as done by RepoACLInterceptor.
+type RepoAdminServer struct {
+	lgr     *logrus.Entry
+	repos   RepoManager
+	dbCache DBCache
+	quotas  RepoQuotas
+
+	remotesapi.UnimplementedRepoAdminServiceServer
+}
+
+var _ remotesapi.RepoAdminServiceServer = (*RepoAdminServer)(nil)
+
+// NewRepoAdminServer returns a RepoAdminServer managing |repos|, whose stores are accessed through |dbCache|. |quotas|
+// may be nil.
+func NewRepoAdminServer(lgr *logrus.Entry, repos RepoManager, dbCache DBCache, quotas RepoQuotas) *RepoAdminServer {
+	return &RepoAdminServer{
+		lgr: lgr.WithFields(logrus.Fields{
+			"service": "dolt.services.remotesapi.v1alpha1.RepoAdminServiceServer",
+		}),
+		repos:   repos,
+		dbCache: dbCache,
+		quotas:  quotas,
+	}
+}
+
+func (s *RepoAdminServer) CreateRepository(ctx context.Context, req *remotesapi.CreateRepositoryRequest) (*remotesapi.CreateRepositoryResponse, error) {
+	logger := getReqLogger(s.lgr, "CreateRepository")
+	if err := ValidateCreateRepositoryRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger = logger.WithField(RepoPathField, req.RepoPath)
+	defer func() { logger.Trace("finished") }()
+
+	nbfVerStr := req.NbfVersion
+	if nbfVerStr == "" {
+		nbfVerStr = types.Format_Default.VersionString()
+	}
+	err := s.repos.CreateRepo(ctx, req.RepoPath, nbfVerStr)
+	if errors.Is(err, ErrRepoExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "repository %s already exists", req.RepoPath)
+	} else if err != nil {
+		logger.WithError(err).Error("error creating repository")
+		return nil, status.Errorf(codes.Internal, "failed to create repository: %v", err)
+	}
+
+	p, _ := PrincipalFromContext(ctx)
+	logger.WithField("principal", p.String()).Info("created repository")
+	return &remotesapi.CreateRepositoryResponse{}, nil
+}
+
+func (s *RepoAdminServer) DeleteRepository(ctx context.Context, req *remotesapi.DeleteRepositoryRequest) (*remotesapi.DeleteRepositoryResponse, error) {
+	logger := getReqLogger(s.lgr, "DeleteRepository")
+	if err := ValidateDeleteRepositoryRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger = logger.WithField(RepoPathField, req.RepoPath)
+	defer func() { logger.Trace("finished") }()
+
+	err := s.repos.DeleteRepo(ctx, req.RepoPath)
+	if errors.Is(err, ErrRepoNotFound) {
+		return nil, status.Errorf(codes.NotFound, "repository %s not found", req.RepoPath)
+	} else if err != nil {
+		logger.WithError(err).Error("error deleting repository")
+		return nil, status.Errorf(codes.Internal, "failed to delete repository: %v", err)
+	}
+
+	p, _ := PrincipalFromContext(ctx)
+	logger.WithField("principal", p.String()).Info("deleted repository")
+	return &remotesapi.DeleteRepositoryResponse{}, nil
+}
+
+func (s *RepoAdminServer) ListRepositories(ctx context.Context, req *remotesapi.ListRepositoriesRequest) (*remotesapi.ListRepositoriesResponse, error) {
+	logger := getReqLogger(s.lgr, "ListRepositories")
+	defer func() { logger.Trace("finished") }()
+
+	paths, err := s.repos.ListRepos(ctx)
+	if err != nil {
+		logger.WithError(err).Error("error listing repositories")
+		return nil, status.Errorf(codes.Internal, "failed to list repositories: %v", err)
+	}
+
+	repos := make([]*remotesapi.RepositoryInfo, 0, len(paths))
+	for _, repoPath := range paths {
+		cs, err := s.dbCache.Get(ctx, repoPath, types.Format_Default.VersionString())
+		if errors.Is(err, ErrRepoNotFound) {
+			// deleted since it was listed
+			continue
+		} else if err != nil {
+			logger.WithError(err).WithField(RepoPathField, repoPath).Error("error getting repository store")
+			return nil, status.Errorf(codes.Internal, "failed to get repository %s: %v", repoPath, err)
+		}
+		size, err := cs.Size(ctx)
+		if err != nil {
+			logger.WithError(err).WithField(RepoPathField, repoPath).Error("error calling Size")
+			return nil, status.Errorf(codes.Internal, "failed to get size of repository %s: %v", repoPath, err)
+		}
+
+		info := &remotesapi.RepositoryInfo{RepoPath: repoPath, StorageSize: size}
+		if s.quotas != nil {
+			info.QuotaSize, _ = s.quotas.MaxRepoSize(repoPath)
+		}
+		repos = append(repos, info)
+	}
+
+	logger = logger.WithField("num_repositories", len(repos))
+	return &remotesapi.ListRepositoriesResponse{Repositories: repos}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"errors"
+	"net"
+	"sync"
+)
+
+// ServeConn serves gRPC and HTTP requests over the single connection |conn|, and returns once it is closed. Both are
+// served as HTTP/2 without TLS, so the server must have been created with the same HttpListenAddr and
+// GrpcListenAddr. This is used to serve a remote over the stdin and stdout of a process, such as one run over ssh.
+func (s *Server) ServeConn(conn net.Conn) error {
+	if s.httpListenAddr != s.grpcListenAddr {
+		return errors.New("remotesrv: ServeConn requires gRPC and HTTP to be served on the same address")
+	}
+
+	l := newSingleConnListener(conn)
+	err := s.httpSrv.Serve(l)
+
+	s.grpcSrv.Stop()
+	s.grpcHttpReqsWG.Wait()
+
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// singleConnListener is a net.Listener that accepts a single connection. Once it has been accepted, Accept blocks
+// until the connection is closed.
+type singleConnListener struct {
+	conn   net.Conn
+	once   sync.Once
+	closed chan struct{}
+}
+
+var _ net.Listener = (*singleConnListener)(nil)
+
+func newSingleConnListener(conn net.Conn) *singleConnListener {
+	l := &singleConnListener{closed: make(chan struct{})}
+	l.conn = &notifyCloseConn{Conn: conn, l: l}
+	return l
+}
+
+func (l *singleConnListener) Accept() (net.Conn, error) {
+	var conn net.Conn
+	l.once.Do(func() {
+		conn = l.conn
+	})
+	if conn != nil {
+		return conn, nil
+	}
+	<-l.closed
+	return nil, net.ErrClosed
+}
+
+func (l *singleConnListener) Close() error {
+	return l.conn.Close()
+}
+
+func (l *singleConnListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+// notifyCloseConn closes the listener that accepted it when it is closed.
+type notifyCloseConn struct {
+	net.Conn
+	l         *singleConnListener
+	closeOnce sync.Once
+}
+
+func (c *notifyCloseConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(func() {
+		close(c.l.closed)
+	})
+	return err
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestServeConn(t *testing.T) {
+	srv, err := NewServer(ServerArgs{
+		HttpListenAddr: "pipe",
+		GrpcListenAddr: "pipe",
+		FS:             filesys.EmptyInMemFS("/"),
+	})
+	require.NoError(t, err)
+
+	client, server := net.Pipe()
+	done := make(chan error)
+	go func() {
+		done <- srv.ServeConn(server)
+	}()
+
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(context.Context, string, string, *tls.Config) (net.Conn, error) {
+			return client, nil
+		},
+	}
+	resp, err := (&http.Client{Transport: transport}).Get("http://pipe/repo/not-a-table-file")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	transport.CloseIdleConnections()
+	assert.NoError(t, <-done)
+}
+
+func TestServeConnRequiresMultiplexing(t *testing.T) {
+	srv, err := NewServer(ServerArgs{
+		HttpListenAddr: ":8080",
+		GrpcListenAddr: ":50051",
+		FS:             filesys.EmptyInMemFS("/"),
+	})
+	require.NoError(t, err)
+
+	client, server := net.Pipe()
+	defer client.Close()
+	assert.Error(t, srv.ServeConn(server))
+}
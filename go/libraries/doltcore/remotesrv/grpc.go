@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync/atomic"
 
+	"github.com/dustin/go-humanize"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -55,9 +57,36 @@ type RemoteChunkStore struct {
 	fs      filesys.Filesys
 	lgr     *logrus.Entry
 	sealer  Sealer
+
+	// pushValidator, if set, is called before the root of a store is updated by Commit.
+	pushValidator PushValidator
+	// pushListener, if set, is called after the root of a store is updated by Commit.
+	pushListener PushListener
+	// quotas, if set, limits the size the stores can grow to through pushes.
+	quotas RepoQuotas
 	remotesapi.UnimplementedChunkStoreServiceServer
 }
 
+// PushValidator checks a push to the store at |repoPath| before the root of the store is moved from |last| to
+// |current|. The chunks reachable from |current| are available in |cs|. An error rejects the push, and is returned
+// to the client.
+type PushValidator interface {
+	ValidatePush(ctx context.Context, repoPath string, cs RemoteSrvStore, last, current hash.Hash) error
+}
+
+// PushListener is notified of every successful push to the store at |repoPath|, after the root of the store has been
+// moved from |last| to |current|. It is called before the push is acknowledged, so it should not do expensive work.
+type PushListener interface {
+	PushCompleted(ctx context.Context, repoPath string, cs RemoteSrvStore, last, current hash.Hash)
+}
+
+// RepoQuotas limits the storage size of the repositories served by a RemoteChunkStore.
+type RepoQuotas interface {
+	// MaxRepoSize returns the maximum total size in bytes of the table files of the store at |repoPath|, and false if
+	// the store has no quota.
+	MaxRepoSize(repoPath string) (uint64, bool)
+}
+
 func NewHttpFSBackedChunkStore(lgr *logrus.Entry, httpHost string, csCache DBCache, fs filesys.Filesys, scheme string, concurrencyControl remotesapi.PushConcurrencyControl, sealer Sealer) *RemoteChunkStore {
 	if concurrencyControl == remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_UNSPECIFIED {
 		concurrencyControl = remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_IGNORE_WORKING_SET
@@ -383,7 +412,7 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Trace("finished") }()
 
-	_, err := rs.getStore(ctx, logger, repoPath)
+	cs, err := rs.getStore(ctx, logger, repoPath)
 	if err != nil {
 		return nil, err
 	}
@@ -393,6 +422,14 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 		return nil, err
 	}
 
+	var uploadSize uint64
+	for _, tfd := range tfds {
+		uploadSize += tfd.ContentLength
+	}
+	if err = rs.checkQuota(ctx, logger, repoPath, cs, uploadSize); err != nil {
+		return nil, err
+	}
+
 	md, _ := metadata.FromIncomingContext(ctx)
 
 	var locs []*remotesapi.UploadLoc
@@ -495,6 +532,10 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 		updates[hash.New(cti.Hash).String()] = int(cti.ChunkCount)
 	}
 
+	if err = rs.checkUploadedTableFilesQuota(ctx, logger, repoPath, cs, updates); err != nil {
+		return nil, err
+	}
+
 	err = cs.AddTableFilesToManifest(ctx, updates, rs.getAddrs(cs.Version()))
 	if err != nil {
 		logger.WithError(err).Error("error calling AddTableFilesToManifest")
@@ -508,6 +549,14 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 	currHash := hash.New(req.Current)
 	lastHash := hash.New(req.Last)
 
+	if rs.pushValidator != nil {
+		err = rs.pushValidator.ValidatePush(ctx, repoPath, cs, lastHash, currHash)
+		if err != nil {
+			logger.WithError(err).Info("push rejected")
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
 	var ok bool
 	ok, err = cs.Commit(ctx, currHash, lastHash)
 	if err != nil {
@@ -523,6 +572,9 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 	}
 
 	logger.Tracef("Commit success; moved from %s -> %s", lastHash.String(), currHash.String())
+	if ok && rs.pushListener != nil {
+		rs.pushListener.PushCompleted(ctx, repoPath, cs, lastHash, currHash)
+	}
 	return &remotesapi.CommitResponse{Success: ok}, nil
 }
 
@@ -652,6 +704,10 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 		updates[hash.New(cti.Hash).String()] = int(cti.ChunkCount)
 	}
 
+	if err = rs.checkUploadedTableFilesQuota(ctx, logger, repoPath, cs, updates); err != nil {
+		return nil, err
+	}
+
 	err = cs.AddTableFilesToManifest(ctx, updates, rs.getAddrs(cs.Version()))
 	if err != nil {
 		logger.WithError(err).Error("error occurred updating the manifest")
@@ -686,6 +742,75 @@ func (rs *RemoteChunkStore) getAddrs(version string) chunks.GetAddrsCurry {
 	}
 }
 
+// checkQuota returns a ResourceExhausted error if adding |additional| bytes to the store at |repoPath| would exceed
+// its quota.
+func (rs *RemoteChunkStore) checkQuota(ctx context.Context, logger *logrus.Entry, repoPath string, cs RemoteSrvStore, additional uint64) error {
+	if rs.quotas == nil {
+		return nil
+	}
+	maxSize, ok := rs.quotas.MaxRepoSize(repoPath)
+	if !ok {
+		return nil
+	}
+	size, err := cs.Size(ctx)
+	if err != nil {
+		logger.WithError(err).Error("error calling Size")
+		return status.Error(codes.Internal, "failed to get store size")
+	}
+	if size+additional > maxSize {
+		logger.WithFields(logrus.Fields{
+			"size":       size,
+			"additional": additional,
+			"max_size":   maxSize,
+		}).Info("push rejected by quota")
+		return status.Errorf(codes.ResourceExhausted, "push would grow %s to %s, exceeding its storage quota of %s", repoPath, humanize.Bytes(size+additional), humanize.Bytes(maxSize))
+	}
+	return nil
+}
+
+// checkUploadedTableFilesQuota checks the quota of the store at |repoPath| before the uploaded table files in
+// |updates| are added to its manifest. Table files which are already in the manifest don't count against the quota.
+func (rs *RemoteChunkStore) checkUploadedTableFilesQuota(ctx context.Context, logger *logrus.Entry, repoPath string, cs RemoteSrvStore, updates map[string]int) error {
+	if rs.quotas == nil {
+		return nil
+	}
+	if _, ok := rs.quotas.MaxRepoSize(repoPath); !ok {
+		return nil
+	}
+	dir, ok := cs.Path()
+	if !ok {
+		return nil
+	}
+
+	_, tables, appendixTables, err := cs.Sources(ctx)
+	if err != nil {
+		logger.WithError(err).Error("error getting chunk store Sources")
+		return status.Error(codes.Internal, "failed to get sources")
+	}
+	present := make(map[string]bool)
+	for _, t := range append(tables, appendixTables...) {
+		present[t.FileID()] = true
+	}
+
+	var uploaded uint64
+	for id := range updates {
+		if present[id] {
+			continue
+		}
+		for _, name := range []string{id, id + nbs.ArchiveFileSuffix} {
+			info, err := os.Stat(filepath.Join(dir, name))
+			if err == nil {
+				uploaded += uint64(info.Size())
+				break
+			} else if !os.IsNotExist(err) {
+				logger.WithError(err).Error("error getting size of uploaded table file")
+				return status.Error(codes.Internal, "failed to get size of uploaded table file")
+			}
+		}
+	}
+	return rs.checkQuota(ctx, logger, repoPath, cs, uploaded)
+}
+
 func (rs *RemoteChunkStore) getStore(ctx context.Context, logger *logrus.Entry, repoPath string) (RemoteSrvStore, error) {
 	return rs.getOrCreateStore(ctx, logger, repoPath, types.Format_Default.VersionString())
 }
@@ -696,6 +821,8 @@ func (rs *RemoteChunkStore) getOrCreateStore(ctx context.Context, logger *logrus
 		logger.WithError(err).Error("Failed to retrieve chunkstore")
 		if errors.Is(err, ErrUnimplemented) {
 			return nil, status.Error(codes.Unimplemented, err.Error())
+		} else if errors.Is(err, ErrRepoNotFound) {
+			return nil, status.Errorf(codes.NotFound, "repository %s not found", repoPath)
 		}
 		return nil, err
 	}
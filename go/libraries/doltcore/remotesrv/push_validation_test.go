@@ -0,0 +1,179 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	cmd "github.com/dolthub/dolt/go/cmd/dolt/commands"
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// TestPushCannotForgeApprovals pushes, in two steps, a protected branch that hasn't been approved: the first push
+// only records an approval of the pushed commit, and the second moves the branch to it.
+func TestPushCannotForgeApprovals(t *testing.T) {
+	ctx := context.Background()
+	dEnv, cs := newProtectedRepo(t, "('main', true, NULL, 1, false)")
+	ddb := dEnv.DoltDB(ctx)
+	client := newPushClient(t, dEnv, cs)
+
+	feature, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("feature"))
+	require.NoError(t, err)
+	featureHash, err := feature.HashOf()
+	require.NoError(t, err)
+
+	last, err := cs.Root(ctx)
+	require.NoError(t, err)
+	forged := forgeRoot(t, cs, last, func() error {
+		return ddb.SetBranchApprovals(ctx, []doltdb.BranchApproval{{Commit: featureHash.String(), Approver: "reviewer"}})
+	})
+	_, err = client.Commit(ctx, &remotesapi.CommitRequest{RepoPath: "repo", Current: forged[:], Last: last[:]})
+	require.ErrorContains(t, err, branch_protection.ErrPushedApprovals.New().Error())
+
+	moved := forgeRoot(t, cs, last, func() error {
+		return ddb.SetHeadToCommit(ctx, ref.NewBranchRef("main"), feature)
+	})
+	_, err = client.Commit(ctx, &remotesapi.CommitRequest{RepoPath: "repo", Current: moved[:], Last: last[:]})
+	require.ErrorContains(t, err, "has 0 of the 1 required approvals")
+
+	root, err := cs.Root(ctx)
+	require.NoError(t, err)
+	require.Equal(t, last, root)
+}
+
+// TestPushCannotForgeCIResults pushes, in two steps, a protected branch whose required workflow hasn't passed: the
+// first push only records a passing result of the workflow on the pushed commit, and the second moves the branch to
+// it. Only the results of the runs of the server count.
+func TestPushCannotForgeCIResults(t *testing.T) {
+	ctx := context.Background()
+	dEnv, cs := newProtectedRepo(t, "('main', true, 'checks', 0, false)")
+	ddb := dEnv.DoltDB(ctx)
+	client := newPushClient(t, dEnv, cs)
+
+	feature, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("feature"))
+	require.NoError(t, err)
+	featureHash, err := feature.HashOf()
+	require.NoError(t, err)
+
+	last, err := cs.Root(ctx)
+	require.NoError(t, err)
+	forged := forgeRoot(t, cs, last, func() error {
+		return ddb.RecordCIWorkflowResult(ctx, featureHash, doltdb.CIWorkflowResult{Workflow: "checks", Status: doltdb.CIWorkflowPassed})
+	})
+	_, err = client.Commit(ctx, &remotesapi.CommitRequest{RepoPath: "repo", Current: forged[:], Last: last[:]})
+	require.ErrorContains(t, err, branch_protection.ErrPushedApprovals.New().Error())
+
+	moved := forgeRoot(t, cs, last, func() error {
+		return ddb.SetHeadToCommit(ctx, ref.NewBranchRef("main"), feature)
+	})
+	_, err = client.Commit(ctx, &remotesapi.CommitRequest{RepoPath: "repo", Current: moved[:], Last: last[:]})
+	require.ErrorContains(t, err, "workflow `checks` has not passed")
+
+	results, err := ddb.GetCIWorkflowResults(ctx, featureHash)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+// newProtectedRepo returns a repository whose main branch is protected by the rule |rule|, a row of
+// dolt_branch_protection, with a branch `feature` one commit ahead of main, and the chunk store of the repository.
+func newProtectedRepo(t *testing.T, rule string) (*env.DoltEnv, remotesrv.RemoteSrvStore) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnvForLocalFilesystem()
+	t.Cleanup(func() {
+		dEnv.DoltDB(ctx).Close()
+	})
+	cliCtx, verr := cmd.NewArgFreeCliContext(ctx, dEnv, dEnv.FS)
+	require.NoError(t, verr)
+	defer cliCtx.Close()
+
+	query := `CREATE TABLE test (pk int PRIMARY KEY);
+INSERT INTO dolt_branch_protection VALUES ` + rule + `;
+CALL DOLT_COMMIT('-Am', 'protect main');
+CALL DOLT_CHECKOUT('-b', 'feature');
+INSERT INTO test VALUES (1);
+CALL DOLT_COMMIT('-am', 'commit on feature');`
+	require.Equal(t, 0, cmd.SqlCmd{}.Exec(ctx, "sql", []string{"-q", query}, dEnv, cliCtx))
+
+	cs, ok := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(dEnv.DoltDB(ctx))).(remotesrv.RemoteSrvStore)
+	require.True(t, ok)
+	return dEnv, cs
+}
+
+// forgeRoot returns the root written to |cs| by |write|, as a client could push it, and resets the root of |cs| to
+// |last|. The chunks of the returned root remain in |cs|.
+func forgeRoot(t *testing.T, cs remotesrv.RemoteSrvStore, last hash.Hash, write func() error) hash.Hash {
+	ctx := context.Background()
+	require.NoError(t, write())
+	forged, err := cs.Root(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, last, forged)
+	ok, err := cs.Commit(ctx, last, forged)
+	require.NoError(t, err)
+	require.True(t, ok)
+	return forged
+}
+
+// newPushClient serves |cs| with the push validation of sql-server and the remote helper, and returns a client of it.
+func newPushClient(t *testing.T, dEnv *env.DoltEnv, cs remotesrv.RemoteSrvStore) remotesapi.ChunkStoreServiceClient {
+	srv, err := remotesrv.NewServer(remotesrv.ServerArgs{
+		HttpListenAddr: "pipe",
+		GrpcListenAddr: "pipe",
+		FS:             dEnv.FS,
+		DBCache:        singleStoreCache{cs},
+		PushValidator:  branch_protection.PushValidator{},
+	})
+	require.NoError(t, err)
+
+	clientConn, serverConn := net.Pipe()
+	done := make(chan error)
+	go func() {
+		done <- srv.ServeConn(serverConn)
+	}()
+
+	conn, err := grpc.Dial("pipe",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return clientConn, nil
+		}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		<-done
+	})
+	return remotesapi.NewChunkStoreServiceClient(conn)
+}
+
+// singleStoreCache is a remotesrv.DBCache serving a single store for every path.
+type singleStoreCache struct {
+	cs remotesrv.RemoteSrvStore
+}
+
+func (c singleStoreCache) Get(context.Context, string, string) (remotesrv.RemoteSrvStore, error) {
+	return c.cs, nil
+}
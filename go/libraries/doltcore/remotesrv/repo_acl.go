@@ -0,0 +1,367 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dolthub/dolt/go/libraries/utils/jwtauth"
+)
+
+// HostingConfig configures a remotesapi server which hosts the repositories of several tenants. Every request must be
+// authenticated, either with a user name and password, or with a JWT validated against one of the configured JWKS.
+// The access of a principal to a repository is given by the first rule of Repos whose path matches the repository.
+// Admins may access every repository, and create and delete repositories through the RepoAdminService.
+type HostingConfig struct {
+	Users  []HostedUser `yaml:"users,omitempty"`
+	Jwks   []HostedJwks `yaml:"jwks,omitempty"`
+	Admins []Grantee    `yaml:"admins,omitempty"`
+	Repos  []RepoRule   `yaml:"repos,omitempty"`
+}
+
+// HostedUser is a user which authenticates with basic auth.
+type HostedUser struct {
+	Name string `yaml:"name"`
+	// PasswordHash is the bcrypt hash of the password of the user.
+	PasswordHash string `yaml:"password_hash"`
+}
+
+// HostedJwks is a JWKS used to validate the JWTs presented by clients, either as a bearer token or as the password
+// of basic auth. Claims holds the expected values of the iss, aud and sub claims of the JWTs.
+type HostedJwks struct {
+	Name        string            `yaml:"name"`
+	LocationUrl string            `yaml:"location_url"`
+	Claims      map[string]string `yaml:"claims,omitempty"`
+}
+
+// Grantee matches the principals authenticated as User, or whose JWT has all of Claims. The supported claims are iss,
+// sub, aud and on_behalf_of. A User of "*" matches every authenticated principal.
+type Grantee struct {
+	User   string            `yaml:"user,omitempty"`
+	Claims map[string]string `yaml:"claims,omitempty"`
+}
+
+// RepoRule gives the access to the repositories whose path matches Path, a pattern in the syntax of path.Match. A
+// repository with a MaxSize can't grow past it through pushes.
+type RepoRule struct {
+	Path    string    `yaml:"path"`
+	MaxSize string    `yaml:"max_size,omitempty"`
+	Read    []Grantee `yaml:"read,omitempty"`
+	Push    []Grantee `yaml:"push,omitempty"`
+}
+
+// ParseHostingConfig parses a YAML hosting config.
+func ParseHostingConfig(data []byte) (HostingConfig, error) {
+	var cfg HostingConfig
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return HostingConfig{}, fmt.Errorf("error parsing hosting config: %w", err)
+	}
+	return cfg, nil
+}
+
+// RepoPermission is the access a principal has to a repository.
+type RepoPermission int
+
+const (
+	RepoPermissionNone RepoPermission = iota
+	RepoPermissionRead
+	RepoPermissionPush
+)
+
+func (p RepoPermission) String() string {
+	switch p {
+	case RepoPermissionRead:
+		return "read"
+	case RepoPermissionPush:
+		return "push"
+	default:
+		return "none"
+	}
+}
+
+// Principal is the authenticated identity of a request. User is set for users authenticated with a password, and
+// Claims for principals authenticated with a JWT.
+type Principal struct {
+	User   string
+	Claims *jwtauth.Claims
+}
+
+func (p Principal) String() string {
+	if p.Claims != nil {
+		return fmt.Sprintf("jwt(iss=%s, sub=%s)", p.Claims.Issuer, p.Claims.Subject)
+	}
+	return p.User
+}
+
+var grantClaims = []string{"iss", "sub", "aud", "on_behalf_of"}
+
+func (g Grantee) validate() error {
+	if g.User == "" && len(g.Claims) == 0 {
+		return errors.New("a grantee must have a user or claims")
+	}
+	for claim := range g.Claims {
+		if !slices.Contains(grantClaims, claim) {
+			return fmt.Errorf("unsupported claim %s, expected one of %s", claim, strings.Join(grantClaims, ", "))
+		}
+	}
+	return nil
+}
+
+func (g Grantee) matches(p Principal) bool {
+	if g.User == "*" {
+		return true
+	}
+	if g.User != "" && g.User != p.User {
+		return false
+	}
+	if len(g.Claims) > 0 {
+		if p.Claims == nil {
+			return false
+		}
+		for claim, val := range g.Claims {
+			if !claimMatches(p.Claims, claim, val) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func claimMatches(claims *jwtauth.Claims, claim, val string) bool {
+	switch claim {
+	case "iss":
+		return claims.Issuer == val
+	case "sub":
+		return claims.Subject == val
+	case "aud":
+		return claims.Audience.Contains(val)
+	case "on_behalf_of":
+		return claims.OnBehalfOf == val
+	}
+	return false
+}
+
+func anyMatches(grantees []Grantee, p Principal) bool {
+	for _, g := range grantees {
+		if g.matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+type repoRule struct {
+	RepoRule
+	maxSize uint64
+}
+
+// RepoACL authenticates the requests to a server hosting repositories for several tenants, and authorizes them
+// against the rules of its HostingConfig. It also implements RepoQuotas.
+type RepoACL struct {
+	users      map[string][]byte
+	validators []jwtauth.JWTValidator
+	admins     []Grantee
+	rules      []repoRule
+}
+
+var _ RepoQuotas = (*RepoACL)(nil)
+
+// NewRepoACL validates |cfg| and returns the RepoACL for it.
+func NewRepoACL(cfg HostingConfig) (*RepoACL, error) {
+	acl := &RepoACL{users: make(map[string][]byte), admins: cfg.Admins}
+	for _, u := range cfg.Users {
+		if u.Name == "" || u.Name == "*" {
+			return nil, fmt.Errorf("invalid user name %q", u.Name)
+		}
+		if _, ok := acl.users[u.Name]; ok {
+			return nil, fmt.Errorf("duplicate user %s", u.Name)
+		}
+		if _, err := bcrypt.Cost([]byte(u.PasswordHash)); err != nil {
+			return nil, fmt.Errorf("invalid password_hash for user %s, expected a bcrypt hash: %w", u.Name, err)
+		}
+		acl.users[u.Name] = []byte(u.PasswordHash)
+	}
+
+	for _, j := range cfg.Jwks {
+		if j.LocationUrl == "" {
+			return nil, fmt.Errorf("jwks %s has no location_url", j.Name)
+		}
+		provider := jwtauth.JWTProvider{URL: j.LocationUrl}
+		for claim, val := range j.Claims {
+			switch claim {
+			case "iss":
+				provider.Issuer = val
+			case "aud":
+				provider.Audience = val
+			case "sub":
+				provider.Subject = val
+			default:
+				return nil, fmt.Errorf("unsupported claim %s for jwks %s, expected one of iss, aud, sub", claim, j.Name)
+			}
+		}
+		v, err := jwtauth.NewJWTValidator(provider)
+		if err != nil {
+			return nil, fmt.Errorf("error loading jwks %s: %w", j.Name, err)
+		}
+		acl.validators = append(acl.validators, v)
+	}
+
+	for _, g := range cfg.Admins {
+		if err := g.validate(); err != nil {
+			return nil, fmt.Errorf("invalid admin: %w", err)
+		}
+	}
+	for _, r := range cfg.Repos {
+		if _, err := path.Match(r.Path, ""); err != nil || r.Path == "" {
+			return nil, fmt.Errorf("invalid repository path pattern %q", r.Path)
+		}
+		rule := repoRule{RepoRule: r}
+		if r.MaxSize != "" {
+			size, err := humanize.ParseBytes(r.MaxSize)
+			if err != nil || size == 0 {
+				return nil, fmt.Errorf("invalid max_size %q for repositories %s", r.MaxSize, r.Path)
+			}
+			rule.maxSize = size
+		}
+		for _, g := range append(slices.Clone(r.Read), r.Push...) {
+			if err := g.validate(); err != nil {
+				return nil, fmt.Errorf("invalid grantee for repositories %s: %w", r.Path, err)
+			}
+		}
+		acl.rules = append(acl.rules, rule)
+	}
+	return acl, nil
+}
+
+// Authenticate returns the principal of the credentials of the incoming request in |ctx|. It accepts a bearer JWT,
+// or basic auth with the password of a configured user or with a JWT as the password.
+func (acl *RepoACL) Authenticate(ctx context.Context) (Principal, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Principal{}, errors.New("no metadata in context")
+	}
+	auths := md.Get("authorization")
+	if len(auths) != 1 {
+		return Principal{}, errors.New("no credentials provided")
+	}
+
+	auth := auths[0]
+	if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+		claims, err := acl.validateJWT(token)
+		if err != nil {
+			return Principal{}, err
+		}
+		return Principal{Claims: claims}, nil
+	}
+
+	encoded, ok := strings.CutPrefix(auth, "Basic ")
+	if !ok {
+		return Principal{}, errors.New("bad request: authorization header did not start with 'Basic ' or 'Bearer '")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return Principal{}, fmt.Errorf("incoming request authorization header failed to decode: %w", err)
+	}
+	user, pass, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return Principal{}, errors.New("bad request: malformed basic auth credentials")
+	}
+
+	if hash, ok := acl.users[user]; ok {
+		if err := bcrypt.CompareHashAndPassword(hash, []byte(pass)); err != nil {
+			return Principal{}, fmt.Errorf("invalid password for user %s", user)
+		}
+		return Principal{User: user}, nil
+	}
+	if len(acl.validators) > 0 {
+		claims, err := acl.validateJWT(pass)
+		if err != nil {
+			return Principal{}, fmt.Errorf("unknown user %s, and its password is not a valid JWT: %w", user, err)
+		}
+		if claims.Subject != user {
+			return Principal{}, fmt.Errorf("user %s does not match the subject of its JWT", user)
+		}
+		return Principal{Claims: claims}, nil
+	}
+	return Principal{}, fmt.Errorf("unknown user %s", user)
+}
+
+func (acl *RepoACL) validateJWT(token string) (*jwtauth.Claims, error) {
+	if len(acl.validators) == 0 {
+		return nil, errors.New("JWT authentication is not configured")
+	}
+	var err error
+	for _, v := range acl.validators {
+		var claims *jwtauth.Claims
+		claims, err = v.ValidateJWT(token, time.Now())
+		if err == nil {
+			return claims, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid JWT: %w", err)
+}
+
+// IsAdmin returns whether |p| is an administrator of the server.
+func (acl *RepoACL) IsAdmin(p Principal) bool {
+	return anyMatches(acl.admins, p)
+}
+
+func (acl *RepoACL) rule(repoPath string) (repoRule, bool) {
+	for _, r := range acl.rules {
+		if ok, _ := path.Match(r.Path, repoPath); ok {
+			return r, true
+		}
+	}
+	return repoRule{}, false
+}
+
+// Permission returns the access |p| has to the repository at |repoPath|.
+func (acl *RepoACL) Permission(p Principal, repoPath string) RepoPermission {
+	if acl.IsAdmin(p) {
+		return RepoPermissionPush
+	}
+	r, ok := acl.rule(repoPath)
+	if !ok {
+		return RepoPermissionNone
+	}
+	if anyMatches(r.Push, p) {
+		return RepoPermissionPush
+	}
+	if anyMatches(r.Read, p) {
+		return RepoPermissionRead
+	}
+	return RepoPermissionNone
+}
+
+// MaxRepoSize implements RepoQuotas.
+func (acl *RepoACL) MaxRepoSize(repoPath string) (uint64, bool) {
+	r, ok := acl.rule(repoPath)
+	if !ok || r.maxSize == 0 {
+		return 0, false
+	}
+	return r.maxSize, true
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var REPO_ADMIN_RPC_METHODS = map[string]bool{
+	"/dolt.services.remotesapi.v1alpha1.RepoAdminService/CreateRepository": true,
+	"/dolt.services.remotesapi.v1alpha1.RepoAdminService/DeleteRepository": true,
+	"/dolt.services.remotesapi.v1alpha1.RepoAdminService/ListRepositories": true,
+}
+
+// RepoACLInterceptor authenticates every request to a server hosting repositories for several tenants, and authorizes
+// it against the repository it accesses. Requests to the ChunkStoreService need read access to their repository, or
+// push access for the methods which write to it. Requests to the RepoAdminService are restricted to admins.
+type RepoACLInterceptor struct {
+	Lgr *logrus.Entry
+	ACL *RepoACL
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the principal authenticated by a RepoACLInterceptor for the request of |ctx|.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+func (ri *RepoACLInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		p, err := ri.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if err = ri.authorizeRequest(p, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(context.WithValue(ctx, principalKey{}, p), req)
+	}
+}
+
+func (ri *RepoACLInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		p, err := ri.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		// the repository of a streaming request is only known once each message is received
+		return handler(srv, &repoACLServerStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), principalKey{}, p),
+			interceptor:  ri,
+			principal:    p,
+			method:       info.FullMethod,
+		})
+	}
+}
+
+func (ri *RepoACLInterceptor) Options() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(ri.Unary()),
+		grpc.ChainStreamInterceptor(ri.Stream()),
+	}
+}
+
+func (ri *RepoACLInterceptor) authenticate(ctx context.Context, method string) (Principal, error) {
+	if !REPO_ADMIN_RPC_METHODS[method] && !SUPER_USER_RPC_METHODS[method] && !CLONE_ADMIN_RPC_METHODS[method] {
+		return Principal{}, status.Errorf(codes.Unimplemented, "unknown rpc method: %s", method)
+	}
+	p, err := ri.ACL.Authenticate(ctx)
+	if err != nil {
+		ri.Lgr.Warnf("authentication failed: %s", err.Error())
+		return Principal{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return p, nil
+}
+
+// authorizeRequest checks that |p| may call |method| with |req|.
+func (ri *RepoACLInterceptor) authorizeRequest(p Principal, method string, req interface{}) error {
+	if REPO_ADMIN_RPC_METHODS[method] {
+		if !ri.ACL.IsAdmin(p) {
+			ri.Lgr.Warnf("authorization failed: %s is not an admin", p)
+			return status.Errorf(codes.PermissionDenied, "%s is not an admin", p)
+		}
+		return nil
+	}
+
+	rr, ok := req.(repoRequest)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unexpected request type %T for %s", req, method)
+	}
+	if err := validateRepoRequest(rr); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	repoPath := getRepoPath(rr)
+	if err := ValidateRepoPath(repoPath); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	needed := RepoPermissionRead
+	if SUPER_USER_RPC_METHODS[method] {
+		needed = RepoPermissionPush
+	}
+	if ri.ACL.Permission(p, repoPath) < needed {
+		ri.Lgr.WithField(RepoPathField, repoPath).Warnf("authorization failed: %s does not have %s access", p, needed)
+		return status.Errorf(codes.PermissionDenied, "%s does not have %s access to %s", p, needed, repoPath)
+	}
+	return nil
+}
+
+// repoACLServerStream authorizes every message received on a stream against the principal of the stream.
+type repoACLServerStream struct {
+	grpc.ServerStream
+	ctx         context.Context
+	interceptor *RepoACLInterceptor
+	principal   Principal
+	method      string
+}
+
+func (s *repoACLServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *repoACLServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.interceptor.authorizeRequest(s.principal, s.method, m)
+}
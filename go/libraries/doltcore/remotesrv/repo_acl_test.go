@@ -0,0 +1,231 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/utils/jwtauth"
+)
+
+const testHostingConfig = `
+users:
+  - name: alice
+    password_hash: %s
+  - name: bob
+    password_hash: %s
+admins:
+  - user: root
+repos:
+  - path: alice/*
+    max_size: 10MB
+    push:
+      - user: alice
+    read:
+      - user: bob
+  - path: public/*
+    read:
+      - user: "*"
+  - path: ci/*
+    push:
+      - claims:
+          iss: ci.example.com
+          sub: builder
+`
+
+// staticJWTValidator accepts the tokens in its map.
+type staticJWTValidator map[string]*jwtauth.Claims
+
+func (v staticJWTValidator) ValidateJWT(unparsed string, _ time.Time) (*jwtauth.Claims, error) {
+	if c, ok := v[unparsed]; ok {
+		return c, nil
+	}
+	return nil, errors.New("unknown token")
+}
+
+func newTestRepoACL(t *testing.T) *RepoACL {
+	hash := func(pass string) string {
+		h, err := bcrypt.GenerateFromPassword([]byte(pass), bcrypt.MinCost)
+		require.NoError(t, err)
+		return string(h)
+	}
+	cfg, err := ParseHostingConfig([]byte(fmt.Sprintf(testHostingConfig, hash("alicepass"), hash("bobpass"))))
+	require.NoError(t, err)
+	cfg.Users = append(cfg.Users, HostedUser{Name: "root", PasswordHash: hash("rootpass")})
+	acl, err := NewRepoACL(cfg)
+	require.NoError(t, err)
+	acl.validators = []jwtauth.JWTValidator{staticJWTValidator{
+		"builder-token": {Claims: jwt.Claims{Issuer: "ci.example.com", Subject: "builder"}},
+		"other-token":   {Claims: jwt.Claims{Issuer: "ci.example.com", Subject: "other"}},
+	}}
+	return acl
+}
+
+func basicAuthCtx(user, pass string) context.Context {
+	creds := base64.StdEncoding.EncodeToString([]byte(user + ":" + pass))
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic "+creds))
+}
+
+func bearerAuthCtx(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestParseHostingConfig(t *testing.T) {
+	_, err := ParseHostingConfig([]byte("repos:\n  - path: a/*\n    writers: []\n"))
+	assert.Error(t, err)
+
+	cfg, err := ParseHostingConfig([]byte("repos:\n  - path: a/*\n    max_size: 1GB\n"))
+	require.NoError(t, err)
+	require.Len(t, cfg.Repos, 1)
+	assert.Equal(t, "1GB", cfg.Repos[0].MaxSize)
+}
+
+func TestNewRepoACLErrors(t *testing.T) {
+	for name, cfg := range map[string]HostingConfig{
+		"bad hash":       {Users: []HostedUser{{Name: "alice", PasswordHash: "plaintext"}}},
+		"wildcard user":  {Users: []HostedUser{{Name: "*"}}},
+		"no jwks url":    {Jwks: []HostedJwks{{Name: "ci"}}},
+		"bad jwks claim": {Jwks: []HostedJwks{{Name: "ci", LocationUrl: "file:///jwks.json", Claims: map[string]string{"email": "x"}}}},
+		"empty admin":    {Admins: []Grantee{{}}},
+		"bad pattern":    {Repos: []RepoRule{{Path: "a/["}}},
+		"bad max size":   {Repos: []RepoRule{{Path: "a/*", MaxSize: "lots"}}},
+		"bad claim":      {Repos: []RepoRule{{Path: "a/*", Read: []Grantee{{Claims: map[string]string{"email": "x"}}}}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRepoACL(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRepoACLAuthenticate(t *testing.T) {
+	acl := newTestRepoACL(t)
+
+	p, err := acl.Authenticate(basicAuthCtx("alice", "alicepass"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", p.User)
+
+	_, err = acl.Authenticate(basicAuthCtx("alice", "bobpass"))
+	assert.Error(t, err)
+	_, err = acl.Authenticate(basicAuthCtx("mallory", "alicepass"))
+	assert.Error(t, err)
+	_, err = acl.Authenticate(context.Background())
+	assert.Error(t, err)
+
+	p, err = acl.Authenticate(bearerAuthCtx("builder-token"))
+	require.NoError(t, err)
+	require.NotNil(t, p.Claims)
+	assert.Equal(t, "builder", p.Claims.Subject)
+	_, err = acl.Authenticate(bearerAuthCtx("bad-token"))
+	assert.Error(t, err)
+
+	// a JWT may be used as the password of its subject
+	p, err = acl.Authenticate(basicAuthCtx("builder", "builder-token"))
+	require.NoError(t, err)
+	assert.Equal(t, "builder", p.Claims.Subject)
+	_, err = acl.Authenticate(basicAuthCtx("someone", "builder-token"))
+	assert.Error(t, err)
+}
+
+func TestRepoACLPermission(t *testing.T) {
+	acl := newTestRepoACL(t)
+	alice := Principal{User: "alice"}
+	bob := Principal{User: "bob"}
+	root := Principal{User: "root"}
+	builder := Principal{Claims: &jwtauth.Claims{Claims: jwt.Claims{Issuer: "ci.example.com", Subject: "builder"}}}
+	other := Principal{Claims: &jwtauth.Claims{Claims: jwt.Claims{Issuer: "ci.example.com", Subject: "other"}}}
+
+	tests := []struct {
+		p        Principal
+		repoPath string
+		expected RepoPermission
+	}{
+		{alice, "alice/db", RepoPermissionPush},
+		{bob, "alice/db", RepoPermissionRead},
+		{builder, "alice/db", RepoPermissionNone},
+		{alice, "alice/nested/db", RepoPermissionNone},
+		{alice, "public/db", RepoPermissionRead},
+		{builder, "public/db", RepoPermissionRead},
+		{builder, "ci/db", RepoPermissionPush},
+		{other, "ci/db", RepoPermissionNone},
+		{alice, "ci/db", RepoPermissionNone},
+		{alice, "unknown/db", RepoPermissionNone},
+		{root, "unknown/db", RepoPermissionPush},
+	}
+	for _, test := range tests {
+		t.Run(test.p.String()+" "+test.repoPath, func(t *testing.T) {
+			assert.Equal(t, test.expected, acl.Permission(test.p, test.repoPath))
+		})
+	}
+
+	assert.True(t, acl.IsAdmin(root))
+	assert.False(t, acl.IsAdmin(alice))
+
+	size, ok := acl.MaxRepoSize("alice/db")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10_000_000), size)
+	_, ok = acl.MaxRepoSize("public/db")
+	assert.False(t, ok)
+}
+
+func TestRepoACLInterceptor(t *testing.T) {
+	ri := &RepoACLInterceptor{Lgr: logrus.NewEntry(logrus.New()), ACL: newTestRepoACL(t)}
+	interceptor := ri.Unary()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, ok := PrincipalFromContext(ctx)
+		assert.True(t, ok)
+		return nil, nil
+	}
+	call := func(ctx context.Context, method string, req interface{}) codes.Code {
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return status.Code(err)
+	}
+
+	const getRepoMetadata = "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetRepoMetadata"
+	const commit = "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/Commit"
+	const createRepo = "/dolt.services.remotesapi.v1alpha1.RepoAdminService/CreateRepository"
+
+	alice := basicAuthCtx("alice", "alicepass")
+	bob := basicAuthCtx("bob", "bobpass")
+	root := basicAuthCtx("root", "rootpass")
+
+	assert.Equal(t, codes.OK, call(alice, getRepoMetadata, &remotesapi.GetRepoMetadataRequest{RepoPath: "alice/db"}))
+	assert.Equal(t, codes.OK, call(alice, commit, &remotesapi.CommitRequest{RepoPath: "alice/db"}))
+	assert.Equal(t, codes.OK, call(bob, getRepoMetadata, &remotesapi.GetRepoMetadataRequest{RepoPath: "alice/db"}))
+	assert.Equal(t, codes.PermissionDenied, call(bob, commit, &remotesapi.CommitRequest{RepoPath: "alice/db"}))
+	assert.Equal(t, codes.PermissionDenied, call(alice, getRepoMetadata, &remotesapi.GetRepoMetadataRequest{RepoPath: "bob/db"}))
+	assert.Equal(t, codes.InvalidArgument, call(alice, getRepoMetadata, &remotesapi.GetRepoMetadataRequest{RepoPath: "alice/../bob"}))
+	assert.Equal(t, codes.Unauthenticated, call(basicAuthCtx("alice", "wrong"), getRepoMetadata, &remotesapi.GetRepoMetadataRequest{RepoPath: "alice/db"}))
+	assert.Equal(t, codes.Unimplemented, call(alice, "/unknown.Service/Method", &remotesapi.GetRepoMetadataRequest{RepoPath: "alice/db"}))
+
+	assert.Equal(t, codes.PermissionDenied, call(alice, createRepo, &remotesapi.CreateRepositoryRequest{RepoPath: "alice/new"}))
+	assert.Equal(t, codes.OK, call(root, createRepo, &remotesapi.CreateRepositoryRequest{RepoPath: "alice/new"}))
+}
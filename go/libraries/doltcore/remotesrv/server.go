@@ -67,6 +67,17 @@ type ServerArgs struct {
 
 	HttpInterceptor func(http.Handler) http.Handler
 
+	// If supplied, pushes are checked by PushValidator before they
+	// are committed.
+	PushValidator PushValidator
+
+	// If supplied, PushListener is notified of every successful push.
+	PushListener PushListener
+
+	// If supplied, pushes which would grow a repository past its
+	// quota are rejected.
+	Quotas RepoQuotas
+
 	// If supplied, the listener(s) returned from Listeners() will be TLS
 	// listeners. The scheme used in the URLs returned from the gRPC server
 	// will be https.
@@ -95,7 +106,11 @@ func NewServer(args ServerArgs) (*Server, error) {
 	s.wg.Add(2)
 	s.grpcListenAddr = args.GrpcListenAddr
 	s.grpcSrv = grpc.NewServer(append([]grpc.ServerOption{grpc.MaxRecvMsgSize(128 * 1024 * 1024)}, args.Options...)...)
-	var chnkSt remotesapi.ChunkStoreServiceServer = NewHttpFSBackedChunkStore(args.Logger, args.HttpHost, args.DBCache, args.FS, scheme, args.ConcurrencyControl, sealer)
+	remoteChunkStore := NewHttpFSBackedChunkStore(args.Logger, args.HttpHost, args.DBCache, args.FS, scheme, args.ConcurrencyControl, sealer)
+	remoteChunkStore.pushValidator = args.PushValidator
+	remoteChunkStore.pushListener = args.PushListener
+	remoteChunkStore.quotas = args.Quotas
+	var chnkSt remotesapi.ChunkStoreServiceServer = remoteChunkStore
 
 	if args.ReadOnly {
 		chnkSt = ReadOnlyChunkStore{chnkSt}
@@ -16,6 +16,7 @@ package remotesrv
 
 import (
 	"fmt"
+	"strings"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -155,3 +156,36 @@ func ValidateAddTableFilesRequest(req *remotesapi.AddTableFilesRequest) error {
 	}
 	return nil
 }
+
+// ValidateRepoPath checks that |repoPath| is a relative, slash-separated path without empty, "." or ".." components,
+// so that it names a single repository and can't escape the storage root of a server.
+func ValidateRepoPath(repoPath string) error {
+	if repoPath == "" {
+		return fmt.Errorf("expected a repository path")
+	}
+	if strings.Contains(repoPath, "\\") {
+		return fmt.Errorf("invalid repository path %q: backslashes are not allowed", repoPath)
+	}
+	for _, component := range strings.Split(repoPath, "/") {
+		if component == "" || component == "." || component == ".." {
+			return fmt.Errorf("invalid repository path %q: expected a relative path without empty, '.' or '..' components", repoPath)
+		}
+	}
+	return nil
+}
+
+func ValidateCreateRepositoryRequest(req *remotesapi.CreateRepositoryRequest) error {
+	if err := ValidateRepoPath(req.RepoPath); err != nil {
+		return err
+	}
+	if req.NbfVersion != "" {
+		if _, err := types.GetFormatForVersionString(req.NbfVersion); err != nil {
+			return fmt.Errorf("unrecognized nbf_version: %s", req.NbfVersion)
+		}
+	}
+	return nil
+}
+
+func ValidateDeleteRepositoryRequest(req *remotesapi.DeleteRepositoryRequest) error {
+	return ValidateRepoPath(req.RepoPath)
+}
@@ -534,3 +534,30 @@ func TestValidateAddTableFilesRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRepoPath(t *testing.T) {
+	for i, repoPath := range []string{
+		"",
+		"/dolthub/database",
+		"dolthub/database/",
+		"dolthub//database",
+		"dolthub/../database",
+		"../database",
+		"./database",
+		"dolthub\\database",
+	} {
+		t.Run(fmt.Sprintf("Error #%02d", i), func(t *testing.T) {
+			assert.Error(t, ValidateRepoPath(repoPath), "%v should not validate", repoPath)
+		})
+	}
+	for i, repoPath := range []string{
+		GoodRepoPath,
+		"database",
+		"dolthub/team/database",
+		"dolthub/database.v2",
+	} {
+		t.Run(fmt.Sprintf("NoError #%02d", i), func(t *testing.T) {
+			assert.NoError(t, ValidateRepoPath(repoPath), "%v should validate", repoPath)
+		})
+	}
+}
@@ -97,7 +97,7 @@ func NewChunkFetcher(ctx context.Context, dcs *DoltChunkStore) *ChunkFetcher {
 		return fetcherDownloadRangesThread(ctx, downloadLocCh, fetchReqCh, locDoneCh)
 	})
 	eg.Go(func() error {
-		return fetcherDownloadURLThreads(ctx, fetchReqCh, locDoneCh, ret.resCh, dcs.csClient, ret.stats, dcs.httpFetcher, dcs.params)
+		return fetcherDownloadURLThreads(ctx, fetchReqCh, locDoneCh, ret.resCh, dcs.csClient, ret.stats, dcs.httpFetcher, dcs.params, dcs.encryption)
 	})
 
 	return ret
@@ -540,13 +540,13 @@ func (cc *ConcurrencyControl) Run(ctx context.Context, done <-chan struct{}, ss
 	}
 }
 
-func fetcherDownloadURLThreads(ctx context.Context, fetchReqCh chan fetchReq, doneCh chan struct{}, chunkCh chan nbs.ToChunker, client remotesapi.ChunkStoreServiceClient, stats StatsRecorder, fetcher HTTPFetcher, params NetworkRequestParams) error {
+func fetcherDownloadURLThreads(ctx context.Context, fetchReqCh chan fetchReq, doneCh chan struct{}, chunkCh chan nbs.ToChunker, client remotesapi.ChunkStoreServiceClient, stats StatsRecorder, fetcher HTTPFetcher, params NetworkRequestParams, ce *nbs.ChunkEncryption) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	cc := &ConcurrencyControl{
 		MaxConcurrency: params.MaximumConcurrentDownloads,
 	}
 	f := func(ctx context.Context, shutdownCh <-chan struct{}) error {
-		return fetcherDownloadURLThread(ctx, fetchReqCh, shutdownCh, chunkCh, client, stats, cc, fetcher, params)
+		return fetcherDownloadURLThread(ctx, fetchReqCh, shutdownCh, chunkCh, client, stats, cc, fetcher, params, ce)
 	}
 	threads := pool.NewDynamic(ctx, f, params.StartingConcurrentDownloads)
 	eg.Go(func() error {
@@ -572,7 +572,7 @@ func fetcherDownloadURLThreads(ctx context.Context, fetchReqCh chan fetchReq, do
 	return nil
 }
 
-func deliverChunkCallback(chunkCh chan nbs.ToChunker, path string, dictCache *dictionaryCache) func(context.Context, []byte, *Range) error {
+func deliverChunkCallback(chunkCh chan nbs.ToChunker, path string, dictCache *dictionaryCache, ce *nbs.ChunkEncryption) func(context.Context, []byte, *Range) error {
 	return func(ctx context.Context, bs []byte, rang *Range) error {
 		h := hash.New(rang.Hash[:])
 		var cc nbs.ToChunker
@@ -586,7 +586,7 @@ func deliverChunkCallback(chunkCh chan nbs.ToChunker, path string, dictCache *di
 			cc = nbs.NewArchiveToChunker(h, bundle, bs)
 		} else {
 			var err error
-			cc, err = nbs.NewCompressedChunk(h, bs)
+			cc, err = nbs.NewCompressedChunkWithEncryption(h, bs, ce)
 			if err != nil {
 				return err
 			}
@@ -600,9 +600,9 @@ func deliverChunkCallback(chunkCh chan nbs.ToChunker, path string, dictCache *di
 	}
 }
 
-func setDictionaryCallback(dictCache *dictionaryCache, path string) func(context.Context, []byte, *Range) error {
+func setDictionaryCallback(dictCache *dictionaryCache, path string, ce *nbs.ChunkEncryption) func(context.Context, []byte, *Range) error {
 	return func(ctx context.Context, bs []byte, rang *Range) error {
-		bundle, err := nbs.NewDecompBundle(bs)
+		bundle, err := nbs.NewDecompBundleWithEncryption(bs, ce)
 		if err != nil {
 			return err
 		}
@@ -615,7 +615,7 @@ func setDictionaryCallback(dictCache *dictionaryCache, path string) func(context
 	}
 }
 
-func fetcherDownloadURLThread(ctx context.Context, fetchReqCh chan fetchReq, doneCh <-chan struct{}, chunkCh chan nbs.ToChunker, client remotesapi.ChunkStoreServiceClient, stats StatsRecorder, health reliable.HealthRecorder, fetcher HTTPFetcher, params NetworkRequestParams) error {
+func fetcherDownloadURLThread(ctx context.Context, fetchReqCh chan fetchReq, doneCh <-chan struct{}, chunkCh chan nbs.ToChunker, client remotesapi.ChunkStoreServiceClient, stats StatsRecorder, health reliable.HealthRecorder, fetcher HTTPFetcher, params NetworkRequestParams, ce *nbs.ChunkEncryption) error {
 	respCh := make(chan fetchResp, 1)
 	for {
 		select {
@@ -630,9 +630,9 @@ func fetcherDownloadURLThread(ctx context.Context, fetchReqCh chan fetchReq, don
 			case fetchResp := <-respCh:
 				var cb func(context.Context, []byte, *Range) error
 				if fetchResp.rangeType == rangeType_Chunk {
-					cb = deliverChunkCallback(chunkCh, fetchResp.path, fetchResp.dictCache)
+					cb = deliverChunkCallback(chunkCh, fetchResp.path, fetchResp.dictCache, ce)
 				} else {
-					cb = setDictionaryCallback(fetchResp.dictCache, fetchResp.path)
+					cb = setDictionaryCallback(fetchResp.dictCache, fetchResp.path, ce)
 				}
 				f := fetchResp.get.GetDownloadFunc(ctx, stats, health, fetcher, params, cb, func(ctx context.Context, lastError error, resourcePath string) (string, error) {
 					return fetchResp.refresh(ctx, lastError, client)
@@ -75,6 +75,7 @@ var _ chunks.TableFileStore = (*DoltChunkStore)(nil)
 var _ nbs.NBSCompressedChunkStore = (*DoltChunkStore)(nil)
 var _ chunks.ChunkStore = (*DoltChunkStore)(nil)
 var _ chunks.LoggingChunkStore = (*DoltChunkStore)(nil)
+var _ nbs.EncryptingChunkStore = (*DoltChunkStore)(nil)
 
 var tracer = otel.Tracer("github.com/dolthub/dolt/go/libraries/doltcore/remotestorage")
 
@@ -133,6 +134,8 @@ type DoltChunkStore struct {
 	stats       cacheStats
 	logger      chunks.DebugLogger
 	wsValidate  bool
+	// encryption seals the chunks written to the remote, and opens the encrypted chunks read from it.
+	encryption *nbs.ChunkEncryption
 }
 
 func NewDoltChunkStoreFromPath(ctx context.Context, nbf *types.NomsBinFormat, path, host string, wsval bool, csClient remotesapi.ChunkStoreServiceClient) (*DoltChunkStore, error) {
@@ -230,6 +233,19 @@ func (dcs *DoltChunkStore) WithNetworkRequestParams(params NetworkRequestParams)
 	return ret
 }
 
+// WithChunkEncryption returns a DoltChunkStore which encrypts the chunk data it writes with |ce|, and opens encrypted
+// chunk data with it.
+func (dcs *DoltChunkStore) WithChunkEncryption(ce *nbs.ChunkEncryption) *DoltChunkStore {
+	ret := dcs.clone()
+	ret.encryption = ce
+	return ret
+}
+
+// ChunkEncryption implements nbs.EncryptingChunkStore.
+func (dcs *DoltChunkStore) ChunkEncryption() *nbs.ChunkEncryption {
+	return dcs.encryption
+}
+
 func (dcs *DoltChunkStore) SetLogger(logger chunks.DebugLogger) {
 	dcs.logger = logger
 }
@@ -730,7 +746,7 @@ func (dcs *DoltChunkStore) Put(ctx context.Context, c chunks.Chunk, getAddrs chu
 		return err
 	}
 
-	cc := nbs.ChunkToCompressedChunk(c)
+	cc := nbs.ChunkToCompressedChunkWithEncryption(c, dcs.encryption)
 	err = dcs.wb.Put(cc)
 	if err != nil {
 		return err
@@ -922,7 +938,7 @@ func (dcs *DoltChunkStore) uploadChunks(ctx context.Context, hashToChunk map[has
 
 	// structuring so this can be done as multiple files in the future.
 	{
-		name, data, splitOffset, err := nbs.WriteChunks(chnks)
+		name, data, splitOffset, err := nbs.WriteChunks(chnks, dcs.encryption)
 
 		if err != nil {
 			return map[hash.Hash]int{}, err
@@ -0,0 +1,279 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"container/list"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// DiskChunkCache is a ChunkCache which stores chunks in files in a directory, and evicts the least recently used
+// chunks once their total size exceeds a bound. Chunks are stored compressed, in the format of table file records, so
+// they're encrypted at rest when the cache is created with a ChunkEncryption. The cache survives restarts, but the has
+// records are only kept in memory.
+type DiskChunkCache struct {
+	dir        string
+	maxSize    uint64
+	encryption *nbs.ChunkEncryption
+
+	mu      sync.Mutex
+	entries map[hash.Hash]*list.Element
+	// recency holds the cached chunks, from most to least recently used.
+	recency *list.List
+	size    uint64
+
+	has *lru.TwoQueueCache[hash.Hash, struct{}]
+}
+
+var _ ChunkCache = (*DiskChunkCache)(nil)
+
+type diskCacheEntry struct {
+	h    hash.Hash
+	size uint64
+}
+
+// NewDiskChunkCache returns a DiskChunkCache storing up to |maxSize| bytes of chunks in |dir|, which is created if
+// it doesn't exist. The chunks already in |dir| are kept, the most recently written first. Cached chunks are encrypted
+// with |ce| if it is not nil.
+func NewDiskChunkCache(dir string, maxSize uint64, ce *nbs.ChunkEncryption) (*DiskChunkCache, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	has, err := lru.New2Q[hash.Hash, struct{}](defaultCacheHasCapacity)
+	if err != nil {
+		return nil, err
+	}
+	cache := &DiskChunkCache{
+		dir:        dir,
+		maxSize:    maxSize,
+		encryption: ce,
+		entries:    make(map[hash.Hash]*list.Element),
+		recency:    list.New(),
+		has:        has,
+	}
+	if err = cache.load(); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// load indexes the chunks already in the cache's directory, and removes the files which aren't chunks.
+func (cache *DiskChunkCache) load() error {
+	type cachedFile struct {
+		h       hash.Hash
+		size    uint64
+		modTime time.Time
+	}
+	var files []cachedFile
+	err := filepath.WalkDir(cache.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if h, ok := hash.MaybeParse(d.Name()); ok && filepath.Dir(path) == cache.subDir(h) {
+			files = append(files, cachedFile{h: h, size: uint64(info.Size()), modTime: info.ModTime()})
+		} else {
+			// Left behind by an interrupted write.
+			_ = os.Remove(path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.After(files[j].modTime)
+	})
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for _, f := range files {
+		cache.entries[f.h] = cache.recency.PushBack(&diskCacheEntry{h: f.h, size: f.size})
+		cache.size += f.size
+	}
+	cache.evict()
+	return nil
+}
+
+func (cache *DiskChunkCache) subDir(h hash.Hash) string {
+	return filepath.Join(cache.dir, h.String()[:2])
+}
+
+func (cache *DiskChunkCache) path(h hash.Hash) string {
+	return filepath.Join(cache.subDir(h), h.String())
+}
+
+// Size returns the total size of the cached chunks.
+func (cache *DiskChunkCache) Size() uint64 {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.size
+}
+
+func (cache *DiskChunkCache) InsertChunks(cs []nbs.ToChunker) {
+	for _, tc := range cs {
+		if tc.IsEmpty() || tc.IsGhost() || cache.contains(tc.Hash()) {
+			continue
+		}
+		c, err := tc.ToChunk()
+		if err != nil {
+			continue
+		}
+		cache.insert(c)
+	}
+}
+
+// insert adds |c| to the cache. Chunks are recompressed, and sealed with the cache's encryption, rather than keeping
+// the bytes of the remote's table files. The cache is best effort, so failing to write a chunk is not an error.
+func (cache *DiskChunkCache) insert(c chunks.Chunk) {
+	h := c.Hash()
+	if cache.contains(h) {
+		return
+	}
+	data := nbs.ChunkToCompressedChunkWithEncryption(c, cache.encryption).FullCompressedChunk
+	if uint64(len(data)) > cache.maxSize {
+		return
+	}
+	if err := cache.write(h, data); err != nil {
+		return
+	}
+
+	cache.mu.Lock()
+	if _, ok := cache.entries[h]; !ok {
+		cache.entries[h] = cache.recency.PushFront(&diskCacheEntry{h: h, size: uint64(len(data))})
+		cache.size += uint64(len(data))
+		cache.evict()
+	}
+	cache.mu.Unlock()
+	cache.has.Add(h, struct{}{})
+}
+
+func (cache *DiskChunkCache) contains(h hash.Hash) bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	_, ok := cache.entries[h]
+	return ok
+}
+
+// write writes a chunk's file atomically, so that a partially written file is never read.
+func (cache *DiskChunkCache) write(h hash.Hash, data []byte) error {
+	subDir := cache.subDir(h)
+	if err := os.MkdirAll(subDir, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(subDir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), cache.path(h))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+// evict removes the least recently used chunks until the cache fits its bound. Callers must hold |cache.mu|.
+func (cache *DiskChunkCache) evict() {
+	for cache.size > cache.maxSize {
+		back := cache.recency.Back()
+		if back == nil {
+			return
+		}
+		e := cache.recency.Remove(back).(*diskCacheEntry)
+		delete(cache.entries, e.h)
+		cache.size -= e.size
+		_ = os.Remove(cache.path(e.h))
+	}
+}
+
+func (cache *DiskChunkCache) GetCachedChunks(hs hash.HashSet) map[hash.Hash]nbs.ToChunker {
+	ret := make(map[hash.Hash]nbs.ToChunker)
+	for h := range hs {
+		cache.mu.Lock()
+		elem, ok := cache.entries[h]
+		if ok {
+			cache.recency.MoveToFront(elem)
+		}
+		cache.mu.Unlock()
+		if !ok {
+			continue
+		}
+
+		// The file may be evicted concurrently, in which case the chunk is a miss.
+		data, err := os.ReadFile(cache.path(h))
+		if err != nil {
+			continue
+		}
+		cc, err := nbs.NewCompressedChunkWithEncryption(h, data, cache.encryption)
+		if err != nil {
+			cache.remove(h)
+			continue
+		}
+		ret[h] = cc
+	}
+	return ret
+}
+
+func (cache *DiskChunkCache) remove(h hash.Hash) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if elem, ok := cache.entries[h]; ok {
+		e := cache.recency.Remove(elem).(*diskCacheEntry)
+		delete(cache.entries, h)
+		cache.size -= e.size
+		_ = os.Remove(cache.path(h))
+	}
+}
+
+func (cache *DiskChunkCache) InsertHas(hs hash.HashSet) {
+	for h := range hs {
+		cache.has.Add(h, struct{}{})
+	}
+}
+
+func (cache *DiskChunkCache) GetCachedHas(hs hash.HashSet) (absent hash.HashSet) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	ret := make(hash.HashSet)
+	for h := range hs {
+		if _, ok := cache.entries[h]; ok {
+			continue
+		}
+		if !cache.has.Contains(h) {
+			ret.Insert(h)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+func randomChunks(rand *rand.ChaCha8, n, size int) []chunks.Chunk {
+	ret := make([]chunks.Chunk, n)
+	for i := range ret {
+		bs := make([]byte, size)
+		rand.Read(bs)
+		ret[i] = chunks.NewChunk(bs)
+	}
+	return ret
+}
+
+func TestDiskChunkCache(t *testing.T) {
+	t.Run("CachesChunks", func(t *testing.T) {
+		var seed [32]byte
+		rand := rand.NewChaCha8(seed)
+		cache, err := NewDiskChunkCache(t.TempDir(), 1<<20, nil)
+		require.NoError(t, err)
+
+		inserted := make(hash.HashSet)
+		for _, chk := range randomChunks(rand, 8, 512) {
+			inserted.Insert(chk.Hash())
+			cache.InsertChunks([]nbs.ToChunker{nbs.ChunkToCompressedChunk(chk)})
+		}
+		query := inserted.Copy()
+		for _, chk := range randomChunks(rand, 8, 512) {
+			query.Insert(chk.Hash())
+		}
+
+		cached := cache.GetCachedChunks(query)
+		assert.Len(t, cached, 8)
+		for h, tc := range cached {
+			assert.True(t, inserted.Has(h))
+			chk, err := tc.ToChunk()
+			require.NoError(t, err)
+			assert.Equal(t, h, chk.Hash())
+		}
+
+		// Cached chunks are present, along with the inserted has records.
+		assert.Len(t, cache.GetCachedHas(query), 8)
+	})
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		var seed [32]byte
+		rand := rand.NewChaCha8(seed)
+		chks := randomChunks(rand, 16, 1024)
+		// Random data doesn't compress, so every chunk takes a bit more than 1024 bytes.
+		cache, err := NewDiskChunkCache(t.TempDir(), 8*1100, nil)
+		require.NoError(t, err)
+
+		for _, chk := range chks[:8] {
+			cache.InsertChunks([]nbs.ToChunker{nbs.ChunkToCompressedChunk(chk)})
+		}
+		assert.Len(t, cache.GetCachedChunks(hash.NewHashSet(chks[0].Hash())), 1)
+
+		for _, chk := range chks[8:10] {
+			cache.InsertChunks([]nbs.ToChunker{nbs.ChunkToCompressedChunk(chk)})
+		}
+		assert.LessOrEqual(t, cache.Size(), uint64(8*1100))
+		// chks[0] was used last of the first chunks, so chks[1] and chks[2] were evicted instead.
+		assert.Len(t, cache.GetCachedChunks(hash.NewHashSet(chks[0].Hash())), 1)
+		assert.Empty(t, cache.GetCachedChunks(hash.NewHashSet(chks[1].Hash(), chks[2].Hash())))
+		assert.Len(t, cache.GetCachedChunks(hash.NewHashSet(chks[9].Hash())), 1)
+	})
+	t.Run("SurvivesRestart", func(t *testing.T) {
+		var seed [32]byte
+		rand := rand.NewChaCha8(seed)
+		dir := t.TempDir()
+		cache, err := NewDiskChunkCache(dir, 1<<20, nil)
+		require.NoError(t, err)
+		chks := randomChunks(rand, 4, 512)
+		hs := make(hash.HashSet)
+		for _, chk := range chks {
+			hs.Insert(chk.Hash())
+			cache.InsertChunks([]nbs.ToChunker{nbs.ChunkToCompressedChunk(chk)})
+		}
+		// Left behind by an interrupted write.
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "tmp-123"), []byte("partial"), 0644))
+
+		reopened, err := NewDiskChunkCache(dir, 1<<20, nil)
+		require.NoError(t, err)
+		assert.Equal(t, cache.Size(), reopened.Size())
+		assert.Len(t, reopened.GetCachedChunks(hs), 4)
+		assert.NoFileExists(t, filepath.Join(dir, "tmp-123"))
+	})
+	t.Run("DropsCorruptChunks", func(t *testing.T) {
+		var seed [32]byte
+		rand := rand.NewChaCha8(seed)
+		cache, err := NewDiskChunkCache(t.TempDir(), 1<<20, nil)
+		require.NoError(t, err)
+		chk := randomChunks(rand, 1, 512)[0]
+		cache.InsertChunks([]nbs.ToChunker{nbs.ChunkToCompressedChunk(chk)})
+
+		require.NoError(t, os.WriteFile(cache.path(chk.Hash()), []byte("corrupt"), 0644))
+		assert.Empty(t, cache.GetCachedChunks(hash.NewHashSet(chk.Hash())))
+		assert.Equal(t, uint64(0), cache.Size())
+		assert.NoFileExists(t, cache.path(chk.Hash()))
+	})
+}
@@ -0,0 +1,349 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+const (
+	// lazyPrefetchWidth is the number of siblings prefetched once a sequential read of a tree node's children is seen.
+	lazyPrefetchWidth = 8
+	// lazyMaxTrackedNodes bounds the number of tree nodes whose children are tracked for prefetching.
+	lazyMaxTrackedNodes = 4 * 1024
+	// lazyMaxTrackedChildren bounds the number of children whose position in their parent is tracked.
+	lazyMaxTrackedChildren = 256 * 1024
+)
+
+// LazySource is an nbs.LazySource reading chunks from a remote, through a DiskChunkCache. The remote is opened on
+// first use.
+//
+// When the children of a prolly tree node are read in order, as they are by a scan of an index, the next few
+// children are prefetched in the background, so that the scan doesn't wait on the remote for every chunk.
+type LazySource struct {
+	open  func(ctx context.Context) (chunks.ChunkStore, error)
+	cache *DiskChunkCache
+
+	openMu sync.Mutex
+	remote chunks.ChunkStore
+
+	// mu guards the sibling tracking below.
+	mu       sync.Mutex
+	nodes    *lru.Cache[hash.Hash, *lazyTreeNode]
+	children *lru.Cache[hash.Hash, lazyChildPos]
+
+	// ctx is the context of prefetches, which outlive the reads that start them. It's canceled by Close.
+	ctx         context.Context
+	cancel      context.CancelFunc
+	prefetching atomic.Bool
+	wg          sync.WaitGroup
+
+	requested  atomic.Uint64
+	cacheHits  atomic.Uint64
+	fetched    atomic.Uint64
+	prefetched atomic.Uint64
+}
+
+var _ nbs.LazySource = (*LazySource)(nil)
+
+// lazyTreeNode is a prolly tree node whose children are being read.
+type lazyTreeNode struct {
+	children []hash.Hash
+	// last is the index of the child read last, or -1.
+	last int
+	// prefetchedTo is the index following the last prefetched child.
+	prefetchedTo int
+}
+
+type lazyChildPos struct {
+	parent hash.Hash
+	idx    int
+}
+
+// LazyFetchStats are the counts of chunks read through a LazySource.
+type LazyFetchStats struct {
+	// RequestedChunks is the number of chunks requested from the source.
+	RequestedChunks uint64
+	// CacheHits is the number of requested chunks read from the disk cache.
+	CacheHits uint64
+	// RemoteChunks is the number of requested chunks fetched from the remote.
+	RemoteChunks uint64
+	// PrefetchedChunks is the number of chunks prefetched from the remote ahead of being requested.
+	PrefetchedChunks uint64
+	// CacheBytes is the size of the disk cache.
+	CacheBytes uint64
+}
+
+// NewLazySource returns a LazySource reading the chunks it doesn't have in |cache| from the chunk store returned by
+// |open|. If |open| fails, it's called again on the next read.
+func NewLazySource(open func(ctx context.Context) (chunks.ChunkStore, error), cache *DiskChunkCache) *LazySource {
+	nodes, err := lru.New[hash.Hash, *lazyTreeNode](lazyMaxTrackedNodes)
+	if err != nil {
+		panic(err)
+	}
+	children, err := lru.New[hash.Hash, lazyChildPos](lazyMaxTrackedChildren)
+	if err != nil {
+		panic(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &LazySource{
+		open:     open,
+		cache:    cache,
+		nodes:    nodes,
+		children: children,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+func (s *LazySource) remoteStore(ctx context.Context) (chunks.ChunkStore, error) {
+	s.openMu.Lock()
+	defer s.openMu.Unlock()
+	if s.remote != nil {
+		return s.remote, nil
+	}
+	cs, err := s.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dcs, ok := cs.(*DoltChunkStore); ok {
+		// The chunks are cached on disk, there's no need to also keep them in memory.
+		cs = dcs.WithNoopChunkCache()
+	}
+	s.remote = cs
+	return cs, nil
+}
+
+func (s *LazySource) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	s.requested.Add(uint64(len(hashes)))
+
+	missing := hashes.Copy()
+	for h, tc := range s.cache.GetCachedChunks(hashes) {
+		c, err := tc.ToChunk()
+		if err != nil {
+			continue
+		}
+		delete(missing, h)
+		s.cacheHits.Add(1)
+		s.observe(c)
+		found(ctx, &c)
+	}
+
+	if len(missing) > 0 {
+		if err := s.fetch(ctx, missing, &s.fetched, func(c chunks.Chunk) {
+			found(ctx, &c)
+		}); err != nil {
+			return err
+		}
+	}
+
+	for h := range hashes {
+		s.maybePrefetch(h)
+	}
+	return nil
+}
+
+// fetch gets |hashes| from the remote, adds them to the cache and counts them in |counter|.
+func (s *LazySource) fetch(ctx context.Context, hashes hash.HashSet, counter *atomic.Uint64, cb func(chunks.Chunk)) error {
+	remote, err := s.remoteStore(ctx)
+	if err != nil {
+		return err
+	}
+	return remote.GetMany(ctx, hashes, func(ctx context.Context, c *chunks.Chunk) {
+		counter.Add(1)
+		s.cache.insert(*c)
+		s.observe(*c)
+		if cb != nil {
+			cb(*c)
+		}
+	})
+}
+
+func (s *LazySource) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	absent := s.cache.GetCachedHas(hashes)
+	if len(absent) == 0 {
+		return absent, nil
+	}
+	remote, err := s.remoteStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remoteAbsent, err := remote.HasMany(ctx, absent)
+	if err != nil {
+		return nil, err
+	}
+	present := make(hash.HashSet, len(absent)-len(remoteAbsent))
+	for h := range absent {
+		if !remoteAbsent.Has(h) {
+			present.Insert(h)
+		}
+	}
+	s.cache.InsertHas(present)
+	return remoteAbsent, nil
+}
+
+// observe records the children of |c| if it's an internal prolly tree node.
+func (s *LazySource) observe(c chunks.Chunk) {
+	if serial.GetFileID(c.Data()) != serial.ProllyTreeNodeFileID {
+		return
+	}
+	var msg serial.ProllyTreeNode
+	if err := serial.InitProllyTreeNodeRoot(&msg, c.Data(), serial.MessagePrefixSz); err != nil {
+		return
+	}
+	if msg.TreeLevel() == 0 {
+		return
+	}
+	addrs := msg.AddressArrayBytes()
+	children := make([]hash.Hash, 0, len(addrs)/hash.ByteLen)
+	for i := 0; i+hash.ByteLen <= len(addrs); i += hash.ByteLen {
+		children = append(children, hash.New(addrs[i:i+hash.ByteLen]))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.nodes.Contains(c.Hash()) {
+		return
+	}
+	s.nodes.Add(c.Hash(), &lazyTreeNode{children: children, last: -1})
+	for i, child := range children {
+		s.children.Add(child, lazyChildPos{parent: c.Hash(), idx: i})
+	}
+}
+
+// maybePrefetch prefetches the siblings following |h| if |h| follows the sibling read last. Only one prefetch runs at a
+// time, the reads made while it runs don't start another.
+func (s *LazySource) maybePrefetch(h hash.Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pos, ok := s.children.Get(h)
+	if !ok {
+		return
+	}
+	node, ok := s.nodes.Get(pos.parent)
+	if !ok {
+		return
+	}
+	sequential := pos.idx == node.last+1
+	node.last = pos.idx
+	if !sequential {
+		return
+	}
+
+	start := pos.idx + 1
+	if node.prefetchedTo > start {
+		start = node.prefetchedTo
+	}
+	end := start + lazyPrefetchWidth
+	if end > len(node.children) {
+		end = len(node.children)
+	}
+	if start >= end || s.ctx.Err() != nil || !s.prefetching.CompareAndSwap(false, true) {
+		return
+	}
+	node.prefetchedTo = end
+
+	batch := hash.NewHashSet()
+	for _, child := range node.children[start:end] {
+		if !s.cache.contains(child) {
+			batch.Insert(child)
+		}
+	}
+	if len(batch) == 0 {
+		s.prefetching.Store(false)
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.prefetching.Store(false)
+		// Failures are ignored, the chunks are fetched when they're read.
+		_ = s.fetch(s.ctx, batch, &s.prefetched, nil)
+	}()
+}
+
+// Stats returns the counts of the chunks read through this source so far.
+func (s *LazySource) Stats() LazyFetchStats {
+	return LazyFetchStats{
+		RequestedChunks:  s.requested.Load(),
+		CacheHits:        s.cacheHits.Load(),
+		RemoteChunks:     s.fetched.Load(),
+		PrefetchedChunks: s.prefetched.Load(),
+		CacheBytes:       s.cache.Size(),
+	}
+}
+
+// waitForPrefetch waits for the prefetch in progress, if any.
+func (s *LazySource) waitForPrefetch() {
+	s.wg.Wait()
+}
+
+// Close cancels the prefetch in progress, if any, and waits for it to stop. No prefetch is started after Close.
+func (s *LazySource) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+var lazySources = struct {
+	mu      sync.Mutex
+	sources map[string]*LazySource
+}{sources: make(map[string]*LazySource)}
+
+// RegisterLazySource makes the stats of |src| available from AllLazyFetchStats under |name|, typically the name of the
+// database reading from it. It replaces any source registered under the same name.
+func RegisterLazySource(name string, src *LazySource) {
+	lazySources.mu.Lock()
+	defer lazySources.mu.Unlock()
+	lazySources.sources[name] = src
+}
+
+// UnregisterLazySource removes the source registered under |name|.
+func UnregisterLazySource(name string) {
+	lazySources.mu.Lock()
+	defer lazySources.mu.Unlock()
+	delete(lazySources.sources, name)
+}
+
+// LazyFetchStatsByName are the stats of the registered lazy sources, by name.
+type LazyFetchStatsByName struct {
+	Name  string
+	Stats LazyFetchStats
+}
+
+// AllLazyFetchStats returns the stats of every registered lazy source, sorted by name.
+func AllLazyFetchStats() []LazyFetchStatsByName {
+	lazySources.mu.Lock()
+	defer lazySources.mu.Unlock()
+	ret := make([]LazyFetchStatsByName, 0, len(lazySources.sources))
+	for name, src := range lazySources.sources {
+		ret = append(ret, LazyFetchStatsByName{Name: name, Stats: src.Stats()})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	flatbuffers "github.com/dolthub/flatbuffers/v23/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func newTestLazySource(t *testing.T, remote chunks.ChunkStore) (*LazySource, *int) {
+	cache, err := NewDiskChunkCache(t.TempDir(), 1<<20, nil)
+	require.NoError(t, err)
+	opens := 0
+	return NewLazySource(func(context.Context) (chunks.ChunkStore, error) {
+		opens++
+		return remote, nil
+	}, cache), &opens
+}
+
+func putChunks(t *testing.T, cs chunks.ChunkStore, chks ...chunks.Chunk) {
+	for _, chk := range chks {
+		require.NoError(t, cs.Put(context.Background(), chk, func(chunks.Chunk) chunks.GetAddrsCb {
+			return func(context.Context, hash.HashSet, chunks.PendingRefExists) error { return nil }
+		}))
+	}
+}
+
+func getChunks(t *testing.T, src *LazySource, hs ...hash.Hash) map[hash.Hash]chunks.Chunk {
+	var mu sync.Mutex
+	ret := make(map[hash.Hash]chunks.Chunk)
+	err := src.GetMany(context.Background(), hash.NewHashSet(hs...), func(_ context.Context, c *chunks.Chunk) {
+		mu.Lock()
+		defer mu.Unlock()
+		ret[c.Hash()] = *c
+	})
+	require.NoError(t, err)
+	return ret
+}
+
+// prollyInternalNode returns a level 1 prolly tree node with |children|.
+func prollyInternalNode(children []hash.Hash) chunks.Chunk {
+	b := flatbuffers.NewBuilder(1024)
+	addrs := make([]byte, 0, len(children)*hash.ByteLen)
+	for _, child := range children {
+		addrs = append(addrs, child[:]...)
+	}
+	addrsOff := b.CreateByteVector(addrs)
+	serial.ProllyTreeNodeStart(b)
+	serial.ProllyTreeNodeAddAddressArray(b, addrsOff)
+	serial.ProllyTreeNodeAddTreeCount(b, uint64(len(children)))
+	serial.ProllyTreeNodeAddTreeLevel(b, 1)
+	msg := serial.FinishMessage(b, serial.ProllyTreeNodeEnd(b), []byte(serial.ProllyTreeNodeFileID))
+	return chunks.NewChunk(msg)
+}
+
+func TestLazySource(t *testing.T) {
+	t.Run("GetMany", func(t *testing.T) {
+		var seed [32]byte
+		rand := rand.NewChaCha8(seed)
+		remote := (&chunks.MemoryStorage{}).NewView()
+		chks := randomChunks(rand, 4, 512)
+		putChunks(t, remote, chks...)
+		src, opens := newTestLazySource(t, remote)
+
+		missing := randomChunks(rand, 1, 512)[0]
+		got := getChunks(t, src, chks[0].Hash(), chks[1].Hash(), missing.Hash())
+		assert.Len(t, got, 2)
+		assert.Equal(t, chks[0].Data(), got[chks[0].Hash()].Data())
+		assert.Equal(t, LazyFetchStats{RequestedChunks: 3, RemoteChunks: 2, CacheBytes: src.cache.Size()}, src.Stats())
+
+		got = getChunks(t, src, chks[0].Hash(), chks[2].Hash())
+		assert.Len(t, got, 2)
+		stats := src.Stats()
+		assert.Equal(t, uint64(5), stats.RequestedChunks)
+		assert.Equal(t, uint64(1), stats.CacheHits)
+		assert.Equal(t, uint64(3), stats.RemoteChunks)
+		assert.Equal(t, 1, *opens)
+	})
+	t.Run("HasMany", func(t *testing.T) {
+		var seed [32]byte
+		rand := rand.NewChaCha8(seed)
+		remote := (&chunks.MemoryStorage{}).NewView()
+		chks := randomChunks(rand, 4, 512)
+		putChunks(t, remote, chks[:2]...)
+		src, _ := newTestLazySource(t, remote)
+
+		hs := hash.NewHashSet(chks[0].Hash(), chks[1].Hash(), chks[2].Hash(), chks[3].Hash())
+		absent, err := src.HasMany(context.Background(), hs)
+		require.NoError(t, err)
+		assert.Equal(t, hash.NewHashSet(chks[2].Hash(), chks[3].Hash()), absent)
+
+		// The present chunks are now cached as present.
+		assert.Equal(t, absent, src.cache.GetCachedHas(hs))
+	})
+	t.Run("RetriesOpen", func(t *testing.T) {
+		cache, err := NewDiskChunkCache(t.TempDir(), 1<<20, nil)
+		require.NoError(t, err)
+		remote := (&chunks.MemoryStorage{}).NewView()
+		chk := chunks.NewChunk([]byte("chunk"))
+		putChunks(t, remote, chk)
+		fail := true
+		src := NewLazySource(func(context.Context) (chunks.ChunkStore, error) {
+			if fail {
+				return nil, errors.New("remote unavailable")
+			}
+			return remote, nil
+		}, cache)
+
+		err = src.GetMany(context.Background(), hash.NewHashSet(chk.Hash()), func(context.Context, *chunks.Chunk) {})
+		assert.Error(t, err)
+		fail = false
+		assert.Len(t, getChunks(t, src, chk.Hash()), 1)
+	})
+	t.Run("PrefetchesSiblings", func(t *testing.T) {
+		var seed [32]byte
+		rand := rand.NewChaCha8(seed)
+		remote := (&chunks.MemoryStorage{}).NewView()
+		children := randomChunks(rand, 20, 512)
+		childHashes := make([]hash.Hash, len(children))
+		for i, c := range children {
+			childHashes[i] = c.Hash()
+		}
+		node := prollyInternalNode(childHashes)
+		putChunks(t, remote, children...)
+		putChunks(t, remote, node)
+		src, _ := newTestLazySource(t, remote)
+
+		getChunks(t, src, node.Hash())
+		// Reading the first child is sequential, so the next lazyPrefetchWidth children are prefetched.
+		getChunks(t, src, childHashes[0])
+		src.waitForPrefetch()
+		assert.Equal(t, uint64(lazyPrefetchWidth), src.Stats().PrefetchedChunks)
+
+		for _, h := range childHashes[1 : 1+lazyPrefetchWidth] {
+			getChunks(t, src, h)
+			src.waitForPrefetch()
+		}
+		stats := src.Stats()
+		assert.Equal(t, uint64(lazyPrefetchWidth), stats.CacheHits)
+		assert.Equal(t, uint64(2), stats.RemoteChunks)
+		// Only the children which weren't prefetched yet are prefetched as the scan continues.
+		assert.Equal(t, uint64(len(children)-1), stats.PrefetchedChunks)
+
+		// Reading out of order doesn't prefetch.
+		other, _ := newTestLazySource(t, remote)
+		getChunks(t, other, node.Hash())
+		getChunks(t, other, childHashes[5])
+		other.waitForPrefetch()
+		assert.Equal(t, uint64(0), other.Stats().PrefetchedChunks)
+	})
+	t.Run("CloseCancelsPrefetch", func(t *testing.T) {
+		var seed [32]byte
+		rand := rand.NewChaCha8(seed)
+		remote := (&chunks.MemoryStorage{}).NewView()
+		children := randomChunks(rand, 4, 512)
+		childHashes := make([]hash.Hash, len(children))
+		for i, c := range children {
+			childHashes[i] = c.Hash()
+		}
+		node := prollyInternalNode(childHashes)
+		putChunks(t, remote, children...)
+		putChunks(t, remote, node)
+		// Fetching the children following the first one blocks until the fetch is canceled.
+		blocking := &blockingChunkStore{ChunkStore: remote, blocked: hash.NewHashSet(childHashes[1:]...)}
+		src, _ := newTestLazySource(t, blocking)
+
+		getChunks(t, src, node.Hash())
+		// Reading the first child starts a prefetch of the next ones, which only returns once Close cancels it.
+		getChunks(t, src, childHashes[0])
+		require.NoError(t, src.Close())
+		assert.Equal(t, uint64(0), src.Stats().PrefetchedChunks)
+	})
+}
+
+// blockingChunkStore is a chunks.ChunkStore whose GetMany waits for its context to be canceled when it's asked for one
+// of the |blocked| chunks.
+type blockingChunkStore struct {
+	chunks.ChunkStore
+	blocked hash.HashSet
+}
+
+func (cs *blockingChunkStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	for h := range hashes {
+		if cs.blocked.Has(h) {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+	}
+	return cs.ChunkStore.GetMany(ctx, hashes, found)
+}
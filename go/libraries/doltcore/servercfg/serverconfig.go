@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -64,6 +65,7 @@ const (
 	DefaultAutoGCBehaviorEnable      = true
 	DefaultDoltTransactionCommit     = false
 	DefaultBranchActivityTracking    = false
+	DefaultRunCIWorkflows            = false
 	DefaultMaxConnections            = 1000
 	DefaultMaxWaitConnections        = 50
 	DefaultMaxWaitConnectionsTimeout = 60 * time.Second
@@ -78,6 +80,10 @@ const (
 	DefaultMySQLUnixSocketFilePath   = "/tmp/mysql.sock"
 	DefaultMaxLoggedQueryLen         = 0
 	DefaultEncodeLoggedQuery         = false
+	DefaultWebhookTimeout            = 10 * time.Second
+	DefaultWebhookMaxAttempts        = 10
+	DefaultWebhookInitialBackoff     = 1 * time.Second
+	DefaultWebhookMaxBackoff         = 5 * time.Minute
 	DefaultCompressionLevel          = 1
 )
 
@@ -165,6 +171,9 @@ type ServerConfig interface {
 	DoltTransactionCommit() bool
 	// BranchActivityTracking enables or disables the tracking of branch activity for the dolt_branch_activity table
 	BranchActivityTracking() bool
+	// RunCIWorkflows enables running dolt ci workflows when the branches they are triggered by are pushed to or
+	// committed to on this server.
+	RunCIWorkflows() bool
 	// DataDir is the path to a directory to use as the data dir, both to create new databases and locate existing ones.
 	DataDir() string
 	// CfgDir is the path to a directory to use to store the dolt configuration files.
@@ -249,6 +258,8 @@ type ServerConfig interface {
 	ValueSet(value string) bool
 	// AutoGCBehavior defines parameters around how auto-GC works for the running server.
 	AutoGCBehavior() AutoGCBehavior
+	// Webhooks returns the webhook commit hooks configured for this server.
+	Webhooks() []WebhookConfig
 	// Overrides returns any overrides that are defined. This is primarily used by Doltgres.
 	Overrides() sql.EngineOverrides
 }
@@ -269,6 +280,7 @@ func defaultServerConfigYAML() *YAMLConfig {
 			AutoCommit:             ptr(DefaultAutoCommit),
 			DoltTransactionCommit:  ptr(DefaultDoltTransactionCommit),
 			BranchActivityTracking: ptr(DefaultBranchActivityTracking),
+			RunCIWorkflows:         ptr(DefaultRunCIWorkflows),
 			AutoGCBehavior: &AutoGCBehaviorYAMLConfig{
 				Enable_:       ptr(DefaultAutoGCBehaviorEnable),
 				ArchiveLevel_: ptr(DefaultCompressionLevel),
@@ -332,6 +344,9 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if err := ValidateWebhooksConfig(config.Webhooks()); err != nil {
+		return err
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
@@ -348,6 +363,7 @@ const (
 	AutoCommitKey                     = "autocommit"
 	DoltTransactionCommitKey          = "dolt_transaction_commit"
 	BranchActivityTrackingKey         = "branch_activity_tracking"
+	RunCIWorkflowsKey                 = "run_ci_workflows"
 	DataDirKey                        = "data_dir"
 	CfgDirKey                         = "cfg_dir"
 	MaxConnectionsKey                 = "max_connections"
@@ -378,6 +394,7 @@ const (
 	RemotesapiReadOnlyKey             = "remotesapi_read_only"
 	ClusterConfigKey                  = "cluster_config"
 	EventSchedulerKey                 = "event_scheduler"
+	WebhooksKey                       = "webhooks"
 )
 
 type SystemVariableTarget interface {
@@ -417,6 +434,35 @@ func ApplySystemVariables(ctx *sql.Context, cfg ServerConfig, sysVarTarget Syste
 	return nil
 }
 
+// ValidateWebhooksConfig returns an error if any of the configured webhooks are invalid.
+func ValidateWebhooksConfig(webhooks []WebhookConfig) error {
+	names := make(map[string]struct{}, len(webhooks))
+	for i, webhook := range webhooks {
+		if webhook.Name() == "" {
+			return fmt.Errorf("webhooks[%d]: name: Cannot be empty", i)
+		}
+		if _, ok := names[webhook.Name()]; ok {
+			return fmt.Errorf("webhooks[%d]: name: \"%s\" is used by more than one webhook", i, webhook.Name())
+		}
+		names[webhook.Name()] = struct{}{}
+
+		u, err := url.Parse(webhook.URL())
+		if err != nil {
+			return fmt.Errorf("webhooks[%d]: url: %w", i, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("webhooks[%d]: url: is \"%s\" but must be an http or https url", i, webhook.URL())
+		}
+		if webhook.MaxAttempts() < 1 {
+			return fmt.Errorf("webhooks[%d]: max_attempts: is %d but must be >= 1", i, webhook.MaxAttempts())
+		}
+		if webhook.InitialBackoff() > webhook.MaxBackoff() {
+			return fmt.Errorf("webhooks[%d]: initial_backoff_millis: must not be greater than max_backoff_millis", i)
+		}
+	}
+	return nil
+}
+
 func ValidateClusterConfig(config ClusterConfig) error {
 	if config == nil {
 		return nil
@@ -564,4 +610,46 @@ func CheckForUnixSocket(config ServerConfig) (string, bool, error) {
 type AutoGCBehavior interface {
 	Enable() bool
 	ArchiveLevel() int
+	// HistoryRetention returns the policy used to squash old history before each automatic garbage collection, or nil
+	// if history is never squashed.
+	HistoryRetention() HistoryRetention
+}
+
+// HistoryRetention configures squashing the history of a database before it is garbage collected. Ages are a number
+// of days (90d), weeks (12w) or years (1y), a duration (36h), or "forever". An empty age disables its tier.
+type HistoryRetention interface {
+	// KeepAll is the age under which every commit is kept.
+	KeepAll() string
+	// KeepDaily is the age under which the last commit of each day is kept.
+	KeepDaily() string
+	// KeepWeekly is the age under which the last commit of each week is kept.
+	KeepWeekly() string
+	// Preserve are the hashes of commits which are always kept.
+	Preserve() []string
+	// DryRun logs the commits which would be squashed, without rewriting history.
+	DryRun() bool
+}
+
+// WebhookConfig configures a commit hook which POSTs a JSON event to an HTTP endpoint every time the head of a
+// matching branch is updated.
+type WebhookConfig interface {
+	// Name uniquely identifies the webhook. Delivery state is tracked per webhook name.
+	Name() string
+	// URL is the http or https endpoint events are POSTed to.
+	URL() string
+	// Databases is the list of databases the webhook fires for. An empty list matches every database.
+	Databases() []string
+	// Branches is the list of branch name patterns the webhook fires for. The '*' wildcard matches zero or more
+	// characters. An empty list matches every branch.
+	Branches() []string
+	// Headers are additional HTTP headers sent with every request, e.g. for authentication.
+	Headers() map[string]string
+	// Timeout is the timeout applied to a single delivery attempt.
+	Timeout() time.Duration
+	// MaxAttempts is the number of delivery attempts made before an event is marked as failed.
+	MaxAttempts() int
+	// InitialBackoff is the delay before the first retry. The delay doubles after each failed attempt.
+	InitialBackoff() time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff() time.Duration
 }
@@ -71,6 +71,8 @@ type BehaviorYAMLConfig struct {
 	AutoGCBehavior *AutoGCBehaviorYAMLConfig `yaml:"auto_gc_behavior,omitempty" minver:"1.50.0"`
 
 	BranchActivityTracking *bool `yaml:"branch_activity_tracking,omitempty" minver:"1.77.0"`
+
+	RunCIWorkflows *bool `yaml:"run_ci_workflows,omitempty" minver:"TBD"`
 }
 
 // UserYAMLConfig contains server configuration regarding the user account clients must use to connect
@@ -171,6 +173,7 @@ type YAMLConfig struct {
 	GoldenMysqlConn *string                `yaml:"golden_mysql_conn,omitempty"`
 	MetricsConfig   MetricsYAMLConfig      `yaml:"metrics,omitempty"`
 	ClusterCfg      *ClusterYAMLConfig     `yaml:"cluster,omitempty"`
+	Webhooks_       []WebhookYAMLConfig    `yaml:"webhooks,omitempty" minver:"TBD"`
 }
 
 var _ ServerConfig = YAMLConfig{}
@@ -221,6 +224,7 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			DisableClientMultiStatements: ptr(cfg.DisableClientMultiStatements()),
 			DoltTransactionCommit:        ptr(cfg.DoltTransactionCommit()),
 			BranchActivityTracking:       ptr(cfg.BranchActivityTracking()),
+			RunCIWorkflows:               ptr(cfg.RunCIWorkflows()),
 			EventSchedulerStatus:         ptr(cfg.EventSchedulerStatus()),
 			AutoGCBehavior:               autoGCBehavior,
 		},
@@ -260,6 +264,7 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 		SystemVars_:       systemVars,
 		Vars:              cfg.UserVars(),
 		Jwks:              cfg.JwksConfig(),
+		Webhooks_:         webhooksAsYAMLConfig(cfg.Webhooks()),
 	}
 }
 
@@ -300,6 +305,7 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			DisableClientMultiStatements: zeroIf(ptr(cfg.DisableClientMultiStatements()), !cfg.ValueSet(DisableClientMultiStatementsKey)),
 			DoltTransactionCommit:        zeroIf(ptr(cfg.DoltTransactionCommit()), !cfg.ValueSet(DoltTransactionCommitKey)),
 			BranchActivityTracking:       zeroIf(ptr(cfg.BranchActivityTracking()), !cfg.ValueSet(BranchActivityTrackingKey)),
+			RunCIWorkflows:               zeroIf(ptr(cfg.RunCIWorkflows()), !cfg.ValueSet(RunCIWorkflowsKey)),
 			EventSchedulerStatus:         zeroIf(ptr(cfg.EventSchedulerStatus()), !cfg.ValueSet(EventSchedulerKey)),
 		},
 		ListenerConfig: ListenerYAMLConfig{
@@ -338,6 +344,7 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 		SystemVars_:       zeroIf(systemVars, !cfg.ValueSet(SystemVarsKey)),
 		Vars:              zeroIf(cfg.UserVars(), !cfg.ValueSet(UserVarsKey)),
 		Jwks:              zeroIf(cfg.JwksConfig(), !cfg.ValueSet(JwksConfigKey)),
+		Webhooks_:         zeroIf(webhooksAsYAMLConfig(cfg.Webhooks()), !cfg.ValueSet(WebhooksKey)),
 	}
 }
 
@@ -711,6 +718,15 @@ func (cfg YAMLConfig) BranchActivityTracking() bool {
 	return *cfg.BehaviorConfig.BranchActivityTracking
 }
 
+// RunCIWorkflows enables running dolt ci workflows when the branches they are triggered by are updated
+func (cfg YAMLConfig) RunCIWorkflows() bool {
+	if cfg.BehaviorConfig.RunCIWorkflows == nil {
+		return DefaultRunCIWorkflows
+	}
+
+	return *cfg.BehaviorConfig.RunCIWorkflows
+}
+
 // LogLevel returns the level of logging that the server will use.
 func (cfg YAMLConfig) LogLevel() LogLevel {
 	if cfg.LogLevelStr == nil {
@@ -1026,6 +1042,17 @@ func (cfg YAMLConfig) AutoGCBehavior() AutoGCBehavior {
 	return cfg.BehaviorConfig.AutoGCBehavior
 }
 
+func (cfg YAMLConfig) Webhooks() []WebhookConfig {
+	if len(cfg.Webhooks_) == 0 {
+		return nil
+	}
+	ret := make([]WebhookConfig, len(cfg.Webhooks_))
+	for i := range cfg.Webhooks_ {
+		ret[i] = cfg.Webhooks_[i]
+	}
+	return ret
+}
+
 func (cfg YAMLConfig) EventSchedulerStatus() string {
 	if cfg.BehaviorConfig.EventSchedulerStatus == nil {
 		return "ON"
@@ -1136,13 +1163,18 @@ func (cfg YAMLConfig) ValueSet(value string) bool {
 		return cfg.ListenerConfig.MaxConnectionsTimeoutMs != nil
 	case EventSchedulerKey:
 		return cfg.BehaviorConfig.EventSchedulerStatus != nil
+	case WebhooksKey:
+		return len(cfg.Webhooks_) != 0
+	case RunCIWorkflowsKey:
+		return cfg.BehaviorConfig.RunCIWorkflows != nil
 	}
 	return false
 }
 
 type AutoGCBehaviorYAMLConfig struct {
-	Enable_       *bool `yaml:"enable,omitempty" minver:"1.50.0"`
-	ArchiveLevel_ *int  `yaml:"archive_level,omitempty" minver:"1.52.1"`
+	Enable_           *bool                       `yaml:"enable,omitempty" minver:"1.50.0"`
+	ArchiveLevel_     *int                        `yaml:"archive_level,omitempty" minver:"1.52.1"`
+	HistoryRetention_ *HistoryRetentionYAMLConfig `yaml:"history_retention,omitempty" minver:"TBD"`
 }
 
 func (a *AutoGCBehaviorYAMLConfig) Enable() bool {
@@ -1159,9 +1191,143 @@ func (a *AutoGCBehaviorYAMLConfig) ArchiveLevel() int {
 	return *a.ArchiveLevel_
 }
 
+func (a *AutoGCBehaviorYAMLConfig) HistoryRetention() HistoryRetention {
+	if a.HistoryRetention_ == nil {
+		return nil
+	}
+	return a.HistoryRetention_
+}
+
 func toAutoGCBehaviorYAML(a AutoGCBehavior) *AutoGCBehaviorYAMLConfig {
 	return &AutoGCBehaviorYAMLConfig{
-		Enable_:       ptr(a.Enable()),
-		ArchiveLevel_: ptr(a.ArchiveLevel()),
+		Enable_:           ptr(a.Enable()),
+		ArchiveLevel_:     ptr(a.ArchiveLevel()),
+		HistoryRetention_: toHistoryRetentionYAML(a.HistoryRetention()),
+	}
+}
+
+// HistoryRetentionYAMLConfig is the YAML representation of a HistoryRetention.
+type HistoryRetentionYAMLConfig struct {
+	KeepAll_    string   `yaml:"keep_all,omitempty"`
+	KeepDaily_  string   `yaml:"keep_daily,omitempty"`
+	KeepWeekly_ string   `yaml:"keep_weekly,omitempty"`
+	Preserve_   []string `yaml:"preserve,omitempty"`
+	DryRun_     bool     `yaml:"dry_run,omitempty"`
+}
+
+func (h *HistoryRetentionYAMLConfig) KeepAll() string {
+	return h.KeepAll_
+}
+
+func (h *HistoryRetentionYAMLConfig) KeepDaily() string {
+	return h.KeepDaily_
+}
+
+func (h *HistoryRetentionYAMLConfig) KeepWeekly() string {
+	return h.KeepWeekly_
+}
+
+func (h *HistoryRetentionYAMLConfig) Preserve() []string {
+	return h.Preserve_
+}
+
+func (h *HistoryRetentionYAMLConfig) DryRun() bool {
+	return h.DryRun_
+}
+
+func toHistoryRetentionYAML(h HistoryRetention) *HistoryRetentionYAMLConfig {
+	if h == nil {
+		return nil
+	}
+	return &HistoryRetentionYAMLConfig{
+		KeepAll_:    h.KeepAll(),
+		KeepDaily_:  h.KeepDaily(),
+		KeepWeekly_: h.KeepWeekly(),
+		Preserve_:   h.Preserve(),
+		DryRun_:     h.DryRun(),
 	}
 }
+
+// WebhookYAMLConfig is the YAML representation of a WebhookConfig.
+type WebhookYAMLConfig struct {
+	Name_                string            `yaml:"name"`
+	URL_                 string            `yaml:"url"`
+	Databases_           []string          `yaml:"databases,omitempty"`
+	Branches_            []string          `yaml:"branches,omitempty"`
+	Headers_             map[string]string `yaml:"headers,omitempty"`
+	TimeoutMillis        *uint64           `yaml:"timeout_millis,omitempty"`
+	MaxAttempts_         *int              `yaml:"max_attempts,omitempty"`
+	InitialBackoffMillis *uint64           `yaml:"initial_backoff_millis,omitempty"`
+	MaxBackoffMillis     *uint64           `yaml:"max_backoff_millis,omitempty"`
+}
+
+var _ WebhookConfig = WebhookYAMLConfig{}
+
+func (w WebhookYAMLConfig) Name() string {
+	return w.Name_
+}
+
+func (w WebhookYAMLConfig) URL() string {
+	return w.URL_
+}
+
+func (w WebhookYAMLConfig) Databases() []string {
+	return w.Databases_
+}
+
+func (w WebhookYAMLConfig) Branches() []string {
+	return w.Branches_
+}
+
+func (w WebhookYAMLConfig) Headers() map[string]string {
+	return w.Headers_
+}
+
+func (w WebhookYAMLConfig) Timeout() time.Duration {
+	if w.TimeoutMillis == nil {
+		return DefaultWebhookTimeout
+	}
+	return time.Duration(*w.TimeoutMillis) * time.Millisecond
+}
+
+func (w WebhookYAMLConfig) MaxAttempts() int {
+	if w.MaxAttempts_ == nil {
+		return DefaultWebhookMaxAttempts
+	}
+	return *w.MaxAttempts_
+}
+
+func (w WebhookYAMLConfig) InitialBackoff() time.Duration {
+	if w.InitialBackoffMillis == nil {
+		return DefaultWebhookInitialBackoff
+	}
+	return time.Duration(*w.InitialBackoffMillis) * time.Millisecond
+}
+
+func (w WebhookYAMLConfig) MaxBackoff() time.Duration {
+	if w.MaxBackoffMillis == nil {
+		return DefaultWebhookMaxBackoff
+	}
+	return time.Duration(*w.MaxBackoffMillis) * time.Millisecond
+}
+
+func webhooksAsYAMLConfig(webhooks []WebhookConfig) []WebhookYAMLConfig {
+	if len(webhooks) == 0 {
+		return nil
+	}
+	ret := make([]WebhookYAMLConfig, len(webhooks))
+	for i, w := range webhooks {
+		ret[i] = WebhookYAMLConfig{
+			Name_:                w.Name(),
+			URL_:                 w.URL(),
+			Databases_:           w.Databases(),
+			Branches_:            w.Branches(),
+			Headers_:             w.Headers(),
+			TimeoutMillis:        ptr(uint64(w.Timeout().Milliseconds())),
+			MaxAttempts_:         ptr(w.MaxAttempts()),
+			InitialBackoffMillis: ptr(uint64(w.InitialBackoff().Milliseconds())),
+			MaxBackoffMillis:     ptr(uint64(w.MaxBackoff().Milliseconds())),
+		}
+	}
+	return ret
+}
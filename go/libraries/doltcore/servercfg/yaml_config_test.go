@@ -16,6 +16,7 @@ package servercfg
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,7 @@ behavior:
         enable: true
         archive_level: 1
     branch_activity_tracking: false
+    run_ci_workflows: true
 
 listener:
     host: localhost
@@ -104,6 +106,7 @@ jwks:
 	expected.BehaviorConfig.DoltTransactionCommit = &trueValue
 	falseValue := false
 	expected.BehaviorConfig.BranchActivityTracking = &falseValue
+	expected.BehaviorConfig.RunCIWorkflows = &trueValue
 	expected.CfgDirStr = nillableStrPtr("")
 	expected.PrivilegeFile = ptr("some other nonsense")
 	expected.BranchControlFile = ptr("third nonsense")
@@ -208,6 +211,115 @@ cluster:
 	require.Equal(t, "http://doltdb-1.doltdb:50051/{database}", config.ClusterConfig().StandbyRemotes()[0].RemoteURLTemplate())
 }
 
+func TestUnmarshallWebhooks(t *testing.T) {
+	testStr := `
+webhooks:
+- name: commits
+  url: https://example.com/dolt
+  databases: [mydb]
+  branches: [main, release/*]
+  headers:
+    Authorization: Bearer token
+  max_attempts: 3
+  initial_backoff_millis: 250
+- name: everything
+  url: http://localhost:8080/hook
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.NoError(t, ValidateWebhooksConfig(config.Webhooks()))
+
+	webhooks := config.Webhooks()
+	require.Len(t, webhooks, 2)
+	require.Equal(t, "commits", webhooks[0].Name())
+	require.Equal(t, "https://example.com/dolt", webhooks[0].URL())
+	require.Equal(t, []string{"mydb"}, webhooks[0].Databases())
+	require.Equal(t, []string{"main", "release/*"}, webhooks[0].Branches())
+	require.Equal(t, map[string]string{"Authorization": "Bearer token"}, webhooks[0].Headers())
+	require.Equal(t, 3, webhooks[0].MaxAttempts())
+	require.Equal(t, 250*time.Millisecond, webhooks[0].InitialBackoff())
+	require.Equal(t, DefaultWebhookMaxBackoff, webhooks[0].MaxBackoff())
+
+	require.Empty(t, webhooks[1].Databases())
+	require.Empty(t, webhooks[1].Branches())
+	require.Equal(t, DefaultWebhookTimeout, webhooks[1].Timeout())
+	require.Equal(t, DefaultWebhookMaxAttempts, webhooks[1].MaxAttempts())
+}
+
+func TestValidateWebhooksConfig(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+		Error  bool
+	}{
+		{
+			Name:   "no webhooks",
+			Config: "",
+			Error:  false,
+		},
+		{
+			Name: "missing name",
+			Config: `
+webhooks:
+- url: https://example.com/dolt
+`,
+			Error: true,
+		},
+		{
+			Name: "duplicate name",
+			Config: `
+webhooks:
+- name: hook
+  url: https://example.com/one
+- name: hook
+  url: https://example.com/two
+`,
+			Error: true,
+		},
+		{
+			Name: "non-http url",
+			Config: `
+webhooks:
+- name: hook
+  url: ftp://example.com/dolt
+`,
+			Error: true,
+		},
+		{
+			Name: "zero max_attempts",
+			Config: `
+webhooks:
+- name: hook
+  url: https://example.com/dolt
+  max_attempts: 0
+`,
+			Error: true,
+		},
+		{
+			Name: "initial backoff greater than max backoff",
+			Config: `
+webhooks:
+- name: hook
+  url: https://example.com/dolt
+  initial_backoff_millis: 2000
+  max_backoff_millis: 1000
+`,
+			Error: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cfg, err := NewYamlConfig([]byte(c.Config))
+			require.NoError(t, err)
+			if c.Error {
+				require.Error(t, ValidateWebhooksConfig(cfg.Webhooks()))
+			} else {
+				require.NoError(t, ValidateWebhooksConfig(cfg.Webhooks()))
+			}
+		})
+	}
+}
+
 func TestYamlConfigFromFileEnvInterpolation_String(t *testing.T) {
 	t.Setenv("DOLT_TEST_SQLSERVER_HOST", "127.0.0.1")
 
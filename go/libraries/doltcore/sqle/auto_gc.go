@@ -17,6 +17,7 @@ package sqle
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -25,10 +26,13 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -43,15 +47,22 @@ import (
 // it is time to perform a GC for that particular database. If it is,
 // they forward a request the background thread to register the
 // database as wanting a GC.
+//
+// If a history retention policy is configured, the history of a
+// database is squashed according to the policy before it is
+// collected, and the collection is a full one whenever commits were
+// squashed.
 
 type AutoGCController struct {
-	workCh   chan autoGCWork
-	lgr      *logrus.Logger
-	hooks    map[string]*autoGCCommitHook
-	ctxF     func(context.Context) (*sql.Context, error)
-	threads  *sql.BackgroundThreads
-	arcLevel chunks.GCArchiveLevel
-	mu       sync.Mutex
+	workCh    chan autoGCWork
+	lgr       *logrus.Logger
+	hooks     map[string]*autoGCCommitHook
+	ctxF      func(context.Context) (*sql.Context, error)
+	threads   *sql.BackgroundThreads
+	arcLevel  chunks.GCArchiveLevel
+	retention *rebase.RetentionPolicy
+	dryRun    bool
+	mu        sync.Mutex
 }
 
 func NewAutoGCController(arcLevel chunks.GCArchiveLevel, lgr *logrus.Logger) *AutoGCController {
@@ -63,6 +74,40 @@ func NewAutoGCController(arcLevel chunks.GCArchiveLevel, lgr *logrus.Logger) *Au
 	}
 }
 
+// SetHistoryRetention configures the policy used to squash the
+// history of every database before it is collected. With a dry run,
+// the commits which would be kept are logged instead.
+func (c *AutoGCController) SetHistoryRetention(cfg servercfg.HistoryRetention) error {
+	var policy rebase.RetentionPolicy
+	for _, tier := range []struct {
+		age string
+		dst *time.Duration
+	}{
+		{cfg.KeepAll(), &policy.KeepAll},
+		{cfg.KeepDaily(), &policy.KeepDaily},
+		{cfg.KeepWeekly(), &policy.KeepWeekly},
+	} {
+		age, err := rebase.ParseRetentionAge(tier.age)
+		if err != nil {
+			return err
+		}
+		*tier.dst = age
+	}
+	if policy.KeepAll == 0 && policy.KeepDaily == 0 && policy.KeepWeekly == 0 {
+		return errors.New("history_retention must set at least one of keep_all, keep_daily or keep_weekly")
+	}
+	for _, commit := range cfg.Preserve() {
+		h, ok := hash.MaybeParse(commit)
+		if !ok {
+			return fmt.Errorf("invalid commit hash to preserve in history_retention: %s", commit)
+		}
+		policy.Preserve = append(policy.Preserve, h)
+	}
+	c.retention = &policy
+	c.dryRun = cfg.DryRun()
+	return nil
+}
+
 // Passed by a commit hook to the auto-GC thread, requesting the
 // thread to dolt_gc |db|. When the GC is finished, |done| will be
 // closed. Signalling completion allows the commit hook to only
@@ -160,7 +205,12 @@ func (c *AutoGCController) doWork(ctx context.Context, work autoGCWork, ctxF fun
 	defer sql.SessionEnd(sqlCtx.Session)
 	sql.SessionCommandBegin(sqlCtx.Session)
 	defer sql.SessionCommandEnd(sqlCtx.Session)
-	err = dprocedures.RunDoltGC(sqlCtx, work.db, types.GCModeDefault, c.arcLevel, work.name)
+	mode := types.GCModeDefault
+	if c.retention != nil && c.squashHistory(sqlCtx, work) {
+		// only a full collection removes the squashed commits from the old generation
+		mode = types.GCModeFull
+	}
+	err = dprocedures.RunDoltGC(sqlCtx, work.db, mode, c.arcLevel, work.name)
 	if err != nil {
 		if !errors.Is(err, chunks.ErrNothingToCollect) {
 			c.lgr.Warnf("sqle/auto_gc: Attempt to auto GC database %s failed with error: %v", work.name, err)
@@ -171,6 +221,30 @@ func (c *AutoGCController) doWork(ctx context.Context, work autoGCWork, ctxF fun
 	c.lgr.Infof("sqle/auto_gc: Successfully completed auto GC of database %s in %v", work.name, time.Since(start))
 }
 
+// squashHistory squashes the history of the database of |work|
+// according to the retention policy, and returns whether any commit
+// was squashed. Failing to squash history doesn't prevent the GC.
+func (c *AutoGCController) squashHistory(ctx *sql.Context, work autoGCWork) bool {
+	squashed, err := rebase.SquashHistory(ctx, work.db, *c.retention, time.Now(), c.dryRun)
+	if err != nil {
+		c.lgr.Warnf("sqle/auto_gc: Attempt to squash the history of database %s failed with error: %v", work.name, err)
+		return false
+	}
+	if c.dryRun {
+		for _, s := range squashed {
+			c.lgr.Infof("sqle/auto_gc: Dry run of squashing history of database %s: %s would keep %d of %d commits", work.name, s.Ref.String(), s.KeptCommits, s.Commits)
+		}
+		return false
+	}
+	if !dprocedures.HistoryWasSquashed(squashed) {
+		return false
+	}
+	for _, s := range squashed {
+		c.lgr.Infof("sqle/auto_gc: Squashed history of database %s: %s kept %d of %d commits, moved from %s to %s", work.name, s.Ref.String(), s.KeptCommits, s.Commits, s.OldHead.String(), s.NewHead.String())
+	}
+	return true
+}
+
 func (c *AutoGCController) newCommitHook(name string, db *doltdb.DoltDB) *autoGCCommitHook {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -15,9 +15,11 @@
 package binlogreplication
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/mysql_db"
@@ -32,6 +34,14 @@ const replicationRunningStateDirectory = ".doltcfg"
 // replicaRunningFilename holds the name of the file that indicates replication was running on a replica server.
 const replicaRunningFilename = "replica-running"
 
+// replicationFiltersFilename holds the name of the file, in the .doltcfg directory, that stores the replication
+// filters configured on a replica server.
+const replicationFiltersFilename = "binlog-filters"
+
+// pendingCommitFilename holds the name of the file, in the .doltcfg directory, that stores the source transactions
+// that have been applied on a replica server, but that haven't been recorded in a Dolt commit yet.
+const pendingCommitFilename = "binlog-pending-commit"
+
 // replicaRunningState indicates if a replica was actively running replication.
 type replicaRunningState int
 
@@ -140,6 +150,148 @@ func persistSourceUuid(ctx *sql.Context, sourceUuid string, mysqlDb *mysql_db.My
 	return persistReplicationConfiguration(ctx, replicaSourceInfo, mysqlDb)
 }
 
+// persistedReplicationFilters is the representation of a filterConfiguration stored on disk.
+type persistedReplicationFilters struct {
+	DoTables         map[string][]string          `json:"do_tables,omitempty"`
+	IgnoreTables     map[string][]string          `json:"ignore_tables,omitempty"`
+	WildDoTables     []string                     `json:"wild_do_tables,omitempty"`
+	WildIgnoreTables []string                     `json:"wild_ignore_tables,omitempty"`
+	RewriteDbs       map[string]string            `json:"rewrite_dbs,omitempty"`
+	RowFilters       map[string]map[string]string `json:"row_filters,omitempty"`
+}
+
+// loadReplicationFilters loads the replication filters stored in the .doltcfg/binlog-filters file at the root of the
+// provider's filesystem into |filters|. Unlike MySQL, which requires replication filters to be set again every time
+// a server is started, Dolt persists them so that a replica keeps filtering the same changes across restarts. If no
+// filters are stored, |filters| is left unchanged.
+func loadReplicationFilters(ctx *sql.Context, filters *filterConfiguration) error {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	filesys := doltSession.Provider().FileSystem()
+
+	filtersFilepath := filepath.Join(replicationRunningStateDirectory, replicationFiltersFilename)
+	if exists, _ := filesys.Exists(filtersFilepath); !exists {
+		return nil
+	}
+	bytes, err := filesys.ReadFile(filtersFilepath)
+	if err != nil {
+		return err
+	}
+
+	var persisted persistedReplicationFilters
+	if err = json.Unmarshal(bytes, &persisted); err != nil {
+		return fmt.Errorf("unable to load replication filters from %s: %w", filtersFilepath, err)
+	}
+	filters.load(persisted)
+	return nil
+}
+
+// persistReplicationFilters saves the replication |filters| to the .doltcfg/binlog-filters file at the root of the
+// provider's filesystem, or removes the file if no filters are configured. An error is returned if any problems were
+// encountered saving the filters to disk.
+func persistReplicationFilters(ctx *sql.Context, filters *filterConfiguration) error {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	filesys := doltSession.Provider().FileSystem()
+
+	persisted := filters.persisted()
+	if persisted.isEmpty() {
+		return deleteReplicationFilters(ctx)
+	}
+
+	// The .doltcfg dir may not exist yet, so create it if necessary.
+	if err := createDoltCfgDir(filesys); err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(persisted)
+	if err != nil {
+		return err
+	}
+	return filesys.WriteFile(filepath.Join(replicationRunningStateDirectory, replicationFiltersFilename), bytes, 0666)
+}
+
+// deleteReplicationFilters deletes the replication filters stored in the .doltcfg/binlog-filters file at the root of
+// the provider's filesystem, if any.
+func deleteReplicationFilters(ctx *sql.Context) error {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	filesys := doltSession.Provider().FileSystem()
+
+	filtersFilepath := filepath.Join(replicationRunningStateDirectory, replicationFiltersFilename)
+	if exists, _ := filesys.Exists(filtersFilepath); !exists {
+		return nil
+	}
+	return filesys.Delete(filtersFilepath, false)
+}
+
+// persistedPendingCommit is the representation of a pendingCommit, and of the databases it changed, stored on disk.
+type persistedPendingCommit struct {
+	Transactions int       `json:"transactions"`
+	FirstGtid    string    `json:"first_gtid"`
+	LastGtid     string    `json:"last_gtid"`
+	SourceTime   time.Time `json:"source_time"`
+	StartTime    time.Time `json:"start_time"`
+	Databases    []string  `json:"databases,omitempty"`
+}
+
+// loadPendingCommit loads the pending source transactions stored in the .doltcfg/binlog-pending-commit file at the
+// root of the provider's filesystem. If no pending transactions are stored, nil is returned.
+func loadPendingCommit(ctx *sql.Context) (*persistedPendingCommit, error) {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	filesys := doltSession.Provider().FileSystem()
+
+	pendingFilepath := filepath.Join(replicationRunningStateDirectory, pendingCommitFilename)
+	if exists, _ := filesys.Exists(pendingFilepath); !exists {
+		return nil, nil
+	}
+	bytes, err := filesys.ReadFile(pendingFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	var persisted persistedPendingCommit
+	if err = json.Unmarshal(bytes, &persisted); err != nil {
+		return nil, fmt.Errorf("unable to load pending binlog transactions from %s: %w", pendingFilepath, err)
+	}
+	return &persisted, nil
+}
+
+// persistPendingCommit saves the |pending| source transactions to the .doltcfg/binlog-pending-commit file at the root
+// of the provider's filesystem, so that a replica that stops before creating their Dolt commit still records them
+// once it's restarted. An error is returned if any problems were encountered saving the transactions to disk.
+func persistPendingCommit(ctx *sql.Context, pending persistedPendingCommit) error {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	filesys := doltSession.Provider().FileSystem()
+
+	// The .doltcfg dir may not exist yet, so create it if necessary.
+	if err := createDoltCfgDir(filesys); err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	return filesys.WriteFile(filepath.Join(replicationRunningStateDirectory, pendingCommitFilename), bytes, 0666)
+}
+
+// deletePendingCommit deletes the pending source transactions stored in the .doltcfg/binlog-pending-commit file at
+// the root of the provider's filesystem, if any.
+func deletePendingCommit(ctx *sql.Context) error {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	filesys := doltSession.Provider().FileSystem()
+
+	pendingFilepath := filepath.Join(replicationRunningStateDirectory, pendingCommitFilename)
+	if exists, _ := filesys.Exists(pendingFilepath); !exists {
+		return nil
+	}
+	return filesys.Delete(pendingFilepath, false)
+}
+
+// isEmpty returns whether no filters are configured in |p|.
+func (p persistedReplicationFilters) isEmpty() bool {
+	return len(p.DoTables) == 0 && len(p.IgnoreTables) == 0 && len(p.WildDoTables) == 0 &&
+		len(p.WildIgnoreTables) == 0 && len(p.RewriteDbs) == 0 && len(p.RowFilters) == 0
+}
+
 // createEmptyFile creates an empty file at |fullFilepath| if a file does not exist already. If a file does exist
 // at that path, no action is taken.
 func createEmptyFile(fullFilepath string) (err error) {
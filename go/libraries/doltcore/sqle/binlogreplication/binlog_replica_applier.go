@@ -40,6 +40,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
@@ -74,10 +75,24 @@ type binlogReplicaApplier struct {
 	format        *mysql.BinlogFormat
 	tableMapsById map[uint64]*mysql.TableMap
 
+	// rowFilters caches the row filter expressions resolved against the schemas of the tables, by qualified table name
+	rowFilters map[string]*resolvedRowFilter
+
 	dbsWithUncommittedChanges map[string]struct{}
 	replicationSourceUuid     string
 	handlerWg                 sync.WaitGroup
 	running                   atomic.Bool
+
+	// pendingCommit tracks the source transactions that haven't been recorded in a Dolt commit yet
+	pendingCommit pendingCommit
+	// currentSourceTime is the time the current source transaction was logged on the source
+	currentSourceTime time.Time
+	// lastSourceTime is the time the previous source transaction was logged on the source
+	lastSourceTime time.Time
+	// lastTaggedHour is the last hourly boundary of the source's binlog that was tagged
+	lastTaggedHour time.Time
+	// dbsCommittedSinceTag holds the databases with Dolt commits since the last hourly boundary was tagged
+	dbsCommittedSinceTag map[string]struct{}
 }
 
 func newBinlogReplicaApplier(filters *filterConfiguration) *binlogReplicaApplier {
@@ -294,6 +309,14 @@ func (a *binlogReplicaApplier) startReplicationEventStream(ctx *sql.Context, con
 
 	a.currentPosition = position
 
+	// Source transactions applied before the replica last stopped, but not recorded in a Dolt commit yet, are recorded
+	// in the next one
+	if a.pendingCommit.transactions == 0 {
+		if err = a.loadPendingTransactions(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Clear out the format description in case we're reconnecting, so that we don't use the old format description
 	// to interpret any event messages before we receive the new format description from the new stream.
 	a.format = nil
@@ -318,6 +341,18 @@ func (a *binlogReplicaApplier) replicaBinlogEventHandler(ctx *sql.Context) error
 
 	var eventProducer *binlogEventProducer
 
+	// Batched Dolt commits are created once their interval passes, and before the replication thread stops
+	commitTicker := time.NewTicker(time.Second)
+	defer commitTicker.Stop()
+	defer func() {
+		if err := sql.SessionCommandBegin(ctx.Session); err != nil {
+			ctx.GetLogger().Errorf("failed to begin session command: %v", err.Error())
+			return
+		}
+		defer sql.SessionCommandEnd(ctx.Session)
+		a.commitPendingTransactions(ctx, engine)
+	}()
+
 	// Process binlog events
 	for {
 		if eventProducer == nil {
@@ -369,6 +404,9 @@ func (a *binlogReplicaApplier) replicaBinlogEventHandler(ctx *sql.Context) error
 				DoltBinlogReplicaController.setIoError(mysql.ERUnknownError, err.Error())
 			}
 
+		case <-commitTicker.C:
+			a.commitPendingTransactionsIfDue(ctx, engine)
+
 		case <-a.stopReplicationChan:
 			ctx.GetLogger().Trace("received stop replication signal")
 			eventProducer.Stop()
@@ -462,7 +500,8 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			ctx.SetSessionVariable(ctx, "unique_checks", 1)
 		}
 
-		ctx.SetCurrentDatabase(query.Database)
+		// Database rewrite rules only apply to the default database of a statement, and not to qualified names in it
+		ctx.SetCurrentDatabase(a.filters.rewriteDatabase(query.Database))
 		executeQueryWithEngine(ctx, engine, query.SQL)
 		createCommit = !strings.EqualFold(query.SQL, "begin")
 
@@ -516,6 +555,8 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			"isBegin": isBegin,
 		}).Trace("Received binlog event: GTID")
 		a.currentGtid = gtid
+		a.currentSourceTime = time.Unix(int64(event.Timestamp()), 0).UTC()
+		a.startSourceTransaction(ctx, engine, a.currentSourceTime)
 		// if the source's UUID hasn't been set yet, set it and persist it
 		if a.replicationSourceUuid == "" {
 			uuid := fmt.Sprintf("%v", gtid.SourceServer())
@@ -563,6 +604,11 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 				ctx.GetLogger().Error(msg)
 				DoltBinlogReplicaController.setSqlError(mysql.ERUnknownError, msg)
 			}
+			if dbName := a.filters.rewriteDatabase(tableMap.Database); dbName != tableMap.Database {
+				rewritten := *tableMap
+				rewritten.Database = dbName
+				tableMap = &rewritten
+			}
 			a.tableMapsById[tableId] = tableMap
 		}
 
@@ -604,6 +650,12 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			return err
 		}
 
+		// The transaction is recorded as pending before its GTID is recorded as executed, so that it's still recorded
+		// in a Dolt commit if the replica stops before creating it
+		if err := a.addPendingTransaction(ctx, databasesToCommit); err != nil {
+			return err
+		}
+
 		// Record the last GTID processed after the commit
 		a.currentPosition.GTIDSet = a.currentPosition.GTIDSet.AddGTID(a.currentGtid)
 		err := sql.SystemVariables.AssignValues(map[string]interface{}{"gtid_executed": a.currentPosition.GTIDSet.String()})
@@ -615,18 +667,16 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			return fmt.Errorf("unable to store GTID executed metadata to disk: %s", err.Error())
 		}
 
-		// For now, create a Dolt commit from every data update. Eventually, we'll want to make this configurable.
-		// We commit to every database that we saw had a dirty session – these identify the databases where we have
-		// run DML commands through the engine. We also commit to every database that was modified through a RowEvent,
-		// which is all tracked through the applier's databasesWithUncommitedChanges property – these don't show up
-		// as dirty in our session, since we used TableWriter to update them.
-		a.addDatabasesWithUncommittedChanges(databasesToCommit...)
-		for _, database := range a.databasesWithUncommittedChanges() {
-			executeQueryWithEngine(ctx, engine, "use `"+database+"`;")
-			executeQueryWithEngine(ctx, engine,
-				fmt.Sprintf("call dolt_commit('-Am', 'Dolt binlog replica commit: GTID %s');", a.currentGtid))
+		// Depending on the commit policy, the changes are recorded in a Dolt commit now, or are batched with the
+		// changes of the next source transactions. Batches are only used by the replication thread, which commits
+		// them once the batch interval passes, even if the source doesn't send any more transactions.
+		policy := loadCommitPolicy(ctx)
+		if !a.IsRunning() {
+			policy.batch = false
+		}
+		if a.pendingCommit.isDue(policy) {
+			a.commitPendingTransactions(ctx, engine)
 		}
-		a.dbsWithUncommittedChanges = nil
 	}
 
 	return nil
@@ -721,6 +771,11 @@ func (a *binlogReplicaApplier) processRowEvent(ctx *sql.Context, event mysql.Bin
 		ctx.GetLogger().Tracef(" - Inserted Rows (table: %s)", tableMap.Name)
 	}
 
+	rowFilter, err := a.getRowFilter(ctx, engine, tableMap.Database, tableName, schema)
+	if err != nil {
+		return err
+	}
+
 	writeSession, tableWriter, err := getTableWriter(ctx, engine, tableName, tableMap.Database, foreignKeyChecksDisabled)
 	if err != nil {
 		return err
@@ -744,13 +799,35 @@ func (a *binlogReplicaApplier) processRowEvent(ctx *sql.Context, event mysql.Bin
 			ctx.GetLogger().Tracef("     - Data: %v ", sql.FormatRow(dataRow))
 		}
 
+		// With a row filter, only the rows matching it are replicated. An update that moves a row into or out of
+		// the filter is applied as an insert or a delete of the row.
+		oldRowMatches, newRowMatches := true, true
+		if rowFilter != nil {
+			if identityRow != nil {
+				if oldRowMatches, err = rowFilter.matches(ctx, identityRow); err != nil {
+					return err
+				}
+			}
+			if dataRow != nil {
+				if newRowMatches, err = rowFilter.matches(ctx, dataRow); err != nil {
+					return err
+				}
+			}
+		}
+
 		switch {
-		case event.IsDeleteRows():
+		case event.IsDeleteRows() && oldRowMatches:
 			err = tableWriter.Delete(ctx, identityRow)
-		case event.IsWriteRows():
+		case event.IsWriteRows() && newRowMatches:
 			err = tableWriter.Insert(ctx, dataRow)
-		case event.IsUpdateRows():
+		case event.IsUpdateRows() && oldRowMatches && newRowMatches:
 			err = tableWriter.Update(ctx, identityRow, dataRow)
+		case event.IsUpdateRows() && oldRowMatches:
+			err = tableWriter.Delete(ctx, identityRow)
+		case event.IsUpdateRows() && newRowMatches:
+			err = tableWriter.Insert(ctx, dataRow)
+		default:
+			ctx.GetLogger().Tracef("     - skipping row (doesn't match row filter of table %s)", tableMap.Name)
 		}
 		if err != nil {
 			return err
@@ -766,10 +843,93 @@ func (a *binlogReplicaApplier) processRowEvent(ctx *sql.Context, event mysql.Bin
 	return nil
 }
 
+// resolvedRowFilter is a row filter expression resolved against the schema of a table.
+type resolvedRowFilter struct {
+	expr   string
+	sch    sql.Schema
+	filter sql.Expression
+}
+
+// matches returns whether |row| matches this row filter.
+func (f *resolvedRowFilter) matches(ctx *sql.Context, row sql.Row) (bool, error) {
+	res, err := f.filter.Eval(ctx, row)
+	if err != nil {
+		return false, err
+	}
+	return sql.IsTrue(res), nil
+}
+
+// getRowFilter returns the row filter of the table |tableName| in the database |databaseName|, resolved against the
+// table's current schema |sch|, or nil if the table doesn't have a row filter. Resolved row filters are cached until
+// the filter or the schema of the table changes.
+func (a *binlogReplicaApplier) getRowFilter(ctx *sql.Context, engine *gms.Engine, databaseName, tableName string, sch sql.Schema) (*resolvedRowFilter, error) {
+	expr := a.filters.getRowFilter(databaseName, tableName)
+	if expr == "" {
+		return nil, nil
+	}
+
+	key := strings.ToLower(databaseName + "." + tableName)
+	if cached, ok := a.rowFilters[key]; ok && cached.expr == expr && cached.sch.Equals(sch) {
+		return cached, nil
+	}
+
+	filter, ok, err := resolveRowFilter(ctx, engine, databaseName, tableName, expr)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("unable to find table %q", tableName)
+	}
+	if a.rowFilters == nil {
+		a.rowFilters = make(map[string]*resolvedRowFilter)
+	}
+	resolved := &resolvedRowFilter{expr: expr, sch: sch, filter: filter}
+	a.rowFilters[key] = resolved
+	return resolved, nil
+}
+
 //
 // Helper functions
 //
 
+// resolveRowFilter returns a sql.Expression for the row filter |expr| of the table |tableName| in the database
+// |databaseName|, which is evaluated against rows containing every column of the table. The returned boolean is false
+// if the table doesn't exist.
+func resolveRowFilter(ctx *sql.Context, engine *gms.Engine, databaseName, tableName, expr string) (sql.Expression, bool, error) {
+	database, err := engine.Analyzer.Catalog.Database(ctx, databaseName)
+	if err != nil {
+		return nil, false, err
+	}
+	if privDatabase, ok := database.(mysql_db.PrivilegedDatabase); ok {
+		database = privDatabase.Unwrap()
+	}
+	sqlDatabase, ok := database.(sqle.Database)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected database type: %T", database)
+	}
+
+	root, err := sqlDatabase.GetRoot(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	table, name, ok, err := doltdb.GetTableInsensitive(ctx, root, doltdb.TableName{Name: tableName})
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	filter, err := expranalysis.ResolveTableExpression(ctx, name, sch, expr)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to resolve the row filter of table %s.%s: %w", databaseName, name, err)
+	}
+	return filter, true, nil
+}
+
 // closeWriteSession flushes and closes the specified |writeSession| and returns an error if anything failed.
 func closeWriteSession(ctx *sql.Context, engine *gms.Engine, databaseName string, writeSession dsess.WriteSession) error {
 	newWorkingSet, err := writeSession.Flush(ctx)
@@ -0,0 +1,279 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const (
+	// commitPolicyTransaction creates a Dolt commit for every source transaction.
+	commitPolicyTransaction = "transaction"
+	// commitPolicyBatch creates a Dolt commit for a batch of source transactions, bounded by the
+	// @@dolt_binlog_replica_commit_interval_secs and @@dolt_binlog_replica_commit_transactions system variables.
+	commitPolicyBatch = "batch"
+)
+
+// hourlyTagFormat is the time layout of the names of the tags created at hourly boundaries of the source's binlog.
+const hourlyTagFormat = "binlog-2006-01-02T15"
+
+// commitPolicy controls how the changes applied by the replica are recorded in Dolt commits.
+type commitPolicy struct {
+	// batch is true if a Dolt commit records the changes of several source transactions.
+	batch bool
+	// interval is the longest time the changes of a batch wait for a Dolt commit, or zero for no bound.
+	interval time.Duration
+	// transactions is the largest number of source transactions in a batch, or zero for no bound.
+	transactions int
+	// tagHourly is true if the replica's commits are tagged at every hourly boundary of the source's binlog.
+	tagHourly bool
+}
+
+// loadCommitPolicy returns the commit policy configured by the @@dolt_binlog_replica_commit_* system variables. A
+// batched commit policy without any bound creates a Dolt commit for every source transaction.
+func loadCommitPolicy(ctx *sql.Context) commitPolicy {
+	var policy commitPolicy
+	if _, value, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaCommitPolicy); ok {
+		switch name := strings.ToLower(fmt.Sprintf("%v", value)); name {
+		case commitPolicyBatch:
+			policy.batch = true
+		case commitPolicyTransaction, "":
+		default:
+			ctx.GetLogger().Warnf("unknown binlog replica commit policy '%s'; creating a Dolt commit per transaction", name)
+		}
+	}
+	if _, value, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaCommitIntervalSecs); ok {
+		if secs, _, err := types.Int64.Convert(ctx, value); err == nil {
+			policy.interval = time.Duration(secs.(int64)) * time.Second
+		}
+	}
+	if _, value, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaCommitTransactions); ok {
+		if transactions, _, err := types.Int64.Convert(ctx, value); err == nil {
+			policy.transactions = int(transactions.(int64))
+		}
+	}
+	if _, value, ok := sql.SystemVariables.GetGlobal(dsess.BinlogReplicaTagHourly); ok {
+		policy.tagHourly = value == dsess.SysVarTrue
+	}
+	if policy.interval <= 0 && policy.transactions <= 0 {
+		policy.batch = false
+	}
+	return policy
+}
+
+// pendingCommit tracks the source transactions that have been applied to the working sets of the replica's databases,
+// but that haven't been recorded in a Dolt commit yet.
+type pendingCommit struct {
+	// transactions is the number of source transactions in the pending commit
+	transactions int
+	firstGtid    mysql.GTID
+	lastGtid     mysql.GTID
+	// sourceTime is the time the last source transaction was logged on the source
+	sourceTime time.Time
+	// startTime is the time the first source transaction was applied on the replica
+	startTime time.Time
+}
+
+// add records that the source transaction |gtid|, logged on the source at |sourceTime|, has been applied. Several
+// statements of a transaction may be applied separately, so |gtid| may already be recorded.
+func (p *pendingCommit) add(gtid mysql.GTID, sourceTime time.Time) {
+	if sourceTime.IsZero() {
+		sourceTime = time.Now().UTC()
+	}
+	if p.transactions == 0 {
+		p.firstGtid = gtid
+		p.startTime = time.Now()
+	}
+	if p.transactions == 0 || p.lastGtid != gtid {
+		p.transactions++
+	}
+	p.lastGtid = gtid
+	p.sourceTime = sourceTime
+}
+
+// isDue returns whether the pending transactions should be recorded in a Dolt commit under |policy|.
+func (p *pendingCommit) isDue(policy commitPolicy) bool {
+	switch {
+	case p.transactions == 0:
+		return false
+	case !policy.batch:
+		return true
+	case policy.transactions > 0 && p.transactions >= policy.transactions:
+		return true
+	case policy.interval > 0 && time.Since(p.startTime) >= policy.interval:
+		return true
+	default:
+		return false
+	}
+}
+
+// persisted returns the representation of the pending transactions, with the |databases| they changed, stored on disk.
+func (p *pendingCommit) persisted(databases []string) persistedPendingCommit {
+	return persistedPendingCommit{
+		Transactions: p.transactions,
+		FirstGtid:    mysql.EncodeGTID(p.firstGtid),
+		LastGtid:     mysql.EncodeGTID(p.lastGtid),
+		SourceTime:   p.sourceTime,
+		StartTime:    p.startTime,
+		Databases:    databases,
+	}
+}
+
+// load replaces the pending transactions with the |persisted| ones.
+func (p *pendingCommit) load(persisted persistedPendingCommit) error {
+	firstGtid, err := mysql.DecodeGTID(persisted.FirstGtid)
+	if err != nil {
+		return err
+	}
+	lastGtid, err := mysql.DecodeGTID(persisted.LastGtid)
+	if err != nil {
+		return err
+	}
+	*p = pendingCommit{
+		transactions: persisted.Transactions,
+		firstGtid:    firstGtid,
+		lastGtid:     lastGtid,
+		sourceTime:   persisted.SourceTime,
+		startTime:    persisted.StartTime,
+	}
+	return nil
+}
+
+// message returns the message of the Dolt commit recording the pending transactions. The message ends with the
+// GTIDs of the transactions and the time the last one was logged on the source, so that the commits of a source
+// transaction can be found by querying dolt_log. The commit's date is also set to that time.
+func (p *pendingCommit) message() string {
+	sb := strings.Builder{}
+	if p.transactions == 1 {
+		fmt.Fprintf(&sb, "Dolt binlog replica commit: GTID %s\n\n", p.lastGtid)
+	} else {
+		fmt.Fprintf(&sb, "Dolt binlog replica commit: %d transactions\n\n", p.transactions)
+		fmt.Fprintf(&sb, "Source-First-GTID: %s\n", p.firstGtid)
+	}
+	fmt.Fprintf(&sb, "Source-GTID: %s\n", p.lastGtid)
+	fmt.Fprintf(&sb, "Source-Timestamp: %s", p.sourceTime.Format(time.RFC3339))
+	return sb.String()
+}
+
+// commitPendingTransactions creates a Dolt commit in every database with changes applied from the pending source
+// transactions.
+func (a *binlogReplicaApplier) commitPendingTransactions(ctx *sql.Context, engine *gms.Engine) {
+	if a.pendingCommit.transactions == 0 {
+		return
+	}
+
+	message := strings.ReplaceAll(a.pendingCommit.message(), "'", "''")
+	date := a.pendingCommit.sourceTime.Format(time.RFC3339)
+	// We commit to every database that we saw had a dirty session – these identify the databases where we have
+	// run DML commands through the engine. We also commit to every database that was modified through a RowEvent,
+	// which is all tracked through the applier's databasesWithUncommitedChanges property – these don't show up
+	// as dirty in our session, since we used TableWriter to update them.
+	for _, database := range a.databasesWithUncommittedChanges() {
+		executeQueryWithEngine(ctx, engine, "use `"+database+"`;")
+		executeQueryWithEngine(ctx, engine,
+			fmt.Sprintf("call dolt_commit('-Am', '%s', '--date', '%s');", message, date))
+		if a.dbsCommittedSinceTag == nil {
+			a.dbsCommittedSinceTag = make(map[string]struct{})
+		}
+		a.dbsCommittedSinceTag[database] = struct{}{}
+	}
+	a.dbsWithUncommittedChanges = nil
+	a.pendingCommit = pendingCommit{}
+	if err := deletePendingCommit(ctx); err != nil {
+		ctx.GetLogger().Errorf("unable to delete pending binlog transactions from disk: %s", err.Error())
+	}
+}
+
+// addPendingTransaction records that the current source transaction, which changed the |databases|, has been applied,
+// and saves the pending transactions to disk, so that they are still recorded in a Dolt commit if the replica stops
+// before creating it.
+func (a *binlogReplicaApplier) addPendingTransaction(ctx *sql.Context, databases []string) error {
+	a.addDatabasesWithUncommittedChanges(databases...)
+	a.pendingCommit.add(a.currentGtid, a.currentSourceTime)
+	if err := persistPendingCommit(ctx, a.pendingCommit.persisted(a.databasesWithUncommittedChanges())); err != nil {
+		return fmt.Errorf("unable to store pending binlog transactions to disk: %s", err.Error())
+	}
+	return nil
+}
+
+// loadPendingTransactions restores the source transactions that were applied, but not recorded in a Dolt commit, the
+// last time the replica ran, so that the next Dolt commit records them.
+func (a *binlogReplicaApplier) loadPendingTransactions(ctx *sql.Context) error {
+	persisted, err := loadPendingCommit(ctx)
+	if err != nil || persisted == nil {
+		return err
+	}
+	if err = a.pendingCommit.load(*persisted); err != nil {
+		return fmt.Errorf("unable to load pending binlog transactions: %s", err.Error())
+	}
+	a.addDatabasesWithUncommittedChanges(persisted.Databases...)
+	return nil
+}
+
+// commitPendingTransactionsIfDue records the pending source transactions in Dolt commits if the commit policy's
+// batch interval has passed. This is called periodically, so that the changes of a batch are committed even when
+// the source stops sending transactions.
+func (a *binlogReplicaApplier) commitPendingTransactionsIfDue(ctx *sql.Context, engine *gms.Engine) {
+	if !a.pendingCommit.isDue(loadCommitPolicy(ctx)) {
+		return
+	}
+	if err := sql.SessionCommandBegin(ctx.Session); err != nil {
+		ctx.GetLogger().Errorf("failed to begin session command: %v", err.Error())
+		return
+	}
+	defer sql.SessionCommandEnd(ctx.Session)
+	a.commitPendingTransactions(ctx, engine)
+}
+
+// startSourceTransaction is called when the source transaction logged on the source at |sourceTime| starts. If
+// hourly tags are enabled and the transaction is the first one of a new hour of the source's binlog, the pending
+// transactions of the previous hour are committed, and the commits of every database changed since the previous
+// boundary are tagged with the hour, so that the tag records the state of the database at that hour.
+func (a *binlogReplicaApplier) startSourceTransaction(ctx *sql.Context, engine *gms.Engine, sourceTime time.Time) {
+	lastSourceTime := a.lastSourceTime
+	a.lastSourceTime = sourceTime
+	if lastSourceTime.IsZero() || !loadCommitPolicy(ctx).tagHourly {
+		return
+	}
+	// The source's clock may go back, so an hour is only tagged once
+	hour := sourceTime.Truncate(time.Hour)
+	if !hour.After(lastSourceTime) || !hour.After(a.lastTaggedHour) {
+		return
+	}
+	a.lastTaggedHour = hour
+
+	a.commitPendingTransactions(ctx, engine)
+	tagName := hour.Format(hourlyTagFormat)
+	for database := range a.dbsCommittedSinceTag {
+		ctx.GetLogger().WithFields(logrus.Fields{
+			"database": database,
+			"tag":      tagName,
+		}).Debug("tagging binlog replica commit at hourly boundary")
+		executeQueryWithEngine(ctx, engine, "use `"+database+"`;")
+		executeQueryWithEngine(ctx, engine, fmt.Sprintf("call dolt_tag('-m', 'Binlog replica state at %s', '%s', 'HEAD');",
+			hour.Format(time.RFC3339), tagName))
+	}
+	a.dbsCommittedSinceTag = nil
+}
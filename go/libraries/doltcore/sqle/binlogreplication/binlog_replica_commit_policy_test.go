@@ -0,0 +1,86 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingCommit(t *testing.T) {
+	sid, err := mysql.ParseSID("3e11fa47-71ca-11e1-9e33-c80aa9429562")
+	require.NoError(t, err)
+	gtid := func(sequence int64) mysql.GTID {
+		return mysql.Mysql56GTID{Server: sid, Sequence: sequence}
+	}
+	sourceTime := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+
+	t.Run("per transaction", func(t *testing.T) {
+		var p pendingCommit
+		require.False(t, p.isDue(commitPolicy{}))
+
+		p.add(gtid(1), sourceTime)
+		// Statements of the same transaction are applied separately
+		p.add(gtid(1), sourceTime)
+		require.Equal(t, 1, p.transactions)
+		require.True(t, p.isDue(commitPolicy{}))
+		require.Equal(t, "Dolt binlog replica commit: GTID 3e11fa47-71ca-11e1-9e33-c80aa9429562:1\n\n"+
+			"Source-GTID: 3e11fa47-71ca-11e1-9e33-c80aa9429562:1\n"+
+			"Source-Timestamp: 2025-03-14T09:26:53Z", p.message())
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		policy := commitPolicy{batch: true, transactions: 3}
+		var p pendingCommit
+		p.add(gtid(1), sourceTime)
+		p.add(gtid(2), sourceTime)
+		require.False(t, p.isDue(policy))
+		p.add(gtid(3), sourceTime.Add(time.Second))
+		require.True(t, p.isDue(policy))
+		require.Equal(t, "Dolt binlog replica commit: 3 transactions\n\n"+
+			"Source-First-GTID: 3e11fa47-71ca-11e1-9e33-c80aa9429562:1\n"+
+			"Source-GTID: 3e11fa47-71ca-11e1-9e33-c80aa9429562:3\n"+
+			"Source-Timestamp: 2025-03-14T09:26:54Z", p.message())
+
+		policy = commitPolicy{batch: true, interval: time.Minute}
+		require.False(t, p.isDue(policy))
+		p.startTime = p.startTime.Add(-time.Minute)
+		require.True(t, p.isDue(policy))
+	})
+
+	t.Run("persisted", func(t *testing.T) {
+		var p pendingCommit
+		p.add(gtid(1), sourceTime)
+		p.add(gtid(2), sourceTime.Add(time.Second))
+		bytes, err := json.Marshal(p.persisted([]string{"db01"}))
+		require.NoError(t, err)
+
+		var persisted persistedPendingCommit
+		require.NoError(t, json.Unmarshal(bytes, &persisted))
+		require.Equal(t, []string{"db01"}, persisted.Databases)
+		var loaded pendingCommit
+		require.NoError(t, loaded.load(persisted))
+		require.Equal(t, p.message(), loaded.message())
+		require.True(t, p.startTime.Equal(loaded.startTime))
+
+		// The transaction that was being applied when the replica stopped is sent again by the source
+		loaded.add(gtid(2), sourceTime.Add(time.Second))
+		require.Equal(t, 2, loaded.transactions)
+	})
+}
@@ -278,7 +278,7 @@ func (d *doltBinlogReplicaController) SetReplicationSourceOptions(ctx *sql.Conte
 }
 
 // SetReplicationFilterOptions implements the BinlogReplicaController interface.
-func (d *doltBinlogReplicaController) SetReplicationFilterOptions(_ *sql.Context, options []binlogreplication.ReplicationOption) error {
+func (d *doltBinlogReplicaController) SetReplicationFilterOptions(ctx *sql.Context, options []binlogreplication.ReplicationOption) error {
 	for _, option := range options {
 		switch strings.ToUpper(option.Name) {
 		case "REPLICATE_DO_TABLE":
@@ -304,15 +304,74 @@ func (d *doltBinlogReplicaController) SetReplicationFilterOptions(_ *sql.Context
 		}
 	}
 
-	// TODO: Consider persisting filter settings. MySQL doesn't actually do this... unlike CHANGE REPLICATION SOURCE,
-	//       CHANGE REPLICATION FILTER requires users to re-apply the filter options every time a server is restarted,
-	//       or to pass them to mysqld on the command line or in configuration. Since we don't want to force users
-	//       to specify these on the command line, we should consider diverging from MySQL behavior here slightly and
-	//       persisting the filter configuration options if customers want this.
+	// Unlike CHANGE REPLICATION SOURCE, MySQL requires CHANGE REPLICATION FILTER options to be re-applied every
+	// time a server is restarted, or to be passed to mysqld on the command line or in configuration. Since we don't
+	// want to force users to specify these on the command line, Dolt diverges from MySQL here and persists them.
+	return persistReplicationFilters(ctx, d.filters)
+}
+
+// setReplicationFilter sets the Dolt-specific replication filter |option|, which CHANGE REPLICATION FILTER doesn't
+// support, to |values| and persists the updated filter configuration. See dolt_replication_filter for the options
+// and their values.
+func (d *doltBinlogReplicaController) setReplicationFilter(ctx *sql.Context, option string, values []string) error {
+	var err error
+	switch strings.ToUpper(option) {
+	case "REPLICATE_WILD_DO_TABLE":
+		err = d.filters.setWildDoTables(values)
+	case "REPLICATE_WILD_IGNORE_TABLE":
+		err = d.filters.setWildIgnoreTables(values)
+	case "REPLICATE_REWRITE_DB":
+		err = d.filters.setRewriteDbs(values)
+	case "REPLICATE_ROW_FILTER":
+		err = d.setRowFilter(ctx, values)
+	default:
+		err = fmt.Errorf("unsupported replication filter option: %s", option)
+	}
+	if err != nil {
+		return err
+	}
+
+	return persistReplicationFilters(ctx, d.filters)
+}
+
+// setRowFilter sets the row filter of the table named by the first of |values|, qualified with the name of the
+// replica database, to the expression in the second of |values|, or removes it if there is no expression. When
+// |values| is empty, the row filters of all tables are removed.
+func (d *doltBinlogReplicaController) setRowFilter(ctx *sql.Context, values []string) error {
+	if len(values) == 0 {
+		d.filters.clearRowFilters()
+		return nil
+	} else if len(values) > 2 {
+		return fmt.Errorf("REPLICATE_ROW_FILTER takes a table name and an expression")
+	}
 
+	dbName, tableName, ok := strings.Cut(values[0], ".")
+	if !ok || dbName == "" || tableName == "" {
+		return fmt.Errorf("no database specified for table '%s'; "+
+			"all filter table names must be qualified with a database name", values[0])
+	}
+	expr := ""
+	if len(values) == 2 {
+		expr = strings.TrimSpace(values[1])
+	}
+
+	// Verify the expression now if the table already exists, instead of failing replication when it's applied
+	if expr != "" && d.engine != nil && d.engine.Analyzer.Catalog.HasDatabase(ctx, dbName) {
+		if _, _, err := resolveRowFilter(ctx, d.engine, dbName, tableName, expr); err != nil {
+			return err
+		}
+	}
+
+	d.filters.setRowFilter(dbName, tableName, expr)
 	return nil
 }
 
+// LoadReplicationFilters loads the replication filters persisted on disk, so that they are applied as soon as
+// replication is started. This method should only be called during the server startup process.
+func (d *doltBinlogReplicaController) LoadReplicationFilters(ctx *sql.Context) error {
+	return loadReplicationFilters(ctx, d.filters)
+}
+
 // GetReplicaStatus implements the BinlogReplicaController interface
 func (d *doltBinlogReplicaController) GetReplicaStatus(ctx *sql.Context) (*binlogreplication.ReplicaStatus, error) {
 	replicaSourceInfo, err := loadReplicationConfiguration(ctx, d.engine.Analyzer.Catalog.MySQLDb)
@@ -372,7 +431,11 @@ func (d *doltBinlogReplicaController) ResetReplica(ctx *sql.Context, resetAll bo
 			return err
 		}
 
-		d.filters = newFilterConfiguration()
+		// The applier shares the filter configuration, so it's reset in place
+		d.filters.reset()
+		if err = deleteReplicationFilters(ctx); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,80 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+// DoltReplicationFilterProcedure is the dolt_replication_filter() stored procedure, which sets the replication filters
+// of a binlog replica that CHANGE REPLICATION FILTER can't express. The first argument names the filter option and the
+// remaining arguments are its values, which replace any previously set values of the option:
+//
+//	CALL dolt_replication_filter('REPLICATE_REWRITE_DB', 'from_db->to_db', ...);
+//	CALL dolt_replication_filter('REPLICATE_WILD_DO_TABLE', 'db_pattern.table_pattern', ...);
+//	CALL dolt_replication_filter('REPLICATE_WILD_IGNORE_TABLE', 'db_pattern.table_pattern', ...);
+//	CALL dolt_replication_filter('REPLICATE_ROW_FILTER', 'db.table', 'expression');
+//
+// REPLICATE_ROW_FILTER sets the row filter of a single table, which is removed when no expression is given, and
+// removes the row filters of all tables when no table is given. Calling the procedure without arguments leaves the
+// filters unchanged. Every call returns all the replication filters in effect, including those set with
+// CHANGE REPLICATION FILTER.
+var DoltReplicationFilterProcedure = sql.ExternalStoredProcedureDetails{
+	Name:      "dolt_replication_filter",
+	Schema:    replicationFilterSchema,
+	Function:  doltReplicationFilter,
+	ReadOnly:  true,
+	AdminOnly: true,
+}
+
+var replicationFilterSchema = sql.Schema{
+	&sql.Column{Name: "option", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "value", Type: types.LongText, Nullable: false},
+}
+
+func doltReplicationFilter(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	controller := DoltBinlogReplicaController
+	if len(args) > 0 {
+		controller.operationMutex.Lock()
+		err := controller.setReplicationFilter(ctx, args[0], args[1:])
+		controller.operationMutex.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filters := controller.filters
+	var rows []sql.Row
+	addRows := func(option string, values []string) {
+		slices.Sort(values)
+		for _, value := range values {
+			rows = append(rows, sql.Row{option, value})
+		}
+	}
+	addRows("REPLICATE_DO_TABLE", filters.getDoTables())
+	addRows("REPLICATE_IGNORE_TABLE", filters.getIgnoreTables())
+	addRows("REPLICATE_WILD_DO_TABLE", filters.getWildDoTables())
+	addRows("REPLICATE_WILD_IGNORE_TABLE", filters.getWildIgnoreTables())
+	addRows("REPLICATE_REWRITE_DB", filters.getRewriteDbs())
+	rowFilters := filters.getRowFilters()
+	for _, tableName := range slices.Sorted(maps.Keys(rowFilters)) {
+		rows = append(rows, sql.Row{"REPLICATE_ROW_FILTER", tableName + ": " + rowFilters[tableName]})
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -16,6 +16,8 @@ package binlogreplication
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
 
@@ -29,6 +31,15 @@ type filterConfiguration struct {
 	doTables map[string]map[string]struct{}
 	// ignoreTables holds a map of database name to map of table names, indicating tables that should NOT be replicated.
 	ignoreTables map[string]map[string]struct{}
+	// wildDoTables holds "db.table" patterns, in the syntax of LIKE, of tables that SHOULD be replicated.
+	wildDoTables []string
+	// wildIgnoreTables holds "db.table" patterns, in the syntax of LIKE, of tables that should NOT be replicated.
+	wildIgnoreTables []string
+	// rewriteDbs holds a map of source database name to the name of the replica database its changes are applied to.
+	rewriteDbs map[string]string
+	// rowFilters holds a map of database name to map of table name to an expression over the columns of the table.
+	// Only rows matching the expression are replicated.
+	rowFilters map[string]map[string]string
 	// mu guards against concurrent access to the filter configuration data.
 	mu *sync.Mutex
 }
@@ -38,10 +49,24 @@ func newFilterConfiguration() *filterConfiguration {
 	return &filterConfiguration{
 		doTables:     make(map[string]map[string]struct{}),
 		ignoreTables: make(map[string]map[string]struct{}),
+		rewriteDbs:   make(map[string]string),
+		rowFilters:   make(map[string]map[string]string),
 		mu:           &sync.Mutex{},
 	}
 }
 
+// reset clears out all filters.
+func (fc *filterConfiguration) reset() {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.doTables = make(map[string]map[string]struct{})
+	fc.ignoreTables = make(map[string]map[string]struct{})
+	fc.wildDoTables = nil
+	fc.wildIgnoreTables = nil
+	fc.rewriteDbs = make(map[string]string)
+	fc.rowFilters = make(map[string]map[string]string)
+}
+
 // setDoTables sets the tables that are allowed to replicate and returns an error if any problems were
 // encountered, such as unqualified tables being specified in |urts|. If any DoTables were previously configured,
 // they are cleared out before the new tables are set as the value of DoTables.
@@ -96,6 +121,115 @@ func (fc *filterConfiguration) setIgnoreTables(urts []sql.UnresolvedTable) error
 	return nil
 }
 
+// setWildDoTables sets the "db.table" |patterns| of the tables that are allowed to replicate and returns an error if
+// any pattern is not qualified with a database pattern. Patterns use the syntax of LIKE, so "%" matches any sequence
+// of characters and "_" matches any single character. If any WildDoTables were previously configured, they are
+// cleared out before the new patterns are set.
+func (fc *filterConfiguration) setWildDoTables(patterns []string) error {
+	patterns, err := normalizeWildTablePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.wildDoTables = patterns
+	return nil
+}
+
+// setWildIgnoreTables sets the "db.table" |patterns| of the tables that are NOT allowed to replicate and returns an
+// error if any pattern is not qualified with a database pattern. If any WildIgnoreTables were previously configured,
+// they are cleared out before the new patterns are set.
+func (fc *filterConfiguration) setWildIgnoreTables(patterns []string) error {
+	patterns, err := normalizeWildTablePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.wildIgnoreTables = patterns
+	return nil
+}
+
+// setRewriteDbs sets the database rewrite |rules|, each in the form "from_db->to_db", and returns an error if any rule
+// is malformed. Changes to the source database from_db are applied to the replica database to_db. If any RewriteDbs
+// were previously configured, they are cleared out before the new rules are set.
+func (fc *filterConfiguration) setRewriteDbs(rules []string) error {
+	rewriteDbs := make(map[string]string, len(rules))
+	for _, rule := range rules {
+		from, to, ok := strings.Cut(rule, "->")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return fmt.Errorf("invalid database rewrite rule '%s'; rules must be in the form 'from_db->to_db'", rule)
+		}
+		if _, ok := rewriteDbs[strings.ToLower(from)]; ok {
+			return fmt.Errorf("database '%s' is rewritten more than once", from)
+		}
+		rewriteDbs[strings.ToLower(from)] = to
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.rewriteDbs = rewriteDbs
+	return nil
+}
+
+// setRowFilter sets the row filter of the table |tableName| in the database |dbName| to |expr|, an expression over
+// the columns of the table. Only inserted, updated and deleted rows matching |expr| are replicated. An empty |expr|
+// removes the row filter of the table.
+func (fc *filterConfiguration) setRowFilter(dbName, tableName, expr string) {
+	db, table := strings.ToLower(dbName), strings.ToLower(tableName)
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if expr == "" {
+		delete(fc.rowFilters[db], table)
+		if len(fc.rowFilters[db]) == 0 {
+			delete(fc.rowFilters, db)
+		}
+		return
+	}
+	if fc.rowFilters[db] == nil {
+		fc.rowFilters[db] = make(map[string]string)
+	}
+	fc.rowFilters[db][table] = expr
+}
+
+// clearRowFilters removes the row filters of all tables.
+func (fc *filterConfiguration) clearRowFilters() {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.rowFilters = make(map[string]map[string]string)
+}
+
+// rewriteDatabase returns the name of the replica database that changes to the source database |dbName| are applied
+// to, which is |dbName| itself unless a database rewrite rule matches it.
+func (fc *filterConfiguration) rewriteDatabase(dbName string) string {
+	if fc == nil {
+		return dbName
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if to, ok := fc.rewriteDbs[strings.ToLower(dbName)]; ok {
+		return to
+	}
+	return dbName
+}
+
+// getRowFilter returns the row filter expression of the table |tableName| in the replica database |dbName|, or an
+// empty string if the table doesn't have one.
+func (fc *filterConfiguration) getRowFilter(dbName, tableName string) string {
+	if fc == nil {
+		return ""
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.rowFilters[strings.ToLower(dbName)][strings.ToLower(tableName)]
+}
+
 // isTableFilteredOut returns true if the table identified by |tableMap| has been filtered out on this replica and
 // should not have any updates applied from binlog messages.
 func (fc *filterConfiguration) isTableFilteredOut(ctx *sql.Context, tableMap *mysql.TableMap) bool {
@@ -132,6 +266,29 @@ func (fc *filterConfiguration) isTableFilteredOut(ctx *sql.Context, tableMap *my
 		}
 	}
 
+	// Wildcard options are processed AFTER the doTables and ignoreTables options, and only for the tables that
+	// aren't listed in doTables. Like doTables options, if any wildDoTables options are specified, a table MUST
+	// match one of them to be replicated.
+	if _, ok := fc.doTables[db][table]; ok {
+		return false
+	}
+	qualifiedName := db + "." + table
+	for _, pattern := range fc.wildIgnoreTables {
+		if matchesWildPattern(pattern, qualifiedName) {
+			ctx.GetLogger().Tracef("skipping table %s.%s (matches wildIgnoreTables pattern %s)", tableMap.Database, tableMap.Name, pattern)
+			return true
+		}
+	}
+	if len(fc.wildDoTables) > 0 {
+		for _, pattern := range fc.wildDoTables {
+			if matchesWildPattern(pattern, qualifiedName) {
+				return false
+			}
+		}
+		ctx.GetLogger().Tracef("skipping table %s.%s (no match in wildDoTables)", tableMap.Database, tableMap.Name)
+		return true
+	}
+
 	return false
 }
 
@@ -149,6 +306,99 @@ func (fc *filterConfiguration) getIgnoreTables() []string {
 	return convertFilterMapToStringSlice(fc.ignoreTables)
 }
 
+// getWildDoTables returns the "db.table" patterns of the tables that are configured to be replicated.
+func (fc *filterConfiguration) getWildDoTables() []string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return slices.Clone(fc.wildDoTables)
+}
+
+// getWildIgnoreTables returns the "db.table" patterns of the tables that are configured to be filtered out of
+// replication.
+func (fc *filterConfiguration) getWildIgnoreTables() []string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return slices.Clone(fc.wildIgnoreTables)
+}
+
+// getRewriteDbs returns the database rewrite rules, each in the form "from_db->to_db".
+func (fc *filterConfiguration) getRewriteDbs() []string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	rules := make([]string, 0, len(fc.rewriteDbs))
+	for from, to := range fc.rewriteDbs {
+		rules = append(rules, fmt.Sprintf("%s->%s", from, to))
+	}
+	return rules
+}
+
+// getRowFilters returns a map of qualified table names to the row filter expressions of the tables.
+func (fc *filterConfiguration) getRowFilters() map[string]string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	rowFilters := make(map[string]string)
+	for dbName, tableMap := range fc.rowFilters {
+		for tableName, expr := range tableMap {
+			rowFilters[fmt.Sprintf("%s.%s", dbName, tableName)] = expr
+		}
+	}
+	return rowFilters
+}
+
+// persisted returns the representation of this filter configuration that is stored on disk.
+func (fc *filterConfiguration) persisted() persistedReplicationFilters {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	p := persistedReplicationFilters{
+		DoTables:         make(map[string][]string, len(fc.doTables)),
+		IgnoreTables:     make(map[string][]string, len(fc.ignoreTables)),
+		WildDoTables:     slices.Clone(fc.wildDoTables),
+		WildIgnoreTables: slices.Clone(fc.wildIgnoreTables),
+		RewriteDbs:       maps.Clone(fc.rewriteDbs),
+		RowFilters:       make(map[string]map[string]string, len(fc.rowFilters)),
+	}
+	for dbName, tableMap := range fc.doTables {
+		p.DoTables[dbName] = keys(tableMap)
+	}
+	for dbName, tableMap := range fc.ignoreTables {
+		p.IgnoreTables[dbName] = keys(tableMap)
+	}
+	for dbName, tableMap := range fc.rowFilters {
+		p.RowFilters[dbName] = maps.Clone(tableMap)
+	}
+	return p
+}
+
+// load replaces this filter configuration with the persisted filter configuration |p|.
+func (fc *filterConfiguration) load(p persistedReplicationFilters) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.doTables = make(map[string]map[string]struct{}, len(p.DoTables))
+	for dbName, tableNames := range p.DoTables {
+		fc.doTables[dbName] = make(map[string]struct{}, len(tableNames))
+		for _, tableName := range tableNames {
+			fc.doTables[dbName][tableName] = struct{}{}
+		}
+	}
+	fc.ignoreTables = make(map[string]map[string]struct{}, len(p.IgnoreTables))
+	for dbName, tableNames := range p.IgnoreTables {
+		fc.ignoreTables[dbName] = make(map[string]struct{}, len(tableNames))
+		for _, tableName := range tableNames {
+			fc.ignoreTables[dbName][tableName] = struct{}{}
+		}
+	}
+	fc.wildDoTables = p.WildDoTables
+	fc.wildIgnoreTables = p.WildIgnoreTables
+	fc.rewriteDbs = make(map[string]string, len(p.RewriteDbs))
+	maps.Copy(fc.rewriteDbs, p.RewriteDbs)
+	fc.rowFilters = make(map[string]map[string]string, len(p.RowFilters))
+	for dbName, tableMap := range p.RowFilters {
+		fc.rowFilters[dbName] = maps.Clone(tableMap)
+	}
+}
+
 // convertFilterMapToStringSlice converts the specified |filterMap| into a string slice, by iterating over every
 // key in the top level map, which stores a database name, and for each of those keys, iterating over every key
 // in the inner map, which stores a table name. Each table name is qualified with the matching database name and the
@@ -166,3 +416,60 @@ func convertFilterMapToStringSlice(filterMap map[string]map[string]struct{}) []s
 	}
 	return tableNames
 }
+
+// normalizeWildTablePatterns returns the lowercased |patterns| and returns an error if any pattern is not of the form
+// "db_pattern.table_pattern".
+func normalizeWildTablePatterns(patterns []string) ([]string, error) {
+	normalized := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		db, table, ok := strings.Cut(pattern, ".")
+		if !ok || db == "" || table == "" {
+			return nil, fmt.Errorf("no database pattern specified for table pattern '%s'; "+
+				"all filter table patterns must be in the form 'db_pattern.table_pattern'", pattern)
+		}
+		normalized[i] = strings.ToLower(pattern)
+	}
+	return normalized, nil
+}
+
+// matchesWildPattern returns whether |s| matches |pattern|, in the syntax of LIKE: "%" matches any sequence of
+// characters, "_" matches any single character, and "\" escapes the character following it.
+func matchesWildPattern(pattern, s string) bool {
+	p, str := []rune(pattern), []rune(s)
+	// The positions to backtrack to when the last "%" seen needs to match one more character
+	starP, starS := -1, -1
+	i, j := 0, 0
+	for j < len(str) {
+		if i < len(p) {
+			switch {
+			case p[i] == '%':
+				starP, starS = i, j
+				i++
+				continue
+			case p[i] == '_':
+				i++
+				j++
+				continue
+			case p[i] == '\\' && i+1 < len(p):
+				if p[i+1] == str[j] {
+					i += 2
+					j++
+					continue
+				}
+			case p[i] == str[j]:
+				i++
+				j++
+				continue
+			}
+		}
+		if starP < 0 {
+			return false
+		}
+		starS++
+		i, j = starP+1, starS
+	}
+	for i < len(p) && p[i] == '%' {
+		i++
+	}
+	return i == len(p)
+}
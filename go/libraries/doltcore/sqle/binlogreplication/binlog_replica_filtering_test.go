@@ -0,0 +1,123 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesWildPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		s        string
+		expected bool
+	}{
+		{"db.%", "db.t1", true},
+		{"db.%", "dbx.t1", false},
+		{"db%.t_", "db01.t1", true},
+		{"db%.t_", "db01.t12", false},
+		{"%.%", "db.t", true},
+		{"%a%b", "xaxbxb", true},
+		{"%a%b", "xaxbx", false},
+		{"db.t%", "db.t", true},
+		{"db.t\\_1", "db.t_1", true},
+		{"db.t\\_1", "db.tx1", false},
+		{"db.t\\%", "db.t%", true},
+		{"db.t\\%", "db.t1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.s, func(t *testing.T) {
+			require.Equal(t, tt.expected, matchesWildPattern(tt.pattern, tt.s))
+		})
+	}
+}
+
+func TestFilterConfiguration(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	filteredOut := func(fc *filterConfiguration, db, table string) bool {
+		return fc.isTableFilteredOut(ctx, &mysql.TableMap{Database: db, Name: table})
+	}
+
+	t.Run("wild tables", func(t *testing.T) {
+		fc := newFilterConfiguration()
+		require.NoError(t, fc.setWildDoTables([]string{"db01.t%", "DB02.%"}))
+		require.NoError(t, fc.setWildIgnoreTables([]string{"db01.t\\_2"}))
+		require.False(t, filteredOut(fc, "db01", "t1"))
+		require.True(t, filteredOut(fc, "db01", "t_2"))
+		require.True(t, filteredOut(fc, "db01", "other"))
+		require.False(t, filteredOut(fc, "db02", "other"))
+		require.True(t, filteredOut(fc, "db03", "t1"))
+
+		// Tables listed in doTables are replicated without checking the wildcard options
+		require.NoError(t, fc.setDoTables([]sql.UnresolvedTable{plan.NewUnresolvedTable("t_2", "db01")}))
+		require.False(t, filteredOut(fc, "db01", "t_2"))
+
+		require.Error(t, fc.setWildDoTables([]string{"t%"}))
+	})
+
+	t.Run("rewrite dbs", func(t *testing.T) {
+		fc := newFilterConfiguration()
+		require.NoError(t, fc.setRewriteDbs([]string{"US_East -> orders", "a->b"}))
+		require.Equal(t, "orders", fc.rewriteDatabase("us_east"))
+		require.Equal(t, "b", fc.rewriteDatabase("A"))
+		require.Equal(t, "Other", fc.rewriteDatabase("Other"))
+
+		require.Error(t, fc.setRewriteDbs([]string{"a"}))
+		require.Error(t, fc.setRewriteDbs([]string{"a->"}))
+		require.Error(t, fc.setRewriteDbs([]string{"a->b", "A->c"}))
+		// Failing to set rewrite rules leaves the previous rules in place
+		require.Equal(t, "orders", fc.rewriteDatabase("us_east"))
+	})
+
+	t.Run("row filters", func(t *testing.T) {
+		fc := newFilterConfiguration()
+		fc.setRowFilter("DB01", "Customers", "region = 'us'")
+		require.Equal(t, "region = 'us'", fc.getRowFilter("db01", "customers"))
+		require.Equal(t, "", fc.getRowFilter("db01", "t1"))
+		require.Equal(t, map[string]string{"db01.customers": "region = 'us'"}, fc.getRowFilters())
+
+		fc.setRowFilter("db01", "customers", "")
+		require.Equal(t, "", fc.getRowFilter("db01", "customers"))
+		require.Empty(t, fc.getRowFilters())
+	})
+
+	t.Run("persisted", func(t *testing.T) {
+		fc := newFilterConfiguration()
+		require.True(t, fc.persisted().isEmpty())
+		require.NoError(t, fc.setIgnoreTables([]sql.UnresolvedTable{plan.NewUnresolvedTable("t2", "db01")}))
+		require.NoError(t, fc.setWildDoTables([]string{"db01.%"}))
+		require.NoError(t, fc.setRewriteDbs([]string{"us_east->db01"}))
+		fc.setRowFilter("db01", "customers", "region = 'us'")
+		persisted := fc.persisted()
+		require.False(t, persisted.isEmpty())
+
+		loaded := newFilterConfiguration()
+		loaded.load(persisted)
+		require.Equal(t, []string{"db01.t2"}, loaded.getIgnoreTables())
+		require.Equal(t, []string{"db01.%"}, loaded.getWildDoTables())
+		require.Equal(t, []string{"us_east->db01"}, loaded.getRewriteDbs())
+		require.Equal(t, "region = 'us'", loaded.getRowFilter("db01", "customers"))
+		require.True(t, filteredOut(loaded, "db01", "t2"))
+		require.True(t, filteredOut(loaded, "db02", "t1"))
+
+		loaded.reset()
+		require.True(t, loaded.persisted().isEmpty())
+	})
+}
@@ -189,3 +189,157 @@ func TestBinlogReplicationFilters_errorCases(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorContains(t, err, "no database specified for table")
 }
+
+// TestBinlogReplicationFilters_rewriteDb tests that the REPLICATE_REWRITE_DB replication filtering option applies
+// the changes to a source database to a differently named replica database.
+func TestBinlogReplicationFilters_rewriteDb(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(doltReplicaSystemVars)
+	h.startReplicationAndCreateTestDb(h.mySqlPort)
+
+	h.replicaDatabase.MustExec("CREATE DATABASE db02;")
+	h.replicaDatabase.MustExec("CALL dolt_replication_filter('REPLICATE_REWRITE_DB', 'DB01->db02');")
+	h.requireReplicaResults("CALL dolt_replication_filter();", [][]any{{"REPLICATE_REWRITE_DB", "db01->db02"}})
+
+	// Rewrite rules apply to the default database of statements, so the table isn't qualified
+	h.primaryDatabase.MustExec("CREATE TABLE t1 (pk INT PRIMARY KEY, c1 VARCHAR(10));")
+	for i := 1; i < 12; i++ {
+		h.primaryDatabase.MustExec(fmt.Sprintf("INSERT INTO db01.t1 VALUES (%d, 'row %d');", i, i))
+	}
+	h.primaryDatabase.MustExec("UPDATE db01.t1 set pk = pk-1;")
+	h.primaryDatabase.MustExec("DELETE FROM db01.t1 WHERE pk = 10;")
+
+	// Pause to let the replica catch up
+	h.waitForReplicaToCatchUp()
+
+	h.requireReplicaResults("SELECT COUNT(pk), MIN(pk), MAX(pk) FROM db02.t1;", [][]any{{"10", "0", "9"}})
+	h.requireReplicaResults("SHOW TABLES FROM db01;", [][]any{})
+
+	// Errors for malformed rewrite rules
+	_, err := h.replicaDatabase.Queryx("CALL dolt_replication_filter('REPLICATE_REWRITE_DB', 'db01');")
+	require.ErrorContains(t, err, "rules must be in the form 'from_db->to_db'")
+	_, err = h.replicaDatabase.Queryx("CALL dolt_replication_filter('REPLICATE_REWRITE_DB', 'db01->db02', 'db01->db03');")
+	require.ErrorContains(t, err, "database 'db01' is rewritten more than once")
+}
+
+// TestBinlogReplicationFilters_wildTables tests that the REPLICATE_WILD_DO_TABLE and REPLICATE_WILD_IGNORE_TABLE
+// replication filtering options are correctly applied and honored.
+func TestBinlogReplicationFilters_wildTables(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(doltReplicaSystemVars)
+	h.startReplicationAndCreateTestDb(h.mySqlPort)
+
+	h.replicaDatabase.MustExec("CALL dolt_replication_filter('REPLICATE_WILD_DO_TABLE', 'db01.t%');")
+	h.replicaDatabase.MustExec("CALL dolt_replication_filter('REPLICATE_WILD_IGNORE_TABLE', 'DB01.T\\\\_2');")
+	h.requireReplicaResults("CALL dolt_replication_filter();", [][]any{
+		{"REPLICATE_WILD_DO_TABLE", "db01.t%"},
+		{"REPLICATE_WILD_IGNORE_TABLE", "db01.t\\_2"},
+	})
+
+	// Make changes on the primary
+	for _, table := range []string{"t_1", "t_2", "other"} {
+		h.primaryDatabase.MustExec(fmt.Sprintf("CREATE TABLE db01.%s (pk INT PRIMARY KEY);", table))
+		for i := 1; i < 6; i++ {
+			h.primaryDatabase.MustExec(fmt.Sprintf("INSERT INTO db01.%s VALUES (%d);", table, i))
+		}
+		h.primaryDatabase.MustExec(fmt.Sprintf("DELETE FROM db01.%s WHERE pk = 5;", table))
+	}
+
+	// Pause to let the replica catch up
+	h.waitForReplicaToCatchUp()
+
+	// Only changes to t_1 were applied on the replica
+	h.requireReplicaResults("SELECT COUNT(pk) FROM db01.t_1;", [][]any{{"4"}})
+	h.requireReplicaResults("SELECT COUNT(pk) FROM db01.t_2;", [][]any{{"0"}})
+	h.requireReplicaResults("SELECT COUNT(pk) FROM db01.other;", [][]any{{"0"}})
+
+	// All table patterns must be qualified with a database pattern
+	_, err := h.replicaDatabase.Queryx("CALL dolt_replication_filter('REPLICATE_WILD_DO_TABLE', 't%');")
+	require.ErrorContains(t, err, "no database pattern specified for table pattern")
+}
+
+// TestBinlogReplicationFilters_rowFilter tests that only the rows matching the row filter of a table are replicated.
+func TestBinlogReplicationFilters_rowFilter(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(doltReplicaSystemVars)
+	h.startReplicationAndCreateTestDb(h.mySqlPort)
+
+	h.replicaDatabase.MustExec("CALL dolt_replication_filter('REPLICATE_ROW_FILTER', 'db01.customers', 'region = ''us''');")
+
+	// Make changes on the primary
+	h.primaryDatabase.MustExec("CREATE TABLE db01.customers (pk INT PRIMARY KEY, region VARCHAR(10));")
+	h.primaryDatabase.MustExec("CREATE TABLE db01.t1 (pk INT PRIMARY KEY, region VARCHAR(10));")
+	h.primaryDatabase.MustExec("INSERT INTO db01.customers VALUES (1, 'us'), (2, 'eu'), (3, 'us'), (4, 'eu'), (5, 'us');")
+	h.primaryDatabase.MustExec("INSERT INTO db01.t1 VALUES (1, 'us'), (2, 'eu');")
+	// Moves row 2 into the filter and row 1 out of it
+	h.primaryDatabase.MustExec("UPDATE db01.customers SET region = 'us' WHERE pk = 2;")
+	h.primaryDatabase.MustExec("UPDATE db01.customers SET region = 'eu' WHERE pk = 1;")
+	h.primaryDatabase.MustExec("UPDATE db01.customers SET pk = 30 WHERE pk = 3;")
+	h.primaryDatabase.MustExec("UPDATE db01.customers SET pk = 40 WHERE pk = 4;")
+	h.primaryDatabase.MustExec("DELETE FROM db01.customers WHERE pk IN (5, 40);")
+
+	// Pause to let the replica catch up
+	h.waitForReplicaToCatchUp()
+
+	h.requireReplicaResults("SELECT * FROM db01.customers ORDER BY pk;", [][]any{{"2", "us"}, {"30", "us"}})
+	h.requireReplicaResults("SELECT * FROM db01.t1 ORDER BY pk;", [][]any{{"1", "us"}, {"2", "eu"}})
+
+	// Row filters are checked against the schema of tables that exist
+	_, err := h.replicaDatabase.Queryx("CALL dolt_replication_filter('REPLICATE_ROW_FILTER', 'db01.customers', 'nosuchcolumn = 1');")
+	require.Error(t, err)
+
+	// Removing the row filter replicates all rows again
+	h.replicaDatabase.MustExec("CALL dolt_replication_filter('REPLICATE_ROW_FILTER', 'db01.customers');")
+	h.requireReplicaResults("CALL dolt_replication_filter();", [][]any{})
+	h.primaryDatabase.MustExec("INSERT INTO db01.customers VALUES (6, 'eu');")
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("SELECT * FROM db01.customers ORDER BY pk;", [][]any{{"2", "us"}, {"6", "eu"}, {"30", "us"}})
+}
+
+// TestBinlogReplicationFilters_persisted tests that replication filters are persisted across server restarts,
+// and are cleared out by RESET REPLICA ALL.
+func TestBinlogReplicationFilters_persisted(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(doltReplicaSystemVars)
+	h.startReplicationAndCreateTestDb(h.mySqlPort)
+
+	h.replicaDatabase.MustExec("CHANGE REPLICATION FILTER REPLICATE_IGNORE_TABLE=(db01.t2);")
+	h.replicaDatabase.MustExec("CALL dolt_replication_filter('REPLICATE_WILD_IGNORE_TABLE', 'db01.t3%');")
+	h.replicaDatabase.MustExec("CALL dolt_replication_filter('REPLICATE_ROW_FILTER', 'db01.t1', 'pk < 10');")
+
+	h.replicaDatabase.MustExec("STOP REPLICA;")
+	h.stopDoltSqlServer()
+	var err error
+	h.doltPort, h.doltProcess, err = h.startDoltSqlServer(nil)
+	require.NoError(t, err)
+
+	expectedFilters := [][]any{
+		{"REPLICATE_IGNORE_TABLE", "db01.t2"},
+		{"REPLICATE_WILD_IGNORE_TABLE", "db01.t3%"},
+		{"REPLICATE_ROW_FILTER", "db01.t1: pk < 10"},
+	}
+	h.requireReplicaResults("CALL dolt_replication_filter();", expectedFilters)
+	status := h.showReplicaStatus()
+	require.Equal(t, "db01.t2", status["Replicate_Ignore_Table"])
+
+	// Filters are applied once replication is restarted
+	h.replicaDatabase.MustExec("set @@global.server_id=123;")
+	h.replicaDatabase.MustExec("START REPLICA;")
+	for _, table := range []string{"t1", "t2", "t30"} {
+		h.primaryDatabase.MustExec(fmt.Sprintf("CREATE TABLE db01.%s (pk INT PRIMARY KEY);", table))
+		h.primaryDatabase.MustExec(fmt.Sprintf("INSERT INTO db01.%s VALUES (1), (20);", table))
+	}
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("SELECT pk FROM db01.t1;", [][]any{{"1"}})
+	h.requireReplicaResults("SELECT COUNT(pk) FROM db01.t2;", [][]any{{"0"}})
+	h.requireReplicaResults("SELECT COUNT(pk) FROM db01.t30;", [][]any{{"0"}})
+
+	h.replicaDatabase.MustExec("STOP REPLICA;")
+	h.replicaDatabase.MustExec("RESET REPLICA ALL;")
+	h.requireReplicaResults("CALL dolt_replication_filter();", [][]any{})
+
+	h.stopDoltSqlServer()
+	h.doltPort, h.doltProcess, err = h.startDoltSqlServer(nil)
+	require.NoError(t, err)
+	h.requireReplicaResults("CALL dolt_replication_filter();", [][]any{})
+}
@@ -608,6 +608,57 @@ func TestDoltCommits(t *testing.T) {
 	require.Equal(t, 5, len(allRows)) // 4 transactions + 1 initial commit
 }
 
+// TestDoltCommitPolicy tests that the changes of several source transactions can be batched into a single Dolt
+// commit, and that commits record the source GTIDs and timestamps.
+func TestDoltCommitPolicy(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(doltReplicaSystemVars)
+	h.startReplicationAndCreateTestDb(h.mySqlPort)
+
+	// By default, every source transaction is recorded in its own Dolt commit
+	h.primaryDatabase.MustExec("create table t1 (pk int primary key);")
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("select count(*) from db01.dolt_log;", [][]any{{"2"}})
+	h.requireReplicaResults("select count(*) from db01.dolt_log where message like 'Dolt binlog replica commit: GTID %' "+
+		"and message like '%Source-GTID: %' and message like '%Source-Timestamp: %';", [][]any{{"1"}})
+	// The commit date is the time the transaction was logged on the source
+	h.requireReplicaResults("select count(*) from db01.dolt_log where message like "+
+		"concat('%Source-Timestamp: ', date_format(date, '%Y-%m-%dT%H:%i:%sZ'));", [][]any{{"1"}})
+
+	// Batch every three source transactions
+	h.replicaDatabase.MustExec("set @@global.dolt_binlog_replica_commit_interval_secs=0;")
+	h.replicaDatabase.MustExec("set @@global.dolt_binlog_replica_commit_transactions=3;")
+	h.replicaDatabase.MustExec("set @@global.dolt_binlog_replica_commit_policy='batch';")
+	h.primaryDatabase.MustExec("insert into t1 values (1);")
+	h.primaryDatabase.MustExec("insert into t1 values (2);")
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("select count(*) from db01.dolt_log;", [][]any{{"2"}})
+	h.requireReplicaResults("select count(*) from db01.t1;", [][]any{{"2"}})
+	h.primaryDatabase.MustExec("insert into t1 values (3);")
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("select count(*) from db01.dolt_log;", [][]any{{"3"}})
+	h.requireReplicaResults("select count(*) from db01.dolt_log where message like 'Dolt binlog replica commit: 3 transactions%' "+
+		"and message like '%Source-First-GTID: %';", [][]any{{"1"}})
+
+	// Batch the source transactions of every second
+	h.replicaDatabase.MustExec("set @@global.dolt_binlog_replica_commit_transactions=0;")
+	h.replicaDatabase.MustExec("set @@global.dolt_binlog_replica_commit_interval_secs=1;")
+	h.primaryDatabase.MustExec("insert into t1 values (4);")
+	h.primaryDatabase.MustExec("insert into t1 values (5);")
+	h.waitForReplicaToCatchUp()
+	time.Sleep(3 * time.Second)
+	h.requireReplicaResults("select count(*) from db01.dolt_log;", [][]any{{"4"}})
+
+	// Pending transactions are committed when replication stops
+	h.replicaDatabase.MustExec("set @@global.dolt_binlog_replica_commit_interval_secs=3600;")
+	h.primaryDatabase.MustExec("insert into t1 values (6);")
+	h.waitForReplicaToCatchUp()
+	h.requireReplicaResults("select count(*) from db01.dolt_log;", [][]any{{"4"}})
+	h.replicaDatabase.MustExec("stop replica;")
+	h.requireReplicaResults("select count(*) from db01.dolt_log;", [][]any{{"5"}})
+	h.requireReplicaResults("select count(*) from db01.t1 as of 'HEAD';", [][]any{{"6"}})
+}
+
 // TestForeignKeyChecks tests that foreign key constraints replicate correctly when foreign key checks are
 // enabled and disabled.
 func TestForeignKeyChecks(t *testing.T) {
@@ -0,0 +1,353 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// A running SQL server can run the dolt ci workflows of its databases,
+// instead of them being run on demand with `dolt ci run`. This is
+// enabled with `behavior.run_ci_workflows` in the server's YAML config,
+// and works as follows:
+//
+// A CIController is created for a running SQL Engine. A CICommitHook is
+// installed on every database, which queues a run request whenever the
+// head of a branch moves, for example by dolt_commit or dolt_merge.
+// Pushes to the server's remotesapi endpoint don't go through commit
+// hooks, so the controller is also a remotesrv.PushListener, and queues
+// a run request for every branch updated by a push. A single background
+// thread serves the requests. For each one, it loads the workflows of
+// the new commit, and runs the ones with a push trigger matching the
+// branch in a new session, against a read-only revision database of the
+// commit, within a read-only transaction. The session runs as a locked
+// account that can only read the revision database, and is subject to
+// the row-level security policies and column masks of the database,
+// since the failing rows of steps are stored with the run. The run
+// history is recorded in the database, outside of the commit graph, and
+// can be inspected in the dolt_ci_runs and dolt_ci_step_results system
+// tables. The result of each run is also recorded for branch protection
+// rules requiring the workflow. Like approvals, results are only
+// recorded by the server: the remotesapi endpoint rejects pushes that
+// change them, so a client can't push a result for a commit the server
+// didn't run.
+
+// ciRunnerUser is the locked account used by the sessions running workflows. It doesn't have the SUPER privilege, so
+// row-level security policies and column masks apply to it.
+const ciRunnerUser = "dolt-ci-runner"
+
+// ciPushEvent is the event of the runs triggered by a branch update.
+const ciPushEvent = "push"
+
+type ciRunRequest struct {
+	database string
+	branch   string
+	commit   hash.Hash
+}
+
+type CIController struct {
+	lgr *logrus.Logger
+
+	mu      sync.Mutex
+	queue   []ciRunRequest
+	pending map[ciRunRequest]struct{}
+	notify  chan struct{}
+
+	ctxFactory func(context.Context) (*sql.Context, error)
+	queryist   cli.Queryist
+	mysqlDb    *mysql_db.MySQLDb
+}
+
+var _ remotesrv.PushListener = (*CIController)(nil)
+
+func NewCIController(lgr *logrus.Logger) *CIController {
+	return &CIController{
+		lgr:     lgr,
+		pending: make(map[ciRunRequest]struct{}),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// During engine initialization, this should be called to ensure the
+// background thread responsible for running workflows is running.
+// Workflows are run with |queryist|, in sessions created by
+// |ctxFactory|, as a locked account added to |mysqlDb| for each run.
+func (c *CIController) RunBackgroundThread(threads *sql.BackgroundThreads, ctxFactory func(context.Context) (*sql.Context, error), queryist cli.Queryist, mysqlDb *mysql_db.MySQLDb) error {
+	c.ctxFactory = ctxFactory
+	c.queryist = queryist
+	c.mysqlDb = mysqlDb
+	return threads.Add("ci_workflow_thread", c.run)
+}
+
+// During engine initialization, called on the original set of
+// databases to install their CI commit hooks.
+func (c *CIController) ApplyCommitHooks(ctx context.Context, mrEnv *env.MultiRepoEnv, dbs ...dsess.SqlDatabase) error {
+	for _, db := range dbs {
+		denv := mrEnv.GetEnv(db.Name())
+		if denv == nil {
+			continue
+		}
+		c.installCommitHook(ctx, db.Name(), denv)
+	}
+	return nil
+}
+
+func (c *CIController) InitDatabaseHook() InitDatabaseHook {
+	return func(ctx *sql.Context, _ *DoltDatabaseProvider, name string, env *env.DoltEnv, _ dsess.SqlDatabase) error {
+		c.installCommitHook(ctx, name, env)
+		return nil
+	}
+}
+
+func (c *CIController) DropDatabaseHook() DropDatabaseHook {
+	return func(_ *sql.Context, name string) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		queue := c.queue[:0]
+		for _, req := range c.queue {
+			if req.database == name {
+				delete(c.pending, req)
+			} else {
+				queue = append(queue, req)
+			}
+		}
+		c.queue = queue
+	}
+}
+
+func (c *CIController) installCommitHook(ctx context.Context, name string, denv *env.DoltEnv) {
+	ddb := denv.DoltDB(ctx)
+	ddb.PrependCommitHooks(ctx, NewCICommitHook(func(branch string, commit hash.Hash) {
+		c.enqueue(ciRunRequest{database: name, branch: branch, commit: commit})
+	}))
+}
+
+// PushCompleted implements remotesrv.PushListener, and queues a run request for every branch updated by a push to
+// the database |repoPath|.
+func (c *CIController) PushCompleted(ctx context.Context, repoPath string, cs remotesrv.RemoteSrvStore, last, current hash.Hash) {
+	ddb, err := doltdb.DoltDBFromCS(cs, "")
+	if err != nil {
+		c.lgr.Errorf("sqle/ci: error loading database %s after push: %v", repoPath, err)
+		return
+	}
+	var oldHeads map[string]hash.Hash
+	if !last.IsEmpty() {
+		oldBranches, err := ddb.GetBranchesByRootHash(ctx, last)
+		if err != nil {
+			c.lgr.Errorf("sqle/ci: error loading branches of database %s: %v", repoPath, err)
+			return
+		}
+		oldHeads = make(map[string]hash.Hash, len(oldBranches))
+		for _, b := range oldBranches {
+			oldHeads[b.Ref.String()] = b.Hash
+		}
+	}
+	newBranches, err := ddb.GetBranchesByRootHash(ctx, current)
+	if err != nil {
+		c.lgr.Errorf("sqle/ci: error loading branches of database %s: %v", repoPath, err)
+		return
+	}
+	for _, b := range newBranches {
+		if old, ok := oldHeads[b.Ref.String()]; ok && old == b.Hash {
+			continue
+		}
+		c.enqueue(ciRunRequest{database: repoPath, branch: b.Ref.GetPath(), commit: b.Hash})
+	}
+}
+
+func (c *CIController) enqueue(req ciRunRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.pending[req]; ok {
+		return
+	}
+	c.pending[req] = struct{}{}
+	c.queue = append(c.queue, req)
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (c *CIController) next() (ciRunRequest, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.queue) == 0 {
+		return ciRunRequest{}, false
+	}
+	req := c.queue[0]
+	c.queue = c.queue[1:]
+	delete(c.pending, req)
+	return req, true
+}
+
+func (c *CIController) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.notify:
+		}
+		for req, ok := c.next(); ok; req, ok = c.next() {
+			if err := c.runWorkflows(ctx, req); err != nil {
+				c.lgr.Errorf("sqle/ci: error running workflows for branch %s of database %s: %v", req.branch, req.database, err)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// setUpRunner replaces the locked account used by the sessions running workflows with one that can only read the
+// database |revisionDb|. The account is ephemeral, and is replaced before each run, since runs are one at a time.
+func (c *CIController) setUpRunner(revisionDb string) {
+	ed := c.mysqlDb.Editor()
+	defer ed.Close()
+	privileges := mysql_db.NewPrivilegeSet()
+	privileges.AddDatabase(revisionDb, sql.PrivilegeType_Select)
+	ed.PutUser(&mysql_db.User{
+		User:                ciRunnerUser,
+		Host:                "localhost",
+		PrivilegeSet:        privileges,
+		Plugin:              "mysql_native_password",
+		PasswordLastChanged: time.Now().UTC(),
+		Locked:              true,
+		IsEphemeral:         true,
+	})
+}
+
+// runWorkflows runs the workflows of the commit of |req| which are triggered by pushes to its branch, and records
+// their runs.
+func (c *CIController) runWorkflows(ctx context.Context, req ciRunRequest) error {
+	// Workflows are loaded from and run against the commit itself, which is read-only, in a read-only transaction.
+	revisionDb := req.database + doltdb.DbRevisionDelimiter + req.commit.String()
+	c.setUpRunner(revisionDb)
+	sqlCtx, err := c.ctxFactory(ctx)
+	if err != nil {
+		return err
+	}
+	defer sql.SessionEnd(sqlCtx.Session)
+	sql.SessionCommandBegin(sqlCtx.Session)
+	defer sql.SessionCommandEnd(sqlCtx.Session)
+	sqlCtx.Session.SetClient(sql.Client{User: ciRunnerUser, Address: "localhost"})
+
+	ddb, ok := dsess.DSessFromSess(sqlCtx.Session).GetDoltDB(sqlCtx, req.database)
+	if !ok {
+		// the database was dropped
+		return nil
+	}
+
+	if _, err = cli.GetRowsForSql(c.queryist, sqlCtx, fmt.Sprintf("USE `%s`", revisionDb)); err != nil {
+		return err
+	}
+	if _, err = cli.GetRowsForSql(c.queryist, sqlCtx, "START TRANSACTION READ ONLY"); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = cli.GetRowsForSql(c.queryist, sqlCtx, "ROLLBACK")
+	}()
+
+	hasTables, err := dolt_ci.HasDoltCITables(c.queryist, sqlCtx)
+	if err != nil || !hasTables {
+		return err
+	}
+	wm := dolt_ci.NewWorkflowManager("", "", c.queryist.Query)
+	workflows, err := wm.ListWorkflows(sqlCtx)
+	if err != nil {
+		return err
+	}
+
+	for _, workflow := range workflows {
+		run := doltdb.CIRun{
+			Workflow:  workflow,
+			Event:     ciPushEvent,
+			Branch:    req.branch,
+			Commit:    req.commit.String(),
+			StartedAt: time.Now(),
+		}
+		config, err := wm.GetWorkflowConfig(sqlCtx, workflow)
+		if err != nil {
+			run.Status, run.Error, run.FinishedAt = doltdb.CIWorkflowError, err.Error(), time.Now()
+			c.recordRun(sqlCtx, ddb, req, run)
+			continue
+		}
+		if !config.TriggeredByPush(req.branch) {
+			continue
+		}
+
+		result, err := dolt_ci.RunWorkflow(sqlCtx, c.queryist, config)
+		if err != nil {
+			run.Status, run.Error, run.FinishedAt = doltdb.CIWorkflowError, err.Error(), time.Now()
+			c.recordRun(sqlCtx, ddb, req, run)
+			continue
+		}
+		run.StartedAt, run.FinishedAt = result.StartedAt, result.FinishedAt
+		run.Status = doltdb.CIWorkflowPassed
+		if !result.Passed() {
+			run.Status = doltdb.CIWorkflowFailed
+		}
+		for _, step := range result.Steps {
+			stepResult := doltdb.CIStepResult{
+				Job:         step.Job,
+				Step:        step.Step,
+				Status:      doltdb.CIWorkflowPassed,
+				StartedAt:   step.StartedAt,
+				FinishedAt:  step.FinishedAt,
+				FailingRows: step.FailingRows,
+			}
+			if !step.Passed() {
+				stepResult.Status, stepResult.Error = doltdb.CIWorkflowFailed, step.Err.Error()
+			}
+			run.Steps = append(run.Steps, stepResult)
+		}
+		c.recordRun(sqlCtx, ddb, req, run)
+	}
+	return nil
+}
+
+// recordRun adds |run| to the run history of |ddb|. The result of a completed run is also recorded for the commit,
+// so that branch protection rules requiring the workflow can check it.
+func (c *CIController) recordRun(ctx context.Context, ddb *doltdb.DoltDB, req ciRunRequest, run doltdb.CIRun) {
+	id, err := ddb.AddCIRun(ctx, run)
+	if err != nil {
+		c.lgr.Errorf("sqle/ci: error recording run of workflow %s for database %s: %v", run.Workflow, req.database, err)
+		return
+	}
+	c.lgr.Infof("sqle/ci: run %d of workflow %s on branch %s of database %s %s", id, run.Workflow, req.branch, req.database, run.Status)
+
+	if run.Status == doltdb.CIWorkflowError {
+		return
+	}
+	result := doltdb.CIWorkflowResult{Workflow: run.Workflow, Status: run.Status, RecordedAt: run.FinishedAt.UTC()}
+	if err = ddb.RecordCIWorkflowResult(ctx, req.commit, result); err != nil {
+		c.lgr.Errorf("sqle/ci: error recording result of workflow %s for database %s: %v", run.Workflow, req.database, err)
+	}
+}
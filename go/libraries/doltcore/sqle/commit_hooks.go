@@ -27,7 +27,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/webhooks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
@@ -440,3 +442,117 @@ func (m *DynamicPushOnWriteHook) Execute(ctx context.Context, ds datas.Dataset,
 func (m *DynamicPushOnWriteHook) ExecuteForWorkingSets() bool {
 	return false
 }
+
+// WebhookCommitHook is a CommitHook which queues an event in a durable outbox for every configured webhook whose
+// branch patterns match an updated branch head. The events are POSTed to the webhook endpoints by the delivery thread
+// of a WebhookController, so that commits never wait on an HTTP endpoint.
+type WebhookCommitHook struct {
+	webhooks []servercfg.WebhookConfig
+	outbox   *webhooks.Outbox
+	notify   func()
+}
+
+var _ doltdb.CommitHook = (*WebhookCommitHook)(nil)
+
+// NewWebhookCommitHook creates a WebhookCommitHook which queues events for |hooks| in |outbox|, and calls |notify|
+// whenever a new event is queued.
+func NewWebhookCommitHook(hooks []servercfg.WebhookConfig, outbox *webhooks.Outbox, notify func()) *WebhookCommitHook {
+	return &WebhookCommitHook{webhooks: hooks, outbox: outbox, notify: notify}
+}
+
+// Execute implements CommitHook, queues an event for every webhook matching the updated branch
+func (wh *WebhookCommitHook) Execute(ctx context.Context, ds datas.Dataset, _ *doltdb.DoltDB) (func(context.Context) error, error) {
+	if !ref.IsRef(ds.ID()) {
+		return nil, nil
+	}
+	r, err := ref.Parse(ds.ID())
+	if err != nil || r.GetType() != ref.BranchRefType {
+		return nil, nil
+	}
+	branch := r.GetPath()
+
+	addr, ok := ds.MaybeHeadAddr()
+	if !ok {
+		// The branch was deleted.
+		err = wh.outbox.RemoveHead(branch)
+		if err != nil {
+			logrus.Errorf("error updating webhook outbox: %v", err)
+		}
+		return nil, err
+	}
+
+	var matching []string
+	for _, webhook := range wh.webhooks {
+		if webhookMatchesBranch(webhook, branch) {
+			matching = append(matching, webhook.Name())
+		}
+	}
+	if len(matching) == 0 {
+		return nil, nil
+	}
+
+	queued, err := wh.outbox.Record(branch, addr, matching, time.Now())
+	if err != nil {
+		logrus.Errorf("error queueing webhook event for branch %s: %v", branch, err)
+		return nil, err
+	}
+	if queued && wh.notify != nil {
+		wh.notify()
+	}
+	return nil, nil
+}
+
+func (*WebhookCommitHook) ExecuteForWorkingSets() bool {
+	return false
+}
+
+// webhookMatchesBranch returns whether any of the branch patterns of |webhook| match |branch|. A webhook without
+// branch patterns matches every branch.
+func webhookMatchesBranch(webhook servercfg.WebhookConfig, branch string) bool {
+	if len(webhook.Branches()) == 0 {
+		return true
+	}
+	for _, pattern := range webhook.Branches() {
+		if matchWildcardPattern(pattern, branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// CICommitHook is a CommitHook which requests a run of the dolt ci workflows of a database whenever the head of one
+// of its branches is updated. The workflows are run by the background thread of a CIController, so that commits never
+// wait on them.
+type CICommitHook struct {
+	enqueue func(branch string, commit hash.Hash)
+}
+
+var _ doltdb.CommitHook = (*CICommitHook)(nil)
+
+// NewCICommitHook creates a CICommitHook which calls |enqueue| with every updated branch and its new head.
+func NewCICommitHook(enqueue func(branch string, commit hash.Hash)) *CICommitHook {
+	return &CICommitHook{enqueue: enqueue}
+}
+
+// Execute implements CommitHook, requests a run of the workflows for the updated branch
+func (ch *CICommitHook) Execute(ctx context.Context, ds datas.Dataset, _ *doltdb.DoltDB) (func(context.Context) error, error) {
+	if !ref.IsRef(ds.ID()) {
+		return nil, nil
+	}
+	r, err := ref.Parse(ds.ID())
+	if err != nil || r.GetType() != ref.BranchRefType {
+		return nil, nil
+	}
+
+	addr, ok := ds.MaybeHeadAddr()
+	if !ok {
+		// The branch was deleted.
+		return nil, nil
+	}
+	ch.enqueue(r.GetPath(), addr)
+	return nil, nil
+}
+
+func (*CICommitHook) ExecuteForWorkingSets() bool {
+	return false
+}
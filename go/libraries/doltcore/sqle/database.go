@@ -51,6 +51,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/concurrentmap"
@@ -459,6 +460,13 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 		if err != nil {
 			return nil, false, err
 		}
+		policies, masks, err := db.accessRestrictions(ctx, root, tname)
+		if err != nil {
+			return nil, false, err
+		}
+		if policies != nil || masks != nil {
+			dt = dt.(*dtables.DiffTable).WithRowSecurity(policies, masks)
+		}
 		return dt, true, nil
 
 	case strings.HasPrefix(lwrName, doltdb.DoltCommitDiffTablePrefix):
@@ -488,6 +496,13 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 		if err != nil {
 			return nil, false, err
 		}
+		policies, masks, err := db.accessRestrictions(ctx, root, tname)
+		if err != nil {
+			return nil, false, err
+		}
+		if policies != nil || masks != nil {
+			dt = dt.(*dtables.CommitDiffTable).WithRowSecurity(policies, masks)
+		}
 		return dt, true, nil
 
 	case lwrName == doltdb.DoltHistoryTablePrefix+doltdb.SchemasTableName:
@@ -550,6 +565,9 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 			}
 		}
 
+		if err := rowsec.CheckUnrestricted(ctx, db.RevisionQualifiedName(), tname, tblName); err != nil {
+			return nil, false, err
+		}
 		srcTable, ok, err := db.getTableInsensitiveWithRoot(ctx, head, ds, root, tname.Name, asOf, readNonlocalTables)
 		if err != nil {
 			return nil, false, err
@@ -583,6 +601,9 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 			}
 		}
 
+		if err := rowsec.CheckUnrestricted(ctx, db.RevisionQualifiedName(), tname, tblName); err != nil {
+			return nil, false, err
+		}
 		dt, err := dtables.NewConstraintViolationsTable(ctx, tname, root, dtables.RootSetter(db))
 		if err != nil {
 			return nil, false, err
@@ -613,6 +634,9 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 			}
 		}
 
+		if err := rowsec.CheckUnrestricted(ctx, db.RevisionQualifiedName(), tname, tblName); err != nil {
+			return nil, false, err
+		}
 		dt, err := dtables.NewWorkspaceTable(ctx, tblName, tname, head, ws)
 		if err != nil {
 			return nil, false, err
@@ -712,6 +736,62 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
 			dt, found = dtables.NewBranchActivityTable(ctx, db), true
 		}
+	case doltdb.GetWebhookDeliveriesTableName(), doltdb.WebhookDeliveriesTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewWebhookDeliveriesTable(ctx, db), true
+		}
+	case doltdb.GetBranchApprovalsTableName(), doltdb.BranchApprovalsTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewBranchApprovalsTable(ctx, db), true
+		}
+	case doltdb.GetCIRunsTableName(), doltdb.CIRunsTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewCIRunsTable(ctx, db), true
+		}
+	case doltdb.GetCIStepResultsTableName(), doltdb.CIStepResultsTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewCIStepResultsTable(ctx, db), true
+		}
+	case doltdb.GetStorageUsageTableName(), doltdb.StorageUsageTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewStorageUsageTable(ctx, db), true
+		}
+	case doltdb.GetPurgedCommitsTableName(), doltdb.PurgedCommitsTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewPurgedCommitsTable(ctx, db), true
+		}
+	case doltdb.GetChangeConsumersTableName(), doltdb.ChangeConsumersTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewChangeConsumersTable(ctx, db), true
+		}
 	case doltdb.RemoteBranchesTableName, doltdb.GetRemoteBranchesTableName():
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -926,6 +1006,61 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 			versionableTable := backingTable.(dtables.VersionableTable)
 			dt, found = dtables.NewNonlocallTablesTable(ctx, versionableTable), true
 		}
+	case doltdb.MergeResolversTableName, doltdb.GetMergeResolversTableName():
+		backingTable, _, err := db.getTable(ctx, root, doltdb.GetMergeResolversTableName())
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMergeResolversTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMergeResolversTable(ctx, versionableTable), true
+		}
+	case doltdb.RowPoliciesTableName, doltdb.GetRowPoliciesTableName():
+		backingTable, _, err := db.getTable(ctx, root, doltdb.GetRowPoliciesTableName())
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyRowPoliciesTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewRowPoliciesTable(ctx, versionableTable), true
+		}
+	case doltdb.ColumnMasksTableName, doltdb.GetColumnMasksTableName():
+		backingTable, _, err := db.getTable(ctx, root, doltdb.GetColumnMasksTableName())
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyColumnMasksTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewColumnMasksTable(ctx, versionableTable), true
+		}
+	case doltdb.BranchProtectionTableName, doltdb.GetBranchProtectionTableName():
+		backingTable, _, err := db.getTable(ctx, root, doltdb.GetBranchProtectionTableName())
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyBranchProtectionTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewBranchProtectionTable(ctx, versionableTable), true
+		}
+	case doltdb.MaterializedViewsTableName, doltdb.GetMaterializedViewsTableName():
+		backingTable, _, err := db.getTable(ctx, root, doltdb.GetMaterializedViewsTableName())
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMaterializedViewsTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMaterializedViewsTable(ctx, versionableTable), true
+		}
 	case doltdb.GetTestsTableName():
 		backingTable, _, err := db.getTable(ctx, root, doltdb.GetTestsTableName())
 		if err != nil {
@@ -1508,7 +1643,7 @@ func (db Database) getTable(ctx *sql.Context, root doltdb.RootValue, tableName s
 
 		cachedTable, ok := dbState.SessionCache().GetCachedTable(key, dsess.TableCacheKey{Name: tableName, Schema: db.schemaName})
 		if ok {
-			return cachedTable, true, nil
+			return db.applyRowSecurity(ctx, root, cachedTable, doltdb.TableName{Name: tableName, Schema: db.schemaName})
 		}
 	}
 
@@ -1556,7 +1691,30 @@ func (db Database) getTable(ctx *sql.Context, root doltdb.RootValue, tableName s
 		dbState.SessionCache().CacheTable(key, dsess.TableCacheKey{Name: tableName, Schema: db.schemaName}, table)
 	}
 
-	return table, true, nil
+	return db.applyRowSecurity(ctx, root, table, tblName)
+}
+
+// applyRowSecurity returns |table| restricted by the row-level security policies and column masks that apply to the
+// current user. Cached tables are shared by every query of a session, so restrictions are applied to a copy of
+// |table|. Restrictions are those of the revision of this database, bounded by those committed to its default branch,
+// as rowsec.Restrictions describes, so that AS OF queries and history tables are subject to the current policies and
+// masks rather than those of the commits they read.
+func (db Database) applyRowSecurity(ctx *sql.Context, root doltdb.RootValue, table sql.Table, tableName doltdb.TableName) (sql.Table, bool, error) {
+	policies, masks, err := db.accessRestrictions(ctx, root, tableName)
+	if err != nil {
+		return nil, false, err
+	}
+	return withRowSecurity(table, policies, masks), true, nil
+}
+
+// accessRestrictions returns the row-level security policies and column masks of |tableName| that apply to the
+// current user. Either is nil if it doesn't restrict the user's access to the table. Materialized views declared on
+// |root| are unavailable to the users restricted on the tables they read.
+func (db Database) accessRestrictions(ctx *sql.Context, root doltdb.RootValue, tableName doltdb.TableName) (*rowsec.Policies, *rowsec.Masks, error) {
+	if err := rowsec.CheckMaterializedView(ctx, db.RevisionQualifiedName(), root, tableName); err != nil {
+		return nil, nil, err
+	}
+	return rowsec.Restrictions(ctx, db.RevisionQualifiedName(), tableName)
 }
 
 // checkForPgCatalogTable checks if the table is of pg_catalog schema
@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
@@ -39,6 +40,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtablefunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/webhooks"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/concurrentmap"
@@ -75,6 +78,11 @@ type DoltDatabaseProvider struct {
 	dbFactoryUrl      string
 	DropDatabaseHooks []DropDatabaseHook
 	InitDatabaseHooks []InitDatabaseHook
+
+	// webhookController delivers the webhooks of this provider's databases, if any are configured
+	webhookController *WebhookController
+	// mysqlDb holds the users and roles of the server, which row-level security policies may be granted to
+	mysqlDb *mysql_db.MySQLDb
 }
 
 type remoteDialerWithGitCacheRoot struct {
@@ -96,6 +104,7 @@ var _ sql.CollatedDatabaseProvider = (*DoltDatabaseProvider)(nil)
 var _ sql.ExternalStoredProcedureProvider = (*DoltDatabaseProvider)(nil)
 var _ sql.TableFunctionProvider = (*DoltDatabaseProvider)(nil)
 var _ dsess.DoltDatabaseProvider = (*DoltDatabaseProvider)(nil)
+var _ rowsec.RoleProvider = (*DoltDatabaseProvider)(nil)
 
 func (p *DoltDatabaseProvider) DefaultBranch() string {
 	return p.defaultBranch
@@ -252,6 +261,47 @@ func (p *DoltDatabaseProvider) AddDropDatabaseHook(hook DropDatabaseHook) {
 	p.DropDatabaseHooks = append(p.DropDatabaseHooks, hook)
 }
 
+// SetWebhookController sets the controller delivering the webhooks of this provider's databases, whose deliveries
+// are shown in the dolt_webhook_deliveries system table.
+func (p *DoltDatabaseProvider) SetWebhookController(controller *WebhookController) {
+	p.webhookController = controller
+}
+
+// WebhookDeliveries returns the webhook deliveries queued for the database |dbName|, or nil if no webhooks are
+// configured for it.
+func (p *DoltDatabaseProvider) WebhookDeliveries(dbName string) []webhooks.Delivery {
+	if p.webhookController == nil {
+		return nil
+	}
+	return p.webhookController.Deliveries(dbName)
+}
+
+// SetMySQLDb sets the database of the users and roles of the server, which is used to find the roles granted to the
+// users that row-level security policies apply to.
+func (p *DoltDatabaseProvider) SetMySQLDb(mysqlDb *mysql_db.MySQLDb) {
+	p.mysqlDb = mysqlDb
+}
+
+// GrantedRoles implements rowsec.RoleProvider.
+func (p *DoltDatabaseProvider) GrantedRoles(ctx *sql.Context) []string {
+	if p.mysqlDb == nil {
+		return nil
+	}
+	rd := p.mysqlDb.Reader()
+	defer rd.Close()
+
+	client := ctx.Client()
+	user := p.mysqlDb.GetUser(rd, client.User, client.Address, false)
+	if user == nil {
+		return nil
+	}
+	var roles []string
+	for _, edge := range rd.GetToUserRoleEdges(mysql_db.RoleEdgesToKey{ToHost: user.Host, ToUser: user.User}) {
+		roles = append(roles, edge.FromUser)
+	}
+	return roles
+}
+
 func (p *DoltDatabaseProvider) FileSystem() filesys.Filesys {
 	return p.fs
 }
@@ -413,7 +463,7 @@ func (p *DoltDatabaseProvider) attemptCloneReplica(ctx *sql.Context, dbName stri
 	// TODO: remote params for AWS, others
 	// TODO: this needs to be robust in the face of the DB not having the default branch
 	// TODO: this treats every database not found error as a clone error, need to tighten
-	err := p.CloneDatabaseFromRemote(ctx, dbName, p.defaultBranch, remoteName, remoteUrl, -1, nil)
+	err := p.CloneDatabaseFromRemote(ctx, dbName, p.defaultBranch, remoteName, remoteUrl, -1, doltdb.SparseFilter{}, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -823,6 +873,8 @@ func (p *DoltDatabaseProvider) CloneDatabaseFromRemote(
 	ctx *sql.Context,
 	dbName, branch, remoteName, remoteUrl string,
 	depth int,
+	sparse doltdb.SparseFilter,
+	lazy *doltdb.LazyFetchConfig,
 	remoteParams map[string]string,
 ) error {
 	p.mu.Lock()
@@ -835,7 +887,7 @@ func (p *DoltDatabaseProvider) CloneDatabaseFromRemote(
 		return fmt.Errorf("cannot create DB, file exists at %s", dbName)
 	}
 
-	err := p.cloneDatabaseFromRemote(ctx, dbName, remoteName, branch, remoteUrl, depth, remoteParams)
+	err := p.cloneDatabaseFromRemote(ctx, dbName, remoteName, branch, remoteUrl, depth, sparse, lazy, remoteParams)
 	if err != nil {
 		// Make a best effort to clean up any artifacts on disk from a failed clone
 		// before we return the error
@@ -861,6 +913,8 @@ func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 	ctx *sql.Context,
 	dbName, remoteName, branch, remoteUrl string,
 	depth int,
+	sparse doltdb.SparseFilter,
+	lazy *doltdb.LazyFetchConfig,
 	remoteParams map[string]string,
 ) error {
 	if p.remoteDialer == nil {
@@ -883,7 +937,7 @@ func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 	}
 	p.applyDBLoadParamsToEnv(dEnv)
 
-	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, false, depth, dEnv)
+	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, false, depth, sparse, lazy, dEnv)
 	if err != nil {
 		return err
 	}
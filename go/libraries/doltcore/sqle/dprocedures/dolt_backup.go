@@ -18,10 +18,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/backup"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -30,7 +32,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -83,6 +87,16 @@ func doltBackup(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 		return nil, fmt.Errorf("AWS parameters are unavailable when running in server mode")
 	}
 
+	if apr.Contains(cli.IncrementalFlag) && funcParam != DoltBackupParamSync && funcParam != DoltBackupParamSyncUrl {
+		return nil, fmt.Errorf("--%s is only supported by '%s' and '%s'", cli.IncrementalFlag, DoltBackupParamSync, DoltBackupParamSyncUrl)
+	}
+	if apr.Contains(cli.AsOfParam) && funcParam != DoltBackupParamRestore {
+		return nil, fmt.Errorf("--%s is only supported by '%s'", cli.AsOfParam, DoltBackupParamRestore)
+	}
+	if apr.Contains(cli.RestoreBranchControl) && !apr.Contains(cli.AsOfParam) {
+		return nil, fmt.Errorf("--%s requires --%s", cli.RestoreBranchControl, cli.AsOfParam)
+	}
+
 	doltSess := dsess.DSessFromSess(ctx.Session)
 	dbData, ok := doltSess.GetDbData(ctx, dbName)
 	if !ok && funcParam != DoltBackupParamRestore {
@@ -106,7 +120,7 @@ func doltBackup(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 			return nil, errDoltBackupUsage(funcParam, []string{"name"}, nil)
 		}
 		name := apr.Arg(1)
-		err = doltBackupSync(ctx, dbData, doltSess, name)
+		err = doltBackupSync(ctx, dbData, doltSess, name, apr.Contains(cli.IncrementalFlag))
 	case DoltBackupParamSyncUrl:
 		if apr.NArg() != 2 {
 			return nil, errDoltBackupUsage(funcParam, []string{"remote_url"}, awsParamsUsage)
@@ -154,8 +168,8 @@ func doltBackupAdd(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dse
 
 // doltBackupSync syncs the current database to an existing backup identified by name in |apr|. The backup is looked up
 // from the repository state via |dbData.Rsr|. The sync operation copies all roots from the current database to the
-// backup location, overwriting any existing data.
-func doltBackupSync(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.DoltSession, backupName string) error {
+// backup location, overwriting any existing data. If |incremental| is set, the sync is recorded in the backup's catalog.
+func doltBackupSync(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.DoltSession, backupName string, incremental bool) error {
 	backups, err := dbData.Rsr.GetBackups()
 	if err != nil {
 		return err
@@ -166,7 +180,7 @@ func doltBackupSync(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *ds
 		return env.ErrBackupNotFound.New(backupName)
 	}
 
-	return syncRemote(ctx, dbData, dsess, backupRemote)
+	return syncRemote(ctx, dbData, dsess, backupRemote, incremental)
 }
 
 // doltBackupSyncUrl syncs the current database to a remote URL specified in |apr| without requiring the remote to exist
@@ -192,7 +206,7 @@ func doltBackupSyncUrl(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 	}
 
 	remote := env.NewRemote(DoltBackupParamSyncUrl, remoteUrl, remoteParams)
-	return syncRemote(ctx, dbData, dsess, remote)
+	return syncRemote(ctx, dbData, dsess, remote, apr.Contains(cli.IncrementalFlag))
 }
 
 // doltBackupRestore clones a database from the remote URL specified in |apr| into a new database with the name
@@ -202,6 +216,9 @@ func doltBackupSyncUrl(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 //
 // If the target database already exists, the restore operation fails unless the --force flag is provided, in which case
 // the existing database is dropped before cloning.
+//
+// With --as-of, the database is restored to the latest point recorded in the backup's catalog at or before the given
+// time, and the branch control data recorded with that point replaces the current branch control data.
 func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.DoltSession, apr *argparser.ArgParseResults) error {
 	remoteUrlScheme, remoteUrl, err := newAbsRemoteUrl(dsess, apr.Arg(1))
 	if err != nil {
@@ -233,6 +250,16 @@ func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 		return err
 	}
 
+	var catalogBs blobstore.Blobstore
+	var point backup.Point
+	asOf, restoreAsOf := apr.GetValue(cli.AsOfParam)
+	if restoreAsOf {
+		catalogBs, point, err = findBackupPoint(ctx, remoteUrl, asOf)
+		if err != nil {
+			return err
+		}
+	}
+
 	lookupDbName := apr.Arg(2)
 	hasLookupDb := dsess.Provider().HasDatabase(ctx, lookupDbName)
 	// We can't only check the databases from memory since this command can be run from subdirectories.
@@ -268,13 +295,98 @@ func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 	}
 
 	// Unlike CloneDatabaseFromRemote which clones tracking branches (remote refs), we need all local changes.
-	return actions.SyncRoots(ctx, remoteDb, newDb.DbData().Ddb, fileSys.TempDir(), runProgFuncs, stopProgFuncs)
+	if !restoreAsOf {
+		return actions.SyncRoots(ctx, remoteDb, newDb.DbData().Ddb, fileSys.TempDir(), runProgFuncs, stopProgFuncs)
+	}
+
+	root, ok := hash.MaybeParse(point.Root)
+	if !ok {
+		return fmt.Errorf("invalid root hash '%s' in the catalog of the backup at '%s'", point.Root, remoteUrl)
+	}
+	err = actions.SyncRootsAt(ctx, remoteDb, newDb.DbData().Ddb, root, fileSys.TempDir(), runProgFuncs, stopProgFuncs)
+	if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
+		return err
+	}
+	// The branch control data is shared by every database of the server, so it's only replaced when asked for
+	if !apr.Contains(cli.RestoreBranchControl) {
+		return nil
+	}
+	return restoreBranchControl(ctx, catalogBs, point)
+}
+
+// findBackupPoint returns the latest point recorded at or before |asOf| in the catalog of the backup at |backupUrl|,
+// along with the blobstore holding the catalog.
+func findBackupPoint(ctx *sql.Context, backupUrl, asOf string) (blobstore.Blobstore, backup.Point, error) {
+	asOfTime, err := backup.ParseAsOf(asOf)
+	if err != nil {
+		return nil, backup.Point{}, err
+	}
+
+	bs, err := dbfactory.CreateBlobstore(ctx, backupUrl)
+	if err != nil {
+		return nil, backup.Point{}, fmt.Errorf("--%s is not supported for the backup at '%s': %w", cli.AsOfParam, backupUrl, err)
+	}
+
+	catalog, _, err := backup.LoadCatalog(ctx, bs)
+	if err != nil {
+		return nil, backup.Point{}, err
+	}
+	point, ok := catalog.PointAsOf(asOfTime)
+	if !ok {
+		return nil, backup.Point{}, fmt.Errorf("the backup at '%s' has no point in time at or before %s, sync it with --%s to record one", backupUrl, asOf, cli.IncrementalFlag)
+	}
+	return bs, point, nil
+}
+
+// restoreBranchControl replaces the branch control data of the server with the data recorded for |point| in the
+// backup catalog stored in |bs|. Nothing is replaced if no data was recorded for |point|.
+func restoreBranchControl(ctx *sql.Context, bs blobstore.Blobstore, point backup.Point) error {
+	data, err := backup.LoadBranchControl(ctx, bs, point)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	branchAwareSession := branch_control.GetBranchAwareSession(ctx)
+	if branchAwareSession == nil {
+		return nil
+	}
+	controller := branchAwareSession.GetController()
+	if controller == nil {
+		return nil
+	}
+	if err = controller.LoadData(ctx, data /* isFirstLoad */, false); err != nil {
+		return err
+	}
+	return controller.SaveData(ctx, branchAwareSession.GetFileSystem())
+}
+
+// recordBackupPoint records the current root of |destDb|, a backup which was just synced, as a point in time in the
+// backup's catalog stored in |bs|, along with the branch control data of the server.
+func recordBackupPoint(ctx *sql.Context, destDb *doltdb.DoltDB, bs blobstore.Blobstore) error {
+	root, err := destDb.NomsRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	var branchControl []byte
+	if branchAwareSession := branch_control.GetBranchAwareSession(ctx); branchAwareSession != nil {
+		if controller := branchAwareSession.GetController(); controller != nil {
+			if serialized := controller.Serialized.Load(); serialized != nil {
+				branchControl = *serialized
+			}
+		}
+	}
+
+	return backup.RecordPoint(ctx, bs, root, branchControl, time.Now())
 }
 
 // syncRemote syncs the roots from |dbData| to the remote specified by |remote|. It prepares the remote database
 // location using PrepareDB, which creates directories for file:// URLs if they do not exist. The sync operation copies
 // all chunks from the source database to the destination, effectively overwriting the destination to match the source.
-func syncRemote(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.DoltSession, remote env.Remote) error {
+// Only the chunks the destination doesn't have yet are copied, and the chunks of its earlier roots are kept. If
+// |incremental| is set, the resulting root is recorded as a point in time in the backup's catalog, so that it can be
+// restored later with --as-of.
+func syncRemote(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.DoltSession, remote env.Remote, incremental bool) error {
 	// Commit the current session's working set to the persistent chunk store. This ensures that uncommitted transaction
 	// changes (e.g. INSERTs) are usually visible to the backup procedure, which reads directly from the roots.
 	err := dsess.CommitWorkingSet(ctx, ctx.GetCurrentDatabase(), ctx.GetTransaction())
@@ -290,6 +402,15 @@ func syncRemote(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.
 	// This fails with unsupported schemes (i.e. http[s]), but in such cases we shouldn't have to prepare the database.
 	// We primarily use this to initialize the directory for file URLs without a directory.
 	_ = dbfactory.PrepareDB(ctx, dbData.Ddb.Format(), remote.Url, params)
+
+	var catalogBs blobstore.Blobstore
+	if incremental {
+		catalogBs, err = dbfactory.CreateBlobstore(ctx, remote.Url)
+		if err != nil {
+			return fmt.Errorf("--%s is not supported for the backup at '%s': %w", cli.IncrementalFlag, remote.Url, err)
+		}
+	}
+
 	destDb, err := dsess.Provider().GetRemoteDB(ctx, dbData.Ddb.Format(), remote, true)
 	if err != nil {
 		return err
@@ -300,6 +421,9 @@ func syncRemote(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.
 		return err
 	}
 
+	if catalogBs != nil {
+		return recordBackupPoint(ctx, destDb, catalogBs)
+	}
 	return nil
 }
 
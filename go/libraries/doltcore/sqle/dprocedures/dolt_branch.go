@@ -23,6 +23,7 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
@@ -128,7 +129,21 @@ func renameBranch(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argpar
 	if err := branch_control.CanCreateBranch(ctx, newBranchName); err != nil {
 		return err
 	}
+	if err := dsess.CheckDefaultBranchUpdate(ctx, dbName, oldBranchName); err != nil {
+		return err
+	}
+	if err := checkBranchProtection(ctx, dbData, oldBranchName, ""); err != nil {
+		return err
+	}
 	force := apr.Contains(cli.ForceFlag)
+	if force {
+		if err := dsess.CheckDefaultBranchUpdate(ctx, dbName, newBranchName); err != nil {
+			return err
+		}
+		if err := checkBranchProtection(ctx, dbData, newBranchName, oldBranchName); err != nil {
+			return err
+		}
+	}
 
 	if !force {
 		err := validateBranchNotActiveInAnySession(ctx, oldBranchName)
@@ -239,6 +254,14 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argp
 		if err = branch_control.CanDeleteBranch(ctx, branchName); err != nil {
 			return err
 		}
+		if err = dsess.CheckDefaultBranchUpdate(ctx, dbName, branchName); err != nil {
+			return err
+		}
+		if !apr.Contains(cli.RemoteParam) {
+			if err = checkBranchProtection(ctx, dbData, branchName, ""); err != nil {
+				return err
+			}
+		}
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
@@ -464,6 +487,14 @@ func createNewBranch(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *arg
 	if err != nil {
 		return err
 	}
+	if apr.Contains(cli.ForceFlag) {
+		if err = dsess.CheckDefaultBranchUpdate(ctx, ctx.GetCurrentDatabase(), branchName); err != nil {
+			return err
+		}
+		if err = checkBranchProtection(ctx, dbData, branchName, startPt); err != nil {
+			return err
+		}
+	}
 	err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, startPt, apr.Contains(cli.ForceFlag), rsc)
 	if err != nil {
 		return err
@@ -513,6 +544,12 @@ func copyABranch(ctx *sql.Context, dbData env.DbData[*sql.Context], srcBr string
 		if err := branch_control.CanDeleteBranch(ctx, destBr); err != nil {
 			return err
 		}
+		if err := dsess.CheckDefaultBranchUpdate(ctx, ctx.GetCurrentDatabase(), destBr); err != nil {
+			return err
+		}
+		if err := checkBranchProtection(ctx, dbData, destBr, srcBr); err != nil {
+			return err
+		}
 	}
 	err := actions.CopyBranchOnDB(ctx, dbData.Ddb, srcBr, destBr, force, rsc)
 	if err != nil {
@@ -536,6 +573,43 @@ func copyABranch(ctx *sql.Context, dbData env.DbData[*sql.Context], srcBr string
 	return nil
 }
 
+// checkBranchProtection checks replacing the head of the branch |branchName| with the commit |newSpec|, or deleting the
+// branch if |newSpec| is empty, against the protection rules of the branch. Branches that don't exist yet aren't
+// protected.
+func checkBranchProtection(ctx *sql.Context, dbData env.DbData[*sql.Context], branchName string, newSpec string) error {
+	branchRef := ref.NewBranchRef(branchName)
+	ok, err := dbData.Ddb.HasRef(ctx, branchRef)
+	if err != nil || !ok {
+		return err
+	}
+	old, err := dbData.Ddb.ResolveCommitRef(ctx, branchRef)
+	if err != nil {
+		return err
+	}
+
+	var new *doltdb.Commit
+	if newSpec != "" {
+		headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+		if err != nil {
+			return err
+		}
+		cs, err := doltdb.NewCommitSpec(newSpec)
+		if err != nil {
+			return err
+		}
+		optCmt, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		new, ok = optCmt.ToCommit()
+		if !ok {
+			return doltdb.ErrGhostCommitEncountered
+		}
+	}
+	return branch_protection.CheckUpdate(ctx, dbData.Ddb, branchName, old, new)
+}
+
 // validateTracking takes in a full remote path, like `origin/main`, or a branch like 'main' and verifies that it's a valid upstream.
 // It errors out if it can't find the remote, or if it can't find the given branch. It returns the remote name and upstream branch.
 func validateTracking(ctx *sql.Context, dbData env.DbData[*sql.Context], maybeUpstream string, selectedBranch string) (string, string, error) {
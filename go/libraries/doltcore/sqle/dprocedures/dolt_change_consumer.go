@@ -0,0 +1,114 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltChangeConsumer is the stored procedure that manages the consumers of dolt_changes_since whose positions are
+// kept by the server. It takes one of:
+//
+//	'add', <name>, <branch>[, <cursor>]: adds a consumer of the changes committed to <branch>, starting after
+//	<cursor>, or at the start of the branch's history without one.
+//	'commit', <name>, <cursor>: records <cursor> as the position of the consumer, once it processed the change
+//	returned with that cursor.
+//	'remove', <name>: removes the consumer.
+//
+// Cursors are the change_cursor values returned by dolt_changes_since, or revisions of the consumer's branch, which
+// are resolved when the procedure is called. The consumers are listed by the dolt_change_consumers system table.
+func doltChangeConsumer(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltChangeConsumer(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltChangeConsumer(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+	ddb := dbData.Ddb
+
+	if len(args) < 2 || args[1] == "" {
+		return 1, fmt.Errorf("error: invalid argument, use 'dolt_change_consumers' system table to list change consumers")
+	}
+	name := args[1]
+
+	switch args[0] {
+	case "add":
+		if len(args) < 3 || len(args) > 4 {
+			return 1, fmt.Errorf("add takes a consumer name, a branch and an optional cursor: %w", InvalidArgErr)
+		}
+		branch, ok, err := ddb.HasBranch(ctx, args[2])
+		if err != nil {
+			return 1, err
+		} else if !ok {
+			return 1, fmt.Errorf("branch not found: %s", args[2])
+		}
+		var cursor doltdb.ChangeCursor
+		if len(args) == 4 {
+			cursor, err = doltdb.ResolveChangeCursor(ctx, ddb, ref.NewBranchRef(branch), args[3])
+			if err != nil {
+				return 1, err
+			}
+		}
+		err = ddb.AddChangeConsumer(ctx, doltdb.ChangeConsumer{Name: name, Branch: branch, Cursor: cursor.String()})
+		if err != nil {
+			return 1, err
+		}
+	case "commit":
+		if len(args) != 3 {
+			return 1, fmt.Errorf("commit takes a consumer name and a cursor: %w", InvalidArgErr)
+		}
+		consumer, ok, err := ddb.GetChangeConsumer(ctx, name)
+		if err != nil {
+			return 1, err
+		} else if !ok {
+			return 1, fmt.Errorf("%w: %s", doltdb.ErrChangeConsumerNotFound, name)
+		}
+		cursor, err := doltdb.ResolveChangeCursor(ctx, ddb, ref.NewBranchRef(consumer.Branch), args[2])
+		if err != nil {
+			return 1, err
+		}
+		if err = ddb.SetChangeConsumerCursor(ctx, name, cursor.String()); err != nil {
+			return 1, err
+		}
+	case "remove", "rm":
+		if len(args) != 2 {
+			return 1, fmt.Errorf("remove takes a consumer name: %w", InvalidArgErr)
+		}
+		if err := ddb.RemoveChangeConsumer(ctx, name); err != nil {
+			return 1, err
+		}
+	default:
+		return 1, fmt.Errorf("error: invalid argument '%s', expected one of add, commit or remove", args[0])
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltCIRecordResult records the result of a run of a dolt ci workflow against the HEAD commit of the current branch,
+// for branch protection rules that require the workflow. The run must have been made against a clean working set, so
+// that the result describes the commit.
+func doltCIRecordResult(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("dolt_ci_record_result expects two arguments: the workflow name and its status")
+	}
+	workflow, status := args[0], doltdb.CIWorkflowStatus(args[1])
+	if status != doltdb.CIWorkflowPassed && status != doltdb.CIWorkflowFailed {
+		return nil, fmt.Errorf("invalid workflow status '%s'; expected '%s' or '%s'", status, doltdb.CIWorkflowPassed, doltdb.CIWorkflowFailed)
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("empty database name")
+	}
+	dSess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return nil, fmt.Errorf("could not load database %s", dbName)
+	}
+	headHash, err := roots.Head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	stagedHash, err := roots.Staged.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	workingHash, err := roots.Working.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if headHash != stagedHash || headHash != workingHash {
+		return nil, fmt.Errorf("cannot record the result of workflow '%s'; the working set has uncommitted changes", workflow)
+	}
+
+	head, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	h, err := head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	result := doltdb.CIWorkflowResult{Workflow: workflow, Status: status, RecordedAt: ctx.QueryTime().UTC()}
+	if err = ddb.RecordCIWorkflowResult(ctx, h, result); err != nil {
+		return nil, err
+	}
+	return rowToIter(h.String()), nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
@@ -76,7 +77,13 @@ func doltClone(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 		depth = -1
 	}
 
-	err = sess.Provider().CloneDatabaseFromRemote(ctx, dir, branch, remoteName, remoteUrl, depth, remoteParms)
+	sparse := actions.SparseFilterFromArgs(apr)
+	lazy, err := actions.LazyFetchConfigFromArgs(apr)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.Provider().CloneDatabaseFromRemote(ctx, dir, branch, remoteName, remoteUrl, depth, sparse, lazy, remoteParms)
 	if err != nil {
 		return nil, err
 	}
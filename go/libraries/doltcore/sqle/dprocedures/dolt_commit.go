@@ -24,10 +24,12 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/matview"
 	"github.com/dolthub/dolt/go/libraries/utils/gpg"
 	"github.com/dolthub/dolt/go/store/datas"
 )
@@ -111,6 +113,11 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 		}
 	}
 
+	roots, err = matview.RefreshForCommit(ctx, dbName, roots)
+	if err != nil {
+		return "", false, err
+	}
+
 	var name, email string
 	if authorStr, ok := apr.GetValue(cli.AuthorParam); ok {
 		name, email, err = cli.ParseAuthor(authorStr)
@@ -199,6 +206,10 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 		return "", false, errors.New("nothing to commit")
 	}
 
+	if err = checkCommitProtection(ctx, dSess, dbName, roots.Staged, amend); err != nil {
+		return "", false, err
+	}
+
 	if apr.Contains(cli.SignFlag) || shouldSign {
 		keyId := apr.GetValueOrDefault(cli.SignFlag, "")
 
@@ -237,6 +248,32 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 	return h.String(), false, nil
 }
 
+// checkCommitProtection checks committing |staged| to the branch of |dbName| against the protection rules of the
+// branch. A commit that concludes a merge is checked as a merge of the commit being merged.
+func checkCommitProtection(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, staged doltdb.RootValue, amend bool) error {
+	headRef, err := dSess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	head, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return err
+	}
+
+	if ws.MergeActive() {
+		ddb, ok := dSess.GetDoltDB(ctx, dbName)
+		if !ok {
+			return sql.ErrDatabaseNotFound.New(dbName)
+		}
+		return branch_protection.CheckMergeCommit(ctx, ddb, headRef.GetPath(), head, ws.MergeState().Commit(), staged)
+	}
+	return branch_protection.CheckCommit(ctx, headRef.GetPath(), head, amend)
+}
+
 func getDoltArgs(ctx *sql.Context, row sql.Row, children []sql.Expression) ([]string, error) {
 	args := make([]string, len(children))
 	for i := range children {
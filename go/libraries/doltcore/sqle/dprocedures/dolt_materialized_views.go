@@ -0,0 +1,114 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/matview"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
+)
+
+// doltCreateMaterializedView creates a materialized view from its name and the SELECT statement defining it. The view
+// is a table holding the result of the statement, keyed by the columns it is grouped by or the primary keys of the
+// tables it selects, which is refreshed when its base tables are committed.
+func doltCreateMaterializedView(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("dolt_create_materialized_view expects two arguments: the name of the view and its definition")
+	}
+	if len(ctx.GetCurrentDatabase()) == 0 {
+		return nil, fmt.Errorf("empty database name")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return nil, err
+	}
+	if err := checkMaterializedViewAccess(ctx, args[0], args[1]); err != nil {
+		return nil, err
+	}
+	if err := matview.Create(ctx, args[0], args[1]); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltDropMaterializedView drops a materialized view and its table.
+func doltDropMaterializedView(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("dolt_drop_materialized_view expects one argument: the name of the view")
+	}
+	if len(ctx.GetCurrentDatabase()) == 0 {
+		return nil, fmt.Errorf("empty database name")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return nil, err
+	}
+	if err := matview.Drop(ctx, args[0]); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltRefreshMaterializedView rebuilds the named materialized views, or every materialized view if no names are given,
+// from the working set.
+func doltRefreshMaterializedView(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(ctx.GetCurrentDatabase()) == 0 {
+		return nil, fmt.Errorf("empty database name")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return nil, err
+	}
+	views, err := matview.Views(ctx, ctx.GetCurrentDatabase(), args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, view := range views {
+		if err = checkMaterializedViewAccess(ctx, view.Name, view.Definition); err != nil {
+			return nil, err
+		}
+	}
+	if err = matview.Refresh(ctx, args...); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// checkMaterializedViewAccess returns an error unless the current user can read every row of the tables read by
+// |definition|, the SELECT statement of the materialized view |name|. Views are computed without the privileges,
+// row-level security policies and column masks of the user creating or refreshing them, so that the view is the same
+// for everyone; a user may only compute a view of rows they can read.
+func checkMaterializedViewAccess(ctx *sql.Context, name, definition string) error {
+	if rowsec.Bypasses(ctx) {
+		return nil
+	}
+	tables, err := matview.BaseTables(definition)
+	if err != nil {
+		return err
+	}
+	dbName := ctx.GetCurrentDatabase()
+	for _, table := range tables {
+		if !dsess.HasTablePrivilege(ctx, dbName, table, sql.PrivilegeType_Select) {
+			return sql.ErrTableAccessDeniedForUser.New("'"+ctx.Client().User+"'", table)
+		}
+		if err = rowsec.CheckUnrestricted(ctx, dbName, doltdb.TableName{Name: table}, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -25,12 +25,14 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/matview"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -221,6 +223,14 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", fmt.Errorf("failed to get dbData")
 	}
 
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+	if err = branch_protection.CheckMerge(ctx, dbData.Ddb, headRef.GetPath(), spec.HeadC, spec.MergeC); err != nil {
+		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+
 	canFF, err := spec.HeadC.CanFastForwardTo(ctx, spec.MergeC)
 	if err != nil {
 		switch err {
@@ -371,6 +381,11 @@ func executeFFMerge(ctx *sql.Context, dbName string, squash bool, ws *doltdb.Wor
 		if err != nil {
 			return nil, err
 		}
+		// the branch is fast-forwarded before the transaction commits, so its restrictions must be checked here
+		err = dsess.CheckAccessRestrictionsUnchanged(ctx, dbName, headRef.GetPath(), ws.WorkingRoot(), workingRoot)
+		if err != nil {
+			return ws, err
+		}
 		err = dbData.Ddb.FastForward(ctx, headRef, cm2)
 		if err != nil {
 			return ws, err
@@ -448,6 +463,11 @@ func executeNoFFMerge(
 		return ws.WithStagedRoot(roots.Staged), nil, nil
 	}
 
+	roots, err = matview.RefreshForCommit(ctx, dbName, roots)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	pendingCommit, err := dSess.NewPendingCommit(ctx, dbName, roots, actions.CommitStagedProps{
 		Message:          msg,
 		Date:             spec.Date,
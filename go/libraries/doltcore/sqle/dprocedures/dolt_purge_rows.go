@@ -0,0 +1,100 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var doltPurgeRowsSchema = stringSchema("old_commit", "new_commit")
+
+// doltPurgeRows is the stored procedure that deletes rows of a table from the entire history of a database. It returns
+// a row for each commit that was rewritten, with the hash of the original commit and of the commit that replaced it.
+// The same rows are recorded in the dolt_purged_commits system table.
+func doltPurgeRows(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	rewritten, err := doDoltPurgeRows(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(rewritten))
+	for i, rc := range rewritten {
+		rows[i] = sql.Row{rc.Old.String(), rc.New.String()}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func doDoltPurgeRows(ctx *sql.Context, args []string) ([]rebase.RewrittenCommit, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return nil, err
+	}
+
+	apr, err := cli.CreatePurgeRowsArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	tableName, ok := apr.GetValue(cli.TableParam)
+	if !ok || len(tableName) == 0 {
+		return nil, fmt.Errorf("--%s is required: %w", cli.TableParam, InvalidArgErr)
+	}
+	where, ok := apr.GetValue(cli.WhereParam)
+	if !ok || len(where) == 0 {
+		// purging every row of a table is almost certainly a mistake, and can be done with an explicit filter
+		return nil, fmt.Errorf("--%s is required: %w", cli.WhereParam, InvalidArgErr)
+	}
+	if doltdb.HasDoltPrefix(tableName) {
+		return nil, fmt.Errorf("cannot purge rows from system table %s", tableName)
+	}
+	skipGC := apr.Contains(cli.SkipGCFlag)
+	if !skipGC && !DoltGCFeatureFlag {
+		return nil, errors.New("DOLT_GC() stored procedure disabled, use --skip-gc and collect garbage later")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	rewritten, err := rebase.PurgeRows(ctx, ddb, dbName, doltdb.TableName{Name: tableName}, where)
+	if err != nil {
+		return nil, err
+	}
+
+	if !skipGC {
+		// only a full collection removes the original commits from the old generation
+		err = RunDoltGC(ctx, ddb, types.GCModeFull, chunks.SimpleArchive, dbName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rewritten, nil
+}
@@ -23,10 +23,12 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/store/datas"
@@ -100,6 +102,12 @@ func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
 		return cmdFailure, "", fmt.Errorf("failed to read latest version of remote database %s@%s: %w", remote.Name, remote.Url, err)
 	}
 
+	for _, target := range targets {
+		if err = checkPushProtection(ctx, dbData.Ddb, remoteDB, target); err != nil {
+			return cmdFailure, "", err
+		}
+	}
+
 	tmpDir, err := dbData.Rsw.TempTableFilesDir()
 	if err != nil {
 		return cmdFailure, "", err
@@ -136,3 +144,29 @@ func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
 	// TODO : set upstream should be persisted outside of session
 	return cmdSuccess, returnMsg, nil
 }
+
+// checkPushProtection checks pushing |target| from |ddb| against the protection rules of the branch it updates in
+// |remoteDB|. The workflow results and approvals of the pushed commits are read from |remoteDB|, which enforces the
+// same rules if it is served by a remotesapi server.
+func checkPushProtection(ctx *sql.Context, ddb, remoteDB *doltdb.DoltDB, target *env.PushTarget) error {
+	if target.DestRef.GetType() != ref.BranchRefType {
+		return nil
+	}
+	ok, err := remoteDB.HasRef(ctx, target.DestRef)
+	if err != nil || !ok {
+		return err
+	}
+	old, err := remoteDB.ResolveCommitRef(ctx, target.DestRef)
+	if err != nil {
+		return err
+	}
+
+	var new *doltdb.Commit
+	if target.SrcRef != ref.EmptyBranchRef {
+		new, err = ddb.ResolveCommitRef(ctx, target.SrcRef)
+		if err != nil {
+			return err
+		}
+	}
+	return branch_protection.CheckUpdate(ctx, remoteDB, target.DestRef.GetPath(), old, new)
+}
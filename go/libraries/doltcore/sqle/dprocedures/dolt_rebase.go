@@ -33,6 +33,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/matview"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
 
@@ -844,6 +845,10 @@ func commitManuallyStagedChangesForStep(ctx *sql.Context, step rebase.RebasePlan
 	if !ok {
 		return fmt.Errorf("unable to get roots for current session")
 	}
+	roots, err = matview.RefreshForCommit(ctx, ctx.GetCurrentDatabase(), roots)
+	if err != nil {
+		return err
+	}
 	pendingCommit, err := doltSession.NewPendingCommit(ctx, ctx.GetCurrentDatabase(), roots, *commitProps)
 	if err != nil {
 		return err
@@ -21,6 +21,7 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
@@ -215,6 +216,10 @@ func resetSoft(
 
 	// If ref is "" that means HEAD, which makes reset --soft a no-op
 	if arg != "" {
+		// the head is moved without going through the working set, so the branch's restrictions must be checked here
+		if err := checkResetSoftAccessRestrictions(ctx, dbData, dSess, dbName, arg); err != nil {
+			return err
+		}
 		roots, err := actions.ResetSoftToRef(ctx, dbData, arg)
 		if err != nil {
 			return err
@@ -232,6 +237,36 @@ func resetSoft(
 	return nil
 }
 
+// checkResetSoftAccessRestrictions applies dsess.CheckAccessRestrictionsUnchanged to the head of the session and the
+// commit |arg| that a soft reset moves it to.
+func checkResetSoftAccessRestrictions(ctx *sql.Context, dbData env.DbData[*sql.Context], dSess *dsess.DoltSession, dbName, arg string) error {
+	cs, err := doltdb.NewCommitSpec(arg)
+	if err != nil {
+		return err
+	}
+	headRef, err := dbData.Rsr.CWBHeadRef(ctx)
+	if err != nil {
+		return err
+	}
+	optCmt, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return err
+	}
+	newHead, ok := optCmt.ToCommit()
+	if !ok {
+		return doltdb.ErrGhostCommitEncountered
+	}
+	newRoot, err := newHead.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", dbName)
+	}
+	return dsess.CheckAccessRestrictionsUnchanged(ctx, dbName, headRef.GetPath(), roots.Head, newRoot)
+}
+
 // resetHard resets the session working and staged to HEAD
 func resetHard(
 	ctx *sql.Context,
@@ -262,6 +297,21 @@ func resetHard(
 		if err != nil {
 			return err
 		}
+		oldHead, err := dSess.GetHeadCommit(ctx, dbName)
+		if err != nil {
+			return err
+		}
+		if err := branch_protection.CheckUpdate(ctx, dbData.Ddb, headRef.GetPath(), oldHead, newHead); err != nil {
+			return err
+		}
+		// the head is moved before the transaction commits, so the branch's restrictions must be checked here
+		ws, err := dSess.WorkingSet(ctx, dbName)
+		if err != nil {
+			return err
+		}
+		if err := dsess.CheckAccessRestrictionsUnchanged(ctx, dbName, headRef.GetPath(), ws.WorkingRoot(), roots.Working); err != nil {
+			return err
+		}
 		if err := dbData.Ddb.SetHeadToCommit(ctx, headRef, newHead); err != nil {
 			return err
 		}
@@ -0,0 +1,171 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var doltSquashHistorySchema = []*sql.Column{
+	{
+		Name:     "ref",
+		Type:     gmstypes.LongText,
+		Nullable: false,
+	},
+	{
+		Name:     "old_head",
+		Type:     gmstypes.LongText,
+		Nullable: false,
+	},
+	{
+		Name:     "new_head",
+		Type:     gmstypes.LongText,
+		Nullable: true,
+	},
+	{
+		Name:     "commits",
+		Type:     gmstypes.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "kept_commits",
+		Type:     gmstypes.Int64,
+		Nullable: false,
+	},
+}
+
+// doltSquashHistory is the stored procedure that squashes old history according to a retention policy, and then
+// collects the squashed commits. It returns a row for every ref, with its head before and after squashing, and the
+// number of commits in its history before and after squashing.
+func doltSquashHistory(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	squashed, err := doDoltSquashHistory(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(squashed))
+	for i, s := range squashed {
+		var newHead interface{}
+		if !s.NewHead.IsEmpty() {
+			newHead = s.NewHead.String()
+		}
+		rows[i] = sql.Row{s.Ref.String(), s.OldHead.String(), newHead, int64(s.Commits), int64(s.KeptCommits)}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func doDoltSquashHistory(ctx *sql.Context, args []string) ([]rebase.SquashedRef, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return nil, err
+	}
+
+	apr, err := cli.CreateSquashHistoryArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := retentionPolicyFromArgs(apr)
+	if err != nil {
+		return nil, err
+	}
+	dryRun := apr.Contains(cli.DryRunFlag)
+	skipGC := apr.Contains(cli.SkipGCFlag) || dryRun
+	if !skipGC && !DoltGCFeatureFlag {
+		return nil, errors.New("DOLT_GC() stored procedure disabled, use --skip-gc and collect garbage later")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	squashed, err := rebase.SquashHistory(ctx, ddb, policy, time.Now(), dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	if !skipGC && HistoryWasSquashed(squashed) {
+		// only a full collection removes the squashed commits from the old generation, and truncates the reflog
+		err = RunDoltGC(ctx, ddb, types.GCModeFull, chunks.SimpleArchive, dbName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return squashed, nil
+}
+
+// HistoryWasSquashed returns whether squashing history removed any commit from the history of a ref.
+func HistoryWasSquashed(squashed []rebase.SquashedRef) bool {
+	for _, s := range squashed {
+		if s.KeptCommits < s.Commits {
+			return true
+		}
+	}
+	return false
+}
+
+func retentionPolicyFromArgs(apr *argparser.ArgParseResults) (rebase.RetentionPolicy, error) {
+	var policy rebase.RetentionPolicy
+	for _, tier := range []struct {
+		param string
+		age   *time.Duration
+	}{
+		{cli.KeepAllParam, &policy.KeepAll},
+		{cli.KeepDailyParam, &policy.KeepDaily},
+		{cli.KeepWeeklyParam, &policy.KeepWeekly},
+	} {
+		if s, ok := apr.GetValue(tier.param); ok {
+			age, err := rebase.ParseRetentionAge(s)
+			if err != nil {
+				return rebase.RetentionPolicy{}, err
+			}
+			*tier.age = age
+		}
+	}
+	if policy.KeepAll == 0 && policy.KeepDaily == 0 && policy.KeepWeekly == 0 {
+		return rebase.RetentionPolicy{}, fmt.Errorf("at least one of --%s, --%s or --%s is required: %w", cli.KeepAllParam, cli.KeepDailyParam, cli.KeepWeeklyParam, InvalidArgErr)
+	}
+
+	if commits, ok := apr.GetValueList(cli.PreserveParam); ok {
+		for _, c := range commits {
+			h, ok := hash.MaybeParse(strings.TrimSpace(c))
+			if !ok {
+				return rebase.RetentionPolicy{}, fmt.Errorf("invalid commit hash to preserve: %s", c)
+			}
+			policy.Preserve = append(policy.Preserve, h)
+		}
+	}
+	return policy, nil
+}
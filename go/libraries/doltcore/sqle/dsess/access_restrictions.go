@@ -0,0 +1,373 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+// ErrAccessRestrictionsReadOnly is returned when a user without the SUPER privilege changes the row-level security
+// policies or column masks of the default branch of a database without writing their tables, for example by resetting,
+// reverting, merging, or checking out an older version of them.
+var ErrAccessRestrictionsReadOnly = errors.NewKind("the row-level security policies and column masks of branch %s of database %s may only be changed by users with the SUPER privilege")
+
+// HasSuperPrivilege returns whether the user of |ctx| has the SUPER privilege. Contexts without a SQL session have
+// every privilege.
+func HasSuperPrivilege(ctx context.Context) bool {
+	branchAwareSession := branch_control.GetBranchAwareSession(ctx)
+	if branchAwareSession == nil {
+		return true
+	}
+	privSet, counter := branchAwareSession.GetPrivilegeSet()
+	if counter == 0 {
+		return false
+	}
+	return privSet.Has(sql.PrivilegeType_Super)
+}
+
+// HasTablePrivilege returns whether the user of |ctx| has |privilege| on the table |tableName| of the database
+// |dbName|, either globally, on the database, or on the table. Contexts without a SQL session have every privilege.
+func HasTablePrivilege(ctx context.Context, dbName, tableName string, privilege sql.PrivilegeType) bool {
+	branchAwareSession := branch_control.GetBranchAwareSession(ctx)
+	if branchAwareSession == nil {
+		return true
+	}
+	privSet, counter := branchAwareSession.GetPrivilegeSet()
+	if counter == 0 {
+		return false
+	}
+	baseName, _ := doltdb.SplitRevisionDbName(dbName)
+	dbSet := privSet.Database(baseName)
+	return privSet.Has(privilege) || dbSet.Has(privilege) || dbSet.Table(tableName).Has(privilege)
+}
+
+// accessRestrictionsLiftedKey is the key of the context value set by WithoutAccessRestrictions.
+type accessRestrictionsLiftedKey struct{}
+
+// WithoutAccessRestrictions returns a copy of |ctx| for the internal queries of a session that must read and write
+// every row of their tables, such as the refresh of materialized views. Row-level security policies and column masks
+// don't apply to the queries run with the returned context, so their results must never be returned to the user.
+func WithoutAccessRestrictions(ctx context.Context) context.Context {
+	return context.WithValue(ctx, accessRestrictionsLiftedKey{}, true)
+}
+
+// AccessRestrictionsLifted returns whether |ctx| was derived from a context returned by WithoutAccessRestrictions.
+func AccessRestrictionsLifted(ctx context.Context) bool {
+	lifted, _ := ctx.Value(accessRestrictionsLiftedKey{}).(bool)
+	return lifted
+}
+
+// AccessRestrictionsRoots returns the roots that the row-level security policies and column masks of the database
+// |dbName| are read from. Restrictions are versioned with the data: the first root is the working root of the
+// revision of the database being queried, whose restrictions apply to it, and the second is the root of the head
+// commit of the database's default branch, whose restrictions apply to every revision of the database, so that they
+// can't be bypassed by querying an older commit or a branch created from one. A user is subject to the restrictions of
+// both roots, and the changes to the restrictions of the default branch apply to its other revisions once they are
+// committed.
+//
+// The root of the default branch is read once per transaction, so the restrictions of a transaction don't change
+// when another session commits new ones, and take effect from the next transaction.
+func AccessRestrictionsRoots(ctx *sql.Context, dbName string) ([]doltdb.RootValue, error) {
+	roots, ok := DSessFromSess(ctx.Session).GetRoots(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	baseName, _ := doltdb.SplitRevisionDbName(dbName)
+	cache := transactionAccessRestrictions(ctx)
+	defaultRoot, ok := cache.root(baseName)
+	if !ok {
+		var err error
+		defaultRoot, _, err = readDefaultBranchRoots(ctx, baseName)
+		if err != nil {
+			return nil, err
+		}
+		cache.cacheRoot(baseName, defaultRoot)
+	}
+	return []doltdb.RootValue{roots.Working, defaultRoot}, nil
+}
+
+// readDefaultBranchRoots reads the root of the head commit of the default branch of the database |baseName|, and the
+// persisted working root of the branch.
+func readDefaultBranchRoots(ctx *sql.Context, baseName string) (head doltdb.RootValue, working doltdb.RootValue, err error) {
+	branch, ddb, err := defaultBranch(ctx, baseName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	branchRef := ref.NewBranchRef(branch)
+	cm, err := ddb.ResolveCommitRef(ctx, branchRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	head, err = cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wsRef, err := ref.WorkingSetRefForHead(branchRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+	if err == doltdb.ErrWorkingSetNotFound {
+		// branches pushed to a sql-server may not have a working set yet
+		return head, head, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	return head, ws.WorkingRoot(), nil
+}
+
+// CheckDefaultBranchUpdate returns ErrAccessRestrictionsReadOnly if |branch| is the default branch of the database
+// |dbName|, the database has row-level security policies or column masks, and the current user doesn't have the
+// SUPER privilege. It guards the operations that move, rename or delete a branch without going through the working
+// set of a session, which could otherwise replace the policies and masks of the database.
+func CheckDefaultBranchUpdate(ctx *sql.Context, dbName string, branch string) error {
+	if HasSuperPrivilege(ctx) {
+		return nil
+	}
+	baseName, _ := doltdb.SplitRevisionDbName(dbName)
+	defaultHead, _, err := defaultBranch(ctx, baseName)
+	if err != nil {
+		return err
+	}
+	if branch != defaultHead {
+		return nil
+	}
+
+	head, working, err := readDefaultBranchRoots(ctx, baseName)
+	if err != nil {
+		return err
+	}
+	for _, root := range []doltdb.RootValue{head, working} {
+		restricted, err := hasAccessRestrictions(ctx, root)
+		if err != nil {
+			return err
+		}
+		if restricted {
+			return ErrAccessRestrictionsReadOnly.New(branch, baseName)
+		}
+	}
+	return nil
+}
+
+// CheckAccessRestrictionsUnchanged returns ErrAccessRestrictionsReadOnly if |branch| is the default branch of the
+// database |dbName|, the row-level security policies or column masks of |from| and |to| differ, and the current user
+// doesn't have the SUPER privilege. Writes to their tables are rejected by the tables themselves, so this guards the
+// version control operations that replace them.
+func CheckAccessRestrictionsUnchanged(ctx *sql.Context, dbName string, branch string, from, to doltdb.RootValue) error {
+	if from == nil || HasSuperPrivilege(ctx) {
+		return nil
+	}
+	baseName, _ := doltdb.SplitRevisionDbName(dbName)
+	defaultHead, _, err := defaultBranch(ctx, baseName)
+	if err != nil {
+		return err
+	}
+	if branch != defaultHead {
+		return nil
+	}
+
+	for _, tableName := range accessRestrictionsTables() {
+		before, _, err := from.GetTableHash(ctx, tableName)
+		if err != nil {
+			return err
+		}
+		after, _, err := to.GetTableHash(ctx, tableName)
+		if err != nil {
+			return err
+		}
+		if before != after {
+			return ErrAccessRestrictionsReadOnly.New(branch, baseName)
+		}
+	}
+	return nil
+}
+
+// checkWorkingSetAccessRestrictions applies CheckAccessRestrictionsUnchanged to the working roots of |startState| and
+// |workingSet|, two versions of the working set of a branch of the database |dbName|.
+func checkWorkingSetAccessRestrictions(ctx *sql.Context, dbName string, startState, workingSet *doltdb.WorkingSet) error {
+	if startState == nil {
+		return nil
+	}
+	headRef, err := workingSet.Ref().ToHeadRef()
+	if err != nil {
+		return err
+	}
+	if headRef.GetType() != ref.BranchRefType {
+		return nil
+	}
+	return CheckAccessRestrictionsUnchanged(ctx, dbName, headRef.GetPath(), startState.WorkingRoot(), workingSet.WorkingRoot())
+}
+
+// hasAccessRestrictions returns whether |root| has a table declaring row-level security policies or column masks.
+func hasAccessRestrictions(ctx *sql.Context, root doltdb.RootValue) (bool, error) {
+	for _, tableName := range accessRestrictionsTables() {
+		ok, err := root.HasTable(ctx, tableName)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// accessRestrictionsTables returns the names of the tables declaring row-level security policies and column masks.
+func accessRestrictionsTables() []doltdb.TableName {
+	return []doltdb.TableName{
+		{Name: doltdb.GetRowPoliciesTableName()},
+		{Name: doltdb.GetColumnMasksTableName()},
+	}
+}
+
+// defaultBranch returns the default branch of the database |baseName|, and the database's DoltDB.
+func defaultBranch(ctx *sql.Context, baseName string) (string, *doltdb.DoltDB, error) {
+	db, ok := DSessFromSess(ctx.Session).Provider().BaseDatabase(ctx, baseName)
+	if !ok {
+		return "", nil, sql.ErrDatabaseNotFound.New(baseName)
+	}
+	head, err := DefaultHead(ctx, baseName, db)
+	if err != nil {
+		return "", nil, err
+	}
+	return head, db.DbData().Ddb, nil
+}
+
+// RowPolicies returns the row-level security policies declared on |root| for |tableName|, as doltdb.GetRowPolicies
+// does. They are cached for the rest of the transaction of |ctx|, keyed by the hash of |root|.
+func RowPolicies(ctx *sql.Context, root doltdb.RootValue, tableName doltdb.TableName) ([]doltdb.RowPolicy, error) {
+	return loadCached(ctx, root, tableName, func(c *accessRestrictionsCache) map[accessRestrictionsKey][]doltdb.RowPolicy {
+		return c.policies
+	}, func() ([]doltdb.RowPolicy, error) {
+		return doltdb.GetRowPolicies(ctx, root, tableName)
+	})
+}
+
+// ColumnMasks returns the column masks declared on |root| for the columns of |tableName|, as doltdb.GetColumnMasks
+// does. They are cached for the rest of the transaction of |ctx|, keyed by the hash of |root|.
+func ColumnMasks(ctx *sql.Context, root doltdb.RootValue, tableName doltdb.TableName) ([]doltdb.ColumnMask, error) {
+	return loadCached(ctx, root, tableName, func(c *accessRestrictionsCache) map[accessRestrictionsKey][]doltdb.ColumnMask {
+		return c.masks
+	}, func() ([]doltdb.ColumnMask, error) {
+		return doltdb.GetColumnMasks(ctx, root, tableName)
+	})
+}
+
+// MaterializedViews returns the materialized views declared on |root|, as doltdb.GetMaterializedViews does. They are
+// cached for the rest of the transaction of |ctx|, keyed by the hash of |root|.
+func MaterializedViews(ctx *sql.Context, root doltdb.RootValue) ([]doltdb.MaterializedView, error) {
+	return loadCached(ctx, root, doltdb.TableName{}, func(c *accessRestrictionsCache) map[accessRestrictionsKey][]doltdb.MaterializedView {
+		return c.matViews
+	}, func() ([]doltdb.MaterializedView, error) {
+		return doltdb.GetMaterializedViews(ctx, root)
+	})
+}
+
+// accessRestrictionsCache caches the access restrictions read by a transaction. Every table a statement resolves is
+// checked for restrictions, so they would otherwise be read again for each of them.
+type accessRestrictionsCache struct {
+	mu sync.Mutex
+	// roots are the roots of the default branches read by AccessRestrictionsRoots, keyed by lower-case database name
+	roots    map[string]doltdb.RootValue
+	policies map[accessRestrictionsKey][]doltdb.RowPolicy
+	masks    map[accessRestrictionsKey][]doltdb.ColumnMask
+	matViews map[accessRestrictionsKey][]doltdb.MaterializedView
+}
+
+// accessRestrictionsKey identifies the restrictions read from a root for a table.
+type accessRestrictionsKey struct {
+	root  doltdb.DataCacheKey
+	table TableCacheKey
+}
+
+func newAccessRestrictionsCache() *accessRestrictionsCache {
+	return &accessRestrictionsCache{
+		roots:    make(map[string]doltdb.RootValue),
+		policies: make(map[accessRestrictionsKey][]doltdb.RowPolicy),
+		masks:    make(map[accessRestrictionsKey][]doltdb.ColumnMask),
+		matViews: make(map[accessRestrictionsKey][]doltdb.MaterializedView),
+	}
+}
+
+// transactionAccessRestrictions returns the cache of the transaction of |ctx|, or nil if it isn't a DoltTransaction,
+// in which case nothing is cached.
+func transactionAccessRestrictions(ctx *sql.Context) *accessRestrictionsCache {
+	tx, ok := ctx.GetTransaction().(*DoltTransaction)
+	if !ok {
+		return nil
+	}
+	return tx.accessRestrictions
+}
+
+func (c *accessRestrictionsCache) root(baseName string) (doltdb.RootValue, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	root, ok := c.roots[strings.ToLower(baseName)]
+	return root, ok
+}
+
+func (c *accessRestrictionsCache) cacheRoot(baseName string, root doltdb.RootValue) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.roots[strings.ToLower(baseName)] = root
+}
+
+// loadCached returns the entry of the map selected by |entries| for |root| and |tableName| in the cache of the
+// transaction of |ctx|, calling |load| to read and cache it if it isn't cached yet.
+func loadCached[T any](ctx *sql.Context, root doltdb.RootValue, tableName doltdb.TableName, entries func(*accessRestrictionsCache) map[accessRestrictionsKey]T, load func() (T, error)) (T, error) {
+	cache := transactionAccessRestrictions(ctx)
+	if cache == nil {
+		return load()
+	}
+	rootKey, err := doltdb.NewDataCacheKey(root)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	key := accessRestrictionsKey{
+		root:  rootKey,
+		table: TableCacheKey{Name: tableName.Name, Schema: tableName.Schema}.ToLower(),
+	}
+
+	cache.mu.Lock()
+	v, ok := entries(cache)[key]
+	cache.mu.Unlock()
+	if ok {
+		return v, nil
+	}
+
+	v, err = load()
+	if err != nil {
+		return v, err
+	}
+	cache.mu.Lock()
+	entries(cache)[key] = v
+	cache.mu.Unlock()
+	return v, nil
+}
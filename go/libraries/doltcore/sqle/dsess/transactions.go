@@ -88,6 +88,8 @@ type DoltTransaction struct {
 	dbStartPoints   map[string]dbRoot
 	savepoints      []savepoint
 	tCharacteristic sql.TransactionCharacteristic
+	// accessRestrictions caches the row-level security policies and column masks read by the transaction
+	accessRestrictions *accessRestrictionsCache
 }
 
 type dbRoot struct {
@@ -123,8 +125,9 @@ func NewDoltTransaction(
 	}
 
 	return &DoltTransaction{
-		dbStartPoints:   startPoints,
-		tCharacteristic: tCharacteristic,
+		dbStartPoints:      startPoints,
+		tCharacteristic:    tCharacteristic,
+		accessRestrictions: newAccessRestrictionsCache(),
 	}, nil
 }
 
@@ -406,6 +409,15 @@ func (tx *DoltTransaction) doCommit(
 
 	// TODO: no-op if the working set hasn't changed since the transaction started
 
+	err = checkWorkingSetAccessRestrictions(ctx, branchState.dbState.dbName, startState, workingSet)
+	if err != nil {
+		rollbackErr := tx.rollback(ctx)
+		if rollbackErr != nil {
+			return nil, nil, rollbackErr
+		}
+		return nil, nil, err
+	}
+
 	mergeOpts := branchState.EditOpts()
 
 	lockID := normalizedDbName + "\u0000" + workingSet.Ref().String()
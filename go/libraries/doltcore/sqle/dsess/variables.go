@@ -45,6 +45,8 @@ const (
 	ReplicateToRemote                    = "dolt_replicate_to_remote"
 	ReadReplicaRemote                    = "dolt_read_replica_remote"
 	ReadReplicaForcePull                 = "dolt_read_replica_force_pull"
+	ReadReplicaLazyFetch                 = "dolt_read_replica_lazy_fetch"
+	ReadReplicaLazyCacheMB               = "dolt_read_replica_lazy_cache_mb"
 	ReplicationRemoteURLTemplate         = "dolt_replication_remote_url_template"
 	SkipReplicationErrors                = "dolt_skip_replication_errors"
 	ReplicateHeads                       = "dolt_replicate_heads"
@@ -57,6 +59,7 @@ const (
 	DoltLogLevel                         = "dolt_log_level"
 	ShowSystemTables                     = "dolt_show_system_tables"
 	AllowCICreation                      = "dolt_allow_ci_creation"
+	ForceColumnMasks                     = "dolt_force_column_masks"
 
 	DoltClusterRoleVariable         = "dolt_cluster_role"
 	DoltClusterRoleEpochVariable    = "dolt_cluster_role_epoch"
@@ -71,6 +74,11 @@ const (
 	DoltStatsGCEnabled   = "dolt_stats_gc_enabled"
 
 	DoltAutoGCEnabled = "dolt_auto_gc_enabled"
+
+	BinlogReplicaCommitPolicy       = "dolt_binlog_replica_commit_policy"
+	BinlogReplicaCommitIntervalSecs = "dolt_binlog_replica_commit_interval_secs"
+	BinlogReplicaCommitTransactions = "dolt_binlog_replica_commit_transactions"
+	BinlogReplicaTagHourly          = "dolt_binlog_replica_tag_hourly"
 )
 
 const URLTemplateDatabasePlaceholder = "{database}"
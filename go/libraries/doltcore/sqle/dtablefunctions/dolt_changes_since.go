@@ -0,0 +1,416 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
+)
+
+var _ sql.TableFunction = (*ChangesSinceTableFunction)(nil)
+var _ sql.ExecSourceRel = (*ChangesSinceTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*ChangesSinceTableFunction)(nil)
+
+// ChangesSinceTableFunction implements the DOLT_CHANGES_SINCE table function, which returns every row change
+// committed to a branch after a cursor, in commit order. Each row carries the cursor that resumes the stream right
+// after it, so that a consumer can tail a branch by passing back the cursor of the last row it processed.
+//
+// It takes the cursor and, optionally, the branch to read. Without a branch, the HEAD of the session is read. The
+// cursor may be empty to read the whole history of the branch, or any revision in its first-parent history, such as
+// a commit hash or tag, to read the changes made after that revision.
+//
+// Instead of a cursor, the function may be given '--consumer' and the name of a consumer added with the
+// DOLT_CHANGE_CONSUMER procedure, in which case the changes committed to the consumer's branch after its position are
+// returned. The server keeps that position, which the consumer moves by calling DOLT_CHANGE_CONSUMER('commit', ...)
+// with the cursor of the last change it processed, so that a consumer can resume from the server after a restart
+// without persisting cursors itself. Go callers can read the same stream without SQL with dtables.NewChangesSinceIter.
+type ChangesSinceTableFunction struct {
+	database sql.Database
+	argExprs []sql.Expression
+}
+
+// changesSinceConsumerFlag is the argument that precedes the name of a change consumer whose changes are read.
+const changesSinceConsumerFlag = "--consumer"
+
+var changesSinceTableSchema = sql.Schema{
+	&sql.Column{Name: "change_cursor", Type: types.LongText},
+	&sql.Column{Name: "commit_hash", Type: types.LongText},
+	&sql.Column{Name: "committer", Type: types.LongText},
+	&sql.Column{Name: "commit_date", Type: types.Datetime},
+	&sql.Column{Name: "table_name", Type: types.LongText},
+	&sql.Column{Name: "diff_type", Type: types.LongText},
+	&sql.Column{Name: "from_row", Type: types.JSON, Nullable: true},
+	&sql.Column{Name: "to_row", Type: types.JSON, Nullable: true},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (ctf *ChangesSinceTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &ChangesSinceTableFunction{
+		database: database,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (ctf *ChangesSinceTableFunction) Name() string {
+	return "dolt_changes_since"
+}
+
+// Database implements the sql.Databaser interface
+func (ctf *ChangesSinceTableFunction) Database() sql.Database {
+	return ctf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (ctf *ChangesSinceTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nctf := *ctf
+	nctf.database = database
+	return &nctf, nil
+}
+
+// Expressions implements the sql.Expressioner interface
+func (ctf *ChangesSinceTableFunction) Expressions() []sql.Expression {
+	return ctf.argExprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (ctf *ChangesSinceTableFunction) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) < 1 || len(exprs) > 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(ctf.Name(), "1 or 2", len(exprs))
+	}
+
+	for _, expr := range exprs {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(ctf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(ctf.Name(), expr.String())
+		}
+	}
+
+	nctf := *ctf
+	nctf.argExprs = exprs
+	return &nctf, nil
+}
+
+// Children implements the sql.Node interface
+func (ctf *ChangesSinceTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (ctf *ChangesSinceTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return ctf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (ctf *ChangesSinceTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tblNames, err := ctf.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		subject := sql.PrivilegeCheckSubject{Database: ctf.database.Name(), Table: tblName}
+		operations = append(operations, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Schema implements the sql.Node interface
+func (ctf *ChangesSinceTableFunction) Schema() sql.Schema {
+	return changesSinceTableSchema
+}
+
+// Resolved implements the sql.Resolvable interface
+func (ctf *ChangesSinceTableFunction) Resolved() bool {
+	for _, expr := range ctf.argExprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (ctf *ChangesSinceTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (ctf *ChangesSinceTableFunction) String() string {
+	args := make([]string, len(ctf.argExprs))
+	for i, expr := range ctf.argExprs {
+		args[i] = expr.String()
+	}
+	return fmt.Sprintf("DOLT_CHANGES_SINCE(%s)", strings.Join(args, ", "))
+}
+
+// RowIter implements the sql.Node interface
+func (ctf *ChangesSinceTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqledb, ok := ctf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", ctf.database)
+	}
+	ddb := sqledb.DbData().Ddb
+
+	args := make([]string, len(ctf.argExprs))
+	for i, expr := range ctf.argExprs {
+		val, err := expr.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			continue
+		}
+		str, ok := val.(string)
+		if !ok {
+			return nil, sql.ErrInvalidArgumentDetails.New(ctf.Name(), expr.String())
+		}
+		args[i] = str
+	}
+
+	if args[0] == changesSinceConsumerFlag {
+		if len(args) != 2 || args[1] == "" {
+			return nil, fmt.Errorf("%s requires the name of a change consumer", changesSinceConsumerFlag)
+		}
+		consumer, ok, err := ddb.GetChangeConsumer(ctx, args[1])
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("%w: %s", doltdb.ErrChangeConsumerNotFound, args[1])
+		}
+		args = []string{consumer.Cursor, consumer.Branch}
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	// the changes are restricted by the policies and masks of the revision they are read from
+	dbName := sqledb.RevisionQualifiedName()
+	var head *doltdb.Commit
+	var headRef ref.DoltRef
+	var err error
+	if len(args) > 1 && args[1] != "" {
+		branch, ok, err := ddb.HasBranch(ctx, args[1])
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("branch not found: %s", args[1])
+		}
+		// revisions in the cursor, such as HEAD~1, are relative to the branch being read
+		headRef = ref.NewBranchRef(branch)
+		dbName = doltdb.RevisionDbName(sqledb.AliasedName(), branch)
+		head, err = ddb.ResolveCommitRef(ctx, headRef)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		head, err = sess.GetHeadCommit(ctx, sqledb.RevisionQualifiedName())
+		if err != nil {
+			return nil, err
+		}
+		headRef, err = sess.CWBHeadRef(ctx, sqledb.RevisionQualifiedName())
+		if err == doltdb.ErrOperationNotSupportedInDetachedHead {
+			// In detached HEAD state, we can still resolve commits without a branch ref
+			headRef = nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	cursor, err := doltdb.ResolveChangeCursor(ctx, ddb, headRef, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	itr, err := dtables.NewChangesSinceIter(ctx, ddb, head, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &changesSinceRowIter{
+		itr:          itr,
+		dbName:       dbName,
+		restrictions: make(map[doltdb.TableName]tableRestrictions),
+	}, nil
+}
+
+type changesSinceRowIter struct {
+	itr    *dtables.ChangesSinceIter
+	dbName string
+	// restrictions caches the row-level security policies and column masks of the changed tables
+	restrictions map[doltdb.TableName]tableRestrictions
+}
+
+type tableRestrictions struct {
+	policies *rowsec.Policies
+	masks    *rowsec.Masks
+}
+
+var _ sql.RowIter = (*changesSinceRowIter)(nil)
+
+func (c *changesSinceRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	var change dtables.Change
+	for {
+		var err error
+		change, err = c.itr.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := c.restrict(ctx, &change)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+	}
+
+	fromRow, err := changedRowToJson(ctx, change.FromSch, change.From)
+	if err != nil {
+		return nil, err
+	}
+	toRow, err := changedRowToJson(ctx, change.ToSch, change.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.Row{
+		change.Cursor.String(),
+		change.Cursor.Commit.String(),
+		change.Meta.Name,
+		change.Meta.Time(),
+		change.Table.String(),
+		change.DiffType,
+		fromRow,
+		toRow,
+	}, nil
+}
+
+// restrict applies the row-level security policies and column masks of the changed table to |change|. Like for the
+// dolt_diff system tables, a change is only accessible if the rows on both of its sides are, and the masked columns
+// of both rows are hidden. Returns false if |change| isn't accessible.
+func (c *changesSinceRowIter) restrict(ctx *sql.Context, change *dtables.Change) (bool, error) {
+	r, ok := c.restrictions[change.Table]
+	if !ok {
+		policies, masks, err := rowsec.Restrictions(ctx, c.dbName, change.Table)
+		if err != nil {
+			return false, err
+		}
+		r = tableRestrictions{policies: policies, masks: masks}
+		c.restrictions[change.Table] = r
+	}
+	if r.policies == nil && r.masks == nil {
+		return true, nil
+	}
+
+	to, from, err := rowsec.DiffSides(ctx, r.policies, r.masks, change.ToSch, change.FromSch)
+	if err != nil {
+		return false, err
+	}
+	if ok, err = restrictChangedRow(ctx, to, &change.To); err != nil || !ok {
+		return false, err
+	}
+	return restrictChangedRow(ctx, from, &change.From)
+}
+
+// restrictChangedRow masks |*row| in place, and returns whether it is accessible. A nil row is always accessible.
+func restrictChangedRow(ctx *sql.Context, side rowsec.DiffSide, row *sql.Row) (bool, error) {
+	if *row == nil {
+		return true, nil
+	}
+	if side.Filter != nil {
+		ok, err := side.Filter.Allows(ctx, *row)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	if side.Masker != nil {
+		masked, err := side.Masker.Apply(ctx, *row)
+		if err != nil {
+			return false, err
+		}
+		*row = masked
+	}
+	return true, nil
+}
+
+func (c *changesSinceRowIter) Close(_ *sql.Context) error {
+	return c.itr.Close()
+}
+
+// changedRowToJson returns |row| as a JSON object keyed by the column names of |sch|, or nil if |row| is nil.
+func changedRowToJson(ctx *sql.Context, sch schema.Schema, row sql.Row) (interface{}, error) {
+	if row == nil {
+		return nil, nil
+	}
+
+	cols := sch.GetAllCols().GetColumns()
+	obj := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		val := row[i]
+		if val == nil {
+			obj[col.Name] = nil
+			continue
+		}
+
+		sqlType := col.TypeInfo.ToSqlType()
+		switch sqlType.(type) {
+		case sql.DatetimeType,
+			sql.DecimalType,
+			sql.EnumType,
+			sql.StringType,
+			sql.SetType,
+			types.TupleType:
+			sqlVal, err := sqlType.SQL(ctx, nil, val)
+			if err != nil {
+				return nil, err
+			}
+			val = sqlVal.ToString()
+		case types.JsonType:
+			sqlVal, err := sqlType.SQL(ctx, nil, val)
+			if err != nil {
+				return nil, err
+			}
+			val = nil
+			if err = json.Unmarshal([]byte(sqlVal.ToString()), &val); err != nil {
+				return nil, err
+			}
+		}
+		obj[col.Name] = val
+	}
+
+	return types.JSONDocument{Val: obj}, nil
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	dolttable "github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/store/types"
@@ -231,7 +232,32 @@ func (dtf *DiffTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter,
 
 	dp := dtables.NewDiffPartition(dtf.tableDelta.ToTable, dtf.tableDelta.FromTable, toCommitStr, fromCommitStr, dtf.toDate, dtf.fromDate, toSchForPartition, fromSchForPartition, nil)
 
-	return dtables.NewDiffPartitionRowIter(dp, ddb), nil
+	return restrictDiffRows(ctx, sqledb, dtf.tableDelta, toSchForPartition, fromSchForPartition, dtables.NewDiffPartitionRowIter(dp, ddb))
+}
+
+// restrictDiffRows returns the rows of |iter|, rows of the diff |td| between rows of |fromSch| and rows of |toSch|,
+// restricted by the row-level security policies and column masks of the diffed table that apply to the current user.
+// Like for the dolt_diff system tables, the policies and masks are those of the revision |db|, not of the diffed
+// commits.
+func restrictDiffRows(ctx *sql.Context, db dsess.SqlDatabase, td diff.TableDelta, toSch, fromSch schema.Schema, iter sql.RowIter) (sql.RowIter, error) {
+	tableName := td.ToName
+	if td.IsDrop() {
+		tableName = td.FromName
+	}
+
+	policies, masks, err := rowsec.Restrictions(ctx, db.RevisionQualifiedName(), tableName)
+	if err != nil {
+		return nil, err
+	}
+	if policies == nil && masks == nil {
+		return iter, nil
+	}
+
+	to, from, err := rowsec.DiffSides(ctx, policies, masks, toSch, fromSch)
+	if err != nil {
+		return nil, err
+	}
+	return rowsec.NewDiffRowIter(iter, to, from), nil
 }
 
 // findMatchingDelta returns the best matching table delta for the table name
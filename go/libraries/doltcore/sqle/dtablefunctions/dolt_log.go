@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
@@ -46,6 +47,7 @@ type LogTableFunctionArgs struct {
 	minParents      int
 	showParents     bool
 	showSignature   bool
+	follow          bool
 }
 
 // Name implements the sql.TableFunction interface
@@ -138,6 +140,10 @@ func (ltfa *LogTableFunctionArgs) addOptions(ctx *sql.Context, expressions []sql
 	ltfa.minParents = minParents
 	ltfa.showParents = apr.Contains(cli.ParentsFlag)
 	ltfa.showSignature = apr.Contains(cli.ShowSignatureFlag)
+	ltfa.follow = apr.Contains(cli.FollowFlag)
+	if ltfa.follow && len(ltfa.tableNames) != 1 {
+		return sql.ErrInvalidArgumentDetails.New(ltfa.Name(), "--follow requires exactly one table")
+	}
 
 	decorateOption := apr.GetValueOrDefault(cli.DecorateFlag, "auto")
 	switch decorateOption {
@@ -277,6 +283,10 @@ func (ltf *LogTableFunction) getOptionsString() string {
 		options = append(options, "--tables", strings.Join(ltf.tableNames, ","))
 	}
 
+	if ltf.follow {
+		options = append(options, fmt.Sprintf("--%s", cli.FollowFlag))
+	}
+
 	return strings.Join(options, ", ")
 }
 
@@ -644,6 +654,9 @@ type logTableFunctionRowIter struct {
 	headHash      hash.Hash
 	showParents   bool
 	showSignature bool
+	// followedNames holds the names the followed table had at commits not yet visited, when following a table across
+	// renames
+	followedNames map[hash.Hash]doltdb.TableName
 }
 
 // NewLogTableFunctionRowIter creates iterator for single commit history traversal.
@@ -666,6 +679,7 @@ func (ltf *LogTableFunction) NewLogTableFunctionRowIter(ctx *sql.Context, ddb *d
 		cHashToRefs:   cHashToRefs,
 		headHash:      h,
 		tableNames:    tableNames,
+		followedNames: ltf.newFollowedNames(),
 	}, nil
 }
 
@@ -708,6 +722,7 @@ func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context,
 		cHashToRefs:   cHashToRefs,
 		headHash:      headHash,
 		tableNames:    tableNames,
+		followedNames: ltf.newFollowedNames(),
 	}, nil
 }
 
@@ -775,13 +790,20 @@ func (itr *logTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
 			}
 
 			didChange := false
-			for _, tableName := range itr.tableNames {
-				didChange, err = didTableChangeBetweenRootValues(ctx, childRV, parent0RV, parent1RV, tableName)
+			if itr.followedNames != nil {
+				didChange, err = itr.didFollowedTableChange(ctx, commitHash, commit, childRV, parent0RV, parent1RV)
 				if err != nil {
 					return nil, err
 				}
-				if didChange {
-					break
+			} else {
+				for _, tableName := range itr.tableNames {
+					didChange, err = didTableChangeBetweenRootValues(ctx, childRV, parent0RV, parent1RV, tableName)
+					if err != nil {
+						return nil, err
+					}
+					if didChange {
+						break
+					}
 				}
 			}
 
@@ -882,6 +904,72 @@ func shouldDecorateWithRefs(decoration string) bool {
 	return decoration == "full" || decoration == "short"
 }
 
+// newFollowedNames returns the map tracking the names of the followed table, or nil if no table is followed.
+func (ltf *LogTableFunction) newFollowedNames() map[hash.Hash]doltdb.TableName {
+	if !ltf.follow {
+		return nil
+	}
+	return make(map[hash.Hash]doltdb.TableName)
+}
+
+// didFollowedTableChange checks if the followed table changed between |commit| and its parents, which includes being
+// renamed. Renames are found by tag continuity, and the name the table had in each parent is recorded so that the
+// table is followed further down the history.
+func (itr *logTableFunctionRowIter) didFollowedTableChange(ctx *sql.Context, commitHash hash.Hash, commit *doltdb.Commit, child, parent0, parent1 doltdb.RootValue) (bool, error) {
+	tblName, ok := itr.followedNames[commitHash]
+	if !ok {
+		tblName = doltdb.TableName{Name: itr.tableNames[0]}
+	}
+	delete(itr.followedNames, commitHash)
+
+	tbl, correctedName, childOk, err := doltdb.GetTableInsensitive(ctx, child, tblName)
+	if err != nil {
+		return false, err
+	}
+	var sch schema.Schema
+	if childOk {
+		tblName.Name = correctedName
+		sch, err = tbl.GetSchema(ctx)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	parentHashes, err := commit.ParentHashes(ctx)
+	if err != nil {
+		return false, err
+	}
+	parents := []doltdb.RootValue{parent0}
+	if parent1 != nil {
+		parents = append(parents, parent1)
+	}
+
+	renamed := false
+	for i, parent := range parents {
+		parentName := tblName
+		if childOk {
+			resolved, ok, err := diff.ResolveTableAcrossRenames(ctx, parent, child, tblName, sch)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				parentName = resolved
+			}
+		}
+		if parentName.Name != tblName.Name {
+			renamed = true
+		}
+		if _, ok := itr.followedNames[parentHashes[i]]; !ok {
+			itr.followedNames[parentHashes[i]] = parentName
+		}
+	}
+
+	if renamed {
+		return true, nil
+	}
+	return didTableChangeBetweenRootValues(ctx, child, parent0, parent1, tblName.Name)
+}
+
 // didTableChangeBetweenRootValues checks if the given table changed between the two given root values.
 func didTableChangeBetweenRootValues(ctx *sql.Context, child, parent0, parent1 doltdb.RootValue, tableName string) (bool, error) {
 	// TODO: schema
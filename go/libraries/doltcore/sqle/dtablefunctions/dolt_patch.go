@@ -30,13 +30,13 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
-	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/types"
@@ -169,7 +169,7 @@ func (p *PatchTableFunction) PartitionRows(ctx *sql.Context, partition sql.Parti
 	includeSchemaDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), schemaChangePartitionKey)
 	includeDataDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), dataChangePartitionKey)
 
-	patches, err := getPatchNodes(ctx, sqledb.DbData(), tableDeltas, fromRefDetails, toRefDetails, includeSchemaDiff, includeDataDiff)
+	patches, err := getPatchNodes(ctx, sqledb, tableDeltas, fromRefDetails, toRefDetails, includeSchemaDiff, includeDataDiff)
 	if err != nil {
 		return nil, err
 	}
@@ -485,7 +485,7 @@ type patchNode struct {
 	dataPatchStmts   []string
 }
 
-func getPatchNodes(ctx *sql.Context, dbData env.DbData[*sql.Context], tableDeltas []diff.TableDelta, fromRefDetails, toRefDetails *refDetails, includeSchemaDiff, includeDataDiff bool) (patches []*patchNode, err error) {
+func getPatchNodes(ctx *sql.Context, sqledb dsess.SqlDatabase, tableDeltas []diff.TableDelta, fromRefDetails, toRefDetails *refDetails, includeSchemaDiff, includeDataDiff bool) (patches []*patchNode, err error) {
 	for _, td := range tableDeltas {
 		if td.FromTable == nil && td.ToTable == nil {
 			// no diff
@@ -529,7 +529,10 @@ func getPatchNodes(ctx *sql.Context, dbData env.DbData[*sql.Context], tableDelta
 		// Get DATA DIFF
 		var dataStmts []string
 		if includeDataDiff && canGetDataDiff(ctx, td) {
-			dataStmts, err = getUserTableDataSqlPatch(ctx, dbData, td, fromRefDetails, toRefDetails)
+			if err = checkPatchPolicies(ctx, sqledb, tblName); err != nil {
+				return nil, err
+			}
+			dataStmts, err = getUserTableDataSqlPatch(ctx, sqledb, td, fromRefDetails, toRefDetails)
 			if err != nil {
 				return nil, err
 			}
@@ -541,6 +544,20 @@ func getPatchNodes(ctx *sql.Context, dbData env.DbData[*sql.Context], tableDelta
 	return patches, nil
 }
 
+// checkPatchPolicies returns rowsec.ErrRestrictedTableUnavailable if the current user is subject to the row-level
+// security policies of |tableName|. A patch of the rows the user can read wouldn't apply to the table, so it's not
+// generated at all. Column masks only hide values, so they are applied to the patch like to the diff it's made from.
+func checkPatchPolicies(ctx *sql.Context, sqledb dsess.SqlDatabase, tableName doltdb.TableName) error {
+	policies, _, err := rowsec.Restrictions(ctx, sqledb.RevisionQualifiedName(), tableName)
+	if err != nil {
+		return err
+	}
+	if policies != nil {
+		return rowsec.ErrRestrictedTableUnavailable.New("dolt_patch()", tableName.Name)
+	}
+	return nil
+}
+
 func canGetDataDiff(ctx *sql.Context, td diff.TableDelta) bool {
 	if td.IsDrop() {
 		return false // don't output DELETE FROM statements after DROP TABLE
@@ -559,9 +576,9 @@ func canGetDataDiff(ctx *sql.Context, td diff.TableDelta) bool {
 	return true
 }
 
-func getUserTableDataSqlPatch(ctx *sql.Context, dbData env.DbData[*sql.Context], td diff.TableDelta, fromRefDetails, toRefDetails *refDetails) ([]string, error) {
+func getUserTableDataSqlPatch(ctx *sql.Context, sqledb dsess.SqlDatabase, td diff.TableDelta, fromRefDetails, toRefDetails *refDetails) ([]string, error) {
 	// ToTable is used as target table as it cannot be nil at this point
-	diffSch, projections, ri, err := getDiffQuery(ctx, dbData, td, fromRefDetails, toRefDetails)
+	diffSch, projections, ri, err := getDiffQuery(ctx, sqledb, td, fromRefDetails, toRefDetails)
 	if err != nil {
 		return nil, err
 	}
@@ -624,7 +641,7 @@ func getDataSqlPatchResults(ctx *sql.Context, diffQuerySch, targetSch sql.Schema
 // on diff table function row iter. This function attempts to imitate running a query
 // fmt.Sprintf("select %s, %s from dolt_diff('%s', '%s', '%s')", columnsWithDiff, "diff_type", fromRef, toRef, tableName)
 // on sql engine, which returns the schema and rowIter of the final data diff result.
-func getDiffQuery(ctx *sql.Context, dbData env.DbData[*sql.Context], td diff.TableDelta, fromRefDetails, toRefDetails *refDetails) (sql.Schema, []sql.Expression, sql.RowIter, error) {
+func getDiffQuery(ctx *sql.Context, sqledb dsess.SqlDatabase, td diff.TableDelta, fromRefDetails, toRefDetails *refDetails) (sql.Schema, []sql.Expression, sql.RowIter, error) {
 	diffTableSchema, err := dtables.GetDiffTableSchemaAndJoiner(td.ToTable.Format(), td.FromSch, td.ToSch)
 	if err != nil {
 		return nil, nil, nil, err
@@ -638,7 +655,10 @@ func getDiffQuery(ctx *sql.Context, dbData env.DbData[*sql.Context], td diff.Tab
 	diffQuerySqlSch, projections := getDiffQuerySqlSchemaAndProjections(diffPKSch.Schema, columnsWithDiff)
 
 	dp := dtables.NewDiffPartition(td.ToTable, td.FromTable, toRefDetails.hashStr, fromRefDetails.hashStr, toRefDetails.commitTime, fromRefDetails.commitTime, td.ToSch, td.FromSch, nil)
-	ri := dtables.NewDiffPartitionRowIter(dp, dbData.Ddb)
+	ri, err := restrictDiffRows(ctx, sqledb, td, td.ToSch, td.FromSch, dtables.NewDiffPartitionRowIter(dp, sqledb.DbData().Ddb))
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	return diffQuerySqlSch, projections, ri, nil
 }
@@ -0,0 +1,538 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const queryHistoryDefaultRowCount = 1000
+
+var _ sql.TableFunction = (*QueryHistoryTableFunction)(nil)
+var _ sql.CatalogTableFunction = (*QueryHistoryTableFunction)(nil)
+var _ sql.ExecSourceRel = (*QueryHistoryTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*QueryHistoryTableFunction)(nil)
+
+// QueryHistoryTableFunction runs a SELECT query against every commit from a starting commit to an ending commit, and
+// returns the rows of each result along with the commit they were read from. The commits are those listed by
+// dolt_log('<from>..<to>'), followed by the starting commit, and the query runs against each of them as the rows are
+// read. When the tables read by the query are unchanged from one commit to the next, the result of the query is reused.
+type QueryHistoryTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	exprs    []sql.Expression
+
+	engine *gms.Engine
+	query  string
+	// tables are the tables read by the query, and cacheable is set if its result only depends on their content.
+	tables    []string
+	cacheable bool
+	querySch  sql.Schema
+	sqlSch    sql.Schema
+}
+
+// nondeterministicFunctions are the functions whose result doesn't only depend on the tables read by a query, which
+// prevent its results from being reused across commits. Functions starting with dolt_ are also nondeterministic.
+var nondeterministicFunctions = map[string]bool{
+	"active_branch": true, "connection_id": true, "curdate": true, "current_date": true, "current_time": true,
+	"current_timestamp": true, "current_user": true, "curtime": true, "database": true, "found_rows": true,
+	"hashof": true, "last_insert_id": true, "localtime": true, "localtimestamp": true, "now": true, "rand": true,
+	"row_count": true, "schema": true, "session_user": true, "sysdate": true, "system_user": true,
+	"unix_timestamp": true, "user": true, "utc_date": true, "utc_time": true, "utc_timestamp": true, "uuid": true,
+	"uuid_short": true,
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (tf *QueryHistoryTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &QueryHistoryTableFunction{
+		ctx:      ctx,
+		database: database,
+	}
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// WithCatalog implements the sql.CatalogTableFunction interface
+func (tf *QueryHistoryTableFunction) WithCatalog(c sql.Catalog) (sql.TableFunction, error) {
+	newInstance := *tf
+	pro, ok := c.(sql.DatabaseProvider)
+	if !ok {
+		return nil, fmt.Errorf("unable to get database provider")
+	}
+	newInstance.engine = gms.NewDefault(pro)
+	if err := newInstance.evalSchema(); err != nil {
+		return nil, err
+	}
+	return &newInstance, nil
+}
+
+// evalSchema parses the query and determines the schema of its result at the ending commit.
+func (tf *QueryHistoryTableFunction) evalSchema() error {
+	args, err := expressionsToString(tf.ctx, tf.exprs)
+	if err != nil {
+		return err
+	}
+	query := strings.TrimSpace(args[0])
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return err
+	}
+	if _, ok := stmt.(sqlparser.SelectStatement); !ok {
+		return fmt.Errorf("query must be a SELECT statement")
+	}
+	tf.query = query
+	tf.tables, tf.cacheable = queryHistoryTables(stmt)
+
+	sqledb, ok := tf.database.(dsess.SqlDatabase)
+	if !ok {
+		return fmt.Errorf("unexpected database type: %T", tf.database)
+	}
+	to, err := tf.resolveCommit(tf.ctx, sqledb, args[2])
+	if err != nil {
+		return err
+	}
+	h, err := to.HashOf()
+	if err != nil {
+		return err
+	}
+	sch, _, err := tf.queryAt(tf.ctx, sqledb, h, false)
+	if err != nil {
+		return err
+	}
+
+	tf.querySch = sch
+	tf.sqlSch = sql.Schema{
+		&sql.Column{Name: "commit_hash", Type: types.Text, Source: tf.Name()},
+		&sql.Column{Name: "commit_date", Type: types.Datetime3, Source: tf.Name()},
+	}
+	for _, col := range sch.Copy() {
+		col.Source = tf.Name()
+		tf.sqlSch = append(tf.sqlSch, col)
+	}
+	return nil
+}
+
+// queryHistoryTables returns the tables read by |stmt|, and whether its result only depends on the content of those
+// tables. Queries reading other databases, reading tables AS OF a revision, calling table functions or calling
+// nondeterministic functions don't.
+func queryHistoryTables(stmt sqlparser.Statement) ([]string, bool) {
+	var tables []string
+	seen := make(map[string]bool)
+	cacheable := true
+	var visit sqlparser.Visit
+	visit = func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.SetOp:
+			// Walk doesn't visit the WITH, ORDER BY and LIMIT clauses of a UNION, which may read tables too
+			if node.With != nil {
+				_ = sqlparser.Walk(visit, node.With)
+			}
+			if node.Limit != nil {
+				_ = sqlparser.Walk(visit, node.Limit)
+			}
+			_ = sqlparser.Walk(visit, node.OrderBy)
+		case *sqlparser.AliasedTableExpr:
+			if node.AsOf != nil {
+				cacheable = false
+			}
+			if tn, ok := node.Expr.(sqlparser.TableName); ok {
+				if !tn.DbQualifier.IsEmpty() {
+					cacheable = false
+				}
+				name := tn.Name.String()
+				if !seen[strings.ToLower(name)] {
+					seen[strings.ToLower(name)] = true
+					tables = append(tables, name)
+				}
+			}
+		case *sqlparser.TableFuncExpr:
+			cacheable = false
+		case *sqlparser.FuncExpr:
+			name := node.Name.Lowered()
+			if nondeterministicFunctions[name] || strings.HasPrefix(name, "dolt_") {
+				cacheable = false
+			}
+		}
+		return true, nil
+	}
+	_ = sqlparser.Walk(visit, stmt)
+	return tables, cacheable
+}
+
+// resolveCommit resolves the commit spec |spec| on the database |sqledb|.
+func (tf *QueryHistoryTableFunction) resolveCommit(ctx *sql.Context, sqledb dsess.SqlDatabase, spec string) (*doltdb.Commit, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	headRef, err := sess.CWBHeadRef(ctx, sqledb.RevisionQualifiedName())
+	if err == doltdb.ErrOperationNotSupportedInDetachedHead {
+		headRef = nil
+	} else if err != nil {
+		return nil, err
+	}
+	cs, err := doltdb.NewCommitSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	optCmt, err := sqledb.DbData().Ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return nil, err
+	}
+	commit, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return commit, nil
+}
+
+// queryAt runs the query against the commit |h|, and returns the schema of its result and, if |readRows| is set, its
+// rows. The query runs on a read-only revision database of the commit, which is the current database while it runs.
+func (tf *QueryHistoryTableFunction) queryAt(ctx *sql.Context, sqledb dsess.SqlDatabase, h hash.Hash, readRows bool) (sql.Schema, []sql.Row, error) {
+	// The query gets its own context, so that it doesn't cancel the statement that runs it when it is closed. The session
+	// ignores autocommit while it runs, so that it doesn't commit the transaction either.
+	qctx := sql.NewContext(ctx, sql.WithSession(ctx.Session))
+	ignoreAutoCommit := ctx.GetIgnoreAutoCommit()
+	qctx.SetIgnoreAutoCommit(true)
+	defer qctx.SetIgnoreAutoCommit(ignoreAutoCommit)
+	prevDb := ctx.GetCurrentDatabase()
+	ctx.SetCurrentDatabase(doltdb.RevisionDbName(sqledb.AliasedName(), h.String()))
+	defer ctx.SetCurrentDatabase(prevDb)
+
+	sch, iter, _, err := tf.engine.Query(qctx, tf.query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !readRows {
+		return sch, nil, iter.Close(qctx)
+	}
+	rows, err := sql.RowIterToRows(qctx, iter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sch, rows, nil
+}
+
+// cacheKey returns a key identifying the content of the tables read by the query on |root|, along with the collation
+// and the row-level security policies and column masks of |root|, which apply to the query. Results of the query on
+// roots with the same key are the same. Returns false if the result of the query can't be reused.
+func (tf *QueryHistoryTableFunction) cacheKey(ctx *sql.Context, root doltdb.RootValue) (string, bool, error) {
+	if !tf.cacheable {
+		return "", false, nil
+	}
+	collation, err := root.GetCollation(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d;", collation)
+	for _, name := range []string{doltdb.GetRowPoliciesTableName(), doltdb.GetColumnMasksTableName()} {
+		tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return "", false, err
+		}
+		if ok {
+			h, err := tbl.HashOf()
+			if err != nil {
+				return "", false, err
+			}
+			sb.WriteString(h.String())
+		}
+		sb.WriteString(";")
+	}
+	for _, name := range tf.tables {
+		tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, root, doltdb.TableName{Name: name})
+		if err != nil {
+			return "", false, err
+		}
+		if !ok {
+			// System tables and views aren't stored in the root, so their content is unknown
+			return "", false, nil
+		}
+		h, err := tbl.HashOf()
+		if err != nil {
+			return "", false, err
+		}
+		sb.WriteString(h.String())
+		sb.WriteString(";")
+	}
+	return sb.String(), true, nil
+}
+
+func (tf *QueryHistoryTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(tf.Schema())
+	numRows, _, err := tf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (tf *QueryHistoryTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return queryHistoryDefaultRowCount, false, nil
+}
+
+// RowIter implements the sql.Node interface
+func (tf *QueryHistoryTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	args, err := expressionsToString(ctx, tf.exprs)
+	if err != nil {
+		return nil, err
+	}
+	sqledb, ok := tf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", tf.database)
+	}
+	from, err := tf.resolveCommit(ctx, sqledb, args[1])
+	if err != nil {
+		return nil, err
+	}
+	to, err := tf.resolveCommit(ctx, sqledb, args[2])
+	if err != nil {
+		return nil, err
+	}
+	fromHash, err := from.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	toHash, err := to.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	ddb := sqledb.DbData().Ddb
+	itr, err := commitwalk.GetDotDotRevisionsIterator[*sql.Context](ctx, ddb, []hash.Hash{toHash}, ddb, []hash.Hash{fromHash}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &queryHistoryRowIter{tf: tf, sqledb: sqledb, commits: itr, from: from, fromHash: fromHash}, nil
+}
+
+// queryHistoryRowIter is the sql.RowIter of dolt_query_history. It runs the query against one commit at a time, when
+// the rows of the previous commit have been read.
+type queryHistoryRowIter struct {
+	tf       *QueryHistoryTableFunction
+	sqledb   dsess.SqlDatabase
+	commits  doltdb.CommitItr[*sql.Context]
+	from     *doltdb.Commit
+	fromHash hash.Hash
+	// done is set once the starting commit, which is the last one, has been queried
+	done bool
+
+	// commitHash and commitDate are the commit of rows, the result of the query at that commit
+	commitHash string
+	commitDate time.Time
+	rows       []sql.Row
+	i          int
+	// key is the cache key of rows, which are reused by the next commit with the same key
+	key       string
+	cacheable bool
+}
+
+var _ sql.RowIter = (*queryHistoryRowIter)(nil)
+
+// Next implements the sql.RowIter interface
+func (itr *queryHistoryRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for itr.i >= len(itr.rows) {
+		if err := itr.nextCommit(ctx); err != nil {
+			return nil, err
+		}
+	}
+	row := append(sql.NewRow(itr.commitHash, itr.commitDate), itr.rows[itr.i]...)
+	itr.i++
+	return row, nil
+}
+
+// nextCommit runs the query against the next commit, or returns io.EOF if every commit has been queried.
+func (itr *queryHistoryRowIter) nextCommit(ctx *sql.Context) error {
+	if itr.done {
+		return io.EOF
+	}
+	h, optCmt, meta, _, err := itr.commits.Next(ctx)
+	var commit *doltdb.Commit
+	if err == io.EOF {
+		h, commit, meta, itr.done = itr.fromHash, itr.from, nil, true
+	} else if err != nil {
+		return err
+	} else {
+		var ok bool
+		commit, ok = optCmt.ToCommit()
+		if !ok {
+			return doltdb.ErrGhostCommitEncountered
+		}
+	}
+
+	if meta == nil {
+		meta, err = commit.GetCommitMeta(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	root, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	key, cacheable, err := itr.tf.cacheKey(ctx, root)
+	if err != nil {
+		return err
+	}
+	if !cacheable || !itr.cacheable || key != itr.key {
+		itr.rows, err = itr.tf.resultAt(ctx, itr.sqledb, h)
+		if err != nil {
+			return err
+		}
+	}
+	itr.key, itr.cacheable = key, cacheable
+	itr.commitHash, itr.commitDate = h.String(), meta.Time()
+	itr.i = 0
+	return nil
+}
+
+// Close implements the sql.RowIter interface
+func (itr *queryHistoryRowIter) Close(*sql.Context) error {
+	return nil
+}
+
+// resultAt returns the rows of the query at the commit |h|, converted to the schema of the query at the ending commit.
+// Commits where a table read by the query doesn't exist have no rows.
+func (tf *QueryHistoryTableFunction) resultAt(ctx *sql.Context, sqledb dsess.SqlDatabase, h hash.Hash) ([]sql.Row, error) {
+	sch, rows, err := tf.queryAt(ctx, sqledb, h, true)
+	if sql.ErrTableNotFound.Is(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query failed at commit %s: %w", h.String(), err)
+	}
+	if len(sch) != len(tf.querySch) {
+		return nil, fmt.Errorf("query returned %d columns at commit %s, but %d columns at the ending commit", len(sch), h.String(), len(tf.querySch))
+	}
+	for _, row := range rows {
+		for i, v := range row {
+			if row[i], _, err = tf.querySch[i].Type.Convert(ctx, v); err != nil {
+				return nil, fmt.Errorf("query failed at commit %s: %w", h.String(), err)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// Database implements the sql.Databaser interface
+func (tf *QueryHistoryTableFunction) Database() sql.Database {
+	return tf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (tf *QueryHistoryTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ntf := *tf
+	ntf.database = database
+	return &ntf, nil
+}
+
+// Expressions implements the sql.Expressioner interface
+func (tf *QueryHistoryTableFunction) Expressions() []sql.Expression {
+	return tf.exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (tf *QueryHistoryTableFunction) WithExpressions(expressions ...sql.Expression) (sql.Node, error) {
+	if len(expressions) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(tf.Name(), "3", len(expressions))
+	}
+	for _, expr := range expressions {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(tf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(tf.Name(), expr.String())
+		}
+	}
+
+	ntf := *tf
+	ntf.exprs = expressions
+	return &ntf, nil
+}
+
+// Children implements the sql.Node interface
+func (tf *QueryHistoryTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (tf *QueryHistoryTableFunction) WithChildren(node ...sql.Node) (sql.Node, error) {
+	if len(node) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return tf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (tf *QueryHistoryTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: tf.database.Name()}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// Schema implements the sql.Node interface
+func (tf *QueryHistoryTableFunction) Schema() sql.Schema {
+	if !tf.Resolved() {
+		return nil
+	}
+	if tf.sqlSch == nil {
+		panic("schema hasn't been generated yet")
+	}
+	return tf.sqlSch
+}
+
+// Resolved implements the sql.Resolvable interface
+func (tf *QueryHistoryTableFunction) Resolved() bool {
+	for _, expr := range tf.exprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (tf *QueryHistoryTableFunction) IsReadOnly() bool {
+	// The query is checked to be a SELECT statement, and runs on read-only revision databases.
+	return true
+}
+
+// String implements the Stringer interface
+func (tf *QueryHistoryTableFunction) String() string {
+	args := make([]string, len(tf.exprs))
+	for i, expr := range tf.exprs {
+		args[i] = expr.String()
+	}
+	return fmt.Sprintf("DOLT_QUERY_HISTORY(%s)", strings.Join(args, ", "))
+}
+
+// Name implements the sql.TableFunction interface
+func (tf *QueryHistoryTableFunction) Name() string {
+	return "dolt_query_history"
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryHistoryTables(t *testing.T) {
+	tests := []struct {
+		query     string
+		tables    []string
+		cacheable bool
+	}{
+		{
+			query:     "select * from t join u on t.pk = u.pk",
+			tables:    []string{"t", "u"},
+			cacheable: true,
+		},
+		{
+			query:     "select pk from t where pk in (select pk from u) and exists (select 1 from v)",
+			tables:    []string{"t", "u", "v"},
+			cacheable: true,
+		},
+		{
+			query:     "with c as (select pk from u) select pk from t union select pk from c order by (select max(pk) from v)",
+			tables:    []string{"u", "t", "c", "v"},
+			cacheable: true,
+		},
+		{
+			query:     "select * from t as of 'HEAD~1'",
+			tables:    []string{"t"},
+			cacheable: false,
+		},
+		{
+			query:     "select * from otherdb.t",
+			tables:    []string{"t"},
+			cacheable: false,
+		},
+		{
+			query:     "select now(), pk from t",
+			tables:    []string{"t"},
+			cacheable: false,
+		},
+		{
+			query:     "select * from dolt_diff('HEAD~1', 'HEAD', 't')",
+			cacheable: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := sqlparser.Parse(test.query)
+			require.NoError(t, err)
+			tables, cacheable := queryHistoryTables(stmt)
+			assert.ElementsMatch(t, test.tables, tables)
+			assert.Equal(t, test.cacheable, cacheable)
+		})
+	}
+}
@@ -17,6 +17,7 @@ package dtablefunctions
 import (
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +35,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/val"
 )
 
@@ -238,12 +240,18 @@ func (trtf *TestsRunTableFunction) queryAndAssert(row sql.Row) (result TestResul
 	}
 
 	var testPassed bool
-	if message == "" {
-		_, queryResult, _, err := trtf.engine.Query(trtf.ctx, *query)
+	if message == "" && *assertion == AssertionExpectedNoDiff {
+		// The query is run against HEAD and the merge base rather than the current state of the database
+		testPassed, message, err = trtf.assertResult(*query, *assertion, *comparison, value, nil, nil)
+		if err != nil {
+			return TestResult{}, err
+		}
+	} else if message == "" {
+		sch, queryResult, _, err := trtf.engine.Query(trtf.ctx, *query)
 		if err != nil {
 			message = fmt.Sprintf("Query error: %s", err.Error())
 		} else {
-			testPassed, message, err = AssertData(trtf.ctx, *assertion, *comparison, value, queryResult)
+			testPassed, message, err = trtf.assertResult(*query, *assertion, *comparison, value, sch, queryResult)
 			if err != nil {
 				return TestResult{}, err
 			}
@@ -327,18 +335,9 @@ func (trtf *TestsRunTableFunction) getDoltTestsData(arg string) ([]sql.Row, erro
 		if err != nil {
 			return nil, err
 		}
-		// Calling iter.Close(ctx) will cause TrackedRowIter to cancel the context, causing problems when running with
-		// dolt sql-server. Since we only support `SELECT...` queries anyway, it's not necessary to Close() the iter.
-		var rows []sql.Row
-		for {
-			row, rErr := iter.Next(trtf.ctx)
-			if rErr == io.EOF {
-				break
-			}
-			if rErr != nil {
-				return nil, rErr
-			}
-			rows = append(rows, row)
+		rows, err := drainRows(trtf.ctx, iter)
+		if err != nil {
+			return nil, err
 		}
 		if len(rows) > 0 {
 			return rows, nil
@@ -347,6 +346,23 @@ func (trtf *TestsRunTableFunction) getDoltTestsData(arg string) ([]sql.Row, erro
 	return nil, fmt.Errorf("could not find tests for argument: %s", arg)
 }
 
+// drainRows reads every row of |iter|. Calling iter.Close(ctx) will cause TrackedRowIter to cancel the context,
+// causing problems when running with dolt sql-server. Since we only support `SELECT...` queries anyway, it's not
+// necessary to Close() the iter once it's exhausted.
+func drainRows(ctx *sql.Context, iter sql.RowIter) ([]sql.Row, error) {
+	var rows []sql.Row
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
 func IsWriteQuery(query string, ctx *sql.Context, catalog sql.Catalog) (bool, error) {
 	builder := planbuilder.New(ctx, catalog, nil)
 
@@ -414,8 +430,15 @@ const (
 	AssertionExpectedRows        = "expected_rows"
 	AssertionExpectedColumns     = "expected_columns"
 	AssertionExpectedSingleValue = "expected_single_value"
+	AssertionExpectedSnapshot    = "expected_snapshot"
+	AssertionExpectedQueryResult = "expected_query_result"
+	AssertionExpectedNoRows      = "expected_no_rows"
+	AssertionExpectedNoDiff      = "expected_no_diff"
 )
 
+// maxReportedTestRows is the number of offending rows listed in the message of a failed test.
+const maxReportedTestRows = 10
+
 // getStringColAsString safely converts a sql value to string
 func getStringColAsString(sqlCtx *sql.Context, tableValue interface{}) (*string, error) {
 	if tableValue == nil {
@@ -474,6 +497,313 @@ func AssertData(sqlCtx *sql.Context, assertion string, comparison string, value
 	return true, "", nil
 }
 
+// assertResult checks the result of the test query |query| against its assertion. The assertions which compare the
+// whole result set, and may need to run other queries, are checked here; the rest are checked by AssertData. The test
+// query isn't run for expected_no_diff assertions, whose |sch| and |queryResult| are nil.
+func (trtf *TestsRunTableFunction) assertResult(query, assertion, comparison string, value *string, sch sql.Schema, queryResult sql.RowIter) (testPassed bool, message string, err error) {
+	switch assertion {
+	case AssertionExpectedSnapshot, AssertionExpectedQueryResult, AssertionExpectedNoRows, AssertionExpectedNoDiff:
+	default:
+		return AssertData(trtf.ctx, assertion, comparison, value, queryResult)
+	}
+
+	if comparison != "==" {
+		return false, fmt.Sprintf("%s is not a valid comparison for %s. Only '==' is supported", comparison, assertion), nil
+	}
+	switch assertion {
+	case AssertionExpectedSnapshot:
+		message, err = expectSnapshot(trtf.ctx, value, sch, queryResult)
+	case AssertionExpectedQueryResult:
+		message, err = trtf.expectQueryResult(value, sch, queryResult)
+	case AssertionExpectedNoRows:
+		message, err = expectNoRows(trtf.ctx, sch, queryResult)
+	case AssertionExpectedNoDiff:
+		message, err = trtf.expectNoDiff(query, value)
+	}
+
+	if err != nil {
+		return false, "", err
+	} else if message != "" {
+		return false, message, nil
+	}
+	return true, "", nil
+}
+
+// expectSnapshot checks that the result of a query matches the snapshot |value|, regardless of the order of the rows.
+func expectSnapshot(sqlCtx *sql.Context, value *string, sch sql.Schema, queryResult sql.RowIter) (message string, err error) {
+	if value == nil {
+		return "no snapshot has been recorded for expected_snapshot. Record one with dolt test --update-snapshots", nil
+	}
+	expected, err := decodeTestSnapshot(*value)
+	if err != nil {
+		return fmt.Sprintf("could not read snapshot: %s", err.Error()), nil
+	}
+	actual, err := newTestSnapshot(sqlCtx, sch, queryResult)
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Equal(expected.Columns, actual.Columns) {
+		return fmt.Sprintf("Assertion failed: %s columns (%s), got (%s)", AssertionExpectedSnapshot,
+			strings.Join(expected.Columns, ", "), strings.Join(actual.Columns, ", ")), nil
+	}
+	return compareResultRows(AssertionExpectedSnapshot, expected.Rows, actual.Rows), nil
+}
+
+// expectQueryResult checks that the result of a query matches the result of the query |value|, regardless of the
+// order of the rows.
+func (trtf *TestsRunTableFunction) expectQueryResult(value *string, sch sql.Schema, queryResult sql.RowIter) (message string, err error) {
+	if value == nil {
+		return "null is not a valid assertion for expected_query_result", nil
+	}
+	message, err = validateQuery(trtf.ctx, trtf.catalog, *value)
+	if err != nil && message == "" {
+		message = fmt.Sprintf("expected query error: %s", err.Error())
+	}
+	if message != "" {
+		return message, nil
+	}
+
+	expectedSch, expectedResult, _, err := trtf.engine.Query(trtf.ctx, *value)
+	if err != nil {
+		return fmt.Sprintf("expected query error: %s", err.Error()), nil
+	}
+	expected, err := readTestResultRows(trtf.ctx, expectedSch, expectedResult)
+	if err != nil {
+		return "", err
+	}
+	actual, err := readTestResultRows(trtf.ctx, sch, queryResult)
+	if err != nil {
+		return "", err
+	}
+
+	if len(expectedSch) != len(sch) {
+		return fmt.Sprintf("Assertion failed: %s with %d columns, got %d", AssertionExpectedQueryResult, len(expectedSch), len(sch)), nil
+	}
+	return compareResultRows(AssertionExpectedQueryResult, expected, actual), nil
+}
+
+// expectNoRows checks that a query returns no rows, and lists the rows it returned otherwise.
+func expectNoRows(sqlCtx *sql.Context, sch sql.Schema, queryResult sql.RowIter) (message string, err error) {
+	rows, err := readTestResultRows(sqlCtx, sch, queryResult)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("Assertion failed: %s, got %d rows: %s", AssertionExpectedNoRows, len(rows), formatResultRows(rows)), nil
+}
+
+// expectNoDiff checks that the changes committed to HEAD since its merge base with the ref |value| don't touch any of
+// the rows matched by the predicate of |query|. Whole rows are compared rather than the columns |query| projects, so
+// the query is rewritten to select every column, and run against both HEAD and the merge base. A row which was added,
+// removed or modified since the merge base, and which matches the predicate before or after the change, fails the
+// assertion. Uncommitted changes aren't considered.
+func (trtf *TestsRunTableFunction) expectNoDiff(query string, value *string) (message string, err error) {
+	if value == nil {
+		return "null is not a valid assertion for expected_no_diff", nil
+	}
+	rowsQuery, message, err := noDiffRowsQuery(query)
+	if err != nil || message != "" {
+		return message, err
+	}
+
+	commitsQuery, err := dbr.InterpolateForDialect("SELECT HASHOF('HEAD'), DOLT_MERGE_BASE('HEAD', ?)", []interface{}{*value}, dialect.MySQL)
+	if err != nil {
+		return "", err
+	}
+	_, iter, _, err := trtf.engine.Query(trtf.ctx, commitsQuery)
+	if err != nil {
+		return fmt.Sprintf("could not find the merge base of HEAD and %s: %s", *value, err.Error()), nil
+	}
+	rows, err := drainRows(trtf.ctx, iter)
+	if err == nil && len(rows) != 1 {
+		err = fmt.Errorf("expected 1 row, got %d", len(rows))
+	}
+	if err != nil {
+		return fmt.Sprintf("could not find the merge base of HEAD and %s: %s", *value, err.Error()), nil
+	}
+	row := rows[0]
+	head, ok := row[0].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected type %T for HEAD, was expecting string", row[0])
+	}
+	mergeBase, ok := row[1].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected type %T for merge base, was expecting string", row[1])
+	}
+
+	actual, message, err := trtf.readRowsAtCommit(rowsQuery, head)
+	if err != nil || message != "" {
+		return message, err
+	}
+	expected, message, err := trtf.readRowsAtCommit(rowsQuery, mergeBase)
+	if err != nil || message != "" {
+		return message, err
+	}
+
+	removed, added := diffResultRows(expected, actual)
+	if len(removed) == 0 && len(added) == 0 {
+		return "", nil
+	}
+	var changes []string
+	if len(added) > 0 {
+		changes = append(changes, fmt.Sprintf("added: %s", formatResultRows(added)))
+	}
+	if len(removed) > 0 {
+		changes = append(changes, fmt.Sprintf("removed: %s", formatResultRows(removed)))
+	}
+	return fmt.Sprintf("Assertion failed: %s since merge base with %s, got %d added and %d removed rows. %s",
+		AssertionExpectedNoDiff, *value, len(added), len(removed), strings.Join(changes, "; ")), nil
+}
+
+// noDiffRowsQuery rewrites the test query |query| of an expected_no_diff assertion to select every column of the rows
+// matching its predicate. Queries which group their rows have no such rows, and are reported in |message|.
+func noDiffRowsQuery(query string) (rowsQuery string, message string, err error) {
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return "", "", err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.GroupBy) > 0 || sel.Having != nil {
+		return "", fmt.Sprintf("%s requires a query of the form SELECT ... FROM ... WHERE ..., without GROUP BY or HAVING", AssertionExpectedNoDiff), nil
+	}
+	sel.SelectExprs = sqlparser.SelectExprs{&sqlparser.StarExpr{}}
+	return sqlparser.String(sel), "", nil
+}
+
+// readRowsAtCommit runs |query| against the read-only revision database of the commit |commitHash|, and returns its
+// rows.
+func (trtf *TestsRunTableFunction) readRowsAtCommit(query string, commitHash string) (rows [][]*string, message string, err error) {
+	currentDb := trtf.ctx.GetCurrentDatabase()
+	baseName, _ := doltdb.SplitRevisionDbName(currentDb)
+	trtf.ctx.SetCurrentDatabase(doltdb.RevisionDbName(baseName, commitHash))
+	defer trtf.ctx.SetCurrentDatabase(currentDb)
+
+	sch, iter, _, err := trtf.engine.Query(trtf.ctx, query)
+	if err != nil {
+		return nil, fmt.Sprintf("query error at commit %s: %s", commitHash, err.Error()), nil
+	}
+	rows, err = readTestResultRows(trtf.ctx, sch, iter)
+	if err != nil {
+		return nil, "", err
+	}
+	return rows, "", nil
+}
+
+// compareResultRows compares the |expected| and |actual| rows of a query result, regardless of their order. It
+// returns a message listing the missing and unexpected rows, or an empty string if the rows match.
+func compareResultRows(assertionType string, expected, actual [][]*string) string {
+	missing, unexpected := diffResultRows(expected, actual)
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return ""
+	}
+	var diffs []string
+	if len(missing) > 0 {
+		diffs = append(diffs, fmt.Sprintf("missing: %s", formatResultRows(missing)))
+	}
+	if len(unexpected) > 0 {
+		diffs = append(diffs, fmt.Sprintf("unexpected: %s", formatResultRows(unexpected)))
+	}
+	return fmt.Sprintf("Assertion failed: %s, got %d missing and %d unexpected rows. %s",
+		assertionType, len(missing), len(unexpected), strings.Join(diffs, "; "))
+}
+
+// diffResultRows returns the rows of |expected| which are not in |actual|, and the rows of |actual| which are not in
+// |expected|. Rows are compared as a multiset, so their order doesn't matter but duplicates do.
+func diffResultRows(expected, actual [][]*string) (missing, unexpected [][]*string) {
+	counts := make(map[string]int, len(expected))
+	for _, row := range expected {
+		counts[resultRowKey(row)]++
+	}
+	for _, row := range actual {
+		key := resultRowKey(row)
+		if counts[key] > 0 {
+			counts[key]--
+		} else {
+			unexpected = append(unexpected, row)
+		}
+	}
+	for _, row := range expected {
+		key := resultRowKey(row)
+		if counts[key] > 0 {
+			counts[key]--
+			missing = append(missing, row)
+		}
+	}
+	return missing, unexpected
+}
+
+func resultRowKey(row []*string) string {
+	var sb strings.Builder
+	for _, v := range row {
+		if v == nil {
+			sb.WriteString("n")
+		} else {
+			sb.WriteString(strconv.Quote(*v))
+		}
+		sb.WriteString(",")
+	}
+	return sb.String()
+}
+
+// formatResultRows formats the first maxReportedTestRows of |rows| for the message of a failed test.
+func formatResultRows(rows [][]*string) string {
+	formatted := make([]string, 0, min(len(rows), maxReportedTestRows)+1)
+	for i, row := range rows {
+		if i == maxReportedTestRows {
+			formatted = append(formatted, fmt.Sprintf("and %d more", len(rows)-maxReportedTestRows))
+			break
+		}
+		values := make([]string, len(row))
+		for j, v := range row {
+			if v == nil {
+				values[j] = "NULL"
+			} else {
+				values[j] = *v
+			}
+		}
+		formatted = append(formatted, "("+strings.Join(values, ", ")+")")
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// readTestResultRows reads every row of |queryResult|, formatting each value as a string, or nil for NULL.
+func readTestResultRows(sqlCtx *sql.Context, sch sql.Schema, queryResult sql.RowIter) ([][]*string, error) {
+	var rows [][]*string
+	for {
+		row, err := queryResult.Next(sqlCtx)
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		values := make([]*string, len(row))
+		for i, v := range row {
+			if v == nil {
+				continue
+			}
+			v, err = sql.UnwrapAny(sqlCtx, v)
+			if err != nil {
+				return nil, err
+			}
+			var str string
+			if i < len(sch) {
+				str, err = sqlutil.SqlColToStr(sqlCtx, sch[i].Type, v)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				str = fmt.Sprintf("%v", v)
+			}
+			values[i] = &str
+		}
+		rows = append(rows, values)
+	}
+}
+
 func expectSingleValue(sqlCtx *sql.Context, comparison string, value *string, queryResult sql.RowIter) (message string, err error) {
 	row, err := queryResult.Next(sqlCtx)
 	if err == io.EOF {
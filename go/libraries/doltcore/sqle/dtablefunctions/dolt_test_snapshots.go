@@ -0,0 +1,223 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+var _ sql.TableFunction = (*TestSnapshotsTableFunction)(nil)
+var _ sql.CatalogTableFunction = (*TestSnapshotsTableFunction)(nil)
+var _ sql.ExecSourceRel = (*TestSnapshotsTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*TestSnapshotsTableFunction)(nil)
+
+// testSnapshot is the result of a query, as stored in the assertion_value of an expected_snapshot test. Snapshots are
+// stored in the dolt_tests table, so changes to them are versioned, and show up in diffs, like any other change to a
+// test. assertion_value is a LONGTEXT column, so snapshots of large results aren't truncated.
+type testSnapshot struct {
+	Columns []string    `json:"columns"`
+	Rows    [][]*string `json:"rows"`
+}
+
+// newTestSnapshot reads every row of |queryResult| into a testSnapshot.
+func newTestSnapshot(sqlCtx *sql.Context, sch sql.Schema, queryResult sql.RowIter) (testSnapshot, error) {
+	rows, err := readTestResultRows(sqlCtx, sch, queryResult)
+	if err != nil {
+		return testSnapshot{}, err
+	}
+	columns := make([]string, len(sch))
+	for i, col := range sch {
+		columns[i] = col.Name
+	}
+	return testSnapshot{Columns: columns, Rows: rows}, nil
+}
+
+func decodeTestSnapshot(value string) (testSnapshot, error) {
+	var snapshot testSnapshot
+	err := json.Unmarshal([]byte(value), &snapshot)
+	return snapshot, err
+}
+
+// encode returns the snapshot as JSON, with each row on its own line so that diffs of snapshots are readable.
+func (s testSnapshot) encode() (string, error) {
+	columns, err := json.Marshal(s.Columns)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString(`{"columns":`)
+	sb.Write(columns)
+	sb.WriteString(`,"rows":[`)
+	for i, row := range s.Rows {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		r, err := json.Marshal(row)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("\n")
+		sb.Write(r)
+	}
+	if len(s.Rows) > 0 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("]}")
+	return sb.String(), nil
+}
+
+// TestSnapshotsTableFunction runs the queries of the expected_snapshot tests selected by its arguments, which are
+// selected like the tests of dolt_test_run, and returns the snapshot of each result. The snapshots are not stored;
+// `dolt test --update-snapshots` stores them in dolt_tests.
+type TestSnapshotsTableFunction struct {
+	TestsRunTableFunction
+}
+
+var testSnapshotsTableSchema = sql.Schema{
+	&sql.Column{Name: "test_name", Type: types.Text},
+	&sql.Column{Name: "snapshot", Type: types.LongText},
+}
+
+func (tstf *TestSnapshotsTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &TestSnapshotsTableFunction{
+		TestsRunTableFunction: TestsRunTableFunction{
+			ctx:      ctx,
+			database: database,
+		},
+	}
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// WithCatalog implements the sql.CatalogTableFunction interface
+func (tstf *TestSnapshotsTableFunction) WithCatalog(c sql.Catalog) (sql.TableFunction, error) {
+	trtf, err := tstf.TestsRunTableFunction.WithCatalog(c)
+	if err != nil {
+		return nil, err
+	}
+	return &TestSnapshotsTableFunction{TestsRunTableFunction: *trtf.(*TestsRunTableFunction)}, nil
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (tstf *TestSnapshotsTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ntf := *tstf
+	ntf.database = database
+	return &ntf, nil
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (tstf *TestSnapshotsTableFunction) WithExpressions(expressions ...sql.Expression) (sql.Node, error) {
+	node, err := tstf.TestsRunTableFunction.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+	return &TestSnapshotsTableFunction{TestsRunTableFunction: *node.(*TestsRunTableFunction)}, nil
+}
+
+func (tstf *TestSnapshotsTableFunction) WithChildren(node ...sql.Node) (sql.Node, error) {
+	if len(node) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return tstf, nil
+}
+
+// Schema implements the sql.Node interface
+func (tstf *TestSnapshotsTableFunction) Schema() sql.Schema {
+	return testSnapshotsTableSchema
+}
+
+// DataLength estimates total data size for query planning.
+func (tstf *TestSnapshotsTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(tstf.Schema())
+	numRows, _, err := tstf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+// String implements the Stringer interface
+func (tstf *TestSnapshotsTableFunction) String() string {
+	return fmt.Sprintf("DOLT_TEST_SNAPSHOTS(%s)", strings.Join(tstf.getOptionsString(), ","))
+}
+
+// Name implements the sql.TableFunction interface
+func (tstf *TestSnapshotsTableFunction) Name() string {
+	return "dolt_test_snapshots"
+}
+
+// RowIter implements the sql.Node interface
+func (tstf *TestSnapshotsTableFunction) RowIter(_ *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	args := tstf.getOptionsString()
+	if len(args) == 0 { // We treat no arguments as a wildcard
+		args = append(args, "*")
+	}
+
+	var resultRows []sql.Row
+	for _, arg := range args {
+		testRows, err := tstf.getDoltTestsData(strings.Trim(arg, "'"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range testRows {
+			testName, _, query, assertion, _, _, err := parseDoltTestsRow(tstf.ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			if *assertion != AssertionExpectedSnapshot {
+				continue
+			}
+			snapshot, err := tstf.takeSnapshot(*testName, *query)
+			if err != nil {
+				return nil, err
+			}
+			resultRows = append(resultRows, sql.NewRow(*testName, snapshot))
+		}
+	}
+	return sql.RowsToRowIter(resultRows...), nil
+}
+
+// takeSnapshot runs the query of the test |testName| and returns the encoded snapshot of its result. Unlike in
+// dolt_test_run, an invalid query is an error, as there is nothing to snapshot.
+func (tstf *TestSnapshotsTableFunction) takeSnapshot(testName, query string) (string, error) {
+	message, err := validateQuery(tstf.ctx, tstf.catalog, query)
+	if err == nil && message != "" {
+		err = fmt.Errorf("%s", message)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not take snapshot for test %s: %w", testName, err)
+	}
+
+	sch, queryResult, _, err := tstf.engine.Query(tstf.ctx, query)
+	if err != nil {
+		return "", fmt.Errorf("could not take snapshot for test %s: %w", testName, err)
+	}
+	snapshot, err := newTestSnapshot(tstf.ctx, sch, queryResult)
+	if err != nil {
+		return "", err
+	}
+	return snapshot.encode()
+}
@@ -28,6 +28,9 @@ var DoltTableFunctions = []sql.TableFunction{
 	&SchemaDiffTableFunction{},
 	&ReflogTableFunction{},
 	&QueryDiffTableFunction{},
+	&QueryHistoryTableFunction{},
 	&TestsRunTableFunction{},
+	&TestSnapshotsTableFunction{},
 	&JsonDiffTableFunction{},
+	&ChangesSinceTableFunction{},
 }
@@ -0,0 +1,220 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var _ sql.Table = (*BranchApprovalsTable)(nil)
+var _ sql.InsertableTable = (*BranchApprovalsTable)(nil)
+var _ sql.DeletableTable = (*BranchApprovalsTable)(nil)
+var _ sql.RowInserter = (*branchApprovalsEditor)(nil)
+var _ sql.RowDeleter = (*branchApprovalsEditor)(nil)
+
+// BranchApprovalsTable is a system table that records the approvals of commits to be merged into protected branches.
+// Approvals are stored outside of the commit graph, so recording one doesn't change the commit being approved, and
+// they are shared by every branch of the database.
+type BranchApprovalsTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+// NewBranchApprovalsTable returns a new BranchApprovalsTable.
+func NewBranchApprovalsTable(_ *sql.Context, db dsess.SqlDatabase) sql.Table {
+	return &BranchApprovalsTable{db: db, tableName: doltdb.BranchApprovalsTableName}
+}
+
+// Name implements the interface sql.Table.
+func (bat *BranchApprovalsTable) Name() string {
+	return bat.tableName
+}
+
+// String implements the interface sql.Table.
+func (bat *BranchApprovalsTable) String() string {
+	return bat.tableName
+}
+
+// Schema implements the interface sql.Table.
+func (bat *BranchApprovalsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "commit_hash", Type: types.Text, Source: bat.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: bat.db.Name()},
+		{Name: "approver", Type: types.Text, Source: bat.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: bat.db.Name()},
+		{Name: "approved_at", Type: types.DatetimeMaxPrecision, Source: bat.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: bat.db.Name()},
+	}
+}
+
+// Collation implements the interface sql.Table.
+func (bat *BranchApprovalsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (bat *BranchApprovalsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (bat *BranchApprovalsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	approvals, err := bat.db.DbData().Ddb.GetBranchApprovals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sql.Row, len(approvals))
+	for i, approval := range approvals {
+		rows[i] = sql.NewRow(approval.Commit, approval.Approver, approval.ApprovedAt)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Inserter implements the interface sql.InsertableTable.
+func (bat *BranchApprovalsTable) Inserter(*sql.Context) sql.RowInserter {
+	return &branchApprovalsEditor{ddb: bat.db.DbData().Ddb}
+}
+
+// Deleter implements the interface sql.DeletableTable.
+func (bat *BranchApprovalsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return &branchApprovalsEditor{ddb: bat.db.DbData().Ddb}
+}
+
+// branchApprovalsEditor applies the changes of a statement to the approvals of a database, which are written when the
+// editor is closed.
+type branchApprovalsEditor struct {
+	ddb       *doltdb.DoltDB
+	approvals []doltdb.BranchApproval
+	loaded    bool
+	dirty     bool
+}
+
+func (e *branchApprovalsEditor) load(ctx *sql.Context) error {
+	if e.loaded {
+		return nil
+	}
+	approvals, err := e.ddb.GetBranchApprovals(ctx)
+	if err != nil {
+		return err
+	}
+	e.approvals, e.loaded = approvals, true
+	return nil
+}
+
+// checkApprover returns an error unless the current user may change the approvals of |approver|. Users may only change
+// their own approvals, unless they have the SUPER privilege.
+func checkApprover(ctx *sql.Context, approver string) error {
+	if rowsec.Bypasses(ctx) || ctx.Client().User == approver {
+		return nil
+	}
+	return branch_protection.ErrApproveAsOtherUser.New(ctx.Client().User, approver)
+}
+
+// StatementBegin implements the interface sql.TableEditor.
+func (e *branchApprovalsEditor) StatementBegin(*sql.Context) {}
+
+// DiscardChanges implements the interface sql.TableEditor.
+func (e *branchApprovalsEditor) DiscardChanges(*sql.Context, error) error {
+	e.approvals, e.loaded, e.dirty = nil, false, false
+	return nil
+}
+
+// StatementComplete implements the interface sql.TableEditor.
+func (e *branchApprovalsEditor) StatementComplete(*sql.Context) error {
+	return nil
+}
+
+// Insert implements the interface sql.RowInserter.
+func (e *branchApprovalsEditor) Insert(ctx *sql.Context, row sql.Row) error {
+	commitStr := strings.ToLower(strings.TrimSpace(row[0].(string)))
+	approver := row[1].(string)
+	approvedAt := time.Now().UTC()
+	if row[2] != nil {
+		approvedAt = row[2].(time.Time)
+	}
+
+	if err := checkApprover(ctx, approver); err != nil {
+		return err
+	}
+	h, ok := hash.MaybeParse(commitStr)
+	if !ok {
+		return fmt.Errorf("invalid commit hash: %s", commitStr)
+	}
+	optCmt, err := e.ddb.ReadCommit(ctx, h)
+	if err != nil {
+		return err
+	}
+	cmt, ok := optCmt.ToCommit()
+	if !ok {
+		return doltdb.ErrGhostCommitEncountered
+	}
+	meta, err := cmt.GetCommitMeta(ctx)
+	if err != nil {
+		return err
+	}
+	if meta.Name == approver {
+		return branch_protection.ErrSelfApproval.New(approver, commitStr)
+	}
+
+	if err = e.load(ctx); err != nil {
+		return err
+	}
+	for _, approval := range e.approvals {
+		if approval.Commit == commitStr && approval.Approver == approver {
+			return sql.NewUniqueKeyErr(fmt.Sprintf(`[%q, %q]`, commitStr, approver), true, sql.Row{commitStr, approver, approval.ApprovedAt})
+		}
+	}
+	e.approvals = append(e.approvals, doltdb.BranchApproval{Commit: commitStr, Approver: approver, ApprovedAt: approvedAt})
+	e.dirty = true
+	return nil
+}
+
+// Delete implements the interface sql.RowDeleter.
+func (e *branchApprovalsEditor) Delete(ctx *sql.Context, row sql.Row) error {
+	commitStr := row[0].(string)
+	approver := row[1].(string)
+	if err := checkApprover(ctx, approver); err != nil {
+		return err
+	}
+
+	if err := e.load(ctx); err != nil {
+		return err
+	}
+	for i, approval := range e.approvals {
+		if approval.Commit == commitStr && approval.Approver == approver {
+			e.approvals = append(e.approvals[:i], e.approvals[i+1:]...)
+			e.dirty = true
+			break
+		}
+	}
+	return nil
+}
+
+// Close implements the interface sql.Closer.
+func (e *branchApprovalsEditor) Close(ctx *sql.Context) error {
+	if !e.dirty {
+		return nil
+	}
+	return e.ddb.SetBranchApprovals(ctx, e.approvals)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+func doltBranchProtectionSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.BranchProtectionBranchPatternCol, Type: sqlTypes.VarChar, Source: doltdb.GetBranchProtectionTableName(), PrimaryKey: true},
+		{Name: doltdb.BranchProtectionBlockDirectCommitsCol, Type: sqlTypes.Boolean, Source: doltdb.GetBranchProtectionTableName(), Nullable: false},
+		{Name: doltdb.BranchProtectionRequiredWorkflowsCol, Type: sqlTypes.VarChar, Source: doltdb.GetBranchProtectionTableName(), Nullable: true},
+		{Name: doltdb.BranchProtectionRequiredApprovalsCol, Type: sqlTypes.Uint32, Source: doltdb.GetBranchProtectionTableName(), Nullable: false},
+		{Name: doltdb.BranchProtectionAllowForcePushCol, Type: sqlTypes.Boolean, Source: doltdb.GetBranchProtectionTableName(), Nullable: false},
+	}
+}
+
+// GetDoltBranchProtectionSchema returns the schema of the dolt_branch_protection system table. This is used by
+// Doltgres to update the dolt_branch_protection schema using Doltgres types.
+var GetDoltBranchProtectionSchema = doltBranchProtectionSchema
+
+// NewBranchProtectionTable creates a dolt_branch_protection table
+func NewBranchProtectionTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    GetDoltBranchProtectionName(),
+		schema:       GetDoltBranchProtectionSchema(),
+		writeCheck:   superUserWriteCheck(doltdb.GetBranchProtectionTableName()),
+	}
+}
+
+// NewEmptyBranchProtectionTable creates an empty dolt_branch_protection table
+func NewEmptyBranchProtectionTable(_ *sql.Context) sql.Table {
+	return &UserSpaceSystemTable{
+		tableName:  GetDoltBranchProtectionName(),
+		schema:     GetDoltBranchProtectionSchema(),
+		writeCheck: superUserWriteCheck(doltdb.GetBranchProtectionTableName()),
+	}
+}
+
+func GetDoltBranchProtectionName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetBranchProtectionTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetBranchProtectionTableName()}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*ChangeConsumersTable)(nil)
+
+// ChangeConsumersTable is a read-only system table that lists the consumers of dolt_changes_since added with
+// dolt_change_consumer, with the branch each of them reads and the cursor of the last change it processed.
+type ChangeConsumersTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+func NewChangeConsumersTable(_ *sql.Context, db dsess.SqlDatabase) sql.Table {
+	return &ChangeConsumersTable{db: db, tableName: doltdb.ChangeConsumersTableName}
+}
+
+func (cct *ChangeConsumersTable) Name() string {
+	return cct.tableName
+}
+
+func (cct *ChangeConsumersTable) String() string {
+	return cct.tableName
+}
+
+func (cct *ChangeConsumersTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: types.Text, Source: cct.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: cct.db.Name()},
+		{Name: "branch", Type: types.Text, Source: cct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: cct.db.Name()},
+		{Name: "change_cursor", Type: types.Text, Source: cct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: cct.db.Name()},
+		{Name: "updated_at", Type: types.DatetimeMaxPrecision, Source: cct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: cct.db.Name()},
+	}
+}
+
+func (cct *ChangeConsumersTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (cct *ChangeConsumersTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (cct *ChangeConsumersTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	consumers, err := cct.db.DbData().Ddb.GetChangeConsumers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(consumers))
+	for i, c := range consumers {
+		rows[i] = sql.NewRow(c.Name, c.Branch, c.Cursor, c.UpdatedAt)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
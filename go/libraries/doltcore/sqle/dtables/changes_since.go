@@ -0,0 +1,349 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ErrCursorNotInHistory is returned when the commit of a doltdb.ChangeCursor is not in the first-parent history of the
+// branch being read.
+var ErrCursorNotInHistory = errors.NewKind("cursor commit %s is not in the first-parent history of the branch")
+
+// Change is a single row-level change returned by a ChangesSinceIter.
+type Change struct {
+	// Cursor resumes the stream right after this change.
+	Cursor doltdb.ChangeCursor
+	Meta   *datas.CommitMeta
+	Table  doltdb.TableName
+	// DiffType is one of "added", "modified" or "removed".
+	DiffType string
+	// FromSch and From are the schema and the value of the row before the change. From is nil for added rows.
+	FromSch schema.Schema
+	From    sql.Row
+	// ToSch and To are the schema and the value of the row after the change. To is nil for removed rows.
+	ToSch schema.Schema
+	To    sql.Row
+}
+
+type changesSinceCommit struct {
+	h hash.Hash
+	// skip is the number of changes at the start of the commit that were already read.
+	skip uint64
+}
+
+// ChangesSinceIter returns every row change committed to a branch after a doltdb.ChangeCursor, in commit order. The
+// branch's first-parent history is walked, so the changes merged into the branch from another branch are returned as part of
+// the merge commit. Within a commit, changes are ordered by table name and then by primary key.
+//
+// Changes are returned oldest first, but first-parent history can only be walked from the head back to the cursor, so
+// that walk has to reach the cursor before the first change is returned. It only keeps the hash of each commit it
+// visits, so its memory grows with the number of commits since the cursor, not with their size. Every commit is then
+// loaded and diffed only when its changes are read.
+type ChangesSinceIter struct {
+	ddb    *doltdb.DoltDB
+	head   *doltdb.Commit
+	cursor doltdb.ChangeCursor
+	warnFn rowconv.WarnFunction
+
+	changes chan Change
+	errChan chan error
+	cancel  func()
+}
+
+// NewChangesSinceIter returns an iterator over the changes made on the first-parent history of |head| after
+// |cursor|. The commit of |cursor| must be in that history, otherwise ErrCursorNotInHistory is returned by Next.
+func NewChangesSinceIter(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, cursor doltdb.ChangeCursor) (*ChangesSinceIter, error) {
+	child, cancel := context.WithCancel(ctx)
+	iter := &ChangesSinceIter{
+		ddb:     ddb,
+		head:    head,
+		cursor:  cursor,
+		warnFn:  ctx.Warn,
+		changes: make(chan Change, 64),
+		errChan: make(chan error),
+		cancel:  cancel,
+	}
+
+	go func() {
+		iter.queueChanges(child)
+	}()
+
+	return iter, nil
+}
+
+// Next returns the next change, or io.EOF once every change has been returned.
+func (itr *ChangesSinceIter) Next(ctx context.Context) (Change, error) {
+	select {
+	case <-ctx.Done():
+		return Change{}, ctx.Err()
+	case err := <-itr.errChan:
+		return Change{}, err
+	case c, ok := <-itr.changes:
+		if !ok {
+			return Change{}, io.EOF
+		}
+		return c, nil
+	}
+}
+
+func (itr *ChangesSinceIter) Close() error {
+	itr.cancel()
+	return nil
+}
+
+// commitsSinceCursor walks the first-parent history of the head back to the cursor, and returns the commits after the
+// cursor, oldest first. Nothing can be returned before the walk reaches the cursor, or the root commit for an empty
+// cursor.
+func (itr *ChangesSinceIter) commitsSinceCursor(ctx context.Context) ([]changesSinceCommit, error) {
+	var commits []changesSinceCommit
+	cmItr := doltdb.NewFirstParentCommitItr[context.Context](itr.ddb, itr.head)
+	for {
+		h, _, _, _, err := cmItr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if !itr.cursor.IsEmpty() && h == itr.cursor.Commit {
+			if itr.cursor.Seq > 0 {
+				commits = append(commits, changesSinceCommit{h: h, skip: itr.cursor.Seq})
+			}
+			slices.Reverse(commits)
+			return commits, nil
+		}
+		commits = append(commits, changesSinceCommit{h: h})
+	}
+
+	if !itr.cursor.IsEmpty() {
+		return nil, ErrCursorNotInHistory.New(itr.cursor.Commit.String())
+	}
+	slices.Reverse(commits)
+	return commits, nil
+}
+
+// queueChanges sends the changes of every commit after the cursor to itr.changes. It runs in its own goroutine, so
+// that a commit is diffed while the changes of the previous one are read.
+func (itr *ChangesSinceIter) queueChanges(ctx context.Context) {
+	commits, err := itr.commitsSinceCursor(ctx)
+	for i := 0; err == nil && i < len(commits); i++ {
+		err = itr.queueCommitChanges(ctx, commits[i])
+	}
+	if err != nil {
+		select {
+		case <-ctx.Done():
+		case itr.errChan <- err:
+		}
+		return
+	}
+	// we need to drain itr.changes before returning io.EOF
+	close(itr.changes)
+}
+
+func (itr *ChangesSinceIter) queueCommitChanges(ctx context.Context, c changesSinceCommit) error {
+	optCmt, err := itr.ddb.ReadCommit(ctx, c.h)
+	if err != nil {
+		return err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return doltdb.ErrGhostCommitEncountered
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return err
+	}
+	toRoot, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	fromRoot, err := itr.parentRoot(ctx, cm)
+	if err != nil {
+		return err
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return err
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	var seq uint64
+	emit := func(ch Change) error {
+		seq++
+		if seq <= c.skip {
+			return nil
+		}
+		ch.Cursor = doltdb.ChangeCursor{Commit: c.h, Seq: seq}
+		ch.Meta = meta
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case itr.changes <- ch:
+			return nil
+		}
+	}
+
+	for _, td := range deltas {
+		if err = itr.diffTable(ctx, td, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (itr *ChangesSinceIter) parentRoot(ctx context.Context, cm *doltdb.Commit) (doltdb.RootValue, error) {
+	if cm.NumParents() == 0 {
+		return doltdb.EmptyRootValue(ctx, itr.ddb.ValueReadWriter(), itr.ddb.NodeStore())
+	}
+	optCmt, err := itr.ddb.ResolveParent(ctx, cm, 0)
+	if err != nil {
+		return nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return parent.GetRootValue(ctx)
+}
+
+// diffTable calls |emit| with every row change of the table delta |td|.
+func (itr *ChangesSinceIter) diffTable(ctx context.Context, td diff.TableDelta, emit func(Change) error) error {
+	fromIdx, toIdx, err := td.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	if fromIdx == nil && toIdx == nil {
+		return nil
+	}
+
+	var from, to prolly.Map
+	var fromConverter, toConverter ProllyRowConverter
+	if fromIdx != nil {
+		if from, err = durable.ProllyMapFromIndex(fromIdx); err != nil {
+			return err
+		}
+		if fromConverter, err = NewProllyRowConverter(td.FromSch, td.FromSch, itr.warnFn, td.FromNodeStore); err != nil {
+			return err
+		}
+	}
+	if toIdx != nil {
+		if to, err = durable.ProllyMapFromIndex(toIdx); err != nil {
+			return err
+		}
+		if toConverter, err = NewProllyRowConverter(td.ToSch, td.ToSch, itr.warnFn, td.ToNodeStore); err != nil {
+			return err
+		}
+	}
+
+	tableName := td.ToName
+	if tableName.Name == "" {
+		tableName = td.FromName
+	}
+	keyless := (td.FromSch != nil && schema.IsKeyless(td.FromSch)) || (td.ToSch != nil && schema.IsKeyless(td.ToSch))
+
+	cb := func(ctx context.Context, d tree.Diff) error {
+		n := uint64(1)
+		if keyless {
+			n, d = keylessCardinality(d)
+		}
+
+		ch := Change{
+			Table:    tableName,
+			DiffType: diffTypeString(d),
+			FromSch:  td.FromSch,
+			ToSch:    td.ToSch,
+		}
+		if d.Type != tree.AddedDiff {
+			ch.From = make(sql.Row, schemaSize(td.FromSch))
+			if err := fromConverter.PutConverted(ctx, val.Tuple(d.Key), val.Tuple(d.From), ch.From); err != nil {
+				return err
+			}
+		}
+		if d.Type != tree.RemovedDiff {
+			ch.To = make(sql.Row, schemaSize(td.ToSch))
+			if err := toConverter.PutConverted(ctx, val.Tuple(d.Key), val.Tuple(d.To), ch.To); err != nil {
+				return err
+			}
+		}
+
+		for i := uint64(0); i < n; i++ {
+			if err := emit(ch); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if fromIdx != nil && toIdx != nil && !schema.ArePrimaryKeySetsDiffable(td.FromSch, td.ToSch) {
+		// The rows of the two versions of the table are keyed differently, so every row is removed and added again.
+		if err = diffMaps(ctx, from, prolly.Map{}, cb); err != nil {
+			return err
+		}
+		return diffMaps(ctx, prolly.Map{}, to, cb)
+	}
+	return diffMaps(ctx, from, to, cb)
+}
+
+func diffMaps(ctx context.Context, from, to prolly.Map, cb tree.DiffFn) error {
+	err := prolly.DiffMaps(ctx, from, to, false, cb)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// keylessCardinality returns the number of rows changed by the keyless row diff |d|, and |d| with its diff type
+// adjusted for changes to the row's cardinality.
+func keylessCardinality(d tree.Diff) (uint64, tree.Diff) {
+	var n uint64
+	switch d.Type {
+	case tree.AddedDiff:
+		n = val.ReadKeylessCardinality(val.Tuple(d.To))
+	case tree.RemovedDiff:
+		n = val.ReadKeylessCardinality(val.Tuple(d.From))
+	case tree.ModifiedDiff:
+		fN := val.ReadKeylessCardinality(val.Tuple(d.From))
+		tN := val.ReadKeylessCardinality(val.Tuple(d.To))
+		if fN < tN {
+			n = tN - fN
+			d.Type = tree.AddedDiff
+		} else {
+			n = fN - tN
+			d.Type = tree.RemovedDiff
+		}
+	}
+	return n, d
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*CIRunsTable)(nil)
+
+// CIRunsTable is a read-only system table that shows the history of the dolt ci workflows run by a sql-server for a
+// database. The results of the steps of each run are in the dolt_ci_step_results system table.
+type CIRunsTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+func NewCIRunsTable(_ *sql.Context, db dsess.SqlDatabase) sql.Table {
+	return &CIRunsTable{db: db, tableName: doltdb.CIRunsTableName}
+}
+
+func (crt *CIRunsTable) Name() string {
+	return crt.tableName
+}
+
+func (crt *CIRunsTable) String() string {
+	return crt.tableName
+}
+
+func (crt *CIRunsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "id", Type: types.Int64, Source: crt.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "workflow", Type: types.Text, Source: crt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "event", Type: types.Text, Source: crt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "branch", Type: types.Text, Source: crt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "commit_hash", Type: types.Text, Source: crt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "status", Type: types.Text, Source: crt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "started_at", Type: types.DatetimeMaxPrecision, Source: crt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "finished_at", Type: types.DatetimeMaxPrecision, Source: crt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "duration_ms", Type: types.Int64, Source: crt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: crt.db.Name()},
+		{Name: "error", Type: types.Text, Source: crt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: crt.db.Name()},
+	}
+}
+
+func (crt *CIRunsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (crt *CIRunsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (crt *CIRunsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	runs, err := crt.db.DbData().Ddb.GetCIRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(runs))
+	for i, run := range runs {
+		var runErr interface{}
+		if run.Error != "" {
+			runErr = run.Error
+		}
+		rows[i] = sql.NewRow(run.ID, run.Workflow, run.Event, run.Branch, run.Commit, string(run.Status), run.StartedAt,
+			run.FinishedAt, run.FinishedAt.Sub(run.StartedAt).Milliseconds(), runErr)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
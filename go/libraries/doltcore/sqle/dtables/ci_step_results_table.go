@@ -0,0 +1,94 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*CIStepResultsTable)(nil)
+
+// CIStepResultsTable is a read-only system table that shows the result of every step of the dolt ci workflow runs
+// in the dolt_ci_runs system table.
+type CIStepResultsTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+func NewCIStepResultsTable(_ *sql.Context, db dsess.SqlDatabase) sql.Table {
+	return &CIStepResultsTable{db: db, tableName: doltdb.CIStepResultsTableName}
+}
+
+func (csrt *CIStepResultsTable) Name() string {
+	return csrt.tableName
+}
+
+func (csrt *CIStepResultsTable) String() string {
+	return csrt.tableName
+}
+
+func (csrt *CIStepResultsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "run_id", Type: types.Int64, Source: csrt.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: csrt.db.Name()},
+		{Name: "step_index", Type: types.Int32, Source: csrt.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: csrt.db.Name()},
+		{Name: "job", Type: types.Text, Source: csrt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: csrt.db.Name()},
+		{Name: "step", Type: types.Text, Source: csrt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: csrt.db.Name()},
+		{Name: "status", Type: types.Text, Source: csrt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: csrt.db.Name()},
+		{Name: "started_at", Type: types.DatetimeMaxPrecision, Source: csrt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: csrt.db.Name()},
+		{Name: "finished_at", Type: types.DatetimeMaxPrecision, Source: csrt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: csrt.db.Name()},
+		{Name: "duration_ms", Type: types.Int64, Source: csrt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: csrt.db.Name()},
+		{Name: "error", Type: types.Text, Source: csrt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: csrt.db.Name()},
+		{Name: "failing_rows", Type: types.JSON, Source: csrt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: csrt.db.Name()},
+	}
+}
+
+func (csrt *CIStepResultsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (csrt *CIStepResultsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (csrt *CIStepResultsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	runs, err := csrt.db.DbData().Ddb.GetCIRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, run := range runs {
+		for i, step := range run.Steps {
+			var stepErr, failingRows interface{}
+			if step.Error != "" {
+				stepErr = step.Error
+			}
+			if len(step.FailingRows) > 0 {
+				failingRows, _, err = types.JSON.Convert(ctx, step.FailingRows)
+				if err != nil {
+					return nil, err
+				}
+			}
+			rows = append(rows, sql.NewRow(run.ID, int32(i), step.Job, step.Step, string(step.Status), step.StartedAt,
+				step.FinishedAt, step.FinishedAt.Sub(step.StartedAt).Milliseconds(), stepErr, failingRows))
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
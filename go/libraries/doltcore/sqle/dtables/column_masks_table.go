@@ -0,0 +1,89 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+func doltColumnMasksSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.ColumnMasksTableNameCol, Type: sqlTypes.VarChar, Source: doltdb.GetColumnMasksTableName(), PrimaryKey: true},
+		{Name: doltdb.ColumnMasksColumnNameCol, Type: sqlTypes.VarChar, Source: doltdb.GetColumnMasksTableName(), PrimaryKey: true},
+		{Name: doltdb.ColumnMasksStrategyCol, Type: sqlTypes.VarChar, Source: doltdb.GetColumnMasksTableName(), Nullable: false},
+		{Name: doltdb.ColumnMasksArgumentCol, Type: sqlTypes.Text, Source: doltdb.GetColumnMasksTableName(), Nullable: true},
+	}
+}
+
+// GetDoltColumnMasksSchema returns the schema of the dolt_column_masks system table. This is used by Doltgres to
+// update the dolt_column_masks schema using Doltgres types.
+var GetDoltColumnMasksSchema = doltColumnMasksSchema
+
+// NewColumnMasksTable creates a dolt_column_masks table
+func NewColumnMasksTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    GetDoltColumnMasksName(),
+		schema:       GetDoltColumnMasksSchema(),
+		checks:       doltColumnMasksChecks(),
+		writeCheck:   superUserWriteCheck(doltdb.GetColumnMasksTableName()),
+	}
+}
+
+// NewEmptyColumnMasksTable creates an empty dolt_column_masks table
+func NewEmptyColumnMasksTable(_ *sql.Context) sql.Table {
+	return &UserSpaceSystemTable{
+		tableName:  GetDoltColumnMasksName(),
+		schema:     GetDoltColumnMasksSchema(),
+		checks:     doltColumnMasksChecks(),
+		writeCheck: superUserWriteCheck(doltdb.GetColumnMasksTableName()),
+	}
+}
+
+func GetDoltColumnMasksName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetColumnMasksTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetColumnMasksTableName()}
+}
+
+// doltColumnMasksChecks returns the constraints enforced on dolt_column_masks, so that invalid strategies are
+// rejected at INSERT time rather than silently leaving a column unmasked.
+func doltColumnMasksChecks() []sql.CheckDefinition {
+	strategies := make([]string, len(doltdb.ColumnMaskStrategies))
+	for i, strategy := range doltdb.ColumnMaskStrategies {
+		strategies[i] = fmt.Sprintf("'%s'", strategy)
+	}
+	return []sql.CheckDefinition{
+		{
+			Name:            "strategy_check",
+			CheckExpression: fmt.Sprintf("LOWER(%s) IN (%s)", doltdb.ColumnMasksStrategyCol, strings.Join(strategies, ", ")),
+			Enforced:        true,
+		},
+		{
+			Name: "argument_check",
+			CheckExpression: fmt.Sprintf("LOWER(%s) NOT IN ('%s', '%s') OR %s IS NOT NULL",
+				doltdb.ColumnMasksStrategyCol, doltdb.ColumnMaskPartial, doltdb.ColumnMaskExpression, doltdb.ColumnMasksArgumentCol),
+			Enforced: true,
+		},
+	}
+}
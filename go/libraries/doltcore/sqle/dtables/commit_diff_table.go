@@ -27,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -53,6 +54,10 @@ type CommitDiffTable struct {
 	fromCommit string
 	dbName     string
 	sqlSch     sql.PrimaryKeySchema
+	// rowSecurity is set when row-level security policies restrict the rows of the table the session can access
+	rowSecurity *rowsec.Policies
+	// columnMasks is set when column masks hide the values of some columns of the table from the session
+	columnMasks *rowsec.Masks
 }
 
 var _ sql.Table = (*CommitDiffTable)(nil)
@@ -317,5 +322,22 @@ func (dt *CommitDiffTable) rootValForHash(ctx *sql.Context, hashStr string) (dol
 
 func (dt *CommitDiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
 	dp := part.(DiffPartition)
-	return dp.GetRowIter(ctx)
+	iter, err := dp.GetRowIter(ctx)
+	if err != nil || (dt.rowSecurity == nil && dt.columnMasks == nil) {
+		return iter, err
+	}
+	to, from, err := rowsec.DiffSides(ctx, dt.rowSecurity, dt.columnMasks, dt.targetSchema, dt.targetSchema)
+	if err != nil {
+		return nil, err
+	}
+	return rowsec.NewDiffRowIter(iter, to, from), nil
+}
+
+// WithRowSecurity returns a copy of this table that only returns the diffs of rows that |policies| allow the session
+// to read, with the columns hidden by |masks| masked. Either of |policies| and |masks| may be nil.
+func (dt *CommitDiffTable) WithRowSecurity(policies *rowsec.Policies, masks *rowsec.Masks) sql.Table {
+	nt := *dt
+	nt.rowSecurity = policies
+	nt.columnMasks = masks
+	return &nt
 }
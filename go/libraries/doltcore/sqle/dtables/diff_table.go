@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -69,6 +70,10 @@ type DiffTable struct {
 	sqlSch            sql.PrimaryKeySchema
 	partitionFilters  []sql.Expression
 	headHash          hash.Hash
+	// rowSecurity is set when row-level security policies restrict the rows of the table the session can access
+	rowSecurity *rowsec.Policies
+	// columnMasks is set when column masks hide the values of some columns of the table from the session
+	columnMasks *rowsec.Masks
 }
 
 var PrimaryKeyChangeWarning = "cannot render full diff between commits %s and %s due to primary key set change"
@@ -185,7 +190,7 @@ func (dt *DiffTable) PartitionRanges(ctx *sql.Context, ranges []prolly.Range) (s
 	}
 
 	cmHashToTblInfo := make(map[hash.Hash]TblInfoAtCommit)
-	cmHashToTblInfo[cmHash] = TblInfoAtCommit{name: "WORKING", tbl: table, tblHash: wrTblHash}
+	cmHashToTblInfo[cmHash] = TblInfoAtCommit{name: "WORKING", tbl: table, tblHash: wrTblHash, tblName: dt.tableName, root: dt.workingRoot}
 
 	err = cmItr.Reset(ctx)
 	if err != nil {
@@ -246,7 +251,24 @@ func (dt *DiffTable) HeadHash() (hash.Hash, error) {
 
 func (dt *DiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
 	dp := part.(DiffPartition)
-	return dp.GetRowIter(ctx)
+	iter, err := dp.GetRowIter(ctx)
+	if err != nil || (dt.rowSecurity == nil && dt.columnMasks == nil) {
+		return iter, err
+	}
+	to, from, err := rowsec.DiffSides(ctx, dt.rowSecurity, dt.columnMasks, dt.targetSch, dt.targetSch)
+	if err != nil {
+		return nil, err
+	}
+	return rowsec.NewDiffRowIter(iter, to, from), nil
+}
+
+// WithRowSecurity returns a copy of this table that only returns the diffs of rows that |policies| allow the session
+// to read, with the columns hidden by |masks| masked. Either of |policies| and |masks| may be nil.
+func (dt *DiffTable) WithRowSecurity(policies *rowsec.Policies, masks *rowsec.Masks) sql.Table {
+	nt := *dt
+	nt.rowSecurity = policies
+	nt.columnMasks = masks
+	return &nt
 }
 
 func (dt *DiffTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
@@ -311,7 +333,7 @@ func (dt *DiffTable) fromCommitLookupPartitions(ctx *sql.Context, hashes []hash.
 			if err != nil {
 				return nil, err
 			}
-			toCmInfo := TblInfoAtCommit{name: "WORKING", tbl: tbl, tblHash: wrTblHash}
+			toCmInfo := TblInfoAtCommit{name: "WORKING", tbl: tbl, tblHash: wrTblHash, tblName: dt.tableName, root: dt.workingRoot}
 			cmHashToTblInfo[hs] = toCmInfo
 			parentHashes = append(parentHashes, hs)
 			pCommits = append(pCommits, dt.head)
@@ -339,7 +361,7 @@ func (dt *DiffTable) fromCommitLookupPartitions(ctx *sql.Context, hashes []hash.
 		}
 
 		if childCm != nil {
-			ti, err := tableInfoForCommit(ctx, dt.tableName, childCm, childHs)
+			ti, err := dt.tableInfoForCommit(ctx, childCm, childHs)
 			if err != nil {
 				return nil, err
 			}
@@ -445,12 +467,22 @@ func (dt *DiffTable) reverseIterForChild(ctx *sql.Context, parent hash.Hash) (*d
 	}
 }
 
-func tableInfoForCommit(ctx *sql.Context, tableName doltdb.TableName, cm *doltdb.Commit, hs hash.Hash) (TblInfoAtCommit, error) {
+// tableInfoForCommit returns the table info of the diffed table at the commit |cm|, under the name it had at that
+// commit, or an empty table info if it didn't exist.
+func (dt *DiffTable) tableInfoForCommit(ctx *sql.Context, cm *doltdb.Commit, hs hash.Hash) (TblInfoAtCommit, error) {
 	r, err := cm.GetRootValue(ctx)
 	if err != nil {
 		return TblInfoAtCommit{}, err
 	}
 
+	tableName, exists, err := diff.ResolveTableAcrossRenames(ctx, r, dt.workingRoot, dt.tableName, dt.targetSch)
+	if err != nil {
+		return TblInfoAtCommit{}, err
+	}
+	if !exists {
+		return TblInfoAtCommit{}, nil
+	}
+
 	tbl, exists, err := r.GetTable(ctx, tableName)
 	if err != nil {
 		return TblInfoAtCommit{}, err
@@ -470,7 +502,9 @@ func tableInfoForCommit(ctx *sql.Context, tableName doltdb.TableName, cm *doltdb
 	}
 
 	ts := types.Timestamp(meta.Time())
-	return NewTblInfoAtCommit(hs.String(), &ts, tbl, tblHash), nil
+	ti := NewTblInfoAtCommit(hs.String(), &ts, tbl, tblHash)
+	ti.tblName, ti.root = tableName, r
+	return ti, nil
 }
 
 // toCommitLookupPartitions creates a diff partition iterator for a set of
@@ -502,7 +536,7 @@ func (dt *DiffTable) toCommitLookupPartitions(ctx *sql.Context, hashes []hash.Ha
 				return nil, err
 			}
 
-			toCmInfo = TblInfoAtCommit{name: "WORKING", tbl: t, tblHash: wrTblHash}
+			toCmInfo = TblInfoAtCommit{name: "WORKING", tbl: t, tblHash: wrTblHash, tblName: dt.tableName, root: dt.workingRoot}
 			cmHashToTblInfo[hs] = toCmInfo
 			parentHashes = append(parentHashes, hs)
 			pCommits = append(pCommits, dt.head)
@@ -522,7 +556,7 @@ func (dt *DiffTable) toCommitLookupPartitions(ctx *sql.Context, hashes []hash.Ha
 			continue
 		}
 
-		ti, err := tableInfoForCommit(ctx, dt.tableName, cm, hs)
+		ti, err := dt.tableInfoForCommit(ctx, cm, hs)
 		if err != nil {
 			return nil, err
 		}
@@ -594,6 +628,9 @@ type TblInfoAtCommit struct {
 	tbl     *doltdb.Table
 	name    string
 	tblHash hash.Hash
+	// tblName is the name of the table at this commit, which differs from its current name if it was renamed since
+	tblName doltdb.TableName
+	root    doltdb.RootValue
 }
 
 func NewTblInfoAtCommit(name string, date *types.Timestamp, tbl *doltdb.Table, tblHash hash.Hash) TblInfoAtCommit {
@@ -742,8 +779,8 @@ type DiffPartitions struct {
 
 // processCommit is called in a commit iteration loop. Adds partitions when it finds a commit and its parent that have
 // different values for the hash of the table being looked at.
-func (dps *DiffPartitions) processCommit(ctx *sql.Context, cmHash hash.Hash, cm *doltdb.Commit, root doltdb.RootValue, tbl *doltdb.Table) (*DiffPartition, error) {
-	tblHash, _, err := root.GetTableHash(ctx, dps.tblName)
+func (dps *DiffPartitions) processCommit(ctx *sql.Context, cmHash hash.Hash, cm *doltdb.Commit, root doltdb.RootValue, tblName doltdb.TableName, tbl *doltdb.Table) (*DiffPartition, error) {
+	tblHash, _, err := root.GetTableHash(ctx, tblName)
 
 	if err != nil {
 		return nil, err
@@ -783,7 +820,7 @@ func (dps *DiffPartitions) processCommit(ctx *sql.Context, cmHash hash.Hash, cm
 		}
 	}
 
-	newInfo := TblInfoAtCommit{name: cmHashStr, date: &ts, tbl: tbl, tblHash: tblHash}
+	newInfo := TblInfoAtCommit{name: cmHashStr, date: &ts, tbl: tbl, tblHash: tblHash, tblName: tblName, root: root}
 	parentHashes, err := cm.ParentHashes(ctx)
 
 	if err != nil {
@@ -819,13 +856,21 @@ func (dps *DiffPartitions) Next(ctx *sql.Context) (sql.Partition, error) {
 			return nil, err
 		}
 
-		tbl, _, _, err := doltdb.GetTableInsensitive(ctx, root, dps.tblName)
+		tblName, err := dps.tableNameAtCommit(ctx, root, dps.cmHashToTblInfo[cmHash])
+		if err != nil {
+			return nil, err
+		}
+
+		tbl, correctedName, _, err := doltdb.GetTableInsensitive(ctx, root, tblName)
 
 		if err != nil {
 			return nil, err
 		}
+		if tbl != nil {
+			tblName.Name = correctedName
+		}
 
-		next, err := dps.processCommit(ctx, cmHash, cm, root, tbl)
+		next, err := dps.processCommit(ctx, cmHash, cm, root, tblName, tbl)
 
 		if err != nil {
 			return nil, err
@@ -853,6 +898,31 @@ func (dps *DiffPartitions) Next(ctx *sql.Context) (sql.Partition, error) {
 	}
 }
 
+// tableNameAtCommit returns the name of the diffed table in |root|, the root value of a commit whose child has the
+// table info |child|. A table renamed by the child is followed by tag continuity, so that its diff goes on past the
+// rename.
+func (dps *DiffPartitions) tableNameAtCommit(ctx *sql.Context, root doltdb.RootValue, child TblInfoAtCommit) (doltdb.TableName, error) {
+	if child.tblName.Name == "" {
+		return dps.tblName, nil
+	}
+	if child.tbl == nil {
+		return child.tblName, nil
+	}
+
+	childSch, err := child.tbl.GetSchema(ctx)
+	if err != nil {
+		return doltdb.TableName{}, err
+	}
+	tblName, ok, err := diff.ResolveTableAcrossRenames(ctx, root, child.root, child.tblName, childSch)
+	if err != nil {
+		return doltdb.TableName{}, err
+	}
+	if !ok {
+		return child.tblName, nil
+	}
+	return tblName, nil
+}
+
 func (dps *DiffPartitions) Close(*sql.Context) error {
 	return nil
 }
@@ -0,0 +1,60 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+func doltMaterializedViewsSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.MaterializedViewsNameCol, Type: sqlTypes.VarChar, Source: doltdb.GetMaterializedViewsTableName(), PrimaryKey: true},
+		{Name: doltdb.MaterializedViewsDefinitionCol, Type: sqlTypes.Text, Source: doltdb.GetMaterializedViewsTableName(), Nullable: false},
+	}
+}
+
+// GetDoltMaterializedViewsSchema returns the schema of the dolt_materialized_views system table. This is used by
+// Doltgres to update the dolt_materialized_views schema using Doltgres types.
+var GetDoltMaterializedViewsSchema = doltMaterializedViewsSchema
+
+// NewMaterializedViewsTable creates a dolt_materialized_views table
+func NewMaterializedViewsTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    GetDoltMaterializedViewsName(),
+		schema:       GetDoltMaterializedViewsSchema(),
+		writeCheck:   superUserWriteCheck(doltdb.GetMaterializedViewsTableName()),
+	}
+}
+
+// NewEmptyMaterializedViewsTable creates an empty dolt_materialized_views table
+func NewEmptyMaterializedViewsTable(_ *sql.Context) sql.Table {
+	return &UserSpaceSystemTable{
+		tableName:  GetDoltMaterializedViewsName(),
+		schema:     GetDoltMaterializedViewsSchema(),
+		writeCheck: superUserWriteCheck(doltdb.GetMaterializedViewsTableName()),
+	}
+}
+
+func GetDoltMaterializedViewsName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetMaterializedViewsTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetMaterializedViewsTableName()}
+}
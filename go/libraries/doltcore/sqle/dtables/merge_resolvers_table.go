@@ -0,0 +1,87 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+func doltMergeResolversSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.MergeResolversTableNameCol, Type: sqlTypes.VarChar, Source: doltdb.GetMergeResolversTableName(), PrimaryKey: true},
+		{Name: doltdb.MergeResolversColumnNameCol, Type: sqlTypes.VarChar, Source: doltdb.GetMergeResolversTableName(), PrimaryKey: true},
+		{Name: doltdb.MergeResolversStrategyCol, Type: sqlTypes.VarChar, Source: doltdb.GetMergeResolversTableName(), Nullable: false},
+		{Name: doltdb.MergeResolversArgumentCol, Type: sqlTypes.Text, Source: doltdb.GetMergeResolversTableName(), Nullable: true},
+	}
+}
+
+// GetDoltMergeResolversSchema returns the schema of the dolt_merge_resolvers system table. This is used by Doltgres
+// to update the dolt_merge_resolvers schema using Doltgres types.
+var GetDoltMergeResolversSchema = doltMergeResolversSchema
+
+// NewMergeResolversTable creates a dolt_merge_resolvers table
+func NewMergeResolversTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    GetDoltMergeResolversName(),
+		schema:       GetDoltMergeResolversSchema(),
+		checks:       doltMergeResolversChecks(),
+	}
+}
+
+// NewEmptyMergeResolversTable creates an empty dolt_merge_resolvers table
+func NewEmptyMergeResolversTable(_ *sql.Context) sql.Table {
+	return &UserSpaceSystemTable{
+		tableName: GetDoltMergeResolversName(),
+		schema:    GetDoltMergeResolversSchema(),
+		checks:    doltMergeResolversChecks(),
+	}
+}
+
+func GetDoltMergeResolversName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetMergeResolversTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetMergeResolversTableName()}
+}
+
+// doltMergeResolversChecks returns the constraints enforced on dolt_merge_resolvers, so that invalid strategies are
+// rejected at INSERT time rather than only being caught during a merge.
+func doltMergeResolversChecks() []sql.CheckDefinition {
+	strategies := make([]string, len(doltdb.MergeResolverStrategies))
+	for i, strategy := range doltdb.MergeResolverStrategies {
+		strategies[i] = fmt.Sprintf("'%s'", strategy)
+	}
+	return []sql.CheckDefinition{
+		{
+			Name:            "strategy_check",
+			CheckExpression: fmt.Sprintf("LOWER(%s) IN (%s)", doltdb.MergeResolversStrategyCol, strings.Join(strategies, ", ")),
+			Enforced:        true,
+		},
+		{
+			Name: "argument_check",
+			CheckExpression: fmt.Sprintf("LOWER(%s) NOT IN ('%s', '%s') OR %s IS NOT NULL",
+				doltdb.MergeResolversStrategyCol, doltdb.MergeResolverLatest, doltdb.MergeResolverExpression, doltdb.MergeResolversArgumentCol),
+			Enforced: true,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*PurgedCommitsTable)(nil)
+
+// PurgedCommitsTable is a read-only system table that maps each commit rewritten by dolt_purge_rows to the commit that
+// replaced it, so that references to the original commits kept outside the database can be updated.
+type PurgedCommitsTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+func NewPurgedCommitsTable(_ *sql.Context, db dsess.SqlDatabase) sql.Table {
+	return &PurgedCommitsTable{db: db, tableName: doltdb.PurgedCommitsTableName}
+}
+
+func (pct *PurgedCommitsTable) Name() string {
+	return pct.tableName
+}
+
+func (pct *PurgedCommitsTable) String() string {
+	return pct.tableName
+}
+
+func (pct *PurgedCommitsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "old_commit_hash", Type: types.Text, Source: pct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: pct.db.Name()},
+		{Name: "new_commit_hash", Type: types.Text, Source: pct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: pct.db.Name()},
+		{Name: "table_name", Type: types.Text, Source: pct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: pct.db.Name()},
+		{Name: "purged_at", Type: types.DatetimeMaxPrecision, Source: pct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: pct.db.Name()},
+	}
+}
+
+func (pct *PurgedCommitsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (pct *PurgedCommitsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (pct *PurgedCommitsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	purged, err := pct.db.DbData().Ddb.GetPurgedCommits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(purged))
+	for i, pc := range purged {
+		rows[i] = sql.NewRow(pc.Old, pc.New, pc.Table, pc.PurgedAt)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
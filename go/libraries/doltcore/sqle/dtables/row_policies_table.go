@@ -0,0 +1,96 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
+)
+
+func doltRowPoliciesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.RowPoliciesTableNameCol, Type: sqlTypes.VarChar, Source: doltdb.GetRowPoliciesTableName(), PrimaryKey: true},
+		{Name: doltdb.RowPoliciesPolicyNameCol, Type: sqlTypes.VarChar, Source: doltdb.GetRowPoliciesTableName(), PrimaryKey: true},
+		{Name: doltdb.RowPoliciesCommandCol, Type: sqlTypes.VarChar, Source: doltdb.GetRowPoliciesTableName(), Nullable: false},
+		{Name: doltdb.RowPoliciesGranteeCol, Type: sqlTypes.VarChar, Source: doltdb.GetRowPoliciesTableName(), Nullable: false},
+		{Name: doltdb.RowPoliciesFilterCol, Type: sqlTypes.Text, Source: doltdb.GetRowPoliciesTableName(), Nullable: false},
+	}
+}
+
+// GetDoltRowPoliciesSchema returns the schema of the dolt_row_policies system table. This is used by Doltgres to
+// update the dolt_row_policies schema using Doltgres types.
+var GetDoltRowPoliciesSchema = doltRowPoliciesSchema
+
+// NewRowPoliciesTable creates a dolt_row_policies table
+func NewRowPoliciesTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    GetDoltRowPoliciesName(),
+		schema:       GetDoltRowPoliciesSchema(),
+		checks:       doltRowPoliciesChecks(),
+		writeCheck:   superUserWriteCheck(doltdb.GetRowPoliciesTableName()),
+	}
+}
+
+// NewEmptyRowPoliciesTable creates an empty dolt_row_policies table
+func NewEmptyRowPoliciesTable(_ *sql.Context) sql.Table {
+	return &UserSpaceSystemTable{
+		tableName:  GetDoltRowPoliciesName(),
+		schema:     GetDoltRowPoliciesSchema(),
+		checks:     doltRowPoliciesChecks(),
+		writeCheck: superUserWriteCheck(doltdb.GetRowPoliciesTableName()),
+	}
+}
+
+func GetDoltRowPoliciesName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetRowPoliciesTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetRowPoliciesTableName()}
+}
+
+// superUserWriteCheck returns a write check for the system table |tableName| that prevents users subject to row-level
+// security and column masks from changing the rules restricting them.
+func superUserWriteCheck(tableName string) func(ctx *sql.Context) error {
+	return func(ctx *sql.Context) error {
+		if rowsec.Bypasses(ctx) {
+			return nil
+		}
+		return rowsec.ErrPoliciesReadOnly.New(tableName)
+	}
+}
+
+// doltRowPoliciesChecks returns the constraints enforced on dolt_row_policies, so that unknown commands are rejected
+// at INSERT time rather than silently matching no statement.
+func doltRowPoliciesChecks() []sql.CheckDefinition {
+	commands := make([]string, len(doltdb.RowPolicyCommands))
+	for i, cmd := range doltdb.RowPolicyCommands {
+		commands[i] = fmt.Sprintf("'%s'", cmd)
+	}
+	return []sql.CheckDefinition{
+		{
+			Name:            "command_check",
+			CheckExpression: fmt.Sprintf("LOWER(%s) IN (%s)", doltdb.RowPoliciesCommandCol, strings.Join(commands, ", ")),
+			Enforced:        true,
+		},
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*StorageUsageTable)(nil)
+
+// StorageUsageTable is a read-only system table that shows the storage used by every ref of a database, and by every
+// table across the history of each ref. Rows with an empty table_name are the usage of the entire ref.
+type StorageUsageTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+func NewStorageUsageTable(_ *sql.Context, db dsess.SqlDatabase) sql.Table {
+	return &StorageUsageTable{db: db, tableName: doltdb.StorageUsageTableName}
+}
+
+func (sut *StorageUsageTable) Name() string {
+	return sut.tableName
+}
+
+func (sut *StorageUsageTable) String() string {
+	return sut.tableName
+}
+
+func (sut *StorageUsageTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "ref", Type: types.Text, Source: sut.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: sut.db.Name()},
+		{Name: "table_name", Type: types.Text, Source: sut.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: sut.db.Name()},
+		{Name: "chunk_count", Type: types.Uint64, Source: sut.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: sut.db.Name()},
+		{Name: "logical_bytes", Type: types.Uint64, Source: sut.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: sut.db.Name()},
+		{Name: "exclusive_bytes", Type: types.Uint64, Source: sut.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: sut.db.Name()},
+	}
+}
+
+func (sut *StorageUsageTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (sut *StorageUsageTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (sut *StorageUsageTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	usage, err := sut.db.DbData().Ddb.StorageUsage(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(usage))
+	for i, u := range usage {
+		rows[i] = sql.NewRow(u.Ref, u.Table.String(), u.ChunkCount, u.LogicalBytes, u.ExclusiveBytes)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -53,7 +53,7 @@ func doltTestsSchema() sql.Schema {
 		{Name: "test_query", Type: sqlTypes.Text, Source: doltdb.TestsTableName, PrimaryKey: false, Nullable: false},
 		{Name: "assertion_type", Type: sqlTypes.Text, Source: doltdb.TestsTableName, PrimaryKey: false, Nullable: false},
 		{Name: "assertion_comparator", Type: sqlTypes.Text, Source: doltdb.TestsTableName, PrimaryKey: false, Nullable: false},
-		{Name: "assertion_value", Type: sqlTypes.Text, Source: doltdb.TestsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "assertion_value", Type: sqlTypes.LongText, Source: doltdb.TestsTableName, PrimaryKey: false, Nullable: true},
 	}
 }
 
@@ -150,7 +150,7 @@ func (tt *TestsTable) GetChecks(_ *sql.Context) ([]sql.CheckDefinition, error) {
 	return []sql.CheckDefinition{
 		{
 			Name:            "assertion_type_check",
-			CheckExpression: "assertion_type IN ('expected_rows', 'expected_columns', 'expected_single_value')",
+			CheckExpression: "assertion_type IN ('expected_rows', 'expected_columns', 'expected_single_value', 'expected_snapshot', 'expected_query_result', 'expected_no_rows', 'expected_no_diff')",
 			Enforced:        true,
 		},
 		{
@@ -32,6 +32,7 @@ var _ sql.DeletableTable = (*UserSpaceSystemTable)(nil)
 var _ sql.InsertableTable = (*UserSpaceSystemTable)(nil)
 var _ sql.ReplaceableTable = (*UserSpaceSystemTable)(nil)
 var _ sql.IndexAddressableTable = (*UserSpaceSystemTable)(nil)
+var _ sql.CheckTable = (*UserSpaceSystemTable)(nil)
 
 // A UserSpaceSystemTable is a system table backed by a normal table in storage.
 // Like other system tables, it always exists. If the backing table doesn't exist, then reads return an empty table,
@@ -40,6 +41,10 @@ type UserSpaceSystemTable struct {
 	backingTable VersionableTable
 	tableName    doltdb.TableName
 	schema       sql.Schema
+	checks       []sql.CheckDefinition
+	// writeCheck, if set, is called at the beginning of every statement that writes to this table, and any error it
+	// returns fails the statement
+	writeCheck func(ctx *sql.Context) error
 }
 
 func (bst *UserSpaceSystemTable) Name() string {
@@ -122,6 +127,11 @@ func (bst *UserSpaceSystemTable) PreciseMatch() bool {
 	return true
 }
 
+// GetChecks implements sql.CheckTable, returning any constraints this system table enforces on written rows.
+func (bst *UserSpaceSystemTable) GetChecks(_ *sql.Context) ([]sql.CheckDefinition, error) {
+	return bst.checks, nil
+}
+
 var _ sql.RowReplacer = (*backedSystemTableWriter)(nil)
 var _ sql.RowUpdater = (*backedSystemTableWriter)(nil)
 var _ sql.RowInserter = (*backedSystemTableWriter)(nil)
@@ -169,6 +179,12 @@ func (bstw *backedSystemTableWriter) Delete(ctx *sql.Context, r sql.Row) error {
 // StatementBegin is called before the first operation of a statement. Integrators should mark the state of the data
 // in some way that it may be returned to in the case of an error.
 func (bstw *backedSystemTableWriter) StatementBegin(ctx *sql.Context) {
+	if bstw.bst.writeCheck != nil {
+		if err := bstw.bst.writeCheck(ctx); err != nil {
+			bstw.errDuringStatementBegin = err
+			return
+		}
+	}
 	prevHash, tableWriter, err := createWriteableSystemTable(ctx, bstw.bst.tableName, bstw.bst.Schema())
 	if err != nil {
 		bstw.errDuringStatementBegin = err
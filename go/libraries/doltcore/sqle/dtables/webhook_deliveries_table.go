@@ -0,0 +1,105 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/webhooks"
+)
+
+var _ sql.Table = (*WebhookDeliveriesTable)(nil)
+
+// WebhookDeliveriesProvider is implemented by database providers which deliver webhooks for their databases.
+type WebhookDeliveriesProvider interface {
+	// WebhookDeliveries returns the webhook deliveries queued for the database |dbName|.
+	WebhookDeliveries(dbName string) []webhooks.Delivery
+}
+
+// WebhookDeliveriesTable is a read-only system table that shows the state of the webhook deliveries queued for a
+// database. It is empty unless webhooks are configured for the running server.
+type WebhookDeliveriesTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+func NewWebhookDeliveriesTable(_ *sql.Context, db dsess.SqlDatabase) sql.Table {
+	return &WebhookDeliveriesTable{db: db, tableName: doltdb.WebhookDeliveriesTableName}
+}
+
+func (wdt *WebhookDeliveriesTable) Name() string {
+	return wdt.tableName
+}
+
+func (wdt *WebhookDeliveriesTable) String() string {
+	return wdt.tableName
+}
+
+func (wdt *WebhookDeliveriesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "id", Type: types.Uint64, Source: wdt.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: wdt.db.Name()},
+		{Name: "webhook", Type: types.Text, Source: wdt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: wdt.db.Name()},
+		{Name: "branch", Type: types.Text, Source: wdt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: wdt.db.Name()},
+		{Name: "old_commit", Type: types.Text, Source: wdt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: wdt.db.Name()},
+		{Name: "new_commit", Type: types.Text, Source: wdt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: wdt.db.Name()},
+		{Name: "status", Type: types.Text, Source: wdt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: wdt.db.Name()},
+		{Name: "attempts", Type: types.Int32, Source: wdt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: wdt.db.Name()},
+		{Name: "last_error", Type: types.Text, Source: wdt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: wdt.db.Name()},
+		{Name: "created_at", Type: types.DatetimeMaxPrecision, Source: wdt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: wdt.db.Name()},
+		{Name: "last_attempt", Type: types.DatetimeMaxPrecision, Source: wdt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: wdt.db.Name()},
+		{Name: "next_attempt", Type: types.DatetimeMaxPrecision, Source: wdt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: wdt.db.Name()},
+	}
+}
+
+func (wdt *WebhookDeliveriesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (wdt *WebhookDeliveriesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (wdt *WebhookDeliveriesTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	provider, ok := dsess.DSessFromSess(ctx.Session).Provider().(WebhookDeliveriesProvider)
+	if !ok {
+		return sql.RowsToRowIter(), nil
+	}
+	dbName, _ := doltdb.SplitRevisionDbName(wdt.db.Name())
+	deliveries := provider.WebhookDeliveries(dbName)
+
+	rows := make([]sql.Row, len(deliveries))
+	for i, d := range deliveries {
+		var oldCommit, lastError, lastAttempt, nextAttempt interface{}
+		if d.OldCommit != "" {
+			oldCommit = d.OldCommit
+		}
+		if d.LastError != "" {
+			lastError = d.LastError
+		}
+		if !d.LastAttempt.IsZero() {
+			lastAttempt = d.LastAttempt
+		}
+		if d.Status == webhooks.StatusPending {
+			nextAttempt = d.NextAttempt
+		}
+		rows[i] = sql.NewRow(d.ID, d.Webhook, d.Branch, oldCommit, d.NewCommit, string(d.Status), int32(d.Attempts),
+			lastError, d.CreatedAt, lastAttempt, nextAttempt)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -1538,7 +1538,12 @@ var BranchControlTests = []BranchControlTest{
 }
 
 func TestBranchControl(t *testing.T) {
-	for _, test := range BranchControlTests {
+	runBranchControlTests(t, BranchControlTests)
+}
+
+// runBranchControlTests runs each of the given tests against a new engine with an enabled privilege system.
+func runBranchControlTests(t *testing.T, tests []BranchControlTest) {
+	for _, test := range tests {
 		harness := newDoltHarness(t)
 		defer harness.Close()
 		t.Run(test.Name, func(t *testing.T) {
@@ -0,0 +1,278 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_protection"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
+)
+
+// branchProtectionSetUpScript creates a reviewer user without the SUPER privilege, and a feature branch with one
+// commit that is not yet merged into main. The protection rules are added by each test.
+var branchProtectionSetUpScript = []string{
+	"CREATE USER reviewer@localhost;",
+	"GRANT ALL ON *.* TO reviewer@localhost;",
+	"REVOKE SUPER ON *.* FROM reviewer@localhost;",
+	"CREATE TABLE t (pk INT PRIMARY KEY);",
+	"CALL DOLT_COMMIT('-Am', 'add t');",
+	"CALL DOLT_BRANCH('feature');",
+	"CALL DOLT_CHECKOUT('feature');",
+	"INSERT INTO t VALUES (1);",
+	"CALL DOLT_COMMIT('-am', 'add a row');",
+	"CALL DOLT_CHECKOUT('main');",
+}
+
+func withBranchProtectionSetUp(script ...string) []string {
+	return append(append([]string{}, branchProtectionSetUpScript...), script...)
+}
+
+var BranchProtectionTests = []BranchControlTest{
+	{
+		Name: "Protected branches reject direct commits and amends",
+		SetUpScript: withBranchProtectionSetUp(
+			"INSERT INTO dolt_branch_protection VALUES ('ma%', true, NULL, 0, false), ('release_%', false, NULL, 0, false);",
+			"CALL DOLT_COMMIT('-Am', 'protect main');",
+			"CALL DOLT_BRANCH('release_1');",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				Query:    "INSERT INTO t VALUES (2);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:       "CALL DOLT_COMMIT('-am', 'direct commit');",
+				ExpectedErr: branch_protection.ErrDirectCommit,
+			},
+			{
+				Query:       "CALL DOLT_COMMIT('--amend', '-m', 'amended');",
+				ExpectedErr: branch_protection.ErrDirectCommit,
+			},
+			{
+				Query:    "CALL DOLT_CHECKOUT('-f', 'release_1');",
+				Expected: []sql.Row{{0, "Switched to branch 'release_1'"}},
+			},
+			{
+				Query:       "CALL DOLT_COMMIT('--amend', '-m', 'amended');",
+				ExpectedErr: branch_protection.ErrForcePush,
+			},
+		},
+	},
+	{
+		Name: "Protection rules can only be changed with SUPER",
+		SetUpScript: withBranchProtectionSetUp(
+			"INSERT INTO dolt_branch_protection VALUES ('main', true, NULL, 1, false);",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:        "reviewer",
+				Host:        "localhost",
+				Query:       "DELETE FROM dolt_branch_protection;",
+				ExpectedErr: rowsec.ErrPoliciesReadOnly,
+			},
+			{
+				User:        "reviewer",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_branch_protection VALUES ('feature', false, NULL, 0, true);",
+				ExpectedErr: rowsec.ErrPoliciesReadOnly,
+			},
+			{
+				User:     "reviewer",
+				Host:     "localhost",
+				Query:    "SELECT branch_pattern, block_direct_commits, required_approvals FROM dolt_branch_protection;",
+				Expected: []sql.Row{{"main", int8(1), uint32(1)}},
+			},
+		},
+	},
+	{
+		Name: "Merges into protected branches require approvals",
+		SetUpScript: withBranchProtectionSetUp(
+			"INSERT INTO dolt_branch_protection VALUES ('main', true, NULL, 1, false);",
+			"CALL DOLT_COMMIT('-Am', 'protect main');",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				Query:       "CALL DOLT_MERGE('feature');",
+				ExpectedErr: branch_protection.ErrNotEnoughApprovals,
+			},
+			{
+				Query:       "INSERT INTO dolt_branch_approvals (commit_hash, approver) VALUES (HASHOF('feature'), 'root');",
+				ExpectedErr: branch_protection.ErrSelfApproval,
+			},
+			{
+				User:        "reviewer",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_branch_approvals (commit_hash, approver) VALUES (HASHOF('feature'), 'someone');",
+				ExpectedErr: branch_protection.ErrApproveAsOtherUser,
+			},
+			{
+				User:     "reviewer",
+				Host:     "localhost",
+				Query:    "INSERT INTO dolt_branch_approvals (commit_hash, approver) VALUES (HASHOF('feature'), 'reviewer');",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT approver FROM dolt_branch_approvals WHERE commit_hash = HASHOF('feature');",
+				Expected: []sql.Row{{"reviewer"}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('feature', '--no-ff', '-m', 'merge feature');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "Merges into protected branches require passing workflows",
+		SetUpScript: withBranchProtectionSetUp(
+			"INSERT INTO dolt_branch_protection VALUES ('main', false, 'checks', 0, false);",
+			"CALL DOLT_COMMIT('-Am', 'protect main');",
+			"CALL DOLT_CHECKOUT('feature');",
+			"CALL DOLT_CI_RECORD_RESULT('checks', 'failed');",
+			"CALL DOLT_CHECKOUT('main');",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				Query:       "CALL DOLT_MERGE('feature', '--no-ff', '-m', 'merge feature');",
+				ExpectedErr: branch_protection.ErrWorkflowNotPassed,
+			},
+			{
+				Query:    "CALL DOLT_CHECKOUT('feature');",
+				Expected: []sql.Row{{0, "Switched to branch 'feature'"}},
+			},
+			{
+				Query:    "CALL DOLT_CI_RECORD_RESULT('checks', 'passed');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "CALL DOLT_CHECKOUT('main');",
+				Expected: []sql.Row{{0, "Switched to branch 'main'"}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('feature', '--no-ff', '-m', 'merge feature');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+		},
+	},
+	{
+		Name: "Merge commits into protected branches can't make other changes",
+		SetUpScript: withBranchProtectionSetUp(
+			"INSERT INTO dolt_branch_protection VALUES ('main', true, NULL, 1, false);",
+			"CALL DOLT_COMMIT('-Am', 'protect main');",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "reviewer",
+				Host:     "localhost",
+				Query:    "INSERT INTO dolt_branch_approvals (commit_hash, approver) VALUES (HASHOF('feature'), 'reviewer');",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('feature', '--no-commit');",
+				Expected: []sql.Row{{"", 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "INSERT INTO t VALUES (2);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:       "CALL DOLT_COMMIT('-am', 'merge feature');",
+				ExpectedErr: branch_protection.ErrMergeChanged,
+			},
+			{
+				Query:    "DELETE FROM t WHERE pk = 2;",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "CALL DOLT_COMMIT('-am', 'merge feature');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "Protected branches can't be reset, overwritten, renamed or deleted",
+		SetUpScript: withBranchProtectionSetUp(
+			"INSERT INTO dolt_branch_protection VALUES ('main', true, NULL, 0, false);",
+			"CALL DOLT_COMMIT('-Am', 'protect main');",
+			"CALL DOLT_BRANCH('ahead');",
+			"CALL DOLT_CHECKOUT('ahead');",
+			"INSERT INTO t VALUES (5);",
+			"CALL DOLT_COMMIT('-am', 'add another row');",
+			"CALL DOLT_CHECKOUT('main');",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				Query:       "CALL DOLT_RESET('--hard', 'HEAD~1');",
+				ExpectedErr: branch_protection.ErrForcePush,
+			},
+			{
+				Query:       "CALL DOLT_RESET('--hard', 'ahead');",
+				ExpectedErr: branch_protection.ErrDirectCommit,
+			},
+			{
+				Query:       "CALL DOLT_BRANCH('-f', 'main', 'feature');",
+				ExpectedErr: branch_protection.ErrForcePush,
+			},
+			{
+				Query:       "CALL DOLT_BRANCH('-f', '-c', 'ahead', 'main');",
+				ExpectedErr: branch_protection.ErrDirectCommit,
+			},
+			{
+				Query:       "CALL DOLT_BRANCH('-m', 'main', 'trunk');",
+				ExpectedErr: branch_protection.ErrForcePush,
+			},
+			{
+				Query:       "CALL DOLT_BRANCH('-D', 'main');",
+				ExpectedErr: branch_protection.ErrForcePush,
+			},
+			{
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "CALL DOLT_REVERT('HEAD');",
+				ExpectedErr: branch_protection.ErrDirectCommit,
+			},
+		},
+	},
+	{
+		Name: "Commits can't be cherry-picked onto protected branches",
+		SetUpScript: withBranchProtectionSetUp(
+			"INSERT INTO dolt_branch_protection VALUES ('main', true, NULL, 0, false);",
+			"CALL DOLT_COMMIT('-Am', 'protect main');",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				Query:       "CALL DOLT_CHERRY_PICK('feature');",
+				ExpectedErr: branch_protection.ErrDirectCommit,
+			},
+		},
+	},
+}
+
+func TestBranchProtection(t *testing.T) {
+	runBranchControlTests(t, BranchProtectionTests)
+}
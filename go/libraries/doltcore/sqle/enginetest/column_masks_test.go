@@ -0,0 +1,303 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
+)
+
+// columnMasksSetUpScript creates an analyst user without the SUPER privilege, and a table with a column masked by
+// each strategy. The table has two commits: one adding two customers, and one adding a third.
+var columnMasksSetUpScript = []string{
+	"CREATE USER analyst@localhost;",
+	"GRANT ALL ON *.* TO analyst@localhost;",
+	"REVOKE SUPER ON *.* FROM analyst@localhost;",
+	"CREATE TABLE customers (id INT PRIMARY KEY, name VARCHAR(100), email VARCHAR(100), ssn VARCHAR(11), salary INT, INDEX (ssn));",
+	"INSERT INTO customers VALUES (1, 'alice', 'alice@example.com', '123-45-6789', 1000), (2, 'bob', 'bob@dolthub.com', '987-65-4321', 2000);",
+	"INSERT INTO dolt_column_masks VALUES ('customers', 'name', 'hash', 'salt');",
+	"INSERT INTO dolt_column_masks VALUES ('customers', 'email', 'expression', 'CONCAT(''***@'', SUBSTRING_INDEX(email, ''@'', -1))');",
+	"INSERT INTO dolt_column_masks VALUES ('customers', 'ssn', 'partial', '4');",
+	"INSERT INTO dolt_column_masks VALUES ('customers', 'salary', 'null', NULL);",
+	"CALL DOLT_COMMIT('-Am', 'add customers');",
+	"INSERT INTO customers VALUES (3, 'carol', 'carol@example.com', '555-55-5555', 3000);",
+	"CALL DOLT_COMMIT('-am', 'add carol');",
+}
+
+// columnMasksUnmaskedSetUpScript extends columnMasksSetUpScript with a branch `unmasked` that deletes every mask.
+var columnMasksUnmaskedSetUpScript = append(append([]string{}, columnMasksSetUpScript...),
+	"CALL DOLT_CHECKOUT('-b', 'unmasked');",
+	"DELETE FROM dolt_column_masks;",
+	"CALL DOLT_COMMIT('-am', 'unmask customers');",
+	"CALL DOLT_CHECKOUT('main');",
+)
+
+// maskedHash returns the value of a column masked with the hash strategy and the argument 'salt'.
+func maskedHash(s string) string {
+	sum := sha256.Sum256([]byte("salt" + s))
+	return hex.EncodeToString(sum[:])
+}
+
+var ColumnMasksTests = []BranchControlTest{
+	{
+		Name:        "Masked columns are hidden from users without SUPER",
+		SetUpScript: columnMasksSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:  "analyst",
+				Host:  "localhost",
+				Query: "SELECT * FROM customers ORDER BY id;",
+				Expected: []sql.Row{
+					{1, maskedHash("alice"), "***@example.com", "*******6789", nil},
+					{2, maskedHash("bob"), "***@dolthub.com", "*******4321", nil},
+					{3, maskedHash("carol"), "***@example.com", "*******5555", nil},
+				},
+			},
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT id FROM customers WHERE ssn = '123-45-6789';",
+				Expected: []sql.Row{},
+			},
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT ssn FROM customers AS OF 'HEAD~1' ORDER BY id;",
+				Expected: []sql.Row{{"*******6789"}, {"*******4321"}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT name, ssn, salary FROM customers WHERE id = 1;",
+				Expected: []sql.Row{{"alice", "123-45-6789", 1000}},
+			},
+		},
+	},
+	{
+		Name:        "History, diffs and patches are masked",
+		SetUpScript: columnMasksSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT id, ssn, salary FROM dolt_history_customers WHERE id = 1;",
+				Expected: []sql.Row{{1, "*******6789", nil}, {1, "*******6789", nil}},
+			},
+			{
+				User:  "analyst",
+				Host:  "localhost",
+				Query: "SELECT to_id, to_ssn, to_salary, diff_type FROM dolt_diff_customers ORDER BY to_id;",
+				Expected: []sql.Row{
+					{1, "*******6789", nil, "added"},
+					{2, "*******4321", nil, "added"},
+					{3, "*******5555", nil, "added"},
+				},
+			},
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT to_id, to_email, to_salary, diff_type FROM dolt_diff('HEAD~1', 'HEAD', 'customers');",
+				Expected: []sql.Row{{3, "***@example.com", nil, "added"}},
+			},
+			{
+				User:  "analyst",
+				Host:  "localhost",
+				Query: "SELECT statement FROM dolt_patch('HEAD~1', 'HEAD', 'customers');",
+				Expected: []sql.Row{{fmt.Sprintf("INSERT INTO `customers` (`id`,`name`,`email`,`ssn`,`salary`) VALUES (3,'%s','***@example.com','*******5555',NULL);",
+					maskedHash("carol"))}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT to_salary FROM dolt_diff('HEAD~1', 'HEAD', 'customers');",
+				Expected: []sql.Row{{3000}},
+			},
+		},
+	},
+	{
+		Name:        "Masked values can't be written back",
+		SetUpScript: columnMasksSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "INSERT INTO customers VALUES (4, 'dave', 'dave@example.com', '111-11-1111', 4000);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				User:        "analyst",
+				Host:        "localhost",
+				Query:       "UPDATE customers SET email = 'new@example.com' WHERE id = 1;",
+				ExpectedErr: rowsec.ErrMaskedTableWrite,
+			},
+			{
+				User:        "analyst",
+				Host:        "localhost",
+				Query:       "DELETE FROM customers WHERE id = 1;",
+				ExpectedErr: rowsec.ErrMaskedTableWrite,
+			},
+			{
+				User:        "analyst",
+				Host:        "localhost",
+				Query:       "REPLACE INTO customers VALUES (1, 'alice', 'alice@example.com', '123-45-6789', 0);",
+				ExpectedErr: rowsec.ErrMaskedTableWrite,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT id, salary FROM customers ORDER BY id;",
+				Expected: []sql.Row{{1, 1000}, {2, 2000}, {3, 3000}, {4, 4000}},
+			},
+		},
+	},
+	{
+		Name:        "Masks can only be changed by super users",
+		SetUpScript: columnMasksSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:        "analyst",
+				Host:        "localhost",
+				Query:       "DELETE FROM dolt_column_masks WHERE column_name = 'salary';",
+				ExpectedErr: rowsec.ErrPoliciesReadOnly,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_column_masks VALUES ('customers', 'id', 'scramble', NULL);",
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_column_masks VALUES ('customers', 'id', 'partial', NULL);",
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "DELETE FROM dolt_column_masks WHERE column_name = 'salary';",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT salary FROM customers WHERE id = 1;",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_COMMIT('-am', 'unmask salary');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT salary FROM customers WHERE id = 1;",
+				Expected: []sql.Row{{1000}},
+			},
+		},
+	},
+	{
+		Name:        "Super users can force masks",
+		SetUpScript: columnMasksSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SET @@dolt_force_column_masks = 1;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT ssn, salary FROM customers WHERE id = 1;",
+				Expected: []sql.Row{{"*******6789", nil}},
+			},
+		},
+	},
+	{
+		Name:        "Every revision is subject to the masks committed to the default branch",
+		SetUpScript: columnMasksUnmaskedSetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT ssn FROM `mydb/unmasked`.customers WHERE id = 1;",
+				Expected: []sql.Row{{"*******6789"}},
+			},
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT ssn FROM customers AS OF 'unmasked' WHERE id = 1;",
+				Expected: []sql.Row{{"*******6789"}},
+			},
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT DISTINCT ssn FROM `mydb/unmasked`.dolt_history_customers WHERE id = 1;",
+				Expected: []sql.Row{{"*******6789"}},
+			},
+			{
+				User:        "analyst",
+				Host:        "localhost",
+				Query:       "CALL DOLT_CHECKOUT('unmasked', '--', 'dolt_column_masks');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "analyst",
+				Host:        "localhost",
+				Query:       "CALL DOLT_MERGE('unmasked');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "analyst",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-f', 'main', 'unmasked');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "analyst",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_workspace_customers;",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:     "analyst",
+				Host:     "localhost",
+				Query:    "SELECT JSON_UNQUOTE(JSON_EXTRACT(to_row, '$.ssn')) FROM dolt_changes_since('HEAD~1');",
+				Expected: []sql.Row{{"*******5555"}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT ssn FROM `mydb/unmasked`.customers WHERE id = 1;",
+				Expected: []sql.Row{{"123-45-6789"}},
+			},
+		},
+	},
+}
+
+func TestColumnMasks(t *testing.T) {
+	runBranchControlTests(t, ColumnMasksTests)
+}
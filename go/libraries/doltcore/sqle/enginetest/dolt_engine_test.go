@@ -1589,6 +1589,16 @@ func TestPatchTableFunctionPrepared(t *testing.T) {
 	RunDoltPatchTableFunctionTestsPrepared(t, harness)
 }
 
+func TestChangesSinceTableFunction(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunChangesSinceTableFunctionTests(t, harness)
+}
+
+func TestChangesSinceTableFunctionPrepared(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunChangesSinceTableFunctionTestsPrepared(t, harness)
+}
+
 func TestLogTableFunction(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunLogTableFunctionTests(t, harness)
@@ -1649,6 +1659,21 @@ func TestDiffSystemTablePrepared(t *testing.T) {
 	RunDoltDiffSystemTableTestsPrepared(t, h)
 }
 
+func TestDoltMergeResolvers(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunDoltMergeResolversTests(t, h)
+}
+
+func TestDoltMaterializedViews(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunDoltMaterializedViewsTests(t, h)
+}
+
+func TestDoltStorageUsage(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunDoltStorageUsageTests(t, h)
+}
+
 func TestNonlocalTable(t *testing.T) {
 	h := newDoltEnginetestHarness(t)
 	RunNonlocalTableTests(t, h)
@@ -1679,6 +1704,11 @@ func TestQueryDiff(t *testing.T) {
 	RunQueryDiffTests(t, harness)
 }
 
+func TestQueryHistory(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunQueryHistoryTests(t, harness)
+}
+
 func TestSystemTableIndexes(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunSystemTableIndexesTests(t, harness)
@@ -1404,6 +1404,26 @@ func RunDoltPatchTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetest
 	}
 }
 
+func RunChangesSinceTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range ChangesSinceTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunChangesSinceTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range ChangesSinceTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			harness.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
 func RunLogTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
 	for _, test := range LogTableFunctionScriptTests {
 		t.Run(test.Name, func(t *testing.T) {
@@ -1534,6 +1554,39 @@ func RunDoltDiffSystemTableTestsPrepared(t *testing.T, h DoltEnginetestHarness)
 	}
 }
 
+func RunDoltMergeResolversTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, test := range DoltMergeResolversScripts {
+		t.Run(test.Name, func(t *testing.T) {
+			h = h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, test)
+		})
+	}
+}
+
+func RunDoltMaterializedViewsTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, test := range DoltMaterializedViewsScripts {
+		t.Run(test.Name, func(t *testing.T) {
+			h = h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, test)
+		})
+	}
+}
+
+func RunDoltStorageUsageTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, test := range DoltStorageUsageScripts {
+		t.Run(test.Name, func(t *testing.T) {
+			h = h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, test)
+		})
+	}
+}
+
 func RunNonlocalTableTests(t *testing.T, h DoltEnginetestHarness) {
 	for _, test := range NonlocalScripts {
 		t.Run(test.Name, func(t *testing.T) {
@@ -1600,6 +1653,17 @@ func RunQueryDiffTests(t *testing.T, harness DoltEnginetestHarness) {
 	}
 }
 
+func RunQueryHistoryTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range QueryHistoryTableScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
 func RunSystemTableIndexesTests(t *testing.T, harness DoltEnginetestHarness) {
 	for _, stt := range SystemTableIndexTests {
 		harness = harness.NewHarness(t).WithParallelism(1)
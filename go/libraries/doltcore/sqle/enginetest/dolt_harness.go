@@ -278,6 +278,7 @@ func (d *DoltHarness) NewEngine(t *testing.T) (enginetest.QueryEngine, error) {
 		e.Analyzer.ExecBuilder = rowexec.NewBuilder(kvexec.Builder{}, e.Analyzer.Overrides)
 		e.Analyzer.ExecBuilder.Runner = e.Analyzer.Runner
 		d.engine = e
+		doltProvider.SetMySQLDb(e.Analyzer.Catalog.MySQLDb)
 
 		sqlCtx := enginetest.NewContext(d)
 		databases := pro.AllDatabases(sqlCtx)
@@ -342,6 +343,9 @@ func (d *DoltHarness) NewEngine(t *testing.T) (enginetest.QueryEngine, error) {
 
 	d.engine.Analyzer.Catalog.MySQLDb = mysql_db.CreateEmptyMySQLDb()
 	d.engine.Analyzer.Catalog.MySQLDb.AddRootAccount()
+	if doltProvider, ok := d.provider.(*sqle.DoltDatabaseProvider); ok {
+		doltProvider.SetMySQLDb(d.engine.Analyzer.Catalog.MySQLDb)
+	}
 
 	e, err := enginetest.RunSetupScripts(ctx, d.engine, d.resetScripts(), d.SupportsNativeIndexCreation())
 	require.NoError(t, err)
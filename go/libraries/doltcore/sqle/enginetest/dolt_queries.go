@@ -2682,6 +2682,38 @@ WHERE z IN (
 			},
 		},
 	},
+	{
+		Name: "dolt_history table follows table and column renames",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(20));",
+			"insert into t values (1, 10, 'one');",
+			"call dolt_commit('-Am', 'create t');",
+			"update t set c1 = 11 where pk = 1;",
+			"call dolt_commit('-am', 'update t');",
+			"rename table t to u;",
+			"alter table u rename column c1 to d1;",
+			"alter table u add column c1 int;",
+			"call dolt_commit('-Am', 'rename t to u');",
+			"insert into u values (2, 20, 'two', 200);",
+			"call dolt_commit('-am', 'insert into u');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select h.pk, h.d1, h.c2, h.c1, l.message from dolt_history_u h join dolt_log l on h.commit_hash = l.commit_hash order by l.commit_order, h.pk;",
+				Expected: []sql.Row{
+					{1, 10, "one", nil, "create t"},
+					{1, 11, "one", nil, "update t"},
+					{1, 11, "one", nil, "rename t to u"},
+					{1, 11, "one", nil, "insert into u"},
+					{2, 20, "two", 200, "insert into u"},
+				},
+			},
+			{
+				Query:    "select d1 from dolt_history_u where pk = 1 and commit_hash = hashof('HEAD~3');",
+				Expected: []sql.Row{{10}},
+			},
+		},
+	},
 }
 
 // BrokenHistorySystemTableScriptTests contains tests that work for non-prepared, but don't work
@@ -5218,6 +5250,45 @@ var LogTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_log follows a table across renames",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"create table other (pk int primary key);",
+			"call dolt_commit('-Am', 'create tables');",
+			"insert into t values (1, 10);",
+			"call dolt_commit('-am', 'insert into t');",
+			"insert into other values (1);",
+			"call dolt_commit('-am', 'insert into other');",
+			"rename table t to u;",
+			"call dolt_commit('-Am', 'rename t to u');",
+			"alter table u rename column c1 to d1;",
+			"update u set d1 = 11;",
+			"call dolt_commit('-am', 'update u');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select message from dolt_log('--tables', 'u');",
+				Expected: []sql.Row{{"update u"}, {"rename t to u"}},
+			},
+			{
+				Query:    "select message from dolt_log('--tables', 'u', '--follow');",
+				Expected: []sql.Row{{"update u"}, {"rename t to u"}, {"insert into t"}, {"create tables"}},
+			},
+			{
+				Query:    "select message from dolt_log('HEAD~1', '--tables', 'u', '--follow');",
+				Expected: []sql.Row{{"rename t to u"}, {"insert into t"}, {"create tables"}},
+			},
+			{
+				Query:       "select message from dolt_log('--follow');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select message from dolt_log('--tables', 'u,other', '--follow');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+		},
+	},
 }
 
 var JsonDiffTableFunctionScriptTests = []queries.ScriptTest{
@@ -7991,7 +8062,11 @@ var DoltSystemVariables = []queries.ScriptTest{
 				Expected: []sql.Row{
 					{"dolt_backups"},
 					{"dolt_branch_activity"},
+					{"dolt_branch_approvals"},
 					{"dolt_branches"},
+					{"dolt_change_consumers"},
+					{"dolt_ci_runs"},
+					{"dolt_ci_step_results"},
 					{"dolt_commit_ancestors"},
 					{"dolt_commit_diff_test"},
 					{"dolt_commits"},
@@ -8003,11 +8078,14 @@ var DoltSystemVariables = []queries.ScriptTest{
 					{"dolt_help"},
 					{"dolt_history_test"},
 					{"dolt_log"},
+					{"dolt_purged_commits"},
 					{"dolt_remote_branches"},
 					{"dolt_remotes"},
 					{"dolt_stashes"},
 					{"dolt_status"},
 					{"dolt_status_ignored"},
+					{"dolt_storage_usage"},
+					{"dolt_webhook_deliveries"},
 					{"dolt_workspace_test"},
 					{"test"},
 				},
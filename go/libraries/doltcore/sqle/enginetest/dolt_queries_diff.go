@@ -819,6 +819,60 @@ var Dolt1DiffSystemTableScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "diff and blame tables follow table and column renames",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(20));",
+			"insert into t values (1, 10, 'one');",
+			"call dolt_commit('-Am', 'create t');",
+			"update t set c1 = 11 where pk = 1;",
+			"call dolt_commit('-am', 'update t');",
+			"rename table t to u;",
+			"alter table u rename column c1 to d1;",
+			"call dolt_commit('-Am', 'rename t to u');",
+			"insert into u values (2, 20, 'two');",
+			"call dolt_commit('-am', 'insert into u');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select to_pk, to_d1, to_c2, from_pk, from_d1, from_c2, diff_type from dolt_diff_u order by to_pk, to_d1;",
+				Expected: []sql.Row{
+					{1, 10, "one", nil, nil, nil, "added"},
+					{1, 11, "one", 1, 10, "one", "modified"},
+					{2, 20, "two", nil, nil, nil, "added"},
+				},
+			},
+			{
+				Query:    "select count(*) from dolt_diff_u where to_commit = hashof('HEAD~1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select to_pk, from_d1, to_d1 from dolt_diff_u where to_commit = hashof('HEAD~2');",
+				Expected: []sql.Row{{1, 10, 11}},
+			},
+			{
+				Query:    "select pk, message from dolt_blame_u order by pk;",
+				Expected: []sql.Row{{1, "update t"}, {2, "insert into u"}},
+			},
+		},
+	},
+	{
+		Name: "diff table doesn't follow a table copied from another one",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"insert into t values (1, 10);",
+			"call dolt_commit('-Am', 'create t');",
+			"create table u like t;",
+			"insert into u select * from t;",
+			"call dolt_commit('-Am', 'copy t to u');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select to_pk, to_c1, from_pk, diff_type, to_commit = hashof('HEAD') from dolt_diff_u;",
+				Expected: []sql.Row{{1, 10, nil, "added", true}},
+			},
+		},
+	},
 }
 
 // assertDoltDiffColumnCount returns assertions that verify a dolt_diff view
@@ -4098,6 +4152,287 @@ var PatchTableFunctionScriptTests = []queries.ScriptTest{
 	},
 }
 
+var ChangesSinceTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"call dolt_checkout('-b', 'other');",
+			"insert into t values (1, 'one');",
+			"set @Other1 = '';",
+			"call dolt_commit_hash_out(@Other1, '-am', 'inserting into t on other');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "SELECT * from dolt_changes_since();",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_changes_since(@Commit1, 'main', 'extra');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_changes_since(123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_changes_since(concat(@Commit1, ':1'));",
+				ExpectedErr: dtablefunctions.ErrInvalidNonLiteralArgument,
+			},
+			{
+				Query:       "SELECT * from dolt_changes_since(@Other1);",
+				ExpectedErr: dtables.ErrCursorNotInHistory,
+			},
+			{
+				Query:          "SELECT * from dolt_changes_since(@Commit1, 'fake-branch');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+		},
+	},
+	{
+		Name: "changes since a commit",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values (1, 'one'), (2, 'two');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+			"call dolt_branch('other');",
+
+			"update t set c1 = 'uno' where pk = 1;",
+			"delete from t where pk = 2;",
+			"set @Commit3 = '';",
+			"call dolt_commit_hash_out(@Commit3, '-am', 'updating and deleting from t');",
+
+			"create table u (pk int primary key);",
+			"insert into u values (10);",
+			"update t set c1 = 'eins' where pk = 1;",
+			"call dolt_add('.')",
+			"set @Commit4 = '';",
+			"call dolt_commit_hash_out(@Commit4, '-am', 'creating table u');",
+
+			"call dolt_checkout('other');",
+			"insert into t values (3, 'three');",
+			"call dolt_commit('-am', 'inserting into t on other');",
+			"call dolt_checkout('main');",
+			"set @Cursor = concat(@Commit3, ':1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT commit_hash = @Commit2, table_name, diff_type, json_unquote(json_extract(from_row, '$.c1')), json_unquote(json_extract(to_row, '$.c1')) from dolt_changes_since(@Commit1);",
+				Expected: []sql.Row{
+					{true, "t", "added", nil, "one"},
+					{true, "t", "added", nil, "two"},
+					{false, "t", "modified", "one", "uno"},
+					{false, "t", "removed", "two", nil},
+					{false, "t", "modified", "uno", "eins"},
+					{false, "u", "added", nil, nil},
+				},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_changes_since(@Commit1) where change_cursor = concat(@Commit3, ':2');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				// resuming from the cursor of the first change of @Commit3 skips that change
+				Query: "SELECT table_name, diff_type, json_unquote(json_extract(coalesce(to_row, from_row), '$.pk')) from dolt_changes_since(@Cursor);",
+				Expected: []sql.Row{
+					{"t", "removed", "2"},
+					{"t", "modified", "1"},
+					{"u", "added", "10"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_changes_since(@Commit3);",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_changes_since(@Commit4);",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_changes_since('HEAD~1');",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "SELECT json_unquote(json_extract(to_row, '$.c1')) from dolt_changes_since(@Commit2, 'other');",
+				Expected: []sql.Row{{"three"}},
+			},
+			{
+				// revisions are resolved relative to the branch being read
+				Query:    "SELECT json_unquote(json_extract(to_row, '$.c1')) from dolt_changes_since('HEAD~1', 'other');",
+				Expected: []sql.Row{{"three"}},
+			},
+		},
+	},
+	{
+		Name: "merged changes are returned with the merge commit",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"call dolt_checkout('-b', 'feature');",
+			"insert into t values (1, 'one');",
+			"call dolt_commit('-am', 'inserting 1 on feature');",
+			"insert into t values (2, 'two');",
+			"call dolt_commit('-am', 'inserting 2 on feature');",
+
+			"call dolt_checkout('main');",
+			"insert into t values (3, 'three');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting 3 on main');",
+			"call dolt_merge('feature');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT commit_hash = hashof('main'), diff_type, json_unquote(json_extract(to_row, '$.pk')) from dolt_changes_since(@Commit2);",
+				Expected: []sql.Row{
+					{true, "added", "1"},
+					{true, "added", "2"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_changes_since(@Commit1);",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "keyless tables",
+		SetUpScript: []string{
+			"create table k (c1 int);",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table k');",
+
+			"insert into k values (1), (1), (2);",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into k');",
+
+			"delete from k where c1 = 2;",
+			"insert into k values (1);",
+			"call dolt_commit('-am', 'updating k');",
+			"set @Cursor = concat(@Commit2, ':2');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT diff_type, json_unquote(json_extract(coalesce(to_row, from_row), '$.c1')) from dolt_changes_since(@Commit1);",
+				Expected: []sql.Row{
+					{"added", "1"},
+					{"added", "1"},
+					{"added", "2"},
+					{"added", "1"},
+					{"removed", "2"},
+				},
+			},
+			{
+				Query:    "SELECT count(*) from dolt_changes_since(@Cursor);",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "change consumers",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+
+			"insert into t values (1, 'one'), (2, 'two');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+
+			"call dolt_branch('other');",
+			"insert into t values (3, 'three');",
+			"call dolt_commit('-am', 'inserting into t on main');",
+			"set @Cursor = concat(@Commit2, ':1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_change_consumer('add', 'sink', 'main', @Commit1);",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_change_consumer('add', 'other_sink', 'other');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "call dolt_change_consumer('add', 'sink', 'main');",
+				ExpectedErrStr: "change consumer already exists: sink",
+			},
+			{
+				Query:          "call dolt_change_consumer('add', 'bad_sink', 'fake-branch');",
+				ExpectedErrStr: "branch not found: fake-branch",
+			},
+			{
+				Query:    "select name, branch, change_cursor = @Commit1 from dolt_change_consumers;",
+				Expected: []sql.Row{{"other_sink", "other", false}, {"sink", "main", true}},
+			},
+			{
+				Query:    "select json_unquote(json_extract(to_row, '$.c1')) from dolt_changes_since('--consumer', 'sink');",
+				Expected: []sql.Row{{"one"}, {"two"}, {"three"}},
+			},
+			{
+				Query:    "select json_unquote(json_extract(to_row, '$.c1')) from dolt_changes_since('--consumer', 'other_sink');",
+				Expected: []sql.Row{{"one"}, {"two"}},
+			},
+			{
+				Query:    "call dolt_change_consumer('commit', 'sink', @Cursor);",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				// the position is kept by the server, and reads resume after it
+				Query:    "select json_unquote(json_extract(to_row, '$.c1')) from dolt_changes_since('--consumer', 'sink');",
+				Expected: []sql.Row{{"two"}, {"three"}},
+			},
+			{
+				Query:    "select change_cursor = @Cursor from dolt_change_consumers where name = 'sink';",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				// revisions are resolved against the consumer's branch when the position is recorded
+				Query:    "call dolt_change_consumer('commit', 'sink', 'HEAD');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select change_cursor = hashof('main') from dolt_change_consumers where name = 'sink';",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "select count(*) from dolt_changes_since('--consumer', 'sink');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "call dolt_change_consumer('commit', 'missing', @Cursor);",
+				ExpectedErrStr: "change consumer not found: missing",
+			},
+			{
+				Query:          "select * from dolt_changes_since('--consumer', 'missing');",
+				ExpectedErrStr: "change consumer not found: missing",
+			},
+			{
+				Query:    "call dolt_change_consumer('remove', 'sink');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select name from dolt_change_consumers;",
+				Expected: []sql.Row{{"other_sink"}},
+			},
+		},
+	},
+}
+
 var UnscopedDiffSystemTableScriptTests = []queries.ScriptTest{
 	{
 		Name: "working set changes",
@@ -6936,3 +7271,76 @@ var QueryDiffTableScriptTests = []queries.ScriptTest{
 		},
 	},
 }
+
+var QueryHistoryTableScriptTests = []queries.ScriptTest{
+	{
+		Name: "basic query history tests",
+		SetUpScript: []string{
+			"create table t (pk int primary key, active bool);",
+			"insert into t values (1, true), (2, false);",
+			"call dolt_commit('-Am', 'first');",
+			"insert into t values (3, true);",
+			"call dolt_commit('-am', 'second');",
+			"create table other (pk int primary key);",
+			"call dolt_commit('-Am', 'third');",
+			"update t set active = true where pk = 2;",
+			"call dolt_commit('-am', 'fourth');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select l.message, h.n from dolt_query_history('select count(*) as n from t where active', 'HEAD~3', 'HEAD') h " +
+					"join dolt_log l on h.commit_hash = l.commit_hash order by l.message;",
+				Expected: []sql.Row{{"first", 1}, {"fourth", 3}, {"second", 2}, {"third", 2}},
+			},
+			{
+				Query:    "select count(*) from dolt_query_history('select * from t', 'HEAD~1', 'HEAD');",
+				Expected: []sql.Row{{6}},
+			},
+			{
+				Query:    "select count(*) from dolt_query_history('select * from t', 'HEAD', 'HEAD');",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				// commits where a table read by the query doesn't exist have no rows
+				Query:    "select l.message, h.n from dolt_query_history('select count(*) as n from other', 'HEAD~3', 'HEAD') h join dolt_log l on h.commit_hash = l.commit_hash order by l.message;",
+				Expected: []sql.Row{{"fourth", 0}, {"third", 0}},
+			},
+			{
+				Query:    "select commit_hash = hashof('HEAD~2'), pk from dolt_query_history('select pk from t where not active', 'HEAD~2', 'HEAD~2');",
+				Expected: []sql.Row{{true, 2}},
+			},
+			{
+				Query:          "select * from dolt_query_history('select * from t');",
+				ExpectedErrStr: "function 'dolt_query_history' expected 3 arguments, 1 received",
+			},
+			{
+				Query:          "select * from dolt_query_history('delete from t', 'HEAD~1', 'HEAD');",
+				ExpectedErrStr: "query must be a SELECT statement",
+			},
+		},
+	},
+	{
+		Name: "query history only reuses results when every table read is unchanged",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"create table u (pk int primary key);",
+			"insert into t values (1);",
+			"call dolt_commit('-Am', 'first');",
+			"insert into u values (1), (2);",
+			"call dolt_commit('-am', 'second');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// t is unchanged, but the query reads u through the common table expression named t
+				Query: "select l.message, h.n from dolt_query_history('with t as (select count(*) as n from u) select n from t union all select -1', 'HEAD~1', 'HEAD') h " +
+					"join dolt_log l on h.commit_hash = l.commit_hash where h.n >= 0 order by l.message;",
+				Expected: []sql.Row{{"first", 0}, {"second", 2}},
+			},
+			{
+				Query: "select l.message, h.n from dolt_query_history('select count(*) as n from t where pk in (select pk from u)', 'HEAD~1', 'HEAD') h " +
+					"join dolt_log l on h.commit_hash = l.commit_hash order by l.message;",
+				Expected: []sql.Row{{"first", 0}, {"second", 1}},
+			},
+		},
+	},
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var DoltMaterializedViewsScripts = []queries.ScriptTest{
+	{
+		Name: "materialized views: filtered view is refreshed on commit",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, a int);",
+			"INSERT INTO t VALUES (1, 1), (2, 5), (3, 10);",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_create_materialized_view('big', 'SELECT pk, a FROM t WHERE a > 3');",
+			"CALL dolt_commit('-Am', 'create big');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_materialized_views;",
+				Expected: []sql.Row{{"big", "SELECT pk, a FROM t WHERE a > 3"}},
+			},
+			{
+				Query:    "select * from big order by pk;",
+				Expected: []sql.Row{{2, 5}, {3, 10}},
+			},
+			{
+				Query:            "update t set a = 4 where pk = 1;",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "delete from t where pk = 3;",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "insert into t values (4, 2), (5, 6);",
+				SkipResultsCheck: true,
+			},
+			{
+				// views are refreshed when their base tables are committed
+				Query:    "select * from big order by pk;",
+				Expected: []sql.Row{{2, 5}, {3, 10}},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'update t');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "select * from big order by pk;",
+				Expected: []sql.Row{{1, 4}, {2, 5}, {5, 6}},
+			},
+			{
+				Query:    "select count(*) from dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select diff_type, to_pk, from_pk from dolt_diff('HEAD~1', 'HEAD', 'big') order by to_pk, from_pk;",
+				Expected: []sql.Row{{"removed", nil, 3}, {"added", 1, nil}, {"added", 5, nil}},
+			},
+		},
+	},
+	{
+		Name: "materialized views: joined view is refreshed on commit",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, a varchar(10));",
+			"CREATE TABLE u (pk int primary key, t_pk int, b int);",
+			"INSERT INTO t VALUES (1, 'one'), (2, 'two');",
+			"INSERT INTO u VALUES (10, 1, 100), (20, 2, 200), (30, 3, 300);",
+			"CALL dolt_create_materialized_view('tu', 'SELECT t.pk, u.pk AS upk, t.a, u.b FROM t JOIN u ON t.pk = u.t_pk');",
+			"CALL dolt_commit('-Am', 'init');",
+			"INSERT INTO t VALUES (3, 'three');",
+			"UPDATE t SET a = 'uno' WHERE pk = 1;",
+			"DELETE FROM u WHERE pk = 20;",
+			"CALL dolt_commit('-am', 'update t and u');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from tu order by pk, upk;",
+				Expected: []sql.Row{{1, 10, "uno", 100}, {3, 30, "three", 300}},
+			},
+		},
+	},
+	{
+		Name: "materialized views: grouped view is refreshed on commit",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, g varchar(10) not null, x int);",
+			"INSERT INTO t VALUES (1, 'a', 1), (2, 'a', 2), (3, 'b', 5), (5, 'it''s', 1);",
+			"CALL dolt_create_materialized_view('totals', 'SELECT g, SUM(x) AS total, COUNT(*) AS n FROM t GROUP BY g');",
+			"CALL dolt_commit('-Am', 'init');",
+			"UPDATE t SET x = 10 WHERE pk = 1;",
+			"INSERT INTO t VALUES (4, 'c', 7), (6, 'it''s', 2);",
+			"DELETE FROM t WHERE pk = 3;",
+			"CALL dolt_commit('-am', 'update t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select g, cast(total as signed), n from totals order by g;",
+				Expected: []sql.Row{{"a", 12, 2}, {"c", 7, 1}, {"it's", 3, 2}},
+			},
+			{
+				Query:    "select column_name from information_schema.columns where table_name = 'totals' and column_key = 'PRI';",
+				Expected: []sql.Row{{"g"}},
+			},
+		},
+	},
+	{
+		Name: "materialized views: views that can't be refreshed incrementally are rebuilt",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, g int not null, a int);",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 2, 2);",
+			"CALL dolt_create_materialized_view('m', 'SELECT g, MAX(a) AS m FROM t GROUP BY g HAVING MAX(a) > 1');",
+			"CALL dolt_commit('-Am', 'init');",
+			"INSERT INTO t VALUES (3, 1, 30);",
+			"CALL dolt_commit('-am', 'insert');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from m order by g;",
+				Expected: []sql.Row{{1, 30}, {2, 2}},
+			},
+		},
+	},
+	{
+		Name: "materialized views: tables of views are keyed",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, g varchar(10), a int);",
+			"CREATE TABLE u (id int primary key, t_pk int);",
+			"INSERT INTO t VALUES (1, 'a', 1);",
+			"INSERT INTO u VALUES (10, 1);",
+			"CALL dolt_create_materialized_view('tu', 'SELECT u.id, t.pk AS tpk, t.a FROM t JOIN u ON t.pk = u.t_pk');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select column_name from information_schema.columns where table_name = 'tu' and column_key = 'PRI' order by ordinal_position;",
+				Expected: []sql.Row{{"id"}, {"tpk"}},
+			},
+			{
+				Query:          "call dolt_create_materialized_view('n', 'SELECT COUNT(*) AS n FROM t');",
+				ExpectedErrStr: "could not create the table of materialized view n: materialized views must select the primary key columns of each of their tables, or be grouped by the columns they select",
+			},
+			{
+				Query:          "call dolt_create_materialized_view('g', 'SELECT g, COUNT(*) AS n FROM t GROUP BY g');",
+				ExpectedErrStr: "could not create the table of materialized view g: materialized views are keyed by the columns they are grouped by, which must be NOT NULL: t.g is nullable",
+			},
+			{
+				Query:    "show tables;",
+				Expected: []sql.Row{{"t"}, {"tu"}, {"u"}},
+			},
+		},
+	},
+	{
+		Name: "materialized views: views with unstaged base tables are not refreshed",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, a int);",
+			"CREATE TABLE other (pk int primary key);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL dolt_create_materialized_view('v', 'SELECT pk, a FROM t');",
+			"CALL dolt_commit('-Am', 'init');",
+			"INSERT INTO t VALUES (2, 2);",
+			"INSERT INTO other VALUES (1);",
+			"CALL dolt_add('other');",
+			"CALL dolt_commit('-m', 'commit other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from v order by pk;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "call dolt_refresh_materialized_view('v');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from v order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				Query:    "select table_name, staged from dolt_status order by table_name;",
+				Expected: []sql.Row{{"t", byte(0)}, {"v", byte(0)}},
+			},
+		},
+	},
+	{
+		Name: "materialized views: create and drop",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, a int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL dolt_create_materialized_view('v', 'SELECT pk, a FROM t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_create_materialized_view('v', 'SELECT a FROM t');",
+				ExpectedErrStr: "materialized view v already exists",
+			},
+			{
+				Query:          "call dolt_create_materialized_view('w', 'DELETE FROM t');",
+				ExpectedErrStr: "the definition of a materialized view must be a SELECT statement",
+			},
+			{
+				Query:          "call dolt_drop_materialized_view('w');",
+				ExpectedErrStr: "materialized view w does not exist",
+			},
+			{
+				Query:    "call dolt_drop_materialized_view('v');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_materialized_views;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "show tables;",
+				Expected: []sql.Row{{"t"}},
+			},
+		},
+	},
+	{
+		Name: "materialized views: cherry-picked changes are refreshed",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, a int);",
+			"INSERT INTO t VALUES (1, 1), (2, 5);",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_create_materialized_view('big', 'SELECT pk, a FROM t WHERE a > 3');",
+			"CALL dolt_commit('-Am', 'create big');",
+			"CALL dolt_checkout('-b', 'feature');",
+			"INSERT INTO t VALUES (3, 10);",
+			"CALL dolt_add('t');",
+			// the unstaged change keeps big from being refreshed by the commit
+			"INSERT INTO t VALUES (4, 20);",
+			"CALL dolt_commit('-m', 'add 3');",
+			"CALL dolt_reset('--hard');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from big as of 'feature' order by pk;",
+				Expected: []sql.Row{{2, 5}},
+			},
+			{
+				Query:    "call dolt_cherry_pick('feature');",
+				Expected: []sql.Row{{doltCommit, 0, 0, 0}},
+			},
+			{
+				Query:    "select * from big order by pk;",
+				Expected: []sql.Row{{2, 5}, {3, 10}},
+			},
+			{
+				Query:    "select count(*) from dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+}
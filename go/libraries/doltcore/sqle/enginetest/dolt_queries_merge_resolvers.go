@@ -0,0 +1,289 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"time"
+
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+var DoltMergeResolversScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_merge_resolvers: sum resolves concurrent counter updates",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, counter int, note varchar(20));",
+			"INSERT INTO t VALUES (1, 10, 'a'), (2, 20, 'b');",
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', 'counter', 'sum', NULL);",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			"UPDATE t SET counter = counter + 5;",
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			"UPDATE t SET counter = counter + 3 WHERE pk = 1;",
+			"UPDATE t SET counter = counter - 1 WHERE pk = 2;",
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_merge_resolvers;",
+				Expected: []sql.Row{{"t", "counter", "sum", nil}},
+			},
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, 18, "a"}, {2, 24, "b"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: columns without a resolver still conflict",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, counter int, note varchar(20));",
+			"INSERT INTO t VALUES (1, 10, 'a');",
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', 'counter', 'max', NULL);",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			"UPDATE t SET counter = 11, note = 'main';",
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			"UPDATE t SET counter = 12, note = 'other';",
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+			"set autocommit = 0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select base_counter, our_counter, their_counter, base_note, our_note, their_note from dolt_conflicts_t;",
+				Expected: []sql.Row{{10, 11, 12, "a", "main", "other"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: max, min and expression strategies",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, hi int, lo int, note varchar(20));",
+			"INSERT INTO t VALUES (1, 10, 10, 'a');",
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', 'hi', 'MAX', NULL), ('t', 'lo', 'min', NULL), ('t', 'note', 'expression', 'concat(our_note, \"+\", their_note)');",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			"UPDATE t SET hi = 15, lo = 15, note = 'main';",
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			"UPDATE t SET hi = 12, lo = 12, note = 'other';",
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 15, 12, "main+other"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: row-level latest strategy",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, a int, b int, updated_at datetime);",
+			"INSERT INTO t VALUES (1, 1, 1, '2020-01-01'), (2, 2, 2, '2020-01-01');",
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', '*', 'latest', 'updated_at');",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			"UPDATE t SET a = 10, b = 10, updated_at = '2021-01-01' WHERE pk = 1;",
+			"UPDATE t SET a = 20, b = 20, updated_at = '2023-01-01' WHERE pk = 2;",
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			"UPDATE t SET a = 11, updated_at = '2022-01-01' WHERE pk = 1;",
+			"UPDATE t SET a = 21, updated_at = '2022-01-01' WHERE pk = 2;",
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query: "select pk, a, b, updated_at from t order by pk;",
+				Expected: []sql.Row{
+					{1, 11, 1, time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)},
+					{2, 20, 20, time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: json_array_concat strategy",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, tags json);",
+			`INSERT INTO t VALUES (1, '["a"]');`,
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', 'tags', 'json_array_concat', NULL);",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			`UPDATE t SET tags = '["a", "b"]';`,
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			`UPDATE t SET tags = '["a", "c"]';`,
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select tags from t;",
+				Expected: []sql.Row{{types.MustJSON(`["a", "b", "c"]`)}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: json_array_union strategy merges nested arrays",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, doc json);",
+			`INSERT INTO t VALUES (1, '{"name": "x", "tags": ["a", "b"]}');`,
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', 'doc', 'json_array_union', NULL);",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			`UPDATE t SET doc = '{"name": "y", "tags": ["b", "c"]}';`,
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			`UPDATE t SET doc = '{"name": "x", "tags": ["a", "b", "d"]}';`,
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select doc from t;",
+				Expected: []sql.Row{{types.MustJSON(`{"name": "y", "tags": ["b", "c", "d"]}`)}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: json_array_set strategy",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, tags json);",
+			`INSERT INTO t VALUES (1, '["a"]');`,
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', 'tags', 'json_array_set', NULL);",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			`UPDATE t SET tags = '["a", "b", "c"]';`,
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			`UPDATE t SET tags = '["c", "d", "d"]';`,
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select tags from t;",
+				Expected: []sql.Row{{types.MustJSON(`["b", "c", "d"]`)}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: json_array_keyed strategy merges edits to different elements",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, doc json);",
+			`INSERT INTO t VALUES (1, '{"rules": [{"name": "r1", "allow": false}, {"name": "r2", "allow": false}]}');`,
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', 'doc', 'json_array_keyed', 'name');",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			`UPDATE t SET doc = '{"rules": [{"name": "r1", "allow": true}, {"name": "r2", "allow": false}]}';`,
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			`UPDATE t SET doc = '{"rules": [{"name": "r1", "allow": false}, {"name": "r2", "allow": true}, {"name": "r3", "allow": true}]}';`,
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select doc from t;",
+				Expected: []sql.Row{{types.MustJSON(`{"rules": [{"name": "r1", "allow": true}, {"name": "r2", "allow": true}, {"name": "r3", "allow": true}]}`)}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: json_array_keyed strategy conflicts on the same element",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, doc json);",
+			`INSERT INTO t VALUES (1, '[{"id": 1, "v": 0}, {"id": 2, "v": 0}]');`,
+			"INSERT INTO dolt_merge_resolvers VALUES ('t', 'doc', 'json_array_keyed', NULL);",
+			"CALL dolt_commit('-Am', 'init');",
+			"CALL dolt_branch('other');",
+			`UPDATE t SET doc = '[{"id": 1, "v": 1}, {"id": 2, "v": 0}]';`,
+			"CALL dolt_commit('-am', 'main');",
+			"CALL dolt_checkout('other');",
+			`UPDATE t SET doc = '[{"id": 1, "v": 2}, {"id": 2, "v": 0}]';`,
+			"CALL dolt_commit('-am', 'other');",
+			"CALL dolt_checkout('main');",
+			"set autocommit = 0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts_t;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_resolvers: invalid definitions are rejected",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "INSERT INTO dolt_merge_resolvers VALUES ('t', 'c', 'average', NULL);",
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				Query:       "INSERT INTO dolt_merge_resolvers VALUES ('t', 'c', 'expression', NULL);",
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				Query:    "select count(*) from dolt_merge_resolvers;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+}
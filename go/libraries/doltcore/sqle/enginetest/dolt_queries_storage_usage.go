@@ -0,0 +1,92 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var DoltStorageUsageScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_storage_usage: refs and tables",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c varchar(100));",
+			"INSERT INTO t VALUES (1, 'one'), (2, 'two');",
+			"CALL dolt_commit('-Am', 'add t');",
+			"CALL dolt_tag('v1');",
+			"CALL dolt_branch('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select ref from dolt_storage_usage where table_name = '' and ref in ('refs/heads/main', 'refs/heads/other', 'refs/tags/v1') order by ref;",
+				Expected: []sql.Row{{"refs/heads/main"}, {"refs/heads/other"}, {"refs/tags/v1"}},
+			},
+			{
+				Query:    "select ref, table_name from dolt_storage_usage where table_name = 't' order by ref;",
+				Expected: []sql.Row{{"refs/heads/main", "t"}, {"refs/heads/other", "t"}, {"refs/tags/v1", "t"}},
+			},
+			{
+				Query:    "select count(*) from dolt_storage_usage where chunk_count = 0 or logical_bytes = 0 or exclusive_bytes > logical_bytes;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				// every chunk of a branch and the tag pointing at its head is shared
+				Query:    "select ref, exclusive_bytes from dolt_storage_usage where table_name = 't' order by ref;",
+				Expected: []sql.Row{{"refs/heads/main", uint64(0)}, {"refs/heads/other", uint64(0)}, {"refs/tags/v1", uint64(0)}},
+			},
+			{
+				Query: "select main.logical_bytes >= t.logical_bytes from dolt_storage_usage main join dolt_storage_usage t " +
+					"on main.ref = t.ref where main.ref = 'refs/heads/main' and main.table_name = '' and t.table_name = 't';",
+				Expected: []sql.Row{{true}},
+			},
+		},
+	},
+	{
+		Name: "dolt_storage_usage: exclusive bytes of a branch",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c varchar(100));",
+			"INSERT INTO t VALUES (1, 'one');",
+			"CALL dolt_commit('-Am', 'add t');",
+			"CALL dolt_checkout('-b', 'other');",
+			"INSERT INTO t VALUES (2, 'two');",
+			"CREATE TABLE u (pk int primary key);",
+			"CALL dolt_commit('-Am', 'add u');",
+			"CALL dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select ref, exclusive_bytes > 0 from dolt_storage_usage where table_name = '' and ref in ('refs/heads/main', 'refs/heads/other') order by ref;",
+				Expected: []sql.Row{{"refs/heads/main", false}, {"refs/heads/other", true}},
+			},
+			{
+				Query:    "select ref, table_name from dolt_storage_usage where table_name in ('t', 'u') order by ref, table_name;",
+				Expected: []sql.Row{{"refs/heads/main", "t"}, {"refs/heads/other", "t"}, {"refs/heads/other", "u"}},
+			},
+			{
+				Query:    "select exclusive_bytes > 0 from dolt_storage_usage where ref = 'refs/heads/other' and table_name = 't';",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:            "call dolt_branch('-D', 'other');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select count(*) from dolt_storage_usage where ref = 'refs/heads/other';",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+}
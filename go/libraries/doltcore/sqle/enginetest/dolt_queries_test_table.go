@@ -527,6 +527,104 @@ var DoltTestRunFunctionScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "Can expect snapshots",
+		SetUpScript: []string{
+			"CREATE TABLE t (i int primary key, s varchar(10))",
+			"INSERT INTO t VALUES (1, 'a'), (2, NULL)",
+			`INSERT INTO dolt_tests VALUES ('snap pass', 'snapshot tests', 'select * from t', 'expected_snapshot', '==', '{"columns":["i","s"],"rows":[["1","a"],["2",null]]}'), ` +
+				`('snap fail', 'snapshot tests', 'select * from t', 'expected_snapshot', '==', '{"columns":["i","s"],"rows":[["1","a"],["3","c"]]}'), ` +
+				`('snap columns', 'snapshot tests', 'select i from t', 'expected_snapshot', '==', '{"columns":["i","s"],"rows":[]}'), ` +
+				`('snap missing', 'snapshot tests', 'select * from t', 'expected_snapshot', '==', NULL)`,
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM dolt_test_run('snapshot tests')",
+				Expected: []sql.Row{
+					{"snap columns", "snapshot tests", "select i from t", "FAIL", "Assertion failed: expected_snapshot columns (i, s), got (i)"},
+					{"snap fail", "snapshot tests", "select * from t", "FAIL", "Assertion failed: expected_snapshot, got 1 missing and 1 unexpected rows. missing: (3, c); unexpected: (2, NULL)"},
+					{"snap missing", "snapshot tests", "select * from t", "FAIL", "no snapshot has been recorded for expected_snapshot. Record one with dolt test --update-snapshots"},
+					{"snap pass", "snapshot tests", "select * from t", "PASS", ""},
+				},
+			},
+			{
+				Query: "SELECT * FROM dolt_test_snapshots('snap missing')",
+				Expected: []sql.Row{
+					{"snap missing", "{\"columns\":[\"i\",\"s\"],\"rows\":[\n[\"1\",\"a\"],\n[\"2\",null]\n]}"},
+				},
+			},
+		},
+	},
+	{
+		Name: "Can expect the result of another query",
+		SetUpScript: []string{
+			"CREATE TABLE t (i int primary key)",
+			"INSERT INTO t VALUES (1), (2)",
+			"INSERT INTO dolt_tests VALUES ('same', 'query tests', 'select i from t', 'expected_query_result', '==', 'select 2 union select 1'), " +
+				"('different', 'query tests', 'select i from t', 'expected_query_result', '==', 'select 1'), " +
+				"('more columns', 'query tests', 'select i from t', 'expected_query_result', '==', 'select 1, 2'), " +
+				"('bad comparator', 'query tests', 'select i from t', 'expected_query_result', '!=', 'select 1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM dolt_test_run('query tests')",
+				Expected: []sql.Row{
+					{"bad comparator", "query tests", "select i from t", "FAIL", "!= is not a valid comparison for expected_query_result. Only '==' is supported"},
+					{"different", "query tests", "select i from t", "FAIL", "Assertion failed: expected_query_result, got 0 missing and 1 unexpected rows. unexpected: (2)"},
+					{"more columns", "query tests", "select i from t", "FAIL", "Assertion failed: expected_query_result with 2 columns, got 1"},
+					{"same", "query tests", "select i from t", "PASS", ""},
+				},
+			},
+		},
+	},
+	{
+		Name: "Can expect no rows",
+		SetUpScript: []string{
+			"CREATE TABLE t (i int primary key, s varchar(10))",
+			"INSERT INTO t VALUES (1, 'a'), (2, NULL), (3, 'c')",
+			"INSERT INTO dolt_tests VALUES ('no rows', 'no rows tests', 'select * from t where i > 5', 'expected_no_rows', '==', NULL), " +
+				"('some rows', 'no rows tests', 'select * from t where i < 3', 'expected_no_rows', '==', NULL)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM dolt_test_run('no rows tests')",
+				Expected: []sql.Row{
+					{"no rows", "no rows tests", "select * from t where i > 5", "PASS", ""},
+					{"some rows", "no rows tests", "select * from t where i < 3", "FAIL", "Assertion failed: expected_no_rows, got 2 rows: (1, a), (2, NULL)"},
+				},
+			},
+		},
+	},
+	{
+		Name: "Can expect no changes since a merge base",
+		SetUpScript: []string{
+			"CREATE TABLE t (i int primary key, region varchar(10))",
+			"INSERT INTO t VALUES (1, 'eu'), (2, 'us')",
+			"CALL DOLT_COMMIT('-Am', 'add t')",
+			"CALL DOLT_BRANCH('base')",
+			"INSERT INTO t VALUES (3, 'us')",
+			"UPDATE t SET region = 'eu' WHERE i = 2",
+			"CALL DOLT_COMMIT('-am', 'change t')",
+			"UPDATE t SET region = 'us' WHERE i = 1",
+			"INSERT INTO dolt_tests VALUES ('eu rows untouched', 'diff tests', 'select * from t where region = ''eu''', 'expected_no_diff', '==', 'base'), " +
+				"('first row untouched', 'diff tests', 'select * from t where i = 1', 'expected_no_diff', '==', 'base'), " +
+				"('second row untouched', 'diff tests', 'select i from t where i = 2', 'expected_no_diff', '==', 'base'), " +
+				"('grouped rows untouched', 'diff tests', 'select region, count(*) from t group by region', 'expected_no_diff', '==', 'base'), " +
+				"('us rows untouched', 'diff tests', 'select * from t where region = ''us''', 'expected_no_diff', '==', 'base')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM dolt_test_run('diff tests')",
+				Expected: []sql.Row{
+					{"eu rows untouched", "diff tests", "select * from t where region = 'eu'", "FAIL", "Assertion failed: expected_no_diff since merge base with base, got 1 added and 0 removed rows. added: (2, eu)"},
+					{"first row untouched", "diff tests", "select * from t where i = 1", "PASS", ""},
+					{"grouped rows untouched", "diff tests", "select region, count(*) from t group by region", "FAIL", "expected_no_diff requires a query of the form SELECT ... FROM ... WHERE ..., without GROUP BY or HAVING"},
+					{"second row untouched", "diff tests", "select i from t where i = 2", "FAIL", "Assertion failed: expected_no_diff since merge base with base, got 1 added and 1 removed rows. added: (2, eu); removed: (2, us)"},
+					{"us rows untouched", "diff tests", "select * from t where region = 'us'", "FAIL", "Assertion failed: expected_no_diff since merge base with base, got 1 added and 1 removed rows. added: (3, us); removed: (2, us)"},
+				},
+			},
+		},
+	},
 }
 
 // RunDoltTestsValidationTests verifies that dolt_tests rejects invalid
@@ -548,6 +646,10 @@ func RunDoltTestsValidationTests(t *testing.T, harness DoltEnginetestHarness) {
 		// Each remaining valid assertion_type with at least one comparator.
 		{"expected_columns", "==", false},
 		{"expected_single_value", "==", false},
+		{"expected_snapshot", "==", false},
+		{"expected_query_result", "==", false},
+		{"expected_no_rows", "==", false},
+		{"expected_no_diff", "==", false},
 
 		// Invalid assertion_type, valid comparator.
 		{"row_count", "==", true},           // common mistake (issue #10568)
@@ -0,0 +1,655 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
+)
+
+// rowSecuritySetUpScript creates two tenant users without the SUPER privilege, and a table whose rows are split
+// between them by the policies in dolt_row_policies. The table has two commits: one adding every row, and one
+// updating a row of each tenant.
+var rowSecuritySetUpScript = []string{
+	"CREATE USER tenant1@localhost;",
+	"GRANT ALL ON *.* TO tenant1@localhost;",
+	"REVOKE SUPER ON *.* FROM tenant1@localhost;",
+	"CREATE USER tenant2@localhost;",
+	"GRANT ALL ON *.* TO tenant2@localhost;",
+	"REVOKE SUPER ON *.* FROM tenant2@localhost;",
+	"CREATE TABLE accounts (id INT PRIMARY KEY, tenant VARCHAR(20), balance INT, INDEX (tenant));",
+	"INSERT INTO accounts VALUES (1, 'tenant1', 100), (2, 'tenant1', 200), (3, 'tenant2', 300);",
+	"INSERT INTO dolt_row_policies VALUES ('accounts', 'tenant1_rows', 'all', 'tenant1', 'tenant = ''tenant1''');",
+	"INSERT INTO dolt_row_policies VALUES ('accounts', 'tenant2_rows', 'all', 'tenant2', 'tenant = ''tenant2''');",
+	"CALL DOLT_COMMIT('-Am', 'add accounts');",
+	"UPDATE accounts SET balance = balance + 50 WHERE id IN (1, 3);",
+	"CALL DOLT_COMMIT('-am', 'update balances');",
+}
+
+// rowSecurityHistorySetUpScript creates a tenant user without the SUPER privilege, and a table whose first commit,
+// tagged `open`, has a policy giving the tenant access to every row, which the second commit restricts. The branches
+// `loose` and `wide` add policies giving the tenant access to every row again; `wide` can be fast-forwarded onto main.
+var rowSecurityHistorySetUpScript = []string{
+	"CREATE USER tenant1@localhost;",
+	"GRANT ALL ON *.* TO tenant1@localhost;",
+	"REVOKE SUPER ON *.* FROM tenant1@localhost;",
+	"CREATE TABLE accounts (id INT PRIMARY KEY, tenant VARCHAR(20), balance INT);",
+	"INSERT INTO accounts VALUES (1, 'tenant1', 100), (2, 'tenant1', 200), (3, 'tenant2', 300);",
+	"INSERT INTO dolt_row_policies VALUES ('accounts', 'tenant1_rows', 'all', 'tenant1', 'true');",
+	"CALL DOLT_COMMIT('-Am', 'add accounts');",
+	"CALL DOLT_TAG('open');",
+	"CALL DOLT_BRANCH('loose');",
+	"UPDATE dolt_row_policies SET filter = 'tenant = ''tenant1''' WHERE policy_name = 'tenant1_rows';",
+	"CALL DOLT_COMMIT('-am', 'restrict accounts');",
+	"CALL DOLT_BRANCH('wide');",
+	"CALL DOLT_CHECKOUT('loose');",
+	"INSERT INTO dolt_row_policies VALUES ('accounts', 'loose_rows', 'select', 'tenant1', 'true');",
+	"CALL DOLT_COMMIT('-am', 'loosen accounts');",
+	"CALL DOLT_CHECKOUT('wide');",
+	"INSERT INTO dolt_row_policies VALUES ('accounts', 'wide_rows', 'select', 'tenant1', 'true');",
+	"CALL DOLT_COMMIT('-am', 'widen accounts');",
+	"CALL DOLT_CHECKOUT('main');",
+}
+
+var RowSecurityTests = []BranchControlTest{
+	{
+		Name:        "Reads are filtered by policies",
+		SetUpScript: rowSecuritySetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT * FROM accounts ORDER BY id;",
+				Expected: []sql.Row{{1, "tenant1", 150}, {2, "tenant1", 200}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT balance FROM accounts WHERE id = 3;",
+				Expected: []sql.Row{},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts WHERE tenant = 'tenant2';",
+				Expected: []sql.Row{},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM accounts;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT a.id, b.id FROM accounts a JOIN accounts b ON a.id = b.id ORDER BY a.id;",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				User:     "tenant2",
+				Host:     "localhost",
+				Query:    "SELECT id, balance FROM accounts;",
+				Expected: []sql.Row{{3, 350}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM accounts;",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id, balance FROM accounts AS OF 'HEAD~1' ORDER BY id;",
+				Expected: []sql.Row{{1, 100}, {2, 200}},
+			},
+		},
+	},
+	{
+		Name:        "History can't be used to bypass policies",
+		SetUpScript: rowSecuritySetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id, balance FROM dolt_history_accounts ORDER BY id, balance;",
+				Expected: []sql.Row{{1, 100}, {1, 150}, {2, 200}, {2, 200}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT to_id, from_id, diff_type FROM dolt_diff_accounts ORDER BY to_id, diff_type;",
+				Expected: []sql.Row{{1, nil, "added"}, {1, 1, "modified"}, {2, nil, "added"}},
+			},
+			{
+				User:     "tenant2",
+				Host:     "localhost",
+				Query:    "SELECT to_balance, from_balance FROM dolt_commit_diff_accounts WHERE to_commit = 'HEAD' AND from_commit = 'HEAD~1';",
+				Expected: []sql.Row{{350, 300}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM dolt_blame_accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_history_accounts;",
+				Expected: []sql.Row{{6}},
+			},
+		},
+	},
+	{
+		Name:        "Writes are checked against policies",
+		SetUpScript: rowSecuritySetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "INSERT INTO accounts VALUES (4, 'tenant2', 400);",
+				ExpectedErr: rowsec.ErrPolicyViolation,
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "INSERT INTO accounts VALUES (4, 'tenant1', 400);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "UPDATE accounts SET tenant = 'tenant2' WHERE id = 1;",
+				ExpectedErr: rowsec.ErrPolicyViolation,
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "UPDATE accounts SET balance = 0 WHERE id = 3;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 0, Info: plan.UpdateInfo{Matched: 0, Updated: 0}}}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "UPDATE accounts SET balance = 0;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 3, Info: plan.UpdateInfo{Matched: 3, Updated: 3}}}},
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "REPLACE INTO accounts VALUES (3, 'tenant1', 0);",
+				ExpectedErr: rowsec.ErrReplaceNotSupported,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "INSERT INTO accounts VALUES (3, 'tenant1', 0) ON DUPLICATE KEY UPDATE tenant = 'tenant1';",
+				ExpectedErr: rowsec.ErrPolicyDenied,
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "DELETE FROM accounts;",
+				Expected: []sql.Row{{types.NewOkResult(3)}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT * FROM accounts;",
+				Expected: []sql.Row{{3, "tenant2", 350}},
+			},
+		},
+	},
+	{
+		Name:        "Policies can only be changed by super users",
+		SetUpScript: rowSecuritySetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_row_policies VALUES ('accounts', 'everything', 'select', 'tenant1', 'true');",
+				ExpectedErr: rowsec.ErrPoliciesReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "DELETE FROM dolt_row_policies;",
+				ExpectedErr: rowsec.ErrPoliciesReadOnly,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "INSERT INTO dolt_row_policies VALUES ('accounts', 'read_all', 'select', '%', 'true');",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "DELETE FROM accounts WHERE id = 3;",
+				ExpectedErr: rowsec.ErrPolicyDenied,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_row_policies VALUES ('accounts', 'bad', 'merge', '%', 'true');",
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+		},
+	},
+	{
+		Name: "Materialized views are refreshed without policies, and unavailable to restricted users",
+		SetUpScript: append(rowSecuritySetUpScript,
+			"CALL DOLT_CREATE_MATERIALIZED_VIEW('accounts_count', 'SELECT COUNT(*) AS n FROM accounts');",
+			"CALL DOLT_COMMIT('-Am', 'add accounts_count');",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "INSERT INTO accounts VALUES (4, 'tenant1', 400);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "CALL DOLT_COMMIT('-am', 'add account 4');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "SELECT * FROM accounts_count;",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "SELECT * FROM accounts_count AS OF 'HEAD~1';",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT * FROM accounts_count;",
+				Expected: []sql.Row{{4}},
+			},
+		},
+	},
+	{
+		Name: "Restricted users can't create, refresh, declare or rename materialized views",
+		SetUpScript: append(rowSecuritySetUpScript,
+			"CALL DOLT_CREATE_MATERIALIZED_VIEW('accounts_count', 'SELECT COUNT(*) AS n FROM accounts');",
+			"CALL DOLT_COMMIT('-Am', 'add accounts_count');",
+			"GRANT EXECUTE ON PROCEDURE mydb.dolt_create_materialized_view TO tenant1@localhost;",
+			"GRANT EXECUTE ON PROCEDURE mydb.dolt_refresh_materialized_view TO tenant1@localhost;",
+			"CREATE TABLE notes (id INT PRIMARY KEY, note TEXT);",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_CREATE_MATERIALIZED_VIEW('all_accounts', 'SELECT * FROM accounts');",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_REFRESH_MATERIALIZED_VIEW('accounts_count');",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "DELETE FROM dolt_materialized_views;",
+				ExpectedErr: rowsec.ErrPoliciesReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_materialized_views VALUES ('notes', 'SELECT * FROM accounts');",
+				ExpectedErr: rowsec.ErrPoliciesReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "RENAME TABLE accounts_count TO my_count;",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "CALL DOLT_CREATE_MATERIALIZED_VIEW('notes_count', 'SELECT COUNT(*) AS n FROM notes');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT view_name FROM dolt_materialized_views ORDER BY view_name;",
+				Expected: []sql.Row{{"accounts_count"}, {"notes_count"}},
+			},
+		},
+	},
+	{
+		Name:        "Every revision is subject to the policies committed to the default branch",
+		SetUpScript: rowSecurityHistorySetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM `mydb/open`.accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts AS OF 'open' ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT DISTINCT id FROM dolt_history_accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT DISTINCT id FROM `mydb/open`.dolt_history_accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM `mydb/loose`.accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "CALL DOLT_BRANCH('old', 'open');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM `mydb/old`.accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT DISTINCT id FROM dolt_query_history('SELECT id FROM accounts', 'HEAD~1', 'HEAD') ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM `mydb/open`.accounts;",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "Branches are subject to their own policies",
+		SetUpScript: append(rowSecurityHistorySetUpScript,
+			"CALL DOLT_CHECKOUT('-b', 'strict');",
+			"UPDATE dolt_row_policies SET filter = 'id = 1' WHERE policy_name = 'tenant1_rows';",
+			"CALL DOLT_COMMIT('-am', 'restrict strict');",
+			"CALL DOLT_CHECKOUT('main');",
+		),
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM `mydb/strict`.accounts ORDER BY id;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "UPDATE dolt_row_policies SET filter = 'true' WHERE policy_name = 'tenant1_rows';",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM `mydb/wide`.accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_COMMIT('-am', 'open accounts');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM `mydb/wide`.accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+		},
+	},
+	{
+		Name:        "Policies can't be replaced by version control operations",
+		SetUpScript: rowSecurityHistorySetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_CHECKOUT('HEAD~1', '--', 'dolt_row_policies');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_RESET('--hard', 'HEAD~1');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_RESET('--soft', 'HEAD~1');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_REVERT('HEAD');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_MERGE('loose');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_MERGE('wide');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-f', 'main', 'open');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-D', 'main');",
+				ExpectedErr: dsess.ErrAccessRestrictionsReadOnly,
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT policy_name FROM dolt_row_policies;",
+				Expected: []sql.Row{{"tenant1_rows"}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_MERGE('wide');",
+				Expected: []sql.Row{{doltCommit, 1, 0, "merge successful"}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+		},
+	},
+	{
+		Name:        "Tables that can't be filtered by policies are unavailable to restricted users",
+		SetUpScript: rowSecuritySetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_workspace_accounts;",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_conflicts_accounts;",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_constraint_violations_accounts;",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:        "tenant1",
+				Host:        "localhost",
+				Query:       "SELECT * FROM dolt_patch('HEAD~1', 'HEAD');",
+				ExpectedErr: rowsec.ErrRestrictedTableUnavailable,
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_changes_since('HEAD~1') WHERE table_name = 'accounts';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_changes_since('HEAD~1') WHERE table_name = 'accounts';",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT count(*) FROM dolt_workspace_accounts;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name:        "Policies apply to the roles granted to a user",
+		SetUpScript: rowSecuritySetUpScript,
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CREATE ROLE auditors;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "GRANT SELECT ON *.* TO auditors;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CREATE USER auditor@localhost;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "GRANT auditors TO auditor@localhost;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "INSERT INTO dolt_row_policies VALUES ('accounts', 'auditor_rows', 'select', 'auditors', 'balance > 175');",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				User:     "auditor",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts ORDER BY id;",
+				Expected: []sql.Row{},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_COMMIT('-am', 'add auditor_rows');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				User:     "auditor",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts ORDER BY id;",
+				Expected: []sql.Row{{2}, {3}},
+			},
+			{
+				User:     "tenant1",
+				Host:     "localhost",
+				Query:    "SELECT id FROM accounts ORDER BY id;",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	},
+}
+
+func TestRowSecurity(t *testing.T) {
+	runBranchControlTests(t, RowSecurityTests)
+}
@@ -76,6 +76,30 @@ func ResolveCheckExpression(ctx *sql.Context, tableName string, sch schema.Schem
 	return nil, fmt.Errorf("unable to find check expression")
 }
 
+// ResolveTableExpression returns a sql.Expression for |expr|, an expression over the columns of |sch|, such as a
+// row-level security filter or a column mask. The returned expression is evaluated against rows containing every
+// column of |sch|.
+func ResolveTableExpression(ctx *sql.Context, tableName string, sch schema.Schema, expr string) (sql.Expression, error) {
+	// The expression is resolved as the only check constraint of a copy of the schema
+	checks := schema.NewCheckCollection()
+	if _, err := checks.AddCheck("expr", expr, true); err != nil {
+		return nil, err
+	}
+	exprSch, err := schema.NewSchema(sch.GetAllCols(), sch.GetPkOrdinals(), sch.GetCollation(), nil, checks)
+	if err != nil {
+		return nil, err
+	}
+
+	ct, err := parseCreateTable(ctx, tableName, exprSch)
+	if err != nil {
+		return nil, err
+	}
+	if len(ct.Checks()) != 1 {
+		return nil, fmt.Errorf("unable to find expression")
+	}
+	return ct.Checks()[0].Expr, nil
+}
+
 func stripTableNamesFromExpression(formatter sql.SchemaFormatter, expr sql.Expression, quoted bool) sql.Expression {
 	e, _, _ := transform.Expr(expr, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
 		if col, ok := e.(*expression.GetField); ok {
@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/dolthub/vitess/go/sqltypes"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
@@ -495,19 +496,40 @@ func (ht *HistoryTable) newRowItrForTableAtCommit(ctx *sql.Context, table *DoltT
 		return nil, err
 	}
 
-	_, ok, err := root.GetTable(ctx, table.TableName())
+	// The table, or some of its columns, may have had other names at this commit
+	currentRoot, err := table.workingRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tblName, ok, err := diff.ResolveTableAcrossRenames(ctx, root, currentRoot, table.TableName(), table.sch)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
 		return &historyIter{nonExistentTable: true}, nil
 	}
+	if tblName.Name != table.tableName {
+		renamed := *table
+		renamed.tableName = tblName.Name
+		table = &renamed
+	}
 
 	lockedTable, err := table.LockedToRoot(ctx, root)
 	if err != nil {
 		return nil, err
 	}
 
+	colNames := historicalColumnNames(table.sch, lockedTable.(*DoltTable).sch)
+	if projected := table.Projections(); projected != nil {
+		historicalProjections := make([]string, 0, len(projected))
+		for _, name := range projected {
+			if historicalName, ok := colNames[strings.ToLower(name)]; ok {
+				historicalProjections = append(historicalProjections, historicalName)
+			}
+		}
+		lockedTable = lockedTable.(*DoltTable).WithProjections(nil).(*DoltTable).WithProjections(historicalProjections).(*DoltTable)
+	}
+
 	var partIter sql.PartitionIter
 	var histTable sql.Table
 	if !lookup.IsEmpty() {
@@ -537,7 +559,7 @@ func (ht *HistoryTable) newRowItrForTableAtCommit(ctx *sql.Context, table *DoltT
 		}
 	}
 
-	converter := ht.rowConverter(ctx, lockedTable.Schema(), targetSchema, h, meta, projections)
+	converter := ht.rowConverter(ctx, lockedTable.Schema(), targetSchema, colNames, h, meta, projections)
 	return &historyIter{
 		table:           histTable,
 		tablePartitions: partIter,
@@ -582,14 +604,46 @@ func (i *historyIter) Close(ctx *sql.Context) error {
 	return nil
 }
 
+// historicalColumnNames maps the lower case names of the columns of |current| to the names they had in |historical|.
+// Columns are matched by tag, which is kept when a column is renamed, and then by name, since changing the type of a
+// column can change its tag. A column is never matched by name to a historical column whose tag is used by another one.
+func historicalColumnNames(current, historical schema.Schema) map[string]string {
+	currentCols := current.GetAllCols()
+	historicalCols := historical.GetAllCols()
+
+	names := make(map[string]string, currentCols.Size())
+	for _, col := range currentCols.GetColumns() {
+		if historicalCol, ok := historicalCols.GetByTag(col.Tag); ok {
+			names[strings.ToLower(col.Name)] = historicalCol.Name
+		}
+	}
+	for _, col := range currentCols.GetColumns() {
+		if _, ok := names[strings.ToLower(col.Name)]; ok {
+			continue
+		}
+		historicalCol, ok := historicalCols.GetByNameCaseInsensitive(col.Name)
+		if !ok {
+			continue
+		}
+		if _, tagInUse := currentCols.GetByTag(historicalCol.Tag); !tagInUse {
+			names[strings.ToLower(col.Name)] = historicalCol.Name
+		}
+	}
+	return names
+}
+
 // rowConverter returns a function that converts a row to another schema for the dolt_history system tables. |srcSchema|
 // describes the incoming row, |targetSchema| describes the desired row schema, and |projections| controls which fields
-// are including the returned row. The hash |h| and commit metadata |meta| are used to augment the row with custom
-// fields for the dolt_history table to return commit metadata.
-func (ht *HistoryTable) rowConverter(ctx *sql.Context, srcSchema, targetSchema sql.Schema, h hash.Hash, meta *datas.CommitMeta, projections []uint64) func(row sql.Row) sql.Row {
+// are including the returned row. |srcNames| maps the lower case names of the columns of |targetSchema| to their names
+// in |srcSchema|, which differ for renamed columns. The hash |h| and commit metadata |meta| are used to augment the row
+// with custom fields for the dolt_history table to return commit metadata.
+func (ht *HistoryTable) rowConverter(ctx *sql.Context, srcSchema, targetSchema sql.Schema, srcNames map[string]string, h hash.Hash, meta *datas.CommitMeta, projections []uint64) func(row sql.Row) sql.Row {
 	srcToTarget := make(map[int]int)
 	for i, col := range targetSchema {
-		srcIdx := srcSchema.IndexOfColName(col.Name)
+		srcIdx := -1
+		if srcName, ok := srcNames[strings.ToLower(col.Name)]; ok {
+			srcIdx = srcSchema.IndexOfColName(srcName)
+		}
 		if srcIdx >= 0 {
 			// only add a conversion if the type is the same
 			// TODO: we could do a projection to convert between types in some cases
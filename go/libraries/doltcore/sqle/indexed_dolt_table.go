@@ -77,13 +77,23 @@ func (idt *IndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Partition)
 	}
 
 	if idt.lb == nil || !canCache || idt.lb.Key() != key {
-		idt.lb, err = index.NewIndexReaderBuilder(ctx, idt.DoltTable, idt.idx, key, idt.DoltTable.projectedCols, idt.DoltTable.sqlSch)
+		idt.lb, err = index.NewIndexReaderBuilder(ctx, idt.DoltTable, idt.idx, key, idt.DoltTable.readTags(), idt.DoltTable.sqlSch)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return idt.lb.NewPartitionRowIter(ctx, part)
+	return idt.partitionRowIter(ctx, part)
+}
+
+// partitionRowIter returns the rows of |part|, restricted by the row-level security policies and column masks of the
+// table if any.
+func (idt *IndexedDoltTable) partitionRowIter(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	iter, err := idt.lb.NewPartitionRowIter(ctx, part)
+	if err != nil || !idt.IsRestricted() {
+		return iter, err
+	}
+	return idt.restrictRows(ctx, iter, true)
 }
 
 func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
@@ -94,13 +104,13 @@ func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition
 		return nil, err
 	}
 	if idt.lb == nil || !canCache || idt.lb.Key() != key {
-		idt.lb, err = index.NewIndexReaderBuilder(ctx, idt.DoltTable, idt.idx, key, idt.DoltTable.projectedCols, idt.DoltTable.sqlSch)
+		idt.lb, err = index.NewIndexReaderBuilder(ctx, idt.DoltTable, idt.idx, key, idt.DoltTable.readTags(), idt.DoltTable.sqlSch)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return idt.lb.NewPartitionRowIter(ctx, part)
+	return idt.partitionRowIter(ctx, part)
 }
 
 var _ sql.IndexedTable = (*WritableIndexedDoltTable)(nil)
@@ -161,13 +171,17 @@ func (t *WritableIndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Part
 		return nil, err
 	}
 	if t.lb == nil || !canCache || t.lb.Key() != key {
-		t.lb, err = index.NewIndexReaderBuilder(ctx, t.DoltTable, t.idx, key, t.projectedCols, t.sqlSch)
+		t.lb, err = index.NewIndexReaderBuilder(ctx, t.DoltTable, t.idx, key, t.readTags(), t.sqlSch)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return t.lb.NewPartitionRowIter(ctx, part)
+	iter, err := t.lb.NewPartitionRowIter(ctx, part)
+	if err != nil || !t.IsRestricted() {
+		return iter, err
+	}
+	return t.restrictRows(ctx, iter, true)
 }
 
 // WithProjections implements sql.ProjectedTable
@@ -364,6 +364,10 @@ func getSourceKv(ctx *sql.Context, n sql.Node, isSrc bool) (prolly.Map, prolly.M
 			return prolly.Map{}, nil, nil, nil, nil, nil, fmt.Errorf("virtual tables unsupported in kvexec")
 		}
 
+		if isRestricted(n.UnderlyingTable()) {
+			return prolly.Map{}, nil, nil, nil, nil, nil, nil
+		}
+
 		var lb index.IndexScanBuilder
 		switch dt := n.UnderlyingTable().(type) {
 		case *sqle.WritableIndexedDoltTable:
@@ -426,6 +430,9 @@ func getSourceKv(ctx *sql.Context, n sql.Node, isSrc bool) (prolly.Map, prolly.M
 		}
 
 	case *plan.ResolvedTable:
+		if isRestricted(n.UnderlyingTable()) {
+			return prolly.Map{}, nil, nil, nil, nil, nil, nil
+		}
 		switch dt := n.UnderlyingTable().(type) {
 		case *sqle.WritableDoltTable:
 			tags = dt.ProjectedTags()
@@ -483,6 +490,13 @@ func getSourceKv(ctx *sql.Context, n sql.Node, isSrc bool) (prolly.Map, prolly.M
 	return priMap, srcIter, dstIter, priSch, tags, nil, nil
 }
 
+// isRestricted returns whether |t| is a Dolt table restricted by row-level security policies or column masks. The
+// rows of those tables are filtered and masked by their row iterators, so they cannot be read from storage directly.
+func isRestricted(t sql.Table) bool {
+	rt, ok := t.(interface{ IsRestricted() bool })
+	return ok && rt.IsRestricted()
+}
+
 // coveringNormalizer inputs a secondary index key tuple and outputs a
 // primary index key/value tuple.
 type coveringNormalizer func(val.Tuple) (val.Tuple, val.Tuple, error)
@@ -552,6 +566,10 @@ func getMergeKv(ctx *sql.Context, n sql.Node) (mergeState, error) {
 			return ms, fmt.Errorf("virtual tables unsupported in kvexec")
 		}
 
+		if isRestricted(n.UnderlyingTable()) {
+			return ms, fmt.Errorf("tables with row-level security or column masks unsupported in kvexec")
+		}
+
 		var doltTable *sqle.DoltTable
 		switch dt := n.UnderlyingTable().(type) {
 		case *sqle.WritableIndexedDoltTable:
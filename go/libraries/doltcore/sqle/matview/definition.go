@@ -0,0 +1,375 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matview
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// refreshKind is how the result of a materialized view is brought up to date with its base tables.
+type refreshKind int
+
+const (
+	// fullRefresh views are recomputed entirely whenever their base tables change.
+	fullRefresh refreshKind = iota
+	// keyedRefresh views select the primary key columns of each of their base tables, which are joined with inner
+	// joins. The rows of the view produced by a changed row of a base table are recomputed from its key.
+	keyedRefresh
+	// groupedRefresh views aggregate a single base table with SUM, COUNT, MIN and MAX, grouped by columns that the
+	// view selects. The groups of the changed rows of the base table are recomputed.
+	groupedRefresh
+)
+
+// source is a table in the FROM clause of a view definition.
+type source struct {
+	table string
+	// alias is the name of the table within the definition: its alias, or else its name.
+	alias string
+}
+
+// columnRef is a column of a source, with lowercased names.
+type columnRef struct {
+	alias  string
+	column string
+}
+
+// definition is the analyzed SELECT statement of a materialized view.
+type definition struct {
+	text string
+	// tables is every table read by the definition, including from subqueries.
+	tables []string
+	kind   refreshKind
+	// sources are the tables of the FROM clause of keyedRefresh and groupedRefresh views.
+	sources []source
+	// outputs maps the plain columns selected by the view to the name of the column of the view they are selected as.
+	outputs map[columnRef]string
+	// star is set if the view selects every column of its single source with `*`.
+	star bool
+	// keyed is set if the rows of the view are the rows of its sources, which are joined with inner joins, so that the
+	// view is keyed by the primary keys of its sources.
+	keyed bool
+	// groupBy are the grouping columns of a view that selects each of them, and is keyed by them.
+	groupBy []*sqlparser.ColName
+}
+
+// errNoPrimaryKey is returned for views that can't be keyed.
+var errNoPrimaryKey = errors.New("materialized views must select the primary key columns of each of their tables, or be grouped by the columns they select")
+
+// aggregateFunctions are the aggregate functions supported by groupedRefresh views.
+var aggregateFunctions = map[string]bool{"sum": true, "count": true, "min": true, "max": true}
+
+// BaseTables returns the names of the tables read by |definition|, the SELECT statement defining a materialized view.
+func BaseTables(definition string) ([]string, error) {
+	def, err := parseDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+	return def.tables, nil
+}
+
+// parseDefinition parses and analyzes the SELECT statement |text| defining a materialized view.
+func parseDefinition(text string) (*definition, error) {
+	stmt, err := sqlparser.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := stmt.(sqlparser.SelectStatement); !ok {
+		return nil, fmt.Errorf("the definition of a materialized view must be a SELECT statement")
+	}
+
+	def := &definition{text: text, kind: fullRefresh}
+	seen := make(map[string]bool)
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if ate, ok := node.(*sqlparser.AliasedTableExpr); ok {
+			if tn, ok := ate.Expr.(sqlparser.TableName); ok {
+				if !tn.DbQualifier.IsEmpty() {
+					return false, fmt.Errorf("materialized views can only read tables of their own database, found %s", sqlparser.String(tn))
+				}
+				name := tn.Name.String()
+				if !seen[strings.ToLower(name)] {
+					seen[strings.ToLower(name)] = true
+					def.tables = append(def.tables, name)
+				}
+			}
+		}
+		return true, nil
+	}, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	if sel, ok := stmt.(*sqlparser.Select); ok {
+		def.analyzeSelect(sel)
+	}
+	return def, nil
+}
+
+// analyzeSelect finds the columns the view of |sel| is keyed by, if any, and sets the refreshKind of the definition,
+// which is fullRefresh unless |sel| has one of the shapes that can be refreshed incrementally.
+func (def *definition) analyzeSelect(sel *sqlparser.Select) {
+	if sel.With != nil || sel.Into != nil || len(sel.Window) > 0 || sel.QueryOpts.Distinct || len(sel.From) != 1 ||
+		hasSubquery(sel) {
+		return
+	}
+	sources, ok := collectSources(sel.From[0])
+	if !ok {
+		return
+	}
+	def.sources = sources
+
+	def.outputs = make(map[columnRef]string)
+	hasAggregate := false
+	for _, se := range sel.SelectExprs {
+		switch se := se.(type) {
+		case *sqlparser.StarExpr:
+			if len(sources) != 1 || !(se.TableName.IsEmpty() || strings.EqualFold(se.TableName.Name.String(), sources[0].alias)) {
+				return
+			}
+			def.star = true
+		case *sqlparser.AliasedExpr:
+			if col, ok := se.Expr.(*sqlparser.ColName); ok {
+				ref, ok := def.resolve(col)
+				if !ok {
+					return
+				}
+				name := col.Name.String()
+				if !se.As.IsEmpty() {
+					name = se.As.String()
+				}
+				if _, ok := def.outputs[ref]; !ok {
+					def.outputs[ref] = name
+				}
+			} else if fn, ok := se.Expr.(*sqlparser.FuncExpr); ok && aggregateFunctions[fn.Name.Lowered()] {
+				if fn.Distinct || fn.Over != nil {
+					return
+				}
+				hasAggregate = true
+			} else if hasAggregateFunction(se.Expr) {
+				return
+			}
+		default:
+			return
+		}
+	}
+
+	if len(sel.GroupBy) == 0 {
+		if !hasAggregate && sel.Having == nil {
+			def.keyed = true
+			if sel.Limit == nil {
+				def.kind = keyedRefresh
+			}
+		}
+		return
+	}
+
+	// Every grouping column must be selected, so that the view can be keyed by them.
+	groups := make(map[columnRef]bool)
+	var groupBy []*sqlparser.ColName
+	for _, expr := range sel.GroupBy {
+		col, ok := expr.(*sqlparser.ColName)
+		if !ok {
+			return
+		}
+		ref, ok := def.resolve(col)
+		if !ok {
+			return
+		}
+		if _, ok := def.outputs[ref]; !ok && !def.star {
+			return
+		}
+		groups[ref] = true
+		groupBy = append(groupBy, col)
+	}
+	def.groupBy = groupBy
+
+	// Every selected column of an incrementally refreshed grouped view must be a grouping column, so that the rows of
+	// the view can be matched to groups.
+	if len(sources) != 1 || def.star || sel.Limit != nil || sel.Having != nil {
+		return
+	}
+	for ref := range def.outputs {
+		if !groups[ref] {
+			return
+		}
+	}
+	def.kind = groupedRefresh
+}
+
+// primaryKey returns the names of the columns of the view the table of the view is keyed by: the columns it is
+// grouped by, or else the primary key columns of its sources, whose schemas are |schemas| by lowercased table name.
+func (def *definition) primaryKey(schemas map[string]schema.Schema) ([]string, error) {
+	var key []string
+	if len(def.groupBy) > 0 {
+		for _, col := range def.groupBy {
+			ref, _ := def.resolve(col)
+			src := def.source(ref)
+			sch, ok := schemas[strings.ToLower(src.table)]
+			if !ok {
+				return nil, fmt.Errorf("table not found: %s", src.table)
+			}
+			schCol, ok := sch.GetAllCols().GetByNameCaseInsensitive(col.Name.String())
+			if !ok {
+				return nil, fmt.Errorf("column %s not found in table %s", col.Name.String(), src.table)
+			}
+			if schCol.IsNullable() {
+				return nil, fmt.Errorf("materialized views are keyed by the columns they are grouped by, which must be NOT NULL: %s.%s is nullable", src.table, schCol.Name)
+			}
+			out, _ := def.output(src, schCol.Name)
+			key = append(key, out)
+		}
+		return key, nil
+	}
+
+	if !def.keyed {
+		return nil, errNoPrimaryKey
+	}
+	for _, src := range def.sources {
+		sch, ok := schemas[strings.ToLower(src.table)]
+		if !ok {
+			return nil, fmt.Errorf("table not found: %s", src.table)
+		}
+		if schema.IsKeyless(sch) {
+			return nil, errNoPrimaryKey
+		}
+		for _, col := range sch.GetPKCols().GetColumns() {
+			out, ok := def.output(src, col.Name)
+			if !ok {
+				return nil, errNoPrimaryKey
+			}
+			key = append(key, out)
+		}
+	}
+	return key, nil
+}
+
+// resolve returns the source column |col| refers to. Unqualified columns can only be resolved in views with a single
+// source.
+func (def *definition) resolve(col *sqlparser.ColName) (columnRef, bool) {
+	qualifier := col.Qualifier.Name.String()
+	if qualifier == "" {
+		if len(def.sources) != 1 {
+			return columnRef{}, false
+		}
+		qualifier = def.sources[0].alias
+	}
+	for _, src := range def.sources {
+		if strings.EqualFold(src.alias, qualifier) {
+			return columnRef{alias: strings.ToLower(src.alias), column: col.Name.Lowered()}, true
+		}
+	}
+	return columnRef{}, false
+}
+
+// source returns the source of the column |ref|.
+func (def *definition) source(ref columnRef) source {
+	for _, src := range def.sources {
+		if strings.ToLower(src.alias) == ref.alias {
+			return src
+		}
+	}
+	return source{}
+}
+
+// output returns the name of the column of the view selecting the column |column| of the source |src|.
+func (def *definition) output(src source, column string) (string, bool) {
+	if def.star {
+		return column, true
+	}
+	name, ok := def.outputs[columnRef{alias: strings.ToLower(src.alias), column: strings.ToLower(column)}]
+	return name, ok
+}
+
+// collectSources returns the tables of |expr|, a FROM clause made of tables joined by inner joins.
+func collectSources(expr sqlparser.TableExpr) ([]source, bool) {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		tn, ok := expr.Expr.(sqlparser.TableName)
+		if !ok || expr.AsOf != nil {
+			return nil, false
+		}
+		alias := tn.Name.String()
+		if !expr.As.IsEmpty() {
+			alias = expr.As.String()
+		}
+		return []source{{table: tn.Name.String(), alias: alias}}, true
+	case *sqlparser.JoinTableExpr:
+		if expr.Join != sqlparser.JoinStr || (expr.Condition.On == nil && expr.Condition.Using == nil) {
+			return nil, false
+		}
+		left, ok := collectSources(expr.LeftExpr)
+		if !ok {
+			return nil, false
+		}
+		right, ok := collectSources(expr.RightExpr)
+		if !ok {
+			return nil, false
+		}
+		return append(left, right...), true
+	case *sqlparser.ParenTableExpr:
+		if len(expr.Exprs) != 1 {
+			return nil, false
+		}
+		return collectSources(expr.Exprs[0])
+	default:
+		return nil, false
+	}
+}
+
+func hasSubquery(node sqlparser.SQLNode) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if _, ok := node.(*sqlparser.Subquery); ok {
+			found = true
+			return false, nil
+		}
+		return true, nil
+	}, node)
+	return found
+}
+
+func hasAggregateFunction(node sqlparser.SQLNode) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if fn, ok := node.(*sqlparser.FuncExpr); ok && aggregateFunctions[fn.Name.Lowered()] {
+			found = true
+			return false, nil
+		}
+		return true, nil
+	}, node)
+	return found
+}
+
+// restricted returns the definition with |cond| added to its WHERE clause.
+func (def *definition) restricted(cond sqlparser.Expr) (string, error) {
+	stmt, err := sqlparser.Parse(def.text)
+	if err != nil {
+		return "", err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return "", fmt.Errorf("expected a SELECT statement")
+	}
+	if sel.Where != nil {
+		sel.Where.Expr = &sqlparser.ParenExpr{Expr: sel.Where.Expr}
+	}
+	sel.AddWhere(&sqlparser.ParenExpr{Expr: cond})
+	return sqlparser.String(sel), nil
+}
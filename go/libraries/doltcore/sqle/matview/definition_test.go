@@ -0,0 +1,183 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matview
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestParseDefinition(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		kind   refreshKind
+		tables []string
+	}{
+		{
+			name:   "filter",
+			query:  "SELECT pk, a FROM t WHERE a > 1",
+			kind:   keyedRefresh,
+			tables: []string{"t"},
+		},
+		{
+			name:   "star",
+			query:  "SELECT * FROM t",
+			kind:   keyedRefresh,
+			tables: []string{"t"},
+		},
+		{
+			name:   "inner join",
+			query:  "SELECT t.pk, u.pk AS upk, u.b FROM t JOIN u ON t.pk = u.t_pk",
+			kind:   keyedRefresh,
+			tables: []string{"t", "u"},
+		},
+		{
+			name:   "left join",
+			query:  "SELECT t.pk, u.pk AS upk FROM t LEFT JOIN u ON t.pk = u.t_pk",
+			kind:   fullRefresh,
+			tables: []string{"t", "u"},
+		},
+		{
+			name:   "grouped",
+			query:  "SELECT g, SUM(x) AS total, COUNT(*) AS n FROM t GROUP BY g",
+			kind:   groupedRefresh,
+			tables: []string{"t"},
+		},
+		{
+			name:   "grouped by an unselected column",
+			query:  "SELECT SUM(x) FROM t GROUP BY g",
+			kind:   fullRefresh,
+			tables: []string{"t"},
+		},
+		{
+			name:   "aggregate without group by",
+			query:  "SELECT COUNT(*) FROM t",
+			kind:   fullRefresh,
+			tables: []string{"t"},
+		},
+		{
+			name:   "subquery",
+			query:  "SELECT pk FROM t WHERE a IN (SELECT a FROM u)",
+			kind:   fullRefresh,
+			tables: []string{"t", "u"},
+		},
+		{
+			name:   "distinct",
+			query:  "SELECT DISTINCT pk FROM t",
+			kind:   fullRefresh,
+			tables: []string{"t"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			def, err := parseDefinition(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.kind, def.kind)
+			assert.Equal(t, test.tables, def.tables)
+		})
+	}
+
+	_, err := parseDefinition("DELETE FROM t")
+	assert.Error(t, err)
+	_, err = parseDefinition("SELECT * FROM otherdb.t")
+	assert.Error(t, err)
+}
+
+func TestDefinitionOutput(t *testing.T) {
+	// u is aliased as x, so its columns can't be selected through u
+	assert.Equal(t, fullRefresh, mustParse(t, "SELECT t.pk, u.pk FROM t JOIN u AS x ON t.pk = x.t_pk").kind)
+
+	def := mustParse(t, "SELECT t.pk, x.pk AS upk FROM t JOIN u AS x ON t.pk = x.t_pk")
+	out, ok := def.output(def.sources[1], "PK")
+	require.True(t, ok)
+	assert.Equal(t, "upk", out)
+	_, ok = def.output(def.sources[1], "t_pk")
+	assert.False(t, ok)
+}
+
+func TestDefinitionPrimaryKey(t *testing.T) {
+	schemas := map[string]schema.Schema{
+		"t": schema.MustSchemaFromCols(schema.NewColCollection(
+			schema.NewColumn("pk", 1, types.IntKind, true, schema.NotNullConstraint{}),
+			schema.NewColumn("g", 2, types.StringKind, false, schema.NotNullConstraint{}),
+			schema.NewColumn("a", 3, types.IntKind, false))),
+		"u": schema.MustSchemaFromCols(schema.NewColCollection(
+			schema.NewColumn("pk", 4, types.IntKind, true, schema.NotNullConstraint{}),
+			schema.NewColumn("t_pk", 5, types.IntKind, false))),
+	}
+
+	tests := []struct {
+		query string
+		key   []string
+		err   string
+	}{
+		{query: "SELECT pk, a FROM t WHERE a > 1", key: []string{"pk"}},
+		{query: "SELECT * FROM t", key: []string{"pk"}},
+		{query: "SELECT t.pk, x.pk AS upk FROM t JOIN u AS x ON t.pk = x.t_pk", key: []string{"pk", "upk"}},
+		{query: "SELECT g AS grp, SUM(a) FROM t GROUP BY g", key: []string{"grp"}},
+		{query: "SELECT g, MAX(a) FROM t GROUP BY g HAVING MAX(a) > 1", key: []string{"g"}},
+		{query: "SELECT a, COUNT(*) FROM t GROUP BY a", err: "t.a is nullable"},
+		{query: "SELECT a FROM t", err: errNoPrimaryKey.Error()},
+		{query: "SELECT COUNT(*) FROM t", err: errNoPrimaryKey.Error()},
+		{query: "SELECT t.pk FROM t LEFT JOIN u ON t.pk = u.t_pk", err: errNoPrimaryKey.Error()},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			key, err := mustParse(t, test.query).primaryKey(schemas)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.key, key)
+		})
+	}
+}
+
+func TestDefinitionRestricted(t *testing.T) {
+	def := mustParse(t, "SELECT pk, a FROM t WHERE a > 1 OR a < 0")
+	q, err := def.restricted(matchCondition([]*sqlparser.ColName{sourceColumn(def.sources[0], "pk")}, [][]interface{}{{1}, {2}}))
+	require.NoError(t, err)
+	assert.Equal(t, "select pk, a from t where (a > 1 or a < 0) and ((t.pk <=> :k0_0) or (t.pk <=> :k1_0))", q)
+}
+
+func TestMatchCondition(t *testing.T) {
+	keys := [][]interface{}{{int64(1), "x'y"}, {nil, 2.5}}
+	cond := matchCondition([]*sqlparser.ColName{sqlparser.NewColName("a"), sqlparser.NewColName("b")}, keys)
+	assert.Equal(t, "(a <=> :k0_0 and b <=> :k0_1) or (a <=> :k1_0 and b <=> :k1_1)", sqlparser.String(cond))
+
+	bindings, err := bindKeys(keys)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]sqlparser.Expr{
+		"k0_0": sqlparser.NewIntVal([]byte("1")),
+		"k0_1": sqlparser.NewStrVal([]byte("x'y")),
+		"k1_0": &sqlparser.NullVal{},
+		"k1_1": sqlparser.NewFloatVal([]byte("2.5e+00")),
+	}, bindings)
+}
+
+func mustParse(t *testing.T, query string) *definition {
+	def, err := parseDefinition(query)
+	require.NoError(t, err)
+	return def
+}
@@ -0,0 +1,889 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package matview maintains materialized views: tables holding the result of a SELECT statement, declared in the
+// dolt_materialized_views system table. A view's table is versioned like any other table, and is refreshed when the
+// base tables it reads are committed. When possible, a refresh only recomputes the rows of the view affected by the
+// rows of the base tables that changed since the last refresh, which are found by diffing the base tables.
+package matview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	// maxIncrementalKeys is the largest number of changed keys or groups refreshed incrementally. Views with more
+	// changes are rebuilt, which is cheaper than recomputing their rows a batch at a time.
+	maxIncrementalKeys = 10_000
+	// keysPerStatement is the number of keys or groups recomputed by each statement of an incremental refresh.
+	keysPerStatement = 100
+)
+
+// errIncrementalUnsupported is returned when a change can't be refreshed incrementally, and the view must be rebuilt.
+var errIncrementalUnsupported = errors.New("incremental refresh is not supported")
+
+// Create creates the materialized view |name|, which holds the result of the SELECT statement |definitionText|, in the
+// current database. The table of the view is keyed by the columns the view is grouped by, or else by the primary key
+// columns of the tables it selects, which the view must select.
+func Create(ctx *sql.Context, name, definitionText string) error {
+	def, err := parseDefinition(definitionText)
+	if err != nil {
+		return err
+	}
+	dbName := ctx.GetCurrentDatabase()
+	roots, ok := dsess.DSessFromSess(ctx.Session).GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", dbName)
+	}
+	views, err := doltdb.GetMaterializedViews(ctx, roots.Working)
+	if err != nil {
+		return err
+	}
+	for _, v := range views {
+		if strings.EqualFold(v.Name, name) {
+			return fmt.Errorf("materialized view %s already exists", name)
+		}
+	}
+
+	view := doltdb.MaterializedView{Name: name, Definition: definitionText}
+	r := newRunner(ctx, dbName)
+	defer r.close()
+	if err = r.create(view, def); err != nil {
+		return err
+	}
+	insert := fmt.Sprintf("INSERT INTO %s VALUES (:name, :definition)", quoteIdentifier(doltdb.GetMaterializedViewsTableName()))
+	err = r.execWithBindings(insert, map[string]sqlparser.Expr{
+		"name":       sqlparser.NewStrVal([]byte(name)),
+		"definition": sqlparser.NewStrVal([]byte(definitionText)),
+	})
+	if err != nil {
+		return err
+	}
+	return r.recordRefresh(view, def)
+}
+
+// Drop drops the materialized view |name| of the current database, and its table.
+func Drop(ctx *sql.Context, name string) error {
+	view, err := findView(ctx, ctx.GetCurrentDatabase(), name)
+	if err != nil {
+		return err
+	}
+
+	r := newRunner(ctx, ctx.GetCurrentDatabase())
+	defer r.close()
+	if err = r.exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteIdentifier(view.Name))); err != nil {
+		return err
+	}
+	del := fmt.Sprintf("DELETE FROM %s WHERE %s = :name", quoteIdentifier(doltdb.GetMaterializedViewsTableName()), quoteIdentifier(doltdb.MaterializedViewsNameCol))
+	return r.execWithBindings(del, map[string]sqlparser.Expr{"name": sqlparser.NewStrVal([]byte(view.Name))})
+}
+
+// Refresh rebuilds the materialized views |names| of the current database from the working set, or every
+// materialized view if no names are given. The table of a view is recreated if it was dropped.
+func Refresh(ctx *sql.Context, names ...string) error {
+	dbName := ctx.GetCurrentDatabase()
+	views, err := Views(ctx, dbName, names...)
+	if err != nil {
+		return err
+	}
+
+	r := newRunner(ctx, dbName)
+	defer r.close()
+	for _, view := range views {
+		def, err := parseDefinition(view.Definition)
+		if err != nil {
+			return fmt.Errorf("invalid definition of materialized view %s: %w", view.Name, err)
+		}
+		if err = r.rebuild(view, def); err != nil {
+			return err
+		}
+		if err = r.recordRefresh(view, def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Views returns the materialized views |names| declared in the working set of the database |dbName|, or every
+// materialized view if no names are given.
+func Views(ctx *sql.Context, dbName string, names ...string) ([]doltdb.MaterializedView, error) {
+	if len(names) == 0 {
+		roots, ok := dsess.DSessFromSess(ctx.Session).GetRoots(ctx, dbName)
+		if !ok {
+			return nil, fmt.Errorf("Could not load database %s", dbName)
+		}
+		return doltdb.GetMaterializedViews(ctx, roots.Working)
+	}
+	views := make([]doltdb.MaterializedView, 0, len(names))
+	for _, name := range names {
+		view, err := findView(ctx, dbName, name)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// RefreshForCommit refreshes the materialized views of the database |dbName| before |roots| are committed, and
+// returns |roots| with the refreshed tables of the views staged. A view is only refreshed if none of its base tables
+// have unstaged changes, so that the staged view matches the staged base tables. Every path which creates a commit
+// calls this, so that materialized views are committed along with the base tables they read.
+func RefreshForCommit(ctx *sql.Context, dbName string, roots doltdb.Roots) (doltdb.Roots, error) {
+	views, err := doltdb.GetMaterializedViews(ctx, roots.Working)
+	if err != nil || len(views) == 0 {
+		return roots, err
+	}
+
+	r := newRunner(ctx, dbName)
+	defer r.close()
+
+	var refreshed []string
+	for _, view := range views {
+		def, err := parseDefinition(view.Definition)
+		if err != nil {
+			return roots, fmt.Errorf("invalid definition of materialized view %s: %w", view.Name, err)
+		}
+		staged, err := tablesStaged(ctx, roots, def.tables)
+		if err != nil {
+			return roots, err
+		}
+		if !staged {
+			continue
+		}
+		if err = r.refresh(roots.Head, view, def); err != nil {
+			return roots, fmt.Errorf("could not refresh materialized view %s: %w", view.Name, err)
+		}
+		refreshed = append(refreshed, view.Name)
+	}
+
+	working, err := r.workingRoot()
+	if err != nil {
+		return roots, err
+	}
+	roots.Working = working
+	for _, name := range refreshed {
+		tbl, ok, err := working.GetTable(ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return roots, err
+		}
+		if !ok {
+			continue
+		}
+		roots.Staged, err = roots.Staged.PutTable(ctx, doltdb.TableName{Name: name}, tbl)
+		if err != nil {
+			return roots, err
+		}
+	}
+	return roots, nil
+}
+
+// findView returns the materialized view |name| of the database |dbName|.
+func findView(ctx *sql.Context, dbName, name string) (doltdb.MaterializedView, error) {
+	roots, ok := dsess.DSessFromSess(ctx.Session).GetRoots(ctx, dbName)
+	if !ok {
+		return doltdb.MaterializedView{}, fmt.Errorf("Could not load database %s", dbName)
+	}
+	views, err := doltdb.GetMaterializedViews(ctx, roots.Working)
+	if err != nil {
+		return doltdb.MaterializedView{}, err
+	}
+	for _, v := range views {
+		if strings.EqualFold(v.Name, name) {
+			return v, nil
+		}
+	}
+	return doltdb.MaterializedView{}, fmt.Errorf("materialized view %s does not exist", name)
+}
+
+// tablesStaged returns whether every table of |tables| is the same in the staged and working roots of |roots|.
+func tablesStaged(ctx context.Context, roots doltdb.Roots, tables []string) (bool, error) {
+	for _, name := range tables {
+		stagedHash, err := tableHash(ctx, roots.Staged, name)
+		if err != nil {
+			return false, err
+		}
+		workingHash, err := tableHash(ctx, roots.Working, name)
+		if err != nil {
+			return false, err
+		}
+		if stagedHash != workingHash {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func tableHash(ctx context.Context, root doltdb.RootValue, name string) (string, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: name})
+	if err != nil || !ok {
+		return "", err
+	}
+	h, err := tbl.HashOf()
+	if err != nil {
+		return "", err
+	}
+	return h.String(), nil
+}
+
+// rowDataHashes returns the hash of the row data of each table of |tables| on |root|, by name. Missing tables have an
+// empty hash.
+func rowDataHashes(ctx context.Context, root doltdb.RootValue, tables []string) (map[string]string, error) {
+	hashes := make(map[string]string, len(tables))
+	for _, name := range tables {
+		tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			hashes[name] = ""
+			continue
+		}
+		h, err := tbl.GetRowDataHash(ctx)
+		if err != nil {
+			return nil, err
+		}
+		hashes[name] = h.String()
+	}
+	return hashes, nil
+}
+
+// runner runs the queries maintaining materialized views on a separate engine, within the transaction of the
+// session. The current database of the session is the database of the views until the runner is closed. Views hold
+// the result of their definition for every user, so the queries aren't subject to the row-level security policies and
+// column masks of the session's user, and the users who are can't read the tables of the views instead.
+type runner struct {
+	ctx    *sql.Context
+	engine *gms.Engine
+	dSess  *dsess.DoltSession
+	dbName string
+	prevDb string
+	// prevIgnoreAutoCommit is whether the session ignored autocommit before the runner was created
+	prevIgnoreAutoCommit bool
+}
+
+func newRunner(ctx *sql.Context, dbName string) *runner {
+	dSess := dsess.DSessFromSess(ctx.Session)
+	// The queries get their own context, so that they don't cancel the statement that runs them when they are closed.
+	// The session ignores autocommit until the runner is closed, so that they don't commit the transaction either.
+	qctx := sql.NewContext(dsess.WithoutAccessRestrictions(ctx), sql.WithSession(ctx.Session))
+	r := &runner{
+		ctx:                  qctx,
+		engine:               gms.NewDefault(dSess.Provider()),
+		dSess:                dSess,
+		dbName:               dbName,
+		prevDb:               ctx.GetCurrentDatabase(),
+		prevIgnoreAutoCommit: ctx.GetIgnoreAutoCommit(),
+	}
+	qctx.SetIgnoreAutoCommit(true)
+	ctx.SetCurrentDatabase(dbName)
+	return r
+}
+
+func (r *runner) close() {
+	r.ctx.SetCurrentDatabase(r.prevDb)
+	r.ctx.SetIgnoreAutoCommit(r.prevIgnoreAutoCommit)
+}
+
+func (r *runner) exec(query string) error {
+	return r.execWithBindings(query, nil)
+}
+
+// execWithBindings runs |query|, whose placeholders are bound to |bindings|.
+func (r *runner) execWithBindings(query string, bindings map[string]sqlparser.Expr) error {
+	_, iter, _, err := r.engine.QueryWithBindings(r.ctx, query, nil, bindings, nil)
+	if err != nil {
+		return err
+	}
+	_, err = sql.RowIterToRows(r.ctx, iter)
+	return err
+}
+
+func (r *runner) workingRoot() (doltdb.RootValue, error) {
+	roots, ok := r.dSess.GetRoots(r.ctx, r.dbName)
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", r.dbName)
+	}
+	return roots.Working, nil
+}
+
+// recordRefresh records that the current table of |view| is the result of its definition on the current base tables.
+func (r *runner) recordRefresh(view doltdb.MaterializedView, def *definition) error {
+	working, err := r.workingRoot()
+	if err != nil {
+		return err
+	}
+	ddb, ok := r.dSess.GetDoltDB(r.ctx, r.dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", r.dbName)
+	}
+	result, err := rowDataHashes(r.ctx, working, []string{view.Name})
+	if err != nil {
+		return err
+	}
+	tables, err := rowDataHashes(r.ctx, working, def.tables)
+	if err != nil {
+		return err
+	}
+	return ddb.RecordMaterializedViewRefresh(r.ctx, doltdb.MaterializedViewRefresh{
+		View:       view.Name,
+		Definition: view.Definition,
+		Result:     result[view.Name],
+		Tables:     tables,
+	})
+}
+
+// create creates the table of |view|, keyed by the key of the view, and fills it with the result of its definition.
+func (r *runner) create(view doltdb.MaterializedView, def *definition) error {
+	working, err := r.workingRoot()
+	if err != nil {
+		return err
+	}
+	schemas := make(map[string]schema.Schema, len(def.sources))
+	for _, src := range def.sources {
+		tbl, ok, err := working.GetTable(r.ctx, doltdb.TableName{Name: src.table})
+		if err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("%w: %s", doltdb.ErrTableNotFound, src.table)
+		}
+		if schemas[strings.ToLower(src.table)], err = tbl.GetSchema(r.ctx); err != nil {
+			return err
+		}
+	}
+	key, err := def.primaryKey(schemas)
+	if err != nil {
+		return fmt.Errorf("could not create the table of materialized view %s: %w", view.Name, err)
+	}
+
+	// The table is created empty, so that its key is added without rewriting its rows.
+	name := quoteIdentifier(view.Name)
+	if err = r.exec(fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM (%s) AS %s LIMIT 0", name, def.text, quoteIdentifier("definition"))); err != nil {
+		return err
+	}
+	columns := make([]string, len(key))
+	for i, col := range key {
+		columns[i] = quoteIdentifier(col)
+	}
+	if err = r.exec(fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", name, strings.Join(columns, ", "))); err != nil {
+		return err
+	}
+	return r.exec(fmt.Sprintf("INSERT INTO %s %s", name, def.text))
+}
+
+// rebuild recomputes every row of the table of |view|, which is created if it doesn't exist.
+func (r *runner) rebuild(view doltdb.MaterializedView, def *definition) error {
+	working, err := r.workingRoot()
+	if err != nil {
+		return err
+	}
+	_, exists, err := working.GetTable(r.ctx, doltdb.TableName{Name: view.Name})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return r.create(view, def)
+	}
+	if err = r.exec(fmt.Sprintf("DELETE FROM %s", quoteIdentifier(view.Name))); err != nil {
+		return err
+	}
+	return r.exec(fmt.Sprintf("INSERT INTO %s %s", quoteIdentifier(view.Name), def.text))
+}
+
+// refresh brings the table of |view| up to date with its base tables in the working set. If the table is the recorded
+// result of the base tables of |head|, only the changes since |head| are refreshed. Otherwise, the table is rebuilt.
+// Views whose table or base tables were dropped are skipped.
+func (r *runner) refresh(head doltdb.RootValue, view doltdb.MaterializedView, def *definition) error {
+	working, err := r.workingRoot()
+	if err != nil {
+		return err
+	}
+	ddb, ok := r.dSess.GetDoltDB(r.ctx, r.dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", r.dbName)
+	}
+	result, err := rowDataHashes(r.ctx, working, []string{view.Name})
+	if err != nil {
+		return err
+	}
+	current, err := rowDataHashes(r.ctx, working, def.tables)
+	if err != nil {
+		return err
+	}
+
+	if result[view.Name] == "" {
+		// The table of the view was dropped, and is only recreated by an explicit refresh.
+		return nil
+	}
+	for _, h := range current {
+		if h == "" {
+			// A base table of the view was dropped, so the view can't be computed.
+			return nil
+		}
+	}
+
+	last, ok, err := ddb.GetMaterializedViewRefresh(r.ctx, view.Name, view.Definition, hash.Parse(result[view.Name]))
+	if err != nil {
+		return err
+	}
+	if ok && sameHashes(last.Tables, current) {
+		return nil
+	}
+	if ok && def.kind != fullRefresh {
+		err = r.refreshIncrementally(head, working, view, def, last)
+		if err == nil {
+			return r.recordRefresh(view, def)
+		}
+		if !errors.Is(err, errIncrementalUnsupported) {
+			return err
+		}
+	}
+
+	if err = r.rebuild(view, def); err != nil {
+		return err
+	}
+	return r.recordRefresh(view, def)
+}
+
+func sameHashes(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// changedTable is a base table of a view whose rows changed between |head| and the working set.
+type changedTable struct {
+	name     string
+	sch      schema.Schema
+	from, to prolly.Map
+}
+
+// refreshIncrementally recomputes the rows of the table of |view| affected by the changes of its base tables between
+// |head| and |working|. |last| is the refresh the table is the result of, whose base tables must be those of |head|.
+// Returns errIncrementalUnsupported if the changes can't be refreshed incrementally.
+func (r *runner) refreshIncrementally(head, working doltdb.RootValue, view doltdb.MaterializedView, def *definition, last doltdb.MaterializedViewRefresh) error {
+	headHashes, err := rowDataHashes(r.ctx, head, def.tables)
+	if err != nil {
+		return err
+	}
+	if !sameHashes(headHashes, last.Tables) {
+		return errIncrementalUnsupported
+	}
+
+	changed := make(map[string]*changedTable)
+	for _, name := range def.tables {
+		fromTbl, ok, err := head.GetTable(r.ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return err
+		}
+		toTbl, ok2, err := working.GetTable(r.ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return err
+		}
+		if !ok || !ok2 {
+			return errIncrementalUnsupported
+		}
+		fromHash, err := fromTbl.GetRowDataHash(r.ctx)
+		if err != nil {
+			return err
+		}
+		toHash, err := toTbl.GetRowDataHash(r.ctx)
+		if err != nil {
+			return err
+		}
+		if fromHash == toHash {
+			continue
+		}
+
+		fromSch, err := fromTbl.GetSchema(r.ctx)
+		if err != nil {
+			return err
+		}
+		toSch, err := toTbl.GetSchema(r.ctx)
+		if err != nil {
+			return err
+		}
+		if schema.IsKeyless(toSch) || !schema.ArePrimaryKeySetsDiffable(fromSch, toSch) || !schema.SchemasAreEqual(fromSch, toSch) {
+			return errIncrementalUnsupported
+		}
+		ct := &changedTable{name: name, sch: toSch}
+		if ct.from, err = prollyRowData(r.ctx, fromTbl); err != nil {
+			return err
+		}
+		if ct.to, err = prollyRowData(r.ctx, toTbl); err != nil {
+			return err
+		}
+		changed[strings.ToLower(name)] = ct
+	}
+
+	var statements []statement
+	switch def.kind {
+	case keyedRefresh:
+		statements, err = keyedStatements(r.ctx, view, def, changed)
+	case groupedRefresh:
+		statements, err = groupedStatements(r.ctx, view, def, changed)
+	default:
+		err = errIncrementalUnsupported
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range statements {
+		if err = r.execWithBindings(stmt.query, stmt.bindings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statement is a query maintaining a view, whose placeholders are bound to bindings.
+type statement struct {
+	query    string
+	bindings map[string]sqlparser.Expr
+}
+
+func prollyRowData(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
+
+// keyedStatements returns the statements recomputing the rows of a keyedRefresh view produced by the changed rows of
+// its sources. For each source, the rows of the view with the key of a changed row are deleted, and the rows the
+// definition produces for that key are inserted.
+func keyedStatements(ctx context.Context, view doltdb.MaterializedView, def *definition, changed map[string]*changedTable) ([]statement, error) {
+	var statements []statement
+	total := 0
+	for _, src := range def.sources {
+		ct, ok := changed[strings.ToLower(src.table)]
+		if !ok {
+			continue
+		}
+
+		var outputs, columns []*sqlparser.ColName
+		for _, col := range ct.sch.GetPKCols().GetColumns() {
+			out, ok := def.output(src, col.Name)
+			if !ok {
+				return nil, errIncrementalUnsupported
+			}
+			outputs = append(outputs, sqlparser.NewColName(out))
+			columns = append(columns, sourceColumn(src, col.Name))
+		}
+
+		ns := ct.to.NodeStore()
+		kd, _ := ct.sch.GetMapDescriptors(ns)
+		var keys [][]interface{}
+		err := diffRows(ctx, ct, func(d tree.Diff) error {
+			if total++; total > maxIncrementalKeys {
+				return errIncrementalUnsupported
+			}
+			key, err := readFields(ctx, kd, val.Tuple(d.Key), ns, allFields(kd.Count()))
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		stmts, err := recomputeStatements(view, def, outputs, columns, keys)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmts...)
+	}
+	return statements, nil
+}
+
+// groupedStatements returns the statements recomputing the groups of a groupedRefresh view that the changed rows of
+// its source belong to, before or after the change.
+func groupedStatements(ctx context.Context, view doltdb.MaterializedView, def *definition, changed map[string]*changedTable) ([]statement, error) {
+	src := def.sources[0]
+	ct, ok := changed[strings.ToLower(src.table)]
+	if !ok {
+		return nil, nil
+	}
+
+	ns := ct.to.NodeStore()
+	kd, vd := ct.sch.GetMapDescriptors(ns)
+	var outputs, columns []*sqlparser.ColName
+	var keyFields, valueFields []int
+	for _, col := range def.groupBy {
+		out, ok := def.output(src, col.Name.String())
+		if !ok {
+			return nil, errIncrementalUnsupported
+		}
+		outputs = append(outputs, sqlparser.NewColName(out))
+		columns = append(columns, sourceColumn(src, col.Name.String()))
+
+		schCol, ok := ct.sch.GetAllCols().GetByNameCaseInsensitive(col.Name.String())
+		if !ok {
+			return nil, errIncrementalUnsupported
+		}
+		if idx := pkIndex(ct.sch, schCol.Name); idx >= 0 {
+			keyFields, valueFields = append(keyFields, idx), append(valueFields, -1)
+		} else if idx, ok := ct.sch.GetNonPKCols().StoredIndexByTag(schCol.Tag); ok {
+			keyFields, valueFields = append(keyFields, -1), append(valueFields, idx)
+		} else {
+			return nil, errIncrementalUnsupported
+		}
+	}
+
+	seen := make(map[string]bool)
+	var groups [][]interface{}
+	addGroup := func(key, value val.Tuple) error {
+		group := make([]interface{}, len(keyFields))
+		for i := range keyFields {
+			var err error
+			if keyFields[i] >= 0 {
+				group[i], err = readField(ctx, kd, key, ns, keyFields[i])
+			} else {
+				group[i], err = readField(ctx, vd, value, ns, valueFields[i])
+			}
+			if err != nil {
+				return err
+			}
+		}
+		id := fmt.Sprintf("%#v", group)
+		if seen[id] {
+			return nil
+		}
+		if len(seen) >= maxIncrementalKeys {
+			return errIncrementalUnsupported
+		}
+		seen[id] = true
+		groups = append(groups, group)
+		return nil
+	}
+
+	err := diffRows(ctx, ct, func(d tree.Diff) error {
+		if d.From != nil {
+			if err := addGroup(val.Tuple(d.Key), val.Tuple(d.From)); err != nil {
+				return err
+			}
+		}
+		if d.To != nil {
+			return addGroup(val.Tuple(d.Key), val.Tuple(d.To))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recomputeStatements(view, def, outputs, columns, groups)
+}
+
+// diffRows calls |cb| with each row of |ct| that changed.
+func diffRows(ctx context.Context, ct *changedTable, cb func(tree.Diff) error) error {
+	err := prolly.DiffMaps(ctx, ct.from, ct.to, false, func(ctx context.Context, d tree.Diff) error {
+		return cb(d)
+	})
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+// recomputeStatements returns the statements deleting the rows of the view whose |outputs| match one of |keys|, and
+// inserting the rows produced by the definition where |columns| match them, in batches of keysPerStatement keys.
+func recomputeStatements(view doltdb.MaterializedView, def *definition, outputs, columns []*sqlparser.ColName, keys [][]interface{}) ([]statement, error) {
+	var statements []statement
+	for start := 0; start < len(keys); start += keysPerStatement {
+		batch := keys[start:min(start+keysPerStatement, len(keys))]
+		bindings, err := bindKeys(batch)
+		if err != nil {
+			return nil, err
+		}
+		insert, err := def.restricted(matchCondition(columns, batch))
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements,
+			statement{
+				query:    fmt.Sprintf("DELETE FROM %s WHERE %s", quoteIdentifier(view.Name), sqlparser.String(matchCondition(outputs, batch))),
+				bindings: bindings,
+			},
+			statement{
+				query:    fmt.Sprintf("INSERT INTO %s %s", quoteIdentifier(view.Name), insert),
+				bindings: bindings,
+			})
+	}
+	return statements, nil
+}
+
+// matchCondition returns a condition matching any of |keys| on |columns|, whose values are the placeholders bound by
+// bindKeys. NULL values are matched with <=>.
+func matchCondition(columns []*sqlparser.ColName, keys [][]interface{}) sqlparser.Expr {
+	var cond sqlparser.Expr
+	for i := range keys {
+		var match sqlparser.Expr
+		for j, col := range columns {
+			cmp := &sqlparser.ComparisonExpr{
+				Operator: sqlparser.NullSafeEqualStr,
+				Left:     col,
+				Right:    sqlparser.NewValArg([]byte(":" + bindVar(i, j))),
+			}
+			if match == nil {
+				match = cmp
+			} else {
+				match = &sqlparser.AndExpr{Left: match, Right: cmp}
+			}
+		}
+		match = &sqlparser.ParenExpr{Expr: match}
+		if cond == nil {
+			cond = match
+		} else {
+			cond = &sqlparser.OrExpr{Left: cond, Right: match}
+		}
+	}
+	return cond
+}
+
+// bindKeys returns the bindings of the placeholders of the values of |keys| in a condition returned by
+// matchCondition.
+func bindKeys(keys [][]interface{}) (map[string]sqlparser.Expr, error) {
+	bindings := make(map[string]sqlparser.Expr)
+	for i, key := range keys {
+		for j, v := range key {
+			bv, err := sqltypes.BuildBindVariable(v)
+			if err != nil {
+				return nil, err
+			}
+			value, err := sqltypes.BindVariableToValue(bv)
+			if err != nil {
+				return nil, err
+			}
+			if bindings[bindVar(i, j)], err = sqlparser.ExprFromValue(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return bindings, nil
+}
+
+// bindVar returns the name of the placeholder of the field |field| of the key |key| of a condition.
+func bindVar(key, field int) string {
+	return fmt.Sprintf("k%d_%d", key, field)
+}
+
+// sourceColumn returns the column |column| of the source |src|, qualified by the name of the source.
+func sourceColumn(src source, column string) *sqlparser.ColName {
+	return &sqlparser.ColName{
+		Name:      sqlparser.NewColIdent(column),
+		Qualifier: sqlparser.TableName{Name: sqlparser.NewTableIdent(src.alias)},
+	}
+}
+
+func pkIndex(sch schema.Schema, name string) int {
+	for i, col := range sch.GetPKCols().GetColumns() {
+		if strings.EqualFold(col.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func allFields(n int) []int {
+	fields := make([]int, n)
+	for i := range fields {
+		fields[i] = i
+	}
+	return fields
+}
+
+func readFields(ctx context.Context, td *val.TupleDesc, tup val.Tuple, ns tree.NodeStore, fields []int) ([]interface{}, error) {
+	values := make([]interface{}, len(fields))
+	for i, f := range fields {
+		v, err := readField(ctx, td, tup, ns, f)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// readField reads the field |i| of |tup| as a value that can be bound to a placeholder of a query. Returns
+// errIncrementalUnsupported for the types that can't be.
+func readField(ctx context.Context, td *val.TupleDesc, tup val.Tuple, ns tree.NodeStore, i int) (interface{}, error) {
+	v, err := tree.GetField(ctx, td, i, tup, ns)
+	if err != nil {
+		return nil, err
+	}
+	v, err = sql.UnwrapAny(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case nil, bool, string, []byte, time.Time, int64, uint64, float64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint:
+		return uint64(v), nil
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case float32:
+		return float64(v), nil
+	case decimal.Decimal:
+		return sqltypes.MakeTrusted(sqltypes.Decimal, []byte(v.String())), nil
+	default:
+		return nil, errIncrementalUnsupported
+	}
+}
+
+func quoteIdentifier(s string) string {
+	return sqlparser.String(sqlparser.NewTableIdent(s))
+}
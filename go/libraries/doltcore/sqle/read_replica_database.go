@@ -28,7 +28,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
@@ -77,6 +79,12 @@ func NewReadReplicaDatabase(ctx context.Context, db Database, remoteName string,
 		return EmptyReadReplica, err
 	}
 
+	if lazy, cacheMB := ReadReplicaLazyFetch(); lazy {
+		if err = configureLazyReplica(db, srcDB, dEnv, cacheMB); err != nil {
+			return EmptyReadReplica, err
+		}
+	}
+
 	return ReadReplicaDatabase{
 		Database: db,
 		remote:   remote,
@@ -86,6 +94,29 @@ func NewReadReplicaDatabase(ctx context.Context, db Database, remoteName string,
 	}, nil
 }
 
+// configureLazyReplica makes |db| read the chunks it doesn't have from |srcDB| when they're needed. Every chunk of
+// |srcDB| is then present in |db|, so pulling a branch only sets its head, and a new replica can serve queries as soon
+// as its branches are set.
+func configureLazyReplica(db Database, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, cacheMB uint64) error {
+	cacheDir, err := dEnv.LazyCacheDir()
+	if err != nil {
+		return err
+	}
+	cache, err := remotestorage.NewDiskChunkCache(cacheDir, cacheMB*1024*1024, db.ddb.ChunkEncryption())
+	if err != nil {
+		return err
+	}
+	srcCS := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(srcDB))
+	src := remotestorage.NewLazySource(func(context.Context) (chunks.ChunkStore, error) {
+		return srcCS, nil
+	}, cache)
+	if err = db.ddb.SetLazySource(src); err != nil {
+		return err
+	}
+	remotestorage.RegisterLazySource(db.Name(), src)
+	return nil
+}
+
 func (rrd ReadReplicaDatabase) WithBranchRevision(requestedName string, branchSpec dsess.SessionDatabaseBranchSpec) (dsess.SqlDatabase, error) {
 	rrd.rsr, rrd.rsw = branchSpec.RepoState, branchSpec.RepoState
 	rrd.revision = branchSpec.Branch
@@ -0,0 +1,256 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rowsec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+)
+
+// ErrMaskedTableWrite is returned when a user subject to column masks updates or deletes rows of a table with masked
+// columns. Those statements read masked values, which would otherwise be written back to the table.
+var ErrMaskedTableWrite = errors.NewKind("table `%s` has masked columns; %s is not allowed for users subject to column masks")
+
+// masksApply returns whether column masks apply to the user of |ctx|. Masks don't apply to users with the SUPER
+// privilege, unless they set @@dolt_force_column_masks, as `dolt dump --apply-masks` does, nor to the internal queries
+// run by dsess.WithoutAccessRestrictions.
+func masksApply(ctx *sql.Context) bool {
+	if dsess.AccessRestrictionsLifted(ctx) {
+		return false
+	}
+	if !Bypasses(ctx) {
+		return true
+	}
+	forced, err := dsess.GetBooleanSystemVar(ctx, dsess.ForceColumnMasks)
+	return err == nil && forced
+}
+
+// Masks are the column masks that hide the values of some columns of a table from the current user.
+type Masks struct {
+	tableName string
+	masks     []doltdb.ColumnMask
+
+	mu      *sync.Mutex
+	maskers map[schema.Schema]*Masker
+}
+
+// LoadMasks returns the masks declared in dolt_column_masks on |root| for the columns of |tableName|, or nil if no
+// mask applies to the current user.
+func LoadMasks(ctx *sql.Context, root doltdb.RootValue, tableName doltdb.TableName) (*Masks, error) {
+	if doltdb.HasDoltPrefix(tableName.Name) {
+		// masks only apply to user tables
+		return nil, nil
+	}
+	masks, err := dsess.ColumnMasks(ctx, root, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(masks) == 0 || !masksApply(ctx) {
+		return nil, nil
+	}
+
+	return &Masks{
+		tableName: tableName.Name,
+		masks:     masks,
+		mu:        &sync.Mutex{},
+		maskers:   make(map[schema.Schema]*Masker),
+	}, nil
+}
+
+// overriddenBy returns the masks of |m| and |other|, with the masks of |other| replacing those of |m| on the columns
+// both mask. Either may be nil.
+func (m *Masks) overriddenBy(other *Masks) *Masks {
+	if m == nil {
+		return other
+	} else if other == nil {
+		return m
+	}
+	masks := slices.Clone(other.masks)
+	for _, mask := range m.masks {
+		if !other.Covers(mask.ColumnName) {
+			masks = append(masks, mask)
+		}
+	}
+	return &Masks{
+		tableName: m.tableName,
+		masks:     masks,
+		mu:        &sync.Mutex{},
+		maskers:   make(map[schema.Schema]*Masker),
+	}
+}
+
+// Covers returns whether any mask applies to the column named |colName|.
+func (m *Masks) Covers(colName string) bool {
+	for _, mask := range m.masks {
+		if strings.EqualFold(mask.ColumnName, colName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Masker returns the masker hiding the values of the masked columns of rows of |sch|. Masks of columns that |sch|
+// doesn't have, as may happen for historical versions of a table, are ignored. An expression mask that can't be
+// resolved against |sch| replaces the values of its column with NULL, so that a schema change never reveals them.
+func (m *Masks) Masker(ctx *sql.Context, sch schema.Schema) (*Masker, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if masker, ok := m.maskers[sch]; ok {
+		return masker, nil
+	}
+
+	cols := sch.GetAllCols()
+	masker := &Masker{}
+	for _, mask := range m.masks {
+		col, ok := cols.GetByNameCaseInsensitive(mask.ColumnName)
+		if !ok {
+			continue
+		}
+		mc := maskedColumn{
+			idx:      cols.TagToIdx[col.Tag],
+			typ:      col.TypeInfo.ToSqlType(),
+			strategy: mask.Strategy,
+			arg:      mask.Argument,
+		}
+		switch mask.Strategy {
+		case doltdb.ColumnMaskPartial:
+			n, err := strconv.Atoi(strings.TrimSpace(mask.Argument))
+			if err != nil || n < 0 {
+				// reveal nothing when the number of characters to reveal is invalid
+				n = 0
+			}
+			mc.reveal = n
+		case doltdb.ColumnMaskExpression:
+			expr, err := expranalysis.ResolveTableExpression(ctx, m.tableName, sch, mask.Argument)
+			if err != nil {
+				mc.strategy = doltdb.ColumnMaskNull
+			} else {
+				mc.expr = expr
+			}
+		case doltdb.ColumnMaskNull, doltdb.ColumnMaskHash:
+		default:
+			// unknown strategies hide the whole value
+			mc.strategy = doltdb.ColumnMaskNull
+		}
+		masker.columns = append(masker.columns, mc)
+	}
+
+	m.maskers[sch] = masker
+	return masker, nil
+}
+
+// Masker hides the values of the masked columns of rows of a table schema.
+type Masker struct {
+	columns []maskedColumn
+}
+
+type maskedColumn struct {
+	// idx is the index of the column in the rows of the schema
+	idx      int
+	typ      sql.Type
+	strategy doltdb.ColumnMaskStrategy
+	arg      string
+	// reveal is the number of trailing characters shown by a partial mask
+	reveal int
+	// expr is the resolved expression of an expression mask
+	expr sql.Expression
+}
+
+// Apply returns a copy of |row|, which must have every column of the schema of this masker, with the values of its
+// masked columns hidden.
+func (m *Masker) Apply(ctx *sql.Context, row sql.Row) (sql.Row, error) {
+	if len(m.columns) == 0 {
+		return row, nil
+	}
+	masked := row.Copy()
+	for _, col := range m.columns {
+		v, err := col.mask(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		masked[col.idx] = v
+	}
+	return masked, nil
+}
+
+// mask returns the masked value of this column in |row|.
+func (c maskedColumn) mask(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	v := row[c.idx]
+	switch c.strategy {
+	case doltdb.ColumnMaskHash:
+		s, ok := c.stringValue(v)
+		if !ok {
+			return nil, nil
+		}
+		sum := sha256.Sum256([]byte(c.arg + s))
+		return hex.EncodeToString(sum[:]), nil
+	case doltdb.ColumnMaskPartial:
+		s, ok := c.stringValue(v)
+		if !ok {
+			return nil, nil
+		}
+		runes := []rune(s)
+		hidden := len(runes) - c.reveal
+		if hidden < 0 {
+			hidden = 0
+		}
+		return strings.Repeat("*", hidden) + string(runes[hidden:]), nil
+	case doltdb.ColumnMaskExpression:
+		res, err := c.expr.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return nil, nil
+		}
+		converted, _, err := c.typ.Convert(ctx, res)
+		if err != nil {
+			// a result that doesn't fit the column is hidden entirely
+			return nil, nil
+		}
+		return converted, nil
+	default:
+		return nil, nil
+	}
+}
+
+// stringValue returns |v| as a string if this is a string column and |v| is not NULL.
+func (c maskedColumn) stringValue(v interface{}) (string, bool) {
+	if v == nil || !sqltypes.IsText(c.typ) {
+		return "", false
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
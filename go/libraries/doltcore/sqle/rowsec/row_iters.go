@@ -0,0 +1,212 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rowsec
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// filteredRowIter returns the rows of a child iterator that are accessible, with their masked columns hidden and
+// projected to a subset of their columns.
+type filteredRowIter struct {
+	child  sql.RowIter
+	filter *Filter
+	masker *Masker
+	// projection holds the index of each returned column in the rows of |child|, or nil to return every column
+	projection []int
+}
+
+var _ sql.RowIter = (*filteredRowIter)(nil)
+
+// NewFilteredRowIter returns an iterator over the rows of |child| that |filter| allows, with the columns masked by
+// |masker| hidden. Either of |filter| and |masker| may be nil. The rows of |child| must have every column of the
+// schema |filter| and |masker| were resolved against. If |projection| is not nil, the returned rows only have the
+// columns of the rows of |child| at the indexes it holds.
+func NewFilteredRowIter(child sql.RowIter, filter *Filter, masker *Masker, projection []int) sql.RowIter {
+	return &filteredRowIter{child: child, filter: filter, masker: masker, projection: projection}
+}
+
+// Next implements sql.RowIter
+func (i *filteredRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		row, err := i.child.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if i.filter != nil {
+			ok, err := i.filter.Allows(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		if i.masker != nil {
+			row, err = i.masker.Apply(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if i.projection == nil {
+			return row, nil
+		}
+		projected := make(sql.Row, len(i.projection))
+		for j, idx := range i.projection {
+			projected[j] = row[idx]
+		}
+		return projected, nil
+	}
+}
+
+// Close implements sql.RowIter
+func (i *filteredRowIter) Close(ctx *sql.Context) error {
+	return i.child.Close(ctx)
+}
+
+// DiffSide describes the rows on one side of the rows of a diff table.
+type DiffSide struct {
+	// NumCols is the number of columns of the rows on this side
+	NumCols int
+	// Filter selects the accessible rows on this side, or is nil if every row is accessible
+	Filter *Filter
+	// Masker hides the masked columns of the rows on this side, or is nil if no column is masked
+	Masker *Masker
+}
+
+// DiffSides returns the sides of the rows of a diff between rows of |fromSch| and rows of |toSch|, restricted by
+// |policies| and |masks|, either of which may be nil. If one of the schemas is nil, the other one is used for both
+// sides. The schemas may be historical, so policies that can't be resolved against them deny access to every row.
+func DiffSides(ctx *sql.Context, policies *Policies, masks *Masks, toSch, fromSch schema.Schema) (to DiffSide, from DiffSide, err error) {
+	if toSch == nil {
+		toSch = fromSch
+	} else if fromSch == nil {
+		fromSch = toSch
+	}
+	to, err = diffSide(ctx, policies, masks, toSch)
+	if err != nil {
+		return DiffSide{}, DiffSide{}, err
+	}
+	from, err = diffSide(ctx, policies, masks, fromSch)
+	if err != nil {
+		return DiffSide{}, DiffSide{}, err
+	}
+	return to, from, nil
+}
+
+func diffSide(ctx *sql.Context, policies *Policies, masks *Masks, sch schema.Schema) (side DiffSide, err error) {
+	side.NumCols = sch.GetAllCols().Size()
+	if policies != nil {
+		side.Filter, err = policies.FilterOrDenyAll(ctx, doltdb.RowPolicySelect, sch)
+		if err != nil {
+			return DiffSide{}, err
+		}
+	}
+	if masks != nil {
+		side.Masker, err = masks.Masker(ctx, sch)
+		if err != nil {
+			return DiffSide{}, err
+		}
+	}
+	return side, nil
+}
+
+// diffRowIter returns the rows of a diff table whose from and to rows are both accessible, with their masked columns
+// hidden.
+type diffRowIter struct {
+	child sql.RowIter
+	to    DiffSide
+	from  DiffSide
+}
+
+var _ sql.RowIter = (*diffRowIter)(nil)
+
+// NewDiffRowIter returns an iterator over the rows of |child|, rows of a dolt_diff or dolt_commit_diff system table
+// or of the dolt_diff() table function, that are accessible on both sides. A diff row is only returned if the rows
+// on both of its sides are accessible, so that the history of a table can't reveal rows that its current policies
+// hide, and the masked columns of both sides are hidden.
+func NewDiffRowIter(child sql.RowIter, to, from DiffSide) sql.RowIter {
+	return &diffRowIter{child: child, to: to, from: from}
+}
+
+// Next implements sql.RowIter
+func (i *diffRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		row, err := i.child.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := i.allows(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return i.mask(ctx, row)
+		}
+	}
+}
+
+// allows returns whether both sides of the diff row |row| are accessible. Diff rows are made of the to_ columns,
+// to_commit and to_commit_date, then the from_ columns, from_commit and from_commit_date, and finally diff_type.
+func (i *diffRowIter) allows(ctx *sql.Context, row sql.Row) (bool, error) {
+	diffType := row[len(row)-1]
+	if diffType != "removed" && i.to.Filter != nil {
+		ok, err := i.to.Filter.Allows(ctx, row[:i.to.NumCols])
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	if diffType != "added" && i.from.Filter != nil {
+		fromStart := i.to.NumCols + 2
+		ok, err := i.from.Filter.Allows(ctx, row[fromStart:fromStart+i.from.NumCols])
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// mask returns a copy of the diff row |row| with the masked columns of both of its sides hidden.
+func (i *diffRowIter) mask(ctx *sql.Context, row sql.Row) (sql.Row, error) {
+	if i.to.Masker == nil && i.from.Masker == nil {
+		return row, nil
+	}
+	diffType := row[len(row)-1]
+	masked := row.Copy()
+	if diffType != "removed" && i.to.Masker != nil {
+		toRow, err := i.to.Masker.Apply(ctx, row[:i.to.NumCols])
+		if err != nil {
+			return nil, err
+		}
+		copy(masked, toRow)
+	}
+	if diffType != "added" && i.from.Masker != nil {
+		fromStart := i.to.NumCols + 2
+		fromRow, err := i.from.Masker.Apply(ctx, row[fromStart:fromStart+i.from.NumCols])
+		if err != nil {
+			return nil, err
+		}
+		copy(masked[fromStart:], fromRow)
+	}
+	return masked, nil
+}
+
+// Close implements sql.RowIter
+func (i *diffRowIter) Close(ctx *sql.Context) error {
+	return i.child.Close(ctx)
+}
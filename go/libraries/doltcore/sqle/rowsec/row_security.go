@@ -0,0 +1,304 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rowsec implements row-level security: the policies declared in the dolt_row_policies system table restrict
+// which rows of a table a user can read, insert, update and delete, and the masks declared in the dolt_column_masks
+// system table hide the values of some of their columns.
+package rowsec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/matview"
+)
+
+var (
+	// ErrPolicyViolation is returned when a statement writes a row that the policies of its table don't allow
+	ErrPolicyViolation = errors.NewKind("new row violates row-level security policy for table `%s`")
+	// ErrPolicyDenied is returned when a statement updates or deletes a row that the policies of its table don't allow
+	ErrPolicyDenied = errors.NewKind("row-level security policy for table `%s` does not allow %s of this row")
+	// ErrPoliciesReadOnly is returned when a user subject to row-level security modifies dolt_row_policies
+	ErrPoliciesReadOnly = errors.NewKind("`%s` may only be modified by users with the SUPER privilege")
+	// ErrReplaceNotSupported is returned when a user subject to row-level security runs a REPLACE statement. REPLACE
+	// deletes rows using the values of the new row, so the policies can't be checked against the row it replaces.
+	ErrReplaceNotSupported = errors.NewKind("REPLACE is not supported on table `%s` with row-level security policies; use INSERT ... ON DUPLICATE KEY UPDATE instead")
+	// ErrRestrictedTableUnavailable is returned when a user subject to the row-level security policies or column masks
+	// of a table reads its rows through a system table or table function that doesn't apply them
+	ErrRestrictedTableUnavailable = errors.NewKind("`%s` is not available to users subject to the row-level security policies or column masks of table `%s`")
+)
+
+// Bypasses returns whether the user of |ctx| is exempt from row-level security. Like branch permissions, policies
+// don't apply to users with the SUPER privilege, nor to the internal queries run by dsess.WithoutAccessRestrictions.
+func Bypasses(ctx *sql.Context) bool {
+	return dsess.HasSuperPrivilege(ctx) || dsess.AccessRestrictionsLifted(ctx)
+}
+
+// RoleProvider is implemented by database providers that know the roles granted to users, so that policies can be
+// granted to roles as well as to users.
+type RoleProvider interface {
+	// GrantedRoles returns the names of the roles granted to the user of |ctx|.
+	GrantedRoles(ctx *sql.Context) []string
+}
+
+// Restrictions returns the row-level security policies and column masks of |tableName| in the revision database
+// |dbName| that apply to the current user. Either is nil if it doesn't restrict the user's access to the table. They
+// combine the restrictions declared on each of the roots returned by dsess.AccessRestrictionsRoots: a row is
+// accessible if the policies of every root allow it, and a column is masked if any root masks it, by the mask of the
+// default branch when both do. They are cached for the rest of the transaction.
+func Restrictions(ctx *sql.Context, dbName string, tableName doltdb.TableName) (*Policies, *Masks, error) {
+	if doltdb.HasDoltPrefix(tableName.Name) || !masksApply(ctx) {
+		// policies and masks only apply to user tables, and neither applies to users with the SUPER privilege
+		// unless they force the masks
+		return nil, nil, nil
+	}
+	roots, err := dsess.AccessRestrictionsRoots(ctx, dbName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var policies *Policies
+	var masks *Masks
+	for _, root := range roots {
+		p, err := Load(ctx, root, tableName)
+		if err != nil {
+			return nil, nil, err
+		}
+		policies = policies.and(p)
+		m, err := LoadMasks(ctx, root, tableName)
+		if err != nil {
+			return nil, nil, err
+		}
+		masks = masks.overriddenBy(m)
+	}
+	return policies, masks, nil
+}
+
+// CheckUnrestricted returns ErrRestrictedTableUnavailable if the current user is subject to row-level security
+// policies or column masks on |tableName| in the database |dbName|. |source| names the system table or table function
+// reading the rows of |tableName|, which can't apply them.
+func CheckUnrestricted(ctx *sql.Context, dbName string, tableName doltdb.TableName, source string) error {
+	policies, masks, err := Restrictions(ctx, dbName, tableName)
+	if err != nil {
+		return err
+	}
+	if policies != nil || masks != nil {
+		return ErrRestrictedTableUnavailable.New(source, tableName.Name)
+	}
+	return nil
+}
+
+// CheckMaterializedView returns ErrRestrictedTableUnavailable if |tableName| is a materialized view declared on |root|
+// and the current user is subject to the row-level security policies or column masks of one of the tables it reads.
+// Views are refreshed without restrictions, so their rows may be derived from rows that the user can't read.
+func CheckMaterializedView(ctx *sql.Context, dbName string, root doltdb.RootValue, tableName doltdb.TableName) error {
+	if doltdb.HasDoltPrefix(tableName.Name) || !masksApply(ctx) {
+		return nil
+	}
+	views, err := dsess.MaterializedViews(ctx, root)
+	if err != nil {
+		return err
+	}
+	for _, view := range views {
+		if !strings.EqualFold(view.Name, tableName.Name) {
+			continue
+		}
+		tables, err := matview.BaseTables(view.Definition)
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			if err = CheckUnrestricted(ctx, dbName, doltdb.TableName{Name: table, Schema: tableName.Schema}, view.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Policies are the row-level security policies that restrict the access of the current user to a table. Policies
+// declared on the same root combine like permissive policies in PostgreSQL: a row is accessible if any policy that
+// applies to the user and the statement grants access to it, and a table with policies denies access to every row
+// when none of them apply. A row must be accessible under the policies of every root they were read from.
+type Policies struct {
+	tableName string
+	// grantees are the name of the current user and the names of the roles granted to it
+	grantees []string
+	// sets are the policies read from each root
+	sets [][]doltdb.RowPolicy
+
+	mu      *sync.Mutex
+	filters map[filterKey]*Filter
+}
+
+type filterKey struct {
+	cmd doltdb.RowPolicyCommand
+	sch schema.Schema
+}
+
+// Load returns the policies declared in dolt_row_policies on |root| for |tableName|, or nil if the access of the
+// current user to the table isn't restricted.
+func Load(ctx *sql.Context, root doltdb.RootValue, tableName doltdb.TableName) (*Policies, error) {
+	if doltdb.HasDoltPrefix(tableName.Name) {
+		// policies only apply to user tables
+		return nil, nil
+	}
+	policies, err := dsess.RowPolicies(ctx, root, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 || Bypasses(ctx) {
+		return nil, nil
+	}
+
+	return &Policies{
+		tableName: tableName.Name,
+		grantees:  grantees(ctx),
+		sets:      [][]doltdb.RowPolicy{policies},
+		mu:        &sync.Mutex{},
+		filters:   make(map[filterKey]*Filter),
+	}, nil
+}
+
+// and returns the policies allowing access to the rows that both |p| and |other| allow access to. Either may be nil.
+func (p *Policies) and(other *Policies) *Policies {
+	if p == nil {
+		return other
+	} else if other == nil {
+		return p
+	}
+	sets := slices.Clone(p.sets)
+	for _, set := range other.sets {
+		if !slices.ContainsFunc(sets, func(s []doltdb.RowPolicy) bool { return slices.Equal(s, set) }) {
+			sets = append(sets, set)
+		}
+	}
+	return &Policies{
+		tableName: p.tableName,
+		grantees:  p.grantees,
+		sets:      sets,
+		mu:        &sync.Mutex{},
+		filters:   make(map[filterKey]*Filter),
+	}
+}
+
+// grantees returns the name of the user of |ctx| and the names of the roles granted to it.
+func grantees(ctx *sql.Context) []string {
+	grantees := []string{ctx.Client().User}
+	if sess, ok := ctx.Session.(*dsess.DoltSession); ok {
+		if roles, ok := sess.Provider().(RoleProvider); ok {
+			grantees = append(grantees, roles.GrantedRoles(ctx)...)
+		}
+	}
+	return grantees
+}
+
+// Filter returns the filter selecting the rows of |sch| that statements of type |cmd| may access.
+func (p *Policies) Filter(ctx *sql.Context, cmd doltdb.RowPolicyCommand, sch schema.Schema) (*Filter, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := filterKey{cmd: cmd, sch: sch}
+	if f, ok := p.filters[key]; ok {
+		return f, nil
+	}
+
+	f := &Filter{tableName: p.tableName}
+	var conjuncts []string
+	for _, set := range p.sets {
+		var exprs []string
+		for _, policy := range set {
+			if policy.AppliesTo(cmd, p.grantees) {
+				exprs = append(exprs, "("+policy.Filter+")")
+			}
+		}
+		if len(exprs) == 0 {
+			// no policy of this set grants access to any row
+			p.filters[key] = f
+			return f, nil
+		}
+		conjuncts = append(conjuncts, "("+strings.Join(exprs, " OR ")+")")
+	}
+
+	expr, err := expranalysis.ResolveTableExpression(ctx, p.tableName, sch, strings.Join(conjuncts, " AND "))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve row-level security policies for table `%s`: %w", p.tableName, err)
+	}
+	f.expr = expr
+
+	p.filters[key] = f
+	return f, nil
+}
+
+// FilterOrDenyAll is like Filter, but returns a filter that denies access to every row if the policies can't be
+// resolved against |sch|. This is used for historical versions of a table, whose schema may lack columns the
+// policies refer to.
+func (p *Policies) FilterOrDenyAll(ctx *sql.Context, cmd doltdb.RowPolicyCommand, sch schema.Schema) (*Filter, error) {
+	f, err := p.Filter(ctx, cmd, sch)
+	if err != nil {
+		return &Filter{tableName: p.tableName}, nil
+	}
+	return f, nil
+}
+
+// Filter is the combination of the policies that apply to a type of statement, resolved against a table schema.
+type Filter struct {
+	tableName string
+	// expr is nil when no policy applies, in which case no row is accessible
+	expr sql.Expression
+}
+
+// Allows returns whether |row| is accessible.
+func (f *Filter) Allows(ctx *sql.Context, row sql.Row) (bool, error) {
+	if f.expr == nil {
+		return false, nil
+	}
+	res, err := f.expr.Eval(ctx, row)
+	if err != nil {
+		return false, err
+	}
+	return sql.IsTrue(res), nil
+}
+
+// CheckNewRow returns ErrPolicyViolation if |row| is not accessible.
+func (f *Filter) CheckNewRow(ctx *sql.Context, row sql.Row) error {
+	ok, err := f.Allows(ctx, row)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPolicyViolation.New(f.tableName)
+	}
+	return nil
+}
+
+// CheckExistingRow returns ErrPolicyDenied if |row| is not accessible to statements of type |cmd|.
+func (f *Filter) CheckExistingRow(ctx *sql.Context, cmd doltdb.RowPolicyCommand, row sql.Row) error {
+	ok, err := f.Allows(ctx, row)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPolicyDenied.New(f.tableName, strings.ToUpper(string(cmd)))
+	}
+	return nil
+}
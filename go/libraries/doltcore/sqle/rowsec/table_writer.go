@@ -0,0 +1,79 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rowsec
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// tableWriter is a dsess.TableWriter that rejects the writes the policies of its table don't allow.
+type tableWriter struct {
+	dsess.TableWriter
+	insert *Filter
+	update *Filter
+	delete *Filter
+}
+
+var _ dsess.TableWriter = (*tableWriter)(nil)
+
+// NewTableWriter returns a dsess.TableWriter that checks every row written through |w| against |p|. Inserted rows
+// must be allowed by the insert policies, deleted rows by the delete policies, and updated rows by the update policies
+// both before and after the update. |sch| is the schema of the rows written.
+func NewTableWriter(ctx *sql.Context, w dsess.TableWriter, p *Policies, sch schema.Schema) (dsess.TableWriter, error) {
+	insert, err := p.Filter(ctx, doltdb.RowPolicyInsert, sch)
+	if err != nil {
+		return nil, err
+	}
+	update, err := p.Filter(ctx, doltdb.RowPolicyUpdate, sch)
+	if err != nil {
+		return nil, err
+	}
+	del, err := p.Filter(ctx, doltdb.RowPolicyDelete, sch)
+	if err != nil {
+		return nil, err
+	}
+	return &tableWriter{TableWriter: w, insert: insert, update: update, delete: del}, nil
+}
+
+// Insert implements sql.RowInserter
+func (w *tableWriter) Insert(ctx *sql.Context, row sql.Row) error {
+	if err := w.insert.CheckNewRow(ctx, row); err != nil {
+		return err
+	}
+	return w.TableWriter.Insert(ctx, row)
+}
+
+// Update implements sql.RowUpdater
+func (w *tableWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	if err := w.update.CheckExistingRow(ctx, doltdb.RowPolicyUpdate, old); err != nil {
+		return err
+	}
+	if err := w.update.CheckNewRow(ctx, new); err != nil {
+		return err
+	}
+	return w.TableWriter.Update(ctx, old, new)
+}
+
+// Delete implements sql.RowDeleter
+func (w *tableWriter) Delete(ctx *sql.Context, row sql.Row) error {
+	if err := w.delete.CheckExistingRow(ctx, doltdb.RowPolicyDelete, row); err != nil {
+		return err
+	}
+	return w.TableWriter.Delete(ctx, row)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
@@ -80,6 +81,54 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemBoolType(dsess.ReadReplicaForcePull),
 		Default:           int8(1),
 	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReadReplicaLazyFetch,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemBoolType(dsess.ReadReplicaLazyFetch),
+		Default:           int8(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReadReplicaLazyCacheMB,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.ReadReplicaLazyCacheMB, 1, math.MaxInt32, false),
+		Default:           int64(doltdb.DefaultLazyFetchCacheSizeMB),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaCommitPolicy,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.BinlogReplicaCommitPolicy),
+		Default:           "transaction",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaCommitIntervalSecs,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.BinlogReplicaCommitIntervalSecs, 0, math.MaxInt32, false),
+		Default:           int64(60),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaCommitTransactions,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.BinlogReplicaCommitTransactions, 0, math.MaxInt32, false),
+		Default:           int64(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.BinlogReplicaTagHourly,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemBoolType(dsess.BinlogReplicaTagHourly),
+		Default:           int8(0),
+	},
 	&sql.MysqlSystemVariable{
 		Name:              dsess.SkipReplicationErrors,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
@@ -293,6 +342,13 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemBoolType(dsess.AllowCICreation),
 		Default: int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.ForceColumnMasks,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Session),
+		Type:    types.NewSystemBoolType(dsess.ForceColumnMasks),
+		Default: int8(0),
+	},
 	&sql.MysqlSystemVariable{
 		Name:    actions.DoltCommitVerificationGroups,
 		Dynamic: true,
@@ -352,6 +408,54 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.ReadReplicaForcePull),
 			Default:           int8(1),
 		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReadReplicaLazyFetch,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemBoolType(dsess.ReadReplicaLazyFetch),
+			Default:           int8(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReadReplicaLazyCacheMB,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.ReadReplicaLazyCacheMB, 1, math.MaxInt32, false),
+			Default:           int64(doltdb.DefaultLazyFetchCacheSizeMB),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaCommitPolicy,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.BinlogReplicaCommitPolicy),
+			Default:           "transaction",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaCommitIntervalSecs,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.BinlogReplicaCommitIntervalSecs, 0, math.MaxInt32, false),
+			Default:           int64(60),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaCommitTransactions,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.BinlogReplicaCommitTransactions, 0, math.MaxInt32, false),
+			Default:           int64(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.BinlogReplicaTagHourly,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemBoolType(dsess.BinlogReplicaTagHourly),
+			Default:           int8(0),
+		},
 		&sql.MysqlSystemVariable{
 			Name:              dsess.SkipReplicationErrors,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
@@ -562,6 +666,13 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemBoolType(dsess.AllowCICreation),
 			Default: int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.ForceColumnMasks,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Session),
+			Type:    types.NewSystemBoolType(dsess.ForceColumnMasks),
+			Default: int8(0),
+		},
 	})
 	sql.SystemVariables.AddSystemVariables(DoltSystemVariables)
 }
@@ -573,3 +684,21 @@ func ReadReplicaForcePull() bool {
 	}
 	return forcePull == dsess.SysVarTrue
 }
+
+// ReadReplicaLazyFetch returns whether read replicas read the chunks they don't have from their remote when they're
+// needed, rather than pulling them, and the size of their disk cache in megabytes.
+func ReadReplicaLazyFetch() (bool, uint64) {
+	_, lazy, ok := sql.SystemVariables.GetGlobal(dsess.ReadReplicaLazyFetch)
+	if !ok {
+		panic("dolt system variables not loaded")
+	}
+	_, cacheMB, ok := sql.SystemVariables.GetGlobal(dsess.ReadReplicaLazyCacheMB)
+	if !ok {
+		panic("dolt system variables not loaded")
+	}
+	size, ok := cacheMB.(int64)
+	if !ok || size <= 0 {
+		size = doltdb.DefaultLazyFetchCacheSizeMB
+	}
+	return lazy == dsess.SysVarTrue, uint64(size)
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/fk"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/rowsec"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
@@ -98,6 +99,11 @@ type DoltTable struct {
 	opts            editor.Options
 	projectedCols   []uint64
 	projectedSchema sql.Schema
+
+	// rowSecurity is set when row-level security policies restrict the rows of this table the session can access
+	rowSecurity *rowsec.Policies
+	// columnMasks is set when column masks hide the values of some columns of this table from the session
+	columnMasks *rowsec.Masks
 }
 
 func (t *DoltTable) TableName() doltdb.TableName {
@@ -286,6 +292,8 @@ func (t *DoltTable) LockedToRoot(ctx *sql.Context, root doltdb.RootValue) (sql.I
 		opts:             t.opts,
 		lockedToRoot:     root,
 		overriddenSchema: t.overriddenSchema,
+		rowSecurity:      t.rowSecurity,
+		columnMasks:      t.columnMasks,
 	}
 	return dt.WithProjections(t.Projections()).(*DoltTable), nil
 }
@@ -381,6 +389,30 @@ func (t *DoltTable) getRoot(ctx *sql.Context) (doltdb.RootValue, error) {
 
 // GetIndexes implements sql.IndexedTable
 func (t *DoltTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	indexes, err := t.getIndexes(ctx)
+	if err != nil || t.columnMasks == nil {
+		return indexes, err
+	}
+
+	// Lookups on an index of a masked column would reveal its values, so those indexes are hidden
+	unmasked := make([]sql.Index, 0, len(indexes))
+	for _, idx := range indexes {
+		masked := false
+		for _, expr := range idx.Expressions() {
+			colName := expr[strings.LastIndex(expr, ".")+1:]
+			if t.columnMasks.Covers(colName) {
+				masked = true
+				break
+			}
+		}
+		if !masked {
+			unmasked = append(unmasked, idx)
+		}
+	}
+	return unmasked, nil
+}
+
+func (t *DoltTable) getIndexes(ctx *sql.Context) ([]sql.Index, error) {
 	// If a schema override is in place, we can't trust that the indexes stored with the data
 	// will match up to the overridden schema, so we disable indexes. We could improve this by
 	// adding schema mapping for the indexes.
@@ -588,7 +620,8 @@ func (t *DoltTable) DataLength(ctx *sql.Context) (uint64, error) {
 // RowCount implements the sql.StatisticsTable interface.
 func (t *DoltTable) RowCount(ctx *sql.Context) (uint64, bool, error) {
 	rows, err := t.numRows(ctx)
-	return rows, true, err
+	// With row-level security, the session may only see some of the rows in storage
+	return rows, t.rowSecurity == nil, err
 }
 
 func (t *DoltTable) PrimaryKeySchema() sql.PrimaryKeySchema {
@@ -619,8 +652,10 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 	// to pass in the full column projection for the original/data schema so that we get all columns back. Then,
 	// the mappingRowIterator that we apply on top of the original row iterator will take care of mapping the
 	// original row and shrinking it down to the projected columns.
+	// Row-level security filters and column masks are evaluated against whole rows, so in that case we also read every
+	// column and project the rows after restricting them.
 	projCols := t.projectedCols
-	if t.overriddenSchema != nil {
+	if t.overriddenSchema != nil || t.IsRestricted() {
 		originalSchemaCols := t.sch.GetAllCols().GetColumns()
 		projCols = make([]uint64, len(originalSchemaCols))
 		for i, col := range originalSchemaCols {
@@ -633,6 +668,13 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 		return originalRowIter, err
 	}
 
+	if t.IsRestricted() {
+		originalRowIter, err = t.restrictRows(ctx, originalRowIter, t.overriddenSchema == nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if t.overriddenSchema != nil {
 		return newMappingRowIter(ctx, t, originalRowIter)
 	} else {
@@ -640,6 +682,84 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 	}
 }
 
+// IsRestricted returns whether row-level security policies or column masks restrict the access of the session to
+// the rows of this table.
+func (t *DoltTable) IsRestricted() bool {
+	return t.rowSecurity != nil || t.columnMasks != nil
+}
+
+// readTags returns the tags of the columns to read from storage: the projected columns, or every column if rows must
+// be restricted by row-level security policies or column masks before being projected.
+func (t *DoltTable) readTags() []uint64 {
+	if t.IsRestricted() {
+		return t.sch.GetAllCols().Tags
+	}
+	return t.projectedCols
+}
+
+// restrictRows returns the rows of |iter|, which have every column of the table, that the row-level security policies
+// of the table allow the session to read, with the columns hidden by column masks masked. If |project| is true, the
+// returned rows only have the projected columns.
+func (t *DoltTable) restrictRows(ctx *sql.Context, iter sql.RowIter, project bool) (sql.RowIter, error) {
+	var filter *rowsec.Filter
+	var err error
+	if t.rowSecurity != nil && t.lockedToRoot != nil {
+		// A historical schema may lack columns the policies refer to, in which case none of its rows are readable
+		filter, err = t.rowSecurity.FilterOrDenyAll(ctx, doltdb.RowPolicySelect, t.sch)
+	} else if t.rowSecurity != nil {
+		filter, err = t.rowSecurity.Filter(ctx, doltdb.RowPolicySelect, t.sch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var masker *rowsec.Masker
+	if t.columnMasks != nil {
+		masker, err = t.columnMasks.Masker(ctx, t.sch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var projection []int
+	if project && t.projectedCols != nil {
+		tagToIdx := t.sch.GetAllCols().TagToIdx
+		projection = make([]int, len(t.projectedCols))
+		for i, tag := range t.projectedCols {
+			projection[i] = tagToIdx[tag]
+		}
+	}
+	return rowsec.NewFilteredRowIter(iter, filter, masker, projection), nil
+}
+
+// withRowSecurity returns a copy of |table| whose rows are restricted by |policies| and whose columns are masked by
+// |masks|, or |table| itself if both are nil or the table is not a Dolt table.
+func withRowSecurity(table sql.Table, policies *rowsec.Policies, masks *rowsec.Masks) sql.Table {
+	if policies == nil && masks == nil {
+		return table
+	}
+	switch t := table.(type) {
+	case *AlterableDoltTable:
+		dt := *t.DoltTable
+		dt.rowSecurity, dt.columnMasks = policies, masks
+		nt := *t
+		nt.DoltTable = &dt
+		return &nt
+	case *WritableDoltTable:
+		dt := *t.DoltTable
+		dt.rowSecurity, dt.columnMasks = policies, masks
+		nt := *t
+		nt.DoltTable = &dt
+		return &nt
+	case *DoltTable:
+		dt := *t
+		dt.rowSecurity, dt.columnMasks = policies, masks
+		return &dt
+	default:
+		return table
+	}
+}
+
 func partitionRows(ctx *sql.Context, t *doltdb.Table, projCols []uint64, partition sql.Partition) (sql.RowIter, error) {
 	switch typedPartition := partition.(type) {
 	case doltTablePartition:
@@ -742,10 +862,13 @@ func (t *WritableDoltTable) getTableEditor(ctx *sql.Context) (ed dsess.TableWrit
 		if err != nil {
 			return nil, err
 		}
-		return multiEditor.(dsess.TableWriter), nil
-	} else {
-		return ed, nil
+		ed = multiEditor.(dsess.TableWriter)
 	}
+
+	if t.rowSecurity != nil {
+		return rowsec.NewTableWriter(ctx, ed, t.rowSecurity, t.sch)
+	}
+	return ed, nil
 }
 
 // getFullTextEditor gathers all pseudo-index tables for a Full-Text index and returns an editor that will write
@@ -965,6 +1088,9 @@ func (t *WritableDoltTable) Deleter(ctx *sql.Context) sql.RowDeleter {
 	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
+	if t.columnMasks != nil {
+		return sqlutil.NewStaticErrorEditor(rowsec.ErrMaskedTableWrite.New(t.tableName, "DELETE"))
+	}
 	te, err := t.getTableEditor(ctx)
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
@@ -977,6 +1103,12 @@ func (t *WritableDoltTable) Replacer(ctx *sql.Context) sql.RowReplacer {
 	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
+	if t.rowSecurity != nil {
+		return sqlutil.NewStaticErrorEditor(rowsec.ErrReplaceNotSupported.New(t.tableName))
+	}
+	if t.columnMasks != nil {
+		return sqlutil.NewStaticErrorEditor(rowsec.ErrMaskedTableWrite.New(t.tableName, "REPLACE"))
+	}
 	te, err := t.getTableEditor(ctx)
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
@@ -989,6 +1121,10 @@ func (t *WritableDoltTable) Truncate(ctx *sql.Context) (int, error) {
 	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
 		return 0, err
 	}
+	if t.rowSecurity != nil {
+		// Only the rows the session is allowed to delete may be removed, so delete them one at a time
+		return t.deleteAccessibleRows(ctx)
+	}
 	table, err := t.DoltTable.DoltTable(ctx)
 	if err != nil {
 		return 0, err
@@ -1032,6 +1168,49 @@ func (t *WritableDoltTable) Truncate(ctx *sql.Context) (int, error) {
 	return numOfRows, nil
 }
 
+// deleteAccessibleRows deletes every row of this table the session can read. Each row is checked against the delete
+// policies of the table, and the deletion fails if any of them is not allowed.
+func (t *WritableDoltTable) deleteAccessibleRows(ctx *sql.Context) (int, error) {
+	dt := t.DoltTable.WithProjections(nil).(*DoltTable)
+	partitions, err := dt.Partitions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rowIter := sql.NewTableRowIter(ctx, dt, partitions)
+
+	deleter := t.Deleter(ctx)
+	deleter.StatementBegin(ctx)
+	numOfRows := 0
+	for {
+		var row sql.Row
+		row, err = rowIter.Next(ctx)
+		if err == io.EOF {
+			err = nil
+			break
+		} else if err != nil {
+			break
+		}
+		if err = deleter.Delete(ctx, row); err != nil {
+			break
+		}
+		numOfRows++
+	}
+
+	if cerr := rowIter.Close(ctx); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = deleter.DiscardChanges(ctx, err)
+		_ = deleter.Close(ctx)
+		return 0, err
+	}
+	if err = deleter.StatementComplete(ctx); err != nil {
+		_ = deleter.Close(ctx)
+		return 0, err
+	}
+	return numOfRows, deleter.Close(ctx)
+}
+
 // truncate returns an empty copy of the table given by setting the rows and indexes to empty. The schema can be
 // updated at the same time.
 func (t *WritableDoltTable) truncate(
@@ -1103,6 +1282,9 @@ func (t *WritableDoltTable) Updater(ctx *sql.Context) sql.RowUpdater {
 	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
+	if t.columnMasks != nil {
+		return sqlutil.NewStaticErrorEditor(rowsec.ErrMaskedTableWrite.New(t.tableName, "UPDATE"))
+	}
 	te, err := t.getTableEditor(ctx)
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
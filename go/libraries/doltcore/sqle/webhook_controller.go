@@ -0,0 +1,114 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/webhooks"
+)
+
+// Webhooks let a running SQL server notify HTTP endpoints of new
+// commits. They are configured in the `webhooks` section of the
+// server's YAML config, and work as follows:
+//
+// A WebhookController is created for a running SQL Engine. A
+// WebhookCommitHook is installed on every database with at least one
+// matching webhook. When the head of a matching branch moves, the hook
+// records a delivery per webhook in the database's outbox, which is
+// persisted in the .dolt directory. A single background thread sends
+// pending deliveries and retries failed ones with exponential backoff.
+// The state of every delivery can be inspected in the
+// dolt_webhook_deliveries system table.
+
+type WebhookController struct {
+	sender *webhooks.Sender
+	lgr    *logrus.Logger
+}
+
+func NewWebhookController(hooks []servercfg.WebhookConfig, lgr *logrus.Logger) *WebhookController {
+	return &WebhookController{
+		sender: webhooks.NewSender(hooks, lgr),
+		lgr:    lgr,
+	}
+}
+
+// During engine initialization, this should be called to ensure the
+// background thread responsible for delivering events is running.
+func (c *WebhookController) RunBackgroundThread(threads *sql.BackgroundThreads) error {
+	return threads.Add("webhook_delivery_thread", c.sender.Run)
+}
+
+// During engine initialization, called on the original set of
+// databases to install their webhook commit hooks.
+func (c *WebhookController) ApplyCommitHooks(ctx context.Context, mrEnv *env.MultiRepoEnv, dbs ...dsess.SqlDatabase) error {
+	for _, db := range dbs {
+		denv := mrEnv.GetEnv(db.Name())
+		if denv == nil {
+			continue
+		}
+		if err := c.installCommitHook(ctx, db.Name(), denv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *WebhookController) InitDatabaseHook() InitDatabaseHook {
+	return func(ctx *sql.Context, _ *DoltDatabaseProvider, name string, env *env.DoltEnv, _ dsess.SqlDatabase) error {
+		return c.installCommitHook(ctx, name, env)
+	}
+}
+
+// Deliveries returns the webhook deliveries queued for the database |name|, or nil if no webhooks are configured
+// for it.
+func (c *WebhookController) Deliveries(name string) []webhooks.Delivery {
+	return c.sender.Deliveries(name)
+}
+
+func (c *WebhookController) DropDatabaseHook() DropDatabaseHook {
+	return func(_ *sql.Context, name string) {
+		c.sender.RemoveDatabase(name)
+	}
+}
+
+func (c *WebhookController) installCommitHook(ctx context.Context, name string, denv *env.DoltEnv) error {
+	hooks := c.sender.WebhooksForDatabase(name)
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	doltDir := denv.GetDoltDir()
+	if doltDir == "" {
+		c.lgr.Warnf("sqle/webhooks: database %s has no .dolt directory, webhooks disabled", name)
+		return nil
+	}
+	outbox, err := webhooks.OpenOutbox(denv.FS, filepath.Join(doltDir, webhooks.OutboxFile))
+	if err != nil {
+		return err
+	}
+
+	ddb := denv.DoltDB(ctx)
+	ddb.PrependCommitHooks(ctx, NewWebhookCommitHook(hooks, outbox, c.sender.Notify))
+	c.sender.AddDatabase(name, ddb, outbox)
+	return nil
+}
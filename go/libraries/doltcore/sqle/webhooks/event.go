@@ -0,0 +1,200 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// Event is the JSON document POSTed to a webhook endpoint when the head of a branch is updated.
+type Event struct {
+	Database  string        `json:"database"`
+	Branch    string        `json:"branch"`
+	OldCommit string        `json:"old_commit"`
+	NewCommit string        `json:"new_commit"`
+	Author    string        `json:"author"`
+	Email     string        `json:"email"`
+	Message   string        `json:"message"`
+	Timestamp time.Time     `json:"timestamp"`
+	Tables    []TableChange `json:"tables"`
+}
+
+// TableChange describes the changes made to a single table between the old and the new commit of an Event.
+type TableChange struct {
+	Table        string `json:"table"`
+	FromTable    string `json:"from_table,omitempty"`
+	DiffType     string `json:"diff_type"`
+	SchemaChange bool   `json:"schema_change"`
+	RowsAdded    uint64 `json:"rows_added"`
+	RowsDeleted  uint64 `json:"rows_deleted"`
+	RowsModified uint64 `json:"rows_modified"`
+}
+
+// NewEvent builds the Event for the head of |branch| in |database| moving from |oldHead| to |newHead|. If |oldHead|
+// is empty, the changes are computed against the first parent of |newHead|.
+func NewEvent(ctx context.Context, ddb *doltdb.DoltDB, database, branch string, oldHead, newHead hash.Hash) (Event, error) {
+	optCmt, err := ddb.ReadCommit(ctx, newHead)
+	if err != nil {
+		return Event{}, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return Event{}, doltdb.ErrGhostCommitEncountered
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return Event{}, err
+	}
+	toRoot, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return Event{}, err
+	}
+
+	fromRoot, err := oldRoot(ctx, ddb, cm, oldHead)
+	if err != nil {
+		return Event{}, err
+	}
+
+	tables, err := changedTables(ctx, fromRoot, toRoot)
+	if err != nil {
+		return Event{}, err
+	}
+
+	var old string
+	if !oldHead.IsEmpty() {
+		old = oldHead.String()
+	}
+
+	return Event{
+		Database:  database,
+		Branch:    branch,
+		OldCommit: old,
+		NewCommit: newHead.String(),
+		Author:    meta.Name,
+		Email:     meta.Email,
+		Message:   meta.Description,
+		Timestamp: meta.Time().UTC(),
+		Tables:    tables,
+	}, nil
+}
+
+// oldRoot returns the root value the changes in an Event are computed against.
+func oldRoot(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, oldHead hash.Hash) (doltdb.RootValue, error) {
+	if !oldHead.IsEmpty() {
+		optCmt, err := ddb.ReadCommit(ctx, oldHead)
+		if err != nil {
+			return nil, err
+		}
+		old, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		return old.GetRootValue(ctx)
+	}
+
+	if cm.NumParents() == 0 {
+		return doltdb.EmptyRootValue(ctx, ddb.ValueReadWriter(), ddb.NodeStore())
+	}
+	optCmt, err := ddb.ResolveParent(ctx, cm, 0)
+	if err != nil {
+		return nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return parent.GetRootValue(ctx)
+}
+
+// changedTables returns a TableChange for every table that differs between |fromRoot| and |toRoot|.
+func changedTables(ctx context.Context, fromRoot, toRoot doltdb.RootValue) ([]TableChange, error) {
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]TableChange, 0, len(deltas))
+	for _, td := range deltas {
+		summary, err := td.GetSummary(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !summary.DataChange && !summary.SchemaChange {
+			continue
+		}
+
+		change := TableChange{
+			Table:        summary.TableName.String(),
+			DiffType:     summary.DiffType,
+			SchemaChange: summary.SchemaChange,
+		}
+		if summary.IsRename() {
+			change.FromTable = summary.FromTableName.String()
+		}
+
+		if summary.DataChange {
+			// Row counts can't be computed across a primary key change, but the table is still reported.
+			stat, err := rowStat(ctx, td)
+			if err != nil && !errors.Is(err, diff.ErrPrimaryKeySetChanged) {
+				return nil, fmt.Errorf("computing changes for table %s: %w", change.Table, err)
+			}
+			change.RowsAdded = stat.Adds
+			change.RowsDeleted = stat.Removes
+			change.RowsModified = stat.Changes
+		}
+
+		tables = append(tables, change)
+	}
+	return tables, nil
+}
+
+// rowStat accumulates the diff stat for |td|.
+func rowStat(ctx context.Context, td diff.TableDelta) (diff.DiffStatProgress, error) {
+	ch := make(chan diff.DiffStatProgress)
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		defer close(ch)
+		return diff.StatForTableDelta(egCtx, ch, td)
+	})
+
+	var acc diff.DiffStatProgress
+	eg.Go(func() error {
+		for {
+			select {
+			case p, ok := <-ch:
+				if !ok {
+					return nil
+				}
+				acc.Adds += p.Adds
+				acc.Removes += p.Removes
+				acc.Changes += p.Changes
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+		}
+	})
+
+	err := eg.Wait()
+	return acc, err
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// OutboxFile is the name of the file, relative to a database's .dolt directory, that webhook deliveries are persisted
+// to.
+const OutboxFile = "webhooks.json"
+
+// outboxFileMode is the mode of the outbox file, which holds the payloads of events and is only readable by the
+// server's user.
+const outboxFileMode os.FileMode = 0600
+
+// maxCompletedDeliveries is the number of delivered or failed deliveries kept in the outbox, so that their status
+// can still be inspected after the fact. Pending deliveries are never pruned.
+const maxCompletedDeliveries = 100
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusDelivered Status = "delivered"
+	StatusFailed    Status = "failed"
+)
+
+// Delivery is a single event queued for a single webhook.
+type Delivery struct {
+	ID          uint64    `json:"id"`
+	Webhook     string    `json:"webhook"`
+	Branch      string    `json:"branch"`
+	OldCommit   string    `json:"old_commit,omitempty"`
+	NewCommit   string    `json:"new_commit"`
+	Status      Status    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastAttempt time.Time `json:"last_attempt"`
+	NextAttempt time.Time `json:"next_attempt"`
+	// Payload is the JSON encoded Event. It is built on the first delivery attempt, so that computing the changed
+	// tables does not slow down the commit which triggered the delivery.
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type outboxState struct {
+	NextID uint64 `json:"next_id"`
+	// Heads is the last commit observed for each branch, which becomes the old commit of the next event.
+	Heads      map[string]string `json:"heads"`
+	Deliveries []*Delivery       `json:"deliveries"`
+}
+
+// Outbox is the durable queue of webhook deliveries for a single database. Every change is written through to a file,
+// so that pending deliveries survive a server restart.
+type Outbox struct {
+	mu    sync.Mutex
+	fs    filesys.ReadWriteFS
+	path  string
+	state outboxState
+}
+
+// OpenOutbox loads the outbox persisted at |path|, or creates a new empty one if |path| does not exist.
+func OpenOutbox(fs filesys.ReadWriteFS, path string) (*Outbox, error) {
+	o := &Outbox{
+		fs:   fs,
+		path: path,
+		state: outboxState{
+			NextID: 1,
+			Heads:  make(map[string]string),
+		},
+	}
+
+	if exists, _ := fs.Exists(path); !exists {
+		return o, nil
+	}
+
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &o.state); err != nil {
+		return nil, fmt.Errorf("error reading webhook outbox %s: %w", path, err)
+	}
+	if o.state.Heads == nil {
+		o.state.Heads = make(map[string]string)
+	}
+	return o, nil
+}
+
+// Record notes that the head of |branch| is now |newHead| and queues a delivery for each of |webhooks|. It returns
+// false if |newHead| was already the recorded head of |branch|, in which case nothing is queued.
+func (o *Outbox) Record(branch string, newHead hash.Hash, webhooks []string, now time.Time) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	oldHead := o.state.Heads[branch]
+	if oldHead == newHead.String() {
+		return false, nil
+	}
+	o.state.Heads[branch] = newHead.String()
+
+	for _, webhook := range webhooks {
+		o.state.Deliveries = append(o.state.Deliveries, &Delivery{
+			ID:          o.state.NextID,
+			Webhook:     webhook,
+			Branch:      branch,
+			OldCommit:   oldHead,
+			NewCommit:   newHead.String(),
+			Status:      StatusPending,
+			CreatedAt:   now,
+			NextAttempt: now,
+		})
+		o.state.NextID++
+	}
+
+	return true, o.persist()
+}
+
+// RemoveHead forgets the recorded head of |branch|, after the branch has been deleted.
+func (o *Outbox) RemoveHead(branch string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, ok := o.state.Heads[branch]; !ok {
+		return nil
+	}
+	delete(o.state.Heads, branch)
+	return o.persist()
+}
+
+// Ready returns the pending deliveries whose next attempt is due at |now|, oldest first.
+func (o *Outbox) Ready(now time.Time) []Delivery {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var ready []Delivery
+	for _, d := range o.state.Deliveries {
+		if d.Status == StatusPending && !d.NextAttempt.After(now) {
+			ready = append(ready, *d)
+		}
+	}
+	return ready
+}
+
+// NextAttempt returns the earliest time at which a pending delivery is due, and false if there are no pending
+// deliveries.
+func (o *Outbox) NextAttempt() (time.Time, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var next time.Time
+	var found bool
+	for _, d := range o.state.Deliveries {
+		if d.Status == StatusPending && (!found || d.NextAttempt.Before(next)) {
+			next = d.NextAttempt
+			found = true
+		}
+	}
+	return next, found
+}
+
+// Update replaces the delivery with the same ID as |d| and prunes old completed deliveries.
+func (o *Outbox) Update(d Delivery) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for i := range o.state.Deliveries {
+		if o.state.Deliveries[i].ID == d.ID {
+			o.state.Deliveries[i] = &d
+			break
+		}
+	}
+	o.prune()
+	return o.persist()
+}
+
+// Deliveries returns a snapshot of every delivery in the outbox, ordered by ID.
+func (o *Outbox) Deliveries() []Delivery {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	ret := make([]Delivery, len(o.state.Deliveries))
+	for i, d := range o.state.Deliveries {
+		ret[i] = *d
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+	return ret
+}
+
+// prune drops the oldest completed deliveries beyond maxCompletedDeliveries. Callers must hold |o.mu|.
+func (o *Outbox) prune() {
+	completed := 0
+	for _, d := range o.state.Deliveries {
+		if d.Status != StatusPending {
+			completed++
+		}
+	}
+	if completed <= maxCompletedDeliveries {
+		return
+	}
+
+	toDrop := completed - maxCompletedDeliveries
+	kept := o.state.Deliveries[:0]
+	for _, d := range o.state.Deliveries {
+		if toDrop > 0 && d.Status != StatusPending {
+			toDrop--
+			continue
+		}
+		kept = append(kept, d)
+	}
+	o.state.Deliveries = kept
+}
+
+// persist writes the outbox to disk. The outbox is written to a temporary file which is then renamed over the
+// previous outbox, so that a crash while writing never leaves a partially written outbox behind. Callers must hold
+// |o.mu|.
+func (o *Outbox) persist() error {
+	data, err := json.Marshal(o.state)
+	if err != nil {
+		return err
+	}
+	tmpPath := o.path + ".tmp"
+	if err = o.fs.WriteFile(tmpPath, data, outboxFileMode); err != nil {
+		return err
+	}
+	return o.fs.MoveFile(tmpPath, o.path)
+}
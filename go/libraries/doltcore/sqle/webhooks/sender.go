@@ -0,0 +1,300 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	// DeliveryHeader carries an identifier which is unique per database and delivery. Endpoints can use it to
+	// discard duplicates, since an event is redelivered if the server stops before its delivery is recorded.
+	DeliveryHeader = "X-Dolt-Delivery"
+	// WebhookHeader carries the name of the webhook the event was delivered for.
+	WebhookHeader = "X-Dolt-Webhook"
+)
+
+// Sender delivers the deliveries queued in the outboxes of every registered database to their webhook endpoints,
+// retrying failed deliveries with exponential backoff.
+type Sender struct {
+	webhooks map[string]servercfg.WebhookConfig
+	all      []servercfg.WebhookConfig
+	client   *http.Client
+	lgr      *logrus.Logger
+	wakeCh   chan struct{}
+
+	mu  sync.Mutex
+	dbs map[string]*database
+}
+
+type database struct {
+	name   string
+	ddb    *doltdb.DoltDB
+	outbox *Outbox
+}
+
+func NewSender(webhooks []servercfg.WebhookConfig, lgr *logrus.Logger) *Sender {
+	byName := make(map[string]servercfg.WebhookConfig, len(webhooks))
+	for _, w := range webhooks {
+		byName[w.Name()] = w
+	}
+	return &Sender{
+		webhooks: byName,
+		all:      webhooks,
+		client:   &http.Client{},
+		lgr:      lgr,
+		wakeCh:   make(chan struct{}, 1),
+		dbs:      make(map[string]*database),
+	}
+}
+
+// WebhooksForDatabase returns the webhooks configured for the database |name|.
+func (s *Sender) WebhooksForDatabase(name string) []servercfg.WebhookConfig {
+	var ret []servercfg.WebhookConfig
+	for _, w := range s.all {
+		if len(w.Databases()) == 0 {
+			ret = append(ret, w)
+			continue
+		}
+		for _, db := range w.Databases() {
+			if strings.EqualFold(db, name) {
+				ret = append(ret, w)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// AddDatabase registers the outbox of database |name| with the sender, and makes its deliveries visible to
+// Deliveries.
+func (s *Sender) AddDatabase(name string, ddb *doltdb.DoltDB, outbox *Outbox) {
+	s.mu.Lock()
+	s.dbs[strings.ToLower(name)] = &database{name: name, ddb: ddb, outbox: outbox}
+	s.mu.Unlock()
+	s.Notify()
+}
+
+// RemoveDatabase stops delivering the events of database |name|. Its outbox is left on disk.
+func (s *Sender) RemoveDatabase(name string) {
+	s.mu.Lock()
+	delete(s.dbs, strings.ToLower(name))
+	s.mu.Unlock()
+}
+
+// Deliveries returns the deliveries in the outbox of database |name|, or nil if no webhooks are configured for it.
+func (s *Sender) Deliveries(name string) []Delivery {
+	s.mu.Lock()
+	db := s.dbs[strings.ToLower(name)]
+	s.mu.Unlock()
+	if db == nil {
+		return nil
+	}
+	return db.outbox.Deliveries()
+}
+
+// Notify wakes up the delivery thread, so that newly queued deliveries are sent immediately.
+func (s *Sender) Notify() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers events until |ctx| is canceled. It is intended to be run as a sql.BackgroundThreads thread.
+func (s *Sender) Run(ctx context.Context) {
+	for {
+		s.deliverReady(ctx)
+
+		// With nothing pending, wait until a commit hook queues a delivery.
+		var timer *time.Timer
+		var timerCh <-chan time.Time
+		if next, ok := s.nextAttempt(); ok {
+			timer = time.NewTimer(time.Until(next))
+			timerCh = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.wakeCh:
+		case <-timerCh:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (s *Sender) databases() []*database {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := make([]*database, 0, len(s.dbs))
+	for _, db := range s.dbs {
+		ret = append(ret, db)
+	}
+	return ret
+}
+
+func (s *Sender) nextAttempt() (time.Time, bool) {
+	var next time.Time
+	var found bool
+	for _, db := range s.databases() {
+		if t, ok := db.outbox.NextAttempt(); ok && (!found || t.Before(next)) {
+			next = t
+			found = true
+		}
+	}
+	return next, found
+}
+
+func (s *Sender) deliverReady(ctx context.Context) {
+	for _, db := range s.databases() {
+		for _, d := range db.outbox.Ready(time.Now()) {
+			if ctx.Err() != nil {
+				return
+			}
+			d = s.attempt(ctx, db, d)
+			if ctx.Err() != nil {
+				// An attempt interrupted by shutdown is not counted. The delivery stays pending and is retried on
+				// the next start.
+				return
+			}
+			if err := db.outbox.Update(d); err != nil {
+				s.lgr.Errorf("webhooks: error recording delivery %d of database %s: %v", d.ID, db.name, err)
+			}
+		}
+	}
+}
+
+// attempt makes one delivery attempt for |d| and returns it updated with the outcome.
+func (s *Sender) attempt(ctx context.Context, db *database, d Delivery) Delivery {
+	webhook, ok := s.webhooks[d.Webhook]
+	if !ok {
+		d.Status = StatusFailed
+		d.LastError = fmt.Sprintf("webhook %s is no longer configured", d.Webhook)
+		return d
+	}
+
+	if d.Payload == nil {
+		payload, err := buildPayload(ctx, db, d)
+		if err != nil {
+			// The commits of an event don't change, so this will not succeed on a retry.
+			s.lgr.Warnf("webhooks: error building event for delivery %d of database %s: %v", d.ID, db.name, err)
+			d.Status = StatusFailed
+			d.LastError = err.Error()
+			return d
+		}
+		d.Payload = payload
+	}
+
+	err := s.post(ctx, webhook, db, d)
+	d.Attempts++
+	d.LastAttempt = time.Now()
+	if err == nil {
+		d.Status = StatusDelivered
+		d.LastError = ""
+		return d
+	}
+
+	d.LastError = err.Error()
+	if d.Attempts >= webhook.MaxAttempts() {
+		s.lgr.Warnf("webhooks: giving up on delivery %d of database %s to %s after %d attempts: %v", d.ID, db.name, webhook.Name(), d.Attempts, err)
+		d.Status = StatusFailed
+	} else {
+		s.lgr.Debugf("webhooks: delivery %d of database %s to %s failed, retrying: %v", d.ID, db.name, webhook.Name(), err)
+		d.NextAttempt = d.LastAttempt.Add(Backoff(webhook, d.Attempts))
+	}
+	return d
+}
+
+func buildPayload(ctx context.Context, db *database, d Delivery) ([]byte, error) {
+	var oldHead hash.Hash
+	if d.OldCommit != "" {
+		var ok bool
+		oldHead, ok = hash.MaybeParse(d.OldCommit)
+		if !ok {
+			return nil, fmt.Errorf("invalid commit hash %s", d.OldCommit)
+		}
+	}
+	newHead, ok := hash.MaybeParse(d.NewCommit)
+	if !ok {
+		return nil, fmt.Errorf("invalid commit hash %s", d.NewCommit)
+	}
+
+	event, err := NewEvent(ctx, db.ddb, db.name, d.Branch, oldHead, newHead)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(event)
+}
+
+func (s *Sender) post(ctx context.Context, webhook servercfg.WebhookConfig, db *database, d Delivery) error {
+	ctx, cancel := context.WithTimeout(ctx, webhook.Timeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL(), bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookHeader, webhook.Name())
+	req.Header.Set(DeliveryHeader, db.name+"-"+strconv.FormatUint(d.ID, 10))
+	for k, v := range webhook.Headers() {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain some of the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+// Backoff returns the delay before the next attempt of a delivery to |webhook| which has failed |attempts| times.
+func Backoff(webhook servercfg.WebhookConfig, attempts int) time.Duration {
+	delay := webhook.InitialBackoff()
+	for i := 1; i < attempts && delay < webhook.MaxBackoff(); i++ {
+		delay *= 2
+	}
+	if delay > webhook.MaxBackoff() {
+		delay = webhook.MaxBackoff()
+	}
+	return delay
+}
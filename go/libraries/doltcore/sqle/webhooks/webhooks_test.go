@@ -0,0 +1,220 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestOutboxPersistence(t *testing.T) {
+	fs := filesys.EmptyInMemFS("/")
+	now := time.Now().UTC()
+	h1 := hash.Of([]byte("one"))
+	h2 := hash.Of([]byte("two"))
+
+	outbox, err := OpenOutbox(fs, "/webhooks.json")
+	require.NoError(t, err)
+
+	queued, err := outbox.Record("main", h1, []string{"a", "b"}, now)
+	require.NoError(t, err)
+	assert.True(t, queued)
+	queued, err = outbox.Record("main", h1, []string{"a", "b"}, now)
+	require.NoError(t, err)
+	assert.False(t, queued, "an unchanged head should not queue deliveries")
+	queued, err = outbox.Record("main", h2, []string{"a"}, now)
+	require.NoError(t, err)
+	assert.True(t, queued)
+
+	reopened, err := OpenOutbox(fs, "/webhooks.json")
+	require.NoError(t, err)
+	deliveries := reopened.Deliveries()
+	require.Len(t, deliveries, 3)
+	assert.Equal(t, uint64(1), deliveries[0].ID)
+	assert.Equal(t, "a", deliveries[0].Webhook)
+	assert.Equal(t, "", deliveries[0].OldCommit)
+	assert.Equal(t, h1.String(), deliveries[0].NewCommit)
+	assert.Equal(t, "b", deliveries[1].Webhook)
+	assert.Equal(t, h1.String(), deliveries[2].OldCommit)
+	assert.Equal(t, h2.String(), deliveries[2].NewCommit)
+	for _, d := range deliveries {
+		assert.Equal(t, StatusPending, d.Status)
+	}
+	assert.Len(t, reopened.Ready(now), 3)
+	assert.Empty(t, reopened.Ready(now.Add(-time.Second)))
+}
+
+func TestOutboxFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OutboxFile)
+	// A temporary file left behind by a crash is ignored and replaced
+	require.NoError(t, os.WriteFile(path+".tmp", []byte("{\"next_id\":"), 0600))
+
+	outbox, err := OpenOutbox(filesys.LocalFS, path)
+	require.NoError(t, err)
+	_, err = outbox.Record("main", hash.Of([]byte("one")), []string{"a"}, time.Now())
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	reopened, err := OpenOutbox(filesys.LocalFS, path)
+	require.NoError(t, err)
+	assert.Len(t, reopened.Deliveries(), 1)
+}
+
+func TestOutboxPrunesCompletedDeliveries(t *testing.T) {
+	outbox, err := OpenOutbox(filesys.EmptyInMemFS("/"), "/webhooks.json")
+	require.NoError(t, err)
+	now := time.Now()
+	for i := 0; i < maxCompletedDeliveries+10; i++ {
+		_, err = outbox.Record("main", hash.Of([]byte{byte(i), byte(i >> 8)}), []string{"a"}, now)
+		require.NoError(t, err)
+	}
+	for _, d := range outbox.Deliveries()[1:] {
+		d.Status = StatusDelivered
+		require.NoError(t, outbox.Update(d))
+	}
+
+	deliveries := outbox.Deliveries()
+	require.Len(t, deliveries, maxCompletedDeliveries+1)
+	assert.Equal(t, StatusPending, deliveries[0].Status, "pending deliveries are never pruned")
+	assert.Equal(t, uint64(11), deliveries[1].ID)
+}
+
+func TestBackoff(t *testing.T) {
+	webhook := servercfg.WebhookYAMLConfig{
+		InitialBackoffMillis: ptr(uint64(100)),
+		MaxBackoffMillis:     ptr(uint64(1000)),
+	}
+	assert.Equal(t, 100*time.Millisecond, Backoff(webhook, 1))
+	assert.Equal(t, 200*time.Millisecond, Backoff(webhook, 2))
+	assert.Equal(t, 400*time.Millisecond, Backoff(webhook, 3))
+	assert.Equal(t, 800*time.Millisecond, Backoff(webhook, 4))
+	assert.Equal(t, 1000*time.Millisecond, Backoff(webhook, 5))
+	assert.Equal(t, 1000*time.Millisecond, Backoff(webhook, 50))
+}
+
+func TestSenderRetriesUntilDelivered(t *testing.T) {
+	var requests atomic.Int32
+	var body atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "hook", r.Header.Get(WebhookHeader))
+		assert.Equal(t, "mydb-1", r.Header.Get(DeliveryHeader))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		data, _ := io.ReadAll(r.Body)
+		body.Store(string(data))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	webhook := servercfg.WebhookYAMLConfig{
+		Name_:                "hook",
+		URL_:                 srv.URL,
+		Headers_:             map[string]string{"Authorization": "secret"},
+		MaxAttempts_:         ptr(3),
+		InitialBackoffMillis: ptr(uint64(0)),
+	}
+	sender := NewSender([]servercfg.WebhookConfig{webhook}, logrus.New())
+
+	outbox, err := OpenOutbox(filesys.EmptyInMemFS("/"), "/webhooks.json")
+	require.NoError(t, err)
+	_, err = outbox.Record("main", hash.Of([]byte("commit")), []string{"hook"}, time.Now())
+	require.NoError(t, err)
+	// Pre-build the payload so that the delivery doesn't need a database to compute the event.
+	d := outbox.Deliveries()[0]
+	d.Payload = []byte(`{"database":"mydb"}`)
+	require.NoError(t, outbox.Update(d))
+
+	sender.AddDatabase("mydb", nil, outbox)
+	defer sender.RemoveDatabase("mydb")
+
+	ctx := context.Background()
+	sender.deliverReady(ctx)
+	d = outbox.Deliveries()[0]
+	assert.Equal(t, StatusPending, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Contains(t, d.LastError, "503")
+
+	sender.deliverReady(ctx)
+	d = outbox.Deliveries()[0]
+	assert.Equal(t, StatusDelivered, d.Status)
+	assert.Equal(t, 2, d.Attempts)
+	assert.Empty(t, d.LastError)
+	assert.Equal(t, `{"database":"mydb"}`, body.Load())
+
+	assert.Len(t, sender.Deliveries("MYDB"), 1)
+	assert.Nil(t, sender.Deliveries("otherdb"))
+}
+
+func TestSenderGivesUpAfterMaxAttempts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	webhook := servercfg.WebhookYAMLConfig{
+		Name_:                "hook",
+		URL_:                 srv.URL,
+		MaxAttempts_:         ptr(2),
+		InitialBackoffMillis: ptr(uint64(0)),
+	}
+	sender := NewSender([]servercfg.WebhookConfig{webhook}, logrus.New())
+	outbox, err := OpenOutbox(filesys.EmptyInMemFS("/"), "/webhooks.json")
+	require.NoError(t, err)
+	_, err = outbox.Record("main", hash.Of([]byte("commit")), []string{"hook"}, time.Now())
+	require.NoError(t, err)
+	d := outbox.Deliveries()[0]
+	d.Payload = []byte(`{}`)
+	require.NoError(t, outbox.Update(d))
+	sender.AddDatabase("mydb", nil, outbox)
+	defer sender.RemoveDatabase("mydb")
+
+	sender.deliverReady(context.Background())
+	sender.deliverReady(context.Background())
+	sender.deliverReady(context.Background())
+
+	d = outbox.Deliveries()[0]
+	assert.Equal(t, StatusFailed, d.Status)
+	assert.Equal(t, 2, d.Attempts)
+	_, pending := outbox.NextAttempt()
+	assert.False(t, pending)
+}
+
+func ptr[T any](t T) *T {
+	return &t
+}
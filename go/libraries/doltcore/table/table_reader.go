@@ -60,3 +60,13 @@ type SqlTableReader interface {
 	// ReadSqlRow reads a row from a table as go-mysql-server sql.Row.
 	ReadSqlRow(ctx context.Context) (sql.Row, error)
 }
+
+// TypedReader is a ReadCloser for a file format that declares the types of its columns, such as Avro or Arrow. When
+// inferring the schema of an import, the column types of a TypedReader's schema are used as is, rather than being
+// inferred from the values of its rows.
+type TypedReader interface {
+	ReadCloser
+
+	// HasDeclaredTypes returns whether the column types of the reader's schema were read from its source.
+	HasDeclaredTypes() bool
+}
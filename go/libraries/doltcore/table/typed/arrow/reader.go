@@ -0,0 +1,295 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// ArrowReader implements TableReader. It reads the record batches of an Arrow IPC file, also known as a Feather file,
+// and returns their rows. The schema of the rows is read from the schema of the file.
+type ArrowReader struct {
+	closer io.Closer
+	fr     *ipc.FileReader
+	sch    schema.Schema
+
+	batch    int
+	rec      arrow.Record
+	rowInRec int
+}
+
+var _ table.SqlTableReader = (*ArrowReader)(nil)
+var _ table.TypedReader = (*ArrowReader)(nil)
+
+// OpenArrowReader opens a reader at a given path within the filesystem given.
+func OpenArrowReader(path string, fs filesys.ReadableFS) (*ArrowReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rd, err := NewArrowReader(r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return rd, nil
+}
+
+// NewArrowReader creates an ArrowReader reading an Arrow IPC file from |r|. The footer of an Arrow IPC file is at its
+// end, so |r| is read into memory if it isn't seekable.
+func NewArrowReader(r io.ReadCloser) (*ArrowReader, error) {
+	ras, ok := r.(ipc.ReadAtSeeker)
+	if !ok {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		ras = bytes.NewReader(data)
+	}
+
+	fr, err := ipc.NewFileReader(ras, ipc.WithAllocator(memory.NewGoAllocator()))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := fr.Schema().Fields()
+	cols := make([]schema.Column, len(fields))
+	for i, f := range fields {
+		sqlType, err := sqlTypeForField(f)
+		if err != nil {
+			return nil, err
+		}
+		ti, err := typeinfo.FromSqlType(sqlType)
+		if err != nil {
+			return nil, err
+		}
+
+		var constraints []schema.ColConstraint
+		if !f.Nullable {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+		cols[i], err = schema.NewColumnWithTypeInfo(f.Name, uint64(i), ti, false, "", false, "", constraints...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArrowReader{closer: r, fr: fr, sch: sch}, nil
+}
+
+// sqlTypeForField returns the SQL type of the column read from |f|. A field written by an ArrowRowWriter is read with
+// the type of the column it was written from.
+func sqlTypeForField(f arrow.Field) (sql.Type, error) {
+	if idx := f.Metadata.FindKey(SqlTypeMetadataKey); idx >= 0 {
+		return planbuilder.ParseColumnTypeString(f.Metadata.Values()[idx])
+	}
+
+	switch dt := f.Type.(type) {
+	case *arrow.BooleanType:
+		return gmstypes.Boolean, nil
+	case *arrow.Int8Type:
+		return gmstypes.Int8, nil
+	case *arrow.Int16Type:
+		return gmstypes.Int16, nil
+	case *arrow.Int32Type:
+		return gmstypes.Int32, nil
+	case *arrow.Int64Type:
+		return gmstypes.Int64, nil
+	case *arrow.Uint8Type:
+		return gmstypes.Uint8, nil
+	case *arrow.Uint16Type:
+		return gmstypes.Uint16, nil
+	case *arrow.Uint32Type:
+		return gmstypes.Uint32, nil
+	case *arrow.Uint64Type:
+		return gmstypes.Uint64, nil
+	case *arrow.Float16Type, *arrow.Float32Type:
+		return gmstypes.Float32, nil
+	case *arrow.Float64Type:
+		return gmstypes.Float64, nil
+	case *arrow.Decimal128Type:
+		return gmstypes.CreateDecimalType(uint8(dt.Precision), uint8(dt.Scale))
+	case *arrow.Decimal256Type:
+		return gmstypes.CreateDecimalType(uint8(dt.Precision), uint8(dt.Scale))
+	case *arrow.Date32Type, *arrow.Date64Type:
+		return gmstypes.Date, nil
+	case *arrow.TimestampType:
+		return gmstypes.DatetimeMaxPrecision, nil
+	case *arrow.Time32Type, *arrow.Time64Type, *arrow.DurationType:
+		return gmstypes.Time, nil
+	case *arrow.StringType, *arrow.LargeStringType, *arrow.StringViewType:
+		return gmstypes.LongText, nil
+	case *arrow.BinaryType, *arrow.LargeBinaryType, *arrow.BinaryViewType, *arrow.FixedSizeBinaryType:
+		return gmstypes.LongBlob, nil
+	case *arrow.ListType, *arrow.LargeListType, *arrow.FixedSizeListType, *arrow.StructType, *arrow.MapType:
+		return gmstypes.JSON, nil
+	default:
+		return nil, fmt.Errorf("cannot read field %s: unsupported type %s", f.Name, f.Type)
+	}
+}
+
+func (ar *ArrowReader) ReadRow(ctx context.Context) (row.Row, error) {
+	panic("deprecated")
+}
+
+func (ar *ArrowReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	for ar.rec == nil || ar.rowInRec >= int(ar.rec.NumRows()) {
+		if ar.batch >= ar.fr.NumRecords() {
+			return nil, io.EOF
+		}
+		// records returned by the FileReader are released when the next one is read
+		rec, err := ar.fr.Record(ar.batch)
+		if err != nil {
+			return nil, err
+		}
+		ar.batch++
+		ar.rec = rec
+		ar.rowInRec = 0
+	}
+
+	r := make(sql.Row, ar.rec.NumCols())
+	for i, col := range ar.rec.Columns() {
+		val, err := arrowValue(col, ar.rowInRec)
+		if err != nil {
+			return nil, err
+		}
+		r[i] = val
+	}
+	ar.rowInRec++
+	return r, nil
+}
+
+// arrowValue returns the value at index |i| of |arr| as a value that can be converted to the SQL type of |arr|'s field.
+func arrowValue(arr arrow.Array, i int) (interface{}, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return a.Value(i), nil
+	case *array.Int8:
+		return a.Value(i), nil
+	case *array.Int16:
+		return a.Value(i), nil
+	case *array.Int32:
+		return a.Value(i), nil
+	case *array.Int64:
+		return a.Value(i), nil
+	case *array.Uint8:
+		return a.Value(i), nil
+	case *array.Uint16:
+		return a.Value(i), nil
+	case *array.Uint32:
+		return a.Value(i), nil
+	case *array.Uint64:
+		return a.Value(i), nil
+	case *array.Float16:
+		return a.Value(i).Float32(), nil
+	case *array.Float32:
+		return a.Value(i), nil
+	case *array.Float64:
+		return a.Value(i), nil
+	case *array.Decimal128:
+		return a.Value(i).ToString(a.DataType().(*arrow.Decimal128Type).Scale), nil
+	case *array.Decimal256:
+		return a.Value(i).ToString(a.DataType().(*arrow.Decimal256Type).Scale), nil
+	case *array.Date32:
+		return a.Value(i).ToTime(), nil
+	case *array.Date64:
+		return a.Value(i).ToTime(), nil
+	case *array.Timestamp:
+		return a.Value(i).ToTime(a.DataType().(*arrow.TimestampType).Unit), nil
+	case *array.Time32:
+		unit := a.DataType().(*arrow.Time32Type).Unit
+		return gmstypes.Timespan((time.Duration(a.Value(i)) * unit.Multiplier()).Microseconds()), nil
+	case *array.Time64:
+		unit := a.DataType().(*arrow.Time64Type).Unit
+		return gmstypes.Timespan((time.Duration(a.Value(i)) * unit.Multiplier()).Microseconds()), nil
+	case *array.Duration:
+		unit := a.DataType().(*arrow.DurationType).Unit
+		return gmstypes.Timespan((time.Duration(a.Value(i)) * unit.Multiplier()).Microseconds()), nil
+	case *array.String:
+		return a.Value(i), nil
+	case *array.LargeString:
+		return a.Value(i), nil
+	case *array.StringView:
+		return a.Value(i), nil
+	case *array.Binary:
+		// the bytes returned by Value share the record's buffers, which are released with the record
+		return bytes.Clone(a.Value(i)), nil
+	case *array.LargeBinary:
+		return bytes.Clone(a.Value(i)), nil
+	case *array.BinaryView:
+		return bytes.Clone(a.Value(i)), nil
+	case *array.FixedSizeBinary:
+		return bytes.Clone(a.Value(i)), nil
+	default:
+		data, err := json.Marshal(arr.GetOneForMarshal(i))
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+}
+
+func (ar *ArrowReader) GetSchema() schema.Schema {
+	return ar.sch
+}
+
+// HasDeclaredTypes implements table.TypedReader.
+func (ar *ArrowReader) HasDeclaredTypes() bool {
+	return true
+}
+
+// Close should release resources being held
+func (ar *ArrowReader) Close(ctx context.Context) error {
+	if ar.closer == nil {
+		return errors.New("already closed")
+	}
+
+	err := ar.fr.Close()
+	if cerr := ar.closer.Close(); err == nil {
+		err = cerr
+	}
+	ar.closer = nil
+	return err
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/decimal256"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+const (
+	// SqlTypeMetadataKey is the key of the field metadata holding the SQL type of the column a field was written from.
+	// It lets an exported file be imported again with the exact column types of the original table.
+	SqlTypeMetadataKey = "dolt.sql_type"
+
+	// rowsPerBatch is the number of rows buffered before a record batch is written.
+	rowsPerBatch = 64 * 1024
+)
+
+type ArrowRowWriter struct {
+	fw      *ipc.FileWriter
+	builder *array.RecordBuilder
+	sch     sql.Schema
+	rows    int
+	closer  io.Closer
+}
+
+var _ table.SqlRowWriter = (*ArrowRowWriter)(nil)
+
+// NewArrowRowWriter creates a new ArrowRowWriter writing the rows of |outSch| as an Arrow IPC file to |w|.
+func NewArrowRowWriter(outSch schema.Schema, w io.WriteCloser) (*ArrowRowWriter, error) {
+	sqlSch, err := sqlutil.FromDoltSchema("", "", outSch)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]arrow.Field, len(sqlSch.Schema))
+	for i, col := range sqlSch.Schema {
+		dt, err := mapTypeToArrowType(col.Type)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = arrow.Field{
+			Name:     col.Name,
+			Type:     dt,
+			Nullable: col.Nullable,
+			Metadata: arrow.NewMetadata([]string{SqlTypeMetadataKey}, []string{col.Type.String()}),
+		}
+	}
+	arrowSch := arrow.NewSchema(fields, nil)
+
+	mem := memory.NewGoAllocator()
+	fw, err := ipc.NewFileWriter(w, ipc.WithSchema(arrowSch), ipc.WithAllocator(mem))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArrowRowWriter{
+		fw:      fw,
+		builder: array.NewRecordBuilder(mem, arrowSch),
+		sch:     sqlSch.Schema,
+		closer:  w,
+	}, nil
+}
+
+func (awr *ArrowRowWriter) WriteSqlRow(ctx *sql.Context, r sql.Row) error {
+	for i, val := range r {
+		if err := appendValue(ctx, awr.builder.Field(i), awr.sch[i].Type, val); err != nil {
+			return err
+		}
+	}
+
+	awr.rows++
+	if awr.rows >= rowsPerBatch {
+		return awr.flush()
+	}
+	return nil
+}
+
+func (awr *ArrowRowWriter) flush() error {
+	if awr.rows == 0 {
+		return nil
+	}
+	rec := awr.builder.NewRecord()
+	defer rec.Release()
+	awr.rows = 0
+	return awr.fw.Write(rec)
+}
+
+// Close should flush all writes, release resources being held
+func (awr *ArrowRowWriter) Close(_ context.Context) error {
+	defer awr.builder.Release()
+
+	err := awr.flush()
+	if err != nil {
+		return err
+	}
+
+	// Close writes the footer of the file, but doesn't close the underlying writer
+	if err = awr.fw.Close(); err != nil {
+		return err
+	}
+
+	if awr.closer != nil {
+		err = awr.closer.Close()
+		awr.closer = nil
+	}
+	return err
+}
+
+// mapTypeToArrowType maps |t| from a sql.Type to an Arrow data type.
+func mapTypeToArrowType(t sql.Type) (arrow.DataType, error) {
+	switch t.Type() {
+	case query.Type_INT8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case query.Type_INT16, query.Type_YEAR:
+		return arrow.PrimitiveTypes.Int16, nil
+	case query.Type_INT24, query.Type_INT32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case query.Type_INT64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case query.Type_UINT8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case query.Type_UINT16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case query.Type_UINT24, query.Type_UINT32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case query.Type_UINT64, query.Type_BIT:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case query.Type_FLOAT32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case query.Type_FLOAT64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case query.Type_DECIMAL:
+		dt := t.(sql.DecimalType)
+		if dt.Precision() > decimal128.MaxPrecision {
+			return &arrow.Decimal256Type{Precision: int32(dt.Precision()), Scale: int32(dt.Scale())}, nil
+		}
+		return &arrow.Decimal128Type{Precision: int32(dt.Precision()), Scale: int32(dt.Scale())}, nil
+	case query.Type_DATE:
+		return arrow.FixedWidthTypes.Date32, nil
+	case query.Type_DATETIME, query.Type_TIMESTAMP:
+		return arrow.FixedWidthTypes.Timestamp_us, nil
+	case query.Type_TIME:
+		// MySQL times are durations rather than times of day, and can be negative or larger than a day
+		return arrow.FixedWidthTypes.Duration_us, nil
+	case query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB, query.Type_GEOMETRY:
+		return arrow.BinaryTypes.Binary, nil
+	case query.Type_CHAR, query.Type_VARCHAR, query.Type_TEXT, query.Type_ENUM, query.Type_SET, query.Type_JSON:
+		return arrow.BinaryTypes.String, nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", t.Type())
+	}
+}
+
+// appendValue appends |val| of SQL type |t| to |b|, which builds an array of the Arrow type of |t|.
+func appendValue(ctx *sql.Context, b array.Builder, t sql.Type, val interface{}) error {
+	if val == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch b := b.(type) {
+	case *array.Int8Builder:
+		v, _, err := types.Int8.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(int8))
+	case *array.Int16Builder:
+		v, _, err := types.Int16.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(int16))
+	case *array.Int32Builder:
+		v, _, err := types.Int32.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(int32))
+	case *array.Int64Builder:
+		v, _, err := types.Int64.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(int64))
+	case *array.Uint8Builder:
+		v, _, err := types.Uint8.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(uint8))
+	case *array.Uint16Builder:
+		v, _, err := types.Uint16.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(uint16))
+	case *array.Uint32Builder:
+		v, _, err := types.Uint32.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(uint32))
+	case *array.Uint64Builder:
+		v, _, err := types.Uint64.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(uint64))
+	case *array.Float32Builder:
+		v, _, err := types.Float32.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(float32))
+	case *array.Float64Builder:
+		v, _, err := types.Float64.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(v.(float64))
+	case *array.Decimal128Builder:
+		dt := b.Type().(*arrow.Decimal128Type)
+		str, err := sqlutil.SqlColToStr(ctx, t, val)
+		if err != nil {
+			return err
+		}
+		num, err := decimal128.FromString(str, dt.Precision, dt.Scale)
+		if err != nil {
+			return err
+		}
+		b.Append(num)
+	case *array.Decimal256Builder:
+		dt := b.Type().(*arrow.Decimal256Type)
+		str, err := sqlutil.SqlColToStr(ctx, t, val)
+		if err != nil {
+			return err
+		}
+		num, err := decimal256.FromString(str, dt.Precision, dt.Scale)
+		if err != nil {
+			return err
+		}
+		b.Append(num)
+	case *array.Date32Builder:
+		v, _, err := t.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Date32FromTime(v.(time.Time)))
+	case *array.TimestampBuilder:
+		v, _, err := t.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Timestamp(v.(time.Time).UnixMicro()))
+	case *array.DurationBuilder:
+		v, _, err := t.Convert(ctx, val)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Duration(v.(types.Timespan).AsMicroseconds()))
+	case *array.BinaryBuilder:
+		sqlVal, err := t.SQL(ctx, nil, val)
+		if err != nil {
+			return err
+		}
+		b.Append(sqlVal.ToBytes())
+	case *array.StringBuilder:
+		str, err := sqlutil.SqlColToStr(ctx, t, val)
+		if err != nil {
+			return err
+		}
+		b.Append(str)
+	default:
+		return fmt.Errorf("unsupported arrow type: %v", b.Type())
+	}
+	return nil
+}
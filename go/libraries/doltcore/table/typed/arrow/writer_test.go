@@ -0,0 +1,72 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	fs := filesys.EmptyInMemFS("/")
+	rows := dtestutils.TypedSampleRows()
+
+	w, err := fs.OpenForWrite("/people.arrow", os.ModePerm)
+	require.NoError(t, err)
+	arWr, err := NewArrowRowWriter(dtestutils.TypedSampleSchema, w)
+	require.NoError(t, err)
+	for _, r := range rows {
+		require.NoError(t, arWr.WriteSqlRow(ctx, r))
+	}
+	require.NoError(t, arWr.Close(ctx))
+
+	arRd, err := OpenArrowReader("/people.arrow", fs)
+	require.NoError(t, err)
+	defer arRd.Close(ctx)
+
+	// The types and names of the columns are read from the file
+	readCols := arRd.GetSchema().GetAllCols().GetColumns()
+	require.Len(t, readCols, len(dtestutils.TypedSampleSchema.GetAllCols().GetColumns()))
+	for i, col := range dtestutils.TypedSampleSchema.GetAllCols().GetColumns() {
+		assert.Equal(t, col.Name, readCols[i].Name)
+		assert.True(t, col.TypeInfo.Equals(readCols[i].TypeInfo), "column %s: expected %s, got %s", col.Name, col.TypeInfo, readCols[i].TypeInfo)
+		assert.Equal(t, col.IsNullable(), readCols[i].IsNullable())
+	}
+
+	for _, expected := range rows {
+		actual, err := arRd.ReadSqlRow(ctx)
+		require.NoError(t, err)
+		require.Len(t, actual, len(expected))
+		for i, col := range readCols {
+			exp, _, err := col.TypeInfo.ToSqlType().Convert(ctx, expected[i])
+			require.NoError(t, err)
+			act, _, err := col.TypeInfo.ToSqlType().Convert(ctx, actual[i])
+			require.NoError(t, err)
+			assert.Equal(t, exp, act, "column %s", col.Name)
+		}
+	}
+
+	_, err = arRd.ReadSqlRow(ctx)
+	assert.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/linkedin/goavro/v2"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// AvroReader implements TableReader. It reads the records of an Avro object container file and returns them as rows.
+// The schema of the rows is read from the schema of the file.
+type AvroReader struct {
+	closer io.Closer
+	ocf    *goavro.OCFReader
+	sch    schema.Schema
+	fields []avroField
+}
+
+var _ table.SqlTableReader = (*AvroReader)(nil)
+var _ table.TypedReader = (*AvroReader)(nil)
+
+// avroField is a field of the record schema of an Avro file, and the column it is read into.
+type avroField struct {
+	Name       string      `json:"name"`
+	Type       interface{} `json:"type"`
+	SqlType    string      `json:"dolt.sql_type"`
+	ColumnName string      `json:"dolt.column_name"`
+
+	sqlType  sql.Type
+	nullable bool
+}
+
+// OpenAvroReader opens a reader at a given path within the filesystem given.
+func OpenAvroReader(path string, fs filesys.ReadableFS) (*AvroReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rd, err := NewAvroReader(r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return rd, nil
+}
+
+// NewAvroReader creates an AvroReader reading an Avro object container file from |r|.
+func NewAvroReader(r io.ReadCloser) (*AvroReader, error) {
+	ocf, err := goavro.NewOCFReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var record struct {
+		Type   interface{} `json:"type"`
+		Fields []avroField `json:"fields"`
+	}
+	if err = json.Unmarshal([]byte(ocf.Codec().Schema()), &record); err != nil {
+		return nil, err
+	}
+	if record.Type != "record" {
+		return nil, errors.New("avro files must contain records to be imported")
+	}
+
+	cols := make([]schema.Column, len(record.Fields))
+	for i := range record.Fields {
+		f := &record.Fields[i]
+		f.sqlType, f.nullable, err = sqlTypeForField(f)
+		if err != nil {
+			return nil, err
+		}
+
+		ti, err := typeinfo.FromSqlType(f.sqlType)
+		if err != nil {
+			return nil, err
+		}
+
+		name := f.Name
+		if f.ColumnName != "" {
+			name = f.ColumnName
+		}
+		var constraints []schema.ColConstraint
+		if !f.nullable {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+		cols[i], err = schema.NewColumnWithTypeInfo(name, uint64(i), ti, false, "", false, "", constraints...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AvroReader{closer: r, ocf: ocf, sch: sch, fields: record.Fields}, nil
+}
+
+// sqlTypeForField returns the SQL type of the column read from |f|, and whether the column is nullable. A field
+// written by an AvroRowWriter is read with the type of the column it was written from.
+func sqlTypeForField(f *avroField) (sql.Type, bool, error) {
+	avroType := f.Type
+	nullable := false
+	if union, ok := avroType.([]interface{}); ok {
+		var branches []interface{}
+		for _, branch := range union {
+			if branch == "null" {
+				nullable = true
+			} else {
+				branches = append(branches, branch)
+			}
+		}
+		if len(branches) != 1 {
+			return nil, false, fmt.Errorf("cannot read field %s: unions of more than one non-null type are not supported", f.Name)
+		}
+		avroType = branches[0]
+	}
+
+	if f.SqlType != "" {
+		t, err := planbuilder.ParseColumnTypeString(f.SqlType)
+		return t, nullable, err
+	}
+
+	switch t := avroType.(type) {
+	case string:
+		switch t {
+		case "boolean":
+			return gmstypes.Boolean, nullable, nil
+		case "int":
+			return gmstypes.Int32, nullable, nil
+		case "long":
+			return gmstypes.Int64, nullable, nil
+		case "float":
+			return gmstypes.Float32, nullable, nil
+		case "double":
+			return gmstypes.Float64, nullable, nil
+		case "bytes":
+			return gmstypes.LongBlob, nullable, nil
+		case "string":
+			return gmstypes.LongText, nullable, nil
+		default:
+			// a reference to a named type, which is a record, an enum or a fixed
+			return gmstypes.JSON, nullable, nil
+		}
+	case map[string]interface{}:
+		switch t["logicalType"] {
+		case "date":
+			return gmstypes.Date, nullable, nil
+		case "timestamp-millis", "timestamp-micros", "local-timestamp-millis", "local-timestamp-micros":
+			return gmstypes.DatetimeMaxPrecision, nullable, nil
+		case "time-millis", "time-micros":
+			return gmstypes.Time, nullable, nil
+		case "decimal":
+			precision, _ := t["precision"].(float64)
+			scale, _ := t["scale"].(float64)
+			dt, err := gmstypes.CreateDecimalType(uint8(precision), uint8(scale))
+			return dt, nullable, err
+		}
+		switch t["type"] {
+		case "enum", "string":
+			return gmstypes.LongText, nullable, nil
+		case "fixed", "bytes":
+			return gmstypes.LongBlob, nullable, nil
+		case "record", "array", "map":
+			return gmstypes.JSON, nullable, nil
+		}
+		return sqlTypeForField(&avroField{Name: f.Name, Type: t["type"]})
+	default:
+		return nil, false, fmt.Errorf("cannot read field %s: unsupported type %v", f.Name, t)
+	}
+}
+
+func (ar *AvroReader) ReadRow(ctx context.Context) (row.Row, error) {
+	panic("deprecated")
+}
+
+func (ar *AvroReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	if !ar.ocf.Scan() {
+		if err := ar.ocf.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	datum, err := ar.ocf.Read()
+	if err != nil {
+		return nil, err
+	}
+	rec, ok := datum.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected avro record %v", datum)
+	}
+
+	r := make(sql.Row, len(ar.fields))
+	for i, f := range ar.fields {
+		val := rec[f.Name]
+		if union, ok := val.(map[string]interface{}); ok && f.nullable {
+			// nullable values are decoded as a map from the name of the union branch to the value
+			for _, v := range union {
+				val = v
+			}
+		}
+		r[i] = fromAvroValue(f.sqlType, val)
+	}
+	return r, nil
+}
+
+// fromAvroValue converts |val| decoded by goavro to a value of the SQL type |t|.
+func fromAvroValue(t sql.Type, val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case time.Duration:
+		return gmstypes.Timespan(v.Microseconds())
+	case *big.Rat:
+		scale := 0
+		if dt, ok := t.(sql.DecimalType); ok {
+			scale = int(dt.Scale())
+		}
+		return v.FloatString(scale)
+	case int64:
+		if t.Type() == query.Type_BIT {
+			return uint64(v)
+		}
+		return v
+	case map[string]interface{}, []interface{}:
+		return gmstypes.JSONDocument{Val: v}
+	default:
+		return v
+	}
+}
+
+func (ar *AvroReader) GetSchema() schema.Schema {
+	return ar.sch
+}
+
+// HasDeclaredTypes implements table.TypedReader.
+func (ar *AvroReader) HasDeclaredTypes() bool {
+	return true
+}
+
+// Close should release resources being held
+func (ar *AvroReader) Close(ctx context.Context) error {
+	if ar.closer != nil {
+		err := ar.closer.Close()
+		ar.closer = nil
+		return err
+	}
+	return errors.New("already closed")
+}
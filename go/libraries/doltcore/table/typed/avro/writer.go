@@ -0,0 +1,312 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/linkedin/goavro/v2"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+const (
+	// SqlTypeProperty is the name of the field property holding the SQL type of the column a field was written from.
+	// It lets an exported file be imported again with the exact column types of the original table.
+	SqlTypeProperty = "dolt.sql_type"
+	// ColumnNameProperty is the name of the field property holding the name of the column a field was written from,
+	// when that name is not a valid Avro name.
+	ColumnNameProperty = "dolt.column_name"
+
+	recordName = "row"
+	// rowsPerBlock is the number of rows buffered before an Avro block is written.
+	rowsPerBlock = 1024
+)
+
+type AvroRowWriter struct {
+	ocf    *goavro.OCFWriter
+	sch    sql.Schema
+	names  []string
+	closer io.Closer
+	block  []interface{}
+}
+
+var _ table.SqlRowWriter = (*AvroRowWriter)(nil)
+
+// NewAvroRowWriter creates a new AvroRowWriter writing the rows of |outSch| as an Avro object container file to |w|.
+func NewAvroRowWriter(outSch schema.Schema, w io.WriteCloser) (*AvroRowWriter, error) {
+	sqlSch, err := sqlutil.FromDoltSchema("", "", outSch)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]map[string]interface{}, len(sqlSch.Schema))
+	colNames := make([]string, len(sqlSch.Schema))
+	for i, col := range sqlSch.Schema {
+		colNames[i] = col.Name
+	}
+	names := avroNames(colNames)
+	for i, col := range sqlSch.Schema {
+		fieldType, err := mapTypeToAvroType(col.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		field := map[string]interface{}{
+			"name":          names[i],
+			SqlTypeProperty: col.Type.String(),
+		}
+		if names[i] != col.Name {
+			field[ColumnNameProperty] = col.Name
+		}
+		if col.Nullable {
+			field["type"] = []interface{}{"null", fieldType}
+			field["default"] = nil
+		} else {
+			field["type"] = fieldType
+		}
+		fields[i] = field
+	}
+
+	avroSch, err := json.Marshal(map[string]interface{}{
+		"type":   "record",
+		"name":   recordName,
+		"fields": fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	codec, err := goavro.NewCodec(string(avroSch))
+	if err != nil {
+		return nil, err
+	}
+
+	ocf, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               w,
+		Codec:           codec,
+		CompressionName: goavro.CompressionSnappyLabel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AvroRowWriter{ocf: ocf, sch: sqlSch.Schema, names: names, closer: w}, nil
+}
+
+func (awr *AvroRowWriter) WriteSqlRow(ctx *sql.Context, r sql.Row) error {
+	rec := make(map[string]interface{}, len(awr.sch))
+	for i, val := range r {
+		col := awr.sch[i]
+		if val == nil {
+			rec[awr.names[i]] = nil
+			continue
+		}
+
+		avroVal, err := toAvroValue(ctx, col.Type, val)
+		if err != nil {
+			return err
+		}
+		if col.Nullable {
+			fieldType, _ := mapTypeToAvroType(col.Type)
+			avroVal = goavro.Union(unionBranchName(fieldType), avroVal)
+		}
+		rec[awr.names[i]] = avroVal
+	}
+
+	awr.block = append(awr.block, rec)
+	if len(awr.block) >= rowsPerBlock {
+		return awr.flush()
+	}
+	return nil
+}
+
+func (awr *AvroRowWriter) flush() error {
+	if len(awr.block) == 0 {
+		return nil
+	}
+	err := awr.ocf.Append(awr.block)
+	awr.block = awr.block[:0]
+	return err
+}
+
+// Close should flush all writes, release resources being held
+func (awr *AvroRowWriter) Close(_ context.Context) error {
+	err := awr.flush()
+	if err != nil {
+		return err
+	}
+
+	if awr.closer != nil {
+		err = awr.closer.Close()
+		awr.closer = nil
+	}
+	return err
+}
+
+// mapTypeToAvroType maps |t| from a sql.Type to the JSON representation of an Avro type.
+func mapTypeToAvroType(t sql.Type) (interface{}, error) {
+	switch t.Type() {
+	case query.Type_INT8, query.Type_INT16, query.Type_INT24, query.Type_INT32,
+		query.Type_UINT8, query.Type_UINT16, query.Type_UINT24, query.Type_YEAR:
+		return "int", nil
+	case query.Type_INT64, query.Type_UINT32, query.Type_BIT:
+		return "long", nil
+	case query.Type_UINT64:
+		// Avro has no unsigned types, so values above math.MaxInt64 are stored as decimals
+		return map[string]interface{}{"type": "bytes", "logicalType": "decimal", "precision": 20, "scale": 0}, nil
+	case query.Type_FLOAT32:
+		return "float", nil
+	case query.Type_FLOAT64:
+		return "double", nil
+	case query.Type_DECIMAL:
+		dt := t.(sql.DecimalType)
+		return map[string]interface{}{"type": "bytes", "logicalType": "decimal", "precision": int(dt.Precision()), "scale": int(dt.Scale())}, nil
+	case query.Type_DATE:
+		return map[string]interface{}{"type": "int", "logicalType": "date"}, nil
+	case query.Type_DATETIME, query.Type_TIMESTAMP:
+		return map[string]interface{}{"type": "long", "logicalType": "timestamp-micros"}, nil
+	case query.Type_TIME:
+		return map[string]interface{}{"type": "long", "logicalType": "time-micros"}, nil
+	case query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB, query.Type_GEOMETRY:
+		return "bytes", nil
+	case query.Type_CHAR, query.Type_VARCHAR, query.Type_TEXT, query.Type_ENUM, query.Type_SET, query.Type_JSON:
+		return "string", nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", t.Type())
+	}
+}
+
+// unionBranchName returns the name goavro uses for the branch of a union of type |avroType|.
+func unionBranchName(avroType interface{}) string {
+	if m, ok := avroType.(map[string]interface{}); ok {
+		return m["type"].(string) + "." + m["logicalType"].(string)
+	}
+	return avroType.(string)
+}
+
+// toAvroValue converts |val| of SQL type |t| to the value goavro expects for the Avro type of |t|.
+func toAvroValue(ctx *sql.Context, t sql.Type, val interface{}) (interface{}, error) {
+	switch t.Type() {
+	case query.Type_INT8, query.Type_INT16, query.Type_INT24, query.Type_INT32,
+		query.Type_UINT8, query.Type_UINT16, query.Type_UINT24, query.Type_YEAR:
+		v, _, err := types.Int32.Convert(ctx, val)
+		return v, err
+	case query.Type_INT64, query.Type_UINT32:
+		v, _, err := types.Int64.Convert(ctx, val)
+		return v, err
+	case query.Type_BIT:
+		v, _, err := types.Uint64.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return int64(v.(uint64)), nil
+	case query.Type_UINT64:
+		v, _, err := types.Uint64.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Rat).SetFrac(new(big.Int).SetUint64(v.(uint64)), big.NewInt(1)), nil
+	case query.Type_FLOAT32:
+		v, _, err := types.Float32.Convert(ctx, val)
+		return v, err
+	case query.Type_FLOAT64:
+		v, _, err := types.Float64.Convert(ctx, val)
+		return v, err
+	case query.Type_DECIMAL:
+		v, _, err := t.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return v.(decimal.Decimal).Rat(), nil
+	case query.Type_DATE, query.Type_DATETIME, query.Type_TIMESTAMP:
+		v, _, err := t.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return v.(time.Time), nil
+	case query.Type_TIME:
+		v, _, err := t.Convert(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return v.(types.Timespan).AsTimeDuration(), nil
+	case query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB, query.Type_GEOMETRY:
+		sqlVal, err := t.SQL(ctx, nil, val)
+		if err != nil {
+			return nil, err
+		}
+		return sqlVal.ToBytes(), nil
+	default:
+		return sqlutil.SqlColToStr(ctx, t, val)
+	}
+}
+
+// avroNames returns a distinct Avro name for each of |colNames|. Column names that are not valid Avro names are
+// sanitized with avroName, and suffixed with a number if that makes them collide with the name of another column.
+func avroNames(colNames []string) []string {
+	names := make([]string, len(colNames))
+	used := make(map[string]struct{}, len(colNames))
+	// valid names are written unchanged, so they are reserved before any sanitized name
+	for i, name := range colNames {
+		if avroName(name) == name {
+			names[i] = name
+			used[name] = struct{}{}
+		}
+	}
+	for i, name := range colNames {
+		if names[i] != "" {
+			continue
+		}
+		base := avroName(name)
+		n := base
+		for suffix := 2; ; suffix++ {
+			if _, ok := used[n]; !ok {
+				break
+			}
+			n = fmt.Sprintf("%s_%d", base, suffix)
+		}
+		names[i] = n
+		used[n] = struct{}{}
+	}
+	return names
+}
+
+// avroName returns |name| with every character that is not valid in an Avro name replaced by an underscore.
+func avroName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		valid := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !valid {
+			b[i] = '_'
+		}
+	}
+	// names cannot be empty or start with a digit
+	if len(b) == 0 || (b[0] >= '0' && b[0] <= '9') {
+		return "_" + string(b)
+	}
+	return string(b)
+}
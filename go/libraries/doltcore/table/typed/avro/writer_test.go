@@ -0,0 +1,84 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	fs := filesys.EmptyInMemFS("/")
+	rows := dtestutils.TypedSampleRows()
+
+	w, err := fs.OpenForWrite("/people.avro", os.ModePerm)
+	require.NoError(t, err)
+	aWr, err := NewAvroRowWriter(dtestutils.TypedSampleSchema, w)
+	require.NoError(t, err)
+	for _, r := range rows {
+		require.NoError(t, aWr.WriteSqlRow(ctx, r))
+	}
+	require.NoError(t, aWr.Close(ctx))
+
+	aRd, err := OpenAvroReader("/people.avro", fs)
+	require.NoError(t, err)
+	defer aRd.Close(ctx)
+
+	// The types and names of the columns are read from the file
+	readCols := aRd.GetSchema().GetAllCols().GetColumns()
+	require.Len(t, readCols, len(dtestutils.TypedSampleSchema.GetAllCols().GetColumns()))
+	for i, col := range dtestutils.TypedSampleSchema.GetAllCols().GetColumns() {
+		assert.Equal(t, col.Name, readCols[i].Name)
+		assert.True(t, col.TypeInfo.Equals(readCols[i].TypeInfo), "column %s: expected %s, got %s", col.Name, col.TypeInfo, readCols[i].TypeInfo)
+		assert.Equal(t, col.IsNullable(), readCols[i].IsNullable())
+	}
+
+	for _, expected := range rows {
+		actual, err := aRd.ReadSqlRow(ctx)
+		require.NoError(t, err)
+		require.Len(t, actual, len(expected))
+		for i, col := range readCols {
+			exp, _, err := col.TypeInfo.ToSqlType().Convert(ctx, expected[i])
+			require.NoError(t, err)
+			act, _, err := col.TypeInfo.ToSqlType().Convert(ctx, actual[i])
+			require.NoError(t, err)
+			assert.Equal(t, exp, act, "column %s", col.Name)
+		}
+	}
+
+	_, err = aRd.ReadSqlRow(ctx)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestAvroName(t *testing.T) {
+	assert.Equal(t, "name", avroName("name"))
+	assert.Equal(t, "nick_name", avroName("nick name"))
+	assert.Equal(t, "_1st", avroName("1st"))
+	assert.Equal(t, "_", avroName(""))
+}
+
+func TestAvroNames(t *testing.T) {
+	assert.Equal(t, []string{"a_b_2", "a_b", "a_b_3", "_1st"}, avroNames([]string{"a-b", "a_b", "a b", "1st"}))
+	assert.Equal(t, []string{"x", "X"}, avroNames([]string{"x", "X"}))
+}
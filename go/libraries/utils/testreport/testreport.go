@@ -0,0 +1,278 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testreport writes the results of dolt tests and dolt ci workflows in standard test report formats, so that
+// they can be ingested by CI systems next to the results of other test suites.
+package testreport
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format is the format of a test report.
+type Format string
+
+const (
+	// FormatText is the human-readable output of the commands, which is not written by this package.
+	FormatText  Format = "text"
+	FormatJUnit Format = "junit"
+	FormatTAP   Format = "tap"
+	FormatJSON  Format = "json"
+)
+
+// ParseFormat returns the Format named |s|.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatText, FormatJUnit, FormatTAP, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid format '%s'. Valid values are text, junit, tap, json", s)
+	}
+}
+
+// Report is the result of a test run, made of suites of test cases.
+type Report struct {
+	Name   string
+	Suites []Suite
+}
+
+// Suite is a named group of test cases.
+type Suite struct {
+	Name  string
+	Cases []Case
+}
+
+// Case is the result of a single test.
+type Case struct {
+	Name     string
+	Query    string
+	Duration time.Duration
+	// Failure is the failure message of a failed test, and is empty if the test passed.
+	Failure string
+	Failed  bool
+}
+
+// AddCase adds |c| to the suite named |suite|, which is created if it doesn't exist yet. Suites are kept in the order
+// they are first seen.
+func (r *Report) AddCase(suite string, c Case) {
+	for i := range r.Suites {
+		if r.Suites[i].Name == suite {
+			r.Suites[i].Cases = append(r.Suites[i].Cases, c)
+			return
+		}
+	}
+	r.Suites = append(r.Suites, Suite{Name: suite, Cases: []Case{c}})
+}
+
+func (s Suite) counts() (tests, failures int, duration time.Duration) {
+	for _, c := range s.Cases {
+		tests++
+		if c.Failed {
+			failures++
+		}
+		duration += c.Duration
+	}
+	return tests, failures, duration
+}
+
+func (r Report) counts() (tests, failures int, duration time.Duration) {
+	for _, s := range r.Suites {
+		t, f, d := s.counts()
+		tests, failures, duration = tests+t, failures+f, duration+d
+	}
+	return tests, failures, duration
+}
+
+// Write writes |r| to |w| in |format|. FormatText is not supported.
+func Write(w io.Writer, format Format, r Report) error {
+	switch format {
+	case FormatJUnit:
+		return WriteJUnit(w, r)
+	case FormatTAP:
+		return WriteTAP(w, r)
+	case FormatJSON:
+		return WriteJSON(w, r)
+	default:
+		return fmt.Errorf("unsupported report format '%s'", format)
+	}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes |r| as JUnit XML. Each suite of the report is a <testsuite>, and the failure of a case includes
+// its failure message and query.
+func WriteJUnit(w io.Writer, r Report) error {
+	tests, failures, duration := r.counts()
+	doc := junitTestSuites{Name: r.Name, Tests: tests, Failures: failures, Time: seconds(duration)}
+	for _, s := range r.Suites {
+		tests, failures, duration := s.counts()
+		suite := junitTestSuite{Name: s.Name, Tests: tests, Failures: failures, Time: seconds(duration)}
+		for _, c := range s.Cases {
+			tc := junitTestCase{Name: c.Name, Classname: s.Name, Time: seconds(c.Duration)}
+			if c.Failed {
+				body := c.Failure
+				if c.Query != "" {
+					body = fmt.Sprintf("query: %s\n%s", c.Query, c.Failure)
+				}
+				tc.Failure = &junitFailure{Message: c.Failure, Body: body}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteTAP writes |r| in version 13 of the Test Anything Protocol. Cases are numbered across suites, and are described
+// by their suite and name. Each case is followed by a YAML block with its duration, and the failure message and query
+// of a failed case.
+func WriteTAP(w io.Writer, r Report) error {
+	tests, _, _ := r.counts()
+	var sb strings.Builder
+	sb.WriteString("TAP version 13\n")
+	sb.WriteString(fmt.Sprintf("1..%d\n", tests))
+
+	n := 0
+	for _, s := range r.Suites {
+		for _, c := range s.Cases {
+			n++
+			status := "ok"
+			if c.Failed {
+				status = "not ok"
+			}
+			description := c.Name
+			if s.Name != "" {
+				description = s.Name + " / " + c.Name
+			}
+			// '#' starts a directive in TAP, so it can't appear in a description
+			description = strings.ReplaceAll(description, "#", "\\#")
+			sb.WriteString(fmt.Sprintf("%s %d - %s\n", status, n, description))
+
+			sb.WriteString("  ---\n")
+			sb.WriteString(fmt.Sprintf("  duration_ms: %d\n", c.Duration.Milliseconds()))
+			if c.Failed {
+				sb.WriteString(fmt.Sprintf("  message: %s\n", yamlString(c.Failure)))
+				if c.Query != "" {
+					sb.WriteString(fmt.Sprintf("  query: %s\n", yamlString(c.Query)))
+				}
+			}
+			sb.WriteString("  ...\n")
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+type jsonReport struct {
+	Name       string      `json:"name"`
+	Tests      int         `json:"tests"`
+	Failures   int         `json:"failures"`
+	DurationMs int64       `json:"duration_ms"`
+	Suites     []jsonSuite `json:"suites"`
+}
+
+type jsonSuite struct {
+	Name       string     `json:"name"`
+	Tests      int        `json:"tests"`
+	Failures   int        `json:"failures"`
+	DurationMs int64      `json:"duration_ms"`
+	Cases      []jsonCase `json:"cases"`
+}
+
+type jsonCase struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+	Query      string `json:"query,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// WriteJSON writes |r| as a JSON document with the totals of the report and of each suite.
+func WriteJSON(w io.Writer, r Report) error {
+	tests, failures, duration := r.counts()
+	doc := jsonReport{Name: r.Name, Tests: tests, Failures: failures, DurationMs: duration.Milliseconds(), Suites: []jsonSuite{}}
+	for _, s := range r.Suites {
+		tests, failures, duration := s.counts()
+		suite := jsonSuite{Name: s.Name, Tests: tests, Failures: failures, DurationMs: duration.Milliseconds(), Cases: []jsonCase{}}
+		for _, c := range s.Cases {
+			jc := jsonCase{Name: c.Name, Status: "pass", DurationMs: c.Duration.Milliseconds(), Query: c.Query}
+			if c.Failed {
+				jc.Status, jc.Message = "fail", c.Failure
+			}
+			suite.Cases = append(suite.Cases, jc)
+		}
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// seconds formats |d| as the number of seconds with millisecond precision, as JUnit reports times.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// yamlString quotes |s| as a YAML double-quoted scalar, which JSON strings are a subset of.
+func yamlString(s string) string {
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
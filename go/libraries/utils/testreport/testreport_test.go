@@ -0,0 +1,105 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReport() Report {
+	var r Report
+	r.Name = "dolt test"
+	r.AddCase("rows", Case{Name: "two rows", Query: "SELECT * FROM t", Duration: 12 * time.Millisecond})
+	r.AddCase("checks", Case{Name: "no b", Query: "SELECT * FROM t WHERE s < 'b'", Duration: 1500 * time.Millisecond, Failed: true, Failure: "Assertion failed: expected_no_rows, got 1 rows: (1, a)"})
+	r.AddCase("rows", Case{Name: "#1", Query: "SELECT 1", Duration: 0})
+	return r
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("JUnit")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJUnit, f)
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, testReport()))
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="dolt test" tests="3" failures="1" time="1.512">
+  <testsuite name="rows" tests="2" failures="0" time="0.012">
+    <testcase name="two rows" classname="rows" time="0.012"></testcase>
+    <testcase name="#1" classname="rows" time="0.000"></testcase>
+  </testsuite>
+  <testsuite name="checks" tests="1" failures="1" time="1.500">
+    <testcase name="no b" classname="checks" time="1.500">
+      <failure message="Assertion failed: expected_no_rows, got 1 rows: (1, a)">query: SELECT * FROM t WHERE s &lt; &#39;b&#39;&#xA;Assertion failed: expected_no_rows, got 1 rows: (1, a)</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteTAP(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteTAP(&buf, testReport()))
+	expected := `TAP version 13
+1..3
+ok 1 - rows / two rows
+  ---
+  duration_ms: 12
+  ...
+ok 2 - rows / \#1
+  ---
+  duration_ms: 0
+  ...
+not ok 3 - checks / no b
+  ---
+  duration_ms: 1500
+  message: "Assertion failed: expected_no_rows, got 1 rows: (1, a)"
+  query: "SELECT * FROM t WHERE s < 'b'"
+  ...
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, testReport()))
+
+	var doc jsonReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, 3, doc.Tests)
+	assert.Equal(t, 1, doc.Failures)
+	assert.Equal(t, int64(1512), doc.DurationMs)
+	require.Len(t, doc.Suites, 2)
+	assert.Equal(t, "checks", doc.Suites[1].Name)
+	assert.Equal(t, jsonCase{
+		Name:       "no b",
+		Status:     "fail",
+		DurationMs: 1500,
+		Query:      "SELECT * FROM t WHERE s < 'b'",
+		Message:    "Assertion failed: expected_no_rows, got 1 rows: (1, a)",
+	}, doc.Suites[1].Cases[0])
+	assert.Contains(t, buf.String(), `"query": "SELECT * FROM t WHERE s < 'b'"`)
+}
@@ -158,6 +158,13 @@ type Database interface {
 	// is not provided, no working set update will be performed.
 	FastForward(ctx context.Context, ds Dataset, newHeadAddr hash.Hash, workingSetPath string) (Dataset, error)
 
+	// SetHeads ignores any lineage constraints and moves the heads of several
+	// commit and tag datasets to new commits atomically. Every dataset must
+	// currently resolve to the commit at |HeadUpdate.Prev|, otherwise no
+	// dataset is updated and ErrOptimisticLockFailed is returned. A tag
+	// dataset is pointed at a new tag with the metadata of its current tag.
+	SetHeads(ctx context.Context, updates []HeadUpdate) error
+
 	// Stats may return some kind of struct that reports statistics about the
 	// ChunkStore that backs this Database instance. The type is
 	// implementation-dependent, and impls may return nil
@@ -182,6 +189,13 @@ type Database interface {
 	chunkStore() chunks.ChunkStore
 }
 
+// HeadUpdate moves the head of the dataset |ID| from the commit |Prev| to the commit |New|.
+type HeadUpdate struct {
+	ID   string
+	Prev hash.Hash
+	New  hash.Hash
+}
+
 func NewDatabase(cs chunks.ChunkStore) Database {
 	vs := types.NewValueStore(cs)
 	ns := tree.NewNodeStore(cs)
@@ -351,6 +351,66 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, addr hash.Hash, w
 	})
 }
 
+func (db *database) SetHeads(ctx context.Context, updates []HeadUpdate) error {
+	for _, u := range updates {
+		newHead, err := db.readHead(ctx, u.New)
+		if err != nil {
+			return err
+		}
+		if newHead == nil {
+			return fmt.Errorf("SetHeads failed: attempt to set a dataset head to an address which is not in the store")
+		}
+		if newHead.TypeName() != commitName {
+			return fmt.Errorf("SetHeads failed: referred to value is not a commit")
+		}
+	}
+
+	return db.update(ctx, func(ctx context.Context, am prolly.AddressMap) (prolly.AddressMap, error) {
+		ae := am.Editor()
+		for _, u := range updates {
+			curr, err := am.Get(ctx, u.ID)
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+			if curr.IsEmpty() {
+				return prolly.AddressMap{}, ErrOptimisticLockFailed
+			}
+			currHead, err := db.readHead(ctx, curr)
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+
+			addr := u.New
+			switch currHead.TypeName() {
+			case commitName:
+				if curr != u.Prev {
+					return prolly.AddressMap{}, ErrOptimisticLockFailed
+				}
+			case tagName:
+				meta, commitAddr, err := currHead.HeadTag()
+				if err != nil {
+					return prolly.AddressMap{}, err
+				}
+				if commitAddr != u.Prev {
+					return prolly.AddressMap{}, ErrOptimisticLockFailed
+				}
+				addr, err = newTag(ctx, db, u.New, meta)
+				if err != nil {
+					return prolly.AddressMap{}, err
+				}
+			default:
+				return prolly.AddressMap{}, fmt.Errorf("SetHeads failed: dataset %s is a %s", u.ID, currHead.TypeName())
+			}
+
+			err = ae.Update(ctx, u.ID, addr)
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+		}
+		return ae.Flush(ctx)
+	})
+}
+
 func (db *database) FastForward(ctx context.Context, ds Dataset, newHeadAddr hash.Hash, wsPath string) (Dataset, error) {
 	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error {
 		return db.doFastForward(ctx, ds, newHeadAddr, wsPath)
@@ -76,6 +76,8 @@ type PullTableFileWriterConfig struct {
 	// chunks to a new file. In bytes.
 	TargetFileSize       uint64
 	MaximumBufferedFiles int
+	// Encryption encrypts the chunk data of the table files written, if it is set.
+	Encryption *nbs.ChunkEncryption
 }
 
 type DestTableFileStore interface {
@@ -260,9 +262,9 @@ LOOP:
 
 			if curWr == nil {
 				if os.Getenv("DOLT_ARCHIVE_PULL_STREAMER") != "0" {
-					curWr, err = nbs.NewArchiveStreamWriter(w.cfg.TempDir)
+					curWr, err = nbs.NewArchiveStreamWriter(w.cfg.TempDir, w.cfg.Encryption)
 				} else {
-					curWr, err = nbs.NewCmpChunkTableWriter(w.cfg.TempDir)
+					curWr, err = nbs.NewCmpChunkTableWriter(w.cfg.TempDir, w.cfg.Encryption)
 				}
 				if err != nil {
 					curWr = nil
@@ -112,6 +112,12 @@ func NewPuller(
 		}
 	}
 
+	// Chunks are written as the sink store would write them, which may encrypt them.
+	var encryption *nbs.ChunkEncryption
+	if ecs, ok := sinkCS.(nbs.EncryptingChunkStore); ok {
+		encryption = ecs.ChunkEncryption()
+	}
+
 	wr := NewPullTableFileWriter(PullTableFileWriterConfig{
 		ConcurrentUploads:    2,
 		TargetFileSize:       targetFileSz,
@@ -119,6 +125,7 @@ func NewPuller(
 		TempDir:              tempDir,
 		DestStore:            sinkCS.(chunks.TableFileStore),
 		GetAddrs:             getAddrs,
+		Encryption:           encryption,
 	})
 
 	var pushLogger *log.Logger
@@ -89,7 +89,7 @@ func unArchiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore,
 		}
 
 		var newTF hash.Hash
-		classicTable, err := NewCmpChunkTableWriter("")
+		classicTable, err := NewCmpChunkTableWriter("", blockStore.encryption)
 		if err != nil {
 			return err
 		}
@@ -199,7 +199,7 @@ func archiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore, da
 		archivePath := ""
 		archiveName := hash.Hash{}
 		chkCnt := uint32(0)
-		archivePath, archiveName, chkCnt, err = convertTableFileToArchive(ctx, cs, idx, dagGroups, path, progress, &stats)
+		archivePath, archiveName, chkCnt, err = convertTableFileToArchive(ctx, cs, idx, dagGroups, path, progress, &stats, blockStore.encryption)
 		if err != nil {
 			if errors.Is(err, errNotEnoughChunks) {
 				progress <- fmt.Sprintf("Not enough chunks to build archive for %s. Skipping.", cs.hash().String())
@@ -216,7 +216,7 @@ func archiveSingleBlockStore(ctx context.Context, blockStore *NomsBlockStore, da
 		}
 		archiveSize := fileInfo.Size()
 
-		err = verifyAllChunks(ctx, idx, archivePath, progress, &stats)
+		err = verifyAllChunks(ctx, idx, archivePath, progress, &stats, blockStore.encryption)
 		if err != nil {
 			return err
 		}
@@ -263,6 +263,7 @@ func convertTableFileToArchive(
 	archivePath string,
 	progress chan interface{},
 	stats *Stats,
+	ce *ChunkEncryption,
 ) (string, hash.Hash, uint32, error) {
 	allChunks, defaultSamples, err := gatherAllChunks(ctx, cs, idx, stats)
 	if err != nil {
@@ -298,12 +299,12 @@ func convertTableFileToArchive(
 	cmpBuff := gozstd.Compress(nil, defaultDict)
 	// p("Default Dict Raw vs Compressed: %d , %d\n", len(defaultDict), len(cmpDefDict))
 
-	arcW, err := newArchiveWriter("")
+	arcW, err := newArchiveWriter("", ce)
 	if err != nil {
 		return "", hash.Hash{}, 0, err
 	}
 	var defaultDictByteSpanId uint32
-	defaultDictByteSpanId, err = arcW.writeByteSpan(cmpBuff)
+	defaultDictByteSpanId, err = arcW.writeByteSpan(arcW.encryption.sealData(cmpBuff))
 	if err != nil {
 		return "", hash.Hash{}, 0, err
 	}
@@ -388,7 +389,7 @@ func writeDataToArchive(
 				groupCount++
 
 				cmpBuff = gozstd.Compress(cmpBuff[:0], cg.dict)
-				dictId, err := arcW.writeByteSpan(cmpBuff)
+				dictId, err := arcW.writeByteSpan(arcW.encryption.sealData(cmpBuff))
 				if err != nil {
 					return 0, 0, 0, err
 				}
@@ -402,7 +403,7 @@ func writeDataToArchive(
 					if !arcW.chunkSeen(cs.chunkId) {
 						cmpBuff = gozstd.CompressDict(cmpBuff[:0], c.Data(), cg.cDict)
 
-						dataId, err := arcW.writeByteSpan(cmpBuff)
+						dataId, err := arcW.writeByteSpan(arcW.encryption.sealData(cmpBuff))
 						if err != nil {
 							return 0, 0, 0, err
 						}
@@ -479,7 +480,7 @@ func compressChunksInParallel(
 						return err
 					}
 					cmpBuff = gozstd.CompressDict(cmpBuff[:0], c.Data(), defaultDict)
-					cp := append([]byte{}, cmpBuff...)
+					cp := arcW.encryption.sealData(append([]byte{}, cmpBuff...))
 					select {
 					case resultCh <- compressedChunk{h: addr, data: cp}:
 					case <-ctx.Done():
@@ -564,7 +565,7 @@ func gatherAllChunks(ctx context.Context, cs chunkSource, idx tableIndex, stats
 	return chkCache, defaultSamples, nil
 }
 
-func verifyAllChunks(ctx context.Context, idx tableIndex, archiveFile string, progress chan interface{}, stats *Stats) error {
+func verifyAllChunks(ctx context.Context, idx tableIndex, archiveFile string, progress chan interface{}, stats *Stats, ce *ChunkEncryption) error {
 	fra, err := newFileReaderAt(archiveFile, false)
 	if err != nil {
 		return err
@@ -576,7 +577,7 @@ func verifyAllChunks(ctx context.Context, idx tableIndex, archiveFile string, pr
 		return fmt.Errorf("invalid archive file path: %s", archiveFile)
 	}
 
-	index, err := newArchiveReader(ctx, fra, name, uint64(fra.sz), NewUnlimitedMemQuotaProvider(), stats)
+	index, err := newArchiveReader(ctx, fra, name, uint64(fra.sz), NewUnlimitedMemQuotaProvider(), stats, ce)
 	if err != nil {
 		return err
 	}
@@ -36,7 +36,7 @@ type archiveChunkSource struct {
 
 var _ chunkSource = &archiveChunkSource{}
 
-func newArchiveChunkSource(ctx context.Context, dir string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, mmapArchiveIndexes bool, stats *Stats) (archiveChunkSource, error) {
+func newArchiveChunkSource(ctx context.Context, dir string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, mmapArchiveIndexes bool, stats *Stats, ce *ChunkEncryption) (archiveChunkSource, error) {
 	archiveFile := filepath.Join(dir, h.String()+ArchiveFileSuffix)
 
 	fra, err := newFileReaderAt(archiveFile, mmapArchiveIndexes)
@@ -44,7 +44,7 @@ func newArchiveChunkSource(ctx context.Context, dir string, h hash.Hash, chunkCo
 		return archiveChunkSource{}, err
 	}
 
-	aRdr, err := newArchiveReader(ctx, fra, h, uint64(fra.sz), q, stats)
+	aRdr, err := newArchiveReader(ctx, fra, h, uint64(fra.sz), q, stats, ce)
 	if err != nil {
 		return archiveChunkSource{}, err
 	}
@@ -57,7 +57,8 @@ func newAWSArchiveChunkSource(ctx context.Context,
 	name string,
 	chunkCount uint32,
 	q MemoryQuotaProvider,
-	stats *Stats) (cs chunkSource, err error) {
+	stats *Stats,
+	ce *ChunkEncryption) (cs chunkSource, err error) {
 
 	footer, err := q.AcquireQuotaByteSlice(ctx, int(archiveFooterSize))
 	if err != nil {
@@ -76,7 +77,7 @@ func newAWSArchiveChunkSource(ctx context.Context,
 		return emptyChunkSource{}, fmt.Errorf("invalid archive file path: %s", name)
 	}
 
-	aRdr, err := newArchiveReaderFromFooter(ctx, &s3TableReaderAt{s3, name}, hashId, sz, footer, q, stats)
+	aRdr, err := newArchiveReaderFromFooter(ctx, &s3TableReaderAt{s3, name}, hashId, sz, footer, q, stats, ce)
 	if err != nil {
 		return emptyChunkSource{}, err
 	}
@@ -30,11 +30,23 @@ type DecompBundle struct {
 	dDict         *gozstd.DDict
 	cDict         *gozstd.CDict
 	rawDictionary *[]byte
+	// encryption opens the dictionary, and the data of the chunks compressed with it, when they are encrypted.
+	encryption *ChunkEncryption
 }
 
 // NewDecompBundle creates a new DecompBundle from a zStd compressed dictionary. The input should be the same
 // bytes we store on disk and transport over the wire. The uncompressed form is preserved in the result.
 func NewDecompBundle(compressedDict []byte) (*DecompBundle, error) {
+	return NewDecompBundleWithEncryption(compressedDict, nil)
+}
+
+// NewDecompBundleWithEncryption creates a new DecompBundle like NewDecompBundle, opening |compressedDict| with |ce| if it is
+// encrypted.
+func NewDecompBundleWithEncryption(compressedDict []byte, ce *ChunkEncryption) (*DecompBundle, error) {
+	compressedDict, err := ce.openData(compressedDict)
+	if err != nil {
+		return nil, err
+	}
 	// Standard zStd decompression. No dictionary for dictionaries.
 	rawDict, err := gozstd.Decompress(nil, compressedDict)
 	if err != nil {
@@ -50,7 +62,7 @@ func NewDecompBundle(compressedDict []byte) (*DecompBundle, error) {
 		return nil, err
 	}
 
-	return &DecompBundle{dDict: dict, rawDictionary: &rawDict, cDict: cDict}, nil
+	return &DecompBundle{dDict: dict, rawDictionary: &rawDict, cDict: cDict, encryption: ce}, nil
 }
 
 type ArchiveToChunker struct {
@@ -75,7 +87,10 @@ func (a ArchiveToChunker) Hash() hash.Hash {
 
 func (a ArchiveToChunker) ToChunk() (chunks.Chunk, error) {
 	dict := a.dict.dDict
-	data := a.chunkData
+	data, err := a.dict.encryption.openData(a.chunkData)
+	if err != nil {
+		return chunks.EmptyChunk, err
+	}
 	rawChunk, err := gozstd.DecompressDict(nil, data, dict)
 	if err != nil {
 		return chunks.EmptyChunk, err
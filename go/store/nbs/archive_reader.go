@@ -51,6 +51,7 @@ type archiveReader struct {
 	indexReader archiveIndexReader // Memory-mapped or fallback index reader
 	dictCache   *lru.TwoQueueCache[uint32, *DecompBundle]
 	footer      archiveFooter
+	encryption  *ChunkEncryption // opens the byte spans of the archive when they are encrypted
 }
 
 type suffix [hash.SuffixLen]byte
@@ -124,7 +125,7 @@ func (f archiveFooter) metadataSpan() byteSpan {
 }
 
 func newArchiveMetadata(ctx context.Context, reader tableReaderAt, name hash.Hash, fileSize uint64, q MemoryQuotaProvider, stats *Stats) (*ArchiveMetadata, error) {
-	aRdr, err := newArchiveReader(ctx, reader, name, fileSize, q, stats)
+	aRdr, err := newArchiveReader(ctx, reader, name, fileSize, q, stats, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +191,7 @@ func newArchiveMetadata(ctx context.Context, reader tableReaderAt, name hash.Has
 	}, nil
 }
 
-func newArchiveReaderFromFooter(ctx context.Context, reader tableReaderAt, name hash.Hash, fileSz uint64, footer []byte, q MemoryQuotaProvider, stats *Stats) (archiveReader, error) {
+func newArchiveReaderFromFooter(ctx context.Context, reader tableReaderAt, name hash.Hash, fileSz uint64, footer []byte, q MemoryQuotaProvider, stats *Stats, ce *ChunkEncryption) (archiveReader, error) {
 	if uint64(len(footer)) != archiveFooterSize {
 		return archiveReader{}, errors.New("runtime error: invalid footer.")
 	}
@@ -200,19 +201,19 @@ func newArchiveReaderFromFooter(ctx context.Context, reader tableReaderAt, name
 		return archiveReader{}, err
 	}
 
-	return buildArchiveReader(ctx, reader, ftr, q, stats)
+	return buildArchiveReader(ctx, reader, ftr, q, stats, ce)
 }
 
-func newArchiveReader(ctx context.Context, reader tableReaderAt, name hash.Hash, fileSize uint64, q MemoryQuotaProvider, stats *Stats) (archiveReader, error) {
+func newArchiveReader(ctx context.Context, reader tableReaderAt, name hash.Hash, fileSize uint64, q MemoryQuotaProvider, stats *Stats, ce *ChunkEncryption) (archiveReader, error) {
 	footer, err := loadFooter(ctx, reader, name, fileSize, stats)
 	if err != nil {
 		return archiveReader{}, fmt.Errorf("Failed to loadFooter: %w", err)
 	}
 
-	return buildArchiveReader(ctx, reader, footer, q, stats)
+	return buildArchiveReader(ctx, reader, footer, q, stats, ce)
 }
 
-func buildArchiveReader(ctx context.Context, reader tableReaderAt, footer archiveFooter, q MemoryQuotaProvider, stats *Stats) (archiveReader, error) {
+func buildArchiveReader(ctx context.Context, reader tableReaderAt, footer archiveFooter, q MemoryQuotaProvider, stats *Stats, ce *ChunkEncryption) (archiveReader, error) {
 	dictCache, err := lru.New2Q[uint32, *DecompBundle](256)
 	if err != nil {
 		return archiveReader{}, err
@@ -241,6 +242,7 @@ func buildArchiveReader(ctx context.Context, reader tableReaderAt, footer archiv
 		indexReader: indexRdr,
 		footer:      footer,
 		dictCache:   dictCache,
+		encryption:  ce,
 	}, nil
 }
 
@@ -399,6 +401,7 @@ func (ar archiveReader) clone() (archiveReader, error) {
 		indexReader: indexReader,
 		footer:      ar.footer,
 		dictCache:   ar.dictCache, // cache is thread safe.
+		encryption:  ar.encryption,
 	}, nil
 }
 
@@ -502,7 +505,7 @@ func (ar archiveReader) get(ctx context.Context, hash hash.Hash, stats *Stats) (
 	if dict == nil {
 		if ar.footer.formatVersion >= archiveVersionSnappySupport {
 			// Snappy compression format. The data is compressed with a checksum at the end.
-			cc, err := NewCompressedChunk(hash, data)
+			cc, err := NewCompressedChunkWithEncryption(hash, data, ar.encryption)
 			if err != nil {
 				return nil, err
 			}
@@ -515,6 +518,11 @@ func (ar archiveReader) get(ctx context.Context, hash hash.Hash, stats *Stats) (
 		return nil, errors.New("runtime error: unable to get archived chunk. dictionary is nil")
 	}
 
+	data, err = ar.encryption.openData(data)
+	if err != nil {
+		return nil, err
+	}
+
 	var result []byte
 	result, err = gozstd.DecompressDict(nil, data, dict.dDict)
 	if err != nil {
@@ -537,7 +545,7 @@ func (ar archiveReader) getAsToChunker(ctx context.Context, h hash.Hash, stats *
 
 	if dict == nil {
 		if ar.footer.formatVersion >= archiveVersionSnappySupport {
-			cc, err := NewCompressedChunk(h, data)
+			cc, err := NewCompressedChunkWithEncryption(h, data, ar.encryption)
 			if err != nil {
 				return nil, err
 			}
@@ -595,7 +603,7 @@ func (ar archiveReader) getRaw(ctx context.Context, hash hash.Hash, stats *Stats
 			if err != nil {
 				return nil, nil, err
 			}
-			dict, err = NewDecompBundle(dictBytes)
+			dict, err = NewDecompBundleWithEncryption(dictBytes, ar.encryption)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -692,7 +700,7 @@ func (ar archiveReader) iterate(ctx context.Context, cb func(chunks.Chunk) error
 		spanData := buf[:span.length]
 
 		if _, exists := dictReverseIndex[byteSpanCounter]; exists {
-			dict, err := NewDecompBundle(spanData)
+			dict, err := NewDecompBundleWithEncryption(spanData, ar.encryption)
 			if err != nil {
 				return fmt.Errorf("Failure creating dictionary from bytes: %w", err)
 			}
@@ -712,7 +720,7 @@ func (ar archiveReader) iterate(ctx context.Context, cb func(chunks.Chunk) error
 			if dictId == 0 {
 				// Snappy compression (no dictionary)
 				if ar.footer.formatVersion >= archiveVersionSnappySupport {
-					cc, err := NewCompressedChunk(h, spanData)
+					cc, err := NewCompressedChunkWithEncryption(h, spanData, ar.encryption)
 					if err != nil {
 						return err
 					}
@@ -730,6 +738,10 @@ func (ar archiveReader) iterate(ctx context.Context, cb func(chunks.Chunk) error
 					panic("Reverse Index incomplete: Dictionary ID not found in loaded dictionaries")
 				}
 
+				spanData, err = ar.encryption.openData(spanData)
+				if err != nil {
+					return err
+				}
 				chunkData, err = gozstd.DecompressDict(nil, spanData, dict)
 				if err != nil {
 					return fmt.Errorf("error decompressing span: %d, %v, %w", byteSpanCounter, span, err)
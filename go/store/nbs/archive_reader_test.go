@@ -32,7 +32,7 @@ import (
 func TestInMemoryArchiveIndexReaderQuota(t *testing.T) {
 	// Write a simple archive file which has non-sense chunks which claim to be snappy encoded.
 	dir := t.TempDir()
-	writer, err := newArchiveWriter(dir)
+	writer, err := newArchiveWriter(dir, nil)
 	require.NoError(t, err)
 	var bytes [1024]byte
 	var h hash.Hash
@@ -65,7 +65,7 @@ func TestInMemoryArchiveIndexReaderQuota(t *testing.T) {
 		assert.Equal(t, uint64(0), q.Usage())
 		ctx := context.Background()
 		stats := &Stats{}
-		reader, err := newArchiveReader(ctx, tra, h, uint64(tra.sz), q, stats)
+		reader, err := newArchiveReader(ctx, tra, h, uint64(tra.sz), q, stats, nil)
 		require.NoError(t, err)
 
 		// It should have acquired quote.
@@ -128,7 +128,7 @@ func TestInMemoryArchiveIndexReaderQuota(t *testing.T) {
 				assert.Equal(t, uint64(0), q.Usage())
 				ctx := context.Background()
 				stats := &Stats{}
-				_, err = newArchiveReader(ctx, &errorAfter{tra, afterBytes}, h, uint64(tra.sz), q, stats)
+				_, err = newArchiveReader(ctx, &errorAfter{tra, afterBytes}, h, uint64(tra.sz), q, stats, nil)
 				require.Error(t, err)
 				assert.Equal(t, uint64(0), q.Usage())
 				require.NoError(t, tra.Close())
@@ -156,7 +156,7 @@ func TestInMemoryArchiveIndexReaderQuota(t *testing.T) {
 				assert.Equal(t, uint64(0), q.Usage())
 				ctx := context.Background()
 				stats := &Stats{}
-				_, err = newArchiveReader(ctx, tra, h, uint64(tra.sz), &q, stats)
+				_, err = newArchiveReader(ctx, tra, h, uint64(tra.sz), &q, stats, nil)
 				require.Error(t, err)
 				assert.Equal(t, uint64(0), q.Usage())
 			})
@@ -80,6 +80,9 @@ type archiveWriter struct {
 	metadataLen     uint32
 	suffixCheckSum  sha512Sum
 	fullMD5         md5Sum
+	// encryption seals the byte spans of archives built from chunk data. It is nil when the data isn't encrypted, or
+	// when the byte spans written are copied from other archives as they are.
+	encryption *ChunkEncryption
 }
 
 /*
@@ -104,8 +107,8 @@ The archiveWriter is not thread safe, and should only be used by a single routin
 */
 
 // newArchiveWriter creates a new archiveWriter. Output is written to a temp file, as the file name won't be known
-// until we've finished writing the footer.
-func newArchiveWriter(tmpDir string) (*archiveWriter, error) {
+// until we've finished writing the footer. Byte spans built from chunk data are sealed with |ce| if it is not nil.
+func newArchiveWriter(tmpDir string, ce *ChunkEncryption) (*archiveWriter, error) {
 	bs, err := NewBufferedFileByteSink(tmpDir, defaultTableSinkBlockSize, defaultChBufferSize)
 	if err != nil {
 		return nil, err
@@ -117,6 +120,7 @@ func newArchiveWriter(tmpDir string) (*archiveWriter, error) {
 		path:       bs.path,
 		seenChunks: hash.HashSet{},
 		output:     hbSha,
+		encryption: ce,
 	}, nil
 }
 
@@ -514,8 +518,10 @@ type ArchiveStreamWriter struct {
 	chunkCount  int32
 }
 
-func NewArchiveStreamWriter(tmpDir string) (*ArchiveStreamWriter, error) {
-	writer, err := newArchiveWriter(tmpDir)
+// NewArchiveStreamWriter returns an ArchiveStreamWriter writing to a temp file in |tmpDir|. Chunk data is encrypted with
+// |ce| if it is not nil.
+func NewArchiveStreamWriter(tmpDir string, ce *ChunkEncryption) (*ArchiveStreamWriter, error) {
+	writer, err := newArchiveWriter(tmpDir, ce)
 	if err != nil {
 		return nil, err
 	}
@@ -631,7 +637,7 @@ func (asw *ArchiveStreamWriter) writeArchiveToChunker(chunker ArchiveToChunker)
 	dictId, ok := asw.dictMap[dict]
 	if !ok {
 		// compress the raw bytes of the dictionary before persisting it.
-		compressedDict := gozstd.Compress(nil, *dict.rawDictionary)
+		compressedDict := asw.writer.encryption.sealData(gozstd.Compress(nil, *dict.rawDictionary))
 
 		// New dictionary. Write it out, and add id to the map.
 		dictId, err = asw.writer.writeByteSpan(compressedDict)
@@ -642,16 +648,26 @@ func (asw *ArchiveStreamWriter) writeArchiveToChunker(chunker ArchiveToChunker)
 		asw.dictMap[dict] = dictId
 	}
 
-	dataId, err := asw.writer.writeByteSpan(chunker.chunkData)
+	chunkData, _, err := asw.writer.encryption.resealData(chunker.chunkData)
 	if err != nil {
 		return bytesWritten, err
 	}
-	bytesWritten += uint32(len(chunker.chunkData))
+	dataId, err := asw.writer.writeByteSpan(chunkData)
+	if err != nil {
+		return bytesWritten, err
+	}
+	bytesWritten += uint32(len(chunkData))
 	asw.chunkCount += 1
 	return bytesWritten, asw.writer.stageZStdChunk(chunker.Hash(), dictId, dataId)
 }
 
 func (asw *ArchiveStreamWriter) writeCompressedChunk(chunker CompressedChunk) (bytesWritten uint32, err error) {
+	// Queued chunks may be written as is by Finish, so they get the current encryption key now.
+	chunker, err = asw.writer.encryption.resealCompressedChunk(chunker)
+	if err != nil {
+		return 0, err
+	}
+
 	if asw.snappyQueue != nil {
 		// We have a queue of compressed chunks that we are waiting to flush.
 		// Add this chunk to the queue.
@@ -674,9 +690,9 @@ func (asw *ArchiveStreamWriter) writeCompressedChunk(chunker CompressedChunk) (b
 			samples[i] = &chk
 		}
 		rawDictionary := buildDictionary(samples)
-		compressedDict := gozstd.Compress(nil, rawDictionary)
+		compressedDict := asw.writer.encryption.sealData(gozstd.Compress(nil, rawDictionary))
 		bytesWritten += uint32(len(compressedDict))
-		asw.snappyDict, err = NewDecompBundle(compressedDict)
+		asw.snappyDict, err = NewDecompBundleWithEncryption(compressedDict, asw.writer.encryption)
 		if err != nil {
 			return 0, err
 		}
@@ -725,7 +741,7 @@ func (asw *ArchiveStreamWriter) convertSnappyAndStage(cc CompressedChunk) (uint3
 		return 0, err
 	}
 
-	compressedData := gozstd.CompressDict(nil, chk.Data(), asw.snappyDict.cDict)
+	compressedData := asw.writer.encryption.sealData(gozstd.CompressDict(nil, chk.Data(), asw.snappyDict.cDict))
 
 	dataId, err := asw.writer.writeByteSpan(compressedData)
 	if err != nil {
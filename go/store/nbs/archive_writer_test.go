@@ -44,7 +44,7 @@ func TestArchiveStreamWriterRemove(t *testing.T) {
 		dir := t.TempDir()
 		// Empty Dir -> 1 for "." found by WalkDir.
 		require.Equal(t, 1, CountFilesInDir(t, dir))
-		asw, err := NewArchiveStreamWriter(dir)
+		asw, err := NewArchiveStreamWriter(dir, nil)
 		require.NoError(t, err)
 		contents := make([]byte, 1024)
 		_, err = io.ReadFull(rand.Reader, contents)
@@ -63,7 +63,7 @@ func TestArchiveStreamWriterRemove(t *testing.T) {
 	t.Run("CancelOnWriterRemovesFile", func(t *testing.T) {
 		dir := t.TempDir()
 		require.Equal(t, 1, CountFilesInDir(t, dir))
-		asw, err := NewArchiveStreamWriter(dir)
+		asw, err := NewArchiveStreamWriter(dir, nil)
 		require.NoError(t, err)
 		contents := make([]byte, 1024)
 		_, err = io.ReadFull(rand.Reader, contents)
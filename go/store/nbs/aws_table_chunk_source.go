@@ -29,7 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
-func newAWSTableFileChunkSource(ctx context.Context, s3 *s3ObjectReader, al awsLimits, name hash.Hash, chunkCount uint32, q MemoryQuotaProvider, stats *Stats) (cs chunkSource, err error) {
+func newAWSTableFileChunkSource(ctx context.Context, s3 *s3ObjectReader, al awsLimits, name hash.Hash, chunkCount uint32, q MemoryQuotaProvider, stats *Stats, ce *ChunkEncryption) (cs chunkSource, err error) {
 	var tra tableReaderAt
 	index, err := loadTableIndex(ctx, stats, chunkCount, q, func(p []byte) error {
 		n, _, err := s3.readS3ObjectFromEnd(ctx, name.String(), p, stats)
@@ -46,7 +46,7 @@ func newAWSTableFileChunkSource(ctx context.Context, s3 *s3ObjectReader, al awsL
 		return &chunkSourceAdapter{}, err
 	}
 
-	tr, err := newTableReader(ctx, index, tra, s3BlockSize)
+	tr, err := newTableReader(ctx, index, tra, s3BlockSize, ce)
 	if err != nil {
 		_ = index.Close()
 		return &chunkSourceAdapter{}, err
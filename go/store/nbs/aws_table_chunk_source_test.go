@@ -51,6 +51,7 @@ func TestAWSChunkSource(t *testing.T) {
 			uint32(len(chunks)),
 			NewUnlimitedMemQuotaProvider(),
 			&Stats{},
+			nil,
 		)
 
 		require.NoError(t, err)
@@ -71,6 +71,8 @@ type awsTablePersister struct {
 	bucket string
 	ns     string
 	limits awsLimits
+	// encryption seals the chunk data of the tables written, and opens the encrypted chunk data of the tables read.
+	encryption *ChunkEncryption
 }
 
 var _ tablePersister = awsTablePersister{}
@@ -91,6 +93,7 @@ func (s3p awsTablePersister) Open(ctx context.Context, name hash.Hash, chunkCoun
 		chunkCount,
 		s3p.q,
 		stats,
+		s3p.encryption,
 	)
 	if err == nil {
 		return cs, nil
@@ -103,7 +106,8 @@ func (s3p awsTablePersister) Open(ctx context.Context, name hash.Hash, chunkCoun
 		name.String()+ArchiveFileSuffix,
 		chunkCount,
 		s3p.q,
-		stats)
+		stats,
+		s3p.encryption)
 }
 
 func (s3p awsTablePersister) Exists(ctx context.Context, name string, _ uint32, stats *Stats) (bool, error) {
@@ -137,7 +141,7 @@ func (s3p awsTablePersister) key(k string) string {
 }
 
 func (s3p awsTablePersister) Persist(ctx context.Context, behavior dherrors.FatalBehavior, mt *memTable, haver chunkReader, keeper keeperF, stats *Stats) (chunkSource, gcBehavior, error) {
-	name, data, _, chunkCount, gcb, err := mt.write(haver, keeper, stats)
+	name, data, _, chunkCount, gcb, err := mt.write(haver, keeper, s3p.encryption, stats)
 	if err != nil {
 		return emptyChunkSource{}, gcBehavior_Continue, err
 	}
@@ -156,7 +160,7 @@ func (s3p awsTablePersister) Persist(ctx context.Context, behavior dherrors.Fata
 	}
 
 	tra := &s3TableReaderAt{&s3ObjectReader{s3: s3p.s3, bucket: s3p.bucket, readRl: s3p.rl, ns: s3p.ns}, name.String()}
-	src, err := newReaderFromIndexData(ctx, s3p.q, data, name, tra, s3BlockSize)
+	src, err := newReaderFromIndexData(ctx, s3p.q, data, name, tra, s3BlockSize, s3p.encryption)
 	if err != nil {
 		return emptyChunkSource{}, gcBehavior_Continue, err
 	}
@@ -252,11 +256,11 @@ func (s3p awsTablePersister) ConjoinAll(ctx context.Context, behavior dherrors.F
 
 	rdr := &s3ObjectReader{s3: s3p.s3, bucket: s3p.bucket, readRl: s3p.rl, ns: s3p.ns}
 	if plan.suffix == ArchiveFileSuffix {
-		cs, err := newAWSArchiveChunkSource(ctx, rdr, s3p.limits, plan.name.String()+plan.suffix, plan.chunkCount, s3p.q, stats)
+		cs, err := newAWSArchiveChunkSource(ctx, rdr, s3p.limits, plan.name.String()+plan.suffix, plan.chunkCount, s3p.q, stats, s3p.encryption)
 		return cs, func() {}, err
 	} else {
 		tra := &s3TableReaderAt{rdr, plan.name.String()}
-		cs, err := newReaderFromIndexData(ctx, s3p.q, plan.mergedIndex, plan.name, tra, s3BlockSize)
+		cs, err := newReaderFromIndexData(ctx, s3p.q, plan.mergedIndex, plan.name, tra, s3BlockSize, s3p.encryption)
 		return cs, func() {}, err
 	}
 }
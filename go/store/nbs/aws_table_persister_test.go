@@ -477,9 +477,9 @@ func bytesToChunkSource(t *testing.T, bs ...[]byte) chunkSource {
 	for _, b := range bs {
 		sum += len(b)
 	}
-	maxSize := maxTableSize(uint64(len(bs)), uint64(sum))
+	maxSize := maxTableSize(uint64(len(bs)), uint64(sum), nil)
 	buff := make([]byte, maxSize)
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, nil, nil)
 	for _, b := range bs {
 		tw.addChunk(computeAddr(b), b)
 	}
@@ -488,7 +488,7 @@ func bytesToChunkSource(t *testing.T, bs ...[]byte) chunkSource {
 	data := buff[:tableSize]
 	ti, err := parseTableIndexByCopy(ctx, data, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	rdr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(data), fileBlockSize)
+	rdr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(data), fileBlockSize, nil)
 	require.NoError(t, err)
 	return chunkSourceAdapter{rdr, name}
 }
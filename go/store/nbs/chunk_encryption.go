@@ -0,0 +1,386 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
+
+// Chunk data can be encrypted at rest with envelope encryption. When a store is created with a ChunkEncryption, the
+// compressed data of every chunk record it writes to a table file, journal or archive is sealed with AES-GCM using a
+// random data key. The data key is wrapped with a key encryption key by a KeyWrapper, and the wrapped key is stored in
+// the header of every sealed record, so that records can be copied between stores, e.g. to remotes and backups, and
+// still be read by any store whose ChunkEncryption can unwrap the data key.
+//
+// A sealed record is laid out as:
+//
+//	magic (5 bytes) | version (1 byte) | key ID length (1 byte) | key ID | wrapped key length (2 bytes) | wrapped key | nonce (12 bytes) | ciphertext | tag (16 bytes)
+//
+// The magic bytes decode as a snappy length prefix larger than any snappy block and can't start a zstd frame, so sealed
+// records are told apart from plain ones, which are still read as usual. Chunks are resealed with the current key
+// encryption key when they are rewritten, which happens for every chunk kept by a full garbage collection, so rotating
+// keys is done by changing the current key encryption key, keeping the old one available for reading, and running a
+// full GC.
+
+var sealedChunkMagic = []byte{0xff, 0xff, 0xff, 0xff, 0x7f}
+
+const (
+	sealedChunkVersion = 1
+	sealedNonceSize    = 12
+	sealedTagSize      = 16
+	dataKeySize        = 32
+
+	// maxSealsPerDataKey bounds the number of records sealed with a single data key, since nonces are random.
+	maxSealsPerDataKey = 1 << 31
+)
+
+// ErrChunkEncryptionNotConfigured is returned when reading encrypted chunk data without a ChunkEncryption.
+var ErrChunkEncryptionNotConfigured = errors.New("chunk data is encrypted, but no encryption key is configured")
+
+// KeyWrapper wraps and unwraps the data keys used to encrypt chunk data with a key encryption key. Implementations can
+// delegate to a key management service. LocalKeyWrapper is an implementation holding its keys in memory.
+type KeyWrapper interface {
+	// KeyID returns the ID of the key encryption key used by WrapKey. It is stored with the wrapped key, and passed
+	// back to UnwrapKey.
+	KeyID() string
+	// WrapKey encrypts |dataKey| with the key encryption key identified by KeyID.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts |wrapped|, which was returned by WrapKey of the key encryption key identified by |keyID|.
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyWrapper is a KeyWrapper wrapping data keys with AES-GCM, using 256-bit key encryption keys held in memory.
+// It stands in for a key management service.
+type LocalKeyWrapper struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+var _ KeyWrapper = (*LocalKeyWrapper)(nil)
+
+// NewLocalKeyWrapper returns a LocalKeyWrapper for |keys|. The first key wraps new data keys, the others can only
+// unwrap them, which allows reading data written before a key rotation.
+func NewLocalKeyWrapper(keys ...[]byte) (*LocalKeyWrapper, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one encryption key is required")
+	}
+
+	lkw := &LocalKeyWrapper{keys: make(map[string]cipher.AEAD, len(keys))}
+	for i, key := range keys {
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("invalid encryption key: expected %d bytes, found %d", dataKeySize, len(key))
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(key)
+		id := "local:" + hex.EncodeToString(sum[:8])
+		if i == 0 {
+			lkw.current = id
+		}
+		lkw.keys[id] = aead
+	}
+	return lkw, nil
+}
+
+// ParseLocalKeys parses the keys of a LocalKeyWrapper from |s|, a list of base64 encoded keys separated by commas or
+// whitespace.
+func ParseLocalKeys(s string) ([][]byte, error) {
+	var keys [][]byte
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for _, field := range fields {
+		key, err := base64.StdEncoding.DecodeString(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (lkw *LocalKeyWrapper) KeyID() string {
+	return lkw.current
+}
+
+func (lkw *LocalKeyWrapper) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	aead := lkw.keys[lkw.current]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(dataKey)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, dataKey, []byte(lkw.current)), nil
+}
+
+func (lkw *LocalKeyWrapper) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := lkw.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key '%s'", keyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, errors.New("invalid wrapped data key")
+	}
+	return aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], []byte(keyID))
+}
+
+// ChunkEncryption seals and opens chunk data with data keys wrapped by a KeyWrapper. It is passed to the constructors
+// of stores and of the table readers and writers they use. A nil *ChunkEncryption leaves new chunk data unencrypted.
+type ChunkEncryption struct {
+	wrapper KeyWrapper
+
+	current  atomic.Pointer[dataKey]
+	rotateMu sync.Mutex
+
+	// opened caches the ciphers of the data keys found in sealed records, by record header.
+	opened sync.Map
+}
+
+// dataKey is a data key along with the header of the records it seals.
+type dataKey struct {
+	aead   cipher.AEAD
+	header []byte
+	seals  atomic.Uint64
+}
+
+// NewChunkEncryption returns a ChunkEncryption sealing chunk data with data keys wrapped by |wrapper|.
+func NewChunkEncryption(ctx context.Context, wrapper KeyWrapper) (*ChunkEncryption, error) {
+	dk, err := newDataKey(ctx, wrapper)
+	if err != nil {
+		return nil, err
+	}
+	ce := &ChunkEncryption{wrapper: wrapper}
+	ce.current.Store(dk)
+	return ce, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func newDataKey(ctx context.Context, wrapper KeyWrapper) (*dataKey, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := wrapper.WrapKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	keyID := wrapper.KeyID()
+	if len(keyID) > 255 || len(wrapped) > 65535 {
+		return nil, errors.New("encryption key ID or wrapped data key is too long")
+	}
+	header := make([]byte, 0, len(sealedChunkMagic)+2+len(keyID)+2+len(wrapped))
+	header = append(header, sealedChunkMagic...)
+	header = append(header, sealedChunkVersion, byte(len(keyID)))
+	header = append(header, keyID...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(wrapped)))
+	header = append(header, wrapped...)
+	return &dataKey{aead: aead, header: header}, nil
+}
+
+// dataKey returns the data key to seal a record with, replacing it with a new one once it sealed too many records. If
+// a new data key can't be wrapped, the current one is kept and replacing it is attempted again on the next seal.
+func (ce *ChunkEncryption) dataKey() *dataKey {
+	dk := ce.current.Load()
+	if dk.seals.Add(1) <= maxSealsPerDataKey {
+		return dk
+	}
+
+	ce.rotateMu.Lock()
+	defer ce.rotateMu.Unlock()
+	if cur := ce.current.Load(); cur != dk {
+		return cur
+	}
+	next, err := newDataKey(context.Background(), ce.wrapper)
+	if err != nil {
+		return dk
+	}
+	ce.current.Store(next)
+	return next
+}
+
+// seal encrypts |data| with the current data key.
+func (ce *ChunkEncryption) seal(data []byte) []byte {
+	dk := ce.dataKey()
+	sealed := make([]byte, len(dk.header)+sealedNonceSize, len(dk.header)+sealedNonceSize+len(data)+sealedTagSize)
+	copy(sealed, dk.header)
+	nonce := sealed[len(dk.header):]
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err) // crypto/rand never fails
+	}
+	return dk.aead.Seal(sealed, nonce, data, dk.header)
+}
+
+// open decrypts |sealed|, data returned by seal.
+func (ce *ChunkEncryption) open(sealed []byte) ([]byte, error) {
+	keyID, wrapped, headerLen, err := parseSealedHeader(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < headerLen+sealedNonceSize+sealedTagSize {
+		return nil, errors.New("invalid encrypted chunk data: too short")
+	}
+
+	header := sealed[:headerLen]
+	var aead cipher.AEAD
+	if cached, ok := ce.opened.Load(string(header)); ok {
+		aead = cached.(cipher.AEAD)
+	} else {
+		key, err := ce.wrapper.UnwrapKey(context.Background(), keyID, wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+		}
+		aead, err = newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		ce.opened.Store(string(header), aead)
+	}
+
+	nonce := sealed[headerLen : headerLen+sealedNonceSize]
+	data, err := aead.Open(nil, nonce, sealed[headerLen+sealedNonceSize:], header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt chunk data: %w", err)
+	}
+	return data, nil
+}
+
+// overhead returns the number of bytes seal currently adds to the data it seals.
+func (ce *ChunkEncryption) overhead() int {
+	return len(ce.current.Load().header) + sealedNonceSize + sealedTagSize
+}
+
+func isSealed(data []byte) bool {
+	return bytes.HasPrefix(data, sealedChunkMagic)
+}
+
+// parseSealedHeader returns the key ID and wrapped data key of a sealed record, along with the length of its header.
+func parseSealedHeader(sealed []byte) (keyID string, wrapped []byte, headerLen int, err error) {
+	errInvalid := errors.New("invalid encrypted chunk data header")
+	pos := len(sealedChunkMagic)
+	if len(sealed) < pos+2 {
+		return "", nil, 0, errInvalid
+	}
+	if sealed[pos] != sealedChunkVersion {
+		return "", nil, 0, fmt.Errorf("unsupported encrypted chunk data version %d", sealed[pos])
+	}
+	idLen := int(sealed[pos+1])
+	pos += 2
+	if len(sealed) < pos+idLen+2 {
+		return "", nil, 0, errInvalid
+	}
+	keyID = string(sealed[pos : pos+idLen])
+	pos += idLen
+	wrappedLen := int(binary.BigEndian.Uint16(sealed[pos:]))
+	pos += 2
+	if len(sealed) < pos+wrappedLen {
+		return "", nil, 0, errInvalid
+	}
+	return keyID, sealed[pos : pos+wrappedLen], pos + wrappedLen, nil
+}
+
+// sealData seals |data| if |ce| is not nil, or returns it as is otherwise.
+func (ce *ChunkEncryption) sealData(data []byte) []byte {
+	if ce == nil {
+		return data
+	}
+	return ce.seal(data)
+}
+
+// openData returns the plain form of |data|, which may be sealed.
+func (ce *ChunkEncryption) openData(data []byte) ([]byte, error) {
+	if !isSealed(data) {
+		return data, nil
+	}
+	if ce == nil {
+		return nil, ErrChunkEncryptionNotConfigured
+	}
+	return ce.open(data)
+}
+
+// resealData returns |data| sealed with the current key encryption key. If |ce| is nil, or |data| is already sealed
+// with that key, |data| is returned as is and the returned bool is false.
+func (ce *ChunkEncryption) resealData(data []byte) ([]byte, bool, error) {
+	if ce == nil {
+		return data, false, nil
+	}
+	if isSealed(data) {
+		keyID, _, _, err := parseSealedHeader(data)
+		if err != nil {
+			return nil, false, err
+		}
+		if keyID == ce.wrapper.KeyID() {
+			return data, false, nil
+		}
+		if data, err = ce.open(data); err != nil {
+			return nil, false, err
+		}
+	}
+	return ce.seal(data), true, nil
+}
+
+// sealOverhead returns the number of bytes sealing currently adds to chunk data, which is 0 if |ce| is nil.
+func (ce *ChunkEncryption) sealOverhead() uint64 {
+	if ce == nil {
+		return 0
+	}
+	return uint64(ce.overhead())
+}
+
+// resealCompressedChunk returns |cc| with its data sealed with the current key encryption key, see resealData. The
+// returned chunk is opened with |ce|.
+func (ce *ChunkEncryption) resealCompressedChunk(cc CompressedChunk) (CompressedChunk, error) {
+	if cc.IsGhost() || ce == nil {
+		return cc, nil
+	}
+	data, changed, err := ce.resealData(cc.CompressedData)
+	if err != nil {
+		return cc, err
+	}
+	if !changed {
+		cc.encryption = ce
+		return cc, nil
+	}
+	full := make([]byte, len(data)+checksumSize)
+	copy(full, data)
+	binary.BigEndian.PutUint32(full[len(data):], crc(data))
+	return CompressedChunk{H: cc.H, FullCompressedChunk: full, CompressedData: full[:len(data)], encryption: ce}, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+func newTestEncryptionKey(t *testing.T) []byte {
+	key := make([]byte, dataKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func newTestChunkEncryption(t *testing.T, keys ...[]byte) *ChunkEncryption {
+	wrapper, err := NewLocalKeyWrapper(keys...)
+	require.NoError(t, err)
+	ce, err := NewChunkEncryption(context.Background(), wrapper)
+	require.NoError(t, err)
+	return ce
+}
+
+func TestChunkEncryption(t *testing.T) {
+	oldKey, newKey := newTestEncryptionKey(t), newTestEncryptionKey(t)
+	data := []byte("some compressed chunk data")
+
+	t.Run("disabled", func(t *testing.T) {
+		var ce *ChunkEncryption
+		assert.Equal(t, data, ce.sealData(data))
+		assert.Equal(t, uint64(0), ce.sealOverhead())
+		resealed, changed, err := ce.resealData(data)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, data, resealed)
+	})
+
+	ce := newTestChunkEncryption(t, oldKey)
+	sealed := ce.sealData(data)
+	assert.True(t, isSealed(sealed))
+	assert.False(t, bytes.Contains(sealed, data))
+	assert.Equal(t, len(data)+int(ce.sealOverhead()), len(sealed))
+
+	opened, err := ce.openData(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+
+	opened, err = ce.openData(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened, "plain data is read as is")
+
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = ce.openData(tampered)
+	assert.Error(t, err)
+
+	resealed, changed, err := ce.resealData(sealed)
+	require.NoError(t, err)
+	assert.False(t, changed, "data sealed with the current key isn't resealed")
+	assert.Equal(t, sealed, resealed)
+
+	// rotate the key encryption key, keeping the old one to read existing data
+	ce = newTestChunkEncryption(t, newKey, oldKey)
+	opened, err = ce.openData(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+
+	resealed, changed, err = ce.resealData(sealed)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	keyID, _, _, err := parseSealedHeader(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, ce.wrapper.KeyID(), keyID)
+
+	// once the old key is dropped, only resealed data can be read
+	ce = newTestChunkEncryption(t, newKey)
+	_, err = ce.openData(sealed)
+	assert.Error(t, err)
+	opened, err = ce.openData(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+
+	ce = nil
+	_, err = ce.openData(resealed)
+	assert.ErrorIs(t, err, ErrChunkEncryptionNotConfigured)
+}
+
+func TestNewLocalKeyWrapper(t *testing.T) {
+	_, err := NewLocalKeyWrapper()
+	assert.Error(t, err)
+	_, err = NewLocalKeyWrapper([]byte("too short"))
+	assert.Error(t, err)
+
+	keys, err := ParseLocalKeys("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=,\n  AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=\n")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	wrapper, err := NewLocalKeyWrapper(keys...)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	dataKey := newTestEncryptionKey(t)
+	wrapped, err := wrapper.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	unwrapped, err := wrapper.UnwrapKey(ctx, wrapper.KeyID(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = wrapper.UnwrapKey(ctx, "local:0000000000000000", wrapped)
+	assert.Error(t, err)
+
+	_, err = ParseLocalKeys("not base64!")
+	assert.Error(t, err)
+}
+
+func TestEncryptedTable(t *testing.T) {
+	ce := newTestChunkEncryption(t, newTestEncryptionKey(t))
+	ctx := context.Background()
+
+	chks := [][]byte{
+		[]byte("hello2"),
+		[]byte("goodbye2"),
+		[]byte("badbye2"),
+	}
+
+	totalData := uint64(0)
+	for _, chk := range chks {
+		totalData += uint64(len(chk))
+	}
+	buff := make([]byte, maxTableSize(uint64(len(chks)), totalData, ce))
+	tw := newTableWriter(buff, nil, ce)
+	for _, chk := range chks {
+		require.NoError(t, tw.addChunk(computeAddr(chk), chk))
+	}
+	length, _, err := tw.finish()
+	require.NoError(t, err)
+	tableData := buff[:length]
+	for _, chk := range chks {
+		assert.False(t, bytes.Contains(tableData, chk))
+	}
+
+	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
+	require.NoError(t, err)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, ce)
+	require.NoError(t, err)
+	defer tr.close()
+
+	for _, chk := range chks {
+		assert.Equal(t, string(chk), mustGetString(assert.New(t), ctx, tr, chk))
+	}
+
+	// a reader without the chunk encryption can't open the table's chunks
+	plainTR, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, nil)
+	require.NoError(t, err)
+	defer plainTR.close()
+	_, _, err = plainTR.get(ctx, computeAddr(chks[0]), nil, &Stats{})
+	assert.ErrorIs(t, err, ErrChunkEncryptionNotConfigured)
+
+	cc := ChunkToCompressedChunkWithEncryption(chunks.NewChunk(chks[0]), ce)
+	assert.True(t, isSealed(cc.CompressedData))
+	chk, err := cc.ToChunk()
+	require.NoError(t, err)
+	assert.Equal(t, chks[0], chk.Data())
+}
+
+func TestTableWriterSealedChunkDoesNotFit(t *testing.T) {
+	ce := newTestChunkEncryption(t, newTestEncryptionKey(t))
+	data := []byte("hello")
+
+	// room for the compressed chunk, but not for the sealing overhead
+	buff := make([]byte, snappy.MaxEncodedLen(len(data))+checksumSize)
+	tw := newTableWriter(buff, nil, ce)
+	assert.Error(t, tw.addChunk(computeAddr(data), data))
+}
@@ -83,12 +83,14 @@ type CmpChunkTableWriter struct {
 	prefixes              prefixIndexSlice
 	chunkDataLength       uint64
 	totalUncompressedData uint64
+	encryption            *ChunkEncryption
 }
 
 var _ GenericTableWriter = (*CmpChunkTableWriter)(nil)
 
-// NewCmpChunkTableWriter creates a new CmpChunkTableWriter instance with a default ByteSink
-func NewCmpChunkTableWriter(tempDir string) (*CmpChunkTableWriter, error) {
+// NewCmpChunkTableWriter creates a new CmpChunkTableWriter instance with a default ByteSink. Chunks are encrypted with
+// |ce| if it is not nil, and chunks which are already encrypted are resealed with its current key.
+func NewCmpChunkTableWriter(tempDir string, ce *ChunkEncryption) (*CmpChunkTableWriter, error) {
 	s, err := NewBufferedFileByteSink(tempDir, defaultTableSinkBlockSize, defaultChBufferSize)
 	if err != nil {
 		return nil, err
@@ -101,6 +103,7 @@ func NewCmpChunkTableWriter(tempDir string) (*CmpChunkTableWriter, error) {
 		prefixes:              nil,
 		blockAddr:             nil,
 		path:                  s.path,
+		encryption:            ce,
 	}, nil
 }
 
@@ -144,7 +147,18 @@ func (tw *CmpChunkTableWriter) AddChunk(tc ToChunker) (uint32, error) {
 		}
 	}
 
-	uncmpLen, err := snappy.DecodedLen(c.CompressedData)
+	// Chunks are copied by GC through this writer, which is where encrypted chunks get the current encryption key.
+	c, err := tw.encryption.resealCompressedChunk(c)
+	if err != nil {
+		return 0, err
+	}
+
+	compressed, err := c.encryption.openData(c.CompressedData)
+	if err != nil {
+		return 0, err
+	}
+
+	uncmpLen, err := snappy.DecodedLen(compressed)
 
 	if err != nil {
 		return 0, err
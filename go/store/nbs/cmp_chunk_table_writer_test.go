@@ -32,13 +32,13 @@ func TestCmpChunkTableWriter(t *testing.T) {
 	// Put some chunks in a table file and get the buffer back which contains the table file data
 	ctx := context.Background()
 
-	expectedId, buff, _, err := WriteChunks(testMDChunks)
+	expectedId, buff, _, err := WriteChunks(testMDChunks, nil)
 	require.NoError(t, err)
 
 	// Setup a TableReader to read compressed chunks out of
 	ti, err := parseTableIndexByCopy(ctx, buff, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(buff), fileBlockSize)
+	tr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(buff), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 
@@ -56,7 +56,7 @@ func TestCmpChunkTableWriter(t *testing.T) {
 	require.NoError(t, eg.Wait())
 
 	// for all the chunks we find, write them using the compressed writer
-	tw, err := NewCmpChunkTableWriter("")
+	tw, err := NewCmpChunkTableWriter("", nil)
 	require.NoError(t, err)
 	for _, cmpChnk := range found {
 		_, err = tw.AddChunk(cmpChnk)
@@ -67,7 +67,7 @@ func TestCmpChunkTableWriter(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("ErrDuplicateChunkWritten", func(t *testing.T) {
-		tw, err := NewCmpChunkTableWriter("")
+		tw, err := NewCmpChunkTableWriter("", nil)
 		require.NoError(t, err)
 		for _, cmpChnk := range found {
 			_, err = tw.AddChunk(cmpChnk)
@@ -88,7 +88,7 @@ func TestCmpChunkTableWriter(t *testing.T) {
 	outputBuff := output.Bytes()
 	outputTI, err := parseTableIndexByCopy(ctx, outputBuff, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	outputTR, err := newTableReader(t.Context(), outputTI, tableReaderAtFromBytes(buff), fileBlockSize)
+	outputTR, err := newTableReader(t.Context(), outputTI, tableReaderAtFromBytes(buff), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer outputTR.close()
 
@@ -96,7 +96,7 @@ func TestCmpChunkTableWriter(t *testing.T) {
 }
 
 func TestCmpChunkTableWriterGhostChunk(t *testing.T) {
-	tw, err := NewCmpChunkTableWriter("")
+	tw, err := NewCmpChunkTableWriter("", nil)
 	require.NoError(t, err)
 	_, err = tw.AddChunk(NewGhostCompressedChunk(hash.Parse("6af71afc2ea0hmp4olev0vp9q1q5gvb1")))
 	require.Error(t, err)
@@ -64,7 +64,7 @@ func makeTestSrcs(t *testing.T, tableSizes []uint32, p tableFilePersister, mode
 		if mode == testConjoinModeArchive && i%2 == 0 {
 			// In Archive mode, every other file is an archive.
 			// We have to use CopyTableFile to get these in, instead of Persist().
-			writer, err := NewArchiveStreamWriter(t.TempDir())
+			writer, err := NewArchiveStreamWriter(t.TempDir(), nil)
 			require.NoError(t, err)
 			defer writer.Remove()
 			for i := uint32(0); i < s; i++ {
@@ -44,8 +44,8 @@ import (
 
 const tempTablePrefix = "nbs_table_"
 
-func newFSTablePersister(dir string, q MemoryQuotaProvider, mmapArchiveIndexes bool) tablePersister {
-	return &fsTablePersister{q, nil, make(map[string]struct{}), dir, sync.Mutex{}, mmapArchiveIndexes}
+func newFSTablePersister(dir string, q MemoryQuotaProvider, mmapArchiveIndexes bool, ce *ChunkEncryption) tablePersister {
+	return &fsTablePersister{q, nil, make(map[string]struct{}), dir, sync.Mutex{}, mmapArchiveIndexes, ce}
 }
 
 type fsTablePersister struct {
@@ -65,13 +65,15 @@ type fsTablePersister struct {
 	// Protects the toKeep and curTmps maps.
 	removeMu           sync.Mutex
 	mmapArchiveIndexes bool
+	// encryption seals the chunk data of the tables written, and opens the chunk data of the tables read.
+	encryption *ChunkEncryption
 }
 
 var _ tablePersister = &fsTablePersister{}
 var _ tableFilePersister = &fsTablePersister{}
 
 func (ftp *fsTablePersister) Open(ctx context.Context, name hash.Hash, chunkCount uint32, stats *Stats) (chunkSource, error) {
-	return newFileTableReader(ctx, ftp.dir, name, chunkCount, ftp.q, ftp.mmapArchiveIndexes, stats)
+	return newFileTableReader(ctx, ftp.dir, name, chunkCount, ftp.q, ftp.mmapArchiveIndexes, stats, ftp.encryption)
 }
 
 func (ftp *fsTablePersister) Exists(ctx context.Context, name string, chunkCount uint32, stats *Stats) (bool, error) {
@@ -96,7 +98,7 @@ func (ftp *fsTablePersister) Persist(ctx context.Context, behavior dherrors.Fata
 	t1 := time.Now()
 	defer stats.PersistLatency.SampleTimeSince(t1)
 
-	name, data, _, chunkCount, gcb, err := mt.write(haver, keeper, stats)
+	name, data, _, chunkCount, gcb, err := mt.write(haver, keeper, ftp.encryption, stats)
 	if err != nil {
 		return emptyChunkSource{}, gcBehavior_Continue, err
 	}
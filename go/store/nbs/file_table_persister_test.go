@@ -73,7 +73,7 @@ func TestFSTablePersisterPersist(t *testing.T) {
 	assert := assert.New(t)
 	dir := makeTempDir(t)
 	defer file.RemoveAll(dir)
-	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false)
+	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false, nil)
 
 	src, err := persistTableData(fts, testChunks...)
 	require.NoError(t, err)
@@ -83,7 +83,7 @@ func TestFSTablePersisterPersist(t *testing.T) {
 		require.NoError(t, err)
 		ti, err := parseTableIndexByCopy(ctx, buff, &UnlimitedQuotaProvider{})
 		require.NoError(t, err)
-		tr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(buff), fileBlockSize)
+		tr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(buff), fileBlockSize, nil)
 		require.NoError(t, err)
 		defer tr.close()
 		assertChunksInReader(testChunks, tr, assert)
@@ -113,7 +113,7 @@ func TestFSTablePersisterPersistNoData(t *testing.T) {
 
 	dir := makeTempDir(t)
 	defer file.RemoveAll(dir)
-	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false)
+	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false, nil)
 
 	src, _, err := fts.Persist(context.Background(), dherrors.FatalBehaviorError, mt, existingTable, nil, &Stats{})
 	require.NoError(t, err)
@@ -131,7 +131,7 @@ func TestFSTablePersisterConjoinAll(t *testing.T) {
 
 	dir := makeTempDir(t)
 	defer file.RemoveAll(dir)
-	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false)
+	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false, nil)
 
 	for i, c := range testChunks {
 		randChunk := make([]byte, (i+1)*13)
@@ -157,7 +157,7 @@ func TestFSTablePersisterConjoinAll(t *testing.T) {
 		require.NoError(t, err)
 		ti, err := parseTableIndexByCopy(ctx, buff, &UnlimitedQuotaProvider{})
 		require.NoError(t, err)
-		tr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(buff), fileBlockSize)
+		tr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(buff), fileBlockSize, nil)
 		require.NoError(t, err)
 		defer tr.close()
 		assertChunksInReader(testChunks, tr, assert)
@@ -169,7 +169,7 @@ func TestFSTablePersisterConjoinAllDups(t *testing.T) {
 	assert := assert.New(t)
 	dir := makeTempDir(t)
 	defer file.RemoveAll(dir)
-	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false)
+	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}, false, nil)
 
 	reps := 3
 	sources := make(chunkSources, reps)
@@ -202,7 +202,7 @@ func TestFSTablePersisterConjoinAllDups(t *testing.T) {
 		require.NoError(t, err)
 		ti, err := parseTableIndexByCopy(ctx, buff, &UnlimitedQuotaProvider{})
 		require.NoError(t, err)
-		tr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(buff), fileBlockSize)
+		tr, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(buff), fileBlockSize, nil)
 		require.NoError(t, err)
 		defer tr.close()
 		assertChunksInReader(testChunks, tr, assert)
@@ -80,20 +80,20 @@ func archiveFileExists(ctx context.Context, dir string, name string) (bool, erro
 	return err == nil, err
 }
 
-func newFileTableReader(ctx context.Context, dir string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, mmapArchiveIndexes bool, stats *Stats) (cs chunkSource, err error) {
+func newFileTableReader(ctx context.Context, dir string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, mmapArchiveIndexes bool, stats *Stats, ce *ChunkEncryption) (cs chunkSource, err error) {
 	// we either have a table file or an archive file
 	tfExists, err := tableFileExists(ctx, dir, h)
 	if err != nil {
 		return nil, err
 	} else if tfExists {
-		return nomsFileTableReader(ctx, filepath.Join(dir, h.String()), h, chunkCount, q)
+		return nomsFileTableReader(ctx, filepath.Join(dir, h.String()), h, chunkCount, q, ce)
 	}
 
 	afExists, err := archiveFileExists(ctx, dir, h.String())
 	if err != nil {
 		return nil, err
 	} else if afExists {
-		return newArchiveChunkSource(ctx, dir, h, chunkCount, q, mmapArchiveIndexes, stats)
+		return newArchiveChunkSource(ctx, dir, h, chunkCount, q, mmapArchiveIndexes, stats, ce)
 	}
 	return nil, fmt.Errorf("error opening table file: %w: %s/%s", ErrTableFileNotFound, dir, h.String())
 }
@@ -116,7 +116,7 @@ func newFileReaderAt(path string, mmapArchiveIndexes bool) (*fileReaderAt, error
 	return &fileReaderAt{f, cnt, path, fi.Size(), mmapArchiveIndexes}, nil
 }
 
-func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider) (cs chunkSource, err error) {
+func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCount uint32, q MemoryQuotaProvider, ce *ChunkEncryption) (cs chunkSource, err error) {
 	// noms files never support mmapped indexes
 	fra, err := newFileReaderAt(path, false)
 	if err != nil {
@@ -158,7 +158,7 @@ func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCou
 		return nil, errors.New("unexpected chunk count")
 	}
 
-	tr, err := newTableReader(ctx, index, fra, fileBlockSize)
+	tr, err := newTableReader(ctx, index, fra, fileBlockSize, ce)
 	if err != nil {
 		index.Close()
 		fra.Close()
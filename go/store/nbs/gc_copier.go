@@ -49,14 +49,14 @@ type gcCopier struct {
 	tfp    tableFilePersister
 }
 
-func newGarbageCollectionCopier(cmp chunks.GCArchiveLevel, tfp tableFilePersister) (*gcCopier, error) {
+func newGarbageCollectionCopier(cmp chunks.GCArchiveLevel, tfp tableFilePersister, ce *ChunkEncryption) (*gcCopier, error) {
 	var writer GenericTableWriter
 	var err error
 	switch cmp {
 	case chunks.SimpleArchive:
-		writer, err = NewArchiveStreamWriter("")
+		writer, err = NewArchiveStreamWriter("", ce)
 	case chunks.NoArchive:
-		writer, err = NewCmpChunkTableWriter("")
+		writer, err = NewCmpChunkTableWriter("", ce)
 	default:
 		return nil, fmt.Errorf("invalid archive level: %d", cmp)
 	}
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/sirupsen/logrus"
 
@@ -41,6 +42,11 @@ type GenerationalNBS struct {
 	oldGen   *NomsBlockStore
 	newGen   *NomsBlockStore
 	ghostGen *GhostBlockStore
+
+	sparseMu      sync.Mutex
+	sparseFetcher SparseFetcher
+
+	lazy atomic.Pointer[lazySourceHolder]
 }
 
 var ErrGhostChunkRequested = errors.New("requested chunk which is expected to be a ghost chunk")
@@ -100,12 +106,22 @@ func (gcs *GenerationalNBS) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 	}
 
 	if c.IsEmpty() && gcs.ghostGen != nil {
+		if gcs.ghostGen.sparse.has(ctx, h) {
+			// Left out of a sparse clone, fetch it now. It's no longer sparse once fetched, so this recurses once.
+			if err = gcs.fetchSparse(ctx, hash.NewHashSet(h)); err != nil {
+				return chunks.EmptyChunk, err
+			}
+			return gcs.Get(ctx, h)
+		}
 		c, err = gcs.ghostGen.Get(ctx, h)
 		if err != nil {
 			return chunks.EmptyChunk, err
 		}
 	}
 
+	if c.IsEmpty() && !c.IsGhost() {
+		return gcs.lazyGet(ctx, h)
+	}
 	return c, nil
 }
 
@@ -148,12 +164,18 @@ func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, fo
 		return nil
 	}
 
-	// Last ditch effort to see if the requested objects are commits we've decided to ignore. Note the function spec
-	// considers non-present chunks to be silently ignored, so we don't need to return an error here
-	if gcs.ghostGen == nil {
-		return nil
+	// Last ditch effort to see if the requested objects are commits we've decided to ignore, or are read lazily. Note
+	// the function spec considers non-present chunks to be silently ignored, so we don't need to return an error here
+	if gcs.ghostGen != nil {
+		if sparse := gcs.ghostGen.sparse.filter(ctx, notFound); len(sparse) > 0 {
+			// Some were left out of a sparse clone, fetch them and try again.
+			if err = gcs.fetchSparse(ctx, sparse); err != nil {
+				return err
+			}
+			return gcs.GetMany(ctx, notFound, found)
+		}
 	}
-	return gcs.ghostGen.GetMany(ctx, notFound, found)
+	return gcs.getManyGhostOrLazy(ctx, notFound, found)
 }
 
 func (gcs *GenerationalNBS) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, ToChunker)) error {
@@ -192,7 +214,38 @@ func (gcs *GenerationalNBS) getManyCompressed(ctx context.Context, hashes hash.H
 
 	// The missing chunks may be ghost chunks.
 	if gcs.ghostGen != nil {
-		return gcs.ghostGen.getManyCompressed(ctx, notFound, found, gcDepMode)
+		// Chunks left out of a sparse clone are fetched, so that they can be pushed. GC doesn't take dependencies, and
+		// leaves them alone like ghost chunks.
+		if gcDepMode == gcDependencyMode_TakeDependency {
+			if sparse := gcs.ghostGen.sparse.filter(ctx, notFound); len(sparse) > 0 {
+				if err = gcs.fetchSparse(ctx, sparse); err != nil {
+					return err
+				}
+				return gcs.getManyCompressed(ctx, notFound, found, gcDepMode)
+			}
+		}
+		err = gcs.ghostGen.getManyCompressed(ctx, notFound, func(ctx context.Context, chunk ToChunker) {
+			mu.Lock()
+			delete(notFound, chunk.Hash())
+			mu.Unlock()
+			found(ctx, chunk)
+		}, gcDepMode)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The chunks of a lazy source are treated like ghost chunks, so that GC leaves them alone. They are not fetched.
+	if len(notFound) > 0 && gcs.lazySource() != nil {
+		absent, err := gcs.lazyHasMany(ctx, notFound)
+		if err != nil {
+			return err
+		}
+		for h := range notFound {
+			if !absent.Has(h) {
+				found(ctx, NewGhostCompressedChunk(h))
+			}
+		}
 	}
 	return nil
 }
@@ -212,11 +265,16 @@ func (gcs *GenerationalNBS) Has(ctx context.Context, h hash.Hash) (bool, error)
 	// Possibly a truncated commit.
 	if gcs.ghostGen != nil {
 		has, err = gcs.ghostGen.Has(ctx, h)
-		if err != nil {
+		if err != nil || has {
 			return has, err
 		}
 	}
-	return has, nil
+
+	absent, err := gcs.lazyHasMany(ctx, hash.NewHashSet(h))
+	if err != nil {
+		return false, err
+	}
+	return !absent.Has(h), nil
 }
 
 // HasMany returns a new HashSet containing any members of |hashes| that are absent from the store.
@@ -233,16 +291,27 @@ func (gcs *GenerationalNBS) HasMany(ctx context.Context, hashes hash.HashSet) (h
 	if err != nil {
 		return nil, err
 	}
-	if len(absent) == 0 || gcs.ghostGen == nil {
+	if len(absent) == 0 {
 		return nil, err
 	}
 
-	return gcs.ghostGen.HasMany(ctx, absent)
+	if gcs.ghostGen != nil {
+		absent, err = gcs.ghostGen.HasMany(ctx, absent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return gcs.lazyHasMany(ctx, absent)
 }
 
 // |refCheck| is called from write processes in newGen, so it is called with
-// newGen.mu held. oldGen.mu is not held however.
-func (gcs *GenerationalNBS) refCheck(recs []hasRecord) (hash.HashSet, error) {
+// newGen.mu held. oldGen.mu is not held however. The lazy source, which may be
+// a remote, isn't queried with newGen.mu held: references found in no
+// generation are looked up in |lazyRefs|, which maps the references already
+// checked against the lazy source to whether it has them, and any reference
+// missing from it is returned in a *lazyRefsUncheckedError. withLazyRefs
+// checks those once newGen.mu is released, and retries the write.
+func (gcs *GenerationalNBS) refCheck(recs []hasRecord, lazyRefs map[hash.Hash]bool) (hash.HashSet, error) {
 	absent, err := gcs.newGen.refCheck(recs)
 	if err != nil {
 		return nil, err
@@ -258,11 +327,38 @@ func (gcs *GenerationalNBS) refCheck(recs []hasRecord) (hash.HashSet, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(absent) == 0 || gcs.ghostGen == nil {
+	if len(absent) == 0 {
+		return absent, nil
+	}
+
+	if gcs.ghostGen != nil {
+		absent, err = gcs.ghostGen.refCheck(recs)
+		if err != nil || len(absent) == 0 {
+			return absent, err
+		}
+	}
+	if gcs.lazySource() == nil {
 		return absent, nil
 	}
 
-	return gcs.ghostGen.refCheck(recs)
+	unchecked := hash.NewHashSet()
+	lazyAbsent := hash.NewHashSet()
+	for h := range absent {
+		if present, ok := lazyRefs[h]; !ok {
+			unchecked.Insert(h)
+		} else if !present {
+			lazyAbsent.Insert(h)
+		}
+	}
+	if len(unchecked) > 0 {
+		return nil, &lazyRefsUncheckedError{refs: unchecked}
+	}
+	for i := range recs {
+		if !recs[i].has && !lazyAbsent.Has(*recs[i].a) {
+			recs[i].has = true
+		}
+	}
+	return lazyAbsent, nil
 }
 
 // Put caches c in the ChunkSource. Upon return, c must be visible to
@@ -270,7 +366,15 @@ func (gcs *GenerationalNBS) refCheck(recs []hasRecord) (hash.HashSet, error) {
 // to Flush(). Put may be called concurrently with other calls to Put(),
 // Get(), GetMany(), Has() and HasMany().
 func (gcs *GenerationalNBS) Put(ctx context.Context, c chunks.Chunk, getAddrs chunks.GetAddrsCurry) error {
-	return gcs.newGen.putChunk(ctx, c, getAddrs, gcs.refCheck)
+	return gcs.withLazyRefs(ctx, func(checker refCheck) error {
+		return gcs.newGen.putChunk(ctx, c, getAddrs, checker)
+	})
+}
+
+// ChunkEncryption implements EncryptingChunkStore. Chunks are written to the new generation, so it returns its
+// ChunkEncryption.
+func (gcs *GenerationalNBS) ChunkEncryption() *ChunkEncryption {
+	return gcs.newGen.ChunkEncryption()
 }
 
 // Returns the NomsBinFormat with which this ChunkSource is compatible.
@@ -312,7 +416,12 @@ func (gcs *GenerationalNBS) Root(ctx context.Context) (hash.Hash, error) {
 // persisted root hash from last to current (or keeps it the same).
 // If last doesn't match the root in persistent storage, returns false.
 func (gcs *GenerationalNBS) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
-	return gcs.newGen.commit(ctx, current, last, gcs.refCheck)
+	var success bool
+	err := gcs.withLazyRefs(ctx, func(checker refCheck) (err error) {
+		success, err = gcs.newGen.commit(ctx, current, last, checker)
+		return err
+	})
+	return success, err
 }
 
 // Stats may return some kind of struct that reports statistics about the
@@ -430,7 +539,9 @@ func (gcs *GenerationalNBS) WriteTableFile(ctx context.Context, fileId string, s
 
 // AddTableFilesToManifest adds table files to the manifest of the newgen cs
 func (gcs *GenerationalNBS) AddTableFilesToManifest(ctx context.Context, fileIdToNumChunks map[string]int, getAddrs chunks.GetAddrsCurry) error {
-	return gcs.newGen.addTableFilesToManifest(ctx, fileIdToNumChunks, getAddrs, gcs.refCheck)
+	return gcs.withLazyRefs(ctx, func(checker refCheck) error {
+		return gcs.newGen.addTableFilesToManifest(ctx, fileIdToNumChunks, getAddrs, checker)
+	})
 }
 
 // PruneTableFiles deletes old table files that are no longer referenced in the manifest of the new or old gen chunkstores
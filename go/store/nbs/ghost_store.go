@@ -31,6 +31,8 @@ import (
 type GhostBlockStore struct {
 	skippedRefs      *hash.HashSet
 	ghostObjectsFile string
+	// sparse holds the chunks left out of a sparse clone. Like ghost chunks, they are reported as present.
+	sparse *sparseObjects
 }
 
 // We use the Has, HasMany, Get, GetMany, GetManyCompressed, and PersistGhostHashes methods from the ChunkStore interface. All other methods are not supported.
@@ -42,6 +44,11 @@ var _ NBSCompressedChunkStore = (*GenerationalNBS)(nil)
 // and use of this file is constrained to this instance. If there is no ghostObjects.txt file, then the GhostBlockStore will
 // be empty - never returning any values from the Has, HasMany, Get, or GetMany methods.
 func NewGhostBlockStore(nomsPath string) (*GhostBlockStore, error) {
+	sparse, err := loadSparseObjects(filepath.Join(nomsPath, sparseObjectsFileName))
+	if err != nil {
+		return nil, err
+	}
+
 	ghostPath := filepath.Join(nomsPath, "ghostObjects.txt")
 	f, err := os.Open(ghostPath)
 	if err != nil {
@@ -49,6 +56,7 @@ func NewGhostBlockStore(nomsPath string) (*GhostBlockStore, error) {
 			return &GhostBlockStore{
 				skippedRefs:      &hash.HashSet{},
 				ghostObjectsFile: ghostPath,
+				sparse:           sparse,
 			}, nil
 		}
 		// Other error, permission denied, etc, we want to hear about.
@@ -69,13 +77,19 @@ func NewGhostBlockStore(nomsPath string) (*GhostBlockStore, error) {
 	return &GhostBlockStore{
 		skippedRefs:      skiplist,
 		ghostObjectsFile: ghostPath,
+		sparse:           sparse,
 	}, nil
 }
 
+// has returns whether |h| is a ghost chunk or a chunk left out of a sparse clone.
+func (g GhostBlockStore) has(ctx context.Context, h hash.Hash) bool {
+	return g.skippedRefs.Has(h) || g.sparse.has(ctx, h)
+}
+
 // Get returns a ghost chunk if the hash is in the ghostObjectsFile. Otherwise, it returns an empty chunk. Chunks returned
 // by this code will always be ghost chunks, ie chunk.IsGhost() will always return true.
 func (g GhostBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
-	if g.skippedRefs.Has(h) {
+	if g.has(ctx, h) {
 		return *chunks.NewGhostChunk(h), nil
 	}
 	return chunks.EmptyChunk, nil
@@ -83,7 +97,7 @@ func (g GhostBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, er
 
 func (g GhostBlockStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
 	for h := range hashes {
-		if g.skippedRefs.Has(h) {
+		if g.has(ctx, h) {
 			found(ctx, chunks.NewGhostChunk(h))
 		}
 	}
@@ -96,7 +110,7 @@ func (g GhostBlockStore) GetManyCompressed(ctx context.Context, hashes hash.Hash
 
 func (g GhostBlockStore) getManyCompressed(ctx context.Context, hashes hash.HashSet, found func(context.Context, ToChunker), gcDepMode gcDependencyMode) error {
 	for h := range hashes {
-		if g.skippedRefs.Has(h) {
+		if g.has(ctx, h) {
 			found(ctx, NewGhostCompressedChunk(h))
 		}
 	}
@@ -129,31 +143,34 @@ func (g *GhostBlockStore) PersistGhostHashes(ctx context.Context, hashes hash.Ha
 }
 
 func (g GhostBlockStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
-	if g.skippedRefs.Has(h) {
+	if g.has(ctx, h) {
 		return true, nil
 	}
 	return false, nil
 }
 
 func (g GhostBlockStore) HasMany(ctx context.Context, hashes hash.HashSet) (absent hash.HashSet, err error) {
-	return g.hasMany(hashes)
+	return g.hasMany(ctx, hashes)
 }
 
-func (g GhostBlockStore) hasMany(hashes hash.HashSet) (absent hash.HashSet, err error) {
+func (g GhostBlockStore) hasMany(ctx context.Context, hashes hash.HashSet) (absent hash.HashSet, err error) {
 	absent = hash.HashSet{}
 	for h := range hashes {
-		if !g.skippedRefs.Has(h) {
+		if !g.has(ctx, h) {
 			absent.Insert(h)
 		}
 	}
 	return absent, nil
 }
 
+// refCheck is called from write processes, which have no context. The chunks of a sparse clone which are being fetched
+// are reported as present to it.
 func (g GhostBlockStore) refCheck(recs []hasRecord) (hash.HashSet, error) {
+	ctx := context.Background()
 	absent := hash.HashSet{}
 	for i := range recs {
 		if !recs[i].has {
-			if g.skippedRefs.Has(*recs[i].a) {
+			if g.has(ctx, *recs[i].a) {
 				recs[i].has = true
 			} else {
 				absent.Insert(*recs[i].a)
@@ -155,7 +155,7 @@ func (j *ChunkJournal) bootstrapJournalWriter(ctx context.Context, warningsCb fu
 	canCreate := !j.backing.readOnly()
 
 	if canCreate && !ok { // create new journal file
-		j.wr, err = createJournalWriter(ctx, j.path)
+		j.wr, err = createJournalWriter(ctx, j.path, j.persister.encryption)
 		if err != nil {
 			return err
 		}
@@ -182,7 +182,7 @@ func (j *ChunkJournal) bootstrapJournalWriter(ctx context.Context, warningsCb fu
 		return
 	}
 
-	j.wr, ok, err = openJournalWriter(ctx, j.path)
+	j.wr, ok, err = openJournalWriter(ctx, j.path, j.persister.encryption)
 	if err != nil {
 		return err
 	} else if !ok {
@@ -259,6 +259,27 @@ func (j *ChunkJournal) IterateRoots(f func(root string, timestamp *time.Time) er
 	})
 }
 
+// TruncateRoots removes every root tracked by the ChunkJournal, except for the current one, so that they are no longer
+// visited by IterateRoots, including after the journal is bootstrapped again.
+func (j *ChunkJournal) TruncateRoots(ctx context.Context, behavior dherrors.FatalBehavior) error {
+	if j.wr != nil {
+		if err := j.wr.indexCurrentRoot(ctx, behavior); err != nil {
+			return err
+		}
+	}
+	if reflogDisabled {
+		return nil
+	}
+	j.reflogRingBuffer.Truncate()
+	if !j.contents.root.IsEmpty() {
+		j.reflogRingBuffer.Push(reflogRootHashEntry{
+			root:      j.contents.root.String(),
+			timestamp: time.Now(),
+		})
+	}
+	return nil
+}
+
 // Persist implements tablePersister.
 func (j *ChunkJournal) Persist(ctx context.Context, behavior dherrors.FatalBehavior, mt *memTable, haver chunkReader, keeper keeperF, stats *Stats) (chunkSource, gcBehavior, error) {
 	if j.backing.readOnly() {
@@ -282,7 +303,7 @@ func (j *ChunkJournal) Persist(ctx context.Context, behavior dherrors.FatalBehav
 			continue
 		}
 		c := chunks.NewChunkWithHash(*record.a, mt.chunks[*record.a])
-		err := j.wr.writeCompressedChunk(ctx, behavior, ChunkToCompressedChunk(c))
+		err := j.wr.writeCompressedChunk(ctx, behavior, ChunkToCompressedChunkWithEncryption(c, j.persister.encryption))
 		if err != nil {
 			return nil, gcBehavior_Continue, err
 		}
@@ -39,7 +39,7 @@ func makeTestChunkJournal(t *testing.T) *ChunkJournal {
 	m, err := newJournalManifest(ctx, dir, false)
 	require.NoError(t, err)
 	q := NewUnlimitedMemQuotaProvider()
-	p := newFSTablePersister(dir, q, false)
+	p := newFSTablePersister(dir, q, false, nil)
 	nbf := types.Format_Default.VersionString()
 	j, err := newChunkJournal(ctx, nbf, dir, m, p.(*fsTablePersister), nil)
 	require.NoError(t, err)
@@ -51,7 +51,7 @@ func openTestChunkJournal(t *testing.T, dir string) *ChunkJournal {
 	m, err := newJournalManifest(t.Context(), dir, false)
 	require.NoError(t, err)
 	q := NewUnlimitedMemQuotaProvider()
-	p := newFSTablePersister(dir, q, false)
+	p := newFSTablePersister(dir, q, false, nil)
 	nbf := types.Format_Default.VersionString()
 	j, err := newChunkJournal(t.Context(), nbf, dir, m, p.(*fsTablePersister), nil)
 	require.NoError(t, err)
@@ -78,7 +78,7 @@ func fileExists(path string) (bool, error) {
 	return true, nil
 }
 
-func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exists bool, err error) {
+func openJournalWriter(ctx context.Context, path string, ce *ChunkEncryption) (wr *journalWriter, exists bool, err error) {
 	var f *os.File
 	if path, err = filepath.Abs(path); err != nil {
 		return nil, false, err
@@ -97,13 +97,14 @@ func openJournalWriter(ctx context.Context, path string) (wr *journalWriter, exi
 	}
 
 	return &journalWriter{
-		buf:     make([]byte, 0, journalWriterBuffSize),
-		journal: f,
-		path:    path,
+		buf:        make([]byte, 0, journalWriterBuffSize),
+		journal:    f,
+		path:       path,
+		encryption: ce,
 	}, true, nil
 }
 
-func createJournalWriter(ctx context.Context, path string) (wr *journalWriter, err error) {
+func createJournalWriter(ctx context.Context, path string, ce *ChunkEncryption) (wr *journalWriter, err error) {
 	var f *os.File
 	if path, err = filepath.Abs(path); err != nil {
 		return nil, err
@@ -121,9 +122,10 @@ func createJournalWriter(ctx context.Context, path string) (wr *journalWriter, e
 	}
 
 	return &journalWriter{
-		buf:     make([]byte, 0, journalWriterBuffSize),
-		journal: f,
-		path:    path,
+		buf:        make([]byte, 0, journalWriterBuffSize),
+		journal:    f,
+		path:       path,
+		encryption: ce,
 	}, nil
 }
 
@@ -151,6 +153,8 @@ type journalWriter struct {
 	lock        sync.RWMutex
 	batchCrc    uint32
 	currentRoot hash.Hash
+	// encryption opens the chunk records of the journal when they are encrypted.
+	encryption *ChunkEncryption
 }
 
 var _ io.Closer = &journalWriter{}
@@ -367,7 +371,7 @@ func (wr *journalWriter) getCompressedChunk(h hash.Hash) (CompressedChunk, error
 	if _, err := wr.readAt(buf, int64(r.Offset)); err != nil {
 		return CompressedChunk{}, err
 	}
-	return NewCompressedChunk(hash.Hash(h), buf)
+	return NewCompressedChunkWithEncryption(hash.Hash(h), buf, wr.encryption)
 }
 
 // getCompressedChunk reads the CompressedChunks with addr |h|.
@@ -376,7 +380,7 @@ func (wr *journalWriter) getCompressedChunkAtRange(r Range, h hash.Hash) (Compre
 	if _, err := wr.readAt(buf, int64(r.Offset)); err != nil {
 		return CompressedChunk{}, err
 	}
-	return NewCompressedChunk(hash.Hash(h), buf)
+	return NewCompressedChunkWithEncryption(hash.Hash(h), buf, wr.encryption)
 }
 
 // getRange returns a Range for the chunk with addr |h|.
@@ -481,6 +485,33 @@ func (wr *journalWriter) commitRootHashUnlocked(ctx context.Context, behavior dh
 	return nil
 }
 
+// indexCurrentRoot commits the current root hash to the journal again, and indexes the journal up to that record, so
+// that bootstrapping the journal no longer replays the root hash records written before it.
+func (wr *journalWriter) indexCurrentRoot(ctx context.Context, behavior dherrors.FatalBehavior) error {
+	wr.lock.Lock()
+	defer wr.lock.Unlock()
+
+	if wr.currentRoot.IsEmpty() {
+		return nil
+	}
+	if err := wr.commitRootHashUnlocked(ctx, behavior, wr.currentRoot); err != nil {
+		return err
+	}
+	o := wr.offset() - int64(rootHashRecordSize()) // pre-commit journal offset
+	if wr.indexed < o {
+		if err := wr.flushIndexRecord(ctx, wr.currentRoot, o); err != nil {
+			return err
+		}
+	}
+	if err := wr.indexWriter.Flush(); err != nil {
+		return err
+	}
+	if err := wr.index.Sync(); err != nil {
+		return dherrors.Fatalf(behavior, "%w: error syncing journal index", err)
+	}
+	return nil
+}
+
 // flushIndexRecord writes metadata for a range of index lookups to the
 // out-of-band journal index file. Index records accelerate journal
 // bootstrapping by reducing the amount of the journal that must be processed.
@@ -182,7 +182,7 @@ func TestJournalWriterReadWrite(t *testing.T) {
 
 func newTestJournalWriter(t *testing.T, path string) *journalWriter {
 	ctx := context.Background()
-	j, err := createJournalWriter(ctx, path)
+	j, err := createJournalWriter(ctx, path, nil)
 	require.NoError(t, err)
 	require.NotNil(t, j)
 	_, err = j.bootstrapJournal(ctx, true, nil, nil)
@@ -217,7 +217,7 @@ func TestJournalWriterBootstrap(t *testing.T) {
 	require.NoError(t, j.commitRootHash(context.Background(), dherrors.FatalBehaviorError, last))
 	require.NoError(t, j.Close())
 
-	j, _, err := openJournalWriter(ctx, path)
+	j, _, err := openJournalWriter(ctx, path, nil)
 	require.NoError(t, err)
 	reflogBuffer := newReflogRingBuffer(10)
 	last, err = j.bootstrapJournal(ctx, true, reflogBuffer, nil)
@@ -236,6 +236,38 @@ func TestJournalWriterBootstrap(t *testing.T) {
 	}
 }
 
+func TestJournalWriterIndexCurrentRoot(t *testing.T) {
+	ctx := context.Background()
+	path := newTestFilePath(t)
+	j := newTestJournalWriter(t, path)
+	data := randomCompressedChunks(8)
+	var roots []string
+	for a, cc := range data {
+		require.NoError(t, j.writeCompressedChunk(ctx, dherrors.FatalBehaviorError, cc))
+		require.NoError(t, j.commitRootHash(ctx, dherrors.FatalBehaviorError, a))
+		roots = append(roots, a.String())
+	}
+
+	// the root hash records before the current root are no longer replayed
+	require.NoError(t, j.indexCurrentRoot(ctx, dherrors.FatalBehaviorError))
+	for a, cc := range randomCompressedChunks(1) {
+		data[a] = cc
+		require.NoError(t, j.writeCompressedChunk(ctx, dherrors.FatalBehaviorError, cc))
+		require.NoError(t, j.commitRootHash(ctx, dherrors.FatalBehaviorError, a))
+		roots = append(roots, a.String())
+	}
+	require.NoError(t, j.Close())
+
+	j, _, err := openJournalWriter(ctx, path, nil)
+	require.NoError(t, err)
+	reflogBuffer := newReflogRingBuffer(10)
+	last, err := j.bootstrapJournal(ctx, true, reflogBuffer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, roots[len(roots)-1], last.String())
+	assertExpectedIterationOrder(t, reflogBuffer, roots[len(roots)-2:])
+	validateAllLookups(t, j, data)
+}
+
 func validateAllLookups(t *testing.T, j *journalWriter, data map[hash.Hash]CompressedChunk) {
 	// move |data| to addr16-keyed map
 	prefixMap := make(map[addr16]CompressedChunk, len(data))
@@ -359,7 +391,7 @@ func TestJournalIndexBootstrap(t *testing.T) {
 			require.NoError(t, err)
 
 			validateJournal := func(p string, expected []epoch) {
-				journal, ok, err := openJournalWriter(ctx, p)
+				journal, ok, err := openJournalWriter(ctx, p, nil)
 				require.NoError(t, err)
 				require.True(t, ok)
 				// bootstrap journal and validate chunk records
@@ -395,7 +427,7 @@ func TestJournalIndexBootstrap(t *testing.T) {
 
 			// bootstrap journal with corrupted index
 			corruptJournalIndex(t, idxPath)
-			jnl, ok, err := openJournalWriter(ctx, idxPath)
+			jnl, ok, err := openJournalWriter(ctx, idxPath, nil)
 			require.NoError(t, err)
 			require.True(t, ok)
 			_, err = jnl.bootstrapJournal(ctx, true, nil, nil)
@@ -0,0 +1,153 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// LazySource is a store, such as the remote a database was cloned or replicated from, which a GenerationalNBS reads
+// the chunks it doesn't have from. The chunks read from a LazySource are not added to the GenerationalNBS, and the
+// chunks it has are reported as present by Has and HasMany, so that pulling them from the same remote is a no-op.
+type LazySource interface {
+	// GetMany gets the chunks with |hashes| from the source. Chunks which aren't present are ignored.
+	GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error
+	// HasMany returns the members of |hashes| which are absent from the source.
+	HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error)
+}
+
+// LazyChunkStore is a ChunkStore which can read the chunks it doesn't have from a LazySource.
+type LazyChunkStore interface {
+	// SetLazySource sets the source of the chunks missing from the store. A nil |src| disables lazy reads.
+	SetLazySource(src LazySource)
+}
+
+var _ LazyChunkStore = (*GenerationalNBS)(nil)
+
+// lazySourceHolder lets a LazySource be stored in an atomic.Pointer.
+type lazySourceHolder struct {
+	src LazySource
+}
+
+func (gcs *GenerationalNBS) SetLazySource(src LazySource) {
+	if src == nil {
+		gcs.lazy.Store(nil)
+		return
+	}
+	gcs.lazy.Store(&lazySourceHolder{src: src})
+}
+
+func (gcs *GenerationalNBS) lazySource() LazySource {
+	if h := gcs.lazy.Load(); h != nil {
+		return h.src
+	}
+	return nil
+}
+
+// lazyGet gets |h| from the lazy source. It returns an empty chunk if there is no lazy source or it doesn't have |h|.
+func (gcs *GenerationalNBS) lazyGet(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	src := gcs.lazySource()
+	if src == nil {
+		return chunks.EmptyChunk, nil
+	}
+	c := chunks.EmptyChunk
+	err := src.GetMany(ctx, hash.NewHashSet(h), func(_ context.Context, found *chunks.Chunk) {
+		c = *found
+	})
+	if err != nil {
+		return chunks.EmptyChunk, err
+	}
+	return c, nil
+}
+
+// lazyHasMany returns the members of |absent| which are absent from the lazy source as well.
+func (gcs *GenerationalNBS) lazyHasMany(ctx context.Context, absent hash.HashSet) (hash.HashSet, error) {
+	src := gcs.lazySource()
+	if src == nil || len(absent) == 0 {
+		return absent, nil
+	}
+	return src.HasMany(ctx, absent)
+}
+
+// lazyRefsUncheckedError is returned by GenerationalNBS.refCheck for the references of the chunks being written which
+// are in no generation, and haven't been checked against the lazy source yet.
+type lazyRefsUncheckedError struct {
+	refs hash.HashSet
+}
+
+func (e *lazyRefsUncheckedError) Error() string {
+	return fmt.Sprintf("%d references must be checked against the lazy source", len(e.refs))
+}
+
+// withLazyRefs calls |write|, a write to newGen which checks the references of the chunks it writes with |checker|.
+// When the checker needs to know whether the lazy source has some references, |write| fails before writing anything,
+// the lazy source is queried without any lock held, and |write| is retried with the answers. References which the
+// lazy source doesn't have either are reported as dangling by the retry.
+func (gcs *GenerationalNBS) withLazyRefs(ctx context.Context, write func(checker refCheck) error) error {
+	lazyRefs := make(map[hash.Hash]bool)
+	checker := func(recs []hasRecord) (hash.HashSet, error) {
+		return gcs.refCheck(recs, lazyRefs)
+	}
+	for {
+		err := write(checker)
+		var unchecked *lazyRefsUncheckedError
+		if !errors.As(err, &unchecked) {
+			return err
+		}
+		absent, err := gcs.lazyHasMany(ctx, unchecked.refs)
+		if err != nil {
+			return err
+		}
+		for h := range unchecked.refs {
+			lazyRefs[h] = !absent.Has(h)
+		}
+	}
+}
+
+// getManyGhostOrLazy gets |hashes|, which are in neither generation, as ghost chunks or from the lazy source.
+func (gcs *GenerationalNBS) getManyGhostOrLazy(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	src := gcs.lazySource()
+	if src == nil {
+		if gcs.ghostGen == nil {
+			return nil
+		}
+		return gcs.ghostGen.GetMany(ctx, hashes, found)
+	}
+
+	notGhost := hashes
+	if gcs.ghostGen != nil {
+		var mu sync.Mutex
+		notGhost = hashes.Copy()
+		err := gcs.ghostGen.GetMany(ctx, hashes, func(ctx context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			delete(notGhost, c.Hash())
+			mu.Unlock()
+			found(ctx, c)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(notGhost) == 0 {
+		return nil
+	}
+	return src.GetMany(ctx, notGhost, found)
+}
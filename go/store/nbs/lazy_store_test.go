@@ -0,0 +1,186 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+type mapLazySource struct {
+	chunks map[hash.Hash]chunks.Chunk
+	gets   int
+}
+
+func (s *mapLazySource) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
+	for h := range hashes {
+		if c, ok := s.chunks[h]; ok {
+			s.gets++
+			found(ctx, &c)
+		}
+	}
+	return nil
+}
+
+func (s *mapLazySource) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	absent := hash.NewHashSet()
+	for h := range hashes {
+		if _, ok := s.chunks[h]; !ok {
+			absent.Insert(h)
+		}
+	}
+	return absent, nil
+}
+
+func TestGenerationalCSLazySource(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	ghostGen, err := NewGhostBlockStore(t.TempDir())
+	require.NoError(t, err)
+	cs := NewGenerationalCS(oldGen, newGen, ghostGen)
+
+	chnks := genChunks(t, 4, 1000)
+	require.NoError(t, cs.Put(ctx, chnks[0], noopGetAddrs))
+	src := &mapLazySource{chunks: map[hash.Hash]chunks.Chunk{
+		chnks[1].Hash(): chnks[1],
+		chnks[2].Hash(): chnks[2],
+	}}
+
+	has, err := cs.Has(ctx, chnks[1].Hash())
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	cs.SetLazySource(src)
+	has, err = cs.Has(ctx, chnks[1].Hash())
+	require.NoError(t, err)
+	assert.True(t, has)
+	absent, err := cs.HasMany(ctx, hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash(), chnks[3].Hash()))
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(chnks[3].Hash()), absent)
+
+	c, err := cs.Get(ctx, chnks[1].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, chnks[1].Data(), c.Data())
+	c, err = cs.Get(ctx, chnks[3].Hash())
+	require.NoError(t, err)
+	assert.True(t, c.IsEmpty())
+
+	found := foundHashes{}
+	require.NoError(t, cs.GetMany(ctx, hash.NewHashSet(chnks[0].Hash(), chnks[2].Hash(), chnks[3].Hash()), found.found))
+	assert.Equal(t, hash.NewHashSet(chnks[0].Hash(), chnks[2].Hash()), hash.HashSet(found))
+	// Chunks read from the lazy source aren't added to the store.
+	assert.Equal(t, 2, src.gets)
+	c, err = cs.Get(ctx, chnks[2].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, chnks[2].Data(), c.Data())
+	assert.Equal(t, 3, src.gets)
+
+	// Chunks of the lazy source are ghosts to GC and are never fetched by it.
+	var mu sync.Mutex
+	ghosts := hash.NewHashSet()
+	err = cs.GetManyCompressed(ctx, hash.NewHashSet(chnks[1].Hash(), chnks[3].Hash()), func(ctx context.Context, tc ToChunker) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.True(t, tc.IsGhost())
+		ghosts.Insert(tc.Hash())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(chnks[1].Hash()), ghosts)
+	assert.Equal(t, 3, src.gets)
+
+	// Chunks which reference the lazy source's chunks can be written.
+	parent := chunks.NewChunk([]byte("parent"))
+	require.NoError(t, cs.Put(ctx, parent, func(chunks.Chunk) chunks.GetAddrsCb {
+		return func(ctx context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+			addrs.Insert(chnks[1].Hash())
+			return nil
+		}
+	}))
+	root, err := cs.Root(ctx)
+	require.NoError(t, err)
+	ok, err := cs.Commit(ctx, parent.Hash(), root)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	cs.SetLazySource(nil)
+	c, err = cs.Get(ctx, chnks[1].Hash())
+	require.NoError(t, err)
+	assert.True(t, c.IsEmpty())
+}
+
+// lockCheckingLazySource records whether newGen.mu of |cs| was held when the lazy source was queried.
+type lockCheckingLazySource struct {
+	mapLazySource
+	cs         *GenerationalNBS
+	queries    int
+	lockedWhen int
+}
+
+func (s *lockCheckingLazySource) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	s.queries++
+	if s.cs.newGen.mu.TryLock() {
+		s.cs.newGen.mu.Unlock()
+	} else {
+		s.lockedWhen++
+	}
+	return s.mapLazySource.HasMany(ctx, hashes)
+}
+
+func TestGenerationalCSLazyRefCheck(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	cs := NewGenerationalCS(oldGen, newGen, nil)
+
+	chnks := genChunks(t, 2, 1000)
+	src := &lockCheckingLazySource{
+		mapLazySource: mapLazySource{chunks: map[hash.Hash]chunks.Chunk{chnks[0].Hash(): chnks[0]}},
+		cs:            cs,
+	}
+	cs.SetLazySource(src)
+	refersTo := func(h hash.Hash) chunks.GetAddrsCurry {
+		return func(chunks.Chunk) chunks.GetAddrsCb {
+			return func(ctx context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+				addrs.Insert(h)
+				return nil
+			}
+		}
+	}
+
+	parent := chunks.NewChunk([]byte("parent"))
+	require.NoError(t, cs.Put(ctx, parent, refersTo(chnks[0].Hash())))
+	root, err := cs.Root(ctx)
+	require.NoError(t, err)
+	ok, err := cs.Commit(ctx, parent.Hash(), root)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Greater(t, src.queries, 0)
+	assert.Equal(t, 0, src.lockedWhen)
+
+	// References which the lazy source doesn't have either are dangling.
+	dangling := chunks.NewChunk([]byte("dangling"))
+	require.NoError(t, cs.Put(ctx, dangling, refersTo(chnks[1].Hash())))
+	_, err = cs.Commit(ctx, dangling.Hash(), parent.Hash())
+	assert.ErrorIs(t, err, ErrDanglingRef)
+	assert.Equal(t, 0, src.lockedWhen)
+}
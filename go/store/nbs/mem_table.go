@@ -42,8 +42,8 @@ const (
 )
 
 // WriteChunks writes the provided chunks to a newly created memory table and returns the name and data of the resulting
-// table.
-func WriteChunks(chunks []chunks.Chunk) (name string, data []byte, splitOffset uint64, err error) {
+// table. The chunk data is encrypted with |ce| if it is not nil.
+func WriteChunks(chunks []chunks.Chunk, ce *ChunkEncryption) (name string, data []byte, splitOffset uint64, err error) {
 	var size uint64
 	for _, chunk := range chunks {
 		size += uint64(len(chunk.Data()))
@@ -51,10 +51,10 @@ func WriteChunks(chunks []chunks.Chunk) (name string, data []byte, splitOffset u
 
 	mt := newMemTable(size)
 
-	return writeChunksToMT(mt, chunks)
+	return writeChunksToMT(mt, chunks, ce)
 }
 
-func writeChunksToMT(mt *memTable, chunks []chunks.Chunk) (name string, data []byte, splitOffset uint64, err error) {
+func writeChunksToMT(mt *memTable, chunks []chunks.Chunk, ce *ChunkEncryption) (name string, data []byte, splitOffset uint64, err error) {
 	for _, chunk := range chunks {
 		res := mt.addChunk(chunk.Hash(), chunk.Data())
 		if res == chunkNotAdded {
@@ -63,7 +63,7 @@ func writeChunksToMT(mt *memTable, chunks []chunks.Chunk) (name string, data []b
 	}
 
 	var stats Stats
-	h, data, splitOffset, count, _, err := mt.write(nil, nil, &stats)
+	h, data, splitOffset, count, _, err := mt.write(nil, nil, ce, &stats)
 	if err != nil {
 		return "", nil, 0, err
 	}
@@ -212,16 +212,18 @@ func (mt *memTable) getManyCompressed(ctx context.Context, eg *errgroup.Group, r
 	return remaining, gcBehavior_Continue, nil
 }
 
-func (mt *memTable) write(haver chunkReader, keeper keeperF, stats *Stats) (name hash.Hash, data []byte, splitOffset uint64, chunkCount uint32, gcb gcBehavior, err error) {
+// write writes the chunks of |mt| which |haver| doesn't have to a new table, encrypting their data with |ce| if it is
+// not nil.
+func (mt *memTable) write(haver chunkReader, keeper keeperF, ce *ChunkEncryption, stats *Stats) (name hash.Hash, data []byte, splitOffset uint64, chunkCount uint32, gcb gcBehavior, err error) {
 	gcb = gcBehavior_Continue
 	numChunks := uint64(len(mt.order))
 	if numChunks == 0 {
 		return hash.Hash{}, nil, 0, 0, gcBehavior_Continue, fmt.Errorf("mem table cannot write with zero chunks")
 	}
-	maxSize := maxTableSize(uint64(len(mt.order)), mt.totalData)
+	maxSize := maxTableSize(uint64(len(mt.order)), mt.totalData, ce)
 	// todo: memory quota
 	buff := make([]byte, maxSize)
-	tw := newTableWriter(buff, mt.snapper)
+	tw := newTableWriter(buff, mt.snapper, ce)
 
 	if haver != nil {
 		sort.Sort(hasRecordByPrefix(mt.order)) // hasMany() requires addresses to be sorted.
@@ -239,7 +241,9 @@ func (mt *memTable) write(haver chunkReader, keeper keeperF, stats *Stats) (name
 	for _, addr := range mt.order {
 		if !addr.has {
 			h := addr.a
-			tw.addChunk(*h, mt.chunks[*h])
+			if err := tw.addChunk(*h, mt.chunks[*h]); err != nil {
+				return hash.Hash{}, nil, 0, 0, gcBehavior_Continue, err
+			}
 			chunkCount++
 		}
 	}
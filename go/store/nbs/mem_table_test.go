@@ -64,7 +64,7 @@ func mustChunk(chunk chunks.Chunk, err error) chunks.Chunk {
 }
 
 func TestWriteChunks(t *testing.T) {
-	name, data, splitOffSet, err := WriteChunks(testMDChunks)
+	name, data, splitOffSet, err := WriteChunks(testMDChunks, nil)
 	require.NoError(t, err)
 	// Size of written data is stable so long as we don't change testMDChunks
 	assert.Equal(t, uint64(845), splitOffSet)
@@ -152,7 +152,7 @@ func TestMemTableWrite(t *testing.T) {
 	require.NoError(t, err)
 	ti1, err := parseTableIndexByCopy(ctx, td1, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr1, err := newTableReader(t.Context(), ti1, tableReaderAtFromBytes(td1), fileBlockSize)
+	tr1, err := newTableReader(t.Context(), ti1, tableReaderAtFromBytes(td1), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr1.close()
 	assert.True(tr1.has(computeAddr(chunks[1]), nil))
@@ -161,18 +161,18 @@ func TestMemTableWrite(t *testing.T) {
 	require.NoError(t, err)
 	ti2, err := parseTableIndexByCopy(ctx, td2, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr2, err := newTableReader(t.Context(), ti2, tableReaderAtFromBytes(td2), fileBlockSize)
+	tr2, err := newTableReader(t.Context(), ti2, tableReaderAtFromBytes(td2), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr2.close()
 	assert.True(tr2.has(computeAddr(chunks[2]), nil))
 
-	_, data, _, count, _, err := mt.write(chunkReaderGroup{tr1, tr2}, nil, &Stats{})
+	_, data, _, count, _, err := mt.write(chunkReaderGroup{tr1, tr2}, nil, nil, &Stats{})
 	require.NoError(t, err)
 	assert.Equal(uint32(1), count)
 
 	ti, err := parseTableIndexByCopy(ctx, data, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	outReader, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(data), fileBlockSize)
+	outReader, err := newTableReader(t.Context(), ti, tableReaderAtFromBytes(data), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer outReader.close()
 	assert.True(outReader.has(computeAddr(chunks[0]), nil))
@@ -220,7 +220,7 @@ func TestMemTableSnappyWriteOutOfLine(t *testing.T) {
 	}
 	mt.snapper = &outOfLineSnappy{[]bool{false, true, false}} // chunks[1] should trigger a panic
 
-	assert.Panics(func() { mt.write(nil, nil, &Stats{}) })
+	assert.Panics(func() { mt.write(nil, nil, nil, &Stats{}) })
 }
 
 type outOfLineSnappy struct {
@@ -33,6 +33,8 @@ type noConjoinBlobstorePersister struct {
 	bs        blobstore.Blobstore
 	q         MemoryQuotaProvider
 	blockSize uint64
+	// encryption seals the chunk data of the tables written, and opens the encrypted chunk data of the tables read.
+	encryption *ChunkEncryption
 }
 
 var _ tablePersister = &noConjoinBlobstorePersister{}
@@ -41,7 +43,7 @@ var _ tableFilePersister = &noConjoinBlobstorePersister{}
 // Persist makes the contents of mt durable. Chunks already present in
 // |haver| may be dropped in the process.
 func (bsp *noConjoinBlobstorePersister) Persist(ctx context.Context, behavior dherrors.FatalBehavior, mt *memTable, haver chunkReader, keeper keeperF, stats *Stats) (chunkSource, gcBehavior, error) {
-	address, data, _, chunkCount, gcb, err := mt.write(haver, keeper, stats)
+	address, data, _, chunkCount, gcb, err := mt.write(haver, keeper, bsp.encryption, stats)
 	if err != nil {
 		return emptyChunkSource{}, gcBehavior_Continue, err
 	} else if gcb != gcBehavior_Continue {
@@ -61,7 +63,7 @@ func (bsp *noConjoinBlobstorePersister) Persist(ctx context.Context, behavior dh
 	}
 
 	rdr := &bsTableReaderAt{key: name, bs: bsp.bs}
-	src, err := newReaderFromIndexData(ctx, bsp.q, data, address, rdr, bsp.blockSize)
+	src, err := newReaderFromIndexData(ctx, bsp.q, data, address, rdr, bsp.blockSize, bsp.encryption)
 	if err != nil {
 		return nil, gcBehavior_Continue, err
 	}
@@ -75,7 +77,7 @@ func (bsp *noConjoinBlobstorePersister) ConjoinAll(ctx context.Context, behavior
 
 // Open a table named |name|, containing |chunkCount| chunks.
 func (bsp *noConjoinBlobstorePersister) Open(ctx context.Context, name hash.Hash, chunkCount uint32, stats *Stats) (chunkSource, error) {
-	return newBSTableChunkSource(ctx, bsp.bs, name, chunkCount, bsp.q, stats)
+	return newBSTableChunkSource(ctx, bsp.bs, name, chunkCount, bsp.q, stats, bsp.encryption)
 }
 
 func (bsp *noConjoinBlobstorePersister) Exists(ctx context.Context, name string, chunkCount uint32, stats *Stats) (bool, error) {
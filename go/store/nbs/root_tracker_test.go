@@ -187,7 +187,7 @@ func TestChunkStoreManifestFirstWriteByOtherProcess(t *testing.T) {
 	newRoot, chunks, err := interloperWrite(fm, p, []byte("new root"), []byte("hello2"), []byte("goodbye2"), []byte("badbye2"))
 	require.NoError(t, err)
 
-	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, inlineConjoiner{defaultMaxTables}, defaultMemTableSize)
+	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, inlineConjoiner{defaultMaxTables}, defaultMemTableSize, nil)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, store.Close())
@@ -233,7 +233,7 @@ func TestChunkStoreManifestPreemptiveOptimisticLockFail(t *testing.T) {
 
 	c := inlineConjoiner{defaultMaxTables}
 
-	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, c, defaultMemTableSize)
+	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, c, defaultMemTableSize, nil)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, store.Close())
@@ -241,7 +241,7 @@ func TestChunkStoreManifestPreemptiveOptimisticLockFail(t *testing.T) {
 	}()
 
 	// Simulate another goroutine writing a manifest behind store's back.
-	interloper, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, c, defaultMemTableSize)
+	interloper, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, c, defaultMemTableSize, nil)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, interloper.Close())
@@ -282,7 +282,7 @@ func TestChunkStoreCommitLocksOutFetch(t *testing.T) {
 	p := newFakeTablePersister(q)
 	c := inlineConjoiner{defaultMaxTables}
 
-	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, c, defaultMemTableSize)
+	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, c, defaultMemTableSize, nil)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, store.Close())
@@ -328,7 +328,7 @@ func TestChunkStoreSerializeCommits(t *testing.T) {
 
 	c := inlineConjoiner{defaultMaxTables}
 
-	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, manifestManager{upm, mc, l}, p, q, c, defaultMemTableSize)
+	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, manifestManager{upm, mc, l}, p, q, c, defaultMemTableSize, nil)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, store.Close())
@@ -348,7 +348,8 @@ func TestChunkStoreSerializeCommits(t *testing.T) {
 		p,
 		q,
 		c,
-		defaultMemTableSize)
+		defaultMemTableSize,
+		nil)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, interloper.Close())
@@ -390,7 +391,7 @@ func makeStoreWithFakes(t *testing.T) (fm *fakeManifest, p tablePersister, q Mem
 	mm := manifestManager{fm, newManifestCache(0), newManifestLocks()}
 	q = NewUnlimitedMemQuotaProvider()
 	p = newFakeTablePersister(q)
-	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, inlineConjoiner{defaultMaxTables}, 0)
+	store, err := newNomsBlockStore(context.Background(), constants.FormatDoltString, mm, p, q, inlineConjoiner{defaultMaxTables}, 0, nil)
 	require.NoError(t, err)
 	return
 }
@@ -512,7 +513,7 @@ func (ftp fakeTablePersister) Persist(ctx context.Context, behavior dherrors.Fat
 		return emptyChunkSource{}, gcBehavior_Continue, nil
 	}
 
-	name, data, _, chunkCount, gcb, err := mt.write(haver, keeper, stats)
+	name, data, _, chunkCount, gcb, err := mt.write(haver, keeper, nil, stats)
 	if err != nil {
 		return emptyChunkSource{}, gcBehavior_Continue, err
 	} else if gcb != gcBehavior_Continue {
@@ -530,7 +531,7 @@ func (ftp fakeTablePersister) Persist(ctx context.Context, behavior dherrors.Fat
 		return nil, gcBehavior_Continue, err
 	}
 
-	cs, err := newTableReader(ctx, ti, tableReaderAtFromBytes(data), fileBlockSize)
+	cs, err := newTableReader(ctx, ti, tableReaderAtFromBytes(data), fileBlockSize, nil)
 	if err != nil {
 		return emptyChunkSource{}, gcBehavior_Continue, err
 	}
@@ -562,7 +563,7 @@ func (ftp fakeTablePersister) ConjoinAll(ctx context.Context, _ dherrors.FatalBe
 		return nil, nil, err
 	}
 
-	cs, err := newTableReader(ctx, ti, tableReaderAtFromBytes(data), fileBlockSize)
+	cs, err := newTableReader(ctx, ti, tableReaderAtFromBytes(data), fileBlockSize, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -579,9 +580,9 @@ func compactSourcesToBuffer(sources chunkSources) (name hash.Hash, data []byte,
 		return
 	}
 
-	maxSize := maxTableSize(uint64(chunkCount), totalData)
+	maxSize := maxTableSize(uint64(chunkCount), totalData, nil)
 	buff := make([]byte, maxSize) // This can blow up RAM
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, nil, nil)
 	errString := ""
 
 	ctx := context.Background()
@@ -634,7 +635,7 @@ func (ftp fakeTablePersister) Open(ctx context.Context, name hash.Hash, chunkCou
 		return nil, err
 	}
 
-	cs, err := newTableReader(ctx, ti, tableReaderAtFromBytes(data), fileBlockSize)
+	cs, err := newTableReader(ctx, ti, tableReaderAtFromBytes(data), fileBlockSize, nil)
 	if err != nil {
 		return emptyChunkSource{}, err
 	}
@@ -0,0 +1,294 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const sparseObjectsFileName = "sparseObjects.txt"
+
+var ErrSparseChunkRequested = errors.New("requested chunk which was left out of a sparse clone")
+
+// SparseFetcher fetches the chunks |addrs|, and every chunk reachable from them, into a store.
+type SparseFetcher func(ctx context.Context, addrs hash.HashSet) error
+
+// SparseChunkStore is a ChunkStore which can hold a sparse clone. A sparse clone leaves out the chunks of some of the
+// objects it references. These chunks are recorded in groups, and are reported as present by Has and HasMany like
+// ghost chunks. The first time a chunk of a group is requested through Get, GetMany or GetManyCompressed, the whole
+// group is fetched with the store's SparseFetcher.
+type SparseChunkStore interface {
+	// PersistSparseHashes records the chunks which were left out of the store in |groups|.
+	PersistSparseHashes(ctx context.Context, groups map[hash.Hash]hash.HashSet) error
+	// HasSparseHashes returns whether any chunk left out of the store has yet to be fetched.
+	HasSparseHashes() bool
+	// SetSparseFetcher sets the function used to fetch the chunks left out of the store.
+	SetSparseFetcher(f SparseFetcher)
+}
+
+var _ SparseChunkStore = (*GenerationalNBS)(nil)
+
+// sparseObjects is the persisted set of the chunks left out of a sparse clone. The file has one line per chunk, with
+// the key of the chunk's group followed by the chunk's address.
+type sparseObjects struct {
+	mu     sync.Mutex
+	path   string
+	groups map[hash.Hash]hash.HashSet
+	// index maps each chunk to a group including it.
+	index map[hash.Hash]hash.Hash
+	// fetching holds the chunks of the groups taken by the fetch in flight. Fetches are serialized, so there is at
+	// most one.
+	fetching hash.HashSet
+}
+
+type sparseFetchKey struct{}
+
+// isSparseFetch returns whether |ctx| is the context of a fetch of sparse chunks.
+func isSparseFetch(ctx context.Context) bool {
+	return ctx.Value(sparseFetchKey{}) != nil
+}
+
+func loadSparseObjects(path string) (*sparseObjects, error) {
+	s := &sparseObjects{
+		path:     path,
+		groups:   make(map[hash.Hash]hash.HashSet),
+		index:    make(map[hash.Hash]hash.Hash),
+		fetching: hash.NewHashSet(),
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		group, addr, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || !hash.IsValid(group) || !hash.IsValid(addr) {
+			return nil, fmt.Errorf("invalid line %q in %s", scanner.Text(), sparseObjectsFileName)
+		}
+		s.insert(hash.Parse(group), hash.Parse(addr))
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *sparseObjects) insert(group, addr hash.Hash) {
+	g, ok := s.groups[group]
+	if !ok {
+		g = hash.NewHashSet()
+		s.groups[group] = g
+	}
+	g.Insert(addr)
+	if _, ok = s.index[addr]; !ok {
+		s.index[addr] = group
+	}
+}
+
+// has returns whether |h| was left out of the store. The chunks being fetched are still reported as present, so that
+// readers wait on the fetch instead of finding them in no generation, except to the fetch itself, which would
+// otherwise skip them.
+func (s *sparseObjects) has(ctx context.Context, h hash.Hash) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.index[h]; ok {
+		return true
+	}
+	return s.fetching.Has(h) && !isSparseFetch(ctx)
+}
+
+func (s *sparseObjects) empty() bool {
+	if s == nil {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.index) == 0
+}
+
+// filter returns the members of |hashes| which were left out of the store, following the same rules as |has|.
+func (s *sparseObjects) filter(ctx context.Context, hashes hash.HashSet) hash.HashSet {
+	sparse := hash.NewHashSet()
+	if s == nil {
+		return sparse
+	}
+	fetch := isSparseFetch(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for h := range hashes {
+		if _, ok := s.index[h]; ok || (!fetch && s.fetching.Has(h)) {
+			sparse.Insert(h)
+		}
+	}
+	return sparse
+}
+
+// add records |groups| and persists them.
+func (s *sparseObjects) add(groups map[hash.Hash]hash.HashSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for group, addrs := range groups {
+		for addr := range addrs {
+			s.insert(group, addr)
+		}
+	}
+	return s.save()
+}
+
+// take removes the groups including any of |hashes| and returns them. Their chunks are held as being fetched until
+// |commit| persists the removal or |restore| undoes it.
+func (s *sparseObjects) take(hashes hash.HashSet) map[hash.Hash]hash.HashSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	taken := make(map[hash.Hash]hash.HashSet)
+	for h := range hashes {
+		group, ok := s.index[h]
+		if !ok {
+			continue
+		}
+		if _, ok = taken[group]; ok {
+			continue
+		}
+		addrs := s.groups[group]
+		taken[group] = addrs
+		s.fetching.InsertAll(addrs)
+		delete(s.groups, group)
+		for addr := range addrs {
+			if s.index[addr] == group {
+				delete(s.index, addr)
+			}
+		}
+	}
+	return taken
+}
+
+func (s *sparseObjects) restore(groups map[hash.Hash]hash.HashSet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetching = hash.NewHashSet()
+	for group, addrs := range groups {
+		for addr := range addrs {
+			s.insert(group, addr)
+		}
+	}
+}
+
+func (s *sparseObjects) commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetching = hash.NewHashSet()
+	return s.save()
+}
+
+// save writes the file atomically, so that the chunks left out of the store are never lost. Callers must hold |s.mu|.
+func (s *sparseObjects) save() error {
+	if len(s.groups) == 0 {
+		err := os.Remove(s.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), sparseObjectsFileName+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	w := bufio.NewWriter(f)
+	for group, addrs := range s.groups {
+		for addr := range addrs {
+			if _, err = w.WriteString(group.String() + " " + addr.String() + "\n"); err != nil {
+				f.Close()
+				return err
+			}
+		}
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+func (gcs *GenerationalNBS) PersistSparseHashes(ctx context.Context, groups map[hash.Hash]hash.HashSet) error {
+	if gcs.ghostGen == nil {
+		return errors.New("runtime error: ghostGen is nil but an attempt to persist sparse hashes was made")
+	}
+	return gcs.ghostGen.sparse.add(groups)
+}
+
+func (gcs *GenerationalNBS) HasSparseHashes() bool {
+	return gcs.ghostGen != nil && !gcs.ghostGen.sparse.empty()
+}
+
+func (gcs *GenerationalNBS) SetSparseFetcher(f SparseFetcher) {
+	gcs.sparseMu.Lock()
+	defer gcs.sparseMu.Unlock()
+	gcs.sparseFetcher = f
+}
+
+// fetchSparse fetches the groups of the chunks |hashes| which were left out of the store. Fetches are serialized, so
+// when it returns without error the chunks have been fetched, either by this call or by a concurrent one. Readers
+// which find a chunk being fetched end up here, and wait on |sparseMu| for the fetch to finish.
+func (gcs *GenerationalNBS) fetchSparse(ctx context.Context, hashes hash.HashSet) error {
+	gcs.sparseMu.Lock()
+	defer gcs.sparseMu.Unlock()
+
+	if gcs.sparseFetcher == nil {
+		return fmt.Errorf("%w: no remote is configured to fetch it from", ErrSparseChunkRequested)
+	}
+	groups := gcs.ghostGen.sparse.take(hashes)
+	if len(groups) == 0 {
+		return nil
+	}
+
+	addrs := hash.NewHashSet()
+	for _, g := range groups {
+		addrs.InsertAll(g)
+	}
+	// The chunks aren't reported as present to the fetch, so that it doesn't skip them.
+	ctx = context.WithValue(ctx, sparseFetchKey{}, true)
+	if err := gcs.sparseFetcher(ctx, addrs); err != nil {
+		gcs.ghostGen.sparse.restore(groups)
+		return fmt.Errorf("%w: failed to fetch it: %w", ErrSparseChunkRequested, err)
+	}
+	return gcs.ghostGen.sparse.commit()
+}
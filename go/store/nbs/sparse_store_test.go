@@ -0,0 +1,229 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestSparseObjects(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+	bs, err := NewGhostBlockStore(path)
+	require.NoError(t, err)
+
+	group1, group2 := hash.Parse("ifho8m890r9787lrpthif5ce6ru353fr"), hash.Parse("6af71afc2ea0hmp4olev0vp9q1q5gvb1")
+	a, b, c := hash.Of([]byte("a")), hash.Of([]byte("b")), hash.Of([]byte("c"))
+	require.NoError(t, bs.sparse.add(map[hash.Hash]hash.HashSet{
+		group1: hash.NewHashSet(a, b),
+		group2: hash.NewHashSet(c),
+	}))
+
+	// sparse chunks are reported as present, and persisted
+	bs, err = NewGhostBlockStore(path)
+	require.NoError(t, err)
+	for _, h := range []hash.Hash{a, b, c} {
+		has, err := bs.Has(ctx, h)
+		require.NoError(t, err)
+		assert.True(t, has)
+	}
+	absent, err := bs.HasMany(ctx, hash.NewHashSet(a, group1))
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(group1), absent)
+
+	// taking a chunk takes its whole group, which is reported as present to everyone but the fetch
+	fetchCtx := context.WithValue(ctx, sparseFetchKey{}, true)
+	taken := bs.sparse.take(hash.NewHashSet(b))
+	assert.Equal(t, map[hash.Hash]hash.HashSet{group1: hash.NewHashSet(a, b)}, taken)
+	assert.True(t, bs.sparse.has(ctx, a))
+	assert.False(t, bs.sparse.has(fetchCtx, a))
+	assert.True(t, bs.sparse.has(fetchCtx, c))
+	assert.Equal(t, hash.NewHashSet(a, b), bs.sparse.filter(ctx, hash.NewHashSet(a, b)))
+	assert.Empty(t, bs.sparse.filter(fetchCtx, hash.NewHashSet(a, b)))
+
+	bs.sparse.restore(taken)
+	assert.True(t, bs.sparse.has(fetchCtx, a))
+
+	bs.sparse.take(hash.NewHashSet(a, c))
+	require.NoError(t, bs.sparse.commit())
+	assert.True(t, bs.sparse.empty())
+	assert.False(t, bs.sparse.has(ctx, a))
+	bs, err = NewGhostBlockStore(path)
+	require.NoError(t, err)
+	assert.True(t, bs.sparse.empty())
+}
+
+func TestGenerationalCSSparseFetch(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	ghostGen, err := NewGhostBlockStore(t.TempDir())
+	require.NoError(t, err)
+	cs := NewGenerationalCS(oldGen, newGen, ghostGen)
+
+	chnks := genChunks(t, 5, 1000)
+	remote := make(map[hash.Hash]chunks.Chunk)
+	for _, c := range chnks {
+		remote[c.Hash()] = c
+	}
+	group := hash.Parse("ifho8m890r9787lrpthif5ce6ru353fr")
+	require.NoError(t, cs.PersistSparseHashes(ctx, map[hash.Hash]hash.HashSet{
+		group: hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash()),
+	}))
+	assert.True(t, cs.HasSparseHashes())
+
+	has, err := cs.Has(ctx, chnks[0].Hash())
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	_, err = cs.Get(ctx, chnks[0].Hash())
+	assert.ErrorIs(t, err, ErrSparseChunkRequested)
+
+	cs.SetSparseFetcher(func(ctx context.Context, addrs hash.HashSet) error {
+		return errors.New("remote is unavailable")
+	})
+	_, err = cs.Get(ctx, chnks[0].Hash())
+	assert.ErrorIs(t, err, ErrSparseChunkRequested)
+	assert.True(t, cs.HasSparseHashes())
+
+	var fetches []hash.HashSet
+	cs.SetSparseFetcher(func(ctx context.Context, addrs hash.HashSet) error {
+		fetches = append(fetches, addrs)
+		for h := range addrs {
+			// the chunks being fetched are not reported as present to the fetch
+			has, err := cs.Has(ctx, h)
+			require.NoError(t, err)
+			require.False(t, has)
+			if err := cs.Put(ctx, remote[h], noopGetAddrs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	c, err := cs.Get(ctx, chnks[0].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, chnks[0].Data(), c.Data())
+	require.Len(t, fetches, 1)
+	assert.Equal(t, hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash()), fetches[0])
+	assert.False(t, cs.HasSparseHashes())
+
+	// the rest of the group was fetched along with it
+	found := foundHashes{}
+	require.NoError(t, cs.GetMany(ctx, hash.NewHashSet(chnks[1].Hash()), found.found))
+	assert.Equal(t, hash.NewHashSet(chnks[1].Hash()), hash.HashSet(found))
+	assert.Len(t, fetches, 1)
+
+	// GetMany fetches sparse chunks too
+	require.NoError(t, cs.PersistSparseHashes(ctx, map[hash.Hash]hash.HashSet{
+		chnks[2].Hash(): hash.NewHashSet(chnks[2].Hash(), chnks[3].Hash()),
+	}))
+	found = foundHashes{}
+	require.NoError(t, cs.GetMany(ctx, hash.NewHashSet(chnks[0].Hash(), chnks[3].Hash()), found.found))
+	assert.Equal(t, hash.NewHashSet(chnks[0].Hash(), chnks[3].Hash()), hash.HashSet(found))
+	assert.Len(t, fetches, 2)
+
+	// GetManyCompressed fetches them too, so that they can be pushed, but GC leaves them alone
+	group = hash.Parse("6af71afc2ea0hmp4olev0vp9q1q5gvb1")
+	require.NoError(t, cs.PersistSparseHashes(ctx, map[hash.Hash]hash.HashSet{
+		group: hash.NewHashSet(chnks[4].Hash()),
+	}))
+	var ghosts []hash.Hash
+	require.NoError(t, cs.getManyCompressed(ctx, hash.NewHashSet(chnks[4].Hash()), func(ctx context.Context, c ToChunker) {
+		assert.True(t, c.IsGhost())
+		ghosts = append(ghosts, c.Hash())
+	}, gcDependencyMode_NoDependency))
+	assert.Equal(t, []hash.Hash{chnks[4].Hash()}, ghosts)
+	assert.Len(t, fetches, 2)
+	require.NoError(t, cs.GetManyCompressed(ctx, hash.NewHashSet(chnks[4].Hash()), func(ctx context.Context, c ToChunker) {
+		assert.False(t, c.IsGhost())
+		chk, err := c.ToChunk()
+		require.NoError(t, err)
+		assert.Equal(t, chnks[4].Data(), chk.Data())
+	}))
+	assert.Len(t, fetches, 3)
+	assert.False(t, cs.HasSparseHashes())
+}
+
+func TestGenerationalCSSparseGetDuringFetch(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	ghostGen, err := NewGhostBlockStore(t.TempDir())
+	require.NoError(t, err)
+	cs := NewGenerationalCS(oldGen, newGen, ghostGen)
+
+	chnks := genChunks(t, 2, 1000)
+	require.NoError(t, cs.PersistSparseHashes(ctx, map[hash.Hash]hash.HashSet{
+		chnks[0].Hash(): hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash()),
+	}))
+
+	started, release := make(chan struct{}), make(chan struct{})
+	var fetches atomic.Int32
+	cs.SetSparseFetcher(func(ctx context.Context, addrs hash.HashSet) error {
+		fetches.Add(1)
+		close(started)
+		<-release
+		for _, c := range chnks {
+			if err := cs.Put(ctx, c, noopGetAddrs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	var eg errgroup.Group
+	got := make([]chunks.Chunk, len(chnks))
+	eg.Go(func() (err error) {
+		got[0], err = cs.Get(ctx, chnks[0].Hash())
+		return err
+	})
+	<-started
+
+	// while the group is fetched, its chunks are still present to concurrent readers, which wait for the fetch
+	has, err := cs.Has(ctx, chnks[1].Hash())
+	require.NoError(t, err)
+	assert.True(t, has)
+	absent, err := cs.HasMany(ctx, hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash()))
+	require.NoError(t, err)
+	assert.Empty(t, absent)
+	eg.Go(func() (err error) {
+		got[1], err = cs.Get(ctx, chnks[1].Hash())
+		return err
+	})
+	found := foundHashes{}
+	eg.Go(func() error {
+		return cs.GetMany(ctx, hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash()), found.found)
+	})
+
+	close(release)
+	require.NoError(t, eg.Wait())
+	for i, c := range chnks {
+		assert.Equal(t, c.Data(), got[i].Data())
+	}
+	assert.Equal(t, hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash()), hash.HashSet(found))
+	assert.Equal(t, int32(1), fetches.Load())
+	assert.False(t, cs.HasSparseHashes())
+}
@@ -94,6 +94,12 @@ type NBSCompressedChunkStore interface {
 	GetManyCompressed(context.Context, hash.HashSet, func(context.Context, ToChunker)) error
 }
 
+// EncryptingChunkStore is implemented by chunk stores which can encrypt the chunk data they write.
+type EncryptingChunkStore interface {
+	// ChunkEncryption returns the ChunkEncryption of the store, or nil if it doesn't encrypt chunk data.
+	ChunkEncryption() *ChunkEncryption
+}
+
 type gcDependencyMode int
 
 const (
@@ -140,6 +146,9 @@ type NomsBlockStore struct {
 	gcInProgress bool
 
 	fatalBehavior dherrors.FatalBehavior
+
+	// encryption seals the chunk data written by this store, and opens the encrypted chunk data it reads.
+	encryption *ChunkEncryption
 }
 
 func (nbs *NomsBlockStore) PersistGhostHashes(ctx context.Context, refs hash.HashSet) error {
@@ -171,6 +180,18 @@ func (nbs *NomsBlockStore) IterateRoots(f func(root string, timestamp *time.Time
 	return cj.IterateRoots(f)
 }
 
+// TruncateRoots removes the previous roots tracked by the ChunkJournal, if there is one, so that IterateRoots only
+// visits the current root.
+func (nbs *NomsBlockStore) TruncateRoots(ctx context.Context) error {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	cj := nbs.chunkJournal()
+	if cj == nil {
+		return nil
+	}
+	return cj.TruncateRoots(ctx, nbs.fatalBehavior)
+}
+
 // ChunkJournal returns the ChunkJournal in use by this NomsBlockStore, or nil if no ChunkJournal is being used.
 func (nbs *NomsBlockStore) chunkJournal() *ChunkJournal {
 	if cj, ok := nbs.persister.(*ChunkJournal); ok {
@@ -617,6 +638,12 @@ func OverwriteStoreManifest(ctx context.Context, store *NomsBlockStore, root has
 }
 
 func NewAWSStore(ctx context.Context, nbfVerStr string, table, ns, bucket string, s3 S3APIV2, ddb DynamoDBAPIV2, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return NewAWSStoreWithEncryption(ctx, nbfVerStr, table, ns, bucket, s3, ddb, memTableSize, q, nil)
+}
+
+// NewAWSStoreWithEncryption returns an AWS store like NewAWSStore, which encrypts the chunk data it writes with |ce|,
+// and opens encrypted chunk data with it.
+func NewAWSStoreWithEncryption(ctx context.Context, nbfVerStr string, table, ns, bucket string, s3 S3APIV2, ddb DynamoDBAPIV2, memTableSize uint64, q MemoryQuotaProvider, ce *ChunkEncryption) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 	readRateLimiter := make(chan struct{}, 32)
 	p := &awsTablePersister{
@@ -626,9 +653,10 @@ func NewAWSStore(ctx context.Context, nbfVerStr string, table, ns, bucket string
 		bucket,
 		ns,
 		awsLimits{defaultS3PartSize, minS3PartSize, maxS3PartSize},
+		ce,
 	}
 	mm := makeManifestManager(newDynamoManifest(table, ns, ddb))
-	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
+	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize, ce)
 }
 
 // NewGCSStore returns an nbs implementation backed by a GCSBlobstore
@@ -653,6 +681,12 @@ func NewOCISStore(ctx context.Context, nbfVerStr string, bucketName, path string
 
 // NewGitStore returns an nbs implementation backed by a GitBlobstore.
 func NewGitStore(ctx context.Context, nbfVerStr string, gitDir string, ref string, opts blobstore.GitBlobstoreOptions, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return NewGitStoreWithEncryption(ctx, nbfVerStr, gitDir, ref, opts, memTableSize, q, nil)
+}
+
+// NewGitStoreWithEncryption returns a Git store like NewGitStore, which encrypts the chunk data it writes with |ce|,
+// and opens encrypted chunk data with it.
+func NewGitStoreWithEncryption(ctx context.Context, nbfVerStr string, gitDir string, ref string, opts blobstore.GitBlobstoreOptions, memTableSize uint64, q MemoryQuotaProvider, ce *ChunkEncryption) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 
 	// A Git remote may reject large blobs. To keep git-backed remotes broadly usable by default, enable
@@ -665,7 +699,7 @@ func NewGitStore(ctx context.Context, nbfVerStr string, gitDir string, ref strin
 	if err != nil {
 		return nil, err
 	}
-	return NewBSStore(ctx, nbfVerStr, bs, memTableSize, q)
+	return NewBSStoreWithEncryption(ctx, nbfVerStr, bs, memTableSize, q, ce)
 }
 
 // NewNoConjoinGitStore returns an nbs implementation backed by a GitBlobstore, but disables conjoin.
@@ -686,29 +720,47 @@ func NewNoConjoinGitStore(ctx context.Context, nbfVerStr string, gitDir string,
 
 // NewBSStore returns an nbs implementation backed by a Blobstore
 func NewBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return NewBSStoreWithEncryption(ctx, nbfVerStr, bs, memTableSize, q, nil)
+}
+
+// NewBSStoreWithEncryption returns a Blobstore backed store like NewBSStore, which encrypts the chunk data it writes
+// with |ce|, and opens encrypted chunk data with it.
+func NewBSStoreWithEncryption(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider, ce *ChunkEncryption) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 
 	mm := makeManifestManager(blobstoreManifest{bs})
 
-	p := &blobstorePersister{bs, q, s3BlockSize}
-	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize)
+	p := &blobstorePersister{bs, q, s3BlockSize, ce}
+	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, inlineConjoiner{defaultMaxTables}, memTableSize, ce)
 }
 
 // NewNoConjoinBSStore returns a nbs implementation backed by a Blobstore
 func NewNoConjoinBSStore(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider) (*NomsBlockStore, error) {
+	return NewNoConjoinBSStoreWithEncryption(ctx, nbfVerStr, bs, memTableSize, q, nil)
+}
+
+// NewNoConjoinBSStoreWithEncryption returns a Blobstore backed store like NewNoConjoinBSStore, which encrypts the
+// chunk data it writes with |ce|, and opens encrypted chunk data with it.
+func NewNoConjoinBSStoreWithEncryption(ctx context.Context, nbfVerStr string, bs blobstore.Blobstore, memTableSize uint64, q MemoryQuotaProvider, ce *ChunkEncryption) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 
 	mm := makeManifestManager(blobstoreManifest{bs})
 
-	p := &noConjoinBlobstorePersister{bs, q, s3BlockSize}
-	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, noopConjoiner{}, memTableSize)
+	p := &noConjoinBlobstorePersister{bs, q, s3BlockSize, ce}
+	return newNomsBlockStore(ctx, nbfVerStr, mm, p, q, noopConjoiner{}, memTableSize, ce)
 }
 
 func NewLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSize uint64, q MemoryQuotaProvider, mmapArchiveIndexes bool) (*NomsBlockStore, error) {
-	return newLocalStore(ctx, nbfVerStr, dir, memTableSize, defaultMaxTables, q, mmapArchiveIndexes)
+	return newLocalStore(ctx, nbfVerStr, dir, memTableSize, defaultMaxTables, q, mmapArchiveIndexes, nil)
+}
+
+// NewLocalStoreWithEncryption returns a local store like NewLocalStore, which encrypts the chunk data it writes with
+// |ce|, and opens encrypted chunk data with it.
+func NewLocalStoreWithEncryption(ctx context.Context, nbfVerStr string, dir string, memTableSize uint64, q MemoryQuotaProvider, mmapArchiveIndexes bool, ce *ChunkEncryption) (*NomsBlockStore, error) {
+	return newLocalStore(ctx, nbfVerStr, dir, memTableSize, defaultMaxTables, q, mmapArchiveIndexes, ce)
 }
 
-func newLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSize uint64, maxTables int, q MemoryQuotaProvider, mmapArchiveIndexes bool) (*NomsBlockStore, error) {
+func newLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSize uint64, maxTables int, q MemoryQuotaProvider, mmapArchiveIndexes bool, ce *ChunkEncryption) (*NomsBlockStore, error) {
 	cacheOnce.Do(makeGlobalCaches)
 	if err := checkDir(dir); err != nil {
 		return nil, err
@@ -724,10 +776,10 @@ func newLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSi
 	if err != nil {
 		return nil, err
 	}
-	p := newFSTablePersister(dir, q, mmapArchiveIndexes)
+	p := newFSTablePersister(dir, q, mmapArchiveIndexes, ce)
 	c := conjoinStrategy(inlineConjoiner{maxTables})
 
-	return newNomsBlockStore(ctx, nbfVerStr, makeManifestManager(m), p, q, c, memTableSize)
+	return newNomsBlockStore(ctx, nbfVerStr, makeManifestManager(m), p, q, c, memTableSize, ce)
 }
 
 func NewLocalJournalingStore(ctx context.Context, nbfVers, dir string, q MemoryQuotaProvider, mmapArchiveIndexes bool, warningsCb func(error)) (*NomsBlockStore, error) {
@@ -739,6 +791,8 @@ type JournalingStoreOptions struct {
 	// FailOnLockTimeout returns an error if the exclusive journal manifest lock cannot be acquired
 	// within Dolt's internal lock timeout, instead of falling back to opening in read-only mode.
 	FailOnLockTimeout bool
+	// Encryption, if set, encrypts the chunk data written by the store, and opens the encrypted chunk data it reads.
+	Encryption *ChunkEncryption
 }
 
 func NewLocalJournalingStoreWithOptions(ctx context.Context, nbfVers, dir string, q MemoryQuotaProvider, mmapArchiveIndexes bool, warningsCb func(error), opts JournalingStoreOptions) (*NomsBlockStore, error) {
@@ -751,7 +805,7 @@ func NewLocalJournalingStoreWithOptions(ctx context.Context, nbfVers, dir string
 	if err != nil {
 		return nil, err
 	}
-	p := newFSTablePersister(dir, q, mmapArchiveIndexes)
+	p := newFSTablePersister(dir, q, mmapArchiveIndexes, opts.Encryption)
 
 	journal, err := newChunkJournal(ctx, nbfVers, dir, m, p.(*fsTablePersister), warningsCb)
 	if err != nil {
@@ -762,7 +816,7 @@ func NewLocalJournalingStoreWithOptions(ctx context.Context, nbfVers, dir string
 	c := journalConjoiner{child: inlineConjoiner{defaultMaxTables}}
 
 	// |journal| serves as the manifest and tablePersister
-	return newNomsBlockStore(ctx, nbfVers, mm, journal, q, c, defaultMemTableSize)
+	return newNomsBlockStore(ctx, nbfVers, mm, journal, q, c, defaultMemTableSize, opts.Encryption)
 }
 
 func checkDir(dir string) error {
@@ -776,7 +830,7 @@ func checkDir(dir string) error {
 	return nil
 }
 
-func newNomsBlockStore(ctx context.Context, nbfVerStr string, mm manifestManager, p tablePersister, q MemoryQuotaProvider, c conjoinStrategy, memTableSize uint64) (*NomsBlockStore, error) {
+func newNomsBlockStore(ctx context.Context, nbfVerStr string, mm manifestManager, p tablePersister, q MemoryQuotaProvider, c conjoinStrategy, memTableSize uint64, ce *ChunkEncryption) (*NomsBlockStore, error) {
 	if memTableSize == 0 {
 		memTableSize = defaultMemTableSize
 	}
@@ -796,6 +850,7 @@ func newNomsBlockStore(ctx context.Context, nbfVerStr string, mm manifestManager
 		hasCache:    hasCache,
 		stats:       NewStats(),
 		logger:      logrus.StandardLogger().WithField("pkg", "store.noms"),
+		encryption:  ce,
 	}
 	nbs.gcCond = sync.NewCond(&nbs.mu)
 	nbs.conjoinOpCond = sync.NewCond(&nbs.mu)
@@ -1588,6 +1643,11 @@ func (nbs *NomsBlockStore) updateManifest(ctx context.Context, current, last has
 	return nil
 }
 
+// ChunkEncryption implements EncryptingChunkStore.
+func (nbs *NomsBlockStore) ChunkEncryption() *ChunkEncryption {
+	return nbs.encryption
+}
+
 func (nbs *NomsBlockStore) Version() string {
 	nbs.mu.RLock()
 	defer nbs.mu.RUnlock()
@@ -2146,7 +2206,7 @@ func markAndSweepChunks(_ context.Context, nbs *NomsBlockStore, src CompressedCh
 		return nil, fmt.Errorf("NBS does not support copying garbage collection")
 	}
 
-	gcc, err := newGarbageCollectionCopier(cmp, tfp)
+	gcc, err := newGarbageCollectionCopier(cmp, tfp, destNBS.encryption)
 	if err != nil {
 		return nil, err
 	}
@@ -391,11 +391,11 @@ func buildFakeChunkTable(chunks []fakeChunk) ([]byte, hash.Hash, error) {
 	for _, chunk := range chunks {
 		totalData += uint64(len(chunk.data))
 	}
-	capacity := maxTableSize(uint64(len(chunks)), totalData)
+	capacity := maxTableSize(uint64(len(chunks)), totalData, nil)
 
 	buff := make([]byte, capacity)
 
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, nil, nil)
 
 	for _, chunk := range chunks {
 		tw.addChunk(chunk.address, chunk.data)
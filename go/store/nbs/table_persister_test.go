@@ -52,7 +52,7 @@ func TestPlanCompaction(t *testing.T) {
 		require.NoError(t, err)
 		ti, err := parseTableIndexByCopy(ctx, data, q)
 		require.NoError(t, err)
-		tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(data), fileBlockSize)
+		tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(data), fileBlockSize, nil)
 		require.NoError(t, err)
 		src := chunkSourceAdapter{tr, name}
 		t.Cleanup(func() { src.close() })
@@ -76,7 +76,7 @@ func TestPlanCompaction(t *testing.T) {
 	assert.Equal(totalChunks, idx.chunkCount())
 	assert.Equal(totalUnc, idx.totalUncompressedData())
 
-	tr, err := newTableReader(ctx, idx, tableReaderAtFromBytes(nil), fileBlockSize)
+	tr, err := newTableReader(ctx, idx, tableReaderAtFromBytes(nil), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 	for _, content := range tableContents {
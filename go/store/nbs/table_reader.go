@@ -59,12 +59,19 @@ type CompressedChunk struct {
 	H hash.Hash
 	// true if the chunk is a ghost chunk.
 	ghost bool
+	// encryption opens CompressedData when it is encrypted.
+	encryption *ChunkEncryption
 }
 
 var _ ToChunker = CompressedChunk{}
 
 // NewCompressedChunk creates a CompressedChunk
 func NewCompressedChunk(h hash.Hash, buff []byte) (CompressedChunk, error) {
+	return NewCompressedChunkWithEncryption(h, buff, nil)
+}
+
+// NewCompressedChunkWithEncryption creates a CompressedChunk whose data is opened with |ce| if it is encrypted.
+func NewCompressedChunkWithEncryption(h hash.Hash, buff []byte, ce *ChunkEncryption) (CompressedChunk, error) {
 	dataLen := uint64(len(buff)) - checksumSize
 
 	chksum := binary.BigEndian.Uint32(buff[dataLen:])
@@ -74,20 +81,24 @@ func NewCompressedChunk(h hash.Hash, buff []byte) (CompressedChunk, error) {
 		return CompressedChunk{}, errors.New("checksum error")
 	}
 
-	return CompressedChunk{H: h, FullCompressedChunk: buff, CompressedData: compressedData}, nil
+	return CompressedChunk{H: h, FullCompressedChunk: buff, CompressedData: compressedData, encryption: ce}, nil
 }
 
 func NewGhostCompressedChunk(h hash.Hash) CompressedChunk {
 	return CompressedChunk{H: h, ghost: true}
 }
 
-// ToChunk snappy decodes the compressed data and returns a chunks.Chunk
+// ToChunk snappy decodes the compressed data, decrypting it first if it is encrypted, and returns a chunks.Chunk
 func (cmp CompressedChunk) ToChunk() (chunks.Chunk, error) {
 	if cmp.IsGhost() {
 		return *chunks.NewGhostChunk(cmp.H), nil
 	}
 
-	data, err := snappy.Decode(nil, cmp.CompressedData)
+	compressed, err := cmp.encryption.openData(cmp.CompressedData)
+	if err != nil {
+		return chunks.Chunk{}, err
+	}
+	data, err := snappy.Decode(nil, compressed)
 	if err != nil {
 		return chunks.Chunk{}, err
 	}
@@ -95,14 +106,19 @@ func (cmp CompressedChunk) ToChunk() (chunks.Chunk, error) {
 }
 
 func ChunkToCompressedChunk(chunk chunks.Chunk) CompressedChunk {
-	compressed := snappy.Encode(nil, chunk.Data())
+	return ChunkToCompressedChunkWithEncryption(chunk, nil)
+}
+
+// ChunkToCompressedChunkWithEncryption compresses |chunk|, and encrypts it with |ce| if it is not nil.
+func ChunkToCompressedChunkWithEncryption(chunk chunks.Chunk, ce *ChunkEncryption) CompressedChunk {
+	compressed := ce.sealData(snappy.Encode(nil, chunk.Data()))
 	length := len(compressed)
 	// todo: this append allocates a new buffer and copies |compressed|.
 	//  This is costly, but maybe better, as it allows us to reclaim the
 	//  extra space allocated in snappy.Encode (see snappy.MaxEncodedLen).
 	compressed = append(compressed, []byte{0, 0, 0, 0}...)
 	binary.BigEndian.PutUint32(compressed[length:], crc(compressed[:length]))
-	return CompressedChunk{H: chunk.Hash(), FullCompressedChunk: compressed, CompressedData: compressed[:length]}
+	return CompressedChunk{H: chunk.Hash(), FullCompressedChunk: compressed, CompressedData: compressed[:length], encryption: ce}
 }
 
 // Hash returns the hash of the data
@@ -173,6 +189,9 @@ type tableReader struct {
 	r         tableReaderAt
 	blockSize uint64
 
+	// encryption opens the chunk data of the table when it is encrypted.
+	encryption *ChunkEncryption
+
 	// Prefixes are quota allocated and need to be released when no longer used.
 	// Each index.prefixes() call allocated a new slice. newTableReader makes a
 	// new refCnt, and clone() keeps the same prefixes and increments the refcnt.
@@ -184,8 +203,8 @@ type tableReader struct {
 
 // newTableReader parses a valid nbs table byte stream and returns a reader. buff must end with an NBS index
 // and footer, though it may contain an unspecified number of bytes before that data. r should allow
-// retrieving any desired range of bytes from the table.
-func newTableReader(ctx context.Context, index tableIndex, r tableReaderAt, blockSize uint64) (tableReader, error) {
+// retrieving any desired range of bytes from the table. Encrypted chunk data is opened with |ce|.
+func newTableReader(ctx context.Context, index tableIndex, r tableReaderAt, blockSize uint64, ce *ChunkEncryption) (tableReader, error) {
 	p, cleanup, err := index.prefixes(ctx)
 	if err != nil {
 		return tableReader{}, err
@@ -196,6 +215,7 @@ func newTableReader(ctx context.Context, index tableIndex, r tableReaderAt, bloc
 		idx:             index,
 		r:               r,
 		blockSize:       blockSize,
+		encryption:      ce,
 		prefixes:        p,
 		prefixesCnt:     cnt,
 		prefixesCleanup: cleanup,
@@ -312,7 +332,7 @@ func (tr tableReader) get(ctx context.Context, h hash.Hash, keeper keeperF, stat
 		return nil, gcBehavior_Continue, errors.New("failed to read all data")
 	}
 
-	cmp, err := NewCompressedChunk(h, buff)
+	cmp, err := NewCompressedChunkWithEncryption(h, buff, tr.encryption)
 
 	if err != nil {
 		return nil, gcBehavior_Continue, err
@@ -394,7 +414,7 @@ func (tr tableReader) readAtOffsetsWithCB(
 	}
 
 	for i := range rb {
-		cmp, err := rb.ExtractChunkFromRead(buff, i)
+		cmp, err := rb.ExtractChunkFromRead(buff, i, tr.encryption)
 		if err != nil {
 			return err
 		}
@@ -479,10 +499,10 @@ func (r readBatch) End() uint64 {
 	return last.offset + uint64(last.length)
 }
 
-func (s readBatch) ExtractChunkFromRead(buff []byte, idx int) (CompressedChunk, error) {
+func (s readBatch) ExtractChunkFromRead(buff []byte, idx int, ce *ChunkEncryption) (CompressedChunk, error) {
 	rec := s[idx]
 	chunkStart := rec.offset - s.Start()
-	return NewCompressedChunk(hash.Hash(*rec.a), buff[chunkStart:chunkStart+uint64(rec.length)])
+	return NewCompressedChunkWithEncryption(hash.Hash(*rec.a), buff[chunkStart:chunkStart+uint64(rec.length)], ce)
 }
 
 func toReadBatches(offsets offsetRecSlice, blockSize uint64) []readBatch {
@@ -684,7 +704,7 @@ func (tr tableReader) extract(ctx context.Context, chunks chan<- extractRecord)
 		if uint32(n) != or.length {
 			return errors.New("did not read all data")
 		}
-		cmp, err := NewCompressedChunk(hash.Hash(*or.a), buff)
+		cmp, err := NewCompressedChunkWithEncryption(hash.Hash(*or.a), buff, tr.encryption)
 
 		if err != nil {
 			return err
@@ -779,6 +799,7 @@ func (tr tableReader) clone() (tableReader, error) {
 		idx:             idx,
 		r:               r,
 		blockSize:       tr.blockSize,
+		encryption:      tr.encryption,
 	}, nil
 }
 
@@ -835,7 +856,7 @@ func (tr tableReader) iterateAllChunks(ctx context.Context, cb func(chunk chunks
 		_, err := io.ReadFull(bufReader, buf[:chunk.length])
 		chunkData := buf[:chunk.length]
 
-		cchk, err := NewCompressedChunk(chunk.hash, chunkData)
+		cchk, err := NewCompressedChunkWithEncryption(chunk.hash, chunkData, tr.encryption)
 		if err != nil {
 			return err
 		}
@@ -63,7 +63,7 @@ func TestCanReadAhead(t *testing.T) {
 func TestTableReaderIndexQuota(t *testing.T) {
 	// Write a simple archive file which has non-sense chunks which claim to be snappy encoded.
 	dir := t.TempDir()
-	writer, err := NewCmpChunkTableWriter(dir)
+	writer, err := NewCmpChunkTableWriter(dir, nil)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		writer.Remove()
@@ -92,7 +92,7 @@ func TestTableReaderIndexQuota(t *testing.T) {
 		q := NewUnlimitedMemQuotaProvider()
 		assert.Equal(t, uint64(0), q.Usage())
 		// stats := &Stats{}
-		reader, err := nomsFileTableReader(ctx, tableFilePath, h, uint32(count), q)
+		reader, err := nomsFileTableReader(ctx, tableFilePath, h, uint32(count), q, nil)
 		require.NoError(t, err)
 		// Immediately after opening the quota acocunts for in memory index.
 		expectedQuotaUsage :=
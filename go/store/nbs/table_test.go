@@ -42,11 +42,11 @@ func buildTable(chunks [][]byte) ([]byte, hash.Hash, error) {
 	for _, chunk := range chunks {
 		totalData += uint64(len(chunk))
 	}
-	capacity := maxTableSize(uint64(len(chunks)), totalData)
+	capacity := maxTableSize(uint64(len(chunks)), totalData, nil)
 
 	buff := make([]byte, capacity)
 
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, nil, nil)
 
 	for _, chunk := range chunks {
 		tw.addChunk(computeAddr(chunk), chunk)
@@ -81,7 +81,7 @@ func TestSimple(t *testing.T) {
 	require.NoError(t, err)
 	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 
@@ -130,7 +130,7 @@ func TestHasMany(t *testing.T) {
 	require.NoError(t, err)
 	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 
@@ -169,9 +169,9 @@ func TestHasManySequentialPrefix(t *testing.T) {
 	bogusData := []byte("bogus") // doesn't matter what this is. hasMany() won't check chunkRecords
 	totalData := uint64(len(bogusData) * len(addrs))
 
-	capacity := maxTableSize(uint64(len(addrs)), totalData)
+	capacity := maxTableSize(uint64(len(addrs)), totalData, nil)
 	buff := make([]byte, capacity)
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, nil, nil)
 
 	for _, a := range addrs {
 		tw.addChunk(a, bogusData)
@@ -183,7 +183,7 @@ func TestHasManySequentialPrefix(t *testing.T) {
 
 	ti, err := parseTableIndexByCopy(ctx, buff, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(buff), fileBlockSize)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(buff), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 
@@ -238,7 +238,7 @@ func BenchmarkHasMany(b *testing.B) {
 	require.NoError(b, err)
 	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
 	require.NoError(b, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, nil)
 	require.NoError(b, err)
 	defer tr.close()
 
@@ -275,7 +275,7 @@ func TestGetMany(t *testing.T) {
 	require.NoError(t, err)
 	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 
@@ -311,7 +311,7 @@ func TestCalcReads(t *testing.T) {
 	require.NoError(t, err)
 	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), 0)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), 0, nil)
 	require.NoError(t, err)
 	defer tr.close()
 	addrs := hash.HashSlice{computeAddr(chunks[0]), computeAddr(chunks[1]), computeAddr(chunks[2])}
@@ -350,7 +350,7 @@ func TestExtract(t *testing.T) {
 	require.NoError(t, err)
 	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 
@@ -391,7 +391,7 @@ func Test65k(t *testing.T) {
 	require.NoError(t, err)
 	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 
@@ -446,7 +446,7 @@ func doTestNGetMany(t *testing.T, count int) {
 	require.NoError(t, err)
 	ti, err := parseTableIndexByCopy(ctx, tableData, &UnlimitedQuotaProvider{})
 	require.NoError(t, err)
-	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize)
+	tr, err := newTableReader(ctx, ti, tableReaderAtFromBytes(tableData), fileBlockSize, nil)
 	require.NoError(t, err)
 	defer tr.close()
 
@@ -485,7 +485,7 @@ func TestEmpty(t *testing.T) {
 	assert := assert.New(t)
 
 	buff := make([]byte, footerSize)
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, nil, nil)
 	length, _, err := tw.finish()
 	require.NoError(t, err)
 	assert.True(length == footerSize)
@@ -44,6 +44,7 @@ type tableWriter struct {
 	pos                   uint64
 	totalCompressedData   uint64
 	totalUncompressedData uint64
+	encryption            *ChunkEncryption
 }
 
 type snappyEncoder interface {
@@ -56,12 +57,14 @@ func (r realSnappyEncoder) Encode(dst, src []byte) []byte {
 	return snappy.Encode(dst, src)
 }
 
-func maxTableSize(numChunks, totalData uint64) uint64 {
+// maxTableSize returns the size of the buffer needed to write a table of |numChunks| chunks holding |totalData| bytes,
+// which are encrypted with |ce| if it is not nil.
+func maxTableSize(numChunks, totalData uint64, ce *ChunkEncryption) uint64 {
 	avgChunkSize := totalData / numChunks
 	d.Chk.True(avgChunkSize < maxChunkSize)
 	maxSnappySize := snappy.MaxEncodedLen(int(avgChunkSize))
 	d.Chk.True(maxSnappySize > 0)
-	return numChunks*(prefixTupleSize+lengthSize+hash.SuffixLen+checksumSize+uint64(maxSnappySize)) + footerSize
+	return numChunks*(prefixTupleSize+lengthSize+hash.SuffixLen+checksumSize+uint64(maxSnappySize)+ce.sealOverhead()) + footerSize
 }
 
 func indexSize(numChunks uint32) uint64 {
@@ -76,19 +79,20 @@ func suffixesOffset(numChunks uint32) uint64 {
 	return uint64(numChunks) * (prefixTupleSize + lengthSize)
 }
 
-// len(buff) must be >= maxTableSize(numChunks, totalData)
-func newTableWriter(buff []byte, snapper snappyEncoder) *tableWriter {
+// len(buff) must be >= maxTableSize(numChunks, totalData, ce). Chunk data is encrypted with |ce| if it is not nil.
+func newTableWriter(buff []byte, snapper snappyEncoder, ce *ChunkEncryption) *tableWriter {
 	if snapper == nil {
 		snapper = realSnappyEncoder{}
 	}
 	return &tableWriter{
-		buff:      buff,
-		blockHash: sha512.New(),
-		snapper:   snapper,
+		buff:       buff,
+		blockHash:  sha512.New(),
+		snapper:    snapper,
+		encryption: ce,
 	}
 }
 
-func (tw *tableWriter) addChunk(h hash.Hash, data []byte) bool {
+func (tw *tableWriter) addChunk(h hash.Hash, data []byte) error {
 	if len(data) == 0 {
 		panic("NBS blocks cannot be zero length")
 	}
@@ -106,6 +110,18 @@ func (tw *tableWriter) addChunk(h hash.Hash, data []byte) bool {
 		panic(fmt.Errorf("bug 3156: unbuffered chunk %s: uncompressed %d, compressed %d, snappy max %d, tw.buff %d", h.String(), len(data), dataLength, snappy.MaxEncodedLen(len(data)), len(tw.buff[tw.pos:])))
 	}
 
+	// Encrypt the compressed data when chunk encryption is enabled. maxTableSize accounts for the sealing overhead, but
+	// a data key rotated since then may have a longer header.
+	if tw.encryption != nil {
+		sealed := tw.encryption.seal(compressed)
+		if uint64(len(tw.buff)) < tw.pos+uint64(len(sealed))+checksumSize {
+			return fmt.Errorf("unbuffered encrypted chunk %s: sealed %d, tw.buff %d", h.String(), len(sealed), len(tw.buff[tw.pos:]))
+		}
+		compressed = tw.buff[tw.pos : tw.pos+uint64(copy(tw.buff[tw.pos:], sealed))]
+		tw.totalCompressedData += uint64(len(sealed)) - dataLength
+		dataLength = uint64(len(sealed))
+	}
+
 	tw.pos += dataLength
 	tw.totalUncompressedData += uint64(len(data))
 
@@ -120,7 +136,7 @@ func (tw *tableWriter) addChunk(h hash.Hash, data []byte) bool {
 		uint32(checksumSize + dataLength),
 	})
 
-	return true
+	return nil
 }
 
 // finish completed table, writing the index and footer. Returns the total length of the table file and the hash used
@@ -23,7 +23,8 @@ import (
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
-func IterChunks(ctx context.Context, rd io.ReadSeeker, cb func(chunk chunks.Chunk) (stop bool, err error)) error {
+// IterChunks calls |cb| with each chunk of the table file read from |rd|, opening its encrypted chunk data with |ce|.
+func IterChunks(ctx context.Context, rd io.ReadSeeker, ce *ChunkEncryption, cb func(chunk chunks.Chunk) (stop bool, err error)) error {
 	idx, err := readTableIndexByCopy(ctx, rd, &UnlimitedQuotaProvider{})
 	if err != nil {
 		return err
@@ -45,7 +46,7 @@ func IterChunks(ctx context.Context, rd io.ReadSeeker, cb func(chunk chunks.Chun
 				return err
 			}
 
-			cmpChnk, err := NewCompressedChunk(h, chunkBytes)
+			cmpChnk, err := NewCompressedChunkWithEncryption(h, chunkBytes, ce)
 			if err != nil {
 				return err
 			}
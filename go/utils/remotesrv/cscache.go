@@ -17,6 +17,7 @@ package main
 import (
 	"context"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
@@ -26,6 +27,11 @@ import (
 
 const (
 	defaultMemTableSize = 128 * 1024 * 1024
+
+	// repoMarkerFile marks the directories of the repositories created through the RepoAdminService, which have no
+	// manifest until they are first pushed to.
+	repoMarkerFile   = ".remotesrv_repo"
+	manifestFileName = "manifest"
 )
 
 type LocalCSCache struct {
@@ -33,16 +39,31 @@ type LocalCSCache struct {
 	dbs map[string]remotesrv.RemoteSrvStore
 
 	fs filesys.Filesys
+
+	// hosted is set for caches which only serve the repositories created through CreateRepo, or which existed
+	// before, instead of creating a repository for every path requested.
+	hosted bool
 }
 
+var _ remotesrv.RepoManager = (*LocalCSCache)(nil)
+
 func NewLocalCSCache(filesys filesys.Filesys) *LocalCSCache {
 	return &LocalCSCache{
 		&sync.Mutex{},
 		make(map[string]remotesrv.RemoteSrvStore),
 		filesys,
+		false,
 	}
 }
 
+// NewHostedCSCache returns a LocalCSCache which returns remotesrv.ErrRepoNotFound for the repositories which don't
+// exist, and manages repositories as a remotesrv.RepoManager.
+func NewHostedCSCache(filesys filesys.Filesys) *LocalCSCache {
+	cache := NewLocalCSCache(filesys)
+	cache.hosted = true
+	return cache
+}
+
 func (cache *LocalCSCache) Get(ctx context.Context, repopath, nbfVerStr string) (remotesrv.RemoteSrvStore, error) {
 	cache.mu.Lock()
 	defer cache.mu.Unlock()
@@ -52,7 +73,22 @@ func (cache *LocalCSCache) Get(ctx context.Context, repopath, nbfVerStr string)
 	if cs, ok := cache.dbs[id]; ok {
 		return cs, nil
 	}
+	if cache.hosted && !cache.repoExists(id) {
+		return nil, remotesrv.ErrRepoNotFound
+	}
+
+	return cache.open(ctx, id, nbfVerStr)
+}
+
+func (cache *LocalCSCache) repoExists(id string) bool {
+	if exists, _ := cache.fs.Exists(filepath.Join(id, repoMarkerFile)); exists {
+		return true
+	}
+	exists, _ := cache.fs.Exists(filepath.Join(id, manifestFileName))
+	return exists
+}
 
+func (cache *LocalCSCache) open(ctx context.Context, id, nbfVerStr string) (remotesrv.RemoteSrvStore, error) {
 	err := cache.fs.MkDirs(id)
 	if err != nil {
 		return nil, err
@@ -72,6 +108,86 @@ func (cache *LocalCSCache) Get(ctx context.Context, repopath, nbfVerStr string)
 	return newCS, nil
 }
 
+// CreateRepo implements remotesrv.RepoManager.
+func (cache *LocalCSCache) CreateRepo(ctx context.Context, repopath, nbfVerStr string) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	id := filepath.FromSlash(repopath)
+	if _, ok := cache.dbs[id]; ok || cache.repoExists(id) {
+		return remotesrv.ErrRepoExists
+	}
+	err := cache.fs.MkDirs(id)
+	if err != nil {
+		return err
+	}
+	err = cache.fs.WriteFile(filepath.Join(id, repoMarkerFile), nil, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = cache.open(ctx, id, nbfVerStr)
+	return err
+}
+
+// DeleteRepo implements remotesrv.RepoManager.
+func (cache *LocalCSCache) DeleteRepo(ctx context.Context, repopath string) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	id := filepath.FromSlash(repopath)
+	cs, ok := cache.dbs[id]
+	if !ok && !cache.repoExists(id) {
+		return remotesrv.ErrRepoNotFound
+	}
+	if ok {
+		delete(cache.dbs, id)
+		if err := cs.Close(); err != nil {
+			return err
+		}
+	}
+	return cache.fs.Delete(id, true)
+}
+
+// ListRepos implements remotesrv.RepoManager.
+func (cache *LocalCSCache) ListRepos(ctx context.Context) ([]string, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	root, err := cache.fs.Abs(".")
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var iterErr error
+	err = cache.fs.Iter(root, true, func(path string, size int64, isDir bool) bool {
+		name := filepath.Base(path)
+		if isDir || (name != repoMarkerFile && name != manifestFileName) {
+			return false
+		}
+		rel, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			iterErr = err
+			return true
+		}
+		if rel != "." {
+			seen[filepath.ToSlash(rel)] = true
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	} else if iterErr != nil {
+		return nil, iterErr
+	}
+
+	repos := make([]string, 0, len(seen))
+	for repo := range seen {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
 type SingletonCSCache struct {
 	s remotesrv.RemoteSrvStore
 }
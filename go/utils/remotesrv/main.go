@@ -22,6 +22,8 @@ import (
 	"os"
 	"os/signal"
 
+	"github.com/sirupsen/logrus"
+
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -44,6 +46,7 @@ func main() {
 	grpcPortParam := flag.Int("grpc-port", -1, "the port the grpc server will listen on; default 50051")
 	httpPortParam := flag.Int("http-port", -1, "the port the http server will listen on; default 80; if http-port is equal to grpc-port, both services will serve over the same port")
 	httpHostParam := flag.String("http-host", "", "hostname to use in the host component of the URLs that the server generates; default ''; if '', server will echo the :authority header")
+	configParam := flag.String("config", "", "YAML hosting config with the users, access rules and quotas of the hosted repositories; repositories must be created through the RepoAdminService")
 	flag.Parse()
 
 	if dirParam != nil && len(*dirParam) > 0 {
@@ -76,8 +79,27 @@ func main() {
 		log.Fatalln("could not get cwd path:", err.Error())
 	}
 
+	var hostingConfig remotesrv.HostingConfig
+	if *configParam != "" {
+		if *repoModeParam {
+			log.Fatalln("'config' parameter can't be used with 'repo-mode'")
+		}
+		data, err := os.ReadFile(*configParam)
+		if err != nil {
+			log.Fatalln("failed to read config:", err.Error())
+		}
+		hostingConfig, err = remotesrv.ParseHostingConfig(data)
+		if err != nil {
+			log.Fatalln(err.Error())
+		}
+	}
+
 	var dbCache remotesrv.DBCache
-	if *repoModeParam {
+	var hostedCache *LocalCSCache
+	if *configParam != "" {
+		hostedCache = NewHostedCSCache(fs)
+		dbCache = hostedCache
+	} else if *repoModeParam {
 		ctx := context.Background()
 		dEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, doltdb.LocalDirDoltDB, "remotesrv")
 		if !dEnv.Valid() {
@@ -90,7 +112,7 @@ func main() {
 		dbCache = NewLocalCSCache(fs)
 	}
 
-	server, err := remotesrv.NewServer(remotesrv.ServerArgs{
+	args := remotesrv.ServerArgs{
 		HttpHost:           *httpHostParam,
 		HttpListenAddr:     fmt.Sprintf(":%d", *httpPortParam),
 		GrpcListenAddr:     fmt.Sprintf(":%d", *grpcPortParam),
@@ -98,10 +120,29 @@ func main() {
 		DBCache:            dbCache,
 		ReadOnly:           *readOnlyParam,
 		ConcurrencyControl: remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_IGNORE_WORKING_SET,
-	})
+	}
+	var acl *remotesrv.RepoACL
+	if hostedCache != nil {
+		acl, err = remotesrv.NewRepoACL(hostingConfig)
+		if err != nil {
+			log.Fatalf("invalid config: %v\n", err)
+		}
+		interceptor := remotesrv.RepoACLInterceptor{
+			Lgr: logrus.NewEntry(logrus.StandardLogger()),
+			ACL: acl,
+		}
+		args.Options = interceptor.Options()
+		args.Quotas = acl
+	}
+
+	server, err := remotesrv.NewServer(args)
 	if err != nil {
 		log.Fatalf("error creating remotesrv Server: %v\n", err)
 	}
+	if hostedCache != nil {
+		adminServer := remotesrv.NewRepoAdminServer(logrus.NewEntry(logrus.StandardLogger()), hostedCache, dbCache, acl)
+		remotesapi.RegisterRepoAdminServiceServer(server.GrpcServer(), adminServer)
+	}
 	listeners, err := server.Listeners()
 	if err != nil {
 		log.Fatalf("error starting remotesrv Server listeners: %v\n", err)
@@ -0,0 +1,83 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY);"
+    dolt sql -q "INSERT INTO dolt_branch_protection VALUES ('main', true, NULL, 0, false);"
+    dolt commit -Am "protect main"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "branch-protection: direct commits to protected branches are rejected" {
+    dolt sql -q "INSERT INTO test VALUES (1);"
+    run dolt commit -am "direct commit"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "branch \`main\` is protected" ]] || false
+
+    dolt checkout -b feature
+    dolt commit -am "commit on feature"
+    dolt checkout main
+    run dolt merge --no-ff feature -m "merge feature"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT count(*) FROM test;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "branch-protection: merges require approvals and passing workflows" {
+    dolt sql -q "UPDATE dolt_branch_protection SET required_workflows = 'checks', required_approvals = 1;"
+    dolt checkout -b rules
+    dolt commit -am "require checks and an approval"
+    dolt checkout main
+    dolt merge --no-ff rules -m "merge rules"
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (1);"
+    dolt commit -am "commit on feature"
+    dolt checkout main
+
+    run dolt merge feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "workflow \`checks\` has not passed" ]] || false
+
+    dolt checkout feature
+    dolt sql -q "CALL dolt_ci_record_result('checks', 'passed');"
+    dolt checkout main
+
+    run dolt merge feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "has 0 of the 1 required approvals" ]] || false
+
+    dolt sql -q "INSERT INTO dolt_branch_approvals (commit_hash, approver) VALUES (HASHOF('feature'), 'reviewer');"
+    run dolt merge feature
+    [ "$status" -eq 0 ]
+}
+
+@test "branch-protection: pushes to protected branches are checked" {
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt push origin main
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (1);"
+    dolt commit -am "commit on feature"
+    run dolt push origin feature:main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "branch \`main\` is protected" ]] || false
+
+    dolt checkout main
+    dolt merge --no-ff feature -m "merge feature"
+    run dolt push origin main
+    [ "$status" -eq 0 ]
+
+    dolt reset --hard HEAD~1
+    run dolt push --force origin main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot be force pushed" ]] || false
+}
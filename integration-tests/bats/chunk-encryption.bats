@@ -0,0 +1,122 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+# base64 encoded 256-bit keys
+OLD_KEY="AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+NEW_KEY="AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="
+
+setup() {
+    export DOLT_ENCRYPTION_KEY="$OLD_KEY"
+    setup_common    # need to set env vars before setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "chunk-encryption: chunk data is encrypted at rest" {
+    dolt sql -q "create table t (pk int primary key, c varchar(100));"
+    dolt sql -q "insert into t values (1, 'supersecretvalue');"
+    dolt commit -Am "add t"
+
+    run grep -r "supersecretvalue" .dolt/noms
+    [ "$status" -ne 0 ]
+
+    run dolt sql -q "select c from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "supersecretvalue" ]] || false
+
+    dolt gc
+    run grep -r "supersecretvalue" .dolt/noms
+    [ "$status" -ne 0 ]
+
+    run dolt sql -q "select c from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "supersecretvalue" ]] || false
+
+    unset DOLT_ENCRYPTION_KEY
+    run dolt sql -q "select c from t" -r csv
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "encrypted" ]] || false
+}
+
+@test "chunk-encryption: keys are rotated by gc" {
+    dolt sql -q "create table t (pk int primary key, c varchar(100));"
+    dolt sql -q "insert into t values (1, 'supersecretvalue');"
+    dolt commit -Am "add t"
+
+    # the old key is still needed to read the data written before the rotation
+    export DOLT_ENCRYPTION_KEY="$NEW_KEY"
+    run dolt sql -q "select c from t" -r csv
+    [ "$status" -ne 0 ]
+
+    export DOLT_ENCRYPTION_KEY="$NEW_KEY,$OLD_KEY"
+    dolt sql -q "insert into t values (2, 'anothersecret');"
+    dolt commit -am "add row"
+    dolt gc --full
+
+    # after gc, everything is encrypted with the new key
+    export DOLT_ENCRYPTION_KEY="$NEW_KEY"
+    run dolt sql -q "select c from t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "supersecretvalue" ]] || false
+    [[ "$output" =~ "anothersecret" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add row" ]] || false
+}
+
+@test "chunk-encryption: remotes and backups are encrypted" {
+    dolt sql -q "create table t (pk int primary key, c varchar(100));"
+    dolt sql -q "insert into t values (1, 'supersecretvalue');"
+    dolt commit -Am "add t"
+
+    mkdir "$BATS_TMPDIR/remote-$$" "$BATS_TMPDIR/backup-$$"
+    dolt remote add origin "file://$BATS_TMPDIR/remote-$$"
+    dolt push origin main
+    dolt backup add bak "file://$BATS_TMPDIR/backup-$$"
+    dolt backup sync bak
+
+    run grep -r "supersecretvalue" "$BATS_TMPDIR/remote-$$" "$BATS_TMPDIR/backup-$$"
+    [ "$status" -ne 0 ]
+
+    cd "$BATS_TMPDIR"
+    dolt clone "file://$BATS_TMPDIR/remote-$$" "clone-$$"
+    cd "clone-$$"
+    run dolt sql -q "select c from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "supersecretvalue" ]] || false
+}
+
+@test "chunk-encryption: key file" {
+    echo "$OLD_KEY" > "$BATS_TMPDIR/dolt-encryption-key"
+    unset DOLT_ENCRYPTION_KEY
+    export DOLT_ENCRYPTION_KEY_FILE="$BATS_TMPDIR/dolt-encryption-key"
+
+    dolt sql -q "create table t (pk int primary key, c varchar(100));"
+    dolt sql -q "insert into t values (1, 'supersecretvalue');"
+    dolt commit -Am "add t"
+
+    run dolt sql -q "select c from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "supersecretvalue" ]] || false
+
+    export DOLT_ENCRYPTION_KEY="$OLD_KEY"
+    run dolt status
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only one of DOLT_ENCRYPTION_KEY and DOLT_ENCRYPTION_KEY_FILE may be set" ]] || false
+}
+
+@test "chunk-encryption: invalid keys" {
+    export DOLT_ENCRYPTION_KEY="not a key"
+    run dolt status
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Failed to configure chunk encryption" ]] || false
+
+    export DOLT_ENCRYPTION_KEY="c2hvcnQ="
+    run dolt status
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid encryption key: expected 32 bytes, found 5" ]] || false
+}
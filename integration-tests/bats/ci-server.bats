@@ -0,0 +1,102 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    setup_no_dolt_init
+
+    mkdir repo1
+    cd repo1
+    dolt init
+    dolt sql -q "CREATE TABLE test (pk INT PRIMARY KEY);"
+    dolt sql --save "count rows" -q "SELECT * FROM test;"
+    cat > workflow.yaml <<EOF
+name: check_rows
+on:
+  push:
+    branches:
+      - main
+jobs:
+  - name: validate
+    steps:
+      - name: at most one row
+        saved_query_name: count rows
+        expected_rows: "<= 1"
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    dolt add .
+    dolt commit -m "add workflow"
+    cd ../
+
+    PORT=$( definePORT )
+    cat > server.yaml <<EOF
+listener:
+  port: $PORT
+
+behavior:
+  run_ci_workflows: true
+EOF
+
+    start_sql_server_with_args_no_port "--config" "server.yaml"
+}
+
+teardown() {
+    stop_sql_server 1
+    teardown_common
+}
+
+# wait_for_runs waits until the ci run history of repo1 has |$1| runs.
+wait_for_runs() {
+    for i in $(seq 1 50); do
+        run dolt --use-db repo1 sql -r csv -q "SELECT count(*) FROM dolt_ci_runs;"
+        if [ "${lines[1]}" -eq "$1" ]; then
+            return 0
+        fi
+        sleep 0.2
+    done
+    fail "expected $1 ci runs, found ${lines[1]}"
+}
+
+@test "ci-server: commits to a triggering branch run workflows" {
+    dolt --use-db repo1 sql -q "INSERT INTO test VALUES (1); CALL dolt_commit('-am', 'one row');"
+    wait_for_runs 1
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT workflow, event, branch, commit_hash = HASHOF('main'), status FROM dolt_ci_runs;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "check_rows,push,main,true,passed" ]] || false
+
+    dolt --use-db repo1 sql -q "INSERT INTO test VALUES (2); CALL dolt_commit('-am', 'two rows');"
+    wait_for_runs 2
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT status FROM dolt_ci_runs WHERE id = 2;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "failed" ]] || false
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT job, step, status, error IS NOT NULL FROM dolt_ci_step_results WHERE run_id = 2;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "validate,at most one row,failed,true" ]] || false
+}
+
+@test "ci-server: commits to other branches do not run workflows" {
+    dolt --use-db repo1 sql -q "CALL dolt_checkout('-b', 'feature'); INSERT INTO test VALUES (1); CALL dolt_commit('-am', 'on feature');"
+    sleep 1
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT count(*) FROM dolt_ci_runs;"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" -eq 0 ]
+}
+
+@test "ci-server: workflows can't read other databases" {
+    dolt sql -q "CREATE DATABASE repo2; CREATE TABLE repo2.secret (pk INT PRIMARY KEY); INSERT INTO repo2.secret VALUES (1);"
+    dolt --use-db repo1 sql -q "UPDATE dolt_query_catalog SET query = 'SELECT * FROM repo2.secret' WHERE name = 'count rows'; CALL dolt_commit('-am', 'read another database');"
+    wait_for_runs 1
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT status FROM dolt_ci_runs WHERE id = 1;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "failed" ]] || false
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT error FROM dolt_ci_step_results WHERE run_id = 1;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "denied" ]] || false
+}
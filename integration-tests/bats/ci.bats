@@ -1206,3 +1206,45 @@ EOF
     [[ "$output" =~ "Step: run unknown group" ]] || false
     [[ "$output" =~ "Result of 'unknown group': FAIL" ]] || false
 }
+
+@test "ci: ci run --format writes test reports" {
+    cat > workflow.yaml <<EOF
+name: workflow
+on:
+  push: {}
+jobs:
+  - name: verify commits
+    steps:
+      - name: one commit
+        saved_query_name: check dolt commit
+        expected_rows: "== 1"
+      - name: five commits
+        saved_query_name: check dolt commit
+        expected_rows: "== 5"
+EOF
+    dolt ci init
+    dolt ci import ./workflow.yaml
+    dolt sql --save "check dolt commit" -q "select * from dolt_commits;"
+
+    run dolt ci run --format junit "workflow"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ '<testsuites name="workflow" tests="2" failures="1"' ]] || false
+    [[ "$output" =~ '<testsuite name="verify commits" tests="2" failures="1"' ]] || false
+    [[ "$output" =~ '<testcase name="one commit" classname="verify commits"' ]] || false
+    [[ "$output" =~ "query: select * from dolt_commits;" ]] || false
+    ! [[ "$output" =~ "Running workflow" ]] || false
+
+    run dolt ci run --format tap "workflow"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "1..2" ]] || false
+    [[ "$output" =~ "not ok 2 - verify commits / five commits" ]] || false
+
+    run dolt ci run --format json "workflow"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ '"failures": 1' ]] || false
+    [[ "$output" =~ '"status": "fail"' ]] || false
+
+    run dolt ci run --format xml "workflow"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid format 'xml'" ]] || false
+}
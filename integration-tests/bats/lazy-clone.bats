@@ -0,0 +1,142 @@
+#!/usr/bin/env bats
+#
+# Tests for lazy clones and read replicas, which fetch the chunks they
+# don't have from their remote when they're first read.
+
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    mkdir repo
+    cd repo
+    dolt init
+    dolt sql <<SQL
+CREATE TABLE items (id int primary key, name varchar(64));
+INSERT INTO items VALUES (1, 'one'), (2, 'two'), (3, 'three');
+CREATE TABLE audit (id int primary key, entry varchar(512), KEY (entry));
+INSERT INTO audit
+  WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < 1000)
+  SELECT n, repeat(md5(n), 16) FROM seq;
+SQL
+    dolt commit -Am "create tables"
+    dolt tag v1
+    dolt branch other
+    dolt remote add origin file://../remote
+    dolt push origin main other
+    dolt push origin v1
+    cd ..
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "lazy-clone: --lazy reads data from the remote" {
+    dolt clone --lazy file://./remote lazy
+    cd lazy
+
+    run dolt sql -q "select count(*), sum(id) from audit" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000,500500" ]] || false
+
+    run dolt sql -q "select id from audit where entry = repeat(md5(7), 16)" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "7" ]] || false
+
+    [ -d .dolt/lazy_cache ]
+    [ -n "$(find .dolt/lazy_cache -type f)" ]
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remotes/origin/other" ]] || false
+
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+}
+
+@test "lazy-clone: a lazy clone can be written to and pulled into" {
+    dolt clone --lazy file://./remote lazy
+    cd lazy
+    dolt sql -q "insert into items values (4, 'four')"
+    dolt commit -am "add four"
+
+    cd ../repo
+    dolt sql -q "update audit set entry = 'changed' where id <= 10"
+    dolt commit -am "change audit"
+    dolt push origin main
+
+    cd ../lazy
+    dolt pull --no-edit origin main
+    run dolt sql -q "select count(*) from items" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+    run dolt sql -q "select count(*) from audit where entry = 'changed'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "10" ]] || false
+}
+
+@test "lazy-clone: cached data is read without the remote" {
+    dolt clone --lazy file://./remote lazy
+    cd lazy
+    dolt sql -q "select count(*) from audit"
+
+    rm -rf ../remote
+    run dolt sql -q "select count(*) from audit" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000" ]] || false
+
+    run dolt sql -q "select * from items"
+    [ "$status" -ne 0 ]
+}
+
+@test "lazy-clone: --lazy can't be combined with other partial clones" {
+    run dolt clone --lazy --depth 1 file://./remote lazy
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--lazy cannot be used with --depth" ]] || false
+
+    run dolt clone --lazy --exclude-tables audit file://./remote lazy
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--lazy cannot be used with --exclude-tables" ]] || false
+
+    run dolt clone --lazy-cache-size 10 file://./remote lazy
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--lazy-cache-size can only be used with --lazy" ]] || false
+    [ ! -d lazy ]
+}
+
+@test "lazy-clone: dolt_clone procedure supports --lazy" {
+    dolt sql -q "call dolt_clone('--lazy', '--lazy-cache-size', '16', 'file://./remote', 'lazy')"
+    [ -d lazy/.dolt/lazy_cache ]
+
+    run dolt --use-db lazy sql -q "select count(*) from audit" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000" ]] || false
+}
+
+@test "lazy-clone: read replicas can read data lazily" {
+    mkdir replica
+    cd replica
+    dolt init
+    dolt remote add origin file://../remote
+    dolt config --local --add sqlserver.global.dolt_read_replica_remote origin
+    dolt config --local --add sqlserver.global.dolt_replicate_heads main
+    dolt config --local --add sqlserver.global.dolt_read_replica_lazy_fetch 1
+    dolt config --local --add sqlserver.global.dolt_read_replica_lazy_cache_mb 16
+
+    run dolt sql -q "select count(*), sum(id) from audit" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000,500500" ]] || false
+    [ -n "$(find .dolt/lazy_cache -type f)" ]
+
+    cd ../repo
+    dolt sql -q "insert into items values (4, 'four')"
+    dolt commit -am "add four"
+    dolt push origin main
+
+    cd ../replica
+    run dolt sql -q "select name from items where id = 4" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "four" ]] || false
+}
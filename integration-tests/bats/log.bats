@@ -596,6 +596,40 @@ export NO_COLOR=1
     ! [[ "$output" =~ "Commit2" ]] || false
 }
 
+@test "log: --follow lists the history of a table across renames" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "create test"
+
+    dolt sql -q "insert into test values (1, 1)"
+    dolt commit -am "insert into test"
+
+    dolt sql -q "rename table test to renamed"
+    dolt add .
+    dolt commit -m "rename test"
+
+    dolt sql -q "alter table renamed rename column c1 to c2"
+    dolt sql -q "insert into renamed values (2, 2)"
+    dolt commit -am "insert into renamed"
+
+    run dolt log --oneline renamed
+    [ $status -eq 0 ]
+    [[ "$output" =~ "insert into renamed" ]] || false
+    [[ "$output" =~ "rename test" ]] || false
+    ! [[ "$output" =~ "insert into test" ]] || false
+
+    run dolt log --oneline --follow renamed
+    [ $status -eq 0 ]
+    [[ "$output" =~ "insert into renamed" ]] || false
+    [[ "$output" =~ "rename test" ]] || false
+    [[ "$output" =~ "insert into test" ]] || false
+    [[ "$output" =~ "create test" ]] || false
+
+    run dolt log --follow
+    [ $status -eq 1 ]
+    [[ "$output" =~ "--follow requires exactly one table" ]] || false
+}
+
 @test "log: --merges, --parents, --min-parents option" {
     if [ "$SQL_ENGINE" = "remote-engine" ]; then
       skip "needs checkout which is unsupported for remote-engine"
@@ -60,7 +60,7 @@ teardown() {
 @test "ls: --system shows system tables" {
     run dolt ls --system
     [ "$status" -eq 0 ]
-    [ "${#lines[@]}" -eq 27 ]
+    [ "${#lines[@]}" -eq 34 ]
     [[ "$output" =~ "System tables:" ]] || false
     [[ "$output" =~ "dolt_status" ]] || false
     [[ "$output" =~ "dolt_status_ignored" ]] || false
@@ -72,6 +72,13 @@ teardown() {
     [[ "$output" =~ "dolt_remotes" ]] || false
     [[ "$output" =~ "dolt_branches" ]] || false
     [[ "$output" =~ "dolt_branch_activity" ]] || false
+    [[ "$output" =~ "dolt_webhook_deliveries" ]] || false
+    [[ "$output" =~ "dolt_branch_approvals" ]] || false
+    [[ "$output" =~ "dolt_ci_runs" ]] || false
+    [[ "$output" =~ "dolt_ci_step_results" ]] || false
+    [[ "$output" =~ "dolt_storage_usage" ]] || false
+    [[ "$output" =~ "dolt_purged_commits" ]] || false
+    [[ "$output" =~ "dolt_change_consumers" ]] || false
     [[ "$output" =~ "dolt_backups" ]] || false
     [[ "$output" =~ "dolt_remote_branches" ]] || false
     [[ "$output" =~ "dolt_help" ]] || false
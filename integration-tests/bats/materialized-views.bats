@@ -0,0 +1,54 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t (pk INT PRIMARY KEY, g VARCHAR(10) NOT NULL, x INT);"
+    dolt sql -q "INSERT INTO t VALUES (1, 'a', 1), (2, 'a', 2), (3, 'b', 5);"
+    dolt sql -q "CALL dolt_create_materialized_view('totals', 'SELECT g, COUNT(*) AS n FROM t GROUP BY g');"
+    dolt commit -Am "add t and totals"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "materialized-views: dolt commit refreshes views" {
+    run dolt sql -r csv -q "SELECT * FROM totals ORDER BY g;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "a,2" ]] || false
+    [[ "$output" =~ "b,1" ]] || false
+
+    dolt sql -q "INSERT INTO t VALUES (4, 'b', 1), (5, 'c', 1);"
+    dolt commit -am "more rows"
+
+    run dolt sql -r csv -q "SELECT * FROM totals ORDER BY g;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "b,2" ]] || false
+    [[ "$output" =~ "c,1" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt diff HEAD~1 HEAD --stat totals
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "totals" ]] || false
+}
+
+@test "materialized-views: dropped view tables are recreated by a refresh" {
+    dolt sql -q "DROP TABLE totals;"
+    dolt commit -am "drop totals"
+
+    dolt sql -q "INSERT INTO t VALUES (4, 'b', 1);"
+    dolt commit -am "more rows"
+    run dolt ls
+    [ "$status" -eq 0 ]
+    ! [[ "$output" =~ "totals" ]] || false
+
+    dolt sql -q "CALL dolt_refresh_materialized_view();"
+    run dolt sql -r csv -q "SELECT * FROM totals ORDER BY g;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "b,2" ]] || false
+}
@@ -0,0 +1,161 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE people (
+  id int NOT NULL PRIMARY KEY,
+  email varchar(100),
+  INDEX (email)
+);
+INSERT INTO people VALUES (1, 'alice@example.com'), (2, 'bob@example.com');
+SQL
+    dolt commit -Am "added people"
+    dolt sql -q "INSERT INTO people VALUES (3, 'carol@example.com');"
+    dolt commit -am "added carol"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "purge-rows: removes rows from every commit, branch and tag" {
+    dolt branch other
+    dolt tag v1 HEAD~1
+    dolt sql -q "UPDATE people SET email = 'bob@dolthub.com' WHERE id = 2;"
+    dolt commit -am "updated bob"
+
+    run dolt purge-rows --table people --where "id = 2"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_history_people WHERE id = 2;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt sql -q "SELECT id FROM people AS OF 'other' ORDER BY id;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ "$output" =~ "3" ]] || false
+    [[ ! "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM people AS OF 'v1';" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt sql -q "SELECT id FROM people WHERE email = 'bob@dolthub.com';" -r csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "2" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "updated bob" ]] || false
+    [[ "$output" =~ "added carol" ]] || false
+}
+
+@test "purge-rows: prints the mapping of rewritten commits" {
+    old_head=$(get_head_commit)
+
+    run dolt purge-rows --table people --where "email LIKE 'carol%'" --skip-gc
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ] || false
+    [[ "$output" =~ "$old_head" ]] || false
+
+    new_head=$(get_head_commit)
+    [ "$output" = "$old_head $new_head" ] || false
+}
+
+@test "purge-rows: records the mapping of rewritten commits" {
+    old_head=$(get_head_commit)
+
+    run dolt purge-rows --table people --where "email LIKE 'carol%'" --skip-gc
+    [ "$status" -eq 0 ]
+    new_head=$(get_head_commit)
+
+    run dolt sql -q "SELECT old_commit_hash, new_commit_hash, table_name FROM dolt_purged_commits;" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ] || false
+    [ "${lines[1]}" = "$old_head,$new_head,people" ] || false
+
+    # the filter isn't recorded, as it may contain the purged values
+    run dolt sql -q "SELECT * FROM dolt_purged_commits;" -r csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "carol" ]] || false
+}
+
+@test "purge-rows: truncates the reflog" {
+    old_head=$(get_head_commit)
+    run dolt sql -q "SELECT count(*) FROM dolt_reflog() WHERE commit_hash = '$old_head';" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ] || false
+
+    # the reflog is truncated even when garbage isn't collected
+    dolt purge-rows --table people --where "id = 3" --skip-gc
+
+    run dolt sql -q "SELECT count(*) FROM dolt_reflog('--all') WHERE commit_hash = '$old_head';" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ] || false
+
+    for commit in $(dolt sql -q "SELECT DISTINCT commit_hash FROM dolt_reflog('--all');" -r csv | tail -n +2); do
+        run dolt sql -q "SELECT count(*) FROM people AS OF '$commit' WHERE id = 3;" -r csv
+        [ "$status" -eq 0 ]
+        [ "${lines[1]}" = "0" ] || false
+    done
+}
+
+@test "purge-rows: purges uncommitted changes" {
+    dolt sql -q "INSERT INTO people VALUES (4, 'dave@example.com');"
+
+    run dolt purge-rows --table people --where "id = 4" --skip-gc
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT count(*) FROM people WHERE id = 4;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "purge-rows: follows renames of the table" {
+    dolt sql -q "RENAME TABLE people TO customers;"
+    dolt commit -am "renamed people"
+    dolt sql -q "INSERT INTO customers VALUES (4, 'bob@dolthub.com');"
+    dolt commit -am "added bob again"
+
+    run dolt purge-rows --table customers --where "email LIKE 'bob%'" --skip-gc
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ] || false
+
+    run dolt sql -q "SELECT count(*) FROM people AS OF 'HEAD~2' WHERE id = 2;" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ] || false
+
+    run dolt sql -q "SELECT count(*) FROM customers WHERE email LIKE 'bob%';" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ] || false
+
+    run dolt sql -q "SELECT id FROM people AS OF 'HEAD~2' ORDER BY id;" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ] || false
+    [ "${lines[2]}" = "3" ] || false
+}
+
+@test "purge-rows: requires a table and a filter" {
+    run dolt purge-rows --table people
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--table and --where are required" ]] || false
+
+    run dolt sql -q "CALL dolt_purge_rows('--table', 'people');"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--where is required" ]] || false
+}
+
+@test "purge-rows: refuses to run with stashes" {
+    dolt sql -q "INSERT INTO people VALUES (4, 'dave@example.com');"
+    dolt stash
+
+    run dolt purge-rows --table people --where "id = 4"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "stashes exist" ]] || false
+}
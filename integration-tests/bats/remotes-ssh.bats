@@ -0,0 +1,88 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+
+    # fake-ssh runs the remote command locally, ignoring ssh options and the host
+    cat > fake-ssh <<'EOF'
+#!/bin/sh
+for last; do :; done
+exec sh -c "$last"
+EOF
+    chmod +x fake-ssh
+    export DOLT_SSH="$(pwd)/fake-ssh"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "remotes-ssh: push, fetch and clone ssh remotes" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int);"
+    dolt sql -q "INSERT INTO test VALUES (1, 1), (2, 2);"
+    dolt commit -Am "added test"
+
+    remote="ssh://me@example.com:2222$(pwd)/remote"
+    dolt remote add origin "$remote"
+    run dolt push origin main
+    [ "$status" -eq 0 ]
+    [ -d remote ]
+
+    cd dolt-repo-clones
+    run dolt clone "$remote" cloned
+    [ "$status" -eq 0 ]
+    cd cloned
+    run dolt sql -q "SELECT count(*) FROM test;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+    dolt commit -am "added a row"
+    run dolt push origin main
+    [ "$status" -eq 0 ]
+
+    cd ../..
+    run dolt pull origin main
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT count(*) FROM test;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "remotes-ssh: read-only helper rejects pushes" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY);"
+    dolt commit -Am "added test"
+
+    remote="ssh://example.com$(pwd)/remote"
+    dolt remote add origin "$remote"
+    dolt push origin main
+
+    # read-only-ssh serves the remote like a key restricted to the read-only helper in authorized_keys
+    cat > read-only-ssh <<'EOF'
+#!/bin/sh
+for last; do :; done
+eval "set -- $last"
+exec "$1" remote-helper --read-only "$3"
+EOF
+    chmod +x read-only-ssh
+    export DOLT_SSH="$(pwd)/read-only-ssh"
+    dolt sql -q "INSERT INTO test VALUES (1);"
+    dolt commit -am "added a row"
+    run dolt push origin main
+    [ "$status" -ne 0 ]
+
+    run dolt fetch origin
+    [ "$status" -eq 0 ]
+}
+
+@test "remotes-ssh: reports ssh failures" {
+    export DOLT_SSH=false
+    dolt remote add origin "ssh://example.com/remote"
+    run dolt fetch origin
+    [ "$status" -ne 0 ]
+}
@@ -0,0 +1,121 @@
+#!/usr/bin/env bats
+#
+# Tests for sparse clones, which leave out the data of some tables
+# and fetch it from the remote when it's first read.
+
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    mkdir repo
+    cd repo
+    dolt init
+    dolt sql <<SQL
+CREATE TABLE items (id int primary key, name varchar(64));
+INSERT INTO items VALUES (1, 'one'), (2, 'two'), (3, 'three');
+CREATE TABLE audit (id int primary key, entry varchar(512), KEY (entry));
+INSERT INTO audit
+  WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < 1000)
+  SELECT n, repeat(md5(n), 16) FROM seq;
+SQL
+    dolt commit -Am "create tables"
+    dolt remote add origin file://../remote
+    dolt push origin main
+    cd ..
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "sparse-clone: --exclude-tables fetches excluded data on first read" {
+    dolt clone --exclude-tables audit file://./remote sparse
+    cd sparse
+
+    [ -f .dolt/noms/sparseObjects.txt ]
+
+    run dolt sql -q "select count(*) from items" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    # the schema of the excluded table is cloned
+    run dolt schema show audit
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "entry" ]] || false
+
+    run dolt sql -q "select count(*), sum(id) from audit" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000,500500" ]] || false
+    [ ! -f .dolt/noms/sparseObjects.txt ]
+
+    run dolt sql -q "select id from audit where entry = repeat(md5(7), 16)" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "7" ]] || false
+}
+
+@test "sparse-clone: --tables clones only the given tables" {
+    dolt clone --tables items file://./remote sparse
+    cd sparse
+
+    [ -f .dolt/noms/sparseObjects.txt ]
+    run dolt sql -q "select name from items where id = 2" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "two" ]] || false
+
+    run dolt sql -q "select count(*) from audit" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000" ]] || false
+    [ ! -f .dolt/noms/sparseObjects.txt ]
+}
+
+@test "sparse-clone: fetch leaves out the excluded tables" {
+    dolt clone --exclude-tables audit file://./remote sparse
+    cd sparse
+    dolt sql -q "select count(*) from audit"
+    [ ! -f .dolt/noms/sparseObjects.txt ]
+
+    cd ../repo
+    dolt sql -q "update audit set entry = 'changed' where id <= 500"
+    dolt sql -q "insert into items values (4, 'four')"
+    dolt commit -am "change tables"
+    dolt push origin main
+
+    cd ../sparse
+    dolt fetch
+    [ -f .dolt/noms/sparseObjects.txt ]
+    dolt merge origin/main
+
+    run dolt sql -q "select count(*) from items" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt sql -q "select count(*) from audit where entry = 'changed'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "500" ]] || false
+    [ ! -f .dolt/noms/sparseObjects.txt ]
+}
+
+@test "sparse-clone: reading excluded data fails without the remote" {
+    dolt clone --exclude-tables audit file://./remote sparse
+    rm -rf remote
+
+    cd sparse
+    run dolt sql -q "select count(*) from items" -r csv
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "select count(*) from audit"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "left out of a sparse clone" ]] || false
+    [ -f .dolt/noms/sparseObjects.txt ]
+}
+
+@test "sparse-clone: dolt_clone procedure supports --exclude-tables" {
+    dolt sql -q "call dolt_clone('--exclude-tables', 'audit', 'file://./remote', 'sparse')"
+    [ -f sparse/.dolt/noms/sparseObjects.txt ]
+
+    run dolt --use-db sparse sql -q "select count(*) from audit" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000" ]] || false
+    [ ! -f sparse/.dolt/noms/sparseObjects.txt ]
+}
@@ -226,6 +226,83 @@ EOF
     (cd the_restore && dolt status)
 }
 
+@test "sql-backup: dolt_backup restore --as-of an incremental sync" {
+    backupFileUrl="file://$BATS_TEST_TMPDIR/the_backup"
+    dolt sql -q "create table t (pk int primary key); insert into t values (1); call dolt_commit('-Am', 'one');"
+    dolt sql -q "insert into dolt_branch_control values ('%', 'first', 'first', '%', 'admin')"
+    dolt sql -q "call dolt_backup('sync-url', '--incremental', '$backupFileUrl')"
+    sleep 1
+    first=$(date -u +"%Y-%m-%d %H:%M:%S")
+    sleep 1
+
+    dolt sql -q "insert into t values (2); call dolt_commit('-am', 'two'); call dolt_branch('feature');"
+    dolt sql -q "insert into t values (3);"
+    dolt sql -q "insert into dolt_branch_control values ('%', 'second', 'second', '%', 'admin')"
+    dolt sql -q "call dolt_backup('sync-url', '--incremental', '$backupFileUrl')"
+
+    run dolt sql -q "call dolt_backup('restore', '--as-of', '2000-01-01', '$backupFileUrl', 'the_restore')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "has no point in time at or before 2000-01-01" ]] || false
+
+    dolt sql -q "call dolt_backup('restore', '--as-of', '$first', '$backupFileUrl', 'the_restore')"
+    run dolt sql -q "use the_restore; select pk from t order by pk;" -r csv
+    [ "$status" -eq 0 ]
+    [ "$output" = "pk
+1" ]
+    run dolt sql -q "use the_restore; select name from dolt_branches;" -r csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "feature" ]] || false
+    # the branch control data of the server is only replaced when asked for
+    run dolt sql -q "select branch from dolt_branch_control order by branch;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "second" ]] || false
+
+    dolt sql -q "call dolt_backup('restore', '--force', '--as-of', '$first', '--restore-branch-control', '$backupFileUrl', 'the_restore')"
+    run dolt sql -q "select branch from dolt_branch_control order by branch;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "first" ]] || false
+    [[ ! "$output" =~ "second" ]] || false
+
+    # the latest point includes the working set
+    sleep 1
+    dolt sql -q "call dolt_backup('restore', '--force', '--as-of', '$(date -u +"%Y-%m-%d %H:%M:%S")', '--restore-branch-control', '$backupFileUrl', 'the_restore')"
+    run dolt sql -q "use the_restore; select pk from t order by pk;" -r csv
+    [ "$status" -eq 0 ]
+    [ "$output" = "pk
+1
+2
+3" ]
+    run dolt sql -q "use the_restore; select name from dolt_branches;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature" ]] || false
+    run dolt sql -q "select branch from dolt_branch_control order by branch;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "second" ]] || false
+}
+
+@test "sql-backup: dolt_backup --incremental and --as-of arguments" {
+    run dolt sql -q "call dolt_backup('sync-url', '--incremental', 'http://localhost:50051/test-org/test-repo')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--incremental is not supported for the backup at 'http://localhost:50051/test-org/test-repo'" ]] || false
+
+    run dolt sql -q "call dolt_backup('add', '--incremental', 'backups', 'file://$BATS_TEST_TMPDIR/backups')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--incremental is only supported by 'sync' and 'sync-url'" ]] || false
+
+    run dolt sql -q "call dolt_backup('sync-url', '--as-of', '2024-01-01', 'file://$BATS_TEST_TMPDIR/backups')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--as-of is only supported by 'restore'" ]] || false
+
+    run dolt sql -q "call dolt_backup('restore', '--restore-branch-control', 'file://$BATS_TEST_TMPDIR/backups', 'the_restore')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--restore-branch-control requires --as-of" ]] || false
+
+    dolt sql -q "call dolt_backup('sync-url', 'file://$BATS_TEST_TMPDIR/backups')"
+    run dolt sql -q "call dolt_backup('restore', '--as-of', 'yesterday', 'file://$BATS_TEST_TMPDIR/backups', 'the_restore')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid time 'yesterday'" ]] || false
+}
+
 @test "sql-backup: dolt_backup sync-url fails on non-grpc http request" {
     run dolt sql -q "call dolt_backup('sync-url', 'http://dolthub.com/dolthub/backup')"
     [ "$status" -ne 0 ]
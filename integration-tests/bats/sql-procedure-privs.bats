@@ -73,8 +73,14 @@ mike_blocked_check() {
     mike_blocked_check "dolt_gc()"
     mike_blocked_check "dolt_pull('origin')"
     mike_blocked_check "dolt_purge_dropped_databases()"
+    mike_blocked_check "dolt_squash_history('--keep-all', '90d')"
     mike_blocked_check "dolt_remote('add','origin1','Dolthub/museum-collections')"
     mike_blocked_check "dolt_undrop('foo')"
+    mike_blocked_check "dolt_purge_rows('--table', 'test', '--where', 'pk = 1')"
+    mike_blocked_check "dolt_create_materialized_view('test_pks', 'SELECT pk FROM test')"
+    mike_blocked_check "dolt_drop_materialized_view('test_pks')"
+    mike_blocked_check "dolt_refresh_materialized_view('test_pks')"
+    mike_blocked_check "dolt_ci_record_result('tests', 'passed')"
 
     # Verify non-admin procedures are executable, not an exhaustive list tho.
     dolt -u mike -p pwd sql -q "call dolt_branch('br1')"
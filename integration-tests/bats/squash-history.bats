@@ -0,0 +1,139 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY, c varchar(20));"
+    dolt add t
+    dolt sql -q "INSERT INTO t VALUES (1, 'one');"
+    dolt commit -am "c1" --date "2020-01-01T10:00:00Z"
+    dolt sql -q "INSERT INTO t VALUES (2, 'two');"
+    dolt commit -am "c2" --date "2020-01-01T12:00:00Z"
+    dolt sql -q "INSERT INTO t VALUES (3, 'three');"
+    dolt commit -am "c3" --date "2020-01-02T10:00:00Z"
+    dolt sql -q "INSERT INTO t VALUES (4, 'four');"
+    dolt commit -am "c4" --date "2020-01-09T10:00:00Z"
+    dolt sql -q "INSERT INTO t VALUES (5, 'five');"
+    dolt commit -am "c5"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "squash-history: squashes commits older than the keep-all tier" {
+    old_head=$(get_head_commit)
+
+    run dolt squash-history --keep-all 30d
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    [[ "$output" =~ "$old_head" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_log;" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "2" ] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c5" ]] || false
+    [[ ! "$output" =~ "c4" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM t;" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "5" ] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "squash-history: keeps the last commit of each day and week" {
+    run dolt squash-history --keep-all 30d --keep-daily forever --skip-gc
+    [ "$status" -eq 0 ]
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c2" ]] || false
+    [[ "$output" =~ "c3" ]] || false
+    [[ "$output" =~ "c4" ]] || false
+    [[ ! "$output" =~ "c1" ]] || false
+
+    run dolt squash-history --keep-all 30d --keep-weekly forever --skip-gc
+    [ "$status" -eq 0 ]
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c3" ]] || false
+    [[ "$output" =~ "c4" ]] || false
+    [[ ! "$output" =~ "c2" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM t AS OF 'HEAD~1';" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "4" ] || false
+}
+
+@test "squash-history: dry run changes nothing" {
+    old_head=$(get_head_commit)
+
+    run dolt squash-history --keep-all 30d --dry-run
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$old_head" ]] || false
+
+    [ "$(get_head_commit)" = "$old_head" ] || false
+    run dolt sql -q "SELECT count(*) FROM dolt_log;" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "6" ] || false
+}
+
+@test "squash-history: keeps tagged and preserved commits" {
+    dolt tag v1 HEAD~4
+    c3=$(dolt sql -q "SELECT commit_hash FROM dolt_log WHERE message = 'c3';" -r csv | tail -n 1)
+
+    run dolt squash-history --keep-all 30d --preserve "$c3" --skip-gc
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/tags/v1" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c3" ]] || false
+    [[ ! "$output" =~ "c4" ]] || false
+
+    run dolt log v1 --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c1" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM t AS OF 'v1';" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ] || false
+}
+
+@test "squash-history: requires a retention tier" {
+    run dolt squash-history --skip-gc
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "at least one of --keep-all, --keep-daily or --keep-weekly is required" ]] || false
+
+    run dolt sql -q "CALL dolt_squash_history('--dry-run');"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "at least one of --keep-all, --keep-daily or --keep-weekly is required" ]] || false
+
+    run dolt squash-history --keep-all 1.5d
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid retention age" ]] || false
+}
+
+@test "squash-history: refuses to run during a merge" {
+    dolt checkout -b other
+    dolt sql -q "UPDATE t SET c = 'other' WHERE pk = 1;"
+    dolt commit -am "other"
+    dolt checkout main
+    dolt sql -q "UPDATE t SET c = 'main' WHERE pk = 1;"
+    dolt commit -am "main"
+    run dolt merge other
+    [ "$status" -ne 0 ]
+
+    run dolt squash-history --keep-all 30d
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot squash history while a merge or rebase is in progress" ]] || false
+}
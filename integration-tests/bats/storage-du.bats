@@ -0,0 +1,64 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "create table t (pk int primary key, c varchar(100));"
+    dolt sql -q "insert into t values (1, 'one'), (2, 'two');"
+    dolt commit -Am "add t"
+    dolt checkout -b other
+    dolt sql -q "create table u (pk int primary key);"
+    dolt commit -Am "add u"
+    dolt checkout main
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "storage-du: shows every ref" {
+    run dolt storage du
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "exclusive" ]] || false
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    [[ "$output" =~ "refs/heads/other" ]] || false
+    ! [[ "$output" =~ "table" ]] || false
+}
+
+@test "storage-du: filters refs" {
+    run dolt storage du other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/other" ]] || false
+    ! [[ "$output" =~ "refs/heads/main" ]] || false
+
+    run dolt storage du refs/heads/main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    ! [[ "$output" =~ "refs/heads/other" ]] || false
+
+    run dolt storage du nosuchref
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown ref 'nosuchref'" ]] || false
+}
+
+@test "storage-du: --tables and --bytes" {
+    run dolt storage du --tables other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "table" ]] || false
+    [[ "$output" =~ " t " ]] || false
+    [[ "$output" =~ " u " ]] || false
+
+    run dolt storage du --bytes main
+    [ "$status" -eq 0 ]
+    ! [[ "$output" =~ "KiB" ]] || false
+}
+
+@test "storage-du: system table" {
+    run dolt sql -q "select ref, table_name from dolt_storage_usage where table_name in ('t', 'u') order by ref, table_name" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main,t" ]] || false
+    [[ "$output" =~ "refs/heads/other,t" ]] || false
+    [[ "$output" =~ "refs/heads/other,u" ]] || false
+    ! [[ "$output" =~ "refs/heads/main,u" ]] || false
+}
@@ -0,0 +1,110 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t (i INT PRIMARY KEY, s VARCHAR(10));"
+    dolt sql -q "INSERT INTO t VALUES (1, 'a'), (2, 'b');"
+    dolt sql -q "INSERT INTO dolt_tests VALUES ('two rows', 'rows', 'SELECT * FROM t', 'expected_rows', '==', '2');"
+    dolt commit -Am "add t and tests"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "test: runs tests and fails when a test fails" {
+    run dolt test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "PASS two rows (rows)" ]] || false
+    [[ "$output" =~ "1 passed, 0 failed" ]] || false
+
+    dolt sql -q "INSERT INTO dolt_tests VALUES ('no b', 'checks', 'SELECT * FROM t WHERE s = ''b''', 'expected_no_rows', '==', NULL);"
+    run dolt test run
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "FAIL no b (checks)" ]] || false
+    [[ "$output" =~ "Assertion failed: expected_no_rows, got 1 rows: (2, b)" ]] || false
+    [[ "$output" =~ "1 passed, 1 failed" ]] || false
+
+    run dolt test rows
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1 passed, 0 failed" ]] || false
+}
+
+@test "test: --update-snapshots records snapshots in dolt_tests" {
+    dolt sql -q "INSERT INTO dolt_tests VALUES ('t snapshot', 'snapshots', 'SELECT * FROM t', 'expected_snapshot', '==', NULL);"
+    run dolt test snapshots
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no snapshot has been recorded" ]] || false
+
+    run dolt test --update-snapshots snapshots
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Updated snapshot for test t snapshot" ]] || false
+    [[ "$output" =~ "PASS t snapshot (snapshots)" ]] || false
+    dolt commit -Am "record snapshot"
+
+    dolt sql -q "UPDATE t SET s = 'c' WHERE i = 2;"
+    run dolt test snapshots
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "missing: (2, b); unexpected: (2, c)" ]] || false
+
+    run dolt test --update-snapshots snapshots
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Updated snapshot for test t snapshot" ]] || false
+
+    run dolt diff dolt_tests
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t snapshot" ]] || false
+
+    run dolt test --update-snapshots snapshots
+    [ "$status" -eq 0 ]
+    ! [[ "$output" =~ "Updated snapshot" ]] || false
+}
+
+@test "test: --format writes test reports" {
+    dolt sql -q "INSERT INTO dolt_tests VALUES ('no b', 'checks', 'SELECT * FROM t WHERE s = ''b''', 'expected_no_rows', '==', NULL);"
+
+    run dolt test run --format junit
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ '<testsuites name="dolt test" tests="2" failures="1"' ]] || false
+    [[ "$output" =~ '<testsuite name="rows" tests="1" failures="0"' ]] || false
+    [[ "$output" =~ '<testcase name="no b" classname="checks"' ]] || false
+    [[ "$output" =~ "Assertion failed: expected_no_rows, got 1 rows: (2, b)" ]] || false
+    ! [[ "$output" =~ "passed" ]] || false
+
+    run dolt test run --format tap
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "TAP version 13" ]] || false
+    [[ "$output" =~ "not ok 1 - checks / no b" ]] || false
+    [[ "$output" =~ "ok 2 - rows / two rows" ]] || false
+    [[ "$output" =~ "duration_ms:" ]] || false
+
+    run dolt test run --format json rows
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"tests": 1' ]] || false
+    [[ "$output" =~ '"status": "pass"' ]] || false
+
+    run dolt test run --format xml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid format 'xml'" ]] || false
+}
+
+@test "test: --update-snapshots records snapshots larger than 64KB" {
+    dolt sql -q "CREATE TABLE big (i INT PRIMARY KEY, s VARCHAR(40));"
+    dolt sql -q "INSERT INTO big WITH RECURSIVE n (i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 5000) SELECT i, REPEAT('x', 30) FROM n;"
+    dolt sql -q "INSERT INTO dolt_tests VALUES ('big snapshot', 'big', 'SELECT * FROM big', 'expected_snapshot', '==', NULL);"
+
+    run dolt test --update-snapshots big
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "PASS big snapshot (big)" ]] || false
+
+    run dolt sql -r csv -q "SELECT LENGTH(assertion_value) > 65535 FROM dolt_tests WHERE test_name = 'big snapshot';"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ] || false
+
+    dolt sql -q "UPDATE big SET s = 'y' WHERE i = 4000;"
+    run dolt test big
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unexpected: (4000, y)" ]] || false
+}